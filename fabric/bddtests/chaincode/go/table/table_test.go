@@ -0,0 +1,124 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+func checkInvoke(t *testing.T, stub *shim.MockStub, function string, args []string) {
+	_, err := stub.MockInvoke("1", function, args)
+	if err != nil {
+		fmt.Println("Invoke", function, args, "failed", err)
+		t.FailNow()
+	}
+}
+
+func checkInvokeFails(t *testing.T, stub *shim.MockStub, function string, args []string) {
+	_, err := stub.MockInvoke("1", function, args)
+	if err == nil {
+		fmt.Println("Invoke", function, args, "was expected to fail")
+		t.FailNow()
+	}
+}
+
+func checkQuery(t *testing.T, stub *shim.MockStub, function string, args []string, value string) {
+	bytes, err := stub.MockQuery(function, args)
+	if err != nil {
+		fmt.Println("Query", function, args, "failed", err)
+		t.FailNow()
+	}
+	if string(bytes) != value {
+		fmt.Println("Query value", function, args, "was", string(bytes), "not", value, "as expected")
+		t.FailNow()
+	}
+}
+
+func tableOneRow(col1 string, col2 int32, col3 int32) string {
+	row := shim.Row{Columns: []*shim.Column{
+		{Value: &shim.Column_String_{String_: col1}},
+		{Value: &shim.Column_Int32{Int32: col2}},
+		{Value: &shim.Column_Int32{Int32: col3}},
+	}}
+	return fmt.Sprintf("%s", row)
+}
+
+func TestTable_TableOne(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("table", scc)
+
+	if _, err := stub.MockInit("1", "init", []string{}); err != nil {
+		fmt.Println("Init failed", err)
+		t.FailNow()
+	}
+
+	checkInvoke(t, stub, "insertRowTableOne", []string{"test1", "10", "20"})
+	checkInvokeFails(t, stub, "insertRowTableOne", []string{"test1", "30", "40"})
+	checkQuery(t, stub, "getRowTableOne", []string{"test1"}, tableOneRow("test1", 10, 20))
+
+	checkInvoke(t, stub, "replaceRowTableOne", []string{"test1", "30", "40"})
+	checkInvokeFails(t, stub, "replaceRowTableOne", []string{"test2", "30", "40"})
+	checkQuery(t, stub, "getRowTableOne", []string{"test1"}, tableOneRow("test1", 30, 40))
+
+	checkInvoke(t, stub, "deleteRowTableOne", []string{"test1"})
+	checkQuery(t, stub, "getRowTableOne", []string{"test1"}, fmt.Sprintf("%s", shim.Row{}))
+
+	checkInvoke(t, stub, "insertRowTableOne", []string{"test2", "10", "20"})
+	checkInvoke(t, stub, "deleteAndRecreateTableOne", []string{})
+	checkQuery(t, stub, "getRowTableOne", []string{"test2"}, fmt.Sprintf("%s", shim.Row{}))
+}
+
+func TestTable_GetRowsTableTwo(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("table", scc)
+
+	if _, err := stub.MockInit("1", "init", []string{}); err != nil {
+		fmt.Println("Init failed", err)
+		t.FailNow()
+	}
+
+	checkInvoke(t, stub, "insertRowTableTwo", []string{"foo", "1", "1", "a"})
+	checkInvoke(t, stub, "insertRowTableTwo", []string{"foo", "1", "2", "b"})
+	checkInvoke(t, stub, "insertRowTableTwo", []string{"foo", "2", "1", "c"})
+	checkInvoke(t, stub, "insertRowTableTwo", []string{"foobar", "1", "1", "d"})
+
+	for _, query := range []struct {
+		args []string
+		rows int
+	}{
+		{[]string{"foo"}, 3},
+		{[]string{"foo", "1"}, 2},
+		{[]string{"foobar"}, 1},
+		{[]string{"bar"}, 0},
+	} {
+		bytes, err := stub.MockQuery("getRowsTableTwo", query.args)
+		if err != nil {
+			fmt.Println("Query getRowsTableTwo", query.args, "failed", err)
+			t.FailNow()
+		}
+		// the oneof column values cannot be unmarshalled back, so count the rows
+		var rows []json.RawMessage
+		if err = json.Unmarshal(bytes, &rows); err != nil || len(rows) != query.rows {
+			fmt.Println("Query getRowsTableTwo", query.args, "returned", string(bytes), "but expected", query.rows, "rows")
+			t.FailNow()
+		}
+	}
+}
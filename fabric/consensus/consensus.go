@@ -17,6 +17,7 @@ limitations under the License.
 package consensus
 
 import (
+	"github.com/hyperledger/fabric/consensus/util/metrics"
 	pb "github.com/hyperledger/fabric/protos"
 )
 
@@ -35,6 +36,18 @@ type Consenter interface {
 	ExecutionConsumer
 }
 
+// MetricsReporter is implemented by consensus plugins which publish metrics about their progress
+type MetricsReporter interface {
+	Metrics() *metrics.Registry
+}
+
+// Reconfigurer is implemented by consensus plugins whose validator set can be changed while
+// the network is running. Reconfigure submits this validator's vote to replace the validator set
+// with the given replica IDs (vpN has replica ID N)
+type Reconfigurer interface {
+	Reconfigure(replicas []uint64) error
+}
+
 // Inquirer is used to retrieve info about the validating network
 type Inquirer interface {
 	GetNetworkInfo() (self *pb.PeerEndpoint, network []*pb.PeerEndpoint, err error)
@@ -25,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/consensus"
 	"github.com/hyperledger/fabric/consensus/noops"
 	"github.com/hyperledger/fabric/consensus/pbft"
+	"github.com/hyperledger/fabric/consensus/raft"
 )
 
 var logger *logging.Logger // package-level logger
@@ -42,6 +43,10 @@ func NewConsenter(stack consensus.Stack) consensus.Consenter {
 		logger.Infof("Creating consensus plugin %s", plugin)
 		return pbft.GetPlugin(stack)
 	}
+	if plugin == "raft" {
+		logger.Infof("Creating consensus plugin %s", plugin)
+		return raft.GetPlugin(stack)
+	}
 	logger.Info("Creating default consensus plugin (noops)")
 	return noops.GetNoops(stack)
 
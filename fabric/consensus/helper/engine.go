@@ -25,6 +25,7 @@ import (
 
 	"github.com/hyperledger/fabric/consensus/controller"
 	"github.com/hyperledger/fabric/consensus/util"
+	"github.com/hyperledger/fabric/consensus/util/metrics"
 	"github.com/hyperledger/fabric/core/chaincode"
 	pb "github.com/hyperledger/fabric/protos"
 	"golang.org/x/net/context"
@@ -57,13 +58,14 @@ func (eng *EngineImpl) ProcessTransactionMsg(msg *pb.Message, tx *pb.Transaction
 		// cxt := context.WithValue(context.Background(), "security", secHelper)
 		cxt := context.Background()
 		//query will ignore events as these are not stored on ledger (and query can report
-		//"event" data synchronously anyway)
-		result, _, err := chaincode.Execute(cxt, chaincode.GetChain(chaincode.DefaultChain), tx)
+		//"event" data synchronously anyway). It is executed against a snapshot of the
+		//committed state and does not go through the consenter
+		result, blockNumber, err := chaincode.ExecuteQuery(cxt, chaincode.GetChain(chaincode.DefaultChain), tx)
 		if err != nil {
 			response = &pb.Response{Status: pb.Response_FAILURE,
 				Msg: []byte(fmt.Sprintf("Error:%s", err))}
 		} else {
-			response = &pb.Response{Status: pb.Response_SUCCESS, Msg: result}
+			response = &pb.Response{Status: pb.Response_SUCCESS, Msg: result, BlockNumber: blockNumber}
 		}
 	} else {
 		// Chaincode Transaction
@@ -90,6 +92,22 @@ func (eng *EngineImpl) ProcessTransactionMsg(msg *pb.Message, tx *pb.Transaction
 	return response
 }
 
+// Metrics returns the metrics published by the consensus plugin, nil if it publishes none
+func (eng *EngineImpl) Metrics() *metrics.Registry {
+	if reporter, ok := eng.consenter.(consensus.MetricsReporter); ok {
+		return reporter.Metrics()
+	}
+	return nil
+}
+
+// Reconfigure submits this validator's vote to replace the validator set of the consensus plugin
+func (eng *EngineImpl) Reconfigure(replicas []uint64) error {
+	if reconfigurer, ok := eng.consenter.(consensus.Reconfigurer); ok {
+		return reconfigurer.Reconfigure(replicas)
+	}
+	return fmt.Errorf("The consensus plugin does not support changing the validator set")
+}
+
 func (eng *EngineImpl) setConsenter(consenter consensus.Consenter) *EngineImpl {
 	eng.consenter = consenter
 	return eng
@@ -108,6 +126,24 @@ func getEngineImpl() *EngineImpl {
 	return engine
 }
 
+// GetMetrics returns the metrics published by the consensus plugin of this
+// peer, nil if it is not a validating peer or the plugin publishes none
+func GetMetrics() *metrics.Registry {
+	if engine == nil {
+		return nil
+	}
+	return engine.Metrics()
+}
+
+// Reconfigure submits the vote of this peer to replace the validator set of
+// the consensus plugin, it fails if this is not a validating peer
+func Reconfigure(replicas []uint64) error {
+	if engine == nil {
+		return fmt.Errorf("Only validating peers take part in consensus")
+	}
+	return engine.Reconfigure(replicas)
+}
+
 // GetEngine returns initialized peer.Engine
 func GetEngine(coord peer.MessageHandlerCoordinator) (peer.Engine, error) {
 	var err error
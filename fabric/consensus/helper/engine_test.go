@@ -16,8 +16,43 @@ limitations under the License.
 
 package helper
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric/consensus"
+)
 
 func TestEngine(t *testing.T) {
 	t.Skip("Engine functions already tested in other consensus components")
 }
+
+type reconfigurableConsenter struct {
+	consensus.Consenter
+	replicas []uint64
+}
+
+func (rc *reconfigurableConsenter) Reconfigure(replicas []uint64) error {
+	rc.replicas = replicas
+	return nil
+}
+
+type staticConsenter struct {
+	consensus.Consenter
+}
+
+func TestEngineReconfigure(t *testing.T) {
+	consenter := &reconfigurableConsenter{}
+	eng := new(EngineImpl).setConsenter(consenter)
+	if err := eng.Reconfigure([]uint64{0, 1, 2, 3, 4}); err != nil {
+		t.Fatalf("Failed to vote for a new validator set: %s", err)
+	}
+	if !reflect.DeepEqual(consenter.replicas, []uint64{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected the consenter to be asked to change the validator set to [0 1 2 3 4], got %v", consenter.replicas)
+	}
+
+	eng = new(EngineImpl).setConsenter(&staticConsenter{})
+	if err := eng.Reconfigure([]uint64{0, 1, 2, 3, 4}); err == nil {
+		t.Errorf("Expected consensus plugins which cannot change their validator set to refuse")
+	}
+}
@@ -0,0 +1,62 @@
+// Code generated by protoc-gen-go.
+// source: messages.proto
+// DO NOT EDIT!
+
+/*
+Package noops is a generated protocol buffer package.
+
+It is generated from these files:
+
+	messages.proto
+
+It has these top-level messages:
+
+	Block
+*/
+package noops
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+// block is cut by the orderer, the other validating peers execute the blocks
+// in the same order
+type Block struct {
+	Number       uint64   `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	Transactions [][]byte `protobuf:"bytes,2,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Id           []byte   `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *Block) Reset()                    { *m = Block{} }
+func (m *Block) String() string            { return proto.CompactTextString(m) }
+func (*Block) ProtoMessage()               {}
+func (*Block) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func init() {
+	proto.RegisterType((*Block)(nil), "noops.block")
+}
+
+func init() { proto.RegisterFile("messages.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 118 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcb, 0x4d, 0x2d, 0x2e,
+	0x4e, 0x4c, 0x4f, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0xcd, 0xcb, 0xcf, 0x2f,
+	0x28, 0x56, 0x0a, 0xe6, 0x62, 0x4d, 0xca, 0xc9, 0x4f, 0xce, 0x16, 0x12, 0xe3, 0x62, 0xcb, 0x2b,
+	0xcd, 0x4d, 0x4a, 0x2d, 0x92, 0x60, 0x54, 0x60, 0xd4, 0x60, 0x09, 0x82, 0xf2, 0x84, 0x94, 0xb8,
+	0x78, 0x4a, 0x8a, 0x12, 0xf3, 0x8a, 0x13, 0x93, 0x4b, 0x32, 0xf3, 0xf3, 0x8a, 0x25, 0x98, 0x14,
+	0x98, 0x35, 0x78, 0x82, 0x50, 0xc4, 0x84, 0xf8, 0xb8, 0x98, 0x32, 0x53, 0x24, 0x98, 0x15, 0x18,
+	0x35, 0x78, 0x82, 0x98, 0x32, 0x53, 0x92, 0xd8, 0xc0, 0x56, 0x18, 0x03, 0x06, 0x00, 0xec, 0x06,
+	0x72, 0x4e, 0x74, 0x00, 0x00, 0x00,
+}
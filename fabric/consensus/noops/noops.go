@@ -25,6 +25,7 @@ import (
 	"github.com/op/go-logging"
 
 	"github.com/hyperledger/fabric/consensus"
+	"github.com/hyperledger/fabric/consensus/util/metrics"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/statemgmt"
 	"github.com/hyperledger/fabric/core/util"
@@ -39,11 +40,39 @@ func init() {
 
 // Noops is a plugin object implementing the consensus.Consenter interface.
 type Noops struct {
-	stack    consensus.Stack
-	txQ      *txq
-	timer    *time.Timer
-	duration time.Duration
-	channel  chan *pb.Transaction
+	stack     consensus.Stack
+	getLedger func() (blockLedger, error)
+	txQ       *txq
+	timer     *time.Timer
+	duration  time.Duration
+	channel   chan *pb.Transaction
+	blocks    chan *Block             // blocks cut by the orderer, followers execute them in order
+	updates   chan *pb.BlockchainInfo // state transfers completed, nil if one failed
+	seqNo     uint64                  // number of batches executed
+	metrics   *noopsMetrics
+
+	self         *pb.PeerID
+	orderer      *pb.PeerID // peer sequencing the transactions, nil if every peer orders them as they arrive
+	transferring bool       // the follower missed blocks of the orderer and catches up through state transfer
+}
+
+// blockLedger is the part of the ledger noops reads the blocks it added from
+type blockLedger interface {
+	GetBlockchainSize() uint64
+	GetBlockByNumber(blockNumber uint64) (*pb.Block, error)
+	GetStateDelta(blockNumber uint64) (*statemgmt.StateDelta, error)
+}
+
+func getLedger() (blockLedger, error) {
+	return ledger.GetLedger()
+}
+
+// noopsMetrics are the subset of the PBFT consensus metrics noops can report
+type noopsMetrics struct {
+	registry            *metrics.Registry
+	seqNo               *metrics.Gauge
+	outstandingRequests *metrics.Gauge
+	batchSize           *metrics.Histogram
 }
 
 // Setting up a singleton NOOPS consenter
@@ -65,8 +94,12 @@ func newNoops(c consensus.Stack) consensus.Consenter {
 	}
 	i := &Noops{}
 	i.stack = c
+	i.getLedger = getLedger
 	config := loadConfig()
 	blockSize := config.GetInt("block.size")
+	if blockSize < 1 {
+		panic(fmt.Errorf("Block size must be greater than 0, got %d", blockSize))
+	}
 	blockWait := config.GetString("block.wait")
 	if _, err = strconv.Atoi(blockWait); err == nil {
 		blockWait = blockWait + "s" //if string does not have unit of measure, default to seconds
@@ -80,24 +113,71 @@ func newNoops(c consensus.Stack) consensus.Consenter {
 	logger.Infof("NOOPS block size = %v", blockSize)
 	logger.Infof("NOOPS block wait = %v", i.duration)
 
+	if name := config.GetString("orderer"); name != "" {
+		i.orderer = &pb.PeerID{Name: name}
+		if i.self, _, err = c.GetNetworkHandles(); err != nil {
+			panic(fmt.Errorf("Cannot determine own peer ID: %s", err))
+		}
+		logger.Infof("NOOPS orderer = %v", name)
+	}
+
 	i.txQ = newTXQ(blockSize)
 
+	i.metrics = newNoopsMetrics()
+
 	i.channel = make(chan *pb.Transaction, 100)
+	i.blocks = make(chan *Block, 100)
+	i.updates = make(chan *pb.BlockchainInfo, 1)
 	i.timer = time.NewTimer(i.duration) // start timer now so we can just reset it
 	i.timer.Stop()
 	go i.handleChannels()
 	return i
 }
 
+func newNoopsMetrics() *noopsMetrics {
+	r := metrics.NewRegistry()
+	return &noopsMetrics{
+		registry:            r,
+		seqNo:               r.NewGauge("consensus_sequence_number", "Sequence number of the last executed request batch."),
+		outstandingRequests: r.NewGauge("consensus_outstanding_requests", "Requests received but not yet executed."),
+		batchSize:           r.NewHistogram("consensus_batch_size", "Number of requests in the executed batches.", metrics.BatchSizeBuckets),
+	}
+}
+
+// Metrics returns the consensus metrics reported by noops
+func (i *Noops) Metrics() *metrics.Registry {
+	return i.metrics.registry
+}
+
+// isOrderer reports whether this peer sequences the transactions of the
+// other validating peers
+func (i *Noops) isOrderer() bool {
+	return i.orderer != nil && i.self.Name == i.orderer.Name
+}
+
+// isFollower reports whether this peer executes the blocks cut by another peer
+func (i *Noops) isFollower() bool {
+	return i.orderer != nil && i.self.Name != i.orderer.Name
+}
+
 // RecvMsg is called for Message_CHAIN_TRANSACTION and Message_CONSENSUS messages.
 func (i *Noops) RecvMsg(msg *pb.Message, senderHandle *pb.PeerID) error {
 	if logger.IsEnabledFor(logging.DEBUG) {
 		logger.Debugf("Handling Message of type: %s ", msg.Type)
 	}
+	if i.isFollower() {
+		return i.recvAsFollower(msg, senderHandle)
+	}
 	if msg.Type == pb.Message_CHAIN_TRANSACTION {
-		if err := i.broadcastConsensusMsg(msg); nil != err {
+		if err := i.toConsensusMsg(msg); nil != err {
 			return err
 		}
+		// The orderer broadcasts the transactions once it cut a block
+		if !i.isOrderer() {
+			if err := i.broadcastConsensusMsg(msg); nil != err {
+				return err
+			}
+		}
 	}
 	if msg.Type == pb.Message_CONSENSUS {
 		tx, err := i.getTxFromMsg(msg)
@@ -112,24 +192,114 @@ func (i *Noops) RecvMsg(msg *pb.Message, senderHandle *pb.PeerID) error {
 	return nil
 }
 
-func (i *Noops) broadcastConsensusMsg(msg *pb.Message) error {
+// recvAsFollower forwards the transactions submitted to this peer to the
+// orderer, and queues the blocks received from the orderer for execution
+func (i *Noops) recvAsFollower(msg *pb.Message, senderHandle *pb.PeerID) error {
+	if msg.Type == pb.Message_CHAIN_TRANSACTION {
+		if err := i.toConsensusMsg(msg); nil != err {
+			return err
+		}
+		if logger.IsEnabledFor(logging.DEBUG) {
+			logger.Debugf("Forwarding %s to orderer %s", msg.Type, i.orderer.Name)
+		}
+		if err := i.stack.Unicast(msg, i.orderer); nil != err {
+			return fmt.Errorf("Failed to forward transaction to orderer %s: %v", i.orderer.Name, err)
+		}
+		return nil
+	}
+	if msg.Type == pb.Message_CONSENSUS {
+		if senderHandle.Name != i.orderer.Name {
+			logger.Warningf("Ignoring block from %s, only the orderer %s cuts blocks", senderHandle.Name, i.orderer.Name)
+			return nil
+		}
+		block := &Block{}
+		if err := proto.Unmarshal(msg.Payload, block); err != nil {
+			return err
+		}
+		if logger.IsEnabledFor(logging.DEBUG) {
+			logger.Debugf("Sending to channel block %d of %d transactions from orderer", block.Number, len(block.Transactions))
+		}
+		i.blocks <- block
+	}
+	return nil
+}
+
+// recvBlock executes a block cut by the orderer if it is the next block of
+// the local blockchain. Blocks which were already executed are ignored, when
+// blocks were missed the follower catches up with the orderer through state
+// transfer, and ignores the blocks it receives until then.
+func (i *Noops) recvBlock(block *Block) error {
+	if i.transferring {
+		if logger.IsEnabledFor(logging.DEBUG) {
+			logger.Debugf("Catching up with orderer, ignoring block %d", block.Number)
+		}
+		return nil
+	}
+
+	height := i.stack.GetBlockchainSize()
+	if block.Number < height {
+		if logger.IsEnabledFor(logging.DEBUG) {
+			logger.Debugf("Ignoring block %d from orderer, blockchain height is already %d", block.Number, height)
+		}
+		return nil
+	}
+	if block.Number > height {
+		target := &pb.BlockchainInfo{}
+		if err := proto.Unmarshal(block.Id, target); err != nil {
+			return fmt.Errorf("Cannot catch up with block %d from orderer: %v", block.Number, err)
+		}
+		logger.Warningf("Missed blocks %d to %d cut by orderer %s, catching up through state transfer", height, block.Number-1, i.orderer.Name)
+		i.transferring = true
+		i.stack.InvalidateState()
+		i.stack.UpdateState(block.Number, target, []*pb.PeerID{i.orderer})
+		return nil
+	}
+
+	txs := make([]*pb.Transaction, len(block.Transactions))
+	for n, raw := range block.Transactions {
+		txs[n] = &pb.Transaction{}
+		if err := proto.Unmarshal(raw, txs[n]); err != nil {
+			return fmt.Errorf("Cannot unmarshal transaction of block %d from orderer: %v", block.Number, err)
+		}
+	}
+	return i.executeBlock(txs)
+}
+
+// stateUpdated resumes executing the blocks of the orderer once the follower
+// caught up with it, or waits for the next block to retry if it failed
+func (i *Noops) stateUpdated(target *pb.BlockchainInfo) {
+	i.transferring = false
+	if target == nil {
+		logger.Warningf("Failed to catch up with orderer %s, retrying with its next block", i.orderer.Name)
+		return
+	}
+	i.stack.ValidateState()
+	logger.Infof("Caught up with orderer %s, blockchain height is now %d", i.orderer.Name, target.Height)
+}
+
+// toConsensusMsg changes a Message_CHAIN_TRANSACTION to the
+// Message_CONSENSUS sent to the other validators
+func (i *Noops) toConsensusMsg(msg *pb.Message) error {
 	t := &pb.Transaction{}
 	if err := proto.Unmarshal(msg.Payload, t); err != nil {
 		return fmt.Errorf("Error unmarshalling payload of received Message:%s.", msg.Type)
 	}
-
-	// Change the msg type to consensus and broadcast to the network so that
-	// other validators may execute the transaction
 	msg.Type = pb.Message_CONSENSUS
-	if logger.IsEnabledFor(logging.DEBUG) {
-		logger.Debugf("Broadcasting %s", msg.Type)
-	}
 	txs := &pb.TransactionBlock{Transactions: []*pb.Transaction{t}}
 	payload, err := proto.Marshal(txs)
 	if err != nil {
 		return err
 	}
 	msg.Payload = payload
+	return nil
+}
+
+// broadcastConsensusMsg sends a Message_CONSENSUS to the network so that
+// other validators may execute its transactions
+func (i *Noops) broadcastConsensusMsg(msg *pb.Message) error {
+	if logger.IsEnabledFor(logging.DEBUG) {
+		logger.Debugf("Broadcasting %s", msg.Type)
+	}
 	if errs := i.stack.Broadcast(msg, pb.PeerEndpoint_VALIDATOR); nil != errs {
 		return fmt.Errorf("Failed to broadcast with errors: %v", errs)
 	}
@@ -144,6 +314,7 @@ func (i *Noops) canProcessBlock(tx *pb.Transaction) bool {
 	// TODO: Ask coordinator if we need to start sync
 
 	i.txQ.append(tx)
+	i.metrics.outstandingRequests.Set(float64(i.txQ.size()))
 
 	// start timer if we get a tx
 	if i.txQ.size() == 1 {
@@ -173,12 +344,28 @@ func (i *Noops) handleChannels() {
 			if err := i.processBlock(); nil != err {
 				logger.Error(err.Error())
 			}
+		case block := <-i.blocks:
+			if logger.IsEnabledFor(logging.DEBUG) {
+				logger.Debug("Process block cut by the orderer")
+			}
+			if err := i.recvBlock(block); nil != err {
+				logger.Error(err.Error())
+			}
+		case target := <-i.updates:
+			i.stateUpdated(target)
 		}
 	}
 }
 
 func (i *Noops) processBlock() error {
-	i.timer.Stop()
+	// When the block is cut by size as the timer expires, the tick is still
+	// pending and would cut the next block on its first transaction
+	if !i.timer.Stop() {
+		select {
+		case <-i.timer.C:
+		default:
+		}
+	}
 
 	if i.txQ.size() < 1 {
 		if logger.IsEnabledFor(logging.DEBUG) {
@@ -186,11 +373,40 @@ func (i *Noops) processBlock() error {
 		}
 		return nil
 	}
+
+	// Grab all transactions from the FIFO queue and run them in order
+	txarr := i.txQ.getTXs()
+	i.metrics.outstandingRequests.Set(0)
+	if !i.isOrderer() {
+		return i.executeBlock(txarr)
+	}
+
+	// Followers execute the block as it was cut here, as the same block of their blockchain
+	block := &Block{Number: i.stack.GetBlockchainSize()}
+	for _, tx := range txarr {
+		raw, err := proto.Marshal(tx)
+		if err != nil {
+			return err
+		}
+		block.Transactions = append(block.Transactions, raw)
+	}
+	if err := i.executeBlock(txarr); nil != err {
+		return err
+	}
+	block.Id = i.stack.GetBlockchainInfoBlob()
+	payload, err := proto.Marshal(block)
+	if err != nil {
+		return err
+	}
+	return i.broadcastConsensusMsg(&pb.Message{Type: pb.Message_CONSENSUS, Payload: payload, Timestamp: util.CreateUtcTimestamp()})
+}
+
+func (i *Noops) executeBlock(txarr []*pb.Transaction) error {
 	var data *pb.Block
 	var delta *statemgmt.StateDelta
 	var err error
 
-	if err = i.processTransactions(); nil != err {
+	if err = i.processTransactions(txarr); nil != err {
 		return err
 	}
 	if data, delta, err = i.getBlockData(); nil != err {
@@ -200,7 +416,7 @@ func (i *Noops) processBlock() error {
 	return nil
 }
 
-func (i *Noops) processTransactions() error {
+func (i *Noops) processTransactions(txarr []*pb.Transaction) error {
 	timestamp := util.CreateUtcTimestamp()
 	if logger.IsEnabledFor(logging.DEBUG) {
 		logger.Debugf("Starting TX batch with timestamp: %v", timestamp)
@@ -209,8 +425,7 @@ func (i *Noops) processTransactions() error {
 		return err
 	}
 
-	// Grab all transactions from the FIFO queue and run them in order
-	txarr := i.txQ.getTXs()
+	i.metrics.batchSize.Observe(float64(len(txarr)))
 	if logger.IsEnabledFor(logging.DEBUG) {
 		logger.Debugf("Executing batch of %d transactions with timestamp %v", len(txarr), timestamp)
 	}
@@ -231,6 +446,8 @@ func (i *Noops) processTransactions() error {
 		i.stack.RollbackTxBatch(timestamp)
 		return err
 	}
+	i.seqNo++
+	i.metrics.seqNo.Set(float64(i.seqNo))
 	return nil
 }
 
@@ -243,7 +460,7 @@ func (i *Noops) getTxFromMsg(msg *pb.Message) (*pb.Transaction, error) {
 }
 
 func (i *Noops) getBlockData() (*pb.Block, *statemgmt.StateDelta, error) {
-	ledger, err := ledger.GetLedger()
+	ledger, err := i.getLedger()
 	if err != nil {
 		return nil, nil, fmt.Errorf("Fail to get the ledger: %v", err)
 	}
@@ -309,7 +526,8 @@ func (i *Noops) RolledBack(tag interface{}) {
 	// Never called
 }
 
-// StatedUpdates is called when state transfer completes, if target is nil, this indicates a failure and a new target should be supplied, no-op for noops as it uses the legacy synchronous api
+// StateUpdated is called when state transfer completes, if target is nil, this indicates a failure and a new target should be supplied
 func (i *Noops) StateUpdated(tag interface{}, target *pb.BlockchainInfo) {
-	// Never called
+	// Only followers catching up with the orderer transfer state
+	i.updates <- target
 }
@@ -0,0 +1,378 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package noops
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/fabric/consensus"
+	"github.com/hyperledger/fabric/core/ledger/statemgmt"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+type mockStack struct {
+	consensus.Stack // panics on anything the tests do not expect
+
+	mutex      sync.Mutex
+	broadcasts []*pb.Message // to validators only
+	unicasts   map[string][]*pb.Message
+	height     uint64
+	pending    []*pb.Transaction
+	executed   chan []*pb.Transaction // transactions of each committed block
+	valid      bool
+	transfers  []*pb.BlockchainInfo
+}
+
+func (s *mockStack) Broadcast(msg *pb.Message, peerType pb.PeerEndpoint_Type) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if peerType == pb.PeerEndpoint_VALIDATOR {
+		s.broadcasts = append(s.broadcasts, msg)
+	}
+	return nil
+}
+
+func (s *mockStack) Unicast(msg *pb.Message, receiverHandle *pb.PeerID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unicasts[receiverHandle.Name] = append(s.unicasts[receiverHandle.Name], msg)
+	return nil
+}
+
+func (s *mockStack) BeginTxBatch(id interface{}) error {
+	return nil
+}
+
+func (s *mockStack) ExecTxs(id interface{}, txs []*pb.Transaction) ([]byte, error) {
+	s.pending = append(s.pending, txs...)
+	return nil, nil
+}
+
+func (s *mockStack) CommitTxBatch(id interface{}, metadata []byte) (*pb.Block, error) {
+	s.mutex.Lock()
+	s.height++
+	s.mutex.Unlock()
+	s.executed <- s.pending
+	s.pending = nil
+	return &pb.Block{}, nil
+}
+
+func (s *mockStack) RollbackTxBatch(id interface{}) error {
+	s.pending = nil
+	return nil
+}
+
+func (s *mockStack) GetBlockchainSize() uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.height
+}
+
+func (s *mockStack) GetBlockchainInfoBlob() []byte {
+	info, _ := proto.Marshal(&pb.BlockchainInfo{Height: s.GetBlockchainSize()})
+	return info
+}
+
+func (s *mockStack) GetBlockByNumber(blockNumber uint64) (*pb.Block, error) {
+	return &pb.Block{}, nil
+}
+
+func (s *mockStack) GetStateDelta(blockNumber uint64) (*statemgmt.StateDelta, error) {
+	return statemgmt.NewStateDelta(), nil
+}
+
+func (s *mockStack) InvalidateState() {
+	s.valid = false
+}
+
+func (s *mockStack) ValidateState() {
+	s.valid = true
+}
+
+func (s *mockStack) UpdateState(tag interface{}, target *pb.BlockchainInfo, peers []*pb.PeerID) {
+	s.transfers = append(s.transfers, target)
+}
+
+// newTestNoops returns a noops instance which does not process its channels,
+// its blockchain holds the genesis block
+func newTestNoops(self, orderer string) (*Noops, *mockStack) {
+	stack := &mockStack{
+		unicasts: make(map[string][]*pb.Message),
+		height:   1,
+		executed: make(chan []*pb.Transaction, 10),
+		valid:    true,
+	}
+	i := &Noops{
+		stack:     stack,
+		getLedger: func() (blockLedger, error) { return stack, nil },
+		txQ:       newTXQ(2),
+		timer:     time.NewTimer(time.Hour),
+		duration:  time.Hour,
+		channel:   make(chan *pb.Transaction, 10),
+		blocks:    make(chan *Block, 10),
+		updates:   make(chan *pb.BlockchainInfo, 1),
+		metrics:   newNoopsMetrics(),
+		self:      &pb.PeerID{Name: self},
+	}
+	i.timer.Stop()
+	if orderer != "" {
+		i.orderer = &pb.PeerID{Name: orderer}
+	}
+	return i, stack
+}
+
+// executed returns the transaction IDs of the next block committed, or nil
+// if no block is committed in time
+func executed(stack *mockStack, wait time.Duration) []string {
+	select {
+	case txs := <-stack.executed:
+		txids := make([]string, len(txs))
+		for n, tx := range txs {
+			txids[n] = tx.Txid
+		}
+		return txids
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+func chainTxMsg(txid string) *pb.Message {
+	payload, _ := proto.Marshal(&pb.Transaction{Txid: txid})
+	return &pb.Message{Type: pb.Message_CHAIN_TRANSACTION, Payload: payload}
+}
+
+func ordererBlock(number uint64, txids ...string) *Block {
+	block := &Block{Number: number}
+	for _, txid := range txids {
+		raw, _ := proto.Marshal(&pb.Transaction{Txid: txid})
+		block.Transactions = append(block.Transactions, raw)
+	}
+	block.Id, _ = proto.Marshal(&pb.BlockchainInfo{Height: number + 1})
+	return block
+}
+
+func ordererBlockMsg(number uint64, txids ...string) *pb.Message {
+	payload, _ := proto.Marshal(ordererBlock(number, txids...))
+	return &pb.Message{Type: pb.Message_CONSENSUS, Payload: payload}
+}
+
+func blockMsg(txids ...string) *pb.Message {
+	block := &pb.TransactionBlock{}
+	for _, txid := range txids {
+		block.Transactions = append(block.Transactions, &pb.Transaction{Txid: txid})
+	}
+	payload, _ := proto.Marshal(block)
+	return &pb.Message{Type: pb.Message_CONSENSUS, Payload: payload}
+}
+
+func TestBroadcastWithoutOrderer(t *testing.T) {
+	i, stack := newTestNoops("vp1", "")
+	if err := i.RecvMsg(chainTxMsg("a"), i.self); err != nil {
+		t.Fatalf("Failed to receive transaction: %s", err)
+	}
+	if len(stack.broadcasts) != 1 {
+		t.Errorf("Expected the transaction to be broadcast, got %d broadcasts", len(stack.broadcasts))
+	}
+	if tx := <-i.channel; tx.Txid != "a" {
+		t.Errorf("Expected transaction a to be queued, got %s", tx.Txid)
+	}
+}
+
+func TestOrdererQueuesTransactions(t *testing.T) {
+	i, stack := newTestNoops("vp0", "vp0")
+	if err := i.RecvMsg(chainTxMsg("a"), i.self); err != nil {
+		t.Fatalf("Failed to receive transaction: %s", err)
+	}
+	if err := i.RecvMsg(blockMsg("b"), &pb.PeerID{Name: "vp1"}); err != nil {
+		t.Fatalf("Failed to receive forwarded transaction: %s", err)
+	}
+	if len(stack.broadcasts) != 0 {
+		t.Errorf("Expected the orderer to broadcast blocks only, got %d broadcasts", len(stack.broadcasts))
+	}
+	for _, txid := range []string{"a", "b"} {
+		if tx := <-i.channel; tx.Txid != txid {
+			t.Errorf("Expected transaction %s to be queued, got %s", txid, tx.Txid)
+		}
+	}
+}
+
+func TestFollowerForwardsToOrderer(t *testing.T) {
+	i, stack := newTestNoops("vp1", "vp0")
+	if err := i.RecvMsg(chainTxMsg("a"), i.self); err != nil {
+		t.Fatalf("Failed to receive transaction: %s", err)
+	}
+	if len(stack.broadcasts) != 0 || len(stack.unicasts["vp0"]) != 1 {
+		t.Fatalf("Expected the transaction to be sent to the orderer only, got %d broadcasts and %d unicasts", len(stack.broadcasts), len(stack.unicasts["vp0"]))
+	}
+	if len(i.channel) != 0 {
+		t.Errorf("Expected the follower not to queue the transaction itself")
+	}
+
+	// The orderer receives the forwarded transaction as it receives those of the other peers
+	orderer, _ := newTestNoops("vp0", "vp0")
+	if err := orderer.RecvMsg(stack.unicasts["vp0"][0], i.self); err != nil {
+		t.Fatalf("Orderer failed to receive forwarded transaction: %s", err)
+	}
+	if tx := <-orderer.channel; tx.Txid != "a" {
+		t.Errorf("Expected the orderer to queue transaction a, got %s", tx.Txid)
+	}
+}
+
+func TestFollowerQueuesOrdererBlocks(t *testing.T) {
+	i, _ := newTestNoops("vp1", "vp0")
+	if err := i.RecvMsg(ordererBlockMsg(1, "x"), &pb.PeerID{Name: "vp2"}); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if len(i.blocks) != 0 {
+		t.Errorf("Expected the block of a peer other than the orderer to be ignored")
+	}
+
+	if err := i.RecvMsg(ordererBlockMsg(1, "a", "b"), &pb.PeerID{Name: "vp0"}); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if block := <-i.blocks; block.Number != 1 || len(block.Transactions) != 2 {
+		t.Errorf("Expected block 1 of the orderer to be queued as cut, got %v", block)
+	}
+}
+
+func TestCutBlockBySize(t *testing.T) {
+	i, stack := newTestNoops("vp0", "")
+	go i.handleChannels()
+
+	i.channel <- &pb.Transaction{Txid: "a"}
+	i.channel <- &pb.Transaction{Txid: "b"}
+	if txids := executed(stack, time.Second); len(txids) != 2 || txids[0] != "a" || txids[1] != "b" {
+		t.Fatalf("Expected a full block of transactions a and b to be cut, got %v", txids)
+	}
+}
+
+func TestCutBlockByTimeout(t *testing.T) {
+	i, stack := newTestNoops("vp0", "")
+	i.duration = 10 * time.Millisecond
+	go i.handleChannels()
+
+	i.channel <- &pb.Transaction{Txid: "a"}
+	if txids := executed(stack, time.Second); len(txids) != 1 || txids[0] != "a" {
+		t.Fatalf("Expected transaction a to be cut once the block wait expired, got %v", txids)
+	}
+}
+
+func TestCutBlockBySizeDrainsTimer(t *testing.T) {
+	i, stack := newTestNoops("vp0", "")
+	i.duration = time.Millisecond
+
+	i.canProcessBlock(&pb.Transaction{Txid: "a"})
+	time.Sleep(10 * time.Millisecond) // the timer expires as the block fills up
+	if !i.canProcessBlock(&pb.Transaction{Txid: "b"}) {
+		t.Fatalf("Expected the block to be full")
+	}
+	if err := i.processBlock(); err != nil {
+		t.Fatalf("Failed to cut block: %s", err)
+	}
+	executed(stack, time.Second)
+
+	select {
+	case <-i.timer.C:
+		t.Errorf("Expected the expired timer not to cut the next block")
+	default:
+	}
+}
+
+func TestFollowerExecutesOrdererBlocks(t *testing.T) {
+	orderer, ordererStack := newTestNoops("vp0", "vp0")
+	follower, followerStack := newTestNoops("vp1", "vp0")
+
+	for _, txids := range [][]string{{"a", "b"}, {"c", "d"}} {
+		for _, txid := range txids {
+			orderer.canProcessBlock(&pb.Transaction{Txid: txid})
+		}
+		if err := orderer.processBlock(); err != nil {
+			t.Fatalf("Orderer failed to cut block: %s", err)
+		}
+		executed(ordererStack, time.Second)
+	}
+	if len(ordererStack.broadcasts) != 2 {
+		t.Fatalf("Expected the orderer to broadcast 2 blocks, got %d", len(ordererStack.broadcasts))
+	}
+
+	for n, expected := range [][]string{{"a", "b"}, {"c", "d"}} {
+		if err := follower.RecvMsg(ordererStack.broadcasts[n], orderer.self); err != nil {
+			t.Fatalf("Failed to receive block: %s", err)
+		}
+		if err := follower.recvBlock(<-follower.blocks); err != nil {
+			t.Fatalf("Failed to execute block: %s", err)
+		}
+		if txids := executed(followerStack, time.Second); len(txids) != 2 || txids[0] != expected[0] || txids[1] != expected[1] {
+			t.Fatalf("Expected the follower to execute block %d with transactions %v, got %v", n+1, expected, txids)
+		}
+	}
+	if followerStack.GetBlockchainSize() != ordererStack.GetBlockchainSize() {
+		t.Errorf("Expected the follower to reach the height %d of the orderer, got %d", ordererStack.GetBlockchainSize(), followerStack.GetBlockchainSize())
+	}
+
+	// Blocks received twice are executed once
+	if err := follower.recvBlock(ordererBlock(2, "c", "d")); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if txids := executed(followerStack, 10*time.Millisecond); txids != nil {
+		t.Errorf("Expected block 2 not to be executed again, executed %v", txids)
+	}
+}
+
+func TestFollowerCatchesUpAfterGap(t *testing.T) {
+	i, stack := newTestNoops("vp1", "vp0")
+
+	// Blocks 1 and 2 were missed
+	if err := i.recvBlock(ordererBlock(3, "e")); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if len(stack.transfers) != 1 || stack.transfers[0].Height != 4 || stack.valid {
+		t.Fatalf("Expected an invalidated state and a state transfer to the height 4 of the orderer, got %v", stack.transfers)
+	}
+	if err := i.recvBlock(ordererBlock(4, "f")); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if len(stack.transfers) != 1 || executed(stack, 10*time.Millisecond) != nil {
+		t.Fatalf("Expected the blocks received while catching up to be ignored")
+	}
+
+	// The state transfer failed, the next block starts another one
+	i.stateUpdated(nil)
+	if err := i.recvBlock(ordererBlock(5, "g")); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if len(stack.transfers) != 2 || stack.transfers[1].Height != 6 {
+		t.Fatalf("Expected the state transfer to be retried, got %v", stack.transfers)
+	}
+
+	stack.height = 6
+	i.StateUpdated(uint64(5), stack.transfers[1])
+	i.stateUpdated(<-i.updates)
+	if !stack.valid {
+		t.Fatalf("Expected the state to be valid once the follower caught up")
+	}
+	if err := i.recvBlock(ordererBlock(6, "h")); err != nil {
+		t.Fatalf("Failed to receive block: %s", err)
+	}
+	if txids := executed(stack, time.Second); len(txids) != 1 || txids[0] != "h" {
+		t.Errorf("Expected the follower to execute block 6 once caught up, got %v", txids)
+	}
+}
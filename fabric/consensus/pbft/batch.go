@@ -18,10 +18,13 @@ package pbft
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/hyperledger/fabric/consensus"
 	"github.com/hyperledger/fabric/consensus/util/events"
+	"github.com/hyperledger/fabric/consensus/util/metrics"
+	"github.com/hyperledger/fabric/core/util"
 	pb "github.com/hyperledger/fabric/protos"
 
 	"github.com/golang/protobuf/proto"
@@ -67,6 +70,11 @@ type batchMessageEvent batchMessage
 // batchTimerEvent is sent when the batch timer expires
 type batchTimerEvent struct{}
 
+// reconfigurationEvent is sent when a change of the validator set is submitted to this replica
+type reconfigurationEvent struct {
+	membership *Membership
+}
+
 func newObcBatch(id uint64, config *viper.Viper, stack consensus.Stack) *obcBatch {
 	var err error
 
@@ -78,6 +86,8 @@ func newObcBatch(id uint64, config *viper.Viper, stack consensus.Stack) *obcBatc
 
 	logger.Debugf("Replica %d obtaining startup information", id)
 
+	op.reqStore = newRequestStore() // needed by the metrics published after every event
+
 	op.manager = events.NewManagerImpl() // TODO, this is hacky, eventually rip it out
 	op.manager.SetReceiver(op)
 	etf := events.NewTimerFactoryImpl(op.manager)
@@ -86,6 +96,7 @@ func newObcBatch(id uint64, config *viper.Viper, stack consensus.Stack) *obcBatc
 	blockchainInfoBlob := stack.GetBlockchainInfoBlob()
 	op.externalEventReceiver.manager = op.manager
 	op.broadcaster = newBroadcaster(id, op.pbft.N, op.pbft.f, op.pbft.broadcastTimeout, stack)
+	op.broadcaster.setReplicas(op.pbft.replicas, op.pbft.f) // the validator set may have been changed before a restart
 	op.manager.Queue() <- workEvent(func() {
 		op.pbft.stateTransfer(&stateUpdateTarget{
 			checkpointMessage: checkpointMessage{
@@ -118,8 +129,6 @@ func newObcBatch(id uint64, config *viper.Viper, stack consensus.Stack) *obcBatc
 
 	op.batchTimer = etf.CreateTimer()
 
-	op.reqStore = newRequestStore()
-
 	op.deduplicator = newDeduplicator()
 
 	op.idleChan = make(chan struct{})
@@ -128,6 +137,24 @@ func newObcBatch(id uint64, config *viper.Viper, stack consensus.Stack) *obcBatc
 	return op
 }
 
+// Reconfigure submits this replica's signed vote to replace the validator set
+// with replicas, tolerating as many byzantine faults as their number allows.
+// The change is ordered once a quorum (2f+1 of 3f+1) of the current validators
+// voted for the same set, and takes effect at the next checkpoint.
+func (op *obcBatch) Reconfigure(replicas []uint64) error {
+	membership := newMembership(replicas)
+	if err := validateMembership(membership); err != nil {
+		return err
+	}
+	op.manager.Queue() <- reconfigurationEvent{membership}
+	return nil
+}
+
+// Metrics returns the metrics published by this replica
+func (op *obcBatch) Metrics() *metrics.Registry {
+	return op.pbft.metrics.registry
+}
+
 // Close tells us to release resources we are holding
 func (op *obcBatch) Close() {
 	op.batchTimer.Halt()
@@ -183,6 +210,11 @@ func (op *obcBatch) sign(msg []byte) ([]byte, error) {
 	return op.stack.Sign(msg)
 }
 
+// adjust the replicas we send to when the validator set changes
+func (op *obcBatch) updateReplicas(replicas []uint64, f int) {
+	op.broadcaster.setReplicas(replicas, f)
+}
+
 // verify message signature
 func (op *obcBatch) verify(senderID uint64, signature []byte, message []byte) error {
 	senderHandle, err := getValidatorHandle(senderID)
@@ -196,6 +228,13 @@ func (op *obcBatch) verify(senderID uint64, signature []byte, message []byte) er
 func (op *obcBatch) execute(seqNo uint64, reqBatch *RequestBatch) {
 	var txs []*pb.Transaction
 	for _, req := range reqBatch.GetBatch() {
+		if membership := req.GetMembership(); membership != nil {
+			logger.Infof("Batch replica %d executing vote of replica %d for validator set %v, seqNo=%d", op.pbft.id, req.ReplicaId, membership.Replicas, seqNo)
+			op.reqStore.remove(req)
+			op.deduplicator.Execute(req)
+			op.pbft.recvReconfigurationVote(seqNo, req)
+			continue
+		}
 		tx := &pb.Transaction{}
 		if err := proto.Unmarshal(req.Payload, tx); err != nil {
 			logger.Warningf("Batch replica %d could not unmarshal transaction %s", op.pbft.id, err)
@@ -208,7 +247,13 @@ func (op *obcBatch) execute(seqNo uint64, reqBatch *RequestBatch) {
 		txs = append(txs, tx)
 		op.deduplicator.Execute(req)
 	}
-	meta, _ := proto.Marshal(&Metadata{seqNo})
+	op.pbft.metrics.batchSize.Observe(float64(len(reqBatch.GetBatch())))
+	meta, _ := proto.Marshal(&Metadata{
+		SeqNo:           seqNo,
+		Membership:      op.pbft.membership(),
+		Reconfiguration: op.pbft.reconfiguration,
+		Votes:           op.pbft.votes,
+	})
 	logger.Debugf("Batch replica %d received exec for seqNo %d containing %d transactions", op.pbft.id, seqNo, len(txs))
 	op.stack.Execute(meta, txs) // This executes in the background, we will receive an executedEvent once it completes
 }
@@ -298,6 +343,10 @@ func (op *obcBatch) processMessage(ocMsg *pb.Message, senderHandle *pb.PeerID) e
 		if err != nil {
 			panic("Cannot map sender's PeerID to a valid replica ID")
 		}
+		if ts := ocMsg.Timestamp; ts != nil {
+			sent := time.Unix(ts.Seconds, int64(ts.Nanos))
+			op.pbft.metrics.messageLatency.Observe(time.Since(sent).Seconds(), strconv.FormatUint(senderID, 10))
+		}
 		msg := &Message{}
 		err = proto.Unmarshal(pbftMsg, msg)
 		if err != nil {
@@ -317,6 +366,10 @@ func (op *obcBatch) processMessage(ocMsg *pb.Message, senderHandle *pb.PeerID) e
 
 func (op *obcBatch) logAddTxFromRequest(req *Request) {
 	if logger.IsEnabledFor(logging.DEBUG) {
+		if membership := req.GetMembership(); membership != nil {
+			logger.Debugf("Replica %d adding vote of %d for validator set %v into outstandingReqs", op.pbft.id, req.ReplicaId, membership.Replicas)
+			return
+		}
 		// This is potentially a very large expensive debug statement, guard
 		tx := &pb.Transaction{}
 		err := proto.Unmarshal(req.Payload, tx)
@@ -354,6 +407,7 @@ func (op *obcBatch) resubmitOutstandingReqs() events.Event {
 // allow the primary to send a batch when the timer expires
 func (op *obcBatch) ProcessEvent(event events.Event) events.Event {
 	logger.Debugf("Replica %d batch main thread looping", op.pbft.id)
+	defer op.updateMetrics()
 	switch et := event.(type) {
 	case batchMessageEvent:
 		ocMsg := et
@@ -369,6 +423,19 @@ func (op *obcBatch) ProcessEvent(event events.Event) events.Event {
 			return res
 		}
 		return op.resubmitOutstandingReqs()
+	case reconfigurationEvent:
+		if !op.pbft.isReplica(op.pbft.id) {
+			logger.Warningf("Replica %d is not part of the validator set, not voting for validator set %v", op.pbft.id, et.membership.Replicas)
+			return nil
+		}
+		req := op.txToReq(nil)
+		req.Membership = et.membership
+		if err := op.pbft.sign(req); err != nil {
+			logger.Errorf("Replica %d could not sign its vote for validator set %v: %s", op.pbft.id, et.membership.Replicas, err)
+			return nil
+		}
+		logger.Infof("Replica %d voting for validator set %v", op.pbft.id, et.membership.Replicas)
+		return op.submitToLeader(req)
 	case batchTimerEvent:
 		logger.Infof("Replica %d batch timer expired", op.pbft.id)
 		if op.pbft.activeView && (len(op.batchStore) > 0) {
@@ -432,6 +499,13 @@ func (op *obcBatch) ProcessEvent(event events.Event) events.Event {
 	return nil
 }
 
+// publish the state of the replica after each event
+func (op *obcBatch) updateMetrics() {
+	op.pbft.updateMetrics()
+	op.pbft.metrics.outstandingRequests.Set(float64(op.reqStore.outstandingRequests.Len()))
+	op.pbft.metrics.pendingRequests.Set(float64(op.reqStore.pendingRequests.Len()))
+}
+
 func (op *obcBatch) startBatchTimer() {
 	op.batchTimer.Reset(op.batchTimeout, batchTimerEvent{})
 	logger.Debugf("Replica %d started the batch timer", op.pbft.id)
@@ -450,8 +524,9 @@ func (op *obcBatch) wrapMessage(msgPayload []byte) *pb.Message {
 	batchMsg := &BatchMessage{Payload: &BatchMessage_PbftMessage{PbftMessage: msgPayload}}
 	packedBatchMsg, _ := proto.Marshal(batchMsg)
 	ocMsg := &pb.Message{
-		Type:    pb.Message_CONSENSUS,
-		Payload: packedBatchMsg,
+		Type:      pb.Message_CONSENSUS,
+		Payload:   packedBatchMsg,
+		Timestamp: util.CreateUtcTimestamp(), // lets the receiver measure the message latency
 	}
 	return ocMsg
 }
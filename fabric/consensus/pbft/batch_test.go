@@ -43,36 +43,36 @@ func TestNetworkBatch(t *testing.T) {
 	net := makeConsumerNetwork(validatorCount, obcBatchHelper, func(ce *consumerEndpoint) {
 		ce.consumer.(*obcBatch).batchSize = batchSize
 	})
-	defer net.stop()
+	defer net.Stop()
 
-	broadcaster := net.endpoints[generateBroadcaster(validatorCount)].getHandle()
-	err := net.endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
+	broadcaster := net.Endpoints[generateBroadcaster(validatorCount)].GetHandle()
+	err := net.Endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
 	if err != nil {
 		t.Errorf("External request was not processed by backup: %v", err)
 	}
-	err = net.endpoints[2].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(2), broadcaster)
+	err = net.Endpoints[2].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(2), broadcaster)
 	if err != nil {
 		t.Fatalf("External request was not processed by backup: %v", err)
 	}
 
-	net.process()
-	net.process()
+	net.Process()
+	net.Process()
 
-	if l := len(net.endpoints[0].(*consumerEndpoint).consumer.(*obcBatch).batchStore); l != 0 {
+	if l := len(net.Endpoints[0].(*consumerEndpoint).consumer.(*obcBatch).batchStore); l != 0 {
 		t.Errorf("%d messages expected in primary's batchStore, found %v", 0,
-			net.endpoints[0].(*consumerEndpoint).consumer.(*obcBatch).batchStore)
+			net.Endpoints[0].(*consumerEndpoint).consumer.(*obcBatch).batchStore)
 	}
 
-	for _, ep := range net.endpoints {
+	for _, ep := range net.Endpoints {
 		ce := ep.(*consumerEndpoint)
 		block, err := ce.consumer.(*obcBatch).stack.GetBlock(1)
 		if nil != err {
-			t.Fatalf("Replica %d executed requests, expected a new block on the chain, but could not retrieve it : %s", ce.id, err)
+			t.Fatalf("Replica %d executed requests, expected a new block on the chain, but could not retrieve it : %s", ce.ID, err)
 		}
 		numTrans := len(block.Transactions)
 		if numTrans != batchSize {
 			t.Fatalf("Replica %d executed %d requests, expected %d",
-				ce.id, numTrans, batchSize)
+				ce.ID, numTrans, batchSize)
 		}
 	}
 }
@@ -292,11 +292,11 @@ func TestClassicStateTransfer(t *testing.T) {
 		ce.consumer.(*obcBatch).pbft.K = 2
 		ce.consumer.(*obcBatch).pbft.L = 4
 	})
-	defer net.stop()
-	// net.debug = true
+	defer net.Stop()
+	// net.Debug = true
 
 	filterMsg := true
-	net.filterFn = func(src int, dst int, msg []byte) []byte {
+	net.FilterFn = func(src int, dst int, msg []byte) []byte {
 		if filterMsg && dst == 3 { // 3 is byz
 			return nil
 		}
@@ -304,27 +304,27 @@ func TestClassicStateTransfer(t *testing.T) {
 	}
 
 	// Advance the network one seqNo past so that Replica 3 will have to do statetransfer
-	broadcaster := net.endpoints[generateBroadcaster(validatorCount)].getHandle()
-	net.endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
-	net.process()
+	broadcaster := net.Endpoints[generateBroadcaster(validatorCount)].GetHandle()
+	net.Endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
+	net.Process()
 
 	// Move the seqNo to 9, at seqNo 6, Replica 3 will realize it's behind, transfer to seqNo 8, then execute seqNo 9
 	filterMsg = false
 	for n := 2; n <= 9; n++ {
-		net.endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(int64(n)), broadcaster)
+		net.Endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(int64(n)), broadcaster)
 	}
 
-	net.process()
+	net.Process()
 
-	for _, ep := range net.endpoints {
+	for _, ep := range net.Endpoints {
 		ce := ep.(*consumerEndpoint)
 		obc := ce.consumer.(*obcBatch)
 		_, err := obc.stack.GetBlock(9)
 		if nil != err {
-			t.Errorf("Replica %d executed requests, expected a new block on the chain, but could not retrieve it : %s", ce.id, err)
+			t.Errorf("Replica %d executed requests, expected a new block on the chain, but could not retrieve it : %s", ce.ID, err)
 		}
 		if !obc.pbft.activeView || obc.pbft.view != 0 {
-			t.Errorf("Replica %d not active in view 0, is %v %d", ce.id, obc.pbft.activeView, obc.pbft.view)
+			t.Errorf("Replica %d not active in view 0, is %v %d", ce.ID, obc.pbft.activeView, obc.pbft.view)
 		}
 	}
 }
@@ -336,11 +336,11 @@ func TestClassicBackToBackStateTransfer(t *testing.T) {
 		ce.consumer.(*obcBatch).pbft.L = 4
 		ce.consumer.(*obcBatch).pbft.requestTimeout = time.Hour // We do not want any view changes
 	})
-	defer net.stop()
-	// net.debug = true
+	defer net.Stop()
+	// net.Debug = true
 
 	filterMsg := true
-	net.filterFn = func(src int, dst int, msg []byte) []byte {
+	net.FilterFn = func(src int, dst int, msg []byte) []byte {
 		if filterMsg && dst == 3 { // 3 is byz
 			return nil
 		}
@@ -348,9 +348,9 @@ func TestClassicBackToBackStateTransfer(t *testing.T) {
 	}
 
 	// Get the group to advance past seqNo 1, leaving Replica 3 behind
-	broadcaster := net.endpoints[generateBroadcaster(validatorCount)].getHandle()
-	net.endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
-	net.process()
+	broadcaster := net.Endpoints[generateBroadcaster(validatorCount)].GetHandle()
+	net.Endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
+	net.Process()
 
 	// Now start including Replica 3, go to sequence number 10, Replica 3 will trigger state transfer
 	// after seeing seqNo 8, then pass another target for seqNo 10 and 12, but transfer to 8, but the network
@@ -358,20 +358,20 @@ func TestClassicBackToBackStateTransfer(t *testing.T) {
 	// Replica 3 will execute through seqNo 12
 	filterMsg = false
 	for n := 2; n <= 21; n++ {
-		net.endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(int64(n)), broadcaster)
+		net.Endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(int64(n)), broadcaster)
 	}
 
-	net.process()
+	net.Process()
 
-	for _, ep := range net.endpoints {
+	for _, ep := range net.Endpoints {
 		ce := ep.(*consumerEndpoint)
 		obc := ce.consumer.(*obcBatch)
 		_, err := obc.stack.GetBlock(21)
 		if nil != err {
-			t.Errorf("Replica %d executed requests, expected a new block on the chain, but could not retrieve it : %s", ce.id, err)
+			t.Errorf("Replica %d executed requests, expected a new block on the chain, but could not retrieve it : %s", ce.ID, err)
 		}
 		if !obc.pbft.activeView || obc.pbft.view != 0 {
-			t.Errorf("Replica %d not active in view 0, is %v %d", ce.id, obc.pbft.activeView, obc.pbft.view)
+			t.Errorf("Replica %d not active in view 0, is %v %d", ce.ID, obc.pbft.activeView, obc.pbft.view)
 		}
 	}
 }
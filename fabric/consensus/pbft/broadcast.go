@@ -32,10 +32,12 @@ type communicator interface {
 
 type broadcaster struct {
 	comm communicator
+	self uint64
 
 	f                int
 	broadcastTimeout time.Duration
 	msgChans         map[uint64]chan *sendRequest
+	stopChans        map[uint64]chan struct{} // closed to stop the drainer of a replica which left the network
 	closed           sync.WaitGroup
 	closedCh         chan struct{}
 }
@@ -46,32 +48,52 @@ type sendRequest struct {
 }
 
 func newBroadcaster(self uint64, N int, f int, broadcastTimeout time.Duration, c communicator) *broadcaster {
-	queueSize := 10 // XXX increase after testing
-
-	chans := make(map[uint64]chan *sendRequest)
 	b := &broadcaster{
 		comm:             c,
+		self:             self,
 		f:                f,
 		broadcastTimeout: broadcastTimeout,
-		msgChans:         chans,
+		msgChans:         make(map[uint64]chan *sendRequest),
+		stopChans:        make(map[uint64]chan struct{}),
 		closedCh:         make(chan struct{}),
 	}
 	for i := 0; i < N; i++ {
-		if uint64(i) == self {
-			continue
-		}
-		chans[uint64(i)] = make(chan *sendRequest, queueSize)
+		b.addReplica(uint64(i))
 	}
 
-	// We do not start the go routines in the above loop to avoid concurrent map read/writes
-	for i := 0; i < N; i++ {
-		if uint64(i) == self {
-			continue
-		}
-		go b.drainer(uint64(i))
+	return b
+}
+
+func (b *broadcaster) addReplica(dest uint64) {
+	queueSize := 10 // XXX increase after testing
+
+	if _, ok := b.msgChans[dest]; ok || dest == b.self {
+		return
 	}
+	destChan := make(chan *sendRequest, queueSize)
+	stopChan := make(chan struct{})
+	b.msgChans[dest] = destChan
+	b.stopChans[dest] = stopChan
+	go b.drainer(dest, destChan, stopChan)
+}
 
-	return b
+// setReplicas changes the replicas messages are sent to, and the number
+// of them which may fail before a broadcast is considered incomplete.
+// Like send, it must be called from a single thread.
+func (b *broadcaster) setReplicas(replicas []uint64, f int) {
+	b.f = f
+	keep := make(map[uint64]bool)
+	for _, id := range replicas {
+		keep[id] = true
+		b.addReplica(id)
+	}
+	for id, stopChan := range b.stopChans {
+		if !keep[id] {
+			close(stopChan)
+			delete(b.msgChans, id)
+			delete(b.stopChans, id)
+		}
+	}
 }
 
 func (b *broadcaster) Close() {
@@ -111,29 +133,32 @@ func (b *broadcaster) drainerSend(dest uint64, send *sendRequest, successLastTim
 
 }
 
-func (b *broadcaster) drainer(dest uint64) {
+func (b *broadcaster) drainer(dest uint64, destChan chan *sendRequest, stopChan chan struct{}) {
 	successLastTime := false
-	destChan, exsit := b.msgChans[dest] // Avoid doing the map lookup every send
-	if !exsit {
-		logger.Warningf("could not get message channel for replica %d", dest)
-		return
-	}
 
 	for {
 		select {
 		case send := <-destChan:
 			successLastTime = b.drainerSend(dest, send, successLastTime)
 		case <-b.closedCh:
-			for {
-				// Drain the message channel to free calling waiters before we shut down
-				select {
-				case send := <-destChan:
-					send.done <- false
-					b.closed.Done()
-				default:
-					return
-				}
-			}
+			b.drain(destChan)
+			return
+		case <-stopChan:
+			b.drain(destChan)
+			return
+		}
+	}
+}
+
+// drain fails the queued sends to free calling waiters before the drainer shuts down
+func (b *broadcaster) drain(destChan chan *sendRequest) {
+	for {
+		select {
+		case send := <-destChan:
+			send.done <- false
+			b.closed.Done()
+		default:
+			return
 		}
 	}
 }
@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbft
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// contiguousReplicas returns the replica IDs 0..N-1, the validator set
+// implied by the static configuration
+func contiguousReplicas(N int) []uint64 {
+	replicas := make([]uint64, N)
+	for i := range replicas {
+		replicas[i] = uint64(i)
+	}
+	return replicas
+}
+
+// newMembership returns a membership for the given validator set which
+// tolerates as many byzantine faults as the set size allows
+func newMembership(replicas []uint64) *Membership {
+	return &Membership{
+		Replicas: replicas,
+		F:        uint64((len(replicas) - 1) / 3),
+	}
+}
+
+// validateMembership checks that a membership names each replica at most
+// once and is large enough to tolerate f byzantine faults
+func validateMembership(m *Membership) error {
+	if m == nil || len(m.Replicas) == 0 {
+		return fmt.Errorf("membership has no replicas")
+	}
+	seen := make(map[uint64]bool)
+	for _, id := range m.Replicas {
+		if seen[id] {
+			return fmt.Errorf("replica %d appears more than once in membership", id)
+		}
+		seen[id] = true
+	}
+	if 3*m.F+1 > uint64(len(m.Replicas)) {
+		return fmt.Errorf("need at least %d replicas to tolerate %d byzantine faults, but membership has only %d", 3*m.F+1, m.F, len(m.Replicas))
+	}
+	return nil
+}
+
+// membership returns the validator set currently ordering requests
+func (instance *pbftCore) membership() *Membership {
+	return &Membership{
+		Replicas: append([]uint64(nil), instance.replicas...),
+		F:        uint64(instance.f),
+	}
+}
+
+// isReplica reports whether id is part of the current validator set
+func (instance *pbftCore) isReplica(id uint64) bool {
+	i := sort.Search(len(instance.replicas), func(i int) bool { return instance.replicas[i] >= id })
+	return i < len(instance.replicas) && instance.replicas[i] == id
+}
+
+// setMembership installs a validator set, N and f (and with them the quorum
+// sizes) are derived from it
+func (instance *pbftCore) setMembership(m *Membership) {
+	replicas := append([]uint64(nil), m.Replicas...)
+	sort.Sort(sortableUint64Slice(replicas))
+	instance.replicas = replicas
+	instance.N = len(replicas)
+	instance.f = int(m.F)
+	logger.Infof("Replica %d validator set is now %v (N=%d, f=%d)", instance.id, instance.replicas, instance.N, instance.f)
+}
+
+// sameReplicas reports whether a and b name the same replicas, in any order
+func sameReplicas(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sa := append(sortableUint64Slice(nil), a...)
+	sb := append(sortableUint64Slice(nil), b...)
+	sort.Sort(sa)
+	sort.Sort(sb)
+	return reflect.DeepEqual(sa, sb)
+}
+
+// voteTime returns the time a vote was signed at
+func voteTime(req *Request) time.Time {
+	if req.Timestamp == nil {
+		return time.Time{}
+	}
+	return time.Unix(req.Timestamp.Seconds, int64(req.Timestamp.Nanos))
+}
+
+// recvReconfigurationVote is called by the consumer when it executes a
+// signed vote of a replica for a new validator set. Each replica of the
+// current set has one vote, a newer vote replaces its previous one. The
+// validator set is replaced once a quorum of the current replicas voted for
+// the same set; votes signed before the current membership was decided are
+// stale, so ordered votes cannot be replayed.
+func (instance *pbftCore) recvReconfigurationVote(seqNo uint64, req *Request) {
+	if !instance.isReplica(req.ReplicaId) {
+		logger.Warningf("Replica %d ignoring reconfiguration vote of replica %d, which is not part of the validator set", instance.id, req.ReplicaId)
+		return
+	}
+	if err := instance.verify(req); err != nil {
+		logger.Warningf("Replica %d ignoring reconfiguration vote with incorrect signature from replica %d: %s", instance.id, req.ReplicaId, err)
+		return
+	}
+	m := newMembership(req.Membership.Replicas)
+	if err := validateMembership(m); err != nil {
+		logger.Warningf("Replica %d ignoring invalid reconfiguration vote of replica %d at seqNo=%d: %s", instance.id, req.ReplicaId, seqNo, err)
+		return
+	}
+
+	signed := voteTime(req)
+	if !signed.After(voteTime(&Request{Timestamp: instance.votes.Since})) {
+		logger.Warningf("Replica %d ignoring stale reconfiguration vote of replica %d", instance.id, req.ReplicaId)
+		return
+	}
+	var votes []*Request
+	for _, vote := range instance.votes.Votes {
+		if vote.ReplicaId != req.ReplicaId {
+			votes = append(votes, vote)
+		} else if !signed.After(voteTime(vote)) {
+			logger.Warningf("Replica %d ignoring reconfiguration vote of replica %d, which is older than its last vote", instance.id, req.ReplicaId)
+			return
+		}
+	}
+	instance.votes.Votes = append(votes, req)
+
+	count := 0
+	for _, vote := range instance.votes.Votes {
+		if instance.isReplica(vote.ReplicaId) && sameReplicas(vote.Membership.Replicas, m.Replicas) {
+			count++
+		}
+	}
+	logger.Infof("Replica %d counted %d of %d votes needed for validator set %v", instance.id, count, instance.intersectionQuorum(), m.Replicas)
+	if count >= instance.intersectionQuorum() {
+		instance.votes = &ReconfigurationVotes{Since: req.Timestamp}
+		instance.recvReconfiguration(seqNo, m)
+	}
+	instance.persistVotes()
+}
+
+// recvReconfiguration is called once a change of the validator set has been
+// ordered. The new membership takes effect at the first checkpoint boundary
+// at or after seqNo, until then the primary does not assign sequence numbers
+// beyond that checkpoint. The fault tolerance is always derived from the size
+// of the new set.
+func (instance *pbftCore) recvReconfiguration(seqNo uint64, m *Membership) {
+	m = newMembership(m.Replicas)
+	if err := validateMembership(m); err != nil {
+		logger.Warningf("Replica %d ignoring invalid reconfiguration at seqNo=%d: %s", instance.id, seqNo, err)
+		return
+	}
+
+	target := (seqNo + instance.K - 1) / instance.K * instance.K
+	if instance.reconfiguration != nil {
+		logger.Warningf("Replica %d replacing pending reconfiguration to %v with %v", instance.id, instance.reconfiguration.Membership.Replicas, m.Replicas)
+	}
+	logger.Infof("Replica %d will change the validator set to %v at checkpoint %d", instance.id, m.Replicas, target)
+	instance.reconfiguration = &Reconfiguration{
+		Membership:     m,
+		SequenceNumber: target,
+	}
+	instance.persistReconfiguration()
+}
+
+// applyReconfiguration switches to the pending membership once its
+// checkpoint has been executed. Members move to a new view whose primary is
+// chosen from the new validator set, replicas which have been removed stop
+// participating.
+func (instance *pbftCore) applyReconfiguration() {
+	reconf := instance.reconfiguration
+	instance.reconfiguration = nil
+	instance.persistReconfiguration()
+
+	instance.setMembership(reconf.Membership)
+	instance.persistMembership()
+	instance.consumer.updateReplicas(instance.replicas, instance.f)
+
+	if !instance.isReplica(instance.id) {
+		logger.Infof("Replica %d is no longer part of the validator set, it stops participating in consensus", instance.id)
+		instance.stopTimer()
+		instance.nullRequestTimer.Stop()
+		instance.activeView = false
+		instance.certStore = make(map[msgID]*msgCert)
+		instance.outstandingReqBatches = make(map[string]*RequestBatch)
+		return
+	}
+
+	if !instance.activeView {
+		// Faster replicas already moved on, the new validator set completes the pending view change
+		logger.Infof("Replica %d already changing to view %d after reconfiguration at seqNo=%d", instance.id, instance.view, reconf.SequenceNumber)
+		return
+	}
+	logger.Infof("Replica %d changing view after reconfiguration at seqNo=%d", instance.id, reconf.SequenceNumber)
+	instance.sendViewChange()
+}
+
+// refreshMembership adopts the membership recorded with the last block,
+// used after state transfer skipped over the execution of a reconfiguration
+func (instance *pbftCore) refreshMembership() {
+	meta, err := instance.consumer.getLastMetadata()
+	if err != nil {
+		logger.Warningf("Replica %d could not read membership after state transfer: %s", instance.id, err)
+		return
+	}
+	m, reconf := meta.Membership, meta.Reconfiguration
+	if reconf != nil && reconf.SequenceNumber <= instance.lastExec {
+		m, reconf = reconf.Membership, nil
+	}
+
+	if !reflect.DeepEqual(instance.reconfiguration, reconf) {
+		instance.reconfiguration = reconf
+		instance.persistReconfiguration()
+	}
+	if votes := meta.Votes; votes != nil && !reflect.DeepEqual(instance.votes, votes) {
+		instance.votes = votes
+		instance.persistVotes()
+	}
+
+	if m == nil || reflect.DeepEqual(m.Replicas, instance.replicas) && int(m.F) == instance.f {
+		return
+	}
+	logger.Infof("Replica %d validator set changed while it was catching up", instance.id)
+	instance.setMembership(m)
+	instance.persistMembership()
+	instance.consumer.updateReplicas(instance.replicas, instance.f)
+}
@@ -0,0 +1,307 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbft
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric/consensus"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/spf13/viper"
+)
+
+func TestValidateMembership(t *testing.T) {
+	for _, m := range []*Membership{
+		nil,
+		&Membership{},
+		&Membership{Replicas: []uint64{0, 1, 1, 2}},
+		&Membership{Replicas: []uint64{0, 1, 2}, F: 1},
+	} {
+		if err := validateMembership(m); err == nil {
+			t.Errorf("Expected membership %v to be rejected", m)
+		}
+	}
+
+	m := newMembership([]uint64{3, 0, 6, 1, 2, 4, 5})
+	if m.F != 2 {
+		t.Errorf("Expected a validator set of 7 to tolerate 2 faults, got %d", m.F)
+	}
+	if err := validateMembership(m); err != nil {
+		t.Errorf("Expected membership %v to be valid: %s", m, err)
+	}
+}
+
+func TestReconfigurationPersisted(t *testing.T) {
+	mock := &omniProto{}
+	persist := &mockPersist{}
+	mock.ReadStateImpl = persist.ReadState
+	mock.ReadStateSetImpl = persist.ReadStateSet
+	mock.StoreStateImpl = persist.StoreState
+	mock.DelStateImpl = persist.DelState
+
+	config := loadConfig()
+	config.Set("general.K", 2)
+	instance := newPbftCore(0, config, mock, &inertTimerFactory{})
+	instance.recvReconfiguration(3, newMembership([]uint64{0, 1, 2, 3, 5}))
+	if instance.reconfiguration == nil || instance.reconfiguration.SequenceNumber != 4 {
+		t.Fatalf("Expected the reconfiguration to take effect at checkpoint 4, got %v", instance.reconfiguration)
+	}
+	if instance.N != 4 {
+		t.Fatalf("Expected the validator set to remain unchanged until checkpoint 4, N is %d", instance.N)
+	}
+	instance.close()
+
+	restarted := newPbftCore(0, config, mock, &inertTimerFactory{})
+	defer restarted.close()
+	if !reflect.DeepEqual(restarted.reconfiguration, instance.reconfiguration) {
+		t.Fatalf("Expected pending reconfiguration %v to be restored, got %v", instance.reconfiguration, restarted.reconfiguration)
+	}
+
+	var replicas []uint64
+	mock.updateReplicasImpl = func(r []uint64, f int) { replicas = r }
+	mock.broadcastImpl = func(msgPayload []byte) {}
+	mock.signImpl = func(msg []byte) ([]byte, error) { return msg, nil }
+	mock.verifyImpl = func(senderID uint64, signature []byte, message []byte) error { return nil }
+	restarted.lastExec = 4
+	restarted.applyReconfiguration()
+	if restarted.N != 5 || restarted.f != 1 || !reflect.DeepEqual(replicas, []uint64{0, 1, 2, 3, 5}) {
+		t.Fatalf("Expected validator set [0 1 2 3 5] with f=1, got %v with f=%d (consumer told %v)", restarted.replicas, restarted.f, replicas)
+	}
+	if restarted.activeView || restarted.view != 1 || restarted.primary(restarted.view) != 1 {
+		t.Fatalf("Expected a view change to view 1 after the reconfiguration, active=%v view=%d", restarted.activeView, restarted.view)
+	}
+	if restarted.primary(4) != 5 {
+		t.Errorf("Expected replica 5 to be primary of view 4, got %d", restarted.primary(4))
+	}
+
+	again := newPbftCore(0, config, mock, &inertTimerFactory{})
+	defer again.close()
+	if again.reconfiguration != nil || !reflect.DeepEqual(again.replicas, []uint64{0, 1, 2, 3, 5}) {
+		t.Fatalf("Expected the applied membership to be restored, got %v (pending %v)", again.replicas, again.reconfiguration)
+	}
+}
+
+func TestReconfigurationVotes(t *testing.T) {
+	mock := &omniProto{}
+	persist := &mockPersist{}
+	mock.ReadStateImpl = persist.ReadState
+	mock.ReadStateSetImpl = persist.ReadStateSet
+	mock.StoreStateImpl = persist.StoreState
+	mock.DelStateImpl = persist.DelState
+	mock.verifyImpl = func(senderID uint64, signature []byte, message []byte) error {
+		if string(signature) != "signed" {
+			return fmt.Errorf("bad signature")
+		}
+		return nil
+	}
+
+	instance := newPbftCore(0, loadConfig(), mock, &inertTimerFactory{})
+	defer instance.close()
+
+	grown := contiguousReplicas(7)
+	vote := func(id uint64, signedAt int64, replicas []uint64) *Request {
+		return &Request{
+			Timestamp:  &timestamp.Timestamp{Seconds: signedAt},
+			ReplicaId:  id,
+			Membership: &Membership{Replicas: replicas}, // the fault tolerance is never taken from the vote
+			Signature:  []byte("signed"),
+		}
+	}
+	recv := func(req *Request) {
+		instance.recvReconfigurationVote(1, req)
+		if instance.reconfiguration != nil {
+			t.Fatalf("Expected no reconfiguration without a quorum, got %v", instance.reconfiguration)
+		}
+	}
+
+	recv(vote(3, 1, []uint64{3}))
+	recv(vote(4, 1, grown))
+	forged := vote(1, 1, grown)
+	forged.Signature = []byte("forged")
+	recv(forged)
+	if len(instance.votes.Votes) != 1 {
+		t.Fatalf("Expected votes of non-members or with incorrect signatures to be ignored, got %v", instance.votes.Votes)
+	}
+
+	recv(vote(0, 2, grown))
+	recv(vote(1, 2, grown))
+	recv(vote(1, 2, grown))
+	recv(vote(2, 2, contiguousReplicas(5)))
+	recv(vote(2, 1, grown))
+	if len(instance.votes.Votes) != 4 {
+		t.Fatalf("Expected one vote per replica, got %v", instance.votes.Votes)
+	}
+
+	restarted := newPbftCore(0, loadConfig(), mock, &inertTimerFactory{})
+	defer restarted.close()
+	if !reflect.DeepEqual(restarted.votes, instance.votes) {
+		t.Fatalf("Expected votes %v to be restored, got %v", instance.votes, restarted.votes)
+	}
+
+	instance.recvReconfigurationVote(1, vote(2, 3, grown))
+	if reconf := instance.reconfiguration; reconf == nil || !reflect.DeepEqual(reconf.Membership, newMembership(grown)) {
+		t.Fatalf("Expected a quorum of votes to change the validator set to %v, got %v", newMembership(grown), reconf)
+	}
+	if len(instance.votes.Votes) != 0 {
+		t.Fatalf("Expected the votes to be cleared once the validator set is decided, got %v", instance.votes.Votes)
+	}
+
+	instance.reconfiguration = nil
+	for id := uint64(0); id < 3; id++ {
+		instance.recvReconfigurationVote(1, vote(id, 2, grown))
+	}
+	if instance.reconfiguration != nil || len(instance.votes.Votes) != 0 {
+		t.Fatalf("Expected votes signed before the validator set was decided to be ignored, got %v", instance.votes.Votes)
+	}
+}
+
+func TestReconfigurationIgnoresNonMembers(t *testing.T) {
+	mock := &omniProto{}
+	instance := newPbftCore(1, loadConfig(), mock, &inertTimerFactory{})
+	defer instance.close()
+
+	reqBatch := createPbftReqBatch(1, 4)
+	msg := &Message{Payload: &Message_Prepare{Prepare: &Prepare{
+		View:           0,
+		SequenceNumber: 1,
+		BatchDigest:    hash(reqBatch),
+		ReplicaId:      4,
+	}}}
+	if next, err := instance.recvMsg(msg, 4); next != nil || err == nil {
+		t.Fatalf("Expected message from replica 4 to be rejected by a network of 4")
+	}
+}
+
+func TestReconfigurationGrowAndShrink(t *testing.T) {
+	initialCount := 4
+	grownCount := 7
+	net := makeConsumerNetwork(grownCount, func(id uint64, config *viper.Viper, stack consensus.Stack) pbftConsumer {
+		// Joining replicas are started with the validator set they join,
+		// the running ones only learn about it through the reconfiguration
+		if id < uint64(initialCount) {
+			config.Set("general.N", initialCount)
+			config.Set("general.f", (initialCount-1)/3)
+		}
+		config.Set("general.batchsize", 1)
+		return newObcBatch(id, config, stack)
+	}, func(ce *consumerEndpoint) {
+		ce.consumer.(*obcBatch).pbft.K = 2
+		ce.consumer.(*obcBatch).pbft.L = 4
+	})
+	defer net.Stop()
+
+	obc := func(id int) *obcBatch {
+		return net.Endpoints[id].(*consumerEndpoint).consumer.(*obcBatch)
+	}
+	broadcaster := net.Endpoints[generateBroadcaster(initialCount)].GetHandle()
+	checkChains := func(members []uint64, height uint64) {
+		for _, id := range members {
+			if size := obc(int(id)).stack.GetBlockchainSize(); size != height {
+				t.Fatalf("Replica %d has a blockchain of height %d, expected %d", id, size, height)
+			}
+			block, _ := obc(int(id)).stack.GetBlock(height - 1)
+			expected, _ := obc(0).stack.GetBlock(height - 1)
+			if !proto.Equal(block, expected) {
+				t.Fatalf("Replica %d has a different block at height %d than replica 0", id, height-1)
+			}
+		}
+	}
+	checkActive := func(members []uint64) {
+		view := obc(int(members[0])).pbft.view
+		for _, id := range members {
+			if pbft := obc(int(id)).pbft; !pbft.activeView || pbft.view != view {
+				t.Errorf("Replica %d should be active in view %d, is %v in view %d", id, view, pbft.activeView, pbft.view)
+			}
+		}
+	}
+	checkMembership := func(ids []uint64, members []uint64, f int) {
+		for _, id := range ids {
+			pbft := obc(int(id)).pbft
+			if !reflect.DeepEqual(pbft.replicas, members) || pbft.f != f || pbft.reconfiguration != nil {
+				t.Fatalf("Replica %d has validator set %v with f=%d (pending %v), expected %v with f=%d", id, pbft.replicas, pbft.f, pbft.reconfiguration, members, f)
+			}
+		}
+	}
+
+	initial := contiguousReplicas(initialCount)
+	grown := contiguousReplicas(grownCount)
+	joining := grown[initialCount:]
+	vote := func(voters []uint64, replicas []uint64) {
+		for _, id := range voters {
+			// Votes are submitted the way the admin service of the peer does
+			reconfigurer, ok := net.Endpoints[id].(*consumerEndpoint).consumer.(consensus.Reconfigurer)
+			if !ok {
+				t.Fatalf("Replica %d does not implement consensus.Reconfigurer", id)
+			}
+			if err := reconfigurer.Reconfigure(replicas); err != nil {
+				t.Fatalf("Could not submit reconfiguration: %s", err)
+			}
+			net.Process()
+		}
+	}
+
+	obc(1).RecvMsg(createTxMsg(1), broadcaster)
+	net.Process()
+	checkChains(initial, 2)
+
+	// A quorum of 3 out of 4 replicas has to agree
+	vote([]uint64{1, 2}, grown)
+	if pbft := obc(0).pbft; pbft.reconfiguration != nil || pbft.N != initialCount {
+		t.Fatalf("Expected 2 votes not to change the validator set, got %v (pending %v)", pbft.replicas, pbft.reconfiguration)
+	}
+	vote([]uint64{3}, grown)
+	checkMembership(grown, grown, 2)
+	height := obc(0).stack.GetBlockchainSize()
+	checkChains(grown, height)
+
+	for n := int64(2); n <= 4; n++ {
+		obc(5).RecvMsg(createTxMsg(n), broadcaster)
+		net.Process()
+	}
+	checkChains(grown, height+3)
+	checkActive(grown)
+
+	// A quorum of 5 out of 7 replicas has to agree
+	vote([]uint64{6, 5, 4, 1, 2}, initial)
+	// The validator set changes at the next checkpoint, keep ordering
+	// requests until it has been reached
+	n := int64(5)
+	for ; obc(0).pbft.reconfiguration != nil; n++ {
+		if n > 10 {
+			t.Fatalf("Reconfiguration did not take effect, pending %v", obc(0).pbft.reconfiguration)
+		}
+		obc(6).RecvMsg(createTxMsg(n), broadcaster)
+		net.Process()
+	}
+	checkMembership(grown, initial, 1)
+	height = obc(0).stack.GetBlockchainSize()
+	checkChains(grown, height)
+
+	obc(2).RecvMsg(createTxMsg(n), broadcaster)
+	net.Process()
+	checkChains(initial, height+1)
+	for _, id := range joining {
+		if size := obc(int(id)).stack.GetBlockchainSize(); size != height {
+			t.Errorf("Replica %d left the validator set, but its blockchain grew to height %d", id, size)
+		}
+	}
+	checkActive(initial)
+}
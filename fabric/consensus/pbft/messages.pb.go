@@ -22,6 +22,9 @@ It has these top-level messages:
 	FetchRequestBatch
 	RequestBatch
 	BatchMessage
+	Membership
+	Reconfiguration
+	ReconfigurationVotes
 	Metadata
 */
 package pbft
@@ -381,10 +384,11 @@ func _Message_OneofSizer(msg proto.Message) (n int) {
 }
 
 type Request struct {
-	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
-	Payload   []byte                     `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
-	ReplicaId uint64                     `protobuf:"varint,3,opt,name=replica_id,json=replicaId" json:"replica_id,omitempty"`
-	Signature []byte                     `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Timestamp  *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=timestamp" json:"timestamp,omitempty"`
+	Payload    []byte                     `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	ReplicaId  uint64                     `protobuf:"varint,3,opt,name=replica_id,json=replicaId" json:"replica_id,omitempty"`
+	Signature  []byte                     `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Membership *Membership                `protobuf:"bytes,5,opt,name=membership" json:"membership,omitempty"`
 }
 
 func (m *Request) Reset()                    { *m = Request{} }
@@ -399,6 +403,13 @@ func (m *Request) GetTimestamp() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *Request) GetMembership() *Membership {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
+}
+
 type PrePrepare struct {
 	View           uint64        `protobuf:"varint,1,opt,name=view" json:"view,omitempty"`
 	SequenceNumber uint64        `protobuf:"varint,2,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
@@ -769,14 +780,89 @@ func _BatchMessage_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+type Membership struct {
+	Replicas []uint64 `protobuf:"varint,1,rep,packed,name=replicas" json:"replicas,omitempty"`
+	F        uint64   `protobuf:"varint,2,opt,name=f" json:"f,omitempty"`
+}
+
+func (m *Membership) Reset()                    { *m = Membership{} }
+func (m *Membership) String() string            { return proto.CompactTextString(m) }
+func (*Membership) ProtoMessage()               {}
+func (*Membership) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+type Reconfiguration struct {
+	Membership     *Membership `protobuf:"bytes,1,opt,name=membership" json:"membership,omitempty"`
+	SequenceNumber uint64      `protobuf:"varint,2,opt,name=sequence_number,json=sequenceNumber" json:"sequence_number,omitempty"`
+}
+
+func (m *Reconfiguration) Reset()                    { *m = Reconfiguration{} }
+func (m *Reconfiguration) String() string            { return proto.CompactTextString(m) }
+func (*Reconfiguration) ProtoMessage()               {}
+func (*Reconfiguration) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *Reconfiguration) GetMembership() *Membership {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
+}
+
+type ReconfigurationVotes struct {
+	Since *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=since" json:"since,omitempty"`
+	Votes []*Request                 `protobuf:"bytes,2,rep,name=votes" json:"votes,omitempty"`
+}
+
+func (m *ReconfigurationVotes) Reset()                    { *m = ReconfigurationVotes{} }
+func (m *ReconfigurationVotes) String() string            { return proto.CompactTextString(m) }
+func (*ReconfigurationVotes) ProtoMessage()               {}
+func (*ReconfigurationVotes) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *ReconfigurationVotes) GetSince() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Since
+	}
+	return nil
+}
+
+func (m *ReconfigurationVotes) GetVotes() []*Request {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
+
 type Metadata struct {
-	SeqNo uint64 `protobuf:"varint,1,opt,name=seqNo" json:"seqNo,omitempty"`
+	SeqNo           uint64                `protobuf:"varint,1,opt,name=seqNo" json:"seqNo,omitempty"`
+	Membership      *Membership           `protobuf:"bytes,2,opt,name=membership" json:"membership,omitempty"`
+	Reconfiguration *Reconfiguration      `protobuf:"bytes,3,opt,name=reconfiguration" json:"reconfiguration,omitempty"`
+	Votes           *ReconfigurationVotes `protobuf:"bytes,4,opt,name=votes" json:"votes,omitempty"`
 }
 
 func (m *Metadata) Reset()                    { *m = Metadata{} }
 func (m *Metadata) String() string            { return proto.CompactTextString(m) }
 func (*Metadata) ProtoMessage()               {}
-func (*Metadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*Metadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *Metadata) GetMembership() *Membership {
+	if m != nil {
+		return m.Membership
+	}
+	return nil
+}
+
+func (m *Metadata) GetReconfiguration() *Reconfiguration {
+	if m != nil {
+		return m.Reconfiguration
+	}
+	return nil
+}
+
+func (m *Metadata) GetVotes() *ReconfigurationVotes {
+	if m != nil {
+		return m.Votes
+	}
+	return nil
+}
 
 func init() {
 	proto.RegisterType((*Message)(nil), "pbft.message")
@@ -794,64 +880,75 @@ func init() {
 	proto.RegisterType((*FetchRequestBatch)(nil), "pbft.fetch_request_batch")
 	proto.RegisterType((*RequestBatch)(nil), "pbft.request_batch")
 	proto.RegisterType((*BatchMessage)(nil), "pbft.batch_message")
+	proto.RegisterType((*Membership)(nil), "pbft.membership")
+	proto.RegisterType((*Reconfiguration)(nil), "pbft.reconfiguration")
+	proto.RegisterType((*ReconfigurationVotes)(nil), "pbft.reconfiguration_votes")
 	proto.RegisterType((*Metadata)(nil), "pbft.metadata")
 }
 
 func init() { proto.RegisterFile("messages.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 848 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
-	0x10, 0xee, 0x52, 0x94, 0x64, 0x8e, 0x68, 0xd5, 0x5e, 0xfb, 0xc0, 0x0a, 0x35, 0xea, 0xd2, 0xa8,
-	0x6b, 0xa3, 0x2d, 0x0d, 0xb8, 0x06, 0x6a, 0x18, 0x3d, 0xd9, 0x2d, 0xea, 0xa2, 0xa8, 0x21, 0x13,
-	0x41, 0x92, 0x1b, 0x41, 0x51, 0x2b, 0x89, 0xb0, 0x44, 0xd2, 0x14, 0xe5, 0xc4, 0x2f, 0x90, 0xe4,
-	0x92, 0x17, 0xc8, 0x03, 0xe5, 0x92, 0x73, 0xde, 0x27, 0xbb, 0xc3, 0xa5, 0xf8, 0x23, 0x45, 0xf6,
-	0x25, 0xc8, 0x81, 0xc0, 0xee, 0xcc, 0xf7, 0x0d, 0x67, 0xbf, 0x9d, 0xdd, 0x59, 0x68, 0x4f, 0xd8,
-	0x74, 0xea, 0x0e, 0xd9, 0xd4, 0x8a, 0xe2, 0x30, 0x09, 0xa9, 0x1a, 0xf5, 0x06, 0x49, 0xe7, 0x87,
-	0x61, 0x18, 0x0e, 0xc7, 0xec, 0x08, 0x6d, 0xbd, 0xd9, 0xe0, 0x28, 0xf1, 0x39, 0x2e, 0x71, 0x27,
-	0x51, 0x0a, 0x33, 0x5f, 0xa9, 0xd0, 0x94, 0x4c, 0x7a, 0x06, 0xeb, 0x31, 0xbb, 0x9d, 0x71, 0xbf,
-	0xd3, 0x73, 0x13, 0x6f, 0x64, 0x90, 0x5d, 0x72, 0xd0, 0x3a, 0xde, 0xb2, 0x44, 0x28, 0xab, 0xe4,
-	0xba, 0xfc, 0xc6, 0xd6, 0xa5, 0xe1, 0x5c, 0xcc, 0xe9, 0x09, 0xb4, 0xa2, 0x98, 0x39, 0xfc, 0x8b,
-	0xdc, 0x98, 0x19, 0x0a, 0x32, 0x37, 0x53, 0x66, 0xc1, 0xc1, 0x79, 0xc0, 0x87, 0xdd, 0x74, 0x46,
-	0x0f, 0xa1, 0x99, 0x31, 0x6a, 0xc8, 0x58, 0x9f, 0x33, 0x24, 0x3a, 0xf3, 0xd3, 0x7d, 0x68, 0x78,
-	0xe1, 0x64, 0xe2, 0x27, 0x86, 0x8a, 0x48, 0x3d, 0x45, 0xa6, 0x36, 0x0e, 0x94, 0x5e, 0x7a, 0x0c,
-	0xe0, 0x8d, 0x98, 0x77, 0x13, 0x85, 0x7e, 0x90, 0x18, 0x75, 0xc4, 0x6e, 0x48, 0xec, 0xdc, 0x2e,
-	0xd2, 0xc8, 0x67, 0x22, 0xf9, 0x3b, 0x9f, 0xbd, 0x70, 0xbc, 0x91, 0x1b, 0x0c, 0x99, 0xd1, 0x28,
-	0x26, 0x5f, 0x70, 0x08, 0x96, 0x98, 0x5e, 0xe0, 0x8c, 0xfe, 0x02, 0x6b, 0x01, 0xf7, 0x09, 0x8b,
-	0xd1, 0x44, 0x4a, 0x3b, 0xa5, 0x64, 0x56, 0x91, 0x3e, 0x1f, 0x3f, 0xe5, 0x43, 0xfa, 0x1f, 0x6c,
-	0x0d, 0x18, 0x17, 0xca, 0x29, 0x2b, 0xbc, 0x86, 0xbc, 0xef, 0x52, 0xde, 0x12, 0x00, 0x0f, 0xb1,
-	0x89, 0x66, 0xbb, 0x28, 0xf6, 0x3f, 0xb0, 0x1d, 0xb3, 0x64, 0x16, 0x07, 0x95, 0x68, 0xda, 0xaa,
-	0xfd, 0xa2, 0x29, 0xa5, 0x18, 0xe8, 0x5c, 0xe3, 0xfa, 0xbb, 0xf7, 0xe3, 0xd0, 0xed, 0x9b, 0xef,
-	0x08, 0x34, 0x25, 0x85, 0x9e, 0x82, 0x36, 0xaf, 0x13, 0x59, 0x04, 0x1d, 0x2b, 0xad, 0x24, 0x2b,
-	0xab, 0x24, 0xeb, 0x49, 0x86, 0xb0, 0x73, 0x30, 0x35, 0xe6, 0x01, 0xb1, 0x04, 0x74, 0x3b, 0x9b,
-	0xd2, 0x1d, 0x00, 0xbe, 0x93, 0x63, 0xdf, 0x73, 0x1d, 0xbf, 0x8f, 0xbb, 0xad, 0xda, 0x9a, 0xb4,
-	0xfc, 0xdb, 0xa7, 0xdf, 0x83, 0x36, 0xf5, 0x87, 0x81, 0xcb, 0x53, 0x64, 0xb8, 0xc3, 0xba, 0x9d,
-	0x1b, 0xcc, 0xf7, 0xa4, 0x54, 0x5e, 0x94, 0x82, 0x8a, 0xb2, 0x13, 0x0c, 0x83, 0x63, 0xfa, 0x33,
-	0x7c, 0x3b, 0x15, 0xf9, 0x07, 0x1e, 0x73, 0x82, 0xd9, 0xa4, 0xc7, 0x62, 0x4c, 0x41, 0xb5, 0xdb,
-	0x99, 0xf9, 0x0a, 0xad, 0xf4, 0x47, 0xd0, 0x51, 0x13, 0xa7, 0xef, 0xf3, 0xe3, 0x92, 0x60, 0x2e,
-	0x9a, 0xdd, 0x42, 0xdb, 0x5f, 0x68, 0xe2, 0x02, 0x54, 0x4e, 0x82, 0xfa, 0x59, 0x65, 0x2b, 0xe7,
-	0xa0, 0xbc, 0xcc, 0x7a, 0x65, 0x99, 0xe6, 0x1b, 0x32, 0xaf, 0xf8, 0x2f, 0xbe, 0x88, 0x72, 0x2a,
-	0x6a, 0x35, 0x95, 0xd7, 0x24, 0x3b, 0x51, 0x5f, 0x3b, 0x93, 0x2b, 0x80, 0xde, 0x38, 0xf4, 0x6e,
-	0x1c, 0x3f, 0x18, 0x84, 0x18, 0x0f, 0x67, 0xf2, 0xaf, 0x69, 0x52, 0x2d, 0xb4, 0xc9, 0x5f, 0xee,
-	0x64, 0x84, 0x91, 0x3b, 0x1d, 0xc9, 0x42, 0xd3, 0xd0, 0x72, 0xc9, 0x0d, 0x66, 0xbf, 0x78, 0x05,
-	0x2c, 0x5b, 0x08, 0x59, 0xba, 0x90, 0x72, 0x96, 0x4a, 0xb5, 0x42, 0xdb, 0xa0, 0xc8, 0xc2, 0xd5,
-	0x6c, 0x3e, 0x32, 0xdf, 0xd6, 0x4a, 0xb7, 0xc6, 0x52, 0x11, 0x75, 0x20, 0x23, 0x19, 0x89, 0x8c,
-	0x78, 0x26, 0xaa, 0x37, 0x65, 0x42, 0xa1, 0x5a, 0x5e, 0x4c, 0x85, 0x10, 0xd6, 0x85, 0x8d, 0x00,
-	0x7a, 0x00, 0x6a, 0x24, 0x80, 0x2a, 0x02, 0xb7, 0x17, 0x81, 0xdd, 0x6b, 0x1b, 0x11, 0x02, 0x79,
-	0x2b, 0x90, 0xf5, 0x55, 0x48, 0x81, 0xa8, 0xac, 0xae, 0xb1, 0xf2, 0xfc, 0x35, 0x2b, 0xe7, 0xaf,
-	0xf3, 0x27, 0x90, 0x8b, 0xc7, 0x0b, 0x59, 0x51, 0xaa, 0xd3, 0x07, 0xa5, 0x7b, 0xfd, 0x78, 0x7a,
-	0xb5, 0xa0, 0x94, 0xc5, 0x82, 0xca, 0xb4, 0xae, 0xe5, 0x5a, 0x9b, 0x47, 0x50, 0xef, 0x5e, 0x8b,
-	0x95, 0xee, 0x43, 0x4d, 0x48, 0x42, 0x56, 0x48, 0x22, 0x00, 0xe6, 0x07, 0x92, 0x5f, 0xe0, 0x4b,
-	0x77, 0xef, 0x27, 0x6e, 0x13, 0x91, 0x14, 0x8c, 0xb4, 0xd8, 0x0f, 0x6c, 0x74, 0xd3, 0x5f, 0x41,
-	0x7d, 0x99, 0x6f, 0xab, 0x51, 0xee, 0x01, 0xd6, 0x73, 0xee, 0xfa, 0x3b, 0x48, 0xe2, 0x7b, 0x1b,
-	0x51, 0x0f, 0x9c, 0x85, 0xce, 0x1f, 0xa0, 0xcd, 0x19, 0x74, 0x03, 0x6a, 0x37, 0xec, 0x5e, 0xe6,
-	0x24, 0x86, 0x74, 0x1b, 0xea, 0x77, 0xee, 0x78, 0xc6, 0xa4, 0x28, 0xe9, 0xe4, 0x4c, 0x39, 0x25,
-	0xe6, 0xb3, 0xa5, 0x0d, 0x66, 0x41, 0x4c, 0xf2, 0xd0, 0xe9, 0xac, 0xd6, 0xbd, 0x79, 0x52, 0xb9,
-	0x0b, 0xe9, 0x1e, 0xd4, 0xb3, 0xe7, 0x41, 0x2d, 0x6f, 0xd9, 0x12, 0x63, 0xa7, 0x3e, 0xf3, 0x23,
-	0x81, 0xf5, 0xf4, 0xc7, 0xd9, 0xeb, 0xe2, 0x70, 0xde, 0x5f, 0x64, 0x4b, 0x29, 0x13, 0x45, 0xb3,
-	0xcc, 0xfa, 0xcf, 0xc2, 0x43, 0x44, 0x79, 0xfc, 0x43, 0x64, 0x0f, 0x74, 0x81, 0xca, 0x7e, 0x8b,
-	0x25, 0xa2, 0x73, 0x54, 0x4b, 0x58, 0xff, 0x97, 0xb9, 0xfc, 0x06, 0x1a, 0xbf, 0xfa, 0xa2, 0xb1,
-	0x2b, 0xde, 0x08, 0xea, 0xf2, 0x6c, 0x72, 0x44, 0xb1, 0x4d, 0xee, 0xc2, 0xda, 0x84, 0x25, 0x6e,
-	0xdf, 0x4d, 0x5c, 0xb1, 0x19, 0xbc, 0x74, 0xaf, 0x42, 0xb9, 0x41, 0xe9, 0xa4, 0xd7, 0xc0, 0x0e,
-	0xf9, 0xfb, 0xa7, 0x00, 0x00, 0x00, 0xff, 0xff, 0x18, 0x21, 0x64, 0x8c, 0x91, 0x09, 0x00, 0x00,
+	// 976 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0x5f, 0x8f, 0xdb, 0x44,
+	0x10, 0x8f, 0x9d, 0xcd, 0xe5, 0x3c, 0xc9, 0x5d, 0xdb, 0xed, 0x55, 0x0a, 0x81, 0x8a, 0xe2, 0x8a,
+	0xfe, 0x11, 0x90, 0x2b, 0xa5, 0x82, 0x53, 0x85, 0x84, 0xd4, 0x03, 0x11, 0x84, 0x38, 0xdd, 0xad,
+	0x10, 0xf0, 0x66, 0x6d, 0x9c, 0x4d, 0x6c, 0x5d, 0x62, 0xfb, 0xbc, 0x9b, 0xbb, 0xde, 0x17, 0x00,
+	0x5e, 0xf8, 0x5a, 0xbc, 0xc0, 0x2b, 0xdf, 0x07, 0xed, 0x1f, 0xc7, 0xf6, 0xc6, 0x5c, 0xf3, 0x44,
+	0xdf, 0x3c, 0xb3, 0xbf, 0xdf, 0xee, 0xec, 0x6f, 0x66, 0x3c, 0x0b, 0xfb, 0x4b, 0xc6, 0x39, 0x9d,
+	0x33, 0x3e, 0xca, 0xf2, 0x54, 0xa4, 0x18, 0x65, 0x93, 0x99, 0x18, 0xbe, 0x3f, 0x4f, 0xd3, 0xf9,
+	0x82, 0x1d, 0x2a, 0xdf, 0x64, 0x35, 0x3b, 0x14, 0xf1, 0x92, 0x71, 0x41, 0x97, 0x99, 0x86, 0xf9,
+	0xbf, 0x22, 0xe8, 0x1a, 0x26, 0x7e, 0x09, 0x7b, 0x39, 0xbb, 0x58, 0x31, 0x2e, 0x82, 0x09, 0x15,
+	0x61, 0x34, 0x70, 0x1e, 0x38, 0x4f, 0x7a, 0xcf, 0xef, 0x8e, 0xe4, 0x56, 0xa3, 0xda, 0xd2, 0xb8,
+	0x45, 0xfa, 0xc6, 0xf1, 0x4a, 0xda, 0xf8, 0x05, 0xf4, 0xb2, 0x9c, 0x05, 0x59, 0xce, 0x32, 0x9a,
+	0xb3, 0x81, 0xab, 0x98, 0x77, 0x34, 0xb3, 0xb2, 0x30, 0x6e, 0x11, 0xc8, 0x72, 0x76, 0xaa, 0x2d,
+	0xfc, 0x14, 0xba, 0x05, 0xa3, 0xad, 0x18, 0x7b, 0x6b, 0x86, 0x41, 0x17, 0xeb, 0xf8, 0x11, 0xec,
+	0x84, 0xe9, 0x72, 0x19, 0x8b, 0x01, 0x52, 0xc8, 0xbe, 0x46, 0x6a, 0xdf, 0xb8, 0x45, 0xcc, 0x2a,
+	0x7e, 0x0e, 0x10, 0x46, 0x2c, 0x3c, 0xcf, 0xd2, 0x38, 0x11, 0x83, 0x8e, 0xc2, 0xde, 0x36, 0xd8,
+	0xb5, 0x5f, 0x86, 0x51, 0x5a, 0x32, 0xf8, 0xcb, 0x98, 0x5d, 0x05, 0x61, 0x44, 0x93, 0x39, 0x1b,
+	0xec, 0x54, 0x83, 0xaf, 0x2c, 0x48, 0x96, 0x34, 0x8f, 0x95, 0x85, 0x3f, 0x82, 0xdd, 0x84, 0x5d,
+	0x05, 0xd2, 0x33, 0xe8, 0x2a, 0xca, 0xbe, 0xa6, 0x14, 0x5e, 0x19, 0x7e, 0xc2, 0xae, 0x7e, 0x8a,
+	0xd9, 0x15, 0xfe, 0x1e, 0xee, 0xce, 0x98, 0x08, 0xa3, 0xa0, 0xae, 0xf0, 0xae, 0xe2, 0xbd, 0xa3,
+	0x79, 0x0d, 0x80, 0x71, 0x8b, 0xdc, 0x51, 0x6e, 0x52, 0x15, 0xfb, 0x5b, 0x38, 0xc8, 0x99, 0x58,
+	0xe5, 0x89, 0xb5, 0x9b, 0x77, 0x53, 0xbe, 0xb0, 0xa6, 0x54, 0x37, 0x7a, 0xe5, 0x41, 0x37, 0xa3,
+	0xd7, 0x8b, 0x94, 0x4e, 0xfd, 0xbf, 0x1d, 0xe8, 0x1a, 0x0a, 0x3e, 0x02, 0x6f, 0x5d, 0x27, 0xa6,
+	0x08, 0x86, 0x23, 0x5d, 0x49, 0xa3, 0xa2, 0x92, 0x46, 0x3f, 0x16, 0x08, 0x52, 0x82, 0xf1, 0x60,
+	0xbd, 0xa1, 0x2a, 0x81, 0x3e, 0x29, 0x4c, 0x7c, 0x1f, 0x20, 0x67, 0xd9, 0x22, 0x0e, 0x69, 0x10,
+	0x4f, 0x55, 0xb6, 0x11, 0xf1, 0x8c, 0xe7, 0xbb, 0x29, 0x7e, 0x0f, 0x3c, 0x1e, 0xcf, 0x13, 0x2a,
+	0x56, 0x39, 0x53, 0x19, 0xee, 0x93, 0xd2, 0x81, 0x9f, 0x01, 0x2c, 0xd9, 0x72, 0xc2, 0x72, 0x1e,
+	0xc5, 0x59, 0x3d, 0xa9, 0xa5, 0x9f, 0x54, 0x30, 0xfe, 0x9f, 0x4e, 0xad, 0x20, 0x31, 0x06, 0xa4,
+	0x12, 0xe5, 0xa8, 0x83, 0xd5, 0x37, 0x7e, 0x0c, 0xb7, 0xb8, 0xbc, 0x71, 0x12, 0xb2, 0x20, 0x59,
+	0x49, 0xaa, 0x0a, 0x1a, 0x91, 0xfd, 0xc2, 0x7d, 0xa2, 0xbc, 0xf8, 0x03, 0xe8, 0x2b, 0x15, 0x83,
+	0x69, 0x3c, 0x67, 0x5c, 0xa8, 0xe8, 0x3d, 0xd2, 0x53, 0xbe, 0xaf, 0x95, 0x0b, 0x1f, 0xd9, 0xbd,
+	0x83, 0xfe, 0x33, 0x17, 0x56, 0xe7, 0xd4, 0x85, 0xe9, 0x58, 0xc2, 0xf8, 0xbf, 0x3b, 0xd0, 0xfd,
+	0xbf, 0x2e, 0x51, 0x0f, 0x05, 0xd9, 0xa1, 0xfc, 0xe6, 0x14, 0x3d, 0xf8, 0xb6, 0x23, 0x39, 0x01,
+	0x98, 0x2c, 0xd2, 0xf0, 0x3c, 0x88, 0x93, 0x59, 0xaa, 0xf6, 0x53, 0x96, 0x39, 0x55, 0x07, 0xd5,
+	0x53, 0x3e, 0x73, 0xe4, 0xfd, 0x82, 0x10, 0x51, 0x1e, 0x99, 0xd2, 0xf4, 0x94, 0x67, 0x4c, 0x79,
+	0xe4, 0x4f, 0xab, 0x3f, 0x8d, 0xa6, 0x8b, 0x38, 0x8d, 0x17, 0xa9, 0x47, 0xe9, 0xda, 0x35, 0xbd,
+	0x0f, 0xae, 0x29, 0x75, 0x8f, 0xb8, 0xf1, 0xd4, 0xff, 0xa3, 0x5d, 0xfb, 0xcf, 0x34, 0x8a, 0xd8,
+	0x07, 0x27, 0x32, 0x3b, 0x39, 0x11, 0x7e, 0x0c, 0x28, 0xe4, 0x4c, 0x2a, 0xd4, 0x2e, 0x8b, 0xa9,
+	0xb2, 0xc5, 0xe8, 0x98, 0x28, 0x00, 0x7e, 0x02, 0x28, 0x93, 0x40, 0xa4, 0x80, 0x07, 0x9b, 0xc0,
+	0xd3, 0x33, 0x82, 0x32, 0x83, 0xbc, 0x90, 0xc8, 0xce, 0x4d, 0x48, 0x89, 0xb0, 0x6e, 0xb7, 0x73,
+	0x63, 0xc7, 0x76, 0xad, 0x8e, 0x1d, 0x7e, 0x09, 0xce, 0xf1, 0xf6, 0x42, 0x5a, 0x4a, 0x0d, 0xa7,
+	0xe0, 0x9e, 0x9e, 0x6d, 0x4f, 0xb7, 0x0b, 0xca, 0xdd, 0x2c, 0xa8, 0x42, 0xeb, 0x76, 0xa9, 0xb5,
+	0x7f, 0x08, 0x9d, 0xd3, 0x33, 0x79, 0xd3, 0x47, 0xd0, 0x96, 0x92, 0x38, 0x37, 0x48, 0x22, 0x01,
+	0xfe, 0x5f, 0x4e, 0xf9, 0xcb, 0x6f, 0xcc, 0xde, 0x87, 0x80, 0x2e, 0xe5, 0x4e, 0xee, 0x83, 0x76,
+	0xe3, 0x04, 0x21, 0x6a, 0x19, 0x7f, 0x0c, 0xe8, 0x75, 0x99, 0xd6, 0x41, 0x7d, 0x6a, 0x8c, 0x7e,
+	0xe1, 0x4c, 0x7c, 0x93, 0x88, 0xfc, 0x9a, 0xa0, 0xd7, 0x9b, 0x79, 0xb0, 0x7b, 0x61, 0xf8, 0x05,
+	0x78, 0x6b, 0x06, 0xbe, 0x0d, 0xed, 0x73, 0x76, 0x6d, 0x62, 0x92, 0x9f, 0xf8, 0x00, 0x3a, 0x97,
+	0x74, 0xb1, 0x62, 0x46, 0x14, 0x6d, 0xbc, 0x74, 0x8f, 0x1c, 0xff, 0xe7, 0xc6, 0x91, 0xb4, 0x21,
+	0xa6, 0xf3, 0xa6, 0xee, 0xb4, 0xeb, 0xde, 0x7f, 0x61, 0xfd, 0x0b, 0xf1, 0x43, 0xe8, 0x14, 0x0f,
+	0x8a, 0x76, 0x39, 0xe4, 0x0d, 0x86, 0xe8, 0x35, 0xff, 0x1f, 0x07, 0xf6, 0xf4, 0xc1, 0xc5, 0x7b,
+	0xe4, 0xe9, 0x7a, 0x22, 0x99, 0x21, 0x54, 0x27, 0xca, 0xf1, 0x6a, 0x3e, 0x37, 0x9f, 0x2e, 0xee,
+	0xf6, 0x4f, 0x97, 0x87, 0xd0, 0x97, 0xa8, 0xe2, 0x58, 0x55, 0x22, 0xfd, 0x71, 0x8b, 0xf4, 0xa4,
+	0xf7, 0x07, 0x13, 0xcb, 0x27, 0xe0, 0x85, 0xe9, 0x32, 0x5b, 0x50, 0xf9, 0xaa, 0x40, 0xcd, 0xd1,
+	0x94, 0x88, 0xea, 0x60, 0xfd, 0xbc, 0x3a, 0xbb, 0xf0, 0x10, 0x76, 0x8d, 0x50, 0x5c, 0xa9, 0x81,
+	0xc8, 0xda, 0x96, 0xbd, 0x3f, 0x2b, 0x7a, 0x7f, 0xe6, 0x2f, 0xe0, 0x56, 0xce, 0xc2, 0x34, 0x99,
+	0xc5, 0xf3, 0x55, 0x4e, 0x45, 0x9c, 0x26, 0xd6, 0x18, 0x74, 0xde, 0x3c, 0x06, 0xb7, 0xfe, 0x27,
+	0xfb, 0x09, 0xdc, 0xb3, 0x4e, 0x0b, 0x2e, 0x53, 0xc1, 0x38, 0x7e, 0x06, 0x1d, 0x1e, 0x27, 0x21,
+	0xdb, 0xe2, 0x1d, 0xa0, 0x81, 0x32, 0xdb, 0x8a, 0x6a, 0xba, 0xc0, 0xce, 0xb6, 0x5a, 0x93, 0xf3,
+	0x79, 0x77, 0xc9, 0x04, 0x9d, 0x52, 0x41, 0x65, 0x8d, 0x72, 0x76, 0x71, 0x92, 0x9a, 0xba, 0xd5,
+	0x86, 0x75, 0x5b, 0x77, 0x8b, 0xdb, 0x7e, 0xb5, 0x21, 0x99, 0x79, 0x56, 0xde, 0x2b, 0x62, 0xa8,
+	0x2d, 0x92, 0x0d, 0x81, 0x3f, 0x2d, 0x42, 0xd7, 0x19, 0x7e, 0xb7, 0x91, 0xa6, 0x85, 0x31, 0x17,
+	0x99, 0xec, 0x28, 0x21, 0x3e, 0xfb, 0x77, 0x00, 0xbf, 0x5e, 0x3b, 0x92, 0x80, 0x0b, 0x00, 0x00,
 }
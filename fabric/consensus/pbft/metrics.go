@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbft
+
+import (
+	"github.com/hyperledger/fabric/consensus/util/metrics"
+)
+
+// pbftMetrics are published by a replica so a stalled network can be
+// diagnosed without its logs. The consensus_ metrics without the pbft_
+// prefix are reported by noops as well.
+type pbftMetrics struct {
+	registry *metrics.Registry
+
+	// pbftCore
+	view                 *metrics.Gauge
+	activeView           *metrics.Gauge
+	seqNo                *metrics.Gauge
+	lastExec             *metrics.Gauge
+	lowWatermark         *metrics.Gauge
+	highWatermark        *metrics.Gauge
+	lastStableCheckpoint *metrics.Gauge
+	lastCheckpoint       *metrics.Gauge
+	replicas             *metrics.Gauge
+	faults               *metrics.Gauge
+	viewChanges          *metrics.Counter
+	messageLatency       *metrics.Histogram
+
+	// obcBatch
+	outstandingRequests *metrics.Gauge
+	pendingRequests     *metrics.Gauge
+	batchSize           *metrics.Histogram
+}
+
+func newPbftMetrics() *pbftMetrics {
+	r := metrics.NewRegistry()
+	return &pbftMetrics{
+		registry: r,
+
+		view:                 r.NewGauge("consensus_pbft_view", "Current view of the replica."),
+		activeView:           r.NewGauge("consensus_pbft_active_view", "1 if the replica is participating in the current view, 0 during a view change."),
+		seqNo:                r.NewGauge("consensus_pbft_assigned_sequence_number", "Highest sequence number the replica has seen assigned to a request batch."),
+		lastExec:             r.NewGauge("consensus_sequence_number", "Sequence number of the last executed request batch."),
+		lowWatermark:         r.NewGauge("consensus_pbft_low_watermark", "Low watermark, sequence numbers at or below it are no longer accepted."),
+		highWatermark:        r.NewGauge("consensus_pbft_high_watermark", "High watermark, sequence numbers above it are not yet accepted."),
+		lastStableCheckpoint: r.NewGauge("consensus_pbft_last_stable_checkpoint", "Sequence number of the last checkpoint a quorum of replicas agreed on."),
+		lastCheckpoint:       r.NewGauge("consensus_pbft_last_checkpoint", "Sequence number of the last checkpoint taken by the replica, stable or not."),
+		replicas:             r.NewGauge("consensus_pbft_replicas", "Number of replicas in the validator set (N)."),
+		faults:               r.NewGauge("consensus_pbft_faults_tolerated", "Number of byzantine replicas tolerated (f)."),
+		viewChanges:          r.NewCounter("consensus_pbft_view_changes_total", "View changes started by the replica."),
+		messageLatency:       r.NewHistogram("consensus_pbft_message_latency_seconds", "Time from sending to receiving a consensus message, by sending replica. Includes the clock skew between the replicas.", metrics.DefaultBuckets, "replica"),
+
+		outstandingRequests: r.NewGauge("consensus_outstanding_requests", "Requests received but not yet executed."),
+		pendingRequests:     r.NewGauge("consensus_pbft_pending_requests", "Outstanding requests which are part of a request batch being ordered."),
+		batchSize:           r.NewHistogram("consensus_batch_size", "Number of requests in the executed batches.", metrics.BatchSizeBuckets),
+	}
+}
+
+// updateMetrics publishes the state of the replica, it must be called from
+// the event thread
+func (instance *pbftCore) updateMetrics() {
+	m := instance.metrics
+	m.view.Set(float64(instance.view))
+	if instance.activeView {
+		m.activeView.Set(1)
+	} else {
+		m.activeView.Set(0)
+	}
+	seqNo := instance.seqNo
+	for idx, cert := range instance.certStore {
+		if cert.prePrepare != nil && idx.n > seqNo {
+			seqNo = idx.n
+		}
+	}
+	m.seqNo.Set(float64(seqNo))
+	m.lastExec.Set(float64(instance.lastExec))
+	m.lowWatermark.Set(float64(instance.h))
+	m.highWatermark.Set(float64(instance.h + instance.L))
+	m.lastStableCheckpoint.Set(float64(instance.h))
+	var lastCheckpoint uint64
+	for n := range instance.chkpts {
+		if n > lastCheckpoint {
+			lastCheckpoint = n
+		}
+	}
+	m.lastCheckpoint.Set(float64(lastCheckpoint))
+	m.replicas.Set(float64(instance.N))
+	m.faults.Set(float64(instance.f))
+}
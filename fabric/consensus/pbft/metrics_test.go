@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbft
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric/consensus/util/metrics"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func sampleValue(registry *metrics.Registry, name string, labels map[string]string) (float64, bool) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for _, sample := range registry.Samples() {
+		if sample.Name == name && reflect.DeepEqual(sample.Labels, labels) {
+			return sample.Value, true
+		}
+	}
+	return 0, false
+}
+
+func TestMetricsPublished(t *testing.T) {
+	batchSize := 2
+	validatorCount := 4
+	net := makeConsumerNetwork(validatorCount, obcBatchHelper, func(ce *consumerEndpoint) {
+		ce.consumer.(*obcBatch).batchSize = batchSize
+	})
+	defer net.Stop()
+
+	broadcaster := net.Endpoints[generateBroadcaster(validatorCount)].GetHandle()
+	net.Endpoints[1].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(1), broadcaster)
+	net.Endpoints[2].(*consumerEndpoint).consumer.RecvMsg(createTxMsg(2), broadcaster)
+	net.Process()
+
+	for _, ep := range net.Endpoints {
+		ce := ep.(*consumerEndpoint)
+		registry := ce.consumer.(*obcBatch).Metrics()
+		for name, expected := range map[string]float64{
+			"consensus_sequence_number":               1,
+			"consensus_pbft_assigned_sequence_number": 1,
+			"consensus_outstanding_requests":          0,
+			"consensus_pbft_pending_requests":         0,
+			"consensus_batch_size_count":              1,
+			"consensus_batch_size_sum":                float64(batchSize),
+			"consensus_pbft_view":                     0,
+			"consensus_pbft_active_view":              1,
+			"consensus_pbft_low_watermark":            0,
+			"consensus_pbft_replicas":                 float64(validatorCount),
+			"consensus_pbft_faults_tolerated":         1,
+			"consensus_pbft_view_changes_total":       0,
+		} {
+			if value, ok := sampleValue(registry, name, nil); !ok || value != expected {
+				t.Errorf("Replica %d expected %s to be %v, got %v (published %v)", ce.ID, name, expected, value, ok)
+			}
+		}
+	}
+
+	// The mock network only delivers payloads, hand the replica a message as it is sent
+	backup := net.Endpoints[1].(*consumerEndpoint).consumer.(*obcBatch)
+	payload, _ := proto.Marshal(&Message{Payload: &Message_Prepare{Prepare: &Prepare{ReplicaId: 2}}})
+	backup.processMessage(backup.wrapMessage(payload), net.Endpoints[2].GetHandle())
+	if count, _ := sampleValue(backup.Metrics(), "consensus_pbft_message_latency_seconds_count", map[string]string{"replica": "2"}); count != 1 {
+		t.Errorf("Expected replica 1 to measure the latency of the message from replica 2")
+	}
+}
+
+func TestMetricsCountViewChanges(t *testing.T) {
+	mock := &omniProto{
+		signImpl:      func(msg []byte) ([]byte, error) { return msg, nil },
+		verifyImpl:    func(senderID uint64, signature []byte, message []byte) error { return nil },
+		broadcastImpl: func(msgPayload []byte) {},
+	}
+	instance := newPbftCore(1, loadConfig(), mock, &inertTimerFactory{})
+	defer instance.close()
+
+	instance.sendViewChange()
+	instance.updateMetrics()
+
+	registry := instance.metrics.registry
+	if value, _ := sampleValue(registry, "consensus_pbft_view_changes_total", nil); value != 1 {
+		t.Errorf("Expected one view change to be counted, got %v", value)
+	}
+	if value, _ := sampleValue(registry, "consensus_pbft_view", nil); value != 1 {
+		t.Errorf("Expected view 1 to be published, got %v", value)
+	}
+	if value, _ := sampleValue(registry, "consensus_pbft_active_view", nil); value != 0 {
+		t.Errorf("Expected the view to be published as inactive during the view change, got %v", value)
+	}
+}
@@ -22,38 +22,39 @@ import (
 
 	"github.com/hyperledger/fabric/consensus"
 	"github.com/hyperledger/fabric/consensus/util/events"
+	"github.com/hyperledger/fabric/consensus/util/testnet"
 	pb "github.com/hyperledger/fabric/protos"
 
 	"github.com/spf13/viper"
 )
 
 type consumerEndpoint struct {
-	*testEndpoint
+	*testnet.TestEndpoint
 	consumer     pbftConsumer
 	execTxResult func([]*pb.Transaction) ([]byte, error)
 }
 
-func (ce *consumerEndpoint) stop() {
+func (ce *consumerEndpoint) Stop() {
 	ce.consumer.Close()
 }
 
-func (ce *consumerEndpoint) isBusy() bool {
+func (ce *consumerEndpoint) IsBusy() bool {
 	pbft := ce.consumer.getPBFTCore()
 	if pbft.timerActive || pbft.skipInProgress || pbft.currentExec != nil {
-		ce.net.debugMsg("Reporting busy because of timer (%v) or skipInProgress (%v) or currentExec (%v)\n", pbft.timerActive, pbft.skipInProgress, pbft.currentExec)
+		ce.Net.DebugMsg("Reporting busy because of timer (%v) or skipInProgress (%v) or currentExec (%v)\n", pbft.timerActive, pbft.skipInProgress, pbft.currentExec)
 		return true
 	}
 
 	select {
 	case <-ce.consumer.idleChannel():
 	default:
-		ce.net.debugMsg("Reporting busy because consumer not idle\n")
+		ce.Net.DebugMsg("Reporting busy because consumer not idle\n")
 		return true
 	}
 
 	select {
 	case ce.consumer.getManager().Queue() <- nil:
-		ce.net.debugMsg("Reporting busy because pbft not idle\n")
+		ce.Net.DebugMsg("Reporting busy because pbft not idle\n")
 	default:
 		return true
 	}
@@ -62,7 +63,7 @@ func (ce *consumerEndpoint) isBusy() bool {
 	return false
 }
 
-func (ce *consumerEndpoint) deliver(msg []byte, senderHandle *pb.PeerID) {
+func (ce *consumerEndpoint) Deliver(msg []byte, senderHandle *pb.PeerID) {
 	ce.consumer.RecvMsg(&pb.Message{Type: pb.Message_CONSENSUS, Payload: msg}, senderHandle)
 }
 
@@ -93,7 +94,7 @@ func (cs *completeStack) UpdateState(tag interface{}, target *pb.BlockchainInfo,
 			<-cs.skipTarget // Basically like releasing a mutex
 		}()
 	default:
-		cs.net.debugMsg("Ignoring skipTo because one is already in progress\n")
+		cs.Net.DebugMsg("Ignoring skipTo because one is already in progress\n")
 	}
 }
 
@@ -107,7 +108,7 @@ type pbftConsumer interface {
 }
 
 type consumerNetwork struct {
-	*testnet
+	*testnet.Network
 	mockLedgers []*MockLedger
 }
 
@@ -122,10 +123,10 @@ func (cnet *consumerNetwork) GetLedgerByPeerID(peerID *pb.PeerID) (consensus.Rea
 func makeConsumerNetwork(N int, makeConsumer func(id uint64, config *viper.Viper, stack consensus.Stack) pbftConsumer, initFNs ...func(*consumerEndpoint)) *consumerNetwork {
 	twl := consumerNetwork{mockLedgers: make([]*MockLedger, N)}
 
-	endpointFunc := func(id uint64, net *testnet) endpoint {
-		tep := makeTestEndpoint(id, net)
+	endpointFunc := func(id uint64, net *testnet.Network) testnet.Endpoint {
+		tep := testnet.NewTestEndpoint(id, net)
 		ce := &consumerEndpoint{
-			testEndpoint: tep,
+			TestEndpoint: tep,
 		}
 
 		ml := NewMockLedger(&twl)
@@ -139,9 +140,10 @@ func makeConsumerNetwork(N int, makeConsumer func(id uint64, config *viper.Viper
 			skipTarget:       make(chan struct{}, 1),
 		}
 
-		ce.consumer = makeConsumer(id, loadConfig(), cs)
-		ce.consumer.getPBFTCore().N = N
-		ce.consumer.getPBFTCore().f = (N - 1) / 3
+		config := loadConfig()
+		config.Set("general.N", N)
+		config.Set("general.f", (N-1)/3)
+		ce.consumer = makeConsumer(id, config, cs)
 
 		for _, fn := range initFNs {
 			fn(ce)
@@ -150,6 +152,6 @@ func makeConsumerNetwork(N int, makeConsumer func(id uint64, config *viper.Viper
 		return ce
 	}
 
-	twl.testnet = makeTestnet(N, endpointFunc)
+	twl.Network = testnet.New(N, endpointFunc)
 	return &twl
 }
@@ -207,6 +207,8 @@ type omniProto struct {
 	signImpl            func(msg []byte) ([]byte, error)
 	verifyImpl          func(senderID uint64, signature []byte, message []byte) error
 	getLastSeqNoImpl    func() (uint64, error)
+	getLastMetadataImpl func() (*Metadata, error)
+	updateReplicasImpl  func(replicas []uint64, f int)
 	validateStateImpl   func()
 	invalidateStateImpl func()
 
@@ -441,6 +443,14 @@ func (op *omniProto) skipTo(seqNo uint64, snapshotID []byte, peers []uint64) {
 
 	panic("Unimplemented")
 }
+func (op *omniProto) updateReplicas(replicas []uint64, f int) {
+	if nil != op.updateReplicasImpl {
+		op.updateReplicasImpl(replicas, f)
+		return
+	}
+
+	panic("Unimplemented")
+}
 func (op *omniProto) viewChange(curView uint64) {
 	if nil != op.viewChangeImpl {
 		op.viewChangeImpl(curView)
@@ -480,6 +490,14 @@ func (op *omniProto) getLastSeqNo() (uint64, error) {
 	return 0, fmt.Errorf("getLastSeqNo is not implemented")
 }
 
+func (op *omniProto) getLastMetadata() (*Metadata, error) {
+	if op.getLastMetadataImpl != nil {
+		return op.getLastMetadataImpl()
+	}
+
+	return nil, fmt.Errorf("getLastMetadata is not implemented")
+}
+
 func (op *omniProto) Close() {
 	if nil != op.CloseImpl {
 		op.CloseImpl()
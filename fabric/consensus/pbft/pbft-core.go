@@ -86,7 +86,9 @@ type innerStack interface {
 	execute(seqNo uint64, reqBatch *RequestBatch) // This is invoked on a separate thread
 	getState() []byte
 	getLastSeqNo() (uint64, error)
+	getLastMetadata() (*Metadata, error)
 	skipTo(seqNo uint64, snapshotID []byte, peers []uint64)
+	updateReplicas(replicas []uint64, f int) // invoked when the validator set changes
 
 	sign(msg []byte) ([]byte, error)
 	verify(senderID uint64, signature []byte, message []byte) error
@@ -128,13 +130,13 @@ type pbftCore struct {
 	byzantine     bool              // whether this node is intentionally acting as Byzantine; useful for debugging on the testnet
 	f             int               // max. number of faults we can tolerate
 	N             int               // max.number of validators in the network
+	replicas      []uint64          // sorted IDs of the current validators; PBFT `R`
 	h             uint64            // low watermark
 	id            uint64            // replica ID; PBFT `i`
 	K             uint64            // checkpoint period
 	logMultiplier uint64            // use this value to calculate log size : k*logMultiplier
 	L             uint64            // log size
 	lastExec      uint64            // last request we executed
-	replicaCount  int               // number of replicas; PBFT `|R|`
 	seqNo         uint64            // PBFT "n", strictly monotonic increasing sequence number
 	view          uint64            // current view
 	chkpts        map[uint64]string // state checkpoints; map lastExec to global hash
@@ -163,6 +165,11 @@ type pbftCore struct {
 	viewChangePeriod   uint64        // period between automatic view changes
 	viewChangeSeqNo    uint64        // next seqNo to perform view change
 
+	reconfiguration *Reconfiguration      // ordered validator set change, applied at its checkpoint
+	votes           *ReconfigurationVotes // votes for a validator set change, ordered but short of a quorum
+
+	metrics *pbftMetrics // published state of the replica
+
 	missingReqBatches map[string]bool // for all the assigned, non-checkpointed request batches we might be missing during view-change
 
 	// implementation of PBFT `in`
@@ -218,6 +225,7 @@ func newPbftCore(id uint64, config *viper.Viper, consumer innerStack, etf events
 	instance := &pbftCore{}
 	instance.id = id
 	instance.consumer = consumer
+	instance.metrics = newPbftMetrics()
 
 	instance.newViewTimer = etf.CreateTimer()
 	instance.vcResendTimer = etf.CreateTimer()
@@ -228,6 +236,8 @@ func newPbftCore(id uint64, config *viper.Viper, consumer innerStack, etf events
 	if instance.f*3+1 > instance.N {
 		panic(fmt.Sprintf("need at least %d enough replicas to tolerate %d byzantine faults, but only %d replicas configured", instance.f*3+1, instance.f, instance.N))
 	}
+	instance.replicas = contiguousReplicas(instance.N)
+	instance.votes = &ReconfigurationVotes{}
 
 	instance.K = uint64(config.GetInt("general.K"))
 
@@ -262,7 +272,6 @@ func newPbftCore(id uint64, config *viper.Viper, consumer innerStack, etf events
 	}
 
 	instance.activeView = true
-	instance.replicaCount = instance.N
 
 	logger.Infof("PBFT type = %T", instance.consumer)
 	logger.Infof("PBFT Max number of validating peers (N) = %v", instance.N)
@@ -377,6 +386,7 @@ func (instance *pbftCore) ProcessEvent(e events.Event) events.Event {
 		}
 		logger.Infof("Replica %d application caught up via state transfer, lastExec now %d", instance.id, update.seqNo)
 		instance.lastExec = update.seqNo
+		instance.refreshMembership()
 		instance.moveWatermarks(instance.lastExec) // The watermark movement handles moving this to a checkpoint boundary
 		instance.skipInProgress = false
 		instance.consumer.validateState()
@@ -426,7 +436,7 @@ func (instance *pbftCore) ProcessEvent(e events.Event) events.Event {
 
 // Given a certain view n, what is the expected primary?
 func (instance *pbftCore) primary(n uint64) uint64 {
-	return n % uint64(instance.replicaCount)
+	return instance.replicas[n%uint64(len(instance.replicas))]
 }
 
 // Is the sequence number between watermarks?
@@ -564,6 +574,10 @@ func (instance *pbftCore) nullRequestHandler() {
 }
 
 func (instance *pbftCore) recvMsg(msg *Message, senderID uint64) (interface{}, error) {
+	if !instance.isReplica(senderID) {
+		return nil, fmt.Errorf("Replica %d received message from %d, which is not part of the validator set %v", instance.id, senderID, instance.replicas)
+	}
+
 	if reqBatch := msg.GetRequestBatch(); reqBatch != nil {
 		return reqBatch, nil
 	} else if preprep := msg.GetPrePrepare(); preprep != nil {
@@ -651,6 +665,11 @@ func (instance *pbftCore) sendPrePrepare(reqBatch *RequestBatch, digest string)
 		return
 	}
 
+	if instance.reconfiguration != nil && n > instance.reconfiguration.SequenceNumber {
+		logger.Infof("Primary %d about to change the validator set, not sending pre-prepare with seqno=%d", instance.id, n)
+		return
+	}
+
 	logger.Debugf("Primary %d broadcasting pre-prepare for view=%d/seqNo=%d and digest %s", instance.id, instance.view, n, digest)
 	instance.seqNo = n
 	preprep := &PrePrepare{
@@ -729,6 +748,11 @@ func (instance *pbftCore) recvPrePrepare(preprep *PrePrepare) error {
 		return nil
 	}
 
+	if instance.reconfiguration != nil && preprep.SequenceNumber > instance.reconfiguration.SequenceNumber {
+		logger.Debugf("Replica %d ignoring pre-prepare for %d, which will be ordered by the next validator set", instance.id, preprep.SequenceNumber)
+		return nil
+	}
+
 	cert := instance.getCert(preprep.View, preprep.SequenceNumber)
 	if cert.digest != "" && cert.digest != preprep.BatchDigest {
 		logger.Warningf("Pre-prepare found for same view/seqNo but different digest: received %s, stored %s", preprep.BatchDigest, cert.digest)
@@ -996,6 +1020,9 @@ func (instance *pbftCore) execDoneSync() {
 		if instance.lastExec%instance.K == 0 {
 			instance.Checkpoint(instance.lastExec, instance.consumer.getState())
 		}
+		if instance.reconfiguration != nil && instance.lastExec == instance.reconfiguration.SequenceNumber {
+			instance.applyReconfiguration()
+		}
 
 	} else {
 		// XXX This masks a bug, this should not be called when currentExec is nil
@@ -1134,7 +1161,7 @@ func (instance *pbftCore) witnessCheckpointWeakCert(chkpt *Checkpoint) {
 
 	if instance.skipInProgress {
 		logger.Debugf("Replica %d is catching up and witnessed a weak certificate for checkpoint %d, weak cert attested to by %d of %d (%v)",
-			instance.id, chkpt.SequenceNumber, i, instance.replicaCount, checkpointMembers)
+			instance.id, chkpt.SequenceNumber, i, instance.N, checkpointMembers)
 		// The view should not be set to active, this should be handled by the yet unimplemented SUSPECT, see https://github.com/hyperledger/fabric/issues/1120
 		instance.retryStateTransfer(target)
 	}
@@ -1284,6 +1311,11 @@ func (instance *pbftCore) recvReturnRequestBatch(reqBatch *RequestBatch) events.
 // Marshals a Message and hands it to the Stack. If toSelf is true,
 // the message is also dispatched to the local instance's RecvMsgSync.
 func (instance *pbftCore) innerBroadcast(msg *Message) error {
+	if !instance.isReplica(instance.id) {
+		logger.Debugf("Replica %d is not part of the validator set, not broadcasting", instance.id)
+		return nil
+	}
+
 	msgRaw, err := proto.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("Cannot marshal message %s", err)
@@ -1302,11 +1334,11 @@ func (instance *pbftCore) innerBroadcast(msg *Message) error {
 	if doByzantine {
 		rand2 := rand.New(rand.NewSource(time.Now().UnixNano()))
 		ignoreidx := rand2.Intn(instance.N)
-		for i := 0; i < instance.N; i++ {
-			if i != ignoreidx && uint64(i) != instance.id { //Pick a random replica and do not send message
-				instance.consumer.unicast(msgRaw, uint64(i))
+		for i, id := range instance.replicas {
+			if i != ignoreidx && id != instance.id { //Pick a random replica and do not send message
+				instance.consumer.unicast(msgRaw, id)
 			} else {
-				logger.Debugf("PBFT byzantine: not broadcasting to replica %v", i)
+				logger.Debugf("PBFT byzantine: not broadcasting to replica %v", id)
 			}
 		}
 	} else {
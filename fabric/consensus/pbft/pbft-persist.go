@@ -109,6 +109,46 @@ func (instance *pbftCore) persistDelCheckpoint(seqNo uint64) {
 	instance.consumer.DelState(key)
 }
 
+func (instance *pbftCore) persistMembership() {
+	raw, err := proto.Marshal(instance.membership())
+	if err != nil {
+		logger.Warningf("Replica %d could not persist membership: %s", instance.id, err)
+		return
+	}
+	err = instance.consumer.StoreState("membership", raw)
+	if err != nil {
+		logger.Warningf("Replica %d could not persist membership: %s", instance.id, err)
+	}
+}
+
+func (instance *pbftCore) persistVotes() {
+	raw, err := proto.Marshal(instance.votes)
+	if err != nil {
+		logger.Warningf("Replica %d could not persist reconfiguration votes: %s", instance.id, err)
+		return
+	}
+	err = instance.consumer.StoreState("votes", raw)
+	if err != nil {
+		logger.Warningf("Replica %d could not persist reconfiguration votes: %s", instance.id, err)
+	}
+}
+
+func (instance *pbftCore) persistReconfiguration() {
+	if instance.reconfiguration == nil {
+		instance.consumer.DelState("reconfiguration")
+		return
+	}
+	raw, err := proto.Marshal(instance.reconfiguration)
+	if err != nil {
+		logger.Warningf("Replica %d could not persist reconfiguration: %s", instance.id, err)
+		return
+	}
+	err = instance.consumer.StoreState("reconfiguration", raw)
+	if err != nil {
+		logger.Warningf("Replica %d could not persist reconfiguration: %s", instance.id, err)
+	}
+}
+
 func (instance *pbftCore) restoreState() {
 	updateSeqView := func(set []*ViewChange_PQ) {
 		for _, e := range set {
@@ -149,6 +189,7 @@ func (instance *pbftCore) restoreState() {
 	}
 
 	instance.restoreLastSeqNo()
+	instance.restoreMembership()
 
 	chkpts, err := instance.consumer.ReadStateSet("chkpt.")
 	if err == nil {
@@ -183,3 +224,40 @@ func (instance *pbftCore) restoreLastSeqNo() {
 	}
 	logger.Infof("Replica %d restored lastExec: %d", instance.id, instance.lastExec)
 }
+
+func (instance *pbftCore) restoreMembership() {
+	raw, err := instance.consumer.ReadState("membership")
+	if err == nil && raw != nil {
+		m := &Membership{}
+		if err = proto.Unmarshal(raw, m); err != nil {
+			logger.Errorf("Replica %d could not unmarshal membership - local state is damaged: %s", instance.id, err)
+		} else if err = validateMembership(m); err != nil {
+			logger.Errorf("Replica %d restored an invalid membership - local state is damaged: %s", instance.id, err)
+		} else {
+			instance.setMembership(m)
+		}
+	}
+
+	raw, err = instance.consumer.ReadState("reconfiguration")
+	if err == nil && raw != nil {
+		reconf := &Reconfiguration{}
+		if err = proto.Unmarshal(raw, reconf); err != nil {
+			logger.Errorf("Replica %d could not unmarshal reconfiguration - local state is damaged: %s", instance.id, err)
+		} else if err = validateMembership(reconf.Membership); err != nil {
+			logger.Errorf("Replica %d restored an invalid reconfiguration - local state is damaged: %s", instance.id, err)
+		} else {
+			instance.reconfiguration = reconf
+			logger.Infof("Replica %d restored pending change of the validator set to %v at checkpoint %d", instance.id, reconf.Membership.Replicas, reconf.SequenceNumber)
+		}
+	}
+
+	raw, err = instance.consumer.ReadState("votes")
+	if err == nil && raw != nil {
+		votes := &ReconfigurationVotes{}
+		if err = proto.Unmarshal(raw, votes); err != nil {
+			logger.Errorf("Replica %d could not unmarshal reconfiguration votes - local state is damaged: %s", instance.id, err)
+		} else {
+			instance.votes = votes
+		}
+	}
+}
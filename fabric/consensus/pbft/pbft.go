@@ -157,3 +157,13 @@ func (op *obcGeneric) getLastSeqNo() (uint64, error) {
 	proto.Unmarshal(raw, meta)
 	return meta.SeqNo, nil
 }
+
+func (op *obcGeneric) getLastMetadata() (*Metadata, error) {
+	raw, err := op.stack.GetBlockHeadMetadata()
+	if err != nil {
+		return nil, err
+	}
+	meta := &Metadata{}
+	proto.Unmarshal(raw, meta)
+	return meta, nil
+}
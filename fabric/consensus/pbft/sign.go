@@ -71,3 +71,23 @@ func (vc *ViewChange) setID(id uint64) {
 func (vc *ViewChange) serialize() ([]byte, error) {
 	return pb.Marshal(vc)
 }
+
+func (req *Request) getSignature() []byte {
+	return req.Signature
+}
+
+func (req *Request) setSignature(sig []byte) {
+	req.Signature = sig
+}
+
+func (req *Request) getID() uint64 {
+	return req.ReplicaId
+}
+
+func (req *Request) setID(id uint64) {
+	req.ReplicaId = id
+}
+
+func (req *Request) serialize() ([]byte, error) {
+	return pb.Marshal(req)
+}
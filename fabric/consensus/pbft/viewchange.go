@@ -128,6 +128,7 @@ func (instance *pbftCore) sendViewChange() events.Event {
 	delete(instance.newViewStore, instance.view)
 	instance.view++
 	instance.activeView = false
+	instance.metrics.viewChanges.Inc()
 
 	instance.pset = instance.calcPSet()
 	instance.qset = instance.calcQSet()
@@ -301,6 +302,10 @@ func (instance *pbftCore) recvNewView(nv *NewView) events.Event {
 	}
 
 	for _, vc := range nv.Vset {
+		if !instance.isReplica(vc.ReplicaId) {
+			logger.Warningf("Replica %d found view-change from replica %d, which is not part of the validator set, in new-view message", instance.id, vc.ReplicaId)
+			return nil
+		}
 		if err := instance.verify(vc); err != nil {
 			logger.Warningf("Replica %d found incorrect view-change signature in new-view message: %s", instance.id, err)
 			return nil
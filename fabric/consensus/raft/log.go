@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/consensus"
+	pb "github.com/hyperledger/fabric/protos"
+
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	hardStateKey   = "raft.state"
+	snapshotKey    = "raft.snapshot"
+	entryKeyPrefix = "raft.entry."
+)
+
+func entryKey(index uint64) string {
+	return fmt.Sprintf("%s%d", entryKeyPrefix, index)
+}
+
+// raftLog holds the entries which have not been compacted into a snapshot
+// yet. Every change is written through to the StatePersistor, so that the
+// log survives a restart of the replica.
+type raftLog struct {
+	persistor consensus.StatePersistor
+
+	snapshot *Snapshot // the log starts right after the snapshot, never nil
+	entries  []*Entry  // entries[i].Index == snapshot.Index+1+i
+
+	// txids maps the transactions in entries to the index of their entry,
+	// compacted holds the ones discarded by the last snapshot. They are used
+	// by the leader to avoid ordering the same transaction twice.
+	txids     map[string]uint64
+	compacted map[string]uint64
+}
+
+func newRaftLog(persistor consensus.StatePersistor) *raftLog {
+	l := &raftLog{
+		persistor: persistor,
+		snapshot:  &Snapshot{},
+		txids:     make(map[string]uint64),
+		compacted: make(map[string]uint64),
+	}
+	l.restore()
+	return l
+}
+
+// restore reads the snapshot and the entries back from the persistor,
+// entries which do not follow the snapshot contiguously are discarded
+func (l *raftLog) restore() {
+	if raw, err := l.persistor.ReadState(snapshotKey); err == nil {
+		snapshot := &Snapshot{}
+		if err = proto.Unmarshal(raw, snapshot); err != nil {
+			logger.Warningf("Replica could not restore snapshot: %v", err)
+		} else {
+			l.snapshot = snapshot
+		}
+	}
+
+	stored, err := l.persistor.ReadStateSet(entryKeyPrefix)
+	if err != nil {
+		logger.Debugf("Replica could not restore log entries: %v", err)
+		return
+	}
+
+	var indexes []uint64
+	entries := make(map[uint64]*Entry)
+	for key, raw := range stored {
+		var index uint64
+		if _, err = fmt.Sscanf(key, entryKeyPrefix+"%d", &index); err != nil {
+			logger.Warningf("Replica could not parse log entry key %s: %v", key, err)
+			continue
+		}
+		entry := &Entry{}
+		if err = proto.Unmarshal(raw, entry); err != nil || entry.Index != index {
+			logger.Warningf("Replica could not restore log entry %d: %v", index, err)
+			continue
+		}
+		indexes = append(indexes, index)
+		entries[index] = entry
+	}
+	sort.Sort(sortableUint64Slice(indexes))
+
+	for _, index := range indexes {
+		if index == l.lastIndex()+1 {
+			l.entries = append(l.entries, entries[index])
+			l.addTxids(entries[index], l.txids)
+			continue
+		}
+		if index > l.snapshot.Index {
+			logger.Warningf("Replica discarding log entry %d, it does not follow entry %d", index, l.lastIndex())
+		}
+		l.persistor.DelState(entryKey(index))
+	}
+}
+
+func (l *raftLog) lastIndex() uint64 {
+	return l.snapshot.Index + uint64(len(l.entries))
+}
+
+func (l *raftLog) lastTerm() uint64 {
+	if len(l.entries) == 0 {
+		return l.snapshot.Term
+	}
+	return l.entries[len(l.entries)-1].Term
+}
+
+// term returns the term of the entry at index, the snapshot counts as the
+// entry at its own index. It returns false if the entry is not in the log.
+func (l *raftLog) term(index uint64) (uint64, bool) {
+	if index == l.snapshot.Index {
+		return l.snapshot.Term, true
+	}
+	if entry := l.entry(index); entry != nil {
+		return entry.Term, true
+	}
+	return 0, false
+}
+
+// entry returns the entry at index, or nil if it was compacted or does not exist yet
+func (l *raftLog) entry(index uint64) *Entry {
+	if index <= l.snapshot.Index || index > l.lastIndex() {
+		return nil
+	}
+	return l.entries[index-l.snapshot.Index-1]
+}
+
+// slice returns at most max entries starting at index from
+func (l *raftLog) slice(from uint64, max int) []*Entry {
+	if from <= l.snapshot.Index || from > l.lastIndex() {
+		return nil
+	}
+	entries := l.entries[from-l.snapshot.Index-1:]
+	if len(entries) > max {
+		entries = entries[:max]
+	}
+	return entries
+}
+
+// append adds an entry at the end of the log, the entry must directly follow the last one
+func (l *raftLog) append(entry *Entry) error {
+	if entry.Index != l.lastIndex()+1 {
+		return fmt.Errorf("entry %d does not follow the last entry %d", entry.Index, l.lastIndex())
+	}
+	raw, err := proto.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err = l.persistor.StoreState(entryKey(entry.Index), raw); err != nil {
+		logger.Warningf("Replica could not persist log entry %d: %v", entry.Index, err)
+	}
+	l.entries = append(l.entries, entry)
+	l.addTxids(entry, l.txids)
+	return nil
+}
+
+// truncate discards the entries from index onwards
+func (l *raftLog) truncate(from uint64) {
+	for index := l.lastIndex(); index >= from && index > l.snapshot.Index; index-- {
+		l.removeTxids(l.entry(index), l.txids)
+		l.persistor.DelState(entryKey(index))
+		l.entries = l.entries[:len(l.entries)-1]
+	}
+}
+
+// compact makes snapshot the new start of the log. The entries it covers
+// are discarded, the following ones are only kept if the log agrees with
+// the snapshot on the term of its last entry.
+func (l *raftLog) compact(snapshot *Snapshot) {
+	if snapshot.Index <= l.snapshot.Index {
+		return
+	}
+
+	raw, err := proto.Marshal(snapshot)
+	if err != nil {
+		logger.Errorf("Replica could not marshal snapshot: %v", err)
+		return
+	}
+	if err = l.persistor.StoreState(snapshotKey, raw); err != nil {
+		logger.Warningf("Replica could not persist snapshot at index %d: %v", snapshot.Index, err)
+	}
+
+	term, ok := l.term(snapshot.Index)
+	agree := ok && term == snapshot.Term
+
+	var keep []*Entry
+	l.compacted = make(map[string]uint64)
+	for _, entry := range l.entries {
+		if entry.Index > snapshot.Index && agree {
+			keep = append(keep, entry)
+			continue
+		}
+		if entry.Index <= snapshot.Index {
+			l.addTxids(entry, l.compacted)
+		}
+		l.removeTxids(entry, l.txids)
+		l.persistor.DelState(entryKey(entry.Index))
+	}
+
+	l.snapshot = snapshot
+	l.entries = keep
+}
+
+// hasTransaction returns true if a transaction with the given id is in the
+// log, or was discarded by the last snapshot
+func (l *raftLog) hasTransaction(txid string) bool {
+	_, inLog := l.txids[txid]
+	_, compacted := l.compacted[txid]
+	return inLog || compacted
+}
+
+func (l *raftLog) addTxids(entry *Entry, txids map[string]uint64) {
+	for _, raw := range entry.Transactions {
+		if tx := unmarshalTransaction(raw); tx != nil {
+			txids[tx.Txid] = entry.Index
+		}
+	}
+}
+
+func (l *raftLog) removeTxids(entry *Entry, txids map[string]uint64) {
+	for _, raw := range entry.Transactions {
+		if tx := unmarshalTransaction(raw); tx != nil && txids[tx.Txid] == entry.Index {
+			delete(txids, tx.Txid)
+		}
+	}
+}
+
+func unmarshalTransaction(raw []byte) *pb.Transaction {
+	tx := &pb.Transaction{}
+	if err := proto.Unmarshal(raw, tx); err != nil {
+		logger.Warningf("Replica could not unmarshal transaction: %v", err)
+		return nil
+	}
+	return tx
+}
+
+type sortableUint64Slice []uint64
+
+func (a sortableUint64Slice) Len() int           { return len(a) }
+func (a sortableUint64Slice) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
+func (a sortableUint64Slice) Less(i, j int) bool { return a[i] < a[j] }
@@ -0,0 +1,116 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+)
+
+func makeEntry(term, index uint64, tags ...int) *Entry {
+	entry := &Entry{Term: term, Index: index}
+	for _, tag := range tags {
+		raw, _ := proto.Marshal(createTx(tag))
+		entry.Transactions = append(entry.Transactions, raw)
+	}
+	return entry
+}
+
+func TestLogRestore(t *testing.T) {
+	persist := newMockPersist()
+	l := newRaftLog(persist)
+	for i, term := range []uint64{1, 1, 2} {
+		if err := l.append(makeEntry(term, uint64(i+1), i)); err != nil {
+			t.Fatalf("Could not append entry %d: %v", i+1, err)
+		}
+	}
+	if err := l.append(makeEntry(2, 5)); err == nil {
+		t.Errorf("Appending an entry which does not follow the log should fail")
+	}
+
+	restored := newRaftLog(persist)
+	if restored.lastIndex() != 3 || restored.lastTerm() != 2 {
+		t.Fatalf("Expected the restored log to end at entry 3 of term 2, ends at %d of term %d", restored.lastIndex(), restored.lastTerm())
+	}
+	if !restored.hasTransaction("tx1") {
+		t.Errorf("Expected the restored log to hold transaction tx1")
+	}
+
+	restored.truncate(2)
+	if restored.lastIndex() != 1 || restored.hasTransaction("tx1") {
+		t.Fatalf("Expected truncate to discard entries 2 and 3, log ends at %d", restored.lastIndex())
+	}
+	if l = newRaftLog(persist); l.lastIndex() != 1 {
+		t.Errorf("Expected truncated entries to be deleted from the persistor, log ends at %d", l.lastIndex())
+	}
+}
+
+func TestLogRestoreGap(t *testing.T) {
+	persist := newMockPersist()
+	l := newRaftLog(persist)
+	l.append(makeEntry(1, 1))
+	l.append(makeEntry(1, 2))
+	raw, _ := proto.Marshal(makeEntry(1, 4))
+	persist.StoreState(entryKey(4), raw)
+
+	if l = newRaftLog(persist); l.lastIndex() != 2 {
+		t.Errorf("Expected the entry following a gap to be discarded, log ends at %d", l.lastIndex())
+	}
+	if _, err := persist.ReadState(entryKey(4)); err == nil {
+		t.Errorf("Expected the entry following a gap to be deleted from the persistor")
+	}
+}
+
+func TestLogCompact(t *testing.T) {
+	persist := newMockPersist()
+	l := newRaftLog(persist)
+	for i, term := range []uint64{1, 1, 2, 2} {
+		l.append(makeEntry(term, uint64(i+1), i+1))
+	}
+
+	l.compact(&Snapshot{Index: 2, Term: 1})
+	if l.entry(2) != nil || l.entry(3) == nil || l.lastIndex() != 4 {
+		t.Fatalf("Expected the log to keep entries 3 and 4 only")
+	}
+	if term, ok := l.term(2); !ok || term != 1 {
+		t.Errorf("Expected the snapshot to stand for entry 2 of term 1, got %d", term)
+	}
+	if !l.hasTransaction("tx1") || !l.hasTransaction("tx4") {
+		t.Errorf("Expected transactions of compacted and kept entries to be known")
+	}
+	if entries := l.slice(2, 10); entries != nil {
+		t.Errorf("Expected no entries to be returned from a compacted index")
+	}
+	if entries := l.slice(3, 1); len(entries) != 1 || entries[0].Index != 3 {
+		t.Errorf("Expected slice to return entry 3 only, got %v", entries)
+	}
+
+	restored := newRaftLog(persist)
+	if restored.snapshot.Index != 2 || restored.lastIndex() != 4 {
+		t.Errorf("Expected the restored log to hold the snapshot at 2 and end at 4, has %d and %d", restored.snapshot.Index, restored.lastIndex())
+	}
+
+	// a snapshot the log does not agree with replaces the whole log
+	l.compact(&Snapshot{Index: 6, Term: 3})
+	if len(l.entries) != 0 || l.lastIndex() != 6 || l.lastTerm() != 3 {
+		t.Errorf("Expected the log to be empty after snapshot 6, ends at %d", l.lastIndex())
+	}
+	if stored, _ := persist.ReadStateSet(entryKeyPrefix); len(stored) != 0 {
+		t.Errorf("Expected compacted entries to be deleted from the persistor, found %d", len(stored))
+	}
+}
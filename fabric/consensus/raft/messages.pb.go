@@ -0,0 +1,187 @@
+// Code generated by protoc-gen-go.
+// source: messages.proto
+// DO NOT EDIT!
+
+/*
+Package raft is a generated protocol buffer package.
+
+It is generated from these files:
+
+	messages.proto
+
+It has these top-level messages:
+
+	Message
+	Entry
+	Snapshot
+	HardState
+	Metadata
+*/
+package raft
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type MessageType int32
+
+const (
+	Message_PROPOSE         MessageType = 0
+	Message_VOTE            MessageType = 1
+	Message_VOTE_RESPONSE   MessageType = 2
+	Message_APPEND          MessageType = 3
+	Message_APPEND_RESPONSE MessageType = 4
+	Message_SNAPSHOT        MessageType = 5
+)
+
+var MessageType_name = map[int32]string{
+	0: "PROPOSE",
+	1: "VOTE",
+	2: "VOTE_RESPONSE",
+	3: "APPEND",
+	4: "APPEND_RESPONSE",
+	5: "SNAPSHOT",
+}
+var MessageType_value = map[string]int32{
+	"PROPOSE":         0,
+	"VOTE":            1,
+	"VOTE_RESPONSE":   2,
+	"APPEND":          3,
+	"APPEND_RESPONSE": 4,
+	"SNAPSHOT":        5,
+}
+
+func (x MessageType) String() string {
+	return proto.EnumName(MessageType_name, int32(x))
+}
+func (MessageType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 0} }
+
+type Message struct {
+	Type MessageType `protobuf:"varint,1,opt,name=type,enum=raft.MessageType" json:"type,omitempty"`
+	Term uint64      `protobuf:"varint,2,opt,name=term" json:"term,omitempty"`
+	// VOTE: index of the last entry of the candidate
+	// APPEND: index of the entry preceding the entries
+	// APPEND_RESPONSE: highest index known to match on success, or a hint where to retry from
+	LogIndex uint64 `protobuf:"varint,3,opt,name=log_index,json=logIndex" json:"log_index,omitempty"`
+	// term of the entry at log_index
+	LogTerm      uint64    `protobuf:"varint,4,opt,name=log_term,json=logTerm" json:"log_term,omitempty"`
+	Entries      []*Entry  `protobuf:"bytes,5,rep,name=entries" json:"entries,omitempty"`
+	Commit       uint64    `protobuf:"varint,6,opt,name=commit" json:"commit,omitempty"`
+	Reject       bool      `protobuf:"varint,7,opt,name=reject" json:"reject,omitempty"`
+	Transactions [][]byte  `protobuf:"bytes,8,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Snapshot     *Snapshot `protobuf:"bytes,9,opt,name=snapshot" json:"snapshot,omitempty"`
+}
+
+func (m *Message) Reset()                    { *m = Message{} }
+func (m *Message) String() string            { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()               {}
+func (*Message) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Message) GetEntries() []*Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *Message) GetSnapshot() *Snapshot {
+	if m != nil {
+		return m.Snapshot
+	}
+	return nil
+}
+
+type Entry struct {
+	Term         uint64   `protobuf:"varint,1,opt,name=term" json:"term,omitempty"`
+	Index        uint64   `protobuf:"varint,2,opt,name=index" json:"index,omitempty"`
+	Transactions [][]byte `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (m *Entry) Reset()                    { *m = Entry{} }
+func (m *Entry) String() string            { return proto.CompactTextString(m) }
+func (*Entry) ProtoMessage()               {}
+func (*Entry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+// snapshot describes the ledger once every entry up to index has been applied
+type Snapshot struct {
+	Index uint64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Term  uint64 `protobuf:"varint,2,opt,name=term" json:"term,omitempty"`
+	Id    []byte `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+// hard_state is the part of the replica state which must survive a restart
+type HardState struct {
+	Term uint64 `protobuf:"varint,1,opt,name=term" json:"term,omitempty"`
+	Vote uint64 `protobuf:"varint,2,opt,name=vote" json:"vote,omitempty"`
+}
+
+func (m *HardState) Reset()                    { *m = HardState{} }
+func (m *HardState) String() string            { return proto.CompactTextString(m) }
+func (*HardState) ProtoMessage()               {}
+func (*HardState) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type Metadata struct {
+	Index uint64 `protobuf:"varint,1,opt,name=index" json:"index,omitempty"`
+	Term  uint64 `protobuf:"varint,2,opt,name=term" json:"term,omitempty"`
+}
+
+func (m *Metadata) Reset()                    { *m = Metadata{} }
+func (m *Metadata) String() string            { return proto.CompactTextString(m) }
+func (*Metadata) ProtoMessage()               {}
+func (*Metadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func init() {
+	proto.RegisterType((*Message)(nil), "raft.message")
+	proto.RegisterType((*Entry)(nil), "raft.entry")
+	proto.RegisterType((*Snapshot)(nil), "raft.snapshot")
+	proto.RegisterType((*HardState)(nil), "raft.hard_state")
+	proto.RegisterType((*Metadata)(nil), "raft.metadata")
+	proto.RegisterEnum("raft.MessageType", MessageType_name, MessageType_value)
+}
+
+func init() { proto.RegisterFile("messages.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 392 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xc1, 0x8e, 0xd3, 0x30,
+	0x10, 0x86, 0x71, 0xe3, 0x36, 0xd9, 0x69, 0x29, 0x61, 0x40, 0xc8, 0x88, 0x4b, 0x14, 0x09, 0x14,
+	0x71, 0xc8, 0xa1, 0xec, 0x0b, 0xac, 0xb4, 0x91, 0xe0, 0xd2, 0x44, 0x4e, 0xe1, 0x1a, 0x99, 0xc6,
+	0x64, 0x83, 0x9a, 0xb8, 0x8a, 0x2d, 0xc4, 0x3e, 0x00, 0xef, 0x8d, 0xec, 0xa4, 0xdb, 0x45, 0xdb,
+	0x03, 0xb7, 0x7f, 0xe6, 0xf3, 0xcc, 0x64, 0xfe, 0x09, 0xac, 0x3b, 0xa9, 0xb5, 0x68, 0xa4, 0x4e,
+	0x8f, 0x83, 0x32, 0x0a, 0xe9, 0x20, 0x7e, 0x98, 0xf8, 0x8f, 0x07, 0xfe, 0x04, 0xf0, 0x03, 0x50,
+	0x73, 0x7f, 0x94, 0x8c, 0x44, 0x24, 0x59, 0x6f, 0x30, 0xb5, 0x0f, 0xd2, 0x09, 0xa6, 0x96, 0x70,
+	0xc7, 0x11, 0x81, 0x1a, 0x39, 0x74, 0x6c, 0x16, 0x91, 0x84, 0x72, 0xa7, 0xf1, 0x1d, 0x5c, 0x1d,
+	0x54, 0x53, 0xb5, 0x7d, 0x2d, 0x7f, 0x33, 0xcf, 0x81, 0xe0, 0xa0, 0x9a, 0x2f, 0x36, 0xc6, 0xb7,
+	0x60, 0x75, 0xe5, 0x8a, 0xa8, 0x63, 0xfe, 0x41, 0x35, 0x3b, 0x5b, 0xf7, 0x1e, 0x7c, 0xd9, 0x9b,
+	0xa1, 0x95, 0x9a, 0xcd, 0x23, 0x2f, 0x59, 0x6e, 0x96, 0xe3, 0x58, 0x9b, 0xbc, 0xe7, 0x27, 0x86,
+	0x6f, 0x60, 0xb1, 0x57, 0x5d, 0xd7, 0x1a, 0xb6, 0x70, 0xf5, 0x53, 0x64, 0xf3, 0x83, 0xfc, 0x29,
+	0xf7, 0x86, 0xf9, 0x11, 0x49, 0x02, 0x3e, 0x45, 0x18, 0xc3, 0xca, 0x0c, 0xa2, 0xd7, 0x62, 0x6f,
+	0x5a, 0xd5, 0x6b, 0x16, 0x44, 0x5e, 0xb2, 0xe2, 0xff, 0xe4, 0xf0, 0x23, 0x04, 0xba, 0x17, 0x47,
+	0x7d, 0xa7, 0x0c, 0xbb, 0x8a, 0x48, 0xb2, 0xdc, 0xac, 0xc7, 0xd9, 0xa7, 0x2c, 0x7f, 0xe0, 0x71,
+	0x35, 0x5a, 0x83, 0x4b, 0xf0, 0x0b, 0x9e, 0x17, 0x79, 0x99, 0x85, 0xcf, 0x30, 0x00, 0xfa, 0x2d,
+	0xdf, 0x65, 0x21, 0xc1, 0x97, 0xf0, 0xdc, 0xaa, 0x8a, 0x67, 0x65, 0x91, 0x6f, 0xcb, 0x2c, 0x9c,
+	0x21, 0xc0, 0xe2, 0xa6, 0x28, 0xb2, 0xed, 0x6d, 0xe8, 0xe1, 0x2b, 0x78, 0x31, 0xea, 0xf3, 0x03,
+	0x8a, 0x2b, 0x08, 0xca, 0xed, 0x4d, 0x51, 0x7e, 0xce, 0x77, 0xe1, 0x3c, 0xfe, 0x0a, 0x73, 0xb7,
+	0xf2, 0x83, 0xb9, 0xe4, 0x91, 0xb9, 0xaf, 0x61, 0x3e, 0x1a, 0x3b, 0x3a, 0x3e, 0x06, 0x4f, 0x76,
+	0xf4, 0x9e, 0xee, 0x18, 0xdf, 0x9e, 0x77, 0x3c, 0x77, 0x21, 0x8f, 0xbb, 0x5c, 0x3a, 0xe6, 0x1a,
+	0x66, 0x6d, 0xed, 0xae, 0xb8, 0xe2, 0xb3, 0xb6, 0x8e, 0xaf, 0x01, 0xee, 0xc4, 0x50, 0x57, 0xda,
+	0x08, 0x23, 0x2f, 0x7e, 0x21, 0x02, 0xfd, 0xa5, 0x8c, 0x3c, 0x75, 0xb1, 0x3a, 0xbe, 0x86, 0xa0,
+	0x93, 0x46, 0xd4, 0xc2, 0x88, 0xff, 0x9f, 0xfd, 0x7d, 0xe1, 0xfe, 0xce, 0x4f, 0x7f, 0x07, 0x00,
+	0xa0, 0xb1, 0x65, 0x96, 0xaf, 0x02, 0x00, 0x00,
+}
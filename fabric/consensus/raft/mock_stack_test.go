@@ -0,0 +1,354 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/fabric/consensus/util/testnet"
+	pb "github.com/hyperledger/fabric/protos"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+)
+
+type mockPersist struct {
+	mutex sync.Mutex
+	store map[string][]byte
+	fail  bool // StoreState fails while set
+}
+
+func newMockPersist() *mockPersist {
+	return &mockPersist{store: make(map[string][]byte)}
+}
+
+func (p *mockPersist) ReadState(key string) ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if val, ok := p.store[key]; ok {
+		return val, nil
+	}
+	return nil, fmt.Errorf("cannot find key %s", key)
+}
+
+func (p *mockPersist) ReadStateSet(prefix string) (map[string][]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	ret := make(map[string][]byte)
+	for k, v := range p.store {
+		if len(k) >= len(prefix) && k[0:len(prefix)] == prefix {
+			ret[k] = v
+		}
+	}
+	return ret, nil
+}
+
+func (p *mockPersist) StoreState(key string, value []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.fail {
+		return fmt.Errorf("cannot store key %s", key)
+	}
+	p.store[key] = value
+	return nil
+}
+
+func (p *mockPersist) failStores(fail bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.fail = fail
+}
+
+func (p *mockPersist) DelState(key string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.store, key)
+}
+
+// mockLedger applies the ledger changes synchronously, so that they survive
+// a crash of the replica, and reports their completion asynchronously
+type mockLedger struct {
+	mutex   sync.Mutex
+	blocks  []*pb.Block
+	pending []*pb.Transaction
+}
+
+func newMockLedger() *mockLedger {
+	return &mockLedger{blocks: []*pb.Block{{}}}
+}
+
+func (l *mockLedger) GetBlock(id uint64) (*pb.Block, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if id >= uint64(len(l.blocks)) {
+		return nil, fmt.Errorf("Block %d not found", id)
+	}
+	return l.blocks[id], nil
+}
+
+func (l *mockLedger) GetBlockchainSize() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return uint64(len(l.blocks))
+}
+
+func (l *mockLedger) GetBlockchainInfo() *pb.BlockchainInfo {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.info()
+}
+
+func (l *mockLedger) GetBlockchainInfoBlob() []byte {
+	raw, _ := proto.Marshal(l.GetBlockchainInfo())
+	return raw
+}
+
+func (l *mockLedger) GetBlockHeadMetadata() ([]byte, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.blocks[len(l.blocks)-1].ConsensusMetadata, nil
+}
+
+func (l *mockLedger) info() *pb.BlockchainInfo {
+	hash, _ := l.blocks[len(l.blocks)-1].GetHash()
+	return &pb.BlockchainInfo{Height: uint64(len(l.blocks)), CurrentBlockHash: hash}
+}
+
+func (l *mockLedger) execute(txs []*pb.Transaction) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.pending = append(l.pending, txs...)
+}
+
+func (l *mockLedger) commit(meta []byte) *pb.BlockchainInfo {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	previousHash, _ := l.blocks[len(l.blocks)-1].GetHash()
+	l.blocks = append(l.blocks, &pb.Block{
+		ConsensusMetadata: meta,
+		PreviousBlockHash: previousHash,
+		Transactions:      l.pending,
+	})
+	l.pending = nil
+	return l.info()
+}
+
+func (l *mockLedger) rollback() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.pending = nil
+}
+
+// skipTo copies the blocks of remote up to target, it returns nil if remote
+// does not have them
+func (l *mockLedger) skipTo(target *pb.BlockchainInfo, remote *mockLedger) *pb.BlockchainInfo {
+	remote.mutex.Lock()
+	if uint64(len(remote.blocks)) < target.Height {
+		remote.mutex.Unlock()
+		return nil
+	}
+	blocks := append([]*pb.Block(nil), remote.blocks[:target.Height]...)
+	remote.mutex.Unlock()
+
+	if hash, _ := blocks[len(blocks)-1].GetHash(); !bytes.Equal(hash, target.CurrentBlockHash) {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.blocks = blocks
+	l.pending = nil
+	return l.info()
+}
+
+// txids lists the ids of the transactions in the ledger, in order
+func (l *mockLedger) txids() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var txids []string
+	for _, block := range l.blocks {
+		for _, tx := range block.Transactions {
+			txids = append(txids, tx.Txid)
+		}
+	}
+	return txids
+}
+
+// raftEndpoint is a replica of the test network, it implements the
+// consensus.Stack. Its ledger and persisted state survive a crash.
+type raftEndpoint struct {
+	*testnet.TestEndpoint
+	*mockLedger
+	*mockPersist
+
+	config *viper.Viper
+
+	// mutex serializes the events handed to the replica with its start and
+	// stop, instanceMutex only guards raft, which the replica reads itself
+	mutex         sync.Mutex
+	instanceMutex sync.RWMutex
+	raft          *raftCore
+	running       bool
+
+	stateTransfers int32 // number of completed state transfers
+}
+
+func (ep *raftEndpoint) start() {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	if ep.running {
+		return
+	}
+	instance := newRaftCore(ep.ID, ep.config, ep)
+	ep.instanceMutex.Lock()
+	ep.raft = instance
+	ep.instanceMutex.Unlock()
+	ep.running = true
+}
+
+func (ep *raftEndpoint) Stop() {
+	ep.mutex.Lock()
+	if !ep.running {
+		ep.mutex.Unlock()
+		return
+	}
+	ep.running = false
+	ep.mutex.Unlock()
+	ep.current().Close()
+}
+
+// consumer runs fn with the replica, unless it is stopped or was
+// restarted since instance
+func (ep *raftEndpoint) consumer(instance *raftCore, fn func(*raftCore)) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+	if ep.running && (instance == nil || ep.raft == instance) {
+		fn(ep.raft)
+	}
+}
+
+func (ep *raftEndpoint) current() *raftCore {
+	ep.instanceMutex.RLock()
+	defer ep.instanceMutex.RUnlock()
+	return ep.raft
+}
+
+func (ep *raftEndpoint) Deliver(msg []byte, senderHandle *pb.PeerID) {
+	ep.consumer(nil, func(c *raftCore) {
+		c.RecvMsg(&pb.Message{Type: pb.Message_CONSENSUS, Payload: msg}, senderHandle)
+	})
+}
+
+func (ep *raftEndpoint) IsBusy() bool {
+	return false
+}
+
+// submit hands a transaction to the replica, as a client would
+func (ep *raftEndpoint) submit(tx *pb.Transaction) {
+	raw, _ := proto.Marshal(tx)
+	ep.consumer(nil, func(c *raftCore) {
+		c.RecvMsg(&pb.Message{Type: pb.Message_CHAIN_TRANSACTION, Payload: raw}, ep.GetHandle())
+	})
+}
+
+// inspect runs fn on the main thread of the replica, it returns false if the replica is stopped
+func (ep *raftEndpoint) inspect(fn func(*raftCore)) bool {
+	done := make(chan struct{})
+	ran := false
+	ep.consumer(nil, func(c *raftCore) {
+		c.manager.Queue() <- workEvent(func() {
+			fn(c)
+			close(done)
+		})
+		ran = true
+	})
+	if ran {
+		<-done
+	}
+	return ran
+}
+
+func (ep *raftEndpoint) Sign(msg []byte) ([]byte, error) {
+	return msg, nil
+}
+
+func (ep *raftEndpoint) Verify(peerID *pb.PeerID, signature []byte, message []byte) error {
+	return nil
+}
+
+func (ep *raftEndpoint) Start() {}
+
+func (ep *raftEndpoint) Halt() {}
+
+func (ep *raftEndpoint) Execute(tag interface{}, txs []*pb.Transaction) {
+	instance := ep.current()
+	ep.execute(txs)
+	go ep.consumer(instance, func(c *raftCore) { c.Executed(tag) })
+}
+
+func (ep *raftEndpoint) Commit(tag interface{}, meta []byte) {
+	instance := ep.current()
+	info := ep.commit(meta)
+	go ep.consumer(instance, func(c *raftCore) { c.Committed(tag, info) })
+}
+
+func (ep *raftEndpoint) Rollback(tag interface{}) {
+	instance := ep.current()
+	ep.rollback()
+	go ep.consumer(instance, func(c *raftCore) { c.RolledBack(tag) })
+}
+
+func (ep *raftEndpoint) UpdateState(tag interface{}, target *pb.BlockchainInfo, peers []*pb.PeerID) {
+	instance := ep.current()
+	go func() {
+		var info *pb.BlockchainInfo
+		if id, err := getValidatorID(peers[0]); err == nil {
+			info = ep.skipTo(target, ep.Net.Endpoints[id].(*raftEndpoint).mockLedger)
+		}
+		if info != nil {
+			atomic.AddInt32(&ep.stateTransfers, 1)
+		}
+		ep.consumer(instance, func(c *raftCore) { c.StateUpdated(tag, info) })
+	}()
+}
+
+func (ep *raftEndpoint) BeginTxBatch(id interface{}) error {
+	return fmt.Errorf("not supported")
+}
+
+func (ep *raftEndpoint) ExecTxs(id interface{}, txs []*pb.Transaction) ([]byte, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (ep *raftEndpoint) CommitTxBatch(id interface{}, metadata []byte) (*pb.Block, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (ep *raftEndpoint) RollbackTxBatch(id interface{}) error {
+	return fmt.Errorf("not supported")
+}
+
+func (ep *raftEndpoint) PreviewCommitTxBatch(id interface{}, metadata []byte) ([]byte, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (ep *raftEndpoint) InvalidateState() {}
+
+func (ep *raftEndpoint) ValidateState() {}
@@ -0,0 +1,962 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hyperledger/fabric/consensus"
+	"github.com/hyperledger/fabric/consensus/util/events"
+	pb "github.com/hyperledger/fabric/protos"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+)
+
+// =============================================================================
+// custom interfaces and structure definitions
+// =============================================================================
+
+type role int
+
+const (
+	follower role = iota
+	candidate
+	leader
+)
+
+func (r role) String() string {
+	switch r {
+	case follower:
+		return "follower"
+	case candidate:
+		return "candidate"
+	case leader:
+		return "leader"
+	}
+	return fmt.Sprintf("role(%d)", int(r))
+}
+
+// noLeader is the value of raftCore.leader while the leader is unknown
+const noLeader = ^uint64(0)
+
+// Event types
+
+// electionTimerEvent is sent when a replica did not hear from a leader for an election timeout
+type electionTimerEvent struct{}
+
+// heartbeatTimerEvent is sent when the leader is due to contact the other replicas
+type heartbeatTimerEvent struct{}
+
+// batchTimerEvent is sent when the batch timer expires
+type batchTimerEvent struct{}
+
+// forwardTimerEvent is sent when forwarded transactions were not applied in time
+type forwardTimerEvent struct{}
+
+// workEvent is used to run a function on the main thread of the replica
+type workEvent func()
+
+type raftCore struct {
+	externalEventReceiver
+
+	id      uint64
+	N       int
+	stack   consensus.Stack
+	sender  *sender
+	manager events.Manager
+	rand    *rand.Rand
+
+	batchSize      int
+	maxAppend      int
+	snapshotPeriod uint64
+
+	batchTimeout     time.Duration
+	heartbeatTimeout time.Duration
+	electionTimeout  time.Duration
+
+	batchTimer       events.Timer
+	batchTimerActive bool
+	heartbeatTimer   events.Timer
+	electionTimer    events.Timer
+	forwardTimer     events.Timer
+
+	// persisted state
+	term uint64   // latest term the replica has seen
+	vote uint64   // replica id + 1 of the candidate voted for in term, 0 if none
+	log  *raftLog // entries not yet compacted into a snapshot
+
+	role       role
+	leader     uint64          // id of the leader of term, noLeader if unknown
+	votes      map[uint64]bool // votes granted to us as a candidate
+	nextIndex  []uint64        // leader: index of the next entry to send to each replica
+	matchIndex []uint64        // leader: highest entry known to be replicated on each replica
+
+	commitIndex    uint64   // highest entry known to be committed
+	lastApplied    uint64   // highest entry applied to the ledger
+	currentExec    *Entry   // entry being executed, nil if none
+	currentTxids   []string // transactions of currentExec
+	skipInProgress bool     // set while the ledger is catching up with a snapshot
+
+	outstanding map[string][]byte // transactions received and not yet applied, by txid
+	batch       [][]byte          // leader: transactions for the next entry
+	batchTxids  map[string]bool   // leader: ids of the transactions in batch
+}
+
+// =============================================================================
+// constructors
+// =============================================================================
+
+func newRaftCore(id uint64, config *viper.Viper, stack consensus.Stack) *raftCore {
+	var err error
+	instance := &raftCore{
+		id:          id,
+		stack:       stack,
+		rand:        rand.New(rand.NewSource(time.Now().UnixNano() + int64(id))),
+		leader:      noLeader,
+		outstanding: make(map[string][]byte),
+		batchTxids:  make(map[string]bool),
+	}
+
+	instance.N = config.GetInt("general.N")
+	if instance.N < 1 || id >= uint64(instance.N) {
+		panic(fmt.Errorf("Replica id %d is not valid for a network of %d replicas", id, instance.N))
+	}
+	instance.batchSize = config.GetInt("general.batchsize")
+	if instance.batchSize < 1 {
+		instance.batchSize = 1
+	}
+	instance.maxAppend = config.GetInt("general.maxappend")
+	if instance.maxAppend < 1 {
+		instance.maxAppend = 1
+	}
+	instance.snapshotPeriod = uint64(config.GetInt("general.snapshotperiod"))
+
+	instance.batchTimeout, err = time.ParseDuration(config.GetString("general.timeout.batch"))
+	if err != nil {
+		panic(fmt.Errorf("Cannot parse batch timeout: %s", err))
+	}
+	instance.heartbeatTimeout, err = time.ParseDuration(config.GetString("general.timeout.heartbeat"))
+	if err != nil {
+		panic(fmt.Errorf("Cannot parse heartbeat timeout: %s", err))
+	}
+	instance.electionTimeout, err = time.ParseDuration(config.GetString("general.timeout.election"))
+	if err != nil {
+		panic(fmt.Errorf("Cannot parse election timeout: %s", err))
+	}
+	if instance.electionTimeout <= instance.heartbeatTimeout {
+		instance.electionTimeout = 4 * instance.heartbeatTimeout
+		logger.Warningf("Configured election timeout must be greater than heartbeat timeout, setting to %v", instance.electionTimeout)
+	}
+
+	logger.Infof("Raft Number of replicas (N) = %d", instance.N)
+	logger.Infof("Raft Batch size = %d", instance.batchSize)
+	logger.Infof("Raft Snapshot period = %d", instance.snapshotPeriod)
+	logger.Infof("Raft Batch timeout = %v", instance.batchTimeout)
+	logger.Infof("Raft Heartbeat timeout = %v", instance.heartbeatTimeout)
+	logger.Infof("Raft Election timeout = %v", instance.electionTimeout)
+
+	instance.restoreState()
+
+	instance.manager = events.NewManagerImpl()
+	instance.manager.SetReceiver(instance)
+	instance.externalEventReceiver.manager = instance.manager
+	etf := events.NewTimerFactoryImpl(instance.manager)
+	instance.batchTimer = etf.CreateTimer()
+	instance.heartbeatTimer = etf.CreateTimer()
+	instance.electionTimer = etf.CreateTimer()
+	instance.forwardTimer = etf.CreateTimer()
+	instance.sender = newSender(id, instance.N, stack)
+	instance.manager.Start()
+
+	instance.resetElectionTimer()
+
+	return instance
+}
+
+// restoreState recovers the term, vote and log from the persistor, and the
+// index of the last applied entry from the head of the ledger
+func (instance *raftCore) restoreState() {
+	instance.log = newRaftLog(instance.stack)
+
+	if raw, err := instance.stack.ReadState(hardStateKey); err == nil {
+		state := &HardState{}
+		if err = proto.Unmarshal(raw, state); err != nil {
+			logger.Warningf("Replica %d could not restore its term: %v", instance.id, err)
+		} else {
+			instance.term = state.Term
+			instance.vote = state.Vote
+		}
+	}
+
+	meta := &Metadata{}
+	if raw, err := instance.stack.GetBlockHeadMetadata(); err != nil {
+		logger.Warningf("Replica %d could not read the head of the ledger: %v", instance.id, err)
+	} else if err = proto.Unmarshal(raw, meta); err != nil {
+		logger.Warningf("Replica %d could not unmarshal the metadata of the head of the ledger: %v", instance.id, err)
+		meta = &Metadata{}
+	}
+
+	instance.lastApplied = meta.Index
+	if snapshot := instance.log.snapshot; snapshot.Index > instance.lastApplied {
+		instance.lastApplied = snapshot.Index
+	}
+	if instance.lastApplied > instance.log.lastIndex() {
+		// The ledger is ahead of the log, the log cannot have been persisted
+		logger.Warningf("Replica %d applied entry %d which is not in its log, restarting the log from the ledger", instance.id, instance.lastApplied)
+		instance.log.compact(&Snapshot{
+			Index: instance.lastApplied,
+			Term:  meta.Term,
+			Id:    instance.stack.GetBlockchainInfoBlob(),
+		})
+	}
+	instance.commitIndex = instance.lastApplied
+
+	logger.Infof("Replica %d restored term %d, last applied entry %d, last log entry %d", instance.id, instance.term, instance.lastApplied, instance.log.lastIndex())
+}
+
+// Close tells us to release resources we are holding
+func (instance *raftCore) Close() {
+	instance.batchTimer.Halt()
+	instance.heartbeatTimer.Halt()
+	instance.electionTimer.Halt()
+	instance.forwardTimer.Halt()
+	instance.manager.Halt()
+	instance.sender.close()
+}
+
+// =============================================================================
+// receive methods
+// =============================================================================
+
+// ProcessEvent is the main event loop of the replica, it is only ever called by the manager thread
+func (instance *raftCore) ProcessEvent(e events.Event) events.Event {
+	switch et := e.(type) {
+	case messageEvent:
+		instance.recvMessage(et.msg, et.sender)
+	case electionTimerEvent:
+		instance.campaign()
+	case heartbeatTimerEvent:
+		instance.heartbeat()
+	case batchTimerEvent:
+		instance.batchTimerActive = false
+		instance.appendBatch()
+	case forwardTimerEvent:
+		instance.forwardOutstanding()
+	case executedEvent:
+		instance.executed(et.tag)
+	case committedEvent:
+		instance.committed(et.tag)
+	case rolledBackEvent:
+		logger.Debugf("Replica %d received a rollback", instance.id)
+	case stateUpdatedEvent:
+		instance.stateUpdated(et.tag, et.target)
+	case workEvent:
+		et()
+	default:
+		logger.Errorf("Replica %d received an unknown event type %T", instance.id, et)
+	}
+	return nil
+}
+
+func (instance *raftCore) recvMessage(ocMsg *pb.Message, senderHandle *pb.PeerID) {
+	if ocMsg.Type == pb.Message_CHAIN_TRANSACTION {
+		instance.recvTransaction(ocMsg.Payload)
+		return
+	}
+
+	if ocMsg.Type != pb.Message_CONSENSUS {
+		logger.Errorf("Unexpected message type: %s", ocMsg.Type)
+		return
+	}
+
+	from, err := getValidatorID(senderHandle)
+	if err != nil || from >= uint64(instance.N) || from == instance.id {
+		logger.Warningf("Replica %d received a message from unexpected peer %v", instance.id, senderHandle)
+		return
+	}
+
+	msg := &Message{}
+	if err = proto.Unmarshal(ocMsg.Payload, msg); err != nil {
+		logger.Errorf("Error unpacking payload from message: %s", err)
+		return
+	}
+
+	if msg.Term > instance.term && msg.Type != Message_PROPOSE {
+		logger.Infof("Replica %d received a %s for term %d from replica %d while at term %d", instance.id, msg.Type, msg.Term, from, instance.term)
+		instance.becomeFollower(msg.Term, noLeader)
+	}
+
+	switch msg.Type {
+	case Message_PROPOSE:
+		for _, raw := range msg.Transactions {
+			instance.recvTransaction(raw)
+		}
+	case Message_VOTE:
+		instance.recvVote(msg, from)
+	case Message_VOTE_RESPONSE:
+		instance.recvVoteResponse(msg, from)
+	case Message_APPEND:
+		instance.recvAppend(msg, from)
+	case Message_APPEND_RESPONSE:
+		instance.recvAppendResponse(msg, from)
+	case Message_SNAPSHOT:
+		instance.recvSnapshot(msg, from)
+	default:
+		logger.Warningf("Replica %d received a message of unknown type %s", instance.id, msg.Type)
+	}
+}
+
+// =============================================================================
+// transactions
+// =============================================================================
+
+// recvTransaction handles a transaction submitted by a client, or forwarded by another replica
+func (instance *raftCore) recvTransaction(raw []byte) {
+	tx := unmarshalTransaction(raw)
+	if tx == nil {
+		return
+	}
+	if _, ok := instance.outstanding[tx.Txid]; ok {
+		logger.Debugf("Replica %d already knows of transaction %s", instance.id, tx.Txid)
+		return
+	}
+	instance.outstanding[tx.Txid] = raw
+
+	switch {
+	case instance.role == leader:
+		instance.propose(tx.Txid, raw)
+	case instance.leader != noLeader:
+		instance.forward([][]byte{raw})
+	default:
+		logger.Debugf("Replica %d holding transaction %s until a leader is elected", instance.id, tx.Txid)
+	}
+}
+
+// forward sends transactions to the leader for ordering
+func (instance *raftCore) forward(txs [][]byte) {
+	logger.Debugf("Replica %d forwarding %d transactions to leader %d", instance.id, len(txs), instance.leader)
+	instance.send(&Message{
+		Type:         Message_PROPOSE,
+		Term:         instance.term,
+		Transactions: txs,
+	}, instance.leader)
+	instance.forwardTimer.SoftReset(instance.electionTimeout, forwardTimerEvent{})
+}
+
+// forwardOutstanding hands all the transactions which were not applied yet
+// to the current leader, the leader ignores the ones it already ordered
+func (instance *raftCore) forwardOutstanding() {
+	if len(instance.outstanding) == 0 || instance.leader == noLeader {
+		return
+	}
+
+	if instance.role == leader {
+		for txid, raw := range instance.outstanding {
+			instance.propose(txid, raw)
+		}
+		return
+	}
+
+	var txs [][]byte
+	for _, raw := range instance.outstanding {
+		txs = append(txs, raw)
+	}
+	instance.forward(txs)
+}
+
+// propose adds a transaction to the batch of the leader
+func (instance *raftCore) propose(txid string, raw []byte) {
+	if instance.log.hasTransaction(txid) || instance.batchTxids[txid] {
+		logger.Debugf("Leader %d already ordered transaction %s", instance.id, txid)
+		return
+	}
+
+	instance.batch = append(instance.batch, raw)
+	instance.batchTxids[txid] = true
+
+	if len(instance.batch) >= instance.batchSize {
+		instance.appendBatch()
+		return
+	}
+	if !instance.batchTimerActive {
+		instance.batchTimer.Reset(instance.batchTimeout, batchTimerEvent{})
+		instance.batchTimerActive = true
+	}
+}
+
+// appendBatch turns the batch into a new entry of the log
+func (instance *raftCore) appendBatch() {
+	if instance.batchTimerActive {
+		instance.batchTimer.Stop()
+		instance.batchTimerActive = false
+	}
+	if instance.role != leader || len(instance.batch) == 0 {
+		return
+	}
+
+	entry := &Entry{
+		Term:         instance.term,
+		Index:        instance.log.lastIndex() + 1,
+		Transactions: instance.batch,
+	}
+	instance.batch = nil
+	instance.batchTxids = make(map[string]bool)
+
+	logger.Infof("Leader %d appending entry %d with %d transactions", instance.id, entry.Index, len(entry.Transactions))
+	instance.appendEntry(entry)
+}
+
+// =============================================================================
+// leader election
+// =============================================================================
+
+func (instance *raftCore) resetElectionTimer() {
+	timeout := instance.electionTimeout + time.Duration(instance.rand.Int63n(int64(instance.electionTimeout)))
+	instance.electionTimer.Reset(timeout, electionTimerEvent{})
+}
+
+// persistHardState stores the term and vote. A replica must not act on a vote
+// before it is durable, or it could vote twice in a term after a restart.
+func (instance *raftCore) persistHardState() error {
+	raw, err := proto.Marshal(&HardState{Term: instance.term, Vote: instance.vote})
+	if err != nil {
+		return fmt.Errorf("could not marshal term %d: %v", instance.term, err)
+	}
+	if err = instance.stack.StoreState(hardStateKey, raw); err != nil {
+		return fmt.Errorf("could not persist term %d: %v", instance.term, err)
+	}
+	return nil
+}
+
+func (instance *raftCore) setLeader(id uint64) {
+	if instance.leader == id {
+		return
+	}
+	instance.leader = id
+	if id != noLeader {
+		logger.Infof("Replica %d recognizes replica %d as leader of term %d", instance.id, id, instance.term)
+		instance.forwardOutstanding()
+	}
+}
+
+// becomeFollower moves to the given term, if it is new, and follows leader
+func (instance *raftCore) becomeFollower(term uint64, leaderID uint64) {
+	if term > instance.term {
+		instance.term = term
+		instance.vote = 0
+		if err := instance.persistHardState(); err != nil {
+			// no vote is cast in the new term until one is persisted
+			logger.Warningf("Replica %d: %v", instance.id, err)
+		}
+	}
+
+	if instance.role == leader {
+		logger.Infof("Replica %d stepping down as leader in term %d", instance.id, instance.term)
+		instance.heartbeatTimer.Stop()
+		if instance.batchTimerActive {
+			instance.batchTimer.Stop()
+			instance.batchTimerActive = false
+		}
+		// the transactions of the batch are still outstanding, they are forwarded to the next leader
+		instance.batch = nil
+		instance.batchTxids = make(map[string]bool)
+	}
+
+	instance.role = follower
+	instance.votes = nil
+	instance.setLeader(leaderID)
+	instance.resetElectionTimer()
+}
+
+// campaign starts an election for the next term
+func (instance *raftCore) campaign() {
+	if instance.role == leader {
+		return
+	}
+
+	instance.term++
+	instance.vote = instance.id + 1
+	if err := instance.persistHardState(); err != nil {
+		logger.Errorf("Replica %d not starting an election: %v", instance.id, err)
+		instance.term--
+		instance.vote = 0
+		instance.resetElectionTimer()
+		return
+	}
+	instance.role = candidate
+	instance.leader = noLeader
+	instance.votes = map[uint64]bool{instance.id: true}
+	instance.resetElectionTimer()
+
+	logger.Infof("Replica %d starting an election for term %d", instance.id, instance.term)
+
+	if len(instance.votes) > instance.N/2 {
+		instance.becomeLeader()
+		return
+	}
+
+	for i := uint64(0); i < uint64(instance.N); i++ {
+		if i == instance.id {
+			continue
+		}
+		instance.send(&Message{
+			Type:     Message_VOTE,
+			Term:     instance.term,
+			LogIndex: instance.log.lastIndex(),
+			LogTerm:  instance.log.lastTerm(),
+		}, i)
+	}
+}
+
+func (instance *raftCore) recvVote(msg *Message, from uint64) {
+	upToDate := msg.LogTerm > instance.log.lastTerm() ||
+		(msg.LogTerm == instance.log.lastTerm() && msg.LogIndex >= instance.log.lastIndex())
+	grant := msg.Term == instance.term && upToDate &&
+		(instance.vote == 0 || instance.vote == from+1)
+
+	if grant && instance.vote == 0 {
+		instance.vote = from + 1
+		if err := instance.persistHardState(); err != nil {
+			logger.Errorf("Replica %d withholding its vote for replica %d: %v", instance.id, from, err)
+			instance.vote = 0
+			grant = false
+		}
+	}
+
+	if grant {
+		logger.Infof("Replica %d voting for replica %d in term %d", instance.id, from, instance.term)
+		instance.resetElectionTimer()
+	} else {
+		logger.Debugf("Replica %d rejecting the vote request of replica %d for term %d", instance.id, from, msg.Term)
+	}
+
+	instance.send(&Message{
+		Type:   Message_VOTE_RESPONSE,
+		Term:   instance.term,
+		Reject: !grant,
+	}, from)
+}
+
+func (instance *raftCore) recvVoteResponse(msg *Message, from uint64) {
+	if instance.role != candidate || msg.Term != instance.term || msg.Reject {
+		return
+	}
+	instance.votes[from] = true
+	if len(instance.votes) > instance.N/2 {
+		instance.becomeLeader()
+	}
+}
+
+func (instance *raftCore) becomeLeader() {
+	logger.Infof("Replica %d elected leader of term %d", instance.id, instance.term)
+
+	instance.role = leader
+	instance.votes = nil
+	instance.electionTimer.Stop()
+	instance.nextIndex = make([]uint64, instance.N)
+	instance.matchIndex = make([]uint64, instance.N)
+	for i := range instance.nextIndex {
+		instance.nextIndex[i] = instance.log.lastIndex() + 1
+	}
+
+	// Entries of previous terms are only known to be committed once an entry
+	// of the current term is, so start the term with an empty entry
+	instance.appendEntry(&Entry{
+		Term:  instance.term,
+		Index: instance.log.lastIndex() + 1,
+	})
+
+	instance.setLeader(instance.id)
+	instance.heartbeatTimer.Reset(instance.heartbeatTimeout, heartbeatTimerEvent{})
+}
+
+// =============================================================================
+// log replication
+// =============================================================================
+
+// appendEntry adds an entry to the log of the leader and replicates it
+func (instance *raftCore) appendEntry(entry *Entry) {
+	if err := instance.log.append(entry); err != nil {
+		logger.Errorf("Leader %d could not append entry: %v", instance.id, err)
+		return
+	}
+	instance.matchIndex[instance.id] = entry.Index
+	instance.nextIndex[instance.id] = entry.Index + 1
+	instance.advanceCommit()
+
+	for i := uint64(0); i < uint64(instance.N); i++ {
+		if i != instance.id && instance.nextIndex[i] == entry.Index {
+			instance.sendAppend(i)
+		}
+	}
+}
+
+func (instance *raftCore) heartbeat() {
+	if instance.role != leader {
+		return
+	}
+	for i := uint64(0); i < uint64(instance.N); i++ {
+		if i != instance.id {
+			instance.sendAppend(i)
+		}
+	}
+	instance.heartbeatTimer.Reset(instance.heartbeatTimeout, heartbeatTimerEvent{})
+}
+
+// sendAppend sends the entries replica to is missing, or the snapshot if
+// those entries were already compacted
+func (instance *raftCore) sendAppend(to uint64) {
+	next := instance.nextIndex[to]
+	if next <= instance.log.snapshot.Index {
+		logger.Debugf("Leader %d sending snapshot at index %d to replica %d", instance.id, instance.log.snapshot.Index, to)
+		instance.send(&Message{
+			Type:     Message_SNAPSHOT,
+			Term:     instance.term,
+			Snapshot: instance.log.snapshot,
+		}, to)
+		return
+	}
+
+	prevTerm, _ := instance.log.term(next - 1)
+	instance.send(&Message{
+		Type:     Message_APPEND,
+		Term:     instance.term,
+		LogIndex: next - 1,
+		LogTerm:  prevTerm,
+		Entries:  instance.log.slice(next, instance.maxAppend),
+		Commit:   instance.commitIndex,
+	}, to)
+}
+
+// followLeader makes sure the replica follows the sender of a message of the current term
+func (instance *raftCore) followLeader(from uint64) {
+	if instance.role != follower || instance.leader != from {
+		instance.becomeFollower(instance.term, from)
+		return
+	}
+	instance.resetElectionTimer()
+}
+
+func (instance *raftCore) recvAppend(msg *Message, from uint64) {
+	if msg.Term < instance.term {
+		instance.send(&Message{
+			Type:     Message_APPEND_RESPONSE,
+			Term:     instance.term,
+			LogIndex: instance.log.lastIndex(),
+			Reject:   true,
+		}, from)
+		return
+	}
+	instance.followLeader(from)
+
+	if instance.skipInProgress {
+		// we will catch up once state transfer completes
+		return
+	}
+
+	prev := msg.LogIndex
+	if prev > instance.log.lastIndex() {
+		instance.rejectAppend(from, instance.log.lastIndex())
+		return
+	}
+	// The entries covered by our snapshot are committed, so they match the ones of the leader
+	if term, ok := instance.log.term(prev); ok && term != msg.LogTerm {
+		logger.Debugf("Replica %d has entry %d of term %d, leader %d has term %d", instance.id, prev, term, from, msg.LogTerm)
+		instance.rejectAppend(from, prev-1)
+		return
+	}
+
+	for _, entry := range msg.Entries {
+		if entry.Index <= instance.log.snapshot.Index {
+			continue
+		}
+		if term, ok := instance.log.term(entry.Index); ok {
+			if term == entry.Term {
+				continue
+			}
+			if entry.Index <= instance.commitIndex {
+				logger.Errorf("Replica %d was asked to replace committed entry %d, ignoring", instance.id, entry.Index)
+				return
+			}
+			logger.Infof("Replica %d discarding the entries from %d, they conflict with leader %d", instance.id, entry.Index, from)
+			instance.log.truncate(entry.Index)
+		}
+		if err := instance.log.append(entry); err != nil {
+			logger.Errorf("Replica %d could not append entry: %v", instance.id, err)
+			return
+		}
+	}
+
+	last := prev + uint64(len(msg.Entries))
+	commit := msg.Commit
+	if commit > last {
+		commit = last
+	}
+	if commit > instance.commitIndex {
+		instance.commitIndex = commit
+	}
+
+	instance.send(&Message{
+		Type:     Message_APPEND_RESPONSE,
+		Term:     instance.term,
+		LogIndex: last,
+	}, from)
+
+	instance.applyCommitted()
+}
+
+func (instance *raftCore) rejectAppend(to uint64, hint uint64) {
+	instance.send(&Message{
+		Type:     Message_APPEND_RESPONSE,
+		Term:     instance.term,
+		LogIndex: hint,
+		Reject:   true,
+	}, to)
+}
+
+func (instance *raftCore) recvAppendResponse(msg *Message, from uint64) {
+	if instance.role != leader || msg.Term != instance.term {
+		return
+	}
+
+	if !msg.Reject {
+		if msg.LogIndex > instance.matchIndex[from] {
+			instance.matchIndex[from] = msg.LogIndex
+			instance.advanceCommit()
+		}
+		if instance.nextIndex[from] <= instance.matchIndex[from] {
+			instance.nextIndex[from] = instance.matchIndex[from] + 1
+		}
+		if instance.nextIndex[from] <= instance.log.lastIndex() {
+			instance.sendAppend(from)
+		}
+		return
+	}
+
+	// Move back to where the replica suggests, but always make progress
+	next := instance.nextIndex[from] - 1
+	if msg.LogIndex+1 < next {
+		next = msg.LogIndex + 1
+	}
+	if next <= instance.matchIndex[from] {
+		next = instance.matchIndex[from] + 1
+	}
+	instance.nextIndex[from] = next
+	instance.sendAppend(from)
+}
+
+// advanceCommit commits the highest entry of the current term stored by a majority of replicas
+func (instance *raftCore) advanceCommit() {
+	for index := instance.log.lastIndex(); index > instance.commitIndex; index-- {
+		if term, _ := instance.log.term(index); term != instance.term {
+			break
+		}
+		count := 0
+		for _, match := range instance.matchIndex {
+			if match >= index {
+				count++
+			}
+		}
+		if count > instance.N/2 {
+			logger.Debugf("Leader %d committing entries up to %d", instance.id, index)
+			instance.commitIndex = index
+			instance.applyCommitted()
+			return
+		}
+	}
+}
+
+// =============================================================================
+// execution and snapshots
+// =============================================================================
+
+// applyCommitted executes the next committed entry, one at a time
+func (instance *raftCore) applyCommitted() {
+	for instance.lastApplied < instance.commitIndex {
+		if instance.currentExec != nil || instance.skipInProgress {
+			return
+		}
+
+		entry := instance.log.entry(instance.lastApplied + 1)
+		if entry == nil {
+			logger.Errorf("Replica %d cannot apply entry %d, it is not in the log", instance.id, instance.lastApplied+1)
+			return
+		}
+
+		if len(entry.Transactions) == 0 {
+			// the empty entries a leader starts its term with do not result in a block
+			instance.lastApplied = entry.Index
+			continue
+		}
+
+		instance.execute(entry)
+		return
+	}
+
+	instance.maybeSnapshot()
+}
+
+func (instance *raftCore) execute(entry *Entry) {
+	var txs []*pb.Transaction
+	instance.currentTxids = nil
+	for _, raw := range entry.Transactions {
+		tx := unmarshalTransaction(raw)
+		if tx == nil {
+			continue
+		}
+		txs = append(txs, tx)
+		instance.currentTxids = append(instance.currentTxids, tx.Txid)
+	}
+
+	logger.Debugf("Replica %d executing entry %d containing %d transactions", instance.id, entry.Index, len(txs))
+	instance.currentExec = entry
+	instance.stack.Execute(entry, txs) // This executes in the background, we will receive an executedEvent once it completes
+}
+
+func (instance *raftCore) executed(tag interface{}) {
+	entry, ok := tag.(*Entry)
+	if !ok || entry != instance.currentExec {
+		logger.Warningf("Replica %d received an unexpected execution result", instance.id)
+		return
+	}
+	meta, _ := proto.Marshal(&Metadata{Index: entry.Index, Term: entry.Term})
+	instance.stack.Commit(entry, meta)
+}
+
+func (instance *raftCore) committed(tag interface{}) {
+	entry, ok := tag.(*Entry)
+	if !ok || entry != instance.currentExec {
+		logger.Warningf("Replica %d received an unexpected commit result", instance.id)
+		return
+	}
+
+	logger.Debugf("Replica %d applied entry %d", instance.id, entry.Index)
+	instance.lastApplied = entry.Index
+	instance.currentExec = nil
+	for _, txid := range instance.currentTxids {
+		delete(instance.outstanding, txid)
+	}
+	instance.currentTxids = nil
+
+	instance.applyCommitted()
+}
+
+// maybeSnapshot compacts the log once enough entries have been applied since the last snapshot
+func (instance *raftCore) maybeSnapshot() {
+	if instance.snapshotPeriod == 0 || instance.lastApplied-instance.log.snapshot.Index < instance.snapshotPeriod {
+		return
+	}
+	term, ok := instance.log.term(instance.lastApplied)
+	if !ok {
+		return
+	}
+
+	logger.Infof("Replica %d taking a snapshot at entry %d", instance.id, instance.lastApplied)
+	instance.log.compact(&Snapshot{
+		Index: instance.lastApplied,
+		Term:  term,
+		Id:    instance.stack.GetBlockchainInfoBlob(),
+	})
+}
+
+func (instance *raftCore) recvSnapshot(msg *Message, from uint64) {
+	if msg.Term < instance.term {
+		instance.rejectAppend(from, instance.log.lastIndex())
+		return
+	}
+	instance.followLeader(from)
+
+	snapshot := msg.Snapshot
+	if snapshot == nil || instance.skipInProgress || instance.currentExec != nil {
+		return
+	}
+
+	if snapshot.Index <= instance.commitIndex {
+		// our committed entries are the ones of the leader
+		instance.send(&Message{
+			Type:     Message_APPEND_RESPONSE,
+			Term:     instance.term,
+			LogIndex: instance.commitIndex,
+		}, from)
+		return
+	}
+
+	target := &pb.BlockchainInfo{}
+	if err := proto.Unmarshal(snapshot.Id, target); err != nil {
+		logger.Warningf("Replica %d received a snapshot it could not unmarshal: %v", instance.id, err)
+		return
+	}
+
+	logger.Infof("Replica %d is behind the snapshot of leader %d at entry %d, initiating state transfer", instance.id, from, snapshot.Index)
+	instance.skipInProgress = true
+	instance.stack.InvalidateState()
+	instance.stack.UpdateState(snapshot, target, []*pb.PeerID{getValidatorHandle(from)})
+}
+
+func (instance *raftCore) stateUpdated(tag interface{}, target *pb.BlockchainInfo) {
+	snapshot, ok := tag.(*Snapshot)
+	if !ok || !instance.skipInProgress {
+		logger.Warningf("Replica %d received an unexpected state update", instance.id)
+		return
+	}
+	instance.skipInProgress = false
+
+	if target == nil {
+		logger.Warningf("Replica %d could not transfer state to entry %d, waiting for the leader to retry", instance.id, snapshot.Index)
+		return
+	}
+
+	logger.Infof("Replica %d completed state transfer to entry %d", instance.id, snapshot.Index)
+	instance.log.compact(snapshot)
+	if snapshot.Index > instance.commitIndex {
+		instance.commitIndex = snapshot.Index
+	}
+	instance.lastApplied = snapshot.Index
+	// the outstanding transactions may have been applied while we were behind
+	instance.outstanding = make(map[string][]byte)
+	instance.stack.ValidateState()
+
+	if instance.leader != noLeader {
+		instance.send(&Message{
+			Type:     Message_APPEND_RESPONSE,
+			Term:     instance.term,
+			LogIndex: snapshot.Index,
+		}, instance.leader)
+	}
+
+	instance.applyCommitted()
+}
+
+// =============================================================================
+// helper functions
+// =============================================================================
+
+// send marshals msg and queues it for delivery to replica to
+func (instance *raftCore) send(msg *Message, to uint64) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		logger.Errorf("Replica %d could not marshal message: %v", instance.id, err)
+		return
+	}
+	instance.sender.send(&pb.Message{
+		Type:    pb.Message_CONSENSUS,
+		Payload: payload,
+	}, to)
+}
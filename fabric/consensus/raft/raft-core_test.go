@@ -0,0 +1,393 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/consensus/util/testnet"
+	pb "github.com/hyperledger/fabric/protos"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/spf13/viper"
+)
+
+// partitions decides which replicas of the test network can talk to each
+// other, messages only flow between replicas of the same group
+type partitions struct {
+	mutex sync.Mutex
+	group []int
+}
+
+func (p *partitions) filter(src, dst int, payload []byte) []byte {
+	if dst == -1 {
+		return payload
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.group[src] != p.group[dst] {
+		return nil
+	}
+	return payload
+}
+
+// isolate cuts the given replicas off from the others
+func (p *partitions) isolate(ids ...int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for _, id := range ids {
+		p.group[id] = 1
+	}
+}
+
+func (p *partitions) heal() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for i := range p.group {
+		p.group[i] = 0
+	}
+}
+
+func makeRaftNetwork(N int, setConfig func(config *viper.Viper)) (*testnet.Network, *partitions) {
+	config := loadConfig()
+	config.Set("general.N", N)
+	config.Set("general.batchsize", 2)
+	config.Set("general.timeout.batch", "20ms")
+	config.Set("general.timeout.heartbeat", "20ms")
+	config.Set("general.timeout.election", "150ms")
+	if setConfig != nil {
+		setConfig(config)
+	}
+
+	p := &partitions{group: make([]int, N)}
+	net := testnet.New(N, func(id uint64, net *testnet.Network) testnet.Endpoint {
+		return &raftEndpoint{
+			TestEndpoint: testnet.NewTestEndpoint(id, net),
+			mockLedger:   newMockLedger(),
+			mockPersist:  newMockPersist(),
+			config:       config,
+		}
+	})
+	net.FilterFn = p.filter
+	for _, ep := range net.Endpoints {
+		ep.(*raftEndpoint).start()
+	}
+	go net.ProcessContinually()
+	return net, p
+}
+
+func replica(net *testnet.Network, id int) *raftEndpoint {
+	return net.Endpoints[id].(*raftEndpoint)
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	deadline := time.Now().Add(20 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type replicaState struct {
+	role        role
+	term        uint64
+	leader      uint64
+	commitIndex uint64
+	lastApplied uint64
+	snapshot    uint64
+}
+
+func (ep *raftEndpoint) state() (state replicaState, running bool) {
+	running = ep.inspect(func(instance *raftCore) {
+		state = replicaState{
+			role:        instance.role,
+			term:        instance.term,
+			leader:      instance.leader,
+			commitIndex: instance.commitIndex,
+			lastApplied: instance.lastApplied,
+			snapshot:    instance.log.snapshot.Index,
+		}
+	})
+	return
+}
+
+// waitForLeader waits until the given replicas agree on a leader of a term
+// greater than minTerm, and returns it along with the term
+func waitForLeader(t *testing.T, net *testnet.Network, ids []int, minTerm uint64) (leaderID int, term uint64) {
+	waitFor(t, fmt.Sprintf("replicas %v to elect a leader after term %d", ids, minTerm), func() bool {
+		var states []replicaState
+		for _, id := range ids {
+			state, running := replica(net, id).state()
+			if !running {
+				return false
+			}
+			states = append(states, state)
+		}
+		for i, state := range states {
+			if state.leader == noLeader || state.term <= minTerm ||
+				state.leader != states[0].leader || state.term != states[0].term {
+				return false
+			}
+			if uint64(ids[i]) == state.leader && state.role != leader {
+				return false
+			}
+		}
+		leaderID, term = int(states[0].leader), states[0].term
+		return true
+	})
+	return
+}
+
+func createTx(tag int) *pb.Transaction {
+	return &pb.Transaction{
+		Type:    pb.Transaction_CHAINCODE_INVOKE,
+		Txid:    fmt.Sprintf("tx%d", tag),
+		Payload: []byte(fmt.Sprint(tag)),
+	}
+}
+
+// waitForLedgers waits until the ledgers of the given replicas hold count
+// transactions, and checks that they are identical
+func waitForLedgers(t *testing.T, net *testnet.Network, ids []int, count int) {
+	waitFor(t, fmt.Sprintf("replicas %v to apply %d transactions", ids, count), func() bool {
+		for _, id := range ids {
+			if len(replica(net, id).txids()) < count {
+				return false
+			}
+		}
+		return true
+	})
+
+	reference := replica(net, ids[0])
+	txids := reference.txids()
+	if len(txids) != count {
+		t.Fatalf("Replica %d applied %d transactions, expected %d: %v", ids[0], len(txids), count, txids)
+	}
+	seen := make(map[string]bool)
+	for _, txid := range txids {
+		if seen[txid] {
+			t.Fatalf("Replica %d applied transaction %s twice: %v", ids[0], txid, txids)
+		}
+		seen[txid] = true
+	}
+	for _, id := range ids[1:] {
+		if !reflect.DeepEqual(replica(net, id).GetBlockchainInfo(), reference.GetBlockchainInfo()) {
+			t.Fatalf("Replica %d has ledger %v, replica %d has %v", id, replica(net, id).txids(), ids[0], txids)
+		}
+	}
+}
+
+func others(N int, excluded ...int) []int {
+	var ids []int
+outer:
+	for i := 0; i < N; i++ {
+		for _, e := range excluded {
+			if i == e {
+				continue outer
+			}
+		}
+		ids = append(ids, i)
+	}
+	return ids
+}
+
+func TestNetworkReplication(t *testing.T) {
+	N := 3
+	net, _ := makeRaftNetwork(N, nil)
+	defer net.Stop()
+
+	leaderID, _ := waitForLeader(t, net, others(N), 0)
+	for i := 0; i < 5; i++ {
+		replica(net, (leaderID+i)%N).submit(createTx(i))
+	}
+	waitForLedgers(t, net, others(N), 5)
+
+	block, err := replica(net, 0).GetBlock(1)
+	if err != nil {
+		t.Fatalf("Expected a block at height 1: %v", err)
+	}
+	if len(block.ConsensusMetadata) == 0 {
+		t.Errorf("Expected the block to carry the index of its entry")
+	}
+}
+
+func TestSingleReplica(t *testing.T) {
+	net, _ := makeRaftNetwork(1, nil)
+	defer net.Stop()
+
+	waitForLeader(t, net, []int{0}, 0)
+	replica(net, 0).submit(createTx(1))
+	waitForLedgers(t, net, []int{0}, 1)
+}
+
+func TestLeaderCrash(t *testing.T) {
+	N := 3
+	net, p := makeRaftNetwork(N, nil)
+	defer net.Stop()
+
+	oldLeader, oldTerm := waitForLeader(t, net, others(N), 0)
+	for i := 0; i < 3; i++ {
+		replica(net, (oldLeader+1)%N).submit(createTx(i))
+	}
+	waitForLedgers(t, net, others(N), 3)
+
+	p.isolate(oldLeader)
+	replica(net, oldLeader).Stop()
+
+	survivors := others(N, oldLeader)
+	newLeader, newTerm := waitForLeader(t, net, survivors, oldTerm)
+	if newLeader == oldLeader {
+		t.Fatalf("Crashed replica %d cannot be the leader of term %d", oldLeader, newTerm)
+	}
+	for i := 3; i < 6; i++ {
+		replica(net, survivors[i%len(survivors)]).submit(createTx(i))
+	}
+	waitForLedgers(t, net, survivors, 6)
+
+	p.heal()
+	replica(net, oldLeader).start()
+	state, _ := replica(net, oldLeader).state()
+	if state.term < oldTerm {
+		t.Errorf("Restarted replica %d should have restored term %d, has %d", oldLeader, oldTerm, state.term)
+	}
+	if state.lastApplied == 0 {
+		t.Errorf("Restarted replica %d should have restored the index of its last applied entry", oldLeader)
+	}
+
+	waitForLedgers(t, net, others(N), 6)
+	waitForLeader(t, net, others(N), oldTerm)
+}
+
+func TestPartitionHealing(t *testing.T) {
+	N := 3
+	net, p := makeRaftNetwork(N, nil)
+	defer net.Stop()
+
+	oldLeader, oldTerm := waitForLeader(t, net, others(N), 0)
+	replica(net, oldLeader).submit(createTx(0))
+	waitForLedgers(t, net, others(N), 1)
+
+	// The old leader keeps accepting transactions, but cannot commit them
+	p.isolate(oldLeader)
+	replica(net, oldLeader).submit(createTx(1))
+	replica(net, oldLeader).submit(createTx(2))
+
+	majority := others(N, oldLeader)
+	_, newTerm := waitForLeader(t, net, majority, oldTerm)
+	for i := 3; i < 6; i++ {
+		replica(net, majority[i%len(majority)]).submit(createTx(i))
+	}
+	waitForLedgers(t, net, majority, 4)
+
+	if txids := replica(net, oldLeader).txids(); len(txids) != 1 {
+		t.Fatalf("Isolated replica %d should not have applied anything, has %v", oldLeader, txids)
+	}
+
+	p.heal()
+
+	// The old leader steps down, discards its uncommitted entries, and the
+	// transactions it held are ordered by the new leader
+	waitForLedgers(t, net, others(N), 6)
+	_, term := waitForLeader(t, net, others(N), oldTerm)
+	if term < newTerm {
+		t.Errorf("Expected the network to be at least at term %d, is at %d", newTerm, term)
+	}
+	state, _ := replica(net, oldLeader).state()
+	if state.role == leader && state.term == oldTerm {
+		t.Errorf("Replica %d should have stepped down as leader of term %d", oldLeader, oldTerm)
+	}
+}
+
+func TestSnapshotStateTransfer(t *testing.T) {
+	N := 3
+	net, p := makeRaftNetwork(N, func(config *viper.Viper) {
+		config.Set("general.batchsize", 1)
+		config.Set("general.snapshotperiod", 2)
+	})
+	defer net.Stop()
+
+	leaderID, _ := waitForLeader(t, net, others(N), 0)
+	lagging := (leaderID + 1) % N
+	p.isolate(lagging)
+	replica(net, lagging).Stop()
+
+	up := others(N, lagging)
+	for i := 0; i < 6; i++ {
+		replica(net, leaderID).submit(createTx(i))
+		waitForLedgers(t, net, up, i+1)
+	}
+	state, _ := replica(net, leaderID).state()
+	if state.snapshot == 0 {
+		t.Fatalf("Leader %d should have compacted its log", leaderID)
+	}
+
+	p.heal()
+	replica(net, lagging).start()
+	waitForLedgers(t, net, others(N), 6)
+	if atomic.LoadInt32(&replica(net, lagging).stateTransfers) == 0 {
+		t.Errorf("Replica %d should have caught up through state transfer", lagging)
+	}
+
+	// Replication carries on from the snapshot
+	replica(net, lagging).submit(createTx(6))
+	waitForLedgers(t, net, others(N), 7)
+}
+
+func TestPersistFailureWithholdsVote(t *testing.T) {
+	N := 3
+	net, p := makeRaftNetwork(N, nil)
+	defer net.Stop()
+
+	leaderID, term := waitForLeader(t, net, others(N), 0)
+	followerID := (leaderID + 1) % N
+	p.isolate(followerID)
+	follower := replica(net, followerID)
+	follower.failStores(true)
+	stored, _ := follower.ReadState(hardStateKey)
+
+	follower.inspect(func(instance *raftCore) {
+		instance.campaign()
+		if instance.role == candidate || instance.term != term || instance.vote == uint64(followerID)+1 {
+			t.Errorf("Expected no election without a persisted term, got role %d in term %d voting %d", instance.role, instance.term, instance.vote)
+		}
+
+		raw, _ := proto.Marshal(&Message{
+			Type:     Message_VOTE,
+			Term:     term + 1,
+			LogIndex: instance.log.lastIndex(),
+			LogTerm:  instance.log.lastTerm(),
+		})
+		instance.recvMessage(&pb.Message{Type: pb.Message_CONSENSUS, Payload: raw}, replica(net, leaderID).GetHandle())
+		if instance.vote != 0 {
+			t.Errorf("Expected the vote to be withheld without persisting it, got a vote for %d", instance.vote-1)
+		}
+	})
+	if raw, _ := follower.ReadState(hardStateKey); !reflect.DeepEqual(raw, stored) {
+		t.Errorf("Expected the persisted term to be unchanged")
+	}
+
+	follower.failStores(false)
+	p.heal()
+	waitForLeader(t, net, others(N), term)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package raft
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/consensus"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+// sender hands messages over to the network without blocking the replica.
+// Each destination has its own queue, messages to a replica whose queue is
+// full are dropped, raft recovers from lost messages by retransmission.
+type sender struct {
+	comm     consensus.Communicator
+	queues   map[uint64]chan *pb.Message
+	closed   sync.WaitGroup
+	closedCh chan struct{}
+}
+
+func newSender(self uint64, N int, comm consensus.Communicator) *sender {
+	queueSize := 50
+
+	s := &sender{
+		comm:     comm,
+		queues:   make(map[uint64]chan *pb.Message),
+		closedCh: make(chan struct{}),
+	}
+	for i := uint64(0); i < uint64(N); i++ {
+		if i == self {
+			continue
+		}
+		s.queues[i] = make(chan *pb.Message, queueSize)
+	}
+
+	// We do not start the go routines in the above loop to avoid concurrent map read/writes
+	for dest, queue := range s.queues {
+		s.closed.Add(1)
+		go s.drain(dest, queue)
+	}
+
+	return s
+}
+
+// send queues msg for delivery to replica dest
+func (s *sender) send(msg *pb.Message, dest uint64) {
+	queue, ok := s.queues[dest]
+	if !ok {
+		logger.Warningf("Replica cannot send to unknown replica %d", dest)
+		return
+	}
+	select {
+	case queue <- msg:
+	case <-s.closedCh:
+	default:
+		logger.Debugf("Queue to replica %d is full, dropping message", dest)
+	}
+}
+
+func (s *sender) drain(dest uint64, queue chan *pb.Message) {
+	defer s.closed.Done()
+
+	handle := getValidatorHandle(dest)
+	successLastTime := true
+	for {
+		select {
+		case msg := <-queue:
+			// successLastTime is only used to avoid flooding the log when a replica is unreachable
+			if err := s.comm.Unicast(msg, handle); err != nil {
+				if successLastTime {
+					logger.Warningf("Could not send to replica %d: %v", dest, err)
+				}
+				successLastTime = false
+				continue
+			}
+			successLastTime = true
+		case <-s.closedCh:
+			return
+		}
+	}
+}
+
+// close stops the delivery of queued messages
+func (s *sender) close() {
+	close(s.closedCh)
+	s.closed.Wait()
+}
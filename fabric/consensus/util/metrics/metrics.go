@@ -0,0 +1,312 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the media type of the Prometheus text format written by WriteText
+const ContentType = "text/plain; version=0.0.4"
+
+// DefaultBuckets are the histogram buckets used for latencies in seconds
+var DefaultBuckets = []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// BatchSizeBuckets are the histogram buckets used for the number of
+// transactions ordered together
+var BatchSizeBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000}
+
+const (
+	counterType   = "counter"
+	gaugeType     = "gauge"
+	histogramType = "histogram"
+)
+
+// Registry holds the metrics published by a consensus plugin, it may be
+// updated and read from different goroutines
+type Registry struct {
+	lock     sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// NewCounter registers a counter, which may only be incremented
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{r.register(name, help, counterType, nil, labels)}
+}
+
+// NewGauge registers a gauge, which may be set to arbitrary values
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r.register(name, help, gaugeType, nil, labels)}
+}
+
+// NewHistogram registers a histogram counting observations into the given
+// upper bounds, which must be sorted
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return &Histogram{r.register(name, help, histogramType, buckets, labels)}
+}
+
+func (r *Registry) register(name, help, kind string, buckets []float64, labels []string) *family {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.families[name]; ok {
+		panic(fmt.Sprintf("metric %s registered twice", name))
+	}
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		buckets: buckets,
+		labels:  labels,
+		series:  make(map[string]*series),
+	}
+	if len(labels) == 0 {
+		// Publish unlabelled metrics before their first update
+		f.get(nil)
+	}
+	r.families[name] = f
+	return f
+}
+
+// Counter is a metric which only goes up, e.g. the number of view changes
+type Counter struct {
+	*family
+}
+
+// Inc increments the counter by one
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increments the counter by delta, which must not be negative
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.name))
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.get(labelValues).value += delta
+}
+
+// Gauge is a metric which may go up and down, e.g. the current view
+type Gauge struct {
+	*family
+}
+
+// Set sets the gauge to v
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.get(labelValues).value = v
+}
+
+// Histogram counts observations into buckets, e.g. message latencies
+type Histogram struct {
+	*family
+}
+
+// Observe adds an observation to the histogram
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	s := h.get(labelValues)
+	for i, bound := range h.buckets {
+		if v <= bound {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.value += v
+}
+
+type family struct {
+	lock    sync.Mutex
+	name    string
+	help    string
+	kind    string
+	buckets []float64
+	labels  []string
+	series  map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64  // the value of a counter or gauge, the sum of a histogram
+	counts      []uint64 // per bucket, cumulative
+	count       uint64
+}
+
+// get returns the series for the label values, the family lock must be held
+func (f *family) get(labelValues []string) *series {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metric %s has labels %v, got values %v", f.name, f.labels, labelValues))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+	if !ok {
+		s = &series{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(f.buckets)),
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// sorted returns copies of the series ordered by label values
+func (f *family) sorted() []series {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]series, len(keys))
+	for i, key := range keys {
+		result[i] = *f.series[key]
+		result[i].counts = append([]uint64(nil), result[i].counts...)
+	}
+	return result
+}
+
+func (r *Registry) sortedFamilies() []*family {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	result := make([]*family, len(names))
+	for i, name := range names {
+		result[i] = r.families[name]
+	}
+	return result
+}
+
+// Sample is the current value of a single metric, histograms are reported
+// as their count and sum
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// Samples returns the current value of every metric, ordered by name
+func (r *Registry) Samples() []Sample {
+	var samples []Sample
+	for _, f := range r.sortedFamilies() {
+		for _, s := range f.sorted() {
+			labels := make(map[string]string)
+			for i, label := range f.labels {
+				labels[label] = s.labelValues[i]
+			}
+			if f.kind != histogramType {
+				samples = append(samples, Sample{Name: f.name, Labels: labels, Value: s.value})
+				continue
+			}
+			samples = append(samples,
+				Sample{Name: f.name + "_count", Labels: labels, Value: float64(s.count)},
+				Sample{Name: f.name + "_sum", Labels: labels, Value: s.value})
+		}
+	}
+	return samples
+}
+
+// WriteText writes all metrics in the Prometheus text exposition format
+func (r *Registry) WriteText(w io.Writer) error {
+	for _, f := range r.sortedFamilies() {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, f.kind); err != nil {
+			return err
+		}
+		for _, s := range f.sorted() {
+			var err error
+			if f.kind != histogramType {
+				_, err = fmt.Fprintf(w, "%s%s %s\n", f.name, formatLabels(f.labels, s.labelValues, "", ""), formatValue(s.value))
+			} else {
+				err = writeHistogram(w, f, &s)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeHistogram(w io.Writer, f *family, s *series) error {
+	for i, bound := range f.buckets {
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "le", formatValue(bound)), s.counts[i]); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", f.name, formatLabels(f.labels, s.labelValues, "le", "+Inf"), s.count); err != nil {
+		return err
+	}
+	labels := formatLabels(f.labels, s.labelValues, "", "")
+	_, err := fmt.Fprintf(w, "%s_sum%s %s\n%s_count%s %d\n", f.name, labels, formatValue(s.value), f.name, labels, s.count)
+	return err
+}
+
+func formatLabels(labels, values []string, extraLabel, extraValue string) string {
+	var pairs []string
+	for i, label := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", label, escapeLabelValue(values[i])))
+	}
+	if extraLabel != "" {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", extraLabel, extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer("\\", `\\`, "\n", `\n`)
+
+var labelValueEscaper = strings.NewReplacer("\\", `\\`, "\n", `\n`, "\"", `\"`)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	view := r.NewGauge("consensus_pbft_view", "Current view")
+	changes := r.NewCounter("consensus_pbft_view_changes_total", "View changes\nstarted", "reason")
+	latency := r.NewHistogram("consensus_pbft_message_latency_seconds", "Message latency", []float64{0.1, 1}, "replica")
+
+	view.Set(3)
+	changes.Inc("timeout")
+	changes.Add(2, `new "primary"`)
+	latency.Observe(0.05, "1")
+	latency.Observe(0.5, "1")
+	latency.Observe(2, "0")
+
+	expected := `# HELP consensus_pbft_message_latency_seconds Message latency
+# TYPE consensus_pbft_message_latency_seconds histogram
+consensus_pbft_message_latency_seconds_bucket{replica="0",le="0.1"} 0
+consensus_pbft_message_latency_seconds_bucket{replica="0",le="1"} 0
+consensus_pbft_message_latency_seconds_bucket{replica="0",le="+Inf"} 1
+consensus_pbft_message_latency_seconds_sum{replica="0"} 2
+consensus_pbft_message_latency_seconds_count{replica="0"} 1
+consensus_pbft_message_latency_seconds_bucket{replica="1",le="0.1"} 1
+consensus_pbft_message_latency_seconds_bucket{replica="1",le="1"} 2
+consensus_pbft_message_latency_seconds_bucket{replica="1",le="+Inf"} 2
+consensus_pbft_message_latency_seconds_sum{replica="1"} 0.55
+consensus_pbft_message_latency_seconds_count{replica="1"} 2
+# HELP consensus_pbft_view Current view
+# TYPE consensus_pbft_view gauge
+consensus_pbft_view 3
+# HELP consensus_pbft_view_changes_total View changes\nstarted
+# TYPE consensus_pbft_view_changes_total counter
+consensus_pbft_view_changes_total{reason="new \"primary\""} 2
+consensus_pbft_view_changes_total{reason="timeout"} 1
+`
+	buf := &bytes.Buffer{}
+	if err := r.WriteText(buf); err != nil {
+		t.Fatalf("Failed to write metrics: %s", err)
+	}
+	if buf.String() != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, buf.String())
+	}
+}
+
+func TestSamples(t *testing.T) {
+	r := NewRegistry()
+	r.NewGauge("b", "unlabelled gauges are published before being set")
+	r.NewCounter("c", "labelled counters are not", "replica")
+	r.NewHistogram("a", "histograms report count and sum", DefaultBuckets, "replica").Observe(0.25, "2")
+
+	expected := []Sample{
+		{Name: "a_count", Labels: map[string]string{"replica": "2"}, Value: 1},
+		{Name: "a_sum", Labels: map[string]string{"replica": "2"}, Value: 0.25},
+		{Name: "b", Labels: map[string]string{}, Value: 0},
+	}
+	if samples := r.Samples(); !reflect.DeepEqual(samples, expected) {
+		t.Errorf("Expected samples %v, got %v", expected, samples)
+	}
+}
+
+func TestMisuse(t *testing.T) {
+	r := NewRegistry()
+	g := r.NewGauge("g", "gauge", "replica")
+	for name, misuse := range map[string]func(){
+		"registered twice": func() { r.NewCounter("g", "counter") },
+		"missing label":    func() { g.Set(1) },
+		"negative counter": func() { r.NewCounter("c", "counter").Add(-1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected a panic when a metric is %s", name)
+				}
+			}()
+			misuse()
+		}()
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package core
 
 import (
+	"fmt"
 	"os"
 	"runtime"
 
@@ -25,6 +26,7 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/consensus/util/metrics"
 	pb "github.com/hyperledger/fabric/protos"
 )
 
@@ -36,8 +38,20 @@ func NewAdminServer() *ServerAdmin {
 	return s
 }
 
+// NewAdminServerWithConsensus creates an Admin service instance which reports
+// the metrics returned by consensusMetrics in the server status, and passes
+// changes of the validator set on to reconfigure
+func NewAdminServerWithConsensus(consensusMetrics func() *metrics.Registry, reconfigure func(replicas []uint64) error) *ServerAdmin {
+	s := new(ServerAdmin)
+	s.consensusMetrics = consensusMetrics
+	s.reconfigure = reconfigure
+	return s
+}
+
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
+	consensusMetrics func() *metrics.Registry
+	reconfigure      func(replicas []uint64) error
 }
 
 func worker(id int, die chan struct{}) {
@@ -53,13 +67,29 @@ func worker(id int, die chan struct{}) {
 	}
 }
 
-// GetStatus reports the status of the server
-func (*ServerAdmin) GetStatus(context.Context, *empty.Empty) (*pb.ServerStatus, error) {
-	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED}
+// GetStatus reports the status of the server, and the metrics of the
+// consensus plugin on a validating peer
+func (s *ServerAdmin) GetStatus(context.Context, *empty.Empty) (*pb.ServerStatus, error) {
+	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED, ConsensusMetrics: s.getConsensusMetrics()}
 	log.Debugf("returning status: %s", status)
 	return status, nil
 }
 
+func (s *ServerAdmin) getConsensusMetrics() []*pb.ConsensusMetric {
+	if s.consensusMetrics == nil {
+		return nil
+	}
+	registry := s.consensusMetrics()
+	if registry == nil {
+		return nil
+	}
+	var result []*pb.ConsensusMetric
+	for _, sample := range registry.Samples() {
+		result = append(result, &pb.ConsensusMetric{Name: sample.Name, Labels: sample.Labels, Value: sample.Value})
+	}
+	return result
+}
+
 // StartServer starts the server
 func (*ServerAdmin) StartServer(context.Context, *empty.Empty) (*pb.ServerStatus, error) {
 	status := &pb.ServerStatus{Status: pb.ServerStatus_STARTED}
@@ -67,6 +97,20 @@ func (*ServerAdmin) StartServer(context.Context, *empty.Empty) (*pb.ServerStatus
 	return status, nil
 }
 
+// ReconfigureConsensus votes, on behalf of this validating peer, to change
+// the validator set of the consensus plugin
+func (s *ServerAdmin) ReconfigureConsensus(ctx context.Context, membership *pb.ConsensusMembership) (*pb.ServerStatus, error) {
+	if s.reconfigure == nil {
+		return nil, fmt.Errorf("Only validating peers take part in consensus")
+	}
+	log.Infof("Voting to change the validator set to %v", membership.Replicas)
+	if err := s.reconfigure(membership.Replicas); err != nil {
+		log.Errorf("Could not vote to change the validator set: %s", err)
+		return nil, err
+	}
+	return &pb.ServerStatus{Status: pb.ServerStatus_STARTED}, nil
+}
+
 // StopServer stops the server
 func (*ServerAdmin) StopServer(context.Context, *empty.Empty) (*pb.ServerStatus, error) {
 	status := &pb.ServerStatus{Status: pb.ServerStatus_STOPPED}
@@ -16,9 +16,67 @@ limitations under the License.
 
 package core
 
-import "testing"
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/consensus/util/metrics"
+	pb "github.com/hyperledger/fabric/protos"
+	"golang.org/x/net/context"
+)
 
 func TestServer_Status(t *testing.T) {
 	t.Skip("TBD")
 	//performHandshake(t, peerClientConn)
 }
+
+func TestServer_StatusReportsConsensusMetrics(t *testing.T) {
+	registry := metrics.NewRegistry()
+	registry.NewGauge("consensus_sequence_number", "").Set(7)
+	registry.NewCounter("consensus_pbft_view_changes_total", "", "replica").Inc("2")
+
+	status, err := NewAdminServerWithConsensus(func() *metrics.Registry { return registry }, nil).GetStatus(context.Background(), &empty.Empty{})
+	if err != nil {
+		t.Fatalf("Failed to get status: %s", err)
+	}
+	expected := []*pb.ConsensusMetric{
+		{Name: "consensus_pbft_view_changes_total", Labels: map[string]string{"replica": "2"}, Value: 1},
+		{Name: "consensus_sequence_number", Labels: map[string]string{}, Value: 7},
+	}
+	if status.Status != pb.ServerStatus_STARTED || !reflect.DeepEqual(status.ConsensusMetrics, expected) {
+		t.Errorf("Expected started status with metrics %v, got %v", expected, status)
+	}
+
+	status, _ = NewAdminServer().GetStatus(context.Background(), &empty.Empty{})
+	if len(status.ConsensusMetrics) != 0 {
+		t.Errorf("Expected no consensus metrics without a consensus plugin, got %v", status.ConsensusMetrics)
+	}
+}
+
+func TestServer_ReconfigureConsensus(t *testing.T) {
+	var voted []uint64
+	admin := NewAdminServerWithConsensus(nil, func(replicas []uint64) error {
+		voted = replicas
+		return nil
+	})
+	status, err := admin.ReconfigureConsensus(context.Background(), &pb.ConsensusMembership{Replicas: []uint64{0, 1, 2, 3, 4}})
+	if err != nil || status.Status != pb.ServerStatus_STARTED {
+		t.Fatalf("Expected the vote to be submitted, got %v, %v", status, err)
+	}
+	if !reflect.DeepEqual(voted, []uint64{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected a vote for validator set [0 1 2 3 4], got %v", voted)
+	}
+
+	admin = NewAdminServerWithConsensus(nil, func(replicas []uint64) error {
+		return fmt.Errorf("invalid validator set")
+	})
+	if _, err = admin.ReconfigureConsensus(context.Background(), &pb.ConsensusMembership{}); err == nil {
+		t.Errorf("Expected the error of the consensus plugin to be returned")
+	}
+
+	if _, err = NewAdminServer().ReconfigureConsensus(context.Background(), &pb.ConsensusMembership{Replicas: []uint64{0}}); err == nil {
+		t.Errorf("Expected non-validating peers to refuse changing the validator set")
+	}
+}
@@ -0,0 +1,353 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/attr"
+	"github.com/hyperledger/fabric/core/crypto"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+// aclAnyFunction is the function of the ACL entry applying to the functions
+// without an entry of their own
+const aclAnyFunction = "*"
+
+// aclUpgradeFunction is the function of the ACL entry applying to the upgrades
+// of the chaincode
+const aclUpgradeFunction = "@upgrade"
+
+// chaincodeACLs caches the access control lists of the deployed chaincodes.
+// It implements ledger.StateListener and ledger.BatchListener. The ACLs of
+// committed deployments and upgrades are read lazily from the ledger, and
+// dropped when an upgrade is committed. The ACLs of the deployments and
+// upgrades of the batch in progress are pending until the batch ends, and are
+// discarded if it is rolled back. A nil ACL is cached for the chaincodes
+// deployed without one.
+type chaincodeACLs struct {
+	sync.RWMutex
+	acls    map[string]*pb.ChaincodeACL
+	pending map[string]*pb.ChaincodeACL
+
+	// generation is incremented whenever committed ACLs are dropped, so that
+	// an ACL read from the ledger before is not cached
+	generation uint64
+}
+
+func newChaincodeACLs() *chaincodeACLs {
+	return &chaincodeACLs{acls: make(map[string]*pb.ChaincodeACL), pending: make(map[string]*pb.ChaincodeACL)}
+}
+
+// get returns the ACL of chaincode, if it is known, and the generation to
+// pass to put otherwise
+func (acls *chaincodeACLs) get(chaincode string) (*pb.ChaincodeACL, bool, uint64) {
+	acls.RLock()
+	defer acls.RUnlock()
+	if acl, ok := acls.pending[chaincode]; ok {
+		return acl, true, acls.generation
+	}
+	acl, ok := acls.acls[chaincode]
+	return acl, ok, acls.generation
+}
+
+// put caches the committed ACL of chaincode read from the ledger at
+// generation. It is not cached if an upgrade was committed since.
+func (acls *chaincodeACLs) put(chaincode string, acl *pb.ChaincodeACL, generation uint64) {
+	acls.Lock()
+	defer acls.Unlock()
+	if acls.generation == generation {
+		acls.acls[chaincode] = acl
+	}
+}
+
+// putPending records the ACL of a deployment or upgrade of the batch in
+// progress, for the invocations of the same batch
+func (acls *chaincodeACLs) putPending(chaincode string, acl *pb.ChaincodeACL) {
+	acls.Lock()
+	defer acls.Unlock()
+	acls.pending[chaincode] = acl
+}
+
+// BatchEnded implements ledger.BatchListener. The committed ACLs of the
+// chaincodes deployed or upgraded by a committed batch are read from the
+// ledger again.
+func (acls *chaincodeACLs) BatchEnded(committed bool) {
+	acls.Lock()
+	defer acls.Unlock()
+	if len(acls.pending) == 0 {
+		return
+	}
+	if committed {
+		for chaincode := range acls.pending {
+			delete(acls.acls, chaincode)
+		}
+		acls.generation++
+	}
+	acls.pending = make(map[string]*pb.ChaincodeACL)
+}
+
+// StateChanged implements ledger.StateListener. It drops the ACLs of the
+// chaincodes whose upgrade was committed through state transfer.
+func (acls *chaincodeACLs) StateChanged(updates map[string][]string) {
+	acls.Lock()
+	defer acls.Unlock()
+	if updates == nil {
+		acls.acls = make(map[string]*pb.ChaincodeACL)
+		acls.generation++
+		return
+	}
+	// The version history of an upgraded chaincode is keyed by its name
+	for _, upgraded := range updates[chaincodeVersionsNamespace] {
+		delete(acls.acls, upgraded)
+		acls.generation++
+	}
+}
+
+// validateACL checks the access control list a chaincode is deployed with
+func validateACL(acl *pb.ChaincodeACL) error {
+	functions := make(map[string]bool)
+	for _, entry := range acl.GetEntries() {
+		if entry.Function == "" {
+			return fmt.Errorf("Invalid access control list: an entry has no function")
+		}
+		if functions[entry.Function] {
+			return fmt.Errorf("Invalid access control list: function %s has several entries", entry.Function)
+		}
+		functions[entry.Function] = true
+		for _, attribute := range entry.Attributes {
+			if attribute.Name == "" {
+				return fmt.Errorf("Invalid access control list: an attribute of function %s has no name", entry.Function)
+			}
+		}
+	}
+	return nil
+}
+
+// getDeploymentACL returns the access control list of a deployment
+// transaction, which must have been decrypted if it is confidential
+func getDeploymentACL(t *pb.Transaction) (*pb.ChaincodeACL, error) {
+	cds := &pb.ChaincodeDeploymentSpec{}
+	if err := proto.Unmarshal(t.Payload, cds); err != nil {
+		return nil, err
+	}
+	return cds.GetChaincodeSpec().GetAcl(), nil
+}
+
+// getACL returns the access control list of a chaincode, reading it from the
+// deployment or last upgrade transaction of the chaincode if it is not cached
+// yet or pending in the batch in progress. The ACL is nil for the chaincodes deployed without one, and for the
+// chaincodes not deployed through a transaction, such as the system
+// chaincodes.
+func (chaincodeSupport *ChaincodeSupport) getACL(chaincode string) (*pb.ChaincodeACL, error) {
+	acl, ok, generation := chaincodeSupport.acls.get(chaincode)
+	if ok {
+		return acl, nil
+	}
+
+	_, cds, err := chaincodeSupport.getDeployment(chaincode)
+	if err == ledger.ErrResourceNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	acl = cds.GetChaincodeSpec().GetAcl()
+	chaincodeSupport.acls.put(chaincode, acl, generation)
+	return acl, nil
+}
+
+// CheckACL checks that the holder of cert, the certificate a transaction was
+// signed with, may call function of chaincode according to the access control
+// list the chaincode was deployed or last upgraded with.
+func (chaincodeSupport *ChaincodeSupport) CheckACL(chaincode string, function string, cert []byte) error {
+	acl, err := chaincodeSupport.getACL(chaincode)
+	if err != nil {
+		return fmt.Errorf("Failed to read the access control list of %s (%s)", chaincode, err)
+	}
+
+	entry := getACLEntry(acl, function)
+	if entry == nil {
+		return nil
+	}
+	if err = checkACLEntry(entry, cert); err != nil {
+		chaincodeLogger.Debugf("Access to function %s of %s denied: %s", function, chaincode, err)
+		return fmt.Errorf("Access denied to function %s of %s (%s)", function, chaincode, err)
+	}
+	return nil
+}
+
+// CheckUpgradeACL checks that the holder of cert may upgrade chaincode, which
+// is controlled by the ACL entry of the function "@upgrade". Without such an
+// entry, only the deployer of the chaincode may upgrade it.
+func (chaincodeSupport *ChaincodeSupport) CheckUpgradeACL(chaincode string, cert []byte) error {
+	acl, err := chaincodeSupport.getACL(chaincode)
+	if err != nil {
+		return fmt.Errorf("Failed to read the access control list of %s (%s)", chaincode, err)
+	}
+	if getACLEntry(acl, aclUpgradeFunction) != nil {
+		return chaincodeSupport.CheckACL(chaincode, aclUpgradeFunction, cert)
+	}
+
+	depTx, _, err := chaincodeSupport.getDeployment(chaincode)
+	if err == ledger.ErrResourceNotFound {
+		return fmt.Errorf("Cannot upgrade %s, it was not deployed through a transaction", chaincode)
+	} else if err != nil {
+		return err
+	}
+	if !isSameEnrollment(depTx.Cert, cert) {
+		return fmt.Errorf("Access denied to upgrade %s (only its deployer may upgrade it, its access control list has no %s entry)", chaincode, aclUpgradeFunction)
+	}
+	return nil
+}
+
+// isSameEnrollment reports whether two transaction certificates were issued
+// to the same enrollment, which can only be told for identical certificates
+// and for ECerts. Without security, transactions have no certificate and
+// none is told apart.
+func isSameEnrollment(cert, other []byte) bool {
+	if cert == nil || other == nil {
+		return cert == nil && other == nil
+	}
+	if bytes.Equal(cert, other) {
+		return true
+	}
+	x509Cert, err := primitives.DERToX509Certificate(cert)
+	if err != nil {
+		return false
+	}
+	x509Other, err := primitives.DERToX509Certificate(other)
+	if err != nil {
+		return false
+	}
+	if _, err = primitives.GetCriticalExtension(x509Cert, crypto.ECertSubjectRole); err != nil {
+		return false
+	}
+	if _, err = primitives.GetCriticalExtension(x509Other, crypto.ECertSubjectRole); err != nil {
+		return false
+	}
+	return x509Cert.Subject.CommonName != "" && x509Cert.Subject.CommonName == x509Other.Subject.CommonName
+}
+
+// checkTransactionACL checks that the caller of a decrypted invocation or
+// query transaction may call the function it calls
+func (chaincodeSupport *ChaincodeSupport) checkTransactionACL(t *pb.Transaction) error {
+	cis := &pb.ChaincodeInvocationSpec{}
+	if err := proto.Unmarshal(t.Payload, cis); err != nil {
+		return err
+	}
+	spec := cis.GetChaincodeSpec()
+	if spec.GetChaincodeID() == nil {
+		return fmt.Errorf("Invalid transaction: no chaincode")
+	}
+	return chaincodeSupport.CheckACL(spec.ChaincodeID.Name, GetFunction(spec.GetCtorMsg()), t.Cert)
+}
+
+// checkChaincodeCallACL checks that the caller of the transaction txid, in
+// which a chaincode calls the chaincode of spec, may call the function it
+// calls. The nested transaction is given the certificate of the originating
+// transaction, so the calls it makes in turn are checked against the same
+// caller.
+func (handler *Handler) checkChaincodeCallACL(txid string, spec *pb.ChaincodeSpec, transaction *pb.Transaction) error {
+	var cert []byte
+	if txctx := handler.getTxContext(txid); txctx != nil && txctx.transactionSecContext != nil {
+		cert = txctx.transactionSecContext.Cert
+	}
+	transaction.Cert = cert
+	return handler.chaincodeSupport.CheckACL(spec.ChaincodeID.Name, GetFunction(spec.GetCtorMsg()), cert)
+}
+
+// GetFunction returns the function called by a chaincode input, which is its
+// first argument
+func GetFunction(input *pb.ChaincodeInput) string {
+	if input == nil || len(input.Args) == 0 {
+		return ""
+	}
+	return string(input.Args[0])
+}
+
+// getACLEntry returns the entry of an ACL for function, if any
+func getACLEntry(acl *pb.ChaincodeACL, function string) *pb.ChaincodeACLEntry {
+	var anyFunction *pb.ChaincodeACLEntry
+	for _, entry := range acl.GetEntries() {
+		if entry.Function == function {
+			return entry
+		}
+		if entry.Function == aclAnyFunction {
+			anyFunction = entry
+		}
+	}
+	return anyFunction
+}
+
+// checkACLEntry checks that cert satisfies the requirements of an ACL entry
+func checkACLEntry(entry *pb.ChaincodeACLEntry, cert []byte) error {
+	if len(entry.Attributes) == 0 && len(entry.Affiliations) == 0 {
+		return nil
+	}
+	if cert == nil {
+		return fmt.Errorf("the transaction has no certificate")
+	}
+
+	for _, attribute := range entry.Attributes {
+		value, err := attr.GetValueFrom(attribute.Name, cert)
+		if err != nil {
+			return fmt.Errorf("attribute %s is required", attribute.Name)
+		}
+		if attribute.Value != "" && string(value) != attribute.Value {
+			return fmt.Errorf("attribute %s must be %s", attribute.Name, attribute.Value)
+		}
+	}
+
+	if len(entry.Affiliations) == 0 {
+		return nil
+	}
+	x509Cert, err := primitives.DERToX509Certificate(cert)
+	if err != nil {
+		return err
+	}
+	affiliation := getCertificateAffiliation(x509Cert)
+	for _, allowed := range entry.Affiliations {
+		if affiliation != "" && affiliation == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("affiliation must be one of %s", strings.Join(entry.Affiliations, ", "))
+}
+
+// getCertificateAffiliation returns the affiliation of the enrollment a
+// certificate was issued to: the affiliation extension of a TCert, or the
+// affiliation in the enrollment ID of an ECert, whose common name is of the
+// form id\affiliation. It returns the empty string if it is not known.
+func getCertificateAffiliation(cert *x509.Certificate) string {
+	if value, err := primitives.GetCriticalExtension(cert, primitives.TCertAffiliation); err == nil {
+		return string(value)
+	}
+	if _, err := primitives.GetCriticalExtension(cert, crypto.ECertSubjectRole); err == nil {
+		if sections := strings.Split(cert.Subject.CommonName, "\\"); len(sections) == 2 {
+			return sections[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,301 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/hyperledger/fabric/core/crypto"
+	attributespb "github.com/hyperledger/fabric/core/crypto/attributes"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+func createTestCertificate(t *testing.T, commonName string, extensions ...pkix.Extension) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		Subject:         pkix.Name{CommonName: commonName},
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		ExtraExtensions: extensions,
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed creating certificate: %s", err)
+	}
+	return raw
+}
+
+// createTestTCert creates a certificate carrying the attribute role=admin
+// and the affiliation bank_a, as issued by the TCA
+func createTestTCert(t *testing.T) []byte {
+	header, err := attributespb.BuildAttributesHeader(map[string]int{"role": 1})
+	if err != nil {
+		t.Fatalf("Failed building attributes header: %s", err)
+	}
+	return createTestCertificate(t, "Transaction Certificate",
+		pkix.Extension{Id: asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 10}, Value: []byte("admin")},
+		pkix.Extension{Id: primitives.TCertAttributesHeaders, Value: header},
+		pkix.Extension{Id: primitives.TCertAffiliation, Value: []byte("bank_a")})
+}
+
+func TestGetACLEntry(t *testing.T) {
+	acl := &pb.ChaincodeACL{Entries: []*pb.ChaincodeACLEntry{
+		{Function: "*", Affiliations: []string{"bank_a"}},
+		{Function: "delete", Affiliations: []string{"bank_b"}},
+	}}
+
+	if entry := getACLEntry(acl, "delete"); entry != acl.Entries[1] {
+		t.Fatalf("Expected the entry of delete, got %v", entry)
+	}
+	if entry := getACLEntry(acl, "create"); entry != acl.Entries[0] {
+		t.Fatalf("Expected the entry of *, got %v", entry)
+	}
+	if entry := getACLEntry(nil, "create"); entry != nil {
+		t.Fatalf("Expected no entry without ACL, got %v", entry)
+	}
+}
+
+func TestValidateACL(t *testing.T) {
+	valid := &pb.ChaincodeACL{Entries: []*pb.ChaincodeACLEntry{
+		{Function: "delete", Attributes: []*pb.ChaincodeACLAttribute{{Name: "role", Value: "admin"}}},
+	}}
+	if err := validateACL(valid); err != nil {
+		t.Fatalf("Expected the ACL to be valid: %s", err)
+	}
+	if err := validateACL(nil); err != nil {
+		t.Fatalf("Expected no ACL to be valid: %s", err)
+	}
+
+	invalid := []*pb.ChaincodeACL{
+		{Entries: []*pb.ChaincodeACLEntry{{Affiliations: []string{"bank_a"}}}},
+		{Entries: []*pb.ChaincodeACLEntry{{Function: "delete"}, {Function: "delete"}}},
+		{Entries: []*pb.ChaincodeACLEntry{{Function: "delete", Attributes: []*pb.ChaincodeACLAttribute{{Value: "admin"}}}}},
+	}
+	for _, acl := range invalid {
+		if err := validateACL(acl); err == nil {
+			t.Fatalf("Expected ACL %v to be invalid", acl)
+		}
+	}
+}
+
+func TestCheckACLEntry(t *testing.T) {
+	tcert := createTestTCert(t)
+	ecert := createTestCertificate(t, "alice\\bank_b",
+		pkix.Extension{Id: crypto.ECertSubjectRole, Critical: true, Value: []byte("1")})
+
+	tests := []struct {
+		entry   *pb.ChaincodeACLEntry
+		cert    []byte
+		allowed bool
+	}{
+		{&pb.ChaincodeACLEntry{Function: "f"}, nil, true},
+		{&pb.ChaincodeACLEntry{Function: "f", Affiliations: []string{"bank_a"}}, nil, false},
+		{&pb.ChaincodeACLEntry{Function: "f", Attributes: []*pb.ChaincodeACLAttribute{{Name: "role"}}}, tcert, true},
+		{&pb.ChaincodeACLEntry{Function: "f", Attributes: []*pb.ChaincodeACLAttribute{{Name: "role", Value: "admin"}}}, tcert, true},
+		{&pb.ChaincodeACLEntry{Function: "f", Attributes: []*pb.ChaincodeACLAttribute{{Name: "role", Value: "auditor"}}}, tcert, false},
+		{&pb.ChaincodeACLEntry{Function: "f", Attributes: []*pb.ChaincodeACLAttribute{{Name: "company"}}}, tcert, false},
+		{&pb.ChaincodeACLEntry{Function: "f", Affiliations: []string{"bank_b", "bank_a"}}, tcert, true},
+		{&pb.ChaincodeACLEntry{Function: "f", Affiliations: []string{"bank_b"}}, tcert, false},
+		{&pb.ChaincodeACLEntry{Function: "f", Affiliations: []string{"bank_b"}}, ecert, true},
+		{&pb.ChaincodeACLEntry{Function: "f", Affiliations: []string{"bank_a"}}, ecert, false},
+		{&pb.ChaincodeACLEntry{Function: "f", Attributes: []*pb.ChaincodeACLAttribute{{Name: "role"}}}, ecert, false},
+	}
+	for i, test := range tests {
+		err := checkACLEntry(test.entry, test.cert)
+		if test.allowed && err != nil {
+			t.Errorf("Test %d: expected access to be allowed, got %s", i, err)
+		} else if !test.allowed && err == nil {
+			t.Errorf("Test %d: expected access to be denied", i)
+		}
+	}
+}
+
+type mockChaincodeStream struct {
+	sent chan *pb.ChaincodeMessage
+}
+
+func (s *mockChaincodeStream) Send(msg *pb.ChaincodeMessage) error {
+	s.sent <- msg
+	return nil
+}
+
+func (s *mockChaincodeStream) Recv() (*pb.ChaincodeMessage, error) {
+	select {}
+}
+
+func TestChaincodeCallACL(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{acls: newChaincodeACLs()}
+	chaincodeSupport.acls.put("restricted", &pb.ChaincodeACL{Entries: []*pb.ChaincodeACLEntry{
+		{Function: "delete", Attributes: []*pb.ChaincodeACLAttribute{{Name: "role", Value: "admin"}}},
+	}}, 0)
+	stream := &mockChaincodeStream{sent: make(chan *pb.ChaincodeMessage, 1)}
+	handler := &Handler{
+		ChatStream:       stream,
+		chaincodeSupport: chaincodeSupport,
+		txCtxs:           make(map[string]*transactionContext),
+		txidMap:          make(map[string]bool),
+	}
+	spec := &pb.ChaincodeSpec{
+		ChaincodeID: &pb.ChaincodeID{Name: "restricted"},
+		CtorMsg:     &pb.ChaincodeInput{Args: [][]byte{[]byte("delete")}},
+	}
+
+	// A proxy chaincode queried by a user without the attribute calls the restricted function
+	if _, err := handler.createTxContext("user", &pb.Transaction{Cert: createTestCertificate(t, "user")}, nil); err != nil {
+		t.Fatalf("Failed creating transaction context: %s", err)
+	}
+	payload, _ := proto.Marshal(spec)
+	handler.handleQueryChaincode(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_INVOKE_QUERY, Payload: payload, Txid: "user"})
+	select {
+	case msg := <-stream.sent:
+		if msg.Type != pb.ChaincodeMessage_ERROR || !strings.Contains(string(msg.Payload), "Access denied") {
+			t.Fatalf("Expected the nested query to be denied, got %s %s", msg.Type, msg.Payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for the nested query to be denied")
+	}
+
+	// The nested transaction carries the certificate of the originating one
+	tcert := createTestTCert(t)
+	if _, err := handler.createTxContext("admin", &pb.Transaction{Cert: tcert}, nil); err != nil {
+		t.Fatalf("Failed creating transaction context: %s", err)
+	}
+	nested := &pb.Transaction{}
+	if err := handler.checkChaincodeCallACL("admin", spec, nested); err != nil {
+		t.Fatalf("Expected the nested call of the admin to be allowed: %s", err)
+	}
+	if string(nested.Cert) != string(tcert) {
+		t.Fatalf("Expected the nested transaction to carry the certificate of the caller")
+	}
+}
+
+func TestIsSameEnrollment(t *testing.T) {
+	alice := createTestCertificate(t, "alice\\bank_b",
+		pkix.Extension{Id: crypto.ECertSubjectRole, Critical: true, Value: []byte("1")})
+	aliceRenewed := createTestCertificate(t, "alice\\bank_b",
+		pkix.Extension{Id: crypto.ECertSubjectRole, Critical: true, Value: []byte("1")})
+	bob := createTestCertificate(t, "bob\\bank_b",
+		pkix.Extension{Id: crypto.ECertSubjectRole, Critical: true, Value: []byte("1")})
+	tcert := createTestTCert(t)
+
+	tests := []struct {
+		cert, other []byte
+		expected    bool
+	}{
+		{alice, alice, true},
+		{alice, aliceRenewed, true},
+		{alice, bob, false},
+		{tcert, tcert, true},
+		{tcert, createTestTCert(t), false},
+		{alice, nil, false},
+		{nil, tcert, false},
+		{nil, nil, true},
+	}
+	for i, test := range tests {
+		if same := isSameEnrollment(test.cert, test.other); same != test.expected {
+			t.Errorf("Test %d: expected %v, got %v", i, test.expected, same)
+		}
+	}
+}
+
+func TestCheckUpgradeACLEntry(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{acls: newChaincodeACLs()}
+	chaincodeSupport.acls.put("restricted", &pb.ChaincodeACL{Entries: []*pb.ChaincodeACLEntry{
+		{Function: "@upgrade", Affiliations: []string{"bank_a"}},
+	}}, 0)
+
+	if err := chaincodeSupport.CheckUpgradeACL("restricted", createTestTCert(t)); err != nil {
+		t.Errorf("Expected a member of bank_a to be allowed to upgrade: %s", err)
+	}
+	if err := chaincodeSupport.CheckUpgradeACL("restricted", createTestCertificate(t, "user")); err == nil {
+		t.Errorf("Expected a user outside of bank_a to be denied the upgrade")
+	}
+}
+
+func TestChaincodeACLsBatch(t *testing.T) {
+	acls := newChaincodeACLs()
+	committed := &pb.ChaincodeACL{}
+	deployed := &pb.ChaincodeACL{Entries: []*pb.ChaincodeACLEntry{{Function: "*", Affiliations: []string{"bank_a"}}}}
+	_, _, generation := acls.get("upgraded")
+	acls.put("upgraded", committed, generation)
+
+	// The ACLs of a batch rolled back are discarded
+	acls.putPending("upgraded", deployed)
+	acls.putPending("deployed", deployed)
+	if acl, ok, _ := acls.get("upgraded"); !ok || acl != deployed {
+		t.Fatalf("Expected the pending ACL of the upgrade, got %v", acl)
+	}
+	acls.BatchEnded(false)
+	if acl, ok, _ := acls.get("upgraded"); !ok || acl != committed {
+		t.Fatalf("Expected the committed ACL after the rollback, got %v", acl)
+	}
+	if _, ok, _ := acls.get("deployed"); ok {
+		t.Fatalf("Expected the ACL of the deployment rolled back to be discarded")
+	}
+
+	// The ACLs of a committed batch are read from the ledger again, and an
+	// ACL read before the commit is not cached
+	_, _, generation = acls.get("upgraded")
+	acls.putPending("upgraded", deployed)
+	acls.BatchEnded(true)
+	if _, ok, _ := acls.get("upgraded"); ok {
+		t.Fatalf("Expected the ACL of the committed upgrade to be read again")
+	}
+	acls.put("upgraded", committed, generation)
+	if _, ok, _ := acls.get("upgraded"); ok {
+		t.Fatalf("Expected an ACL read before the commit not to be cached")
+	}
+
+	// An upgrade committed through state transfer drops the ACL
+	_, _, generation = acls.get("upgraded")
+	acls.put("upgraded", deployed, generation)
+	acls.StateChanged(map[string][]string{chaincodeVersionsNamespace: {"upgraded"}})
+	if _, ok, _ := acls.get("upgraded"); ok {
+		t.Fatalf("Expected the ACL of the chaincode upgraded through state transfer to be dropped")
+	}
+}
+
+func TestCheckPendingACL(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{acls: newChaincodeACLs()}
+	chaincodeSupport.acls.put("restricted", nil, 0)
+	chaincodeSupport.acls.putPending("restricted", &pb.ChaincodeACL{Entries: []*pb.ChaincodeACLEntry{
+		{Function: "*", Affiliations: []string{"bank_a"}},
+	}})
+	if err := chaincodeSupport.CheckACL("restricted", "invoke", createTestCertificate(t, "user")); err == nil {
+		t.Fatalf("Expected the pending ACL to deny the invocation")
+	}
+	chaincodeSupport.acls.BatchEnded(false)
+	if err := chaincodeSupport.CheckACL("restricted", "invoke", createTestCertificate(t, "user")); err != nil {
+		t.Fatalf("Expected the ACL of the rolled back upgrade to be discarded: %s", err)
+	}
+}
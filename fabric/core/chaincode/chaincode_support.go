@@ -18,6 +18,7 @@ package chaincode
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"strconv"
@@ -34,6 +35,7 @@ import (
 	"github.com/hyperledger/fabric/core/container/ccintf"
 	"github.com/hyperledger/fabric/core/crypto"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/util"
 	pb "github.com/hyperledger/fabric/protos"
 )
 
@@ -97,7 +99,7 @@ func NewChaincodeSupport(chainname ChainName, getPeerEndpoint func() (*pb.PeerEn
 	pnid := viper.GetString("peer.networkId")
 	pid := viper.GetString("peer.id")
 
-	s := &ChaincodeSupport{name: chainname, runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv)}, secHelper: secHelper, peerNetworkID: pnid, peerID: pid}
+	s := &ChaincodeSupport{name: chainname, runningChaincodes: &runningChaincodes{chaincodeMap: make(map[string]*chaincodeRTEnv)}, secHelper: secHelper, peerNetworkID: pnid, peerID: pid, acls: newChaincodeACLs()}
 
 	//initialize global chain
 	chains[chainname] = s
@@ -147,9 +149,44 @@ func NewChaincodeSupport(chainname ChainName, getPeerEndpoint func() (*pb.PeerEn
 		s.keepalive = time.Duration(t) * time.Second
 	}
 
+	s.initACLs()
+
+	if viper.GetBool("chaincode.queryCache.enabled") {
+		s.initQueryCache(viper.GetInt("chaincode.queryCache.size"))
+	}
+
 	return s
 }
 
+// initACLs registers the ACL cache with the ledger, so that the ACLs of the
+// batches rolled back are discarded and those of upgrades are read again
+func (chaincodeSupport *ChaincodeSupport) initACLs() {
+	ledgerObj, err := ledger.GetLedger()
+	if err != nil {
+		chaincodeLogger.Errorf("Failed to get ledger for the ACL cache: %s", err)
+		return
+	}
+	ledgerObj.AddStateListener(chaincodeSupport.acls)
+	ledgerObj.AddBatchListener(chaincodeSupport.acls)
+}
+
+// initQueryCache sets up the query result cache. The cache is not used when
+// security is enabled, as query results then depend on the caller.
+func (chaincodeSupport *ChaincodeSupport) initQueryCache(size int) {
+	if chaincodeSupport.secHelper != nil {
+		chaincodeLogger.Warning("The query cache is not supported when security is enabled, disabling it")
+		return
+	}
+	ledgerObj, err := ledger.GetLedger()
+	if err != nil {
+		chaincodeLogger.Errorf("Failed to get ledger, disabling the query cache: %s", err)
+		return
+	}
+	chaincodeLogger.Infof("Query cache enabled with size %d", size)
+	chaincodeSupport.queryCache = newQueryCache(size)
+	ledgerObj.AddStateListener(chaincodeSupport.queryCache)
+}
+
 // // ChaincodeStream standard stream for ChaincodeMessage type.
 // type ChaincodeStream interface {
 // 	Send(*pb.ChaincodeMessage) error
@@ -172,6 +209,8 @@ type ChaincodeSupport struct {
 	peerTLSKeyFile       string
 	peerTLSSvrHostOrd    string
 	keepalive            time.Duration
+	queryCache           *queryCache
+	acls                 *chaincodeACLs
 }
 
 // DuplicateChaincodeHandlerError returned if attempt to register same chaincodeID while a stream already exists.
@@ -233,10 +272,17 @@ func (chaincodeSupport *ChaincodeSupport) deregisterHandler(chaincodehandler *Ha
 	chaincodeLogger.Debugf("Deregister handler: %s", key)
 	chaincodeSupport.runningChaincodes.Lock()
 	defer chaincodeSupport.runningChaincodes.Unlock()
-	if _, ok := chaincodeSupport.chaincodeHasBeenLaunched(key); !ok {
+	chrte, ok := chaincodeSupport.chaincodeHasBeenLaunched(key)
+	if !ok {
 		// Handler NOT found
 		return fmt.Errorf("Error deregistering handler, could not find handler with key: %s", key)
 	}
+	if chrte.handler != chaincodehandler {
+		// The chaincode was stopped and launched again, as when it is
+		// upgraded, the handler belongs to the new container
+		chaincodeLogger.Debugf("Handler with key %s was replaced, not deregistering it", key)
+		return nil
+	}
 	delete(chaincodeSupport.runningChaincodes.chaincodeMap, key)
 	chaincodeLogger.Debugf("Deregistered handler with key: %s", key)
 	return nil
@@ -277,8 +323,10 @@ func (chaincodeSupport *ChaincodeSupport) sendInitOrReady(context context.Contex
 	return err
 }
 
-//get args and env given chaincodeID
-func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cID *pb.ChaincodeID, cLang pb.ChaincodeSpec_Type) (args []string, envs []string, err error) {
+//get args and env given chaincodeID and the deployment spec of the code it runs. The
+//executable is named after the code, which is the chaincode itself unless it was upgraded
+func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cID *pb.ChaincodeID, cds *pb.ChaincodeDeploymentSpec) (args []string, envs []string, err error) {
+	cLang := cds.ChaincodeSpec.Type
 	envs = []string{"CORE_CHAINCODE_ID_NAME=" + cID.Name}
 	//if TLS is enabled, pass TLS material to chaincode
 	if chaincodeSupport.peerTLS {
@@ -292,8 +340,8 @@ func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cID *pb.ChaincodeID, cLa
 	}
 	switch cLang {
 	case pb.ChaincodeSpec_GOLANG, pb.ChaincodeSpec_CAR:
-		//chaincode executable will be same as the name of the chaincode
-		args = []string{chaincodeSupport.chaincodeInstallPath + cID.Name, fmt.Sprintf("-peer.address=%s", chaincodeSupport.peerAddress)}
+		//chaincode executable will be same as the name of the code
+		args = []string{chaincodeSupport.chaincodeInstallPath + cds.ChaincodeSpec.ChaincodeID.Name, fmt.Sprintf("-peer.address=%s", chaincodeSupport.peerAddress)}
 		chaincodeLogger.Debugf("Executable is %s", args[0])
 	case pb.ChaincodeSpec_JAVA:
 		//TODO add security args
@@ -311,8 +359,20 @@ func (chaincodeSupport *ChaincodeSupport) getArgsAndEnv(cID *pb.ChaincodeID, cLa
 	return args, envs, nil
 }
 
+// getCCID returns the ID of the container running the code of cds for the chaincode cID.
+// The code of an upgrade is named after its hash, which does not identify the upgraded
+// chaincode, so its container is named after both the chaincode and the code.
+func (chaincodeSupport *ChaincodeSupport) getCCID(cID *pb.ChaincodeID, cds *pb.ChaincodeDeploymentSpec) ccintf.CCID {
+	spec := cds.ChaincodeSpec
+	if spec.ChaincodeID.Name != cID.Name {
+		spec = proto.Clone(spec).(*pb.ChaincodeSpec)
+		spec.ChaincodeID.Name = hex.EncodeToString(util.ComputeCryptoHash([]byte(cID.Name + "/" + cds.ChaincodeSpec.ChaincodeID.Name)))
+	}
+	return ccintf.CCID{ChaincodeSpec: spec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}
+}
+
 // launchAndWaitForRegister will launch container if not already running. Use the targz to create the image if not found
-func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.Context, cds *pb.ChaincodeDeploymentSpec, cID *pb.ChaincodeID, txid string, cLang pb.ChaincodeSpec_Type, targz io.Reader) (bool, error) {
+func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.Context, cds *pb.ChaincodeDeploymentSpec, cID *pb.ChaincodeID, txid string, targz io.Reader) (bool, error) {
 	chaincode := cID.Name
 	if chaincode == "" {
 		return false, fmt.Errorf("chaincode name not set")
@@ -333,7 +393,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 
 	//launch the chaincode
 
-	args, env, err := chaincodeSupport.getArgsAndEnv(cID, cLang)
+	args, env, err := chaincodeSupport.getArgsAndEnv(cID, cds)
 	if err != nil {
 		return alreadyRunning, err
 	}
@@ -342,7 +402,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
-	sir := container.StartImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}, Reader: targz, Args: args, Env: env}
+	sir := container.StartImageReq{CCID: chaincodeSupport.getCCID(cID, cds), Reader: targz, Args: args, Env: env}
 
 	ipcCtxt := context.WithValue(ctxt, ccintf.GetCCHandlerKey(), chaincodeSupport)
 
@@ -369,7 +429,7 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 	}
 	if err != nil {
 		chaincodeLogger.Debugf("stopping due to error while launching %s", err)
-		errIgnore := chaincodeSupport.Stop(ctxt, cds)
+		errIgnore := chaincodeSupport.stopChaincode(ctxt, cID, cds)
 		if errIgnore != nil {
 			chaincodeLogger.Debugf("error on stop %s(%s)", errIgnore, err)
 		}
@@ -379,13 +439,18 @@ func (chaincodeSupport *ChaincodeSupport) launchAndWaitForRegister(ctxt context.
 
 //Stop stops a chaincode if running
 func (chaincodeSupport *ChaincodeSupport) Stop(context context.Context, cds *pb.ChaincodeDeploymentSpec) error {
-	chaincode := cds.ChaincodeSpec.ChaincodeID.Name
+	return chaincodeSupport.stopChaincode(context, cds.ChaincodeSpec.ChaincodeID, cds)
+}
+
+//stopChaincode stops the chaincode cID running the code of cds if running
+func (chaincodeSupport *ChaincodeSupport) stopChaincode(context context.Context, cID *pb.ChaincodeID, cds *pb.ChaincodeDeploymentSpec) error {
+	chaincode := cID.Name
 	if chaincode == "" {
 		return fmt.Errorf("chaincode name not set")
 	}
 
 	//stop the chaincode
-	sir := container.StopImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}, Timeout: 0}
+	sir := container.StopImageReq{CCID: chaincodeSupport.getCCID(cID, cds), Timeout: 0}
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
@@ -414,7 +479,6 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, t *pb.
 	//build the chaincode
 	var cID *pb.ChaincodeID
 	var cMsg *pb.ChaincodeInput
-	var cLang pb.ChaincodeSpec_Type
 	var initargs [][]byte
 
 	cds := &pb.ChaincodeDeploymentSpec{}
@@ -425,8 +489,20 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, t *pb.
 		}
 		cID = cds.ChaincodeSpec.ChaincodeID
 		cMsg = cds.ChaincodeSpec.CtorMsg
-		cLang = cds.ChaincodeSpec.Type
 		initargs = cMsg.Args
+	} else if t.Type == pb.Transaction_CHAINCODE_UPGRADE {
+		cus, err := getUpgradeSpec(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		//launch the new code under the name of the upgraded chaincode and
+		//initialize it with the migration function, if any
+		cds = cus.DeploymentSpec
+		cID = &pb.ChaincodeID{Name: cus.ChaincodeName}
+		cMsg = cds.ChaincodeSpec.CtorMsg
+		if cMsg != nil {
+			initargs = cMsg.Args
+		}
 	} else if t.Type == pb.Transaction_CHAINCODE_INVOKE || t.Type == pb.Transaction_CHAINCODE_QUERY {
 		ci := &pb.ChaincodeInvocationSpec{}
 		err := proto.Unmarshal(t.Payload, ci)
@@ -453,8 +529,12 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, t *pb.
 			return cID, cMsg, err
 		}
 		if chrte.handler.isRunning() {
-			chaincodeLogger.Debugf("chaincode is running(no need to launch) : %s", chaincode)
 			chaincodeSupport.runningChaincodes.Unlock()
+			if t.Type == pb.Transaction_CHAINCODE_UPGRADE {
+				//only happens in development mode, where the user runs the chaincode
+				return cID, cMsg, fmt.Errorf("chaincode %s is running its previous code, restart it with the new code before upgrading it", chaincode)
+			}
+			chaincodeLogger.Debugf("chaincode is running(no need to launch) : %s", chaincode)
 			return cID, cMsg, nil
 		}
 		chaincodeLogger.Debugf("Container not in READY state(%s)...send init/ready", chrte.handler.FSM.Current())
@@ -476,38 +556,23 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, t *pb.
 	// See issue #710
 
 	if t.Type != pb.Transaction_CHAINCODE_DEPLOY {
-		ledger, ledgerErr := ledger.GetLedger()
-
-		if chaincodeSupport.userRunsCC {
+		if chaincodeSupport.userRunsCC && t.Type != pb.Transaction_CHAINCODE_UPGRADE {
 			chaincodeLogger.Error("You are attempting to perform an action other than Deploy on Chaincode that is not ready and you are in developer mode. Did you forget to Deploy your chaincode?")
 		}
 
-		if ledgerErr != nil {
-			return cID, cMsg, fmt.Errorf("Failed to get handle to ledger (%s)", ledgerErr)
-		}
-
 		//hopefully we are restarting from existing image and the deployed transaction exists
-		depTx, ledgerErr = ledger.GetTransactionByID(chaincode)
-		if ledgerErr != nil {
-			return cID, cMsg, fmt.Errorf("Could not get deployment transaction for %s - %s", chaincode, ledgerErr)
-		}
-		if depTx == nil {
-			return cID, cMsg, fmt.Errorf("deployment transaction does not exist for %s", chaincode)
-		}
-		if nil != chaincodeSupport.secHelper {
-			var err error
-			depTx, err = chaincodeSupport.secHelper.TransactionPreExecution(depTx)
-			// Note that t is now decrypted and is a deep clone of the original input t
-			if nil != err {
-				return cID, cMsg, fmt.Errorf("failed tx preexecution%s - %s", chaincode, err)
-			}
+		var codeSpec *pb.ChaincodeDeploymentSpec
+		depTx, codeSpec, err = chaincodeSupport.getDeployment(chaincode)
+		if err == ledger.ErrResourceNotFound {
+			return cID, cMsg, fmt.Errorf("Could not get deployment transaction for %s - %s", chaincode, err)
+		} else if err != nil {
+			return cID, cMsg, err
 		}
-		//Get lang from original deployment
-		err := proto.Unmarshal(depTx.Payload, cds)
-		if err != nil {
-			return cID, cMsg, fmt.Errorf("failed to unmarshal deployment transactions for %s - %s", chaincode, err)
+		//an upgrade launches its own code, the other transactions the code
+		//the chaincode was deployed or last upgraded with
+		if t.Type != pb.Transaction_CHAINCODE_UPGRADE {
+			cds = codeSpec
 		}
-		cLang = cds.ChaincodeSpec.Type
 	}
 
 	//from here on : if we launch the container and get an error, we need to stop the container
@@ -515,7 +580,7 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, t *pb.
 	//launch container if it is a System container or not in dev mode
 	if (!chaincodeSupport.userRunsCC || cds.ExecEnv == pb.ChaincodeDeploymentSpec_SYSTEM) && (chrte == nil || chrte.handler == nil) {
 		var targz io.Reader = bytes.NewBuffer(cds.CodePackage)
-		_, err = chaincodeSupport.launchAndWaitForRegister(context, cds, cID, t.Txid, cLang, targz)
+		_, err = chaincodeSupport.launchAndWaitForRegister(context, cds, cID, t.Txid, targz)
 		if err != nil {
 			chaincodeLogger.Errorf("launchAndWaitForRegister failed %s", err)
 			return cID, cMsg, err
@@ -528,7 +593,7 @@ func (chaincodeSupport *ChaincodeSupport) Launch(context context.Context, t *pb.
 		if err != nil {
 			chaincodeLogger.Errorf("sending init failed(%s)", err)
 			err = fmt.Errorf("Failed to init chaincode(%s)", err)
-			errIgnore := chaincodeSupport.Stop(context, cds)
+			errIgnore := chaincodeSupport.stopChaincode(context, cID, cds)
 			if errIgnore != nil {
 				chaincodeLogger.Errorf("stop failed %s(%s)", errIgnore, err)
 			}
@@ -564,11 +629,13 @@ func (chaincodeSupport *ChaincodeSupport) Deploy(context context.Context, t *pb.
 		return nil, err
 	}
 	cID := cds.ChaincodeSpec.ChaincodeID
-	cLang := cds.ChaincodeSpec.Type
 	chaincode := cID.Name
 	if err != nil {
 		return cds, err
 	}
+	if chaincode == chaincodeVersionsNamespace {
+		return cds, fmt.Errorf("chaincode name %s is reserved", chaincode)
+	}
 
 	if chaincodeSupport.userRunsCC {
 		chaincodeLogger.Debug("user runs chaincode, not deploying chaincode")
@@ -584,13 +651,13 @@ func (chaincodeSupport *ChaincodeSupport) Deploy(context context.Context, t *pb.
 	}
 	chaincodeSupport.runningChaincodes.Unlock()
 
-	args, envs, err := chaincodeSupport.getArgsAndEnv(cID, cLang)
+	args, envs, err := chaincodeSupport.getArgsAndEnv(cID, cds)
 	if err != nil {
 		return cds, fmt.Errorf("error getting args for chaincode %s", err)
 	}
 
 	var targz io.Reader = bytes.NewBuffer(cds.CodePackage)
-	cir := &container.CreateImageReq{CCID: ccintf.CCID{ChaincodeSpec: cds.ChaincodeSpec, NetworkID: chaincodeSupport.peerNetworkID, PeerID: chaincodeSupport.peerID}, Args: args, Reader: targz, Env: envs}
+	cir := &container.CreateImageReq{CCID: chaincodeSupport.getCCID(cID, cds), Args: args, Reader: targz, Env: envs}
 
 	vmtype, _ := chaincodeSupport.getVMType(cds)
 
@@ -646,9 +713,15 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, chaincod
 	}
 	chaincodeSupport.runningChaincodes.Unlock()
 
+	// A cached query result depends on the code of the chaincodes it executed
+	queryCtx := getQueryContext(ctxt)
+	if queryCtx != nil {
+		queryCtx.addChaincodeID(chaincode)
+	}
+
 	var notfy chan *pb.ChaincodeMessage
 	var err error
-	if notfy, err = chrte.handler.sendExecuteMessage(msg, tx); err != nil {
+	if notfy, err = chrte.handler.sendExecuteMessage(msg, tx, queryCtx); err != nil {
 		return nil, fmt.Errorf("Error sending %s: %s", msg.Type.String(), err)
 	}
 	var ccresp *pb.ChaincodeMessage
@@ -49,20 +49,69 @@ func Execute(ctxt context.Context, chain *ChaincodeSupport, t *pb.Transaction) (
 	}
 
 	if t.Type == pb.Transaction_CHAINCODE_DEPLOY {
-		_, err := chain.Deploy(ctxt, t)
+		acl, err := getDeploymentACL(t)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to deploy chaincode spec(%s)", err)
+		}
+		if err = validateACL(acl); err != nil {
+			return nil, nil, err
+		}
+
+		_, err = chain.Deploy(ctxt, t)
 		if err != nil {
 			return nil, nil, fmt.Errorf("Failed to deploy chaincode spec(%s)", err)
 		}
 
 		//launch and wait for ready
 		markTxBegin(ledger, t)
+		cID, _, err := chain.Launch(ctxt, t)
+		if err != nil {
+			markTxFinish(ledger, t, false)
+			return nil, nil, fmt.Errorf("%s", err)
+		}
+		markTxFinish(ledger, t, true)
+		// The deployment is not committed yet, record the ACL for the
+		// invocations of the same batch
+		chain.acls.putPending(cID.Name, acl)
+	} else if t.Type == pb.Transaction_CHAINCODE_UPGRADE {
+		cus, err := getUpgradeSpec(t)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Failed to upgrade chaincode spec(%s)", err)
+		}
+		acl := cus.DeploymentSpec.ChaincodeSpec.GetAcl()
+		if err = validateACL(acl); err != nil {
+			return nil, nil, err
+		}
+		if err = chain.CheckUpgradeACL(cus.ChaincodeName, t.Cert); err != nil {
+			return nil, nil, err
+		}
+
+		// the version history is part of the state, so the whole upgrade
+		// runs within the transaction
+		markTxBegin(ledger, t)
+		_, err = chain.Upgrade(ctxt, t)
+		if err != nil {
+			markTxFinish(ledger, t, false)
+			return nil, nil, fmt.Errorf("Failed to upgrade chaincode spec(%s)", err)
+		}
+
+		//launch the new code, run the migration function and wait for ready
 		_, _, err = chain.Launch(ctxt, t)
 		if err != nil {
 			markTxFinish(ledger, t, false)
 			return nil, nil, fmt.Errorf("%s", err)
 		}
 		markTxFinish(ledger, t, true)
+		// The upgrade is not committed yet, record the ACL for the
+		// invocations of the same batch
+		chain.acls.putPending(cus.ChaincodeName, acl)
 	} else if t.Type == pb.Transaction_CHAINCODE_INVOKE || t.Type == pb.Transaction_CHAINCODE_QUERY {
+		// reject the callers the ACL of the chaincode denies before
+		// launching it
+		if err := chain.checkTransactionACL(t); err != nil {
+			return nil, nil, err
+		}
+
 		//will launch if necessary (and wait for ready)
 		cID, cMsg, err := chain.Launch(ctxt, t)
 		if err != nil {
@@ -132,11 +181,61 @@ func Execute(ctxt context.Context, chain *ChaincodeSupport, t *pb.Transaction) (
 	return nil, nil, err
 }
 
+// ExecuteQuery executes a query transaction against a snapshot of the
+// committed state, so that all the reads of the query and of the queries it
+// makes to other chaincodes are consistent. It returns the result along with
+// the number of the block the result reflects. If the query cache is enabled
+// a cached result is returned when none of the chaincodes read by the query
+// changed since it was computed.
+func ExecuteQuery(ctxt context.Context, chain *ChaincodeSupport, t *pb.Transaction) ([]byte, uint64, error) {
+	if t.Type != pb.Transaction_CHAINCODE_QUERY {
+		return nil, 0, fmt.Errorf("Invalid transaction type %s for a query", t.Type.String())
+	}
+
+	// The invocation spec identifies the chaincode, function and arguments
+	cacheKey := string(t.Payload)
+	cache := chain.queryCache
+	var generation uint64
+	if cache != nil {
+		// Cached results are served without executing the query, so the ACL
+		// of the chaincode is checked here. The cache is only used when
+		// security is disabled, when the transaction is not encrypted.
+		if err := chain.checkTransactionACL(t); err != nil {
+			return nil, 0, err
+		}
+		if result, blockNumber, ok := cache.get(cacheKey); ok {
+			chaincodeLogger.Debugf("[%s]Query result served from cache at block %d", shorttxid(t.Txid), blockNumber)
+			return result, blockNumber, nil
+		}
+		generation = cache.getGeneration()
+	}
+
+	ledger, ledgerErr := ledger.GetLedger()
+	if ledgerErr != nil {
+		return nil, 0, fmt.Errorf("Failed to get handle to ledger (%s)", ledgerErr)
+	}
+	snapshot, err := ledger.GetStateSnapshot()
+	if err != nil {
+		return nil, 0, fmt.Errorf("Failed to get state snapshot for query (%s)", err)
+	}
+	queryCtx := newQueryContext(snapshot)
+	defer queryCtx.release()
+
+	result, _, err := Execute(withQueryContext(ctxt, queryCtx), chain, t)
+	if err != nil {
+		return nil, 0, err
+	}
+	if cache != nil {
+		cache.put(cacheKey, result, snapshot.GetBlockNumber(), generation, queryCtx.getChaincodeIDs())
+	}
+	return result, snapshot.GetBlockNumber(), nil
+}
+
 //ExecuteTransactions - will execute transactions on the array one by one
 //will return an array of errors one for each transaction. If the execution
-//succeeded, array element will be nil. returns []byte of state hash or
-//error
-func ExecuteTransactions(ctxt context.Context, cname ChainName, xacts []*pb.Transaction) (succeededTXs []*pb.Transaction, stateHash []byte, ccevents []*pb.ChaincodeEvent, txerrs []error, err error) {
+//succeeded, array element will be nil. returns []byte of state hash, the
+//result of each transaction or error
+func ExecuteTransactions(ctxt context.Context, cname ChainName, xacts []*pb.Transaction) (succeededTXs []*pb.Transaction, stateHash []byte, results [][]byte, ccevents []*pb.ChaincodeEvent, txerrs []error, err error) {
 	var chain = GetChain(cname)
 	if chain == nil {
 		// TODO: We should never get here, but otherwise a good reminder to better handle
@@ -144,10 +243,11 @@ func ExecuteTransactions(ctxt context.Context, cname ChainName, xacts []*pb.Tran
 	}
 
 	txerrs = make([]error, len(xacts))
+	results = make([][]byte, len(xacts))
 	ccevents = make([]*pb.ChaincodeEvent, len(xacts))
 	var succeededTxs = make([]*pb.Transaction, 0)
 	for i, t := range xacts {
-		_, ccevents[i], txerrs[i] = Execute(ctxt, chain, t)
+		results[i], ccevents[i], txerrs[i] = Execute(ctxt, chain, t)
 		if txerrs[i] == nil {
 			succeededTxs = append(succeededTxs, t)
 		} else {
@@ -161,7 +261,7 @@ func ExecuteTransactions(ctxt context.Context, cname ChainName, xacts []*pb.Tran
 		stateHash, err = lgr.GetTempStateHash()
 	}
 
-	return succeededTxs, stateHash, ccevents, txerrs, err
+	return succeededTxs, stateHash, results, ccevents, txerrs, err
 }
 
 // GetSecureContext returns the security context from the context object or error
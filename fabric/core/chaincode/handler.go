@@ -61,6 +61,9 @@ type transactionContext struct {
 
 	// tracks open iterators used for range queries
 	rangeQueryIteratorMap map[string]statemgmt.RangeScanIterator
+
+	// snapshot of the committed state read by a query, nil for transactions
+	queryContext *queryContext
 }
 
 type nextStateInfo struct {
@@ -113,7 +116,7 @@ func (handler *Handler) serialSend(msg *pb.ChaincodeMessage) error {
 	return nil
 }
 
-func (handler *Handler) createTxContext(txid string, tx *pb.Transaction) (*transactionContext, error) {
+func (handler *Handler) createTxContext(txid string, tx *pb.Transaction, queryCtx *queryContext) (*transactionContext, error) {
 	if handler.txCtxs == nil {
 		return nil, fmt.Errorf("cannot create notifier for txid:%s", txid)
 	}
@@ -123,7 +126,7 @@ func (handler *Handler) createTxContext(txid string, tx *pb.Transaction) (*trans
 		return nil, fmt.Errorf("txid:%s exists", txid)
 	}
 	txctx := &transactionContext{transactionSecContext: tx, responseNotifier: make(chan *pb.ChaincodeMessage, 1),
-		rangeQueryIteratorMap: make(map[string]statemgmt.RangeScanIterator)}
+		rangeQueryIteratorMap: make(map[string]statemgmt.RangeScanIterator), queryContext: queryCtx}
 	handler.txCtxs[txid] = txctx
 	return txctx, nil
 }
@@ -138,10 +141,29 @@ func (handler *Handler) deleteTxContext(txid string) {
 	handler.Lock()
 	defer handler.Unlock()
 	if handler.txCtxs != nil {
+		// close the iterators the chaincode left open, they may read a
+		// snapshot that is released once the transaction context is gone
+		if txctx := handler.txCtxs[txid]; txctx != nil {
+			for iterID, rangeScanIterator := range txctx.rangeQueryIteratorMap {
+				rangeScanIterator.Close()
+				delete(txctx.rangeQueryIteratorMap, iterID)
+			}
+		}
 		delete(handler.txCtxs, txid)
 	}
 }
 
+// getTxQueryContext returns the query context of txid, or nil if txid is not
+// a query executed against a snapshot
+func (handler *Handler) getTxQueryContext(txid string) *queryContext {
+	handler.Lock()
+	defer handler.Unlock()
+	if txctx := handler.txCtxs[txid]; txctx != nil {
+		return txctx.queryContext
+	}
+	return nil
+}
+
 func (handler *Handler) putRangeQueryIterator(txContext *transactionContext, txid string,
 	rangeScanIterator statemgmt.RangeScanIterator) {
 	handler.Lock()
@@ -623,8 +645,14 @@ func (handler *Handler) handleGetState(msg *pb.ChaincodeMessage) {
 		// Invoke ledger to get state
 		chaincodeID := handler.ChaincodeID.Name
 
-		readCommittedState := !handler.getIsTransaction(msg.Txid)
-		res, err := ledgerObj.GetState(chaincodeID, key, readCommittedState)
+		var res []byte
+		var err error
+		if queryCtx := handler.getTxQueryContext(msg.Txid); queryCtx != nil {
+			res, err = queryCtx.getState(chaincodeID, key)
+		} else {
+			readCommittedState := !handler.getIsTransaction(msg.Txid)
+			res, err = ledgerObj.GetState(chaincodeID, key, readCommittedState)
+		}
 		if err != nil {
 			// Send error msg back to chaincode. GetState will not trigger event
 			payload := []byte(err.Error())
@@ -712,8 +740,14 @@ func (handler *Handler) handleRangeQueryState(msg *pb.ChaincodeMessage) {
 
 		chaincodeID := handler.ChaincodeID.Name
 
-		readCommittedState := !handler.getIsTransaction(msg.Txid)
-		rangeIter, err := ledger.GetStateRangeScanIterator(chaincodeID, rangeQueryState.StartKey, rangeQueryState.EndKey, readCommittedState)
+		var rangeIter statemgmt.RangeScanIterator
+		var err error
+		if queryCtx := handler.getTxQueryContext(msg.Txid); queryCtx != nil {
+			rangeIter, err = queryCtx.getStateRangeScanIterator(chaincodeID, rangeQueryState.StartKey, rangeQueryState.EndKey)
+		} else {
+			readCommittedState := !handler.getIsTransaction(msg.Txid)
+			rangeIter, err = ledger.GetStateRangeScanIterator(chaincodeID, rangeQueryState.StartKey, rangeQueryState.EndKey, readCommittedState)
+		}
 		if err != nil {
 			// Send error msg back to chaincode. GetState will not trigger event
 			payload := []byte(err.Error())
@@ -1064,6 +1098,11 @@ func (handler *Handler) enterBusyState(e *fsm.Event, state string) {
 			// Create the transaction object
 			chaincodeInvocationSpec := &pb.ChaincodeInvocationSpec{ChaincodeSpec: chaincodeSpec}
 			transaction, _ := pb.NewChaincodeExecute(chaincodeInvocationSpec, msg.Txid, pb.Transaction_CHAINCODE_INVOKE)
+			if aclErr := handler.checkChaincodeCallACL(msg.Txid, chaincodeSpec, transaction); aclErr != nil {
+				chaincodeLogger.Debugf("[%s]Invoked chaincode denied access. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+				triggerNextStateMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(aclErr.Error()), Txid: msg.Txid}
+				return
+			}
 
 			// Launch the new chaincode if not already running
 			_, chaincodeInput, launchErr := handler.chaincodeSupport.Launch(context.Background(), transaction)
@@ -1260,7 +1299,7 @@ func (handler *Handler) initOrReady(txid string, initArgs [][]byte, tx *pb.Trans
 	var ccMsg *pb.ChaincodeMessage
 	var send bool
 
-	txctx, funcErr := handler.createTxContext(txid, tx)
+	txctx, funcErr := handler.createTxContext(txid, tx, nil)
 	if funcErr != nil {
 		return nil, funcErr
 	}
@@ -1336,6 +1375,11 @@ func (handler *Handler) handleQueryChaincode(msg *pb.ChaincodeMessage) {
 		// Create the transaction object
 		chaincodeInvocationSpec := &pb.ChaincodeInvocationSpec{ChaincodeSpec: chaincodeSpec}
 		transaction, _ := pb.NewChaincodeExecute(chaincodeInvocationSpec, msg.Txid, pb.Transaction_CHAINCODE_QUERY)
+		if aclErr := handler.checkChaincodeCallACL(msg.Txid, chaincodeSpec, transaction); aclErr != nil {
+			chaincodeLogger.Debugf("[%s]Queried chaincode denied access. Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_ERROR)
+			serialSendMsg = &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(aclErr.Error()), Txid: msg.Txid}
+			return
+		}
 
 		// Launch the new chaincode if not already running
 		_, chaincodeInput, launchErr := handler.chaincodeSupport.Launch(context.Background(), transaction)
@@ -1351,9 +1395,13 @@ func (handler *Handler) handleQueryChaincode(msg *pb.ChaincodeMessage) {
 
 		ccMsg, _ := createQueryMessage(transaction.Txid, chaincodeInput)
 
-		// Query the chaincode
+		// Query the chaincode, against the snapshot of the calling query if any
 		//NOTE: when confidential C-call-C is understood, transaction should have the correct sec context for enc/dec
-		response, execErr := handler.chaincodeSupport.Execute(context.Background(), newChaincodeID, ccMsg, timeout, transaction)
+		ctxt := context.Background()
+		if queryCtx := handler.getTxQueryContext(msg.Txid); queryCtx != nil {
+			ctxt = withQueryContext(ctxt, queryCtx)
+		}
+		response, execErr := handler.chaincodeSupport.Execute(ctxt, newChaincodeID, ccMsg, timeout, transaction)
 
 		if execErr != nil {
 			// Send error msg back to chaincode and trigger event
@@ -1451,8 +1499,8 @@ func filterError(errFromFSMEvent error) error {
 	return nil
 }
 
-func (handler *Handler) sendExecuteMessage(msg *pb.ChaincodeMessage, tx *pb.Transaction) (chan *pb.ChaincodeMessage, error) {
-	txctx, err := handler.createTxContext(msg.Txid, tx)
+func (handler *Handler) sendExecuteMessage(msg *pb.ChaincodeMessage, tx *pb.Transaction, queryCtx *queryContext) (chan *pb.ChaincodeMessage, error) {
+	txctx, err := handler.createTxContext(msg.Txid, tx, queryCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"container/list"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/statemgmt"
+	"github.com/hyperledger/fabric/core/ledger/statemgmt/state"
+	"golang.org/x/net/context"
+)
+
+// queryContext is shared by a query and the queries it makes to other
+// chaincodes. They all read the same snapshot of the committed state, and the
+// chaincodes executed or whose state was read are recorded so that a cached
+// result can be invalidated when any of them changes.
+type queryContext struct {
+	sync.Mutex
+	snapshot     *state.StateSnapshot
+	released     bool
+	chaincodeIDs map[string]bool
+}
+
+type queryContextKey struct{}
+
+func newQueryContext(snapshot *state.StateSnapshot) *queryContext {
+	return &queryContext{snapshot: snapshot, chaincodeIDs: make(map[string]bool)}
+}
+
+// withQueryContext returns a copy of ctxt carrying queryCtx
+func withQueryContext(ctxt context.Context, queryCtx *queryContext) context.Context {
+	return context.WithValue(ctxt, queryContextKey{}, queryCtx)
+}
+
+// getQueryContext returns the query context carried by ctxt, or nil if ctxt
+// is not the context of a query
+func getQueryContext(ctxt context.Context) *queryContext {
+	queryCtx, _ := ctxt.Value(queryContextKey{}).(*queryContext)
+	return queryCtx
+}
+
+func (queryCtx *queryContext) getState(chaincodeID string, key string) ([]byte, error) {
+	queryCtx.Lock()
+	defer queryCtx.Unlock()
+	if queryCtx.released {
+		return nil, fmt.Errorf("The query has already completed")
+	}
+	queryCtx.chaincodeIDs[chaincodeID] = true
+	return queryCtx.snapshot.Get(chaincodeID, key)
+}
+
+func (queryCtx *queryContext) getStateRangeScanIterator(chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
+	queryCtx.Lock()
+	defer queryCtx.Unlock()
+	if queryCtx.released {
+		return nil, fmt.Errorf("The query has already completed")
+	}
+	queryCtx.chaincodeIDs[chaincodeID] = true
+	return queryCtx.snapshot.GetRangeScanIterator(chaincodeID, startKey, endKey)
+}
+
+// addChaincodeID records a chaincode executed by the query
+func (queryCtx *queryContext) addChaincodeID(chaincodeID string) {
+	queryCtx.Lock()
+	defer queryCtx.Unlock()
+	queryCtx.chaincodeIDs[chaincodeID] = true
+}
+
+// getChaincodeIDs returns the chaincodes executed or whose state was read by
+// the query
+func (queryCtx *queryContext) getChaincodeIDs() []string {
+	queryCtx.Lock()
+	defer queryCtx.Unlock()
+	chaincodeIDs := make([]string, 0, len(queryCtx.chaincodeIDs))
+	for chaincodeID := range queryCtx.chaincodeIDs {
+		chaincodeIDs = append(chaincodeIDs, chaincodeID)
+	}
+	sort.Strings(chaincodeIDs)
+	return chaincodeIDs
+}
+
+// release releases the snapshot. Requests made by the chaincode after this
+// fail.
+func (queryCtx *queryContext) release() {
+	queryCtx.Lock()
+	defer queryCtx.Unlock()
+	queryCtx.released = true
+	queryCtx.snapshot.Release()
+}
+
+// queryCache is an LRU cache of query results. It implements
+// ledger.StateListener, and the results that executed a chaincode or read its
+// state are dropped when a change to that state or an upgrade of that
+// chaincode is committed.
+type queryCache struct {
+	sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+
+	// generation is incremented for every committed change. changedAt holds
+	// the generation at which the state of a chaincode last changed and
+	// resetAt the generation at which the whole state last changed.
+	generation uint64
+	changedAt  map[string]uint64
+	resetAt    uint64
+}
+
+type queryCacheEntry struct {
+	key          string
+	result       []byte
+	blockNumber  uint64
+	chaincodeIDs []string
+}
+
+func newQueryCache(capacity int) *queryCache {
+	return &queryCache{capacity: capacity, entries: make(map[string]*list.Element), lru: list.New(), changedAt: make(map[string]uint64)}
+}
+
+// getGeneration returns the current generation. It must be called before the
+// snapshot the result passed to put is computed from is taken.
+func (cache *queryCache) getGeneration() uint64 {
+	cache.Lock()
+	defer cache.Unlock()
+	return cache.generation
+}
+
+// get returns the cached result for key and the block it reflects
+func (cache *queryCache) get(key string) ([]byte, uint64, bool) {
+	cache.Lock()
+	defer cache.Unlock()
+	element, ok := cache.entries[key]
+	if !ok {
+		return nil, 0, false
+	}
+	cache.lru.MoveToFront(element)
+	entry := element.Value.(*queryCacheEntry)
+	return entry.result, entry.blockNumber, true
+}
+
+// put caches the result of a query that read the state of chaincodeIDs from
+// a snapshot taken at generation. The result is not cached if any of these
+// changed since.
+func (cache *queryCache) put(key string, result []byte, blockNumber uint64, generation uint64, chaincodeIDs []string) {
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.capacity <= 0 || cache.resetAt > generation {
+		return
+	}
+	for _, chaincodeID := range chaincodeIDs {
+		if cache.changedAt[chaincodeID] > generation {
+			return
+		}
+	}
+	if element, ok := cache.entries[key]; ok {
+		cache.lru.Remove(element)
+		delete(cache.entries, key)
+	}
+	entry := &queryCacheEntry{key: key, result: result, blockNumber: blockNumber, chaincodeIDs: chaincodeIDs}
+	cache.entries[key] = cache.lru.PushFront(entry)
+	for cache.lru.Len() > cache.capacity {
+		oldest := cache.lru.Back()
+		cache.lru.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*queryCacheEntry).key)
+	}
+}
+
+// StateChanged implements ledger.StateListener
+func (cache *queryCache) StateChanged(updates map[string][]string) {
+	cache.Lock()
+	defer cache.Unlock()
+	cache.generation++
+	if updates == nil {
+		cache.resetAt = cache.generation
+		cache.entries = make(map[string]*list.Element)
+		cache.lru.Init()
+		return
+	}
+
+	changed := make(map[string]bool)
+	for chaincodeID, keys := range updates {
+		changed[chaincodeID] = true
+		// The version history of an upgraded chaincode is keyed by its name
+		if chaincodeID == chaincodeVersionsNamespace {
+			for _, upgraded := range keys {
+				changed[upgraded] = true
+			}
+		}
+	}
+	for chaincodeID := range changed {
+		cache.changedAt[chaincodeID] = cache.generation
+	}
+	var next *list.Element
+	for element := cache.lru.Front(); element != nil; element = next {
+		next = element.Next()
+		entry := element.Value.(*queryCacheEntry)
+		for _, chaincodeID := range entry.chaincodeIDs {
+			if changed[chaincodeID] {
+				cache.lru.Remove(element)
+				delete(cache.entries, entry.key)
+				break
+			}
+		}
+	}
+}
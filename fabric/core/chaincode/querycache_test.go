@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"testing"
+)
+
+func checkQueryCacheEntry(t *testing.T, cache *queryCache, key string, expected []byte) {
+	result, _, ok := cache.get(key)
+	if expected == nil {
+		if ok {
+			t.Fatalf("Expected no cached result for %s, got %s", key, result)
+		}
+		return
+	}
+	if !ok || !bytes.Equal(result, expected) {
+		t.Fatalf("Expected cached result %s for %s, got %s (cached: %t)", expected, key, result, ok)
+	}
+}
+
+func TestQueryCacheEviction(t *testing.T) {
+	cache := newQueryCache(2)
+	generation := cache.getGeneration()
+	cache.put("q1", []byte("r1"), 1, generation, []string{"cc1"})
+	cache.put("q2", []byte("r2"), 1, generation, []string{"cc1"})
+	// q1 becomes the most recently used, so q2 is evicted
+	checkQueryCacheEntry(t, cache, "q1", []byte("r1"))
+	cache.put("q3", []byte("r3"), 1, generation, []string{"cc2"})
+	checkQueryCacheEntry(t, cache, "q1", []byte("r1"))
+	checkQueryCacheEntry(t, cache, "q2", nil)
+	checkQueryCacheEntry(t, cache, "q3", []byte("r3"))
+
+	_, blockNumber, _ := cache.get("q3")
+	if blockNumber != 1 {
+		t.Fatalf("Expected the cached result to reflect block 1, got %d", blockNumber)
+	}
+}
+
+func TestQueryCacheInvalidation(t *testing.T) {
+	cache := newQueryCache(10)
+	generation := cache.getGeneration()
+	cache.put("q1", []byte("r1"), 1, generation, []string{"cc1"})
+	cache.put("q2", []byte("r2"), 1, generation, []string{"cc1", "cc2"})
+	cache.put("q3", []byte("r3"), 1, generation, []string{"cc3"})
+
+	cache.StateChanged(map[string][]string{"cc2": {"key"}})
+	checkQueryCacheEntry(t, cache, "q1", []byte("r1"))
+	checkQueryCacheEntry(t, cache, "q2", nil)
+	checkQueryCacheEntry(t, cache, "q3", []byte("r3"))
+
+	// A result computed from a snapshot taken before cc2 changed is not cached
+	cache.put("q2", []byte("r2"), 1, generation, []string{"cc1", "cc2"})
+	checkQueryCacheEntry(t, cache, "q2", nil)
+	cache.put("q4", []byte("r4"), 1, generation, []string{"cc1"})
+	checkQueryCacheEntry(t, cache, "q4", []byte("r4"))
+
+	generation = cache.getGeneration()
+	cache.put("q2", []byte("r5"), 2, generation, []string{"cc1", "cc2"})
+	checkQueryCacheEntry(t, cache, "q2", []byte("r5"))
+
+	// Deleting the whole state drops every result
+	cache.StateChanged(nil)
+	for _, key := range []string{"q1", "q2", "q3", "q4"} {
+		checkQueryCacheEntry(t, cache, key, nil)
+	}
+	cache.put("q1", []byte("r1"), 1, generation, []string{"cc1"})
+	checkQueryCacheEntry(t, cache, "q1", nil)
+}
+
+func TestQueryCacheInvalidationOnUpgrade(t *testing.T) {
+	cache := newQueryCache(10)
+	generation := cache.getGeneration()
+	cache.put("q1", []byte("r1"), 1, generation, []string{"cc1"})
+	cache.put("q2", []byte("r2"), 1, generation, []string{"cc2"})
+
+	// An upgrade only changes the version history of the chaincode
+	cache.StateChanged(map[string][]string{chaincodeVersionsNamespace: {"cc1"}})
+	checkQueryCacheEntry(t, cache, "q1", nil)
+	checkQueryCacheEntry(t, cache, "q2", []byte("r2"))
+
+	// A result computed by the previous code is not cached
+	cache.put("q1", []byte("r1"), 1, generation, []string{"cc1"})
+	checkQueryCacheEntry(t, cache, "q1", nil)
+}
+
+func TestQueryContextRecordsExecutedChaincodes(t *testing.T) {
+	queryCtx := newQueryContext(nil)
+	queryCtx.addChaincodeID("proxy")
+	queryCtx.addChaincodeID("callee")
+	queryCtx.addChaincodeID("proxy")
+	if ids := queryCtx.getChaincodeIDs(); len(ids) != 2 || ids[0] != "callee" || ids[1] != "proxy" {
+		t.Fatalf("Expected the executed chaincodes to be recorded once, got %v", ids)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+// chaincodeAdapter runs a SingleEntryChaincode as a Chaincode
+type chaincodeAdapter struct {
+	cc SingleEntryChaincode
+}
+
+// NewChaincodeAdapter returns a Chaincode that passes Init to cc.Init and
+// both Invoke and Query to cc.Invoke, so that a chaincode written against the
+// single-entry interface can be started with Start and tested with MockStub.
+// The function and args are ignored, cc reads them from the stub.
+func NewChaincodeAdapter(cc SingleEntryChaincode) Chaincode {
+	return &chaincodeAdapter{cc}
+}
+
+func (a *chaincodeAdapter) Init(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return a.cc.Init(stub)
+}
+
+func (a *chaincodeAdapter) Invoke(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return a.cc.Invoke(stub)
+}
+
+func (a *chaincodeAdapter) Query(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return a.cc.Invoke(stub)
+}
+
+// getFunctionAndParameters splits args into the function name and its
+// parameters
+func getFunctionAndParameters(args []string) (string, []string) {
+	if len(args) == 0 {
+		return "", []string{}
+	}
+	return args[0], args[1:]
+}
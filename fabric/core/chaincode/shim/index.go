@@ -0,0 +1,164 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Composite keys start with compositeKeyNamespace so that they can never
+// collide with plain keys, and every part is terminated by
+// compositeKeySeparator so that a prefix scan over ("name", "a") does not
+// match ("name", "ab").
+const (
+	compositeKeyNamespace = "\x00"
+	compositeKeySeparator = "\x00"
+	maxUnicodeRuneValue   = utf8.MaxRune
+)
+
+// indexEntryValue is stored under every index entry key. The key itself
+// carries all the information, but an empty value is indistinguishable from
+// a deleted key.
+var indexEntryValue = []byte{0x00}
+
+// CreateCompositeKey combines the given objectType and attributes into a
+// single key. Neither the objectType nor the attributes may contain U+0000.
+func CreateCompositeKey(objectType string, attributes []string) (string, error) {
+	if err := validateCompositeKeyAttribute(objectType); err != nil {
+		return "", err
+	}
+	ck := compositeKeyNamespace + objectType + compositeKeySeparator
+	for _, att := range attributes {
+		if err := validateCompositeKeyAttribute(att); err != nil {
+			return "", err
+		}
+		ck += att + compositeKeySeparator
+	}
+	return ck, nil
+}
+
+// SplitCompositeKey splits a key created with CreateCompositeKey back into
+// its objectType and attributes.
+func SplitCompositeKey(compositeKey string) (string, []string, error) {
+	if !strings.HasPrefix(compositeKey, compositeKeyNamespace) || !strings.HasSuffix(compositeKey, compositeKeySeparator) {
+		return "", nil, fmt.Errorf("Not a composite key: %q", compositeKey)
+	}
+	parts := strings.Split(compositeKey[len(compositeKeyNamespace):len(compositeKey)-len(compositeKeySeparator)], compositeKeySeparator)
+	return parts[0], parts[1:], nil
+}
+
+// GetStateByPartialCompositeKey returns an iterator over all keys whose
+// objectType and leading attributes match the given ones.
+func GetStateByPartialCompositeKey(stub ChaincodeStubInterface, objectType string, attributes []string) (StateRangeQueryIteratorInterface, error) {
+	startKey, err := CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return stub.RangeQueryState(startKey, startKey+string(maxUnicodeRuneValue))
+}
+
+// PutIndexEntry records id under value in the index indexName. Each entry is
+// its own key, so concurrent transactions indexing different ids do not
+// conflict and adding an id that is already indexed is a no-op.
+func PutIndexEntry(stub ChaincodeStubInterface, indexName string, value string, id string) error {
+	key, err := CreateCompositeKey(indexName, []string{value, id})
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, indexEntryValue)
+}
+
+// DelIndexEntry removes id from value in the index indexName.
+func DelIndexEntry(stub ChaincodeStubInterface, indexName string, value string, id string) error {
+	key, err := CreateCompositeKey(indexName, []string{value, id})
+	if err != nil {
+		return err
+	}
+	return stub.DelState(key)
+}
+
+// GetIndexEntries returns the ids recorded under value in the index
+// indexName, in key order.
+func GetIndexEntries(stub ChaincodeStubInterface, indexName string, value string) ([]string, error) {
+	iter, err := GetStateByPartialCompositeKey(stub, indexName, []string{value})
+	if err != nil {
+		return nil, err
+	}
+	return collectIndexEntries(iter, indexName)
+}
+
+// GetIndexEntriesByRange returns the ids recorded under every value between
+// startValue and endValue (both inclusive) in the index indexName, in key
+// order.
+func GetIndexEntriesByRange(stub ChaincodeStubInterface, indexName string, startValue string, endValue string) ([]string, error) {
+	startKey, err := CreateCompositeKey(indexName, []string{startValue})
+	if err != nil {
+		return nil, err
+	}
+	endKey, err := CreateCompositeKey(indexName, []string{endValue})
+	if err != nil {
+		return nil, err
+	}
+	iter, err := stub.RangeQueryState(startKey, endKey+string(maxUnicodeRuneValue))
+	if err != nil {
+		return nil, err
+	}
+	return collectIndexEntries(iter, indexName)
+}
+
+// collectIndexEntries drains iter and returns the ids in key order. The peer
+// does not guarantee the order in which a range query returns its keys, so
+// they are sorted here rather than relied upon.
+func collectIndexEntries(iter StateRangeQueryIteratorInterface, indexName string) ([]string, error) {
+	defer iter.Close()
+
+	keys := []string{}
+	for iter.HasNext() {
+		key, _, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		_, attributes, err := SplitCompositeKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if len(attributes) != 2 {
+			return nil, fmt.Errorf("Malformed index entry %q in index %s", key, indexName)
+		}
+		ids = append(ids, attributes[1])
+	}
+	return ids, nil
+}
+
+func validateCompositeKeyAttribute(str string) error {
+	if !utf8.ValidString(str) {
+		return fmt.Errorf("Not a valid utf8 string: [%x]", str)
+	}
+	if strings.Contains(str, compositeKeySeparator) {
+		return fmt.Errorf("Composite key attribute must not contain U+0000: %q", str)
+	}
+	return nil
+}
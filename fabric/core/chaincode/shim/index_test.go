@@ -0,0 +1,167 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompositeKey(t *testing.T) {
+	key, err := CreateCompositeKey("color~name", []string{"blue", "marble1"})
+	if err != nil {
+		t.Fatalf("CreateCompositeKey failed: %s", err)
+	}
+	objectType, attributes, err := SplitCompositeKey(key)
+	if err != nil {
+		t.Fatalf("SplitCompositeKey failed: %s", err)
+	}
+	if objectType != "color~name" || !reflect.DeepEqual(attributes, []string{"blue", "marble1"}) {
+		t.Fatalf("SplitCompositeKey returned %s %v", objectType, attributes)
+	}
+
+	if _, err = CreateCompositeKey("color~name", []string{"bl\x00ue"}); err == nil {
+		t.Fatal("CreateCompositeKey should reject attributes containing U+0000")
+	}
+	if _, _, err = SplitCompositeKey("SLA_CONTRACT_ID_COUNT"); err == nil {
+		t.Fatal("SplitCompositeKey should reject plain keys")
+	}
+}
+
+func TestIndexEntries(t *testing.T) {
+	stub := NewMockStub("indexTest", nil)
+	stub.MockTransactionStart("init")
+	defer stub.MockTransactionEnd("init")
+
+	PutIndexEntry(stub, "client", "bank", "SLA_CONT_1")
+	PutIndexEntry(stub, "client", "bank", "SLA_CONT_12")
+	PutIndexEntry(stub, "client", "bank", "SLA_CONT_1")
+	PutIndexEntry(stub, "client", "bank2", "SLA_CONT_2")
+	PutIndexEntry(stub, "name", "bank", "SLA_CONT_3")
+	stub.PutState("bank", []byte("plain key"))
+
+	ids, err := GetIndexEntries(stub, "client", "bank")
+	if err != nil {
+		t.Fatalf("GetIndexEntries failed: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"SLA_CONT_1", "SLA_CONT_12"}) {
+		t.Fatalf("GetIndexEntries returned %v", ids)
+	}
+
+	DelIndexEntry(stub, "client", "bank", "SLA_CONT_1")
+	ids, _ = GetIndexEntries(stub, "client", "bank")
+	if !reflect.DeepEqual(ids, []string{"SLA_CONT_12"}) {
+		t.Fatalf("GetIndexEntries after DelIndexEntry returned %v", ids)
+	}
+
+	ids, _ = GetIndexEntries(stub, "client", "unknown")
+	if len(ids) != 0 {
+		t.Fatalf("GetIndexEntries for an unknown value returned %v", ids)
+	}
+}
+
+func TestIndexEntriesByRange(t *testing.T) {
+	stub := NewMockStub("indexTest", nil)
+	stub.MockTransactionStart("init")
+	defer stub.MockTransactionEnd("init")
+
+	PutIndexEntry(stub, "date", "2017-02-28", "1")
+	PutIndexEntry(stub, "date", "2017-03-01", "2")
+	PutIndexEntry(stub, "date", "2017-03-01", "3")
+	PutIndexEntry(stub, "date", "2017-03-31", "4")
+	PutIndexEntry(stub, "date", "2017-04-01", "5")
+	PutIndexEntry(stub, "registeredBy", "2017-03-15", "6")
+
+	ids, err := GetIndexEntriesByRange(stub, "date", "2017-03-01", "2017-03-31")
+	if err != nil {
+		t.Fatalf("GetIndexEntriesByRange failed: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"2", "3", "4"}) {
+		t.Fatalf("GetIndexEntriesByRange returned %v", ids)
+	}
+}
+
+// unorderedStub returns the keys of a range query in reverse order, as the
+// peer is free to do.
+type unorderedStub struct {
+	*MockStub
+}
+
+type reverseIterator struct {
+	keys   []string
+	values [][]byte
+}
+
+func (stub unorderedStub) RangeQueryState(startKey, endKey string) (StateRangeQueryIteratorInterface, error) {
+	iter, err := stub.MockStub.RangeQueryState(startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	reversed := &reverseIterator{}
+	for iter.HasNext() {
+		key, value, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		reversed.keys = append([]string{key}, reversed.keys...)
+		reversed.values = append([][]byte{value}, reversed.values...)
+	}
+	return reversed, nil
+}
+
+func (iter *reverseIterator) HasNext() bool {
+	return len(iter.keys) > 0
+}
+
+func (iter *reverseIterator) Next() (string, []byte, error) {
+	key, value := iter.keys[0], iter.values[0]
+	iter.keys, iter.values = iter.keys[1:], iter.values[1:]
+	return key, value, nil
+}
+
+func (iter *reverseIterator) Close() error {
+	return nil
+}
+
+func TestIndexEntriesUnorderedRangeQuery(t *testing.T) {
+	mock := NewMockStub("indexTest", nil)
+	stub := unorderedStub{mock}
+	mock.MockTransactionStart("init")
+	defer mock.MockTransactionEnd("init")
+
+	PutIndexEntry(stub, "date", "2017-03-01", "2")
+	PutIndexEntry(stub, "date", "2017-03-01", "3")
+	PutIndexEntry(stub, "date", "2017-03-31", "4")
+
+	ids, err := GetIndexEntries(stub, "date", "2017-03-01")
+	if err != nil {
+		t.Fatalf("GetIndexEntries failed: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"2", "3"}) {
+		t.Fatalf("GetIndexEntries returned %v", ids)
+	}
+
+	ids, err = GetIndexEntriesByRange(stub, "date", "2017-03-01", "2017-03-31")
+	if err != nil {
+		t.Fatalf("GetIndexEntriesByRange failed: %s", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"2", "3", "4"}) {
+		t.Fatalf("GetIndexEntriesByRange returned %v", ids)
+	}
+}
@@ -38,6 +38,19 @@ type Chaincode interface {
 	Query(stub ChaincodeStubInterface, function string, args []string) ([]byte, error)
 }
 
+// SingleEntryChaincode is the single-entry form of Chaincode used by newer
+// fabric releases. The function and its arguments are read from the stub with
+// GetFunctionAndParameters, and queries are served by Invoke. Use
+// NewChaincodeAdapter to run it where a Chaincode is expected.
+type SingleEntryChaincode interface {
+	// Init is called during Deploy transaction after the container has been
+	// established, allowing the chaincode to initialize its internal data
+	Init(stub ChaincodeStubInterface) ([]byte, error)
+
+	// Invoke is called for every Invoke and Query transaction
+	Invoke(stub ChaincodeStubInterface) ([]byte, error)
+}
+
 // ChaincodeStubInterface is used by deployable chaincode apps to access and modify their ledgers
 type ChaincodeStubInterface interface {
 	// Get the arguments to the stub call as a 2D byte array
@@ -46,6 +59,10 @@ type ChaincodeStubInterface interface {
 	// Get the arguments to the stub call as a string array
 	GetStringArgs() []string
 
+	// Get the function name, which is the first argument, and the remaining
+	// arguments as its parameters
+	GetFunctionAndParameters() (string, []string)
+
 	// Get the transaction ID
 	GetTxID() string
 
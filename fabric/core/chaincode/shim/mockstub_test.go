@@ -17,8 +17,16 @@ limitations under the License.
 package shim
 
 import (
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/attr"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
 )
 
 func TestMockStateRangeQueryIterator(t *testing.T) {
@@ -50,3 +58,401 @@ func TestMockStateRangeQueryIterator(t *testing.T) {
 		}
 	}
 }
+
+func TestMockStateRangeQueryIteratorBounds(t *testing.T) {
+	stub := NewMockStub("rangeTest", nil)
+	stub.MockTransactionStart("init")
+	stub.PutState("a", []byte{61})
+	stub.PutState("b1", []byte{62})
+	stub.PutState("b2", []byte{63})
+	stub.PutState("c", []byte{64})
+	stub.MockTransactionEnd("init")
+
+	expectKeys := []string{"b1", "b2"}
+
+	rqi := NewMockStateRangeQueryIterator(stub, "b", "b~")
+	keys := []string{}
+	for rqi.HasNext() {
+		key, _, err := rqi.Next()
+		if err != nil {
+			fmt.Println("Unexpected error", err)
+			t.FailNow()
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) != len(expectKeys) {
+		fmt.Println("Expected keys", expectKeys, "got", keys)
+		t.FailNow()
+	}
+	for i := range expectKeys {
+		if expectKeys[i] != keys[i] {
+			fmt.Println("Expected key", expectKeys[i], "got", keys[i])
+			t.FailNow()
+		}
+	}
+
+	rqi = NewMockStateRangeQueryIterator(stub, "d", "e")
+	if rqi.HasNext() {
+		fmt.Println("Expected empty range")
+		t.FailNow()
+	}
+}
+
+func TestMockStubTxTimestamp(t *testing.T) {
+	stub := NewMockStub("timestampTest", nil)
+	if ts, _ := stub.GetTxTimestamp(); ts != nil {
+		t.Fatalf("Expected no timestamp, got %v", ts)
+	}
+
+	txTime := time.Date(2017, 3, 1, 10, 0, 0, 500, time.UTC)
+	stub.SetTxTimestamp(txTime)
+	ts, err := stub.GetTxTimestamp()
+	if err != nil || ts == nil {
+		t.Fatalf("GetTxTimestamp failed: %v %v", ts, err)
+	}
+	if !time.Unix(ts.Seconds, int64(ts.Nanos)).Equal(txTime) {
+		t.Fatalf("Expected %v, got %v", txTime, ts)
+	}
+}
+
+func TestMockStubSetEvent(t *testing.T) {
+	stub := NewMockStub("eventTest", nil)
+	stub.MockTransactionStart("1")
+	stub.SetEvent("first", []byte("1"))
+	stub.SetEvent("second", []byte("2"))
+	if stub.ChaincodeEvent == nil || stub.ChaincodeEvent.EventName != "second" || string(stub.ChaincodeEvent.Payload) != "2" {
+		t.Fatalf("Expected the last event to be kept, got %v", stub.ChaincodeEvent)
+	}
+	stub.MockTransactionEnd("1")
+
+	stub.MockTransactionStart("2")
+	if stub.ChaincodeEvent != nil {
+		t.Fatalf("Expected the event to be cleared by a new transaction, got %v", stub.ChaincodeEvent)
+	}
+}
+
+// writeThenFailChaincode writes every arg as a key and fails if function is "fail"
+type writeThenFailChaincode struct{}
+
+func (t *writeThenFailChaincode) Init(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return nil, nil
+}
+
+func (t *writeThenFailChaincode) Invoke(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	if _, err := stub.GetState("counter"); err != nil {
+		return nil, err
+	}
+	for _, arg := range args {
+		if err := stub.PutState(arg, []byte(function)); err != nil {
+			return nil, err
+		}
+	}
+	if err := stub.DelState("counter"); err != nil {
+		return nil, err
+	}
+	stub.SetEvent("written", nil)
+	if function == "fail" {
+		return nil, errors.New("failed after writing")
+	}
+	return nil, nil
+}
+
+func (t *writeThenFailChaincode) Query(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return nil, nil
+}
+
+func TestMockStubRollback(t *testing.T) {
+	stub := NewMockStub("rollbackTest", new(writeThenFailChaincode))
+	stub.MockTransactionStart("init")
+	stub.PutState("a", []byte("init"))
+	stub.PutState("counter", []byte{1})
+	stub.MockTransactionEnd("init")
+
+	if _, err := stub.MockInvoke("1", "fail", []string{"a", "b", "a"}); err == nil {
+		t.Fatalf("Expected the invoke to fail")
+	}
+	if string(stub.State["a"]) != "init" || stub.State["b"] != nil || stub.State["counter"] == nil {
+		t.Fatalf("Expected the writes to be rolled back, got %v", stub.State)
+	}
+	keys := []string{}
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		keys = append(keys, elem.Value.(string))
+	}
+	if !reflect.DeepEqual(keys, []string{"a", "counter"}) {
+		t.Fatalf("Expected keys [a counter], got %v", keys)
+	}
+	if stub.ChaincodeEvent != nil {
+		t.Fatalf("Expected the event to be dropped, got %v", stub.ChaincodeEvent)
+	}
+	if tx := stub.LastTransaction(); tx == nil || tx.TxID != "1" || !tx.RolledBack {
+		t.Fatalf("Expected transaction 1 to be rolled back, got %v", tx)
+	}
+
+	if _, err := stub.MockInvoke("2", "ok", []string{"b"}); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if string(stub.State["b"]) != "ok" || stub.State["counter"] != nil {
+		t.Fatalf("Expected the writes to be kept, got %v", stub.State)
+	}
+	if tx := stub.LastTransaction(); tx == nil || tx.TxID != "2" || tx.RolledBack {
+		t.Fatalf("Expected transaction 2 to be committed, got %v", tx)
+	}
+}
+
+// invokeThenFailChaincode invokes "ok" on the peer chaincode "callee" with
+// its args, twice, and fails if function is "fail"
+type invokeThenFailChaincode struct{}
+
+func (t *invokeThenFailChaincode) Init(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return nil, nil
+}
+
+func (t *invokeThenFailChaincode) Invoke(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	for _, arg := range args {
+		if _, err := stub.InvokeChaincode("callee", [][]byte{[]byte("ok"), []byte(arg)}); err != nil {
+			return nil, err
+		}
+	}
+	if function == "fail" {
+		return nil, errors.New("failed after invoking callee")
+	}
+	return nil, nil
+}
+
+func (t *invokeThenFailChaincode) Query(stub ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return nil, nil
+}
+
+func TestMockStubInvokeChaincodeRollback(t *testing.T) {
+	callee := NewMockStub("callee", new(writeThenFailChaincode))
+	callee.MockTransactionStart("init")
+	callee.PutState("counter", []byte{1})
+	callee.MockTransactionEnd("init")
+
+	stub := NewMockStub("caller", new(invokeThenFailChaincode))
+	stub.MockPeerChaincode("callee", callee)
+
+	if _, err := stub.MockInvoke("1", "fail", []string{"a", "b"}); err == nil {
+		t.Fatalf("Expected the invoke to fail")
+	}
+	if callee.State["a"] != nil || callee.State["b"] != nil || callee.State["counter"] == nil {
+		t.Fatalf("Expected the writes of the callee to be rolled back, got %v", callee.State)
+	}
+	if callee.Keys.Len() != 1 {
+		t.Fatalf("Expected only the key counter, got %d keys", callee.Keys.Len())
+	}
+	if tx := callee.LastTransaction(); tx == nil || tx.TxID != "1" || !tx.RolledBack {
+		t.Fatalf("Expected transaction 1 of the callee to be rolled back, got %v", tx)
+	}
+	if len(callee.Transactions) != 2 {
+		t.Fatalf("Expected both invokes of the callee to be one transaction, got %d transactions", len(callee.Transactions))
+	}
+
+	if _, err := stub.MockInvoke("2", "ok", []string{"a"}); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if string(callee.State["a"]) != "ok" || callee.State["counter"] != nil {
+		t.Fatalf("Expected the writes of the callee to be kept, got %v", callee.State)
+	}
+	if tx := callee.LastTransaction(); tx == nil || tx.TxID != "2" || tx.RolledBack {
+		t.Fatalf("Expected transaction 2 of the callee to be committed, got %v", tx)
+	}
+	if err := callee.PutState("c", nil); err == nil {
+		t.Fatalf("Expected the transaction of the callee to be ended")
+	}
+}
+
+func TestMockStubReadWriteSet(t *testing.T) {
+	stub := NewMockStub("readWriteSetTest", nil)
+	if stub.LastTransaction() != nil {
+		t.Fatalf("Expected no transaction")
+	}
+	if err := stub.DelState("a"); err == nil {
+		t.Fatalf("Expected DelState to fail without a transaction")
+	}
+
+	stub.MockTransactionStart("1")
+	stub.PutState("a", []byte{1})
+	stub.PutState("b", []byte{2})
+	stub.PutState("c", []byte{3})
+	stub.MockTransactionEnd("1")
+
+	stub.MockTransactionStart("2")
+	stub.GetState("c")
+	rqi, _ := stub.RangeQueryState("a", "b")
+	for rqi.HasNext() {
+		rqi.Next()
+	}
+	rqi.Close()
+	stub.GetState("c")
+	stub.PutState("a", []byte{4})
+	stub.PutState("a", []byte{5})
+	stub.DelState("b")
+	stub.MockTransactionEnd("2")
+
+	if len(stub.Transactions) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(stub.Transactions))
+	}
+	tx := stub.LastTransaction()
+	if !reflect.DeepEqual(tx.ReadSet, []string{"c", "a", "b"}) {
+		t.Fatalf("Expected read set [c a b], got %v", tx.ReadSet)
+	}
+	if !reflect.DeepEqual(tx.WriteSet, map[string][]byte{"a": {5}, "b": nil}) {
+		t.Fatalf("Expected write set a=5 and b deleted, got %v", tx.WriteSet)
+	}
+}
+
+// echoChaincode returns the function and parameters it was called with
+type echoChaincode struct{}
+
+func (t *echoChaincode) Init(stub ChaincodeStubInterface) ([]byte, error) {
+	return t.Invoke(stub)
+}
+
+func (t *echoChaincode) Invoke(stub ChaincodeStubInterface) ([]byte, error) {
+	function, params := stub.GetFunctionAndParameters()
+	return []byte(fmt.Sprintf("%s %v", function, params)), nil
+}
+
+func TestChaincodeAdapter(t *testing.T) {
+	stub := NewMockStub("adapterTest", NewChaincodeAdapter(new(echoChaincode)))
+
+	if function, params := stub.GetFunctionAndParameters(); function != "" || len(params) != 0 {
+		t.Fatalf("Expected no function and parameters, got %v %v", function, params)
+	}
+	if bytes, err := stub.MockInit("1", "init", []string{"a"}); err != nil || string(bytes) != "init [a]" {
+		t.Fatalf("Expected init [a], got %s %v", bytes, err)
+	}
+	if bytes, err := stub.MockInvoke("2", "invoke", []string{"b", "c"}); err != nil || string(bytes) != "invoke [b c]" {
+		t.Fatalf("Expected invoke [b c], got %s %v", bytes, err)
+	}
+	if bytes, err := stub.MockQuery("query", []string{}); err != nil || string(bytes) != "query []" {
+		t.Fatalf("Expected query [], got %s %v", bytes, err)
+	}
+}
+
+func stringColumn(value string) *Column {
+	return &Column{Value: &Column_String_{String_: value}}
+}
+
+func int32Column(value int32) *Column {
+	return &Column{Value: &Column_Int32{Int32: value}}
+}
+
+func TestMockStubTable(t *testing.T) {
+	stub := NewMockStub("tableTest", nil)
+	stub.MockTransactionStart("1")
+	defer stub.MockTransactionEnd("1")
+
+	if _, err := stub.GetTable("accounts"); err != ErrTableNotFound {
+		t.Fatalf("Expected ErrTableNotFound, got %v", err)
+	}
+	err := stub.CreateTable("accounts", []*ColumnDefinition{
+		{Name: "bank", Type: ColumnDefinition_STRING, Key: true},
+		{Name: "id", Type: ColumnDefinition_STRING, Key: true},
+		{Name: "balance", Type: ColumnDefinition_INT32, Key: false},
+	})
+	if err != nil {
+		t.Fatalf("CreateTable failed: %v", err)
+	}
+	if err = stub.CreateTable("accounts", []*ColumnDefinition{{Name: "id", Type: ColumnDefinition_STRING, Key: true}}); err == nil {
+		t.Fatalf("Expected CreateTable to fail for an existing table")
+	}
+	table, err := stub.GetTable("accounts")
+	if err != nil || table.Name != "accounts" || len(table.ColumnDefinitions) != 3 {
+		t.Fatalf("GetTable returned %v %v", table, err)
+	}
+
+	rows := []Row{
+		{Columns: []*Column{stringColumn("a"), stringColumn("1"), int32Column(10)}},
+		{Columns: []*Column{stringColumn("a"), stringColumn("2"), int32Column(20)}},
+		{Columns: []*Column{stringColumn("ab"), stringColumn("1"), int32Column(30)}},
+	}
+	for _, row := range rows {
+		if ok, err := stub.InsertRow("accounts", row); !ok || err != nil {
+			t.Fatalf("InsertRow %v returned %v %v", row, ok, err)
+		}
+	}
+	if ok, err := stub.InsertRow("accounts", rows[0]); ok || err != nil {
+		t.Fatalf("Expected InsertRow of an existing row to return false, got %v %v", ok, err)
+	}
+	if _, err := stub.InsertRow("accounts", Row{Columns: []*Column{stringColumn("a"), stringColumn("3"), stringColumn("x")}}); err == nil {
+		t.Fatalf("Expected InsertRow to fail for a mistyped column")
+	}
+	if ok, err := stub.ReplaceRow("accounts", Row{Columns: []*Column{stringColumn("a"), stringColumn("3"), int32Column(0)}}); ok || err != nil {
+		t.Fatalf("Expected ReplaceRow of a missing row to return false, got %v %v", ok, err)
+	}
+	if ok, err := stub.ReplaceRow("accounts", Row{Columns: []*Column{stringColumn("a"), stringColumn("1"), int32Column(11)}}); !ok || err != nil {
+		t.Fatalf("ReplaceRow returned %v %v", ok, err)
+	}
+
+	row, err := stub.GetRow("accounts", []Column{*stringColumn("a"), *stringColumn("1")})
+	if err != nil || row.Columns[2].GetInt32() != 11 {
+		t.Fatalf("GetRow returned %v %v", row, err)
+	}
+
+	// a partial key does not match rows of another key sharing its prefix
+	rowChannel, err := stub.GetRows("accounts", []Column{*stringColumn("a")})
+	if err != nil {
+		t.Fatalf("GetRows failed: %v", err)
+	}
+	var balances []int32
+	for row := range rowChannel {
+		balances = append(balances, row.Columns[2].GetInt32())
+	}
+	if fmt.Sprint(balances) != "[11 20]" {
+		t.Fatalf("GetRows returned balances %v", balances)
+	}
+
+	if err = stub.DeleteRow("accounts", []Column{*stringColumn("a"), *stringColumn("1")}); err != nil {
+		t.Fatalf("DeleteRow failed: %v", err)
+	}
+	if row, _ = stub.GetRow("accounts", []Column{*stringColumn("a"), *stringColumn("1")}); len(row.Columns) != 0 {
+		t.Fatalf("Expected deleted row to be empty, got %v", row)
+	}
+
+	if err = stub.DeleteTable("accounts"); err != nil {
+		t.Fatalf("DeleteTable failed: %v", err)
+	}
+	if len(stub.State) != 0 {
+		t.Fatalf("Expected DeleteTable to delete every row, state is %v", stub.State)
+	}
+}
+
+func TestMockStubAttributes(t *testing.T) {
+	stub := NewMockStub("attributesTest", nil)
+	if _, err := stub.ReadCertAttribute("position"); err == nil {
+		t.Fatalf("Expected ReadCertAttribute to fail without a caller certificate")
+	}
+
+	stub.SetAttributes(map[string]string{"position": "Software Engineer"})
+	value, err := stub.ReadCertAttribute("position")
+	if err != nil || string(value) != "Software Engineer" {
+		t.Fatalf("ReadCertAttribute returned %s %v", value, err)
+	}
+	if _, err = stub.ReadCertAttribute("company"); err == nil {
+		t.Fatalf("Expected ReadCertAttribute of a missing attribute to fail")
+	}
+	if ok, err := stub.VerifyAttribute("position", []byte("Manager")); ok || err != nil {
+		t.Fatalf("VerifyAttribute of a different value returned %v %v", ok, err)
+	}
+	if ok, err := stub.VerifyAttributes(&attr.Attribute{Name: "position", Value: []byte("Software Engineer")}); !ok || err != nil {
+		t.Fatalf("VerifyAttributes returned %v %v", ok, err)
+	}
+
+	// attributes embedded in the caller certificate
+	primitives.SetSecurityLevel("SHA3", 256)
+	pemBytes, err := ioutil.ReadFile("./crypto/attr/test_resources/tcert_clear.dump")
+	if err != nil {
+		t.Fatalf("Failed to read test certificate: %v", err)
+	}
+	block, _ := pem.Decode(pemBytes)
+	stub.SetAttributes(nil)
+	stub.SetCallerCertificate(block.Bytes, nil)
+	if cert, _ := stub.GetCallerCertificate(); string(cert) != string(block.Bytes) {
+		t.Fatalf("GetCallerCertificate did not return the certificate set with SetCallerCertificate")
+	}
+	if ok, err := stub.VerifyAttribute("position", []byte("Software Engineer")); !ok || err != nil {
+		t.Fatalf("VerifyAttribute from certificate returned %v %v", ok, err)
+	}
+}
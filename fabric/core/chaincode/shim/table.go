@@ -0,0 +1,413 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// Table Errors
+var (
+	// ErrTableNotFound if the specified table cannot be found
+	ErrTableNotFound = errors.New("chaincode: Table not found")
+)
+
+// createTable implements CreateTable on top of the state functions of stub.
+func createTable(stub ChaincodeStubInterface, name string, columnDefinitions []*ColumnDefinition) error {
+
+	_, err := getTable(stub, name)
+	if err == nil {
+		return fmt.Errorf("CreateTable operation failed. Table %s already exists.", name)
+	}
+	if err != ErrTableNotFound {
+		return fmt.Errorf("CreateTable operation failed. %s", err)
+	}
+
+	if columnDefinitions == nil || len(columnDefinitions) == 0 {
+		return errors.New("Invalid column definitions. Tables must contain at least one column.")
+	}
+
+	hasKey := false
+	nameMap := make(map[string]bool)
+	for i, definition := range columnDefinitions {
+
+		// Check name
+		if definition == nil {
+			return fmt.Errorf("Column definition %d is invalid. Definition must not be nil.", i)
+		}
+		if len(definition.Name) == 0 {
+			return fmt.Errorf("Column definition %d is invalid. Name must be 1 or more characters.", i)
+		}
+		if _, exists := nameMap[definition.Name]; exists {
+			return fmt.Errorf("Invalid table. Table contains duplicate column name '%s'.", definition.Name)
+		}
+		nameMap[definition.Name] = true
+
+		// Check type
+		switch definition.Type {
+		case ColumnDefinition_STRING:
+		case ColumnDefinition_INT32:
+		case ColumnDefinition_INT64:
+		case ColumnDefinition_UINT32:
+		case ColumnDefinition_UINT64:
+		case ColumnDefinition_BYTES:
+		case ColumnDefinition_BOOL:
+		default:
+			return fmt.Errorf("Column definition %s does not have a valid type.", definition.Name)
+		}
+
+		if definition.Key {
+			hasKey = true
+		}
+	}
+
+	if !hasKey {
+		return errors.New("Inavlid table. One or more columns must be a key.")
+	}
+
+	table := &Table{name, columnDefinitions}
+	tableBytes, err := proto.Marshal(table)
+	if err != nil {
+		return fmt.Errorf("Error marshalling table: %s", err)
+	}
+	tableNameKey, err := getTableNameKey(name)
+	if err != nil {
+		return fmt.Errorf("Error creating table key: %s", err)
+	}
+	err = stub.PutState(tableNameKey, tableBytes)
+	if err != nil {
+		return fmt.Errorf("Error inserting table in state: %s", err)
+	}
+	return nil
+}
+
+// deleteTable implements DeleteTable.
+func deleteTable(stub ChaincodeStubInterface, tableName string) error {
+	tableNameKey, err := getTableNameKey(tableName)
+	if err != nil {
+		return err
+	}
+
+	// Delete rows
+	iter, err := stub.RangeQueryState(tableNameKey+"1", tableNameKey+":")
+	if err != nil {
+		return fmt.Errorf("Error deleting table: %s", err)
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		key, _, err := iter.Next()
+		if err != nil {
+			return fmt.Errorf("Error deleting table: %s", err)
+		}
+		err = stub.DelState(key)
+		if err != nil {
+			return fmt.Errorf("Error deleting table: %s", err)
+		}
+	}
+
+	return stub.DelState(tableNameKey)
+}
+
+// getRow implements GetRow.
+func getRow(stub ChaincodeStubInterface, tableName string, key []Column) (Row, error) {
+
+	var row Row
+
+	keyString, err := buildKeyString(tableName, key)
+	if err != nil {
+		return row, err
+	}
+
+	rowBytes, err := stub.GetState(keyString)
+	if err != nil {
+		return row, fmt.Errorf("Error fetching row from DB: %s", err)
+	}
+
+	err = proto.Unmarshal(rowBytes, &row)
+	if err != nil {
+		return row, fmt.Errorf("Error unmarshalling row: %s", err)
+	}
+
+	return row, nil
+
+}
+
+// getRows implements GetRows.
+func getRows(stub ChaincodeStubInterface, tableName string, key []Column) (<-chan Row, error) {
+
+	keyString, err := buildKeyString(tableName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := getTable(stub, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Need to check for special case where table has a single column
+	if len(table.GetColumnDefinitions()) < 2 && len(key) > 0 {
+
+		row, err := getRow(stub, tableName, key)
+		if err != nil {
+			return nil, err
+		}
+		rows := make(chan Row)
+		go func() {
+			rows <- row
+			close(rows)
+		}()
+		return rows, nil
+	}
+
+	iter, err := stub.RangeQueryState(keyString+"1", keyString+":")
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching rows: %s", err)
+	}
+
+	rows := make(chan Row)
+
+	// The iterator is read by the goroutine, so it is closed there and not
+	// when getRows returns.
+	go func() {
+		defer close(rows)
+		defer iter.Close()
+		for iter.HasNext() {
+			_, rowBytes, err := iter.Next()
+			if err != nil {
+				return
+			}
+
+			var row Row
+			err = proto.Unmarshal(rowBytes, &row)
+			if err != nil {
+				return
+			}
+
+			rows <- row
+
+		}
+	}()
+
+	return rows, nil
+
+}
+
+// deleteRow implements DeleteRow.
+func deleteRow(stub ChaincodeStubInterface, tableName string, key []Column) error {
+
+	keyString, err := buildKeyString(tableName, key)
+	if err != nil {
+		return err
+	}
+
+	err = stub.DelState(keyString)
+	if err != nil {
+		return fmt.Errorf("DeleteRow operation error. Error deleting row: %s", err)
+	}
+
+	return nil
+}
+
+func getTable(stub ChaincodeStubInterface, tableName string) (*Table, error) {
+
+	tableName, err := getTableNameKey(tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	tableBytes, err := stub.GetState(tableName)
+	if tableBytes == nil {
+		return nil, ErrTableNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching table: %s", err)
+	}
+	table := &Table{}
+	err = proto.Unmarshal(tableBytes, table)
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshalling table: %s", err)
+	}
+
+	return table, nil
+}
+
+func validateTableName(name string) error {
+	if len(name) == 0 {
+		return errors.New("Inavlid table name. Table name must be 1 or more characters.")
+	}
+
+	return nil
+}
+
+func getTableNameKey(name string) (string, error) {
+	err := validateTableName(name)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(len(name)) + name, nil
+}
+
+func buildKeyString(tableName string, keys []Column) (string, error) {
+
+	var keyBuffer bytes.Buffer
+
+	tableNameKey, err := getTableNameKey(tableName)
+	if err != nil {
+		return "", err
+	}
+
+	keyBuffer.WriteString(tableNameKey)
+
+	for _, key := range keys {
+
+		var keyString string
+		switch key.Value.(type) {
+		case *Column_String_:
+			keyString = key.GetString_()
+		case *Column_Int32:
+			// b := make([]byte, 4)
+			// binary.LittleEndian.PutUint32(b, uint32(key.GetInt32()))
+			// keyBuffer.Write(b)
+			keyString = strconv.FormatInt(int64(key.GetInt32()), 10)
+		case *Column_Int64:
+			keyString = strconv.FormatInt(key.GetInt64(), 10)
+		case *Column_Uint32:
+			keyString = strconv.FormatUint(uint64(key.GetUint32()), 10)
+		case *Column_Uint64:
+			keyString = strconv.FormatUint(key.GetUint64(), 10)
+		case *Column_Bytes:
+			keyString = string(key.GetBytes())
+		case *Column_Bool:
+			keyString = strconv.FormatBool(key.GetBool())
+		}
+
+		keyBuffer.WriteString(strconv.Itoa(len(keyString)))
+		keyBuffer.WriteString(keyString)
+	}
+
+	return keyBuffer.String(), nil
+}
+
+func getKeyAndVerifyRow(table Table, row Row) ([]Column, error) {
+
+	var keys []Column
+
+	if row.Columns == nil || len(row.Columns) != len(table.ColumnDefinitions) {
+		return keys, fmt.Errorf("Table '%s' defines %d columns, but row has %d columns.",
+			table.Name, len(table.ColumnDefinitions), len(row.Columns))
+	}
+
+	for i, column := range row.Columns {
+
+		// Check types
+		var expectedType bool
+		switch column.Value.(type) {
+		case *Column_String_:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_STRING
+		case *Column_Int32:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_INT32
+		case *Column_Int64:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_INT64
+		case *Column_Uint32:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_UINT32
+		case *Column_Uint64:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_UINT64
+		case *Column_Bytes:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_BYTES
+		case *Column_Bool:
+			expectedType = table.ColumnDefinitions[i].Type == ColumnDefinition_BOOL
+		default:
+			expectedType = false
+		}
+		if !expectedType {
+			return keys, fmt.Errorf("The type for table '%s', column '%s' is '%s', but the column in the row does not match.",
+				table.Name, table.ColumnDefinitions[i].Name, table.ColumnDefinitions[i].Type)
+		}
+
+		if table.ColumnDefinitions[i].Key {
+			keys = append(keys, *column)
+		}
+
+	}
+
+	return keys, nil
+}
+
+func isRowPresent(stub ChaincodeStubInterface, tableName string, key []Column) (bool, error) {
+	keyString, err := buildKeyString(tableName, key)
+	if err != nil {
+		return false, err
+	}
+	rowBytes, err := stub.GetState(keyString)
+	if err != nil {
+		return false, fmt.Errorf("Error fetching row for key %s: %s", keyString, err)
+	}
+	if rowBytes != nil {
+		return true, nil
+	}
+	return false, nil
+}
+
+// insertRowInternal inserts a new row into the specified table.
+// Returns -
+// true and no error if the row is successfully inserted.
+// false and no error if a row already exists for the given key.
+// false and a TableNotFoundError if the specified table name does not exist.
+// false and an error if there is an unexpected error condition.
+func insertRowInternal(stub ChaincodeStubInterface, tableName string, row Row, update bool) (bool, error) {
+
+	table, err := getTable(stub, tableName)
+	if err != nil {
+		return false, err
+	}
+
+	key, err := getKeyAndVerifyRow(*table, row)
+	if err != nil {
+		return false, err
+	}
+
+	present, err := isRowPresent(stub, tableName, key)
+	if err != nil {
+		return false, err
+	}
+	if (present && !update) || (!present && update) {
+		return false, nil
+	}
+
+	rowBytes, err := proto.Marshal(&row)
+	if err != nil {
+		return false, fmt.Errorf("Error marshalling row: %s", err)
+	}
+
+	keyString, err := buildKeyString(tableName, key)
+	if err != nil {
+		return false, err
+	}
+	err = stub.PutState(keyString, rowBytes)
+	if err != nil {
+		return false, fmt.Errorf("Error inserting row in table %s: %s", tableName, err)
+	}
+
+	return true, nil
+}
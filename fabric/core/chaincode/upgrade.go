@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/ledger"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+// chaincodeVersionsNamespace is the namespace of the state holding the
+// version histories of the upgraded chaincodes, keyed by chaincode name. No
+// chaincode may be deployed under this name.
+const chaincodeVersionsNamespace = "__chaincode_versions"
+
+// getUpgradeSpec returns the upgrade spec of a decrypted upgrade transaction
+func getUpgradeSpec(t *pb.Transaction) (*pb.ChaincodeUpgradeSpec, error) {
+	cus := &pb.ChaincodeUpgradeSpec{}
+	if err := proto.Unmarshal(t.Payload, cus); err != nil {
+		return nil, err
+	}
+	if cus.ChaincodeName == "" {
+		return nil, fmt.Errorf("Invalid upgrade: no chaincode name")
+	}
+	if cus.GetDeploymentSpec().GetChaincodeSpec().GetChaincodeID() == nil {
+		return nil, fmt.Errorf("Invalid upgrade of %s: no chaincode spec", cus.ChaincodeName)
+	}
+	return cus, nil
+}
+
+// GetVersionHistory returns the version history of a chaincode, which is
+// empty if the chaincode was never upgraded.
+func GetVersionHistory(lgr *ledger.Ledger, chaincode string, committed bool) (*pb.ChaincodeVersionHistory, error) {
+	history := &pb.ChaincodeVersionHistory{}
+	data, err := lgr.GetState(chaincodeVersionsNamespace, chaincode, committed)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return history, nil
+	}
+	if err = proto.Unmarshal(data, history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// getDeployment returns the decrypted deployment transaction of a chaincode,
+// and the deployment spec of the code the chaincode runs: the one of its last
+// upgrade, or else the one of its deployment. It returns
+// ledger.ErrResourceNotFound for the chaincodes not deployed through a
+// transaction, such as the system chaincodes.
+func (chaincodeSupport *ChaincodeSupport) getDeployment(chaincode string) (*pb.Transaction, *pb.ChaincodeDeploymentSpec, error) {
+	lgr, err := ledger.GetLedger()
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get handle to ledger (%s)", err)
+	}
+
+	// The ID of a deployment transaction is the name of the chaincode
+	depTx, err := lgr.GetTransactionByID(chaincode)
+	if err == ledger.ErrResourceNotFound {
+		return nil, nil, err
+	} else if err != nil {
+		return nil, nil, fmt.Errorf("Could not get deployment transaction for %s - %s", chaincode, err)
+	}
+	if depTx == nil || depTx.Type != pb.Transaction_CHAINCODE_DEPLOY {
+		return nil, nil, ledger.ErrResourceNotFound
+	}
+	if secHelper := chaincodeSupport.getSecHelper(); nil != secHelper {
+		depTx, err = secHelper.TransactionPreExecution(depTx)
+		// Note that depTx is now decrypted and is a deep clone of the original
+		if nil != err {
+			return nil, nil, fmt.Errorf("failed tx preexecution%s - %s", chaincode, err)
+		}
+	}
+	cds := &pb.ChaincodeDeploymentSpec{}
+	if err = proto.Unmarshal(depTx.Payload, cds); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal deployment transactions for %s - %s", chaincode, err)
+	}
+
+	// The history includes the upgrades of the current batch, whose code is
+	// launched by the upgrade itself
+	history, err := GetVersionHistory(lgr, chaincode, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not get version history of %s - %s", chaincode, err)
+	}
+	if len(history.Versions) == 0 {
+		return depTx, cds, nil
+	}
+	last := history.Versions[len(history.Versions)-1]
+	upgradeTx, err := lgr.GetTransactionByID(last.Txid)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Could not get upgrade transaction %s of %s - %s", last.Txid, chaincode, err)
+	}
+	cus, err := getUpgradeSpec(upgradeTx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal upgrade transaction %s of %s - %s", last.Txid, chaincode, err)
+	}
+	return depTx, cus.DeploymentSpec, nil
+}
+
+// recordVersion adds the version of an upgrade transaction to the version
+// history of a chaincode. The history starts with the version of the
+// deployment of the chaincode, which is added along with the first upgrade.
+func recordVersion(lgr *ledger.Ledger, t *pb.Transaction, cus *pb.ChaincodeUpgradeSpec, depTx *pb.Transaction, depSpec *pb.ChaincodeDeploymentSpec) (*pb.ChaincodeVersion, error) {
+	history, err := GetVersionHistory(lgr, cus.ChaincodeName, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(history.Versions) == 0 {
+		history.Versions = append(history.Versions, &pb.ChaincodeVersion{
+			Version:   1,
+			Txid:      depTx.Txid,
+			CodeID:    depSpec.ChaincodeSpec.ChaincodeID,
+			Timestamp: depTx.Timestamp,
+		})
+	}
+	version := &pb.ChaincodeVersion{
+		Version:   uint64(len(history.Versions) + 1),
+		Txid:      t.Txid,
+		CodeID:    cus.DeploymentSpec.ChaincodeSpec.ChaincodeID,
+		Timestamp: t.Timestamp,
+	}
+	history.Versions = append(history.Versions, version)
+
+	data, err := proto.Marshal(history)
+	if err != nil {
+		return nil, err
+	}
+	if err = lgr.SetState(chaincodeVersionsNamespace, cus.ChaincodeName, data); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// Upgrade prepares the upgrade of a deployed chaincode: it records the new
+// version of the chaincode in its version history, stops the container running
+// the previous code and creates the image of the new code, unless in
+// development mode where the user runs the chaincode. The new code is then
+// launched and initialized with the migration function by Launch. It must be
+// called within the transaction, so that the version history is rolled back
+// if the upgrade fails.
+func (chaincodeSupport *ChaincodeSupport) Upgrade(context context.Context, t *pb.Transaction) (*pb.ChaincodeUpgradeSpec, error) {
+	cus, err := getUpgradeSpec(t)
+	if err != nil {
+		return nil, err
+	}
+	chaincode := cus.ChaincodeName
+	cds := cus.DeploymentSpec
+
+	depTx, current, err := chaincodeSupport.getDeployment(chaincode)
+	if err == ledger.ErrResourceNotFound {
+		return cus, fmt.Errorf("Cannot upgrade %s, it was not deployed through a transaction", chaincode)
+	} else if err != nil {
+		return cus, err
+	}
+	// The state of a confidential chaincode is encrypted with keys derived
+	// from its deployment transaction
+	if depTx.ConfidentialityLevel != pb.ConfidentialityLevel_PUBLIC || t.ConfidentialityLevel != pb.ConfidentialityLevel_PUBLIC {
+		return cus, fmt.Errorf("Cannot upgrade %s, confidential chaincodes cannot be upgraded", chaincode)
+	}
+
+	lgr, err := ledger.GetLedger()
+	if err != nil {
+		return cus, fmt.Errorf("Failed to get handle to ledger (%s)", err)
+	}
+	version, err := recordVersion(lgr, t, cus, depTx, current)
+	if err != nil {
+		return cus, fmt.Errorf("Failed to record the version of %s (%s)", chaincode, err)
+	}
+
+	if chaincodeSupport.userRunsCC {
+		chaincodeLogger.Debug("user runs chaincode, not deploying the code of the upgrade")
+		return cus, nil
+	}
+
+	cID := &pb.ChaincodeID{Name: chaincode}
+	if err = chaincodeSupport.stopChaincode(context, cID, current); err != nil {
+		chaincodeLogger.Debugf("error stopping the previous code of %s: %s", chaincode, err)
+	}
+
+	args, envs, err := chaincodeSupport.getArgsAndEnv(cID, cds)
+	if err != nil {
+		return cus, fmt.Errorf("error getting args for chaincode %s", err)
+	}
+
+	var targz io.Reader = bytes.NewBuffer(cds.CodePackage)
+	cir := &container.CreateImageReq{CCID: chaincodeSupport.getCCID(cID, cds), Args: args, Reader: targz, Env: envs}
+
+	vmtype, _ := chaincodeSupport.getVMType(cds)
+
+	chaincodeLogger.Debugf("upgrading chaincode %s to version %d(networkid:%s,peerid:%s)", chaincode, version.Version, chaincodeSupport.peerNetworkID, chaincodeSupport.peerID)
+
+	//create image and create container
+	_, err = container.VMCProcess(context, vmtype, cir)
+	if err != nil {
+		err = fmt.Errorf("Error starting container: %s", err)
+	}
+
+	return cus, err
+}
@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+func TestGetUpgradeSpec(t *testing.T) {
+	spec := &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeID: &pb.ChaincodeID{Path: "github.com/mycc/v2", Name: "codehash"}}
+	cus := &pb.ChaincodeUpgradeSpec{ChaincodeName: "mycc", DeploymentSpec: &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec}}
+	tx, err := pb.NewChaincodeUpgradeTransaction(cus, "upgrade-txid")
+	if err != nil {
+		t.Fatalf("Failed creating upgrade transaction: %s", err)
+	}
+	got, err := getUpgradeSpec(tx)
+	if err != nil {
+		t.Fatalf("Failed getting upgrade spec: %s", err)
+	}
+	if got.ChaincodeName != "mycc" || got.DeploymentSpec.ChaincodeSpec.ChaincodeID.Path != "github.com/mycc/v2" {
+		t.Fatalf("Expected upgrade spec %v, got %v", cus, got)
+	}
+
+	invalid := []*pb.ChaincodeUpgradeSpec{
+		{DeploymentSpec: &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec}},
+		{ChaincodeName: "mycc"},
+		{ChaincodeName: "mycc", DeploymentSpec: &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{}}},
+	}
+	for _, cus := range invalid {
+		tx, err := pb.NewChaincodeUpgradeTransaction(cus, "upgrade-txid")
+		if err != nil {
+			t.Fatalf("Failed creating upgrade transaction: %s", err)
+		}
+		if _, err = getUpgradeSpec(tx); err == nil {
+			t.Fatalf("Expected upgrade spec %v to be invalid", cus)
+		}
+	}
+}
+
+func TestGetCCID(t *testing.T) {
+	chaincodeSupport := &ChaincodeSupport{peerNetworkID: "net", peerID: "peer"}
+	deployed := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "mycc"}}}
+	upgrade := &pb.ChaincodeDeploymentSpec{ChaincodeSpec: &pb.ChaincodeSpec{ChaincodeID: &pb.ChaincodeID{Name: "codehash"}}}
+
+	ccid := chaincodeSupport.getCCID(&pb.ChaincodeID{Name: "mycc"}, deployed)
+	if ccid.ChaincodeSpec.ChaincodeID.Name != "mycc" {
+		t.Fatalf("Expected the container of the deployed code to be named after the chaincode, got %s", ccid.ChaincodeSpec.ChaincodeID.Name)
+	}
+
+	ccid = chaincodeSupport.getCCID(&pb.ChaincodeID{Name: "mycc"}, upgrade)
+	other := chaincodeSupport.getCCID(&pb.ChaincodeID{Name: "othercc"}, upgrade)
+	name := ccid.ChaincodeSpec.ChaincodeID.Name
+	if name == "mycc" || name == "codehash" || name == other.ChaincodeSpec.ChaincodeID.Name {
+		t.Fatalf("Expected the container of an upgrade to be named after both the chaincode and the code, got %s", name)
+	}
+	if upgrade.ChaincodeSpec.ChaincodeID.Name != "codehash" {
+		t.Fatalf("Expected the deployment spec of the upgrade to be left unchanged")
+	}
+}
@@ -59,6 +59,29 @@ func (client *clientImpl) NewChaincodeDeployTransaction(chaincodeDeploymentSpec
 	return client.newChaincodeDeployUsingTCert(chaincodeDeploymentSpec, uuid, attributes, tCerts[0].tCert, nil)
 }
 
+// NewChaincodeUpgradeTransaction is used to upgrade a deployed chaincode.
+func (client *clientImpl) NewChaincodeUpgradeTransaction(chaincodeUpgradeSpec *obc.ChaincodeUpgradeSpec, uuid string, attributes ...string) (*obc.Transaction, error) {
+	// Verify that the client is initialized
+	if !client.IsInitialized() {
+		return nil, utils.ErrNotInitialized
+	}
+
+	// Get next available (not yet used) transaction certificate
+	tCerts, err := client.tCertPool.GetNextTCerts(1, attributes...)
+	if err != nil {
+		client.Errorf("Failed to obtain a (not yet used) TCert for Chaincode Upgrade[%s].", err.Error())
+		return nil, err
+	}
+
+	if len(tCerts) != 1 {
+		client.Error("Failed to obtain a (not yet used) TCert.")
+		return nil, errors.New("Failed to obtain a TCert for Chaincode Upgrade Transaction using TCert. Expected exactly one returned TCert.")
+	}
+
+	// Create Transaction
+	return client.newChaincodeUpgradeUsingTCert(chaincodeUpgradeSpec, uuid, attributes, tCerts[0].tCert, nil)
+}
+
 // GetNextTCerts Gets next available (not yet used) transaction certificate.
 func (client *clientImpl) GetNextTCerts(nCerts int, attributes ...string) (tCerts []tCert, err error) {
 	if nCerts < 1 {
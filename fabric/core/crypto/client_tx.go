@@ -81,6 +81,47 @@ func (client *clientImpl) createDeployTx(chaincodeDeploymentSpec *obc.ChaincodeD
 	return tx, nil
 }
 
+// createUpgradeTx creates an upgrade transaction. Upgrade transactions are
+// not confidential: the state of a confidential chaincode is encrypted with
+// keys derived from its deployment transaction, so confidential chaincodes
+// cannot be upgraded.
+func (client *clientImpl) createUpgradeTx(chaincodeUpgradeSpec *obc.ChaincodeUpgradeSpec, uuid string, nonce []byte, tCert tCert, attrs ...string) (*obc.Transaction, error) {
+	chaincodeSpec := chaincodeUpgradeSpec.GetDeploymentSpec().GetChaincodeSpec()
+	if chaincodeSpec == nil {
+		return nil, fmt.Errorf("Invalid upgrade spec: no chaincode spec.")
+	}
+	if chaincodeSpec.ConfidentialityLevel == obc.ConfidentialityLevel_CONFIDENTIAL {
+		return nil, fmt.Errorf("Confidential chaincodes cannot be upgraded.")
+	}
+
+	// Create a new transaction
+	tx, err := obc.NewChaincodeUpgradeTransaction(chaincodeUpgradeSpec, uuid)
+	if err != nil {
+		client.Errorf("Failed creating new transaction [%s].", err.Error())
+		return nil, err
+	}
+
+	// Copy metadata from ChaincodeSpec
+	tx.Metadata, err = getMetadata(chaincodeSpec, tCert, attrs...)
+	if err != nil {
+		client.Errorf("Failed creating new transaction [%s].", err.Error())
+		return nil, err
+	}
+
+	if nonce == nil {
+		tx.Nonce, err = primitives.GetRandomNonce()
+		if err != nil {
+			client.Errorf("Failed creating nonce [%s].", err.Error())
+			return nil, err
+		}
+	} else {
+		// TODO: check that it is a well formed nonce
+		tx.Nonce = nonce
+	}
+
+	return tx, nil
+}
+
 func getMetadata(chaincodeSpec *obc.ChaincodeSpec, tCert tCert, attrs ...string) ([]byte, error) {
 	//TODO this code is being commented due temporarily is not enabled attributes encryption.
 	/*
@@ -225,6 +266,43 @@ func (client *clientImpl) newChaincodeDeployUsingTCert(chaincodeDeploymentSpec *
 	return tx, nil
 }
 
+func (client *clientImpl) newChaincodeUpgradeUsingTCert(chaincodeUpgradeSpec *obc.ChaincodeUpgradeSpec, uuid string, attributeNames []string, tCert tCert, nonce []byte) (*obc.Transaction, error) {
+	// Create a new transaction
+	tx, err := client.createUpgradeTx(chaincodeUpgradeSpec, uuid, nonce, tCert, attributeNames...)
+	if err != nil {
+		client.Errorf("Failed creating new upgrade transaction [%s].", err.Error())
+		return nil, err
+	}
+
+	// Sign the transaction
+
+	// Append the certificate to the transaction
+	client.Debugf("Appending certificate [% x].", tCert.GetCertificate().Raw)
+	tx.Cert = tCert.GetCertificate().Raw
+
+	// Sign the transaction and append the signature
+	// 1. Marshall tx to bytes
+	rawTx, err := proto.Marshal(tx)
+	if err != nil {
+		client.Errorf("Failed marshaling tx [%s].", err.Error())
+		return nil, err
+	}
+
+	// 2. Sign rawTx and check signature
+	rawSignature, err := tCert.Sign(rawTx)
+	if err != nil {
+		client.Errorf("Failed creating signature [% x]: [%s].", rawTx, err.Error())
+		return nil, err
+	}
+
+	// 3. Append the signature
+	tx.Signature = rawSignature
+
+	client.Debugf("Appending signature: [% x]", rawSignature)
+
+	return tx, nil
+}
+
 func (client *clientImpl) newChaincodeExecuteUsingTCert(chaincodeInvocation *obc.ChaincodeInvocationSpec, uuid string, attributeKeys []string, tCert tCert, nonce []byte) (*obc.Transaction, error) {
 	/// Create a new transaction
 	tx, err := client.createExecuteTx(chaincodeInvocation, uuid, nonce, tCert, attributeKeys...)
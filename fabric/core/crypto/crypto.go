@@ -51,6 +51,9 @@ type Client interface {
 	// NewChaincodeDeployTransaction is used to deploy chaincode.
 	NewChaincodeDeployTransaction(chaincodeDeploymentSpec *obc.ChaincodeDeploymentSpec, uuid string, attributes ...string) (*obc.Transaction, error)
 
+	// NewChaincodeUpgradeTransaction is used to upgrade a deployed chaincode.
+	NewChaincodeUpgradeTransaction(chaincodeUpgradeSpec *obc.ChaincodeUpgradeSpec, uuid string, attributes ...string) (*obc.Transaction, error)
+
 	// NewChaincodeExecute is used to execute chaincode's functions.
 	NewChaincodeExecute(chaincodeInvocation *obc.ChaincodeInvocationSpec, uuid string, attributes ...string) (*obc.Transaction, error)
 
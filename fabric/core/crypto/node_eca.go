@@ -322,6 +322,22 @@ func (node *nodeImpl) callECAReadCertificateByHash(ctx context.Context, in *memb
 	return &membersrvc.CertPair{Sign: resp.Cert, Enc: nil}, nil
 }
 
+func (node *nodeImpl) callECAReadCRL(ctx context.Context, opts ...grpc.CallOption) (*membersrvc.CRL, error) {
+	// Get an ECA Client
+	sock, ecaP, err := node.getECAClient()
+	defer sock.Close()
+
+	// Issue the request
+	crl, err := ecaP.ReadCRL(ctx, &membersrvc.Empty{}, opts...)
+	if err != nil {
+		node.Errorf("Failed requesting ECA CRL [%s].", err.Error())
+
+		return nil, err
+	}
+
+	return crl, nil
+}
+
 func (node *nodeImpl) getEnrollmentCertificateFromECA(id, pw string) (interface{}, []byte, []byte, error) {
 	// Get a new ECA Client
 	sock, ecaP, err := node.getECAClient()
@@ -46,6 +46,10 @@ var (
 
 	// TCertAttributesHeaders is the ASN1 object identifier of attributes header.
 	TCertAttributesHeaders = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 9}
+
+	// TCertAffiliation is the ASN1 object identifier of the affiliation of the
+	// enrollment.
+	TCertAffiliation = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 6}
 )
 
 // DERToX509Certificate converts der to x509
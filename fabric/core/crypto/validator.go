@@ -129,7 +129,7 @@ func CloseAllValidators() (bool, []error) {
 // Private Methods
 
 func newValidator() *validatorImpl {
-	return &validatorImpl{&peerImpl{&nodeImpl{}, sync.RWMutex{}, nil}, nil}
+	return &validatorImpl{peerImpl: &peerImpl{&nodeImpl{}, sync.RWMutex{}, nil}}
 }
 
 func closeValidatorInternal(peer Peer, force bool) error {
@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"crypto/x509"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	obc "github.com/hyperledger/fabric/protos"
+)
+
+func crlVerificationEnabled() bool {
+	// If the verification of the certificate status is enabled in the configuration file return the configured value
+	if viper.IsSet("peer.validator.crl.verification") {
+		return viper.GetBool("peer.validator.crl.verification")
+	}
+
+	// Certificate status verification is enabled by default if no configuration was specified.
+	return true
+}
+
+func crlRefreshInterval() time.Duration {
+	if viper.IsSet("peer.validator.crl.refresh") {
+		return viper.GetDuration("peer.validator.crl.refresh")
+	}
+	return time.Minute
+}
+
+// crlFailOpen tells whether transactions are accepted without checking the
+// status of their certificate until the CRLs could be read for the first time.
+// They are rejected by default.
+func crlFailOpen() bool {
+	return viper.GetBool("peer.validator.crl.failOpen")
+}
+
+// crlMinRetryInterval is the delay before reading the CRLs again after a
+// first failure. It doubles on every consecutive failure, up to the refresh
+// interval.
+const crlMinRetryInterval = time.Second
+
+// crlRetryInterval returns the delay before reading the CRLs again after the
+// given number of consecutive failures.
+func crlRetryInterval(failures uint, refresh time.Duration) time.Duration {
+	retry := crlMinRetryInterval
+	for i := uint(1); i < failures && retry < refresh; i++ {
+		retry *= 2
+	}
+	if retry > refresh {
+		return refresh
+	}
+	return retry
+}
+
+// crlLogger logs the failures to read the CRLs.
+type crlLogger interface {
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// crlCache holds the serial numbers of the revoked certificates read from the
+// CRLs, and when to read them again.
+type crlCache struct {
+	mutex    sync.Mutex
+	revoked  map[string]bool // nil until the CRLs are read for the first time
+	updated  time.Time       // when the CRLs were last read
+	nextRead time.Time       // when to read the CRLs again
+	failures uint            // consecutive failures reading the CRLs
+	reading  bool            // whether a caller is reading the CRLs
+}
+
+// get returns the serial numbers of the revoked certificates. When they are
+// due, the CRLs are read with read by a single caller without holding the
+// mutex, the others using the last CRLs read meanwhile. After a failure, the
+// last CRLs read are used and reading is retried with an exponential backoff.
+// get fails until the CRLs are read for the first time, unless failOpen is set.
+func (c *crlCache) get(read func() (map[string]bool, error), refresh time.Duration, failOpen bool, logger crlLogger) (map[string]bool, error) {
+	c.mutex.Lock()
+	revoked := c.revoked
+	due := !c.reading && !time.Now().Before(c.nextRead)
+	if due {
+		c.reading = true
+	}
+	c.mutex.Unlock()
+
+	if due {
+		fresh, err := read()
+
+		c.mutex.Lock()
+		c.reading = false
+		if err != nil {
+			c.failures++
+			c.nextRead = time.Now().Add(crlRetryInterval(c.failures, refresh))
+			if c.revoked != nil {
+				logger.Warningf("Failed reading CRLs, using the CRLs read at %s until %s [%s].", c.updated, c.nextRead, err)
+			} else {
+				logger.Errorf("Failed reading CRLs, retrying at %s [%s].", c.nextRead, err)
+			}
+		} else {
+			c.revoked = fresh
+			c.updated = time.Now()
+			c.nextRead = c.updated.Add(refresh)
+			c.failures = 0
+		}
+		revoked = c.revoked
+		c.mutex.Unlock()
+	}
+
+	if revoked == nil {
+		if failOpen {
+			return map[string]bool{}, nil
+		}
+		return nil, errors.New("The CRLs of the CAs could not be read yet.")
+	}
+	return revoked, nil
+}
+
+// verifyCertificateStatus rejects transactions whose certificate was revoked,
+// according to the CRLs of the ECA and the TCA.
+func (validator *validatorImpl) verifyCertificateStatus(tx *obc.Transaction) (*obc.Transaction, error) {
+	if tx.Cert == nil {
+		return tx, nil
+	}
+
+	cert, err := primitives.DERToX509Certificate(tx.Cert)
+	if err != nil {
+		validator.Errorf("verifyCertificateStatus: failed unmarshalling cert %s:", err)
+		return tx, err
+	}
+
+	revoked, err := validator.getRevokedCertificates()
+	if err != nil {
+		validator.Errorf("verifyCertificateStatus: cannot verify the status of certificate [%s]: %s", cert.SerialNumber, err)
+		return tx, err
+	}
+
+	// The serial numbers of the certificates issued by the ECA and the TCA
+	// are random, and so identify a certificate of either CA
+	if revoked[cert.SerialNumber.String()] {
+		validator.Warningf("verifyCertificateStatus: certificate [%s] was revoked", cert.SerialNumber)
+		return tx, errors.New("Transaction certificate was revoked.")
+	}
+
+	return tx, nil
+}
+
+// getRevokedCertificates returns the serial numbers of the revoked
+// certificates, reading the CRLs again when they are older than the refresh
+// interval. The last CRLs read are used while the CAs cannot be reached.
+func (validator *validatorImpl) getRevokedCertificates() (map[string]bool, error) {
+	return validator.crl.get(validator.readRevokedCertificates, crlRefreshInterval(), crlFailOpen(), validator)
+}
+
+func (validator *validatorImpl) readRevokedCertificates() (map[string]bool, error) {
+	revoked := make(map[string]bool)
+
+	ecaCRL, err := validator.callECAReadCRL(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err = validator.addRevokedCertificates(revoked, ecaCRL.Crl, validator.conf.getECACertsChainFilename()); err != nil {
+		return nil, err
+	}
+
+	tcaCRL, err := validator.callTCAReadCRL(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	if err = validator.addRevokedCertificates(revoked, tcaCRL.Crl, validator.conf.getTCACertsChainFilename()); err != nil {
+		return nil, err
+	}
+
+	return revoked, nil
+}
+
+// addRevokedCertificates adds the serial numbers of the certificates of a CRL
+// to revoked, after checking that it was signed by the CA whose certificate is
+// stored under caCertAlias.
+func (validator *validatorImpl) addRevokedCertificates(revoked map[string]bool, raw []byte, caCertAlias string) error {
+	crl, err := x509.ParseCRL(raw)
+	if err != nil {
+		return err
+	}
+
+	pem, err := validator.ks.loadCert(caCertAlias)
+	if err != nil {
+		return err
+	}
+	caCert, err := primitives.PEMtoCertificate(pem)
+	if err != nil {
+		return err
+	}
+	if err = caCert.CheckCRLSignature(crl); err != nil {
+		return err
+	}
+
+	for _, cert := range crl.TBSCertList.RevokedCertificates {
+		revoked[cert.SerialNumber.String()] = true
+	}
+	return nil
+}
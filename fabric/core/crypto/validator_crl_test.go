@@ -0,0 +1,130 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crypto
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type nopCRLLogger struct{}
+
+func (nopCRLLogger) Warningf(format string, args ...interface{}) {}
+func (nopCRLLogger) Errorf(format string, args ...interface{})   {}
+
+func TestCRLRetryInterval(t *testing.T) {
+	for _, c := range []struct {
+		failures uint
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{6, 32 * time.Second},
+		{7, time.Minute},
+		{100, time.Minute},
+	} {
+		if retry := crlRetryInterval(c.failures, time.Minute); retry != c.expected {
+			t.Errorf("Expected a retry interval of %s after %d failures, got %s", c.expected, c.failures, retry)
+		}
+	}
+}
+
+func TestCRLCacheInitialFailure(t *testing.T) {
+	var cache crlCache
+	reads := 0
+	failing := func() (map[string]bool, error) {
+		reads++
+		return nil, errors.New("CA unreachable")
+	}
+
+	if _, err := cache.get(failing, time.Minute, false, nopCRLLogger{}); err == nil {
+		t.Fatal("Expected the certificate status not to be verifiable before the CRLs are read")
+	}
+	if revoked, err := cache.get(failing, time.Minute, true, nopCRLLogger{}); err != nil || len(revoked) != 0 {
+		t.Fatalf("Expected no revoked certificates when failing open, got %v, %v", revoked, err)
+	}
+	if reads != 1 {
+		t.Fatalf("Expected the CRLs not to be read again before the retry interval, read %d times", reads)
+	}
+	if retry := cache.nextRead.Sub(time.Now()); retry <= 0 || retry > crlMinRetryInterval {
+		t.Fatalf("Expected the CRLs to be read again within %s, got %s", crlMinRetryInterval, retry)
+	}
+}
+
+func TestCRLCacheKeepsLastCRLsOnFailure(t *testing.T) {
+	var cache crlCache
+	read := func() (map[string]bool, error) {
+		return map[string]bool{"1": true}, nil
+	}
+	if revoked, err := cache.get(read, time.Minute, false, nopCRLLogger{}); err != nil || !revoked["1"] {
+		t.Fatalf("Expected certificate 1 to be revoked, got %v, %v", revoked, err)
+	}
+
+	// The CRLs are due, but cannot be read
+	cache.nextRead = time.Time{}
+	failing := func() (map[string]bool, error) {
+		return nil, errors.New("CA unreachable")
+	}
+	for i := 0; i < 2; i++ {
+		if revoked, err := cache.get(failing, time.Minute, false, nopCRLLogger{}); err != nil || !revoked["1"] {
+			t.Fatalf("Expected the last CRLs read to be used, got %v, %v", revoked, err)
+		}
+		cache.nextRead = time.Time{}
+	}
+	if cache.failures != 2 {
+		t.Fatalf("Expected 2 consecutive failures, got %d", cache.failures)
+	}
+
+	if _, err := cache.get(read, time.Minute, false, nopCRLLogger{}); err != nil || cache.failures != 0 {
+		t.Fatalf("Expected the failures to be reset once the CRLs are read, got %d, %v", cache.failures, err)
+	}
+}
+
+func TestCRLCacheReadsWithoutLock(t *testing.T) {
+	var cache crlCache
+	cache.revoked = map[string]bool{"1": true}
+
+	reading := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		cache.get(func() (map[string]bool, error) {
+			close(reading)
+			<-release
+			return map[string]bool{"2": true}, nil
+		}, time.Minute, false, nopCRLLogger{})
+		close(done)
+	}()
+	<-reading
+
+	// Other callers neither wait for the CAs nor read the CRLs concurrently
+	revoked, err := cache.get(func() (map[string]bool, error) {
+		t.Fatal("Expected the CRLs to be read by a single caller")
+		return nil, nil
+	}, time.Minute, false, nopCRLLogger{})
+	if err != nil || !revoked["1"] {
+		t.Fatalf("Expected the last CRLs read to be used while reading, got %v, %v", revoked, err)
+	}
+
+	close(release)
+	<-done
+	if revoked, _ := cache.get(nil, time.Minute, false, nopCRLLogger{}); !revoked["2"] {
+		t.Fatalf("Expected the CRLs read to be used, got %v", revoked)
+	}
+}
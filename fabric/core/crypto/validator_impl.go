@@ -33,6 +33,9 @@ type validatorImpl struct {
 
 	// Chain
 	chainPrivateKey primitives.PrivateKey
+
+	// Serial numbers of the revoked certificates, read from the CRLs
+	crl crlCache
 }
 
 // TransactionPreValidation verifies that the transaction is
@@ -43,7 +46,12 @@ func (validator *validatorImpl) TransactionPreValidation(tx *obc.Transaction) (*
 		return nil, utils.ErrNotInitialized
 	}
 
-	return validator.peerImpl.TransactionPreValidation(tx)
+	tx, err := validator.peerImpl.TransactionPreValidation(tx)
+	if err != nil || !crlVerificationEnabled() {
+		return tx, err
+	}
+
+	return validator.verifyCertificateStatus(tx)
 }
 
 // TransactionPreValidation verifies that the transaction is
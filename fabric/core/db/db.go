@@ -41,6 +41,7 @@ const stateCF = "stateCF"
 const stateDeltaCF = "stateDeltaCF"
 const indexesCF = "indexesCF"
 const persistCF = "persistCF"
+const historyCF = "historyCF"
 
 var columnfamilies = []string{
 	blockchainCF, // blocks of the block chain
@@ -48,6 +49,7 @@ var columnfamilies = []string{
 	stateDeltaCF, // open transaction state
 	indexesCF,    // tx uuid -> blockno
 	persistCF,    // persistent per-peer state (consensus)
+	historyCF,    // (chaincode id, key, blockno, tx index) -> value
 }
 
 // OpenchainDB encapsulates rocksdb's structures
@@ -58,6 +60,7 @@ type OpenchainDB struct {
 	StateDeltaCF *gorocksdb.ColumnFamilyHandle
 	IndexesCF    *gorocksdb.ColumnFamilyHandle
 	PersistCF    *gorocksdb.ColumnFamilyHandle
+	HistoryCF    *gorocksdb.ColumnFamilyHandle
 }
 
 var openchainDB = create()
@@ -97,6 +100,11 @@ func (openchainDB *OpenchainDB) GetFromStateCF(key []byte) ([]byte, error) {
 	return openchainDB.Get(openchainDB.StateCF, key)
 }
 
+// GetFromStateCFSnapshot get value for given key from column family in a DB snapshot - stateCF
+func (openchainDB *OpenchainDB) GetFromStateCFSnapshot(snapshot *gorocksdb.Snapshot, key []byte) ([]byte, error) {
+	return openchainDB.getFromSnapshot(snapshot, openchainDB.StateCF, key)
+}
+
 // GetFromStateDeltaCF get value for given key from column family - stateDeltaCF
 func (openchainDB *OpenchainDB) GetFromStateDeltaCF(key []byte) ([]byte, error) {
 	return openchainDB.Get(openchainDB.StateDeltaCF, key)
@@ -107,6 +115,11 @@ func (openchainDB *OpenchainDB) GetFromIndexesCF(key []byte) ([]byte, error) {
 	return openchainDB.Get(openchainDB.IndexesCF, key)
 }
 
+// GetFromHistoryCF get value for given key from column family - historyCF
+func (openchainDB *OpenchainDB) GetFromHistoryCF(key []byte) ([]byte, error) {
+	return openchainDB.Get(openchainDB.HistoryCF, key)
+}
+
 // GetBlockchainCFIterator get iterator for column family - blockchainCF
 func (openchainDB *OpenchainDB) GetBlockchainCFIterator() *gorocksdb.Iterator {
 	return openchainDB.GetIterator(openchainDB.BlockchainCF)
@@ -129,6 +142,16 @@ func (openchainDB *OpenchainDB) GetStateDeltaCFIterator() *gorocksdb.Iterator {
 	return openchainDB.GetIterator(openchainDB.StateDeltaCF)
 }
 
+// GetIndexesCFIterator get iterator for column family - indexCF
+func (openchainDB *OpenchainDB) GetIndexesCFIterator() *gorocksdb.Iterator {
+	return openchainDB.GetIterator(openchainDB.IndexesCF)
+}
+
+// GetHistoryCFIterator get iterator for column family - historyCF
+func (openchainDB *OpenchainDB) GetHistoryCFIterator() *gorocksdb.Iterator {
+	return openchainDB.GetIterator(openchainDB.HistoryCF)
+}
+
 // GetSnapshot returns a point-in-time view of the DB. You MUST call snapshot.Release()
 // when you are done with the snapshot.
 func (openchainDB *OpenchainDB) GetSnapshot() *gorocksdb.Snapshot {
@@ -207,6 +230,7 @@ func (openchainDB *OpenchainDB) open() {
 	openchainDB.StateDeltaCF = cfHandlers[3]
 	openchainDB.IndexesCF = cfHandlers[4]
 	openchainDB.PersistCF = cfHandlers[5]
+	openchainDB.HistoryCF = cfHandlers[6]
 }
 
 // Close releases all column family handles and closes rocksdb
@@ -216,6 +240,7 @@ func (openchainDB *OpenchainDB) close() {
 	openchainDB.StateDeltaCF.Destroy()
 	openchainDB.IndexesCF.Destroy()
 	openchainDB.PersistCF.Destroy()
+	openchainDB.HistoryCF.Destroy()
 	openchainDB.DB.Close()
 }
 
@@ -299,7 +324,10 @@ func (openchainDB *OpenchainDB) getFromSnapshot(snapshot *gorocksdb.Snapshot, cf
 		return nil, err
 	}
 	defer slice.Free()
-	data := append([]byte(nil), slice.Data()...)
+	if slice.Data() == nil {
+		return nil, nil
+	}
+	data := makeCopy(slice.Data())
 	return data, nil
 }
 
@@ -202,6 +202,84 @@ func (d *Devops) Deploy(ctx context.Context, spec *pb.ChaincodeSpec) (*pb.Chainc
 	return chaincodeDeploymentSpec, err
 }
 
+// Upgrade upgrades the deployed chaincode named in the supplied spec to the
+// code at the path of the spec through a transaction. The constructor of the
+// spec is the migration function, run by the new code against the state of
+// the chaincode.
+func (d *Devops) Upgrade(ctx context.Context, spec *pb.ChaincodeSpec) (*pb.ChaincodeUpgradeSpec, error) {
+	if spec.GetChaincodeID() == nil || spec.ChaincodeID.Name == "" {
+		return nil, fmt.Errorf("name not given for upgrade")
+	}
+	// building the code package sets the name of the spec to the name
+	// generated for the new code
+	chaincodeName := spec.ChaincodeID.Name
+
+	// get the deployment spec of the new code
+	chaincodeDeploymentSpec, err := d.getChaincodeBytes(ctx, spec)
+	if err != nil {
+		devopsLogger.Error(fmt.Sprintf("Error upgrading chaincode spec: %v\n\n error: %s", spec, err))
+		return nil, err
+	}
+	chaincodeUpgradeSpec := &pb.ChaincodeUpgradeSpec{ChaincodeName: chaincodeName, DeploymentSpec: chaincodeDeploymentSpec}
+
+	// Now create the Transactions message and send to Peer.
+
+	transID := util.GenerateUUID()
+
+	var tx *pb.Transaction
+	var sec crypto.Client
+
+	if peer.SecurityEnabled() {
+		if devopsLogger.IsEnabledFor(logging.DEBUG) {
+			devopsLogger.Debugf("Initializing secure devops using context %s", spec.SecureContext)
+		}
+		sec, err = crypto.InitClient(spec.SecureContext, nil)
+		defer crypto.CloseClient(sec)
+
+		// remove the security context since we are no longer need it down stream
+		spec.SecureContext = ""
+
+		if nil != err {
+			return nil, err
+		}
+
+		if devopsLogger.IsEnabledFor(logging.DEBUG) {
+			devopsLogger.Debugf("Creating secure upgrade transaction %s", transID)
+		}
+		tx, err = sec.NewChaincodeUpgradeTransaction(chaincodeUpgradeSpec, transID, spec.Attributes...)
+		if nil != err {
+			return nil, err
+		}
+	} else {
+		if devopsLogger.IsEnabledFor(logging.DEBUG) {
+			devopsLogger.Debugf("Creating upgrade transaction (%s)", transID)
+		}
+		tx, err = pb.NewChaincodeUpgradeTransaction(chaincodeUpgradeSpec, transID)
+		if err != nil {
+			return nil, fmt.Errorf("Error upgrading chaincode: %s ", err)
+		}
+	}
+
+	if peer.ValidatorEnabled() {
+		// Reject the upgrades the ACL of the chaincode denies right away
+		if chain := chaincode.GetChain(chaincode.DefaultChain); chain != nil {
+			if err = chain.CheckUpgradeACL(chaincodeName, tx.Cert); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if devopsLogger.IsEnabledFor(logging.DEBUG) {
+		devopsLogger.Debugf("Sending upgrade transaction (%s) to validator", tx.Txid)
+	}
+	resp := d.coord.ExecuteTransaction(tx)
+	if resp.Status == pb.Response_FAILURE {
+		err = errors.New(string(resp.Msg))
+	}
+
+	return chaincodeUpgradeSpec, err
+}
+
 func (d *Devops) invokeOrQuery(ctx context.Context, chaincodeInvocationSpec *pb.ChaincodeInvocationSpec, attributes []string, invoke bool) (*pb.Response, error) {
 
 	if chaincodeInvocationSpec.ChaincodeSpec.ChaincodeID.Name == "" {
@@ -249,12 +327,22 @@ func (d *Devops) invokeOrQuery(ctx context.Context, chaincodeInvocationSpec *pb.
 	if err != nil {
 		return nil, err
 	}
+	if invoke && peer.ValidatorEnabled() {
+		// Reject the invocations the ACL of the chaincode denies right away,
+		// instead of only with a rejection event once they are executed
+		if chain := chaincode.GetChain(chaincode.DefaultChain); chain != nil {
+			spec := chaincodeInvocationSpec.ChaincodeSpec
+			if err = chain.CheckACL(spec.ChaincodeID.Name, chaincode.GetFunction(spec.CtorMsg), transaction.Cert); err != nil {
+				return nil, err
+			}
+		}
+	}
 	if devopsLogger.IsEnabledFor(logging.DEBUG) {
 		devopsLogger.Debugf("Sending invocation transaction (%s) to validator", transaction.Txid)
 	}
 	resp := d.coord.ExecuteTransaction(transaction)
 	if resp.Status == pb.Response_FAILURE {
-		err = fmt.Errorf(string(resp.Msg))
+		err = errors.New(string(resp.Msg))
 	} else {
 		if !invoke && nil != sec && viper.GetBool("security.privacy") {
 			if resp.Msg, err = sec.DecryptQueryResult(transaction, resp.Msg); nil != err {
@@ -21,6 +21,7 @@ import (
 	"encoding/binary"
 	"strconv"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/util"
 	"github.com/hyperledger/fabric/protos"
@@ -157,6 +158,47 @@ func (blockchain *blockchain) getTransactionByBlockHash(blockHash []byte, txInde
 	return block.GetTransactions()[txIndex], nil
 }
 
+func (blockchain *blockchain) getChaincodeDeployments() ([]*ChaincodeDeployment, error) {
+	positions, err := blockchain.indexer.fetchChaincodeDeployments()
+	if err != nil {
+		return nil, err
+	}
+	deployments := make([]*ChaincodeDeployment, 0, len(positions))
+	for _, position := range positions {
+		transaction, err := blockchain.getTransaction(position.blockNumber, position.txIndex)
+		if err != nil {
+			return nil, err
+		}
+		chaincodeID := &protos.ChaincodeID{}
+		err = proto.Unmarshal(transaction.ChaincodeID, chaincodeID)
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, &ChaincodeDeployment{chaincodeID, transaction.Txid, position.blockNumber, position.txIndex})
+	}
+	return deployments, nil
+}
+
+func (blockchain *blockchain) getChaincodeTransactions(chaincodeName string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*ChaincodeTransaction, error) {
+	positions, err := blockchain.indexer.fetchChaincodeTransactions(chaincodeName, startBlockNumber, startTxIndex, limit)
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]*ChaincodeTransaction, 0, len(positions))
+	var block *protos.Block
+	for _, position := range positions {
+		// consecutive transactions of a chaincode are often in the same block
+		if block == nil || transactions[len(transactions)-1].BlockNumber != position.blockNumber {
+			block, err = blockchain.getBlock(position.blockNumber)
+			if err != nil {
+				return nil, err
+			}
+		}
+		transactions = append(transactions, &ChaincodeTransaction{block.GetTransactions()[position.txIndex], position.blockNumber, position.txIndex})
+	}
+	return transactions, nil
+}
+
 func (blockchain *blockchain) getBlockchainInfo() (*protos.BlockchainInfo, error) {
 	if blockchain.getSize() == 0 {
 		return &protos.BlockchainInfo{Height: 0}, nil
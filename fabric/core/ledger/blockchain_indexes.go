@@ -30,6 +30,18 @@ var indexLogger = logging.MustGetLogger("indexes")
 var prefixBlockHashKey = byte(1)
 var prefixTxIDKey = byte(2)
 var prefixAddressBlockNumCompositeKey = byte(3)
+var prefixChaincodeKey = byte(4)
+var prefixChaincodeTxKey = byte(5)
+var chaincodeIndexCreatedKey = []byte{byte(6)}
+var prefixTxResultKey = byte(7)
+
+// chaincodeTxPosition is the position on the blockchain of a transaction that
+// deployed, upgraded or invoked a chaincode
+type chaincodeTxPosition struct {
+	chaincodeName string
+	blockNumber   uint64
+	txIndex       uint64
+}
 
 type blockchainIndexer interface {
 	isSynchronous() bool
@@ -37,6 +49,8 @@ type blockchainIndexer interface {
 	createIndexes(block *protos.Block, blockNumber uint64, blockHash []byte, writeBatch *gorocksdb.WriteBatch) error
 	fetchBlockNumberByBlockHash(blockHash []byte) (uint64, error)
 	fetchTransactionIndexByID(txID string) (uint64, uint64, error)
+	fetchChaincodeDeployments() ([]*chaincodeTxPosition, error)
+	fetchChaincodeTransactions(chaincodeName string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*chaincodeTxPosition, error)
 	stop()
 }
 
@@ -53,7 +67,7 @@ func (indexer *blockchainIndexerSync) isSynchronous() bool {
 }
 
 func (indexer *blockchainIndexerSync) start(blockchain *blockchain) error {
-	return nil
+	return indexChaincodesOfCommittedBlocks(blockchain)
 }
 
 func (indexer *blockchainIndexerSync) createIndexes(
@@ -69,6 +83,14 @@ func (indexer *blockchainIndexerSync) fetchTransactionIndexByID(txID string) (ui
 	return fetchTransactionIndexByIDFromDB(txID)
 }
 
+func (indexer *blockchainIndexerSync) fetchChaincodeDeployments() ([]*chaincodeTxPosition, error) {
+	return fetchChaincodeDeploymentsFromDB()
+}
+
+func (indexer *blockchainIndexerSync) fetchChaincodeTransactions(chaincodeName string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*chaincodeTxPosition, error) {
+	return fetchChaincodeTransactionsFromDB(chaincodeName, startBlockNumber, startTxIndex, limit)
+}
+
 func (indexer *blockchainIndexerSync) stop() {
 	return
 }
@@ -94,7 +116,7 @@ func addIndexDataForPersistence(block *protos.Block, blockNumber uint64, blockHa
 		addressToTxIndexesMap[txExecutingAddress] = append(addressToTxIndexesMap[txExecutingAddress], uint64(txIndex))
 
 		switch tx.Type {
-		case protos.Transaction_CHAINCODE_DEPLOY, protos.Transaction_CHAINCODE_INVOKE:
+		case protos.Transaction_CHAINCODE_DEPLOY, protos.Transaction_CHAINCODE_UPGRADE, protos.Transaction_CHAINCODE_INVOKE:
 			authroizedAddresses, chaincodeID := getAuthorisedAddresses(tx)
 			for _, authroizedAddress := range authroizedAddresses {
 				addressToChaincodeIDsMap[authroizedAddress] = append(addressToChaincodeIDsMap[authroizedAddress], chaincodeID)
@@ -104,9 +126,86 @@ func addIndexDataForPersistence(block *protos.Block, blockNumber uint64, blockHa
 	for address, txsIndexes := range addressToTxIndexesMap {
 		writeBatch.PutCF(cf, encodeAddressBlockNumCompositeKey(address, blockNumber), encodeListTxIndexes(txsIndexes))
 	}
+	return addChaincodeIndexDataForPersistence(block, blockNumber, writeBatch)
+}
+
+// addChaincodeIndexDataForPersistence indexes the transactions of a block by
+// the chaincode they deploy, upgrade or invoke, and the chaincodes by the
+// transaction that first deployed them. Confidential transactions are not
+// indexed as their chaincode ID is encrypted.
+func addChaincodeIndexDataForPersistence(block *protos.Block, blockNumber uint64, writeBatch *gorocksdb.WriteBatch) error {
+	openchainDB := db.GetDBHandle()
+	cf := openchainDB.IndexesCF
+
+	deployed := make(map[string]bool)
+	for txIndex, tx := range block.GetTransactions() {
+		switch tx.Type {
+		case protos.Transaction_CHAINCODE_DEPLOY, protos.Transaction_CHAINCODE_UPGRADE, protos.Transaction_CHAINCODE_INVOKE:
+		default:
+			continue
+		}
+		chaincodeName := getChaincodeName(tx)
+		if chaincodeName == "" {
+			continue
+		}
+		writeBatch.PutCF(cf, encodeChaincodeTxKey(chaincodeName, blockNumber, uint64(txIndex)), []byte{})
+
+		if tx.Type != protos.Transaction_CHAINCODE_DEPLOY || deployed[chaincodeName] {
+			continue
+		}
+		deployed[chaincodeName] = true
+		chaincodeKey := encodeChaincodeKey(chaincodeName)
+		deployment, err := openchainDB.GetFromIndexesCF(chaincodeKey)
+		if err != nil {
+			return err
+		}
+		if deployment == nil {
+			indexLogger.Debugf("Indexing chaincode [%s] deployed at block number [%d]", chaincodeName, blockNumber)
+			writeBatch.PutCF(cf, chaincodeKey, encodeBlockNumTxIndex(blockNumber, uint64(txIndex)))
+		}
+	}
 	return nil
 }
 
+// indexChaincodesOfCommittedBlocks adds the chaincode index data for the blocks
+// committed before the chaincode index was introduced. It has no effect once
+// the index has been created.
+func indexChaincodesOfCommittedBlocks(blockchain *blockchain) error {
+	openchainDB := db.GetDBHandle()
+	created, err := openchainDB.GetFromIndexesCF(chaincodeIndexCreatedKey)
+	if err != nil {
+		return err
+	}
+	if created != nil {
+		return nil
+	}
+
+	size := blockchain.getSize()
+	if size > 0 {
+		indexLogger.Infof("Indexing the chaincode transactions of [%d] committed blocks", size)
+	}
+	opt := gorocksdb.NewDefaultWriteOptions()
+	defer opt.Destroy()
+	for blockNumber := uint64(0); blockNumber < size; blockNumber++ {
+		block, err := blockchain.getBlock(blockNumber)
+		if err != nil {
+			return err
+		}
+		// every block is written on its own as the deployment of a chaincode
+		// is only indexed if no earlier one was
+		writeBatch := gorocksdb.NewWriteBatch()
+		err = addChaincodeIndexDataForPersistence(block, blockNumber, writeBatch)
+		if err == nil {
+			err = openchainDB.DB.Write(opt, writeBatch)
+		}
+		writeBatch.Destroy()
+		if err != nil {
+			return err
+		}
+	}
+	return openchainDB.Put(openchainDB.IndexesCF, chaincodeIndexCreatedKey, encodeBlockNumber(size))
+}
+
 func fetchBlockNumberByBlockHashFromDB(blockHash []byte) (uint64, error) {
 	indexLogger.Debugf("fetchBlockNumberByBlockHashFromDB() for blockhash [%x]", blockHash)
 	blockNumberBytes, err := db.GetDBHandle().GetFromIndexesCF(encodeBlockHashKey(blockHash))
@@ -132,6 +231,43 @@ func fetchTransactionIndexByIDFromDB(txID string) (uint64, uint64, error) {
 	return decodeBlockNumTxIndex(blockNumTxIndexBytes)
 }
 
+func fetchChaincodeDeploymentsFromDB() ([]*chaincodeTxPosition, error) {
+	itr := db.GetDBHandle().GetIndexesCFIterator()
+	defer itr.Close()
+
+	deployments := []*chaincodeTxPosition{}
+	prefix := []byte{prefixChaincodeKey}
+	for itr.Seek(prefix); itr.ValidForPrefix(prefix); itr.Next() {
+		key := itr.Key()
+		value := itr.Value()
+		chaincodeName := string(key.Data()[1:])
+		blockNumber, txIndex, err := decodeBlockNumTxIndex(value.Data())
+		key.Free()
+		value.Free()
+		if err != nil {
+			return nil, err
+		}
+		deployments = append(deployments, &chaincodeTxPosition{chaincodeName, blockNumber, txIndex})
+	}
+	return deployments, nil
+}
+
+func fetchChaincodeTransactionsFromDB(chaincodeName string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*chaincodeTxPosition, error) {
+	itr := db.GetDBHandle().GetIndexesCFIterator()
+	defer itr.Close()
+
+	transactions := []*chaincodeTxPosition{}
+	prefix := encodeChaincodeTxKeyPrefix(chaincodeName)
+	itr.Seek(encodeChaincodeTxKey(chaincodeName, startBlockNumber, startTxIndex))
+	for ; itr.ValidForPrefix(prefix) && len(transactions) < limit; itr.Next() {
+		key := itr.Key()
+		blockNumber, txIndex := decodeChaincodeTxKey(key.Data())
+		key.Free()
+		transactions = append(transactions, &chaincodeTxPosition{chaincodeName, blockNumber, txIndex})
+	}
+	return transactions, nil
+}
+
 func getTxExecutingAddress(tx *protos.Transaction) string {
 	// TODO Fetch address form tx
 	return "address1"
@@ -149,6 +285,20 @@ func getAuthorisedAddresses(tx *protos.Transaction) ([]string, *protos.Chaincode
 	return []string{"address1", "address2"}, cID
 }
 
+// getChaincodeName returns the name of the chaincode a transaction deploys,
+// upgrades or invokes, or an empty string if the chaincode ID cannot be read
+func getChaincodeName(tx *protos.Transaction) string {
+	if tx.ConfidentialityLevel == protos.ConfidentialityLevel_CONFIDENTIAL {
+		return ""
+	}
+	chaincodeID := &protos.ChaincodeID{}
+	err := proto.Unmarshal(tx.ChaincodeID, chaincodeID)
+	if err != nil {
+		return ""
+	}
+	return chaincodeID.Name
+}
+
 // functions for encoding/decoding db keys/values for index data
 // encode / decode BlockNumber
 func encodeBlockNumber(blockNumber uint64) []byte {
@@ -198,6 +348,32 @@ func encodeAddressBlockNumCompositeKey(address string, blockNumber uint64) []byt
 	return b.Bytes()
 }
 
+// encode ChaincodeKey
+func encodeChaincodeKey(chaincodeName string) []byte {
+	return prependKeyPrefix(prefixChaincodeKey, []byte(chaincodeName))
+}
+
+// encode / decode ChaincodeTxKey. The block number and the tx index are
+// encoded with a fixed length so that the transactions of a chaincode are
+// iterated in the order they were committed.
+func encodeChaincodeTxKeyPrefix(chaincodeName string) []byte {
+	b := proto.NewBuffer([]byte{prefixChaincodeTxKey})
+	b.EncodeRawBytes([]byte(chaincodeName))
+	return b.Bytes()
+}
+
+func encodeChaincodeTxKey(chaincodeName string, blockNumber uint64, txIndex uint64) []byte {
+	key := encodeChaincodeTxKeyPrefix(chaincodeName)
+	key = append(key, encodeUint64(blockNumber)...)
+	return append(key, encodeUint64(txIndex)...)
+}
+
+func decodeChaincodeTxKey(key []byte) (blockNumber uint64, txIndex uint64) {
+	blockNumber = decodeToUint64(key[len(key)-16 : len(key)-8])
+	txIndex = decodeToUint64(key[len(key)-8:])
+	return
+}
+
 func encodeListTxIndexes(listTx []uint64) []byte {
 	b := proto.NewBuffer([]byte{})
 	for i := range listTx {
@@ -56,6 +56,10 @@ func (indexer *blockchainIndexerAsync) start(blockchain *blockchain) error {
 		return err
 	}
 	indexer.indexerState = indexerState
+	err = indexChaincodesOfCommittedBlocks(blockchain)
+	if err != nil {
+		return err
+	}
 	indexLogger.Debugf("staring indexer, lastIndexedBlockNum = [%d]",
 		indexer.indexerState.getLastIndexedBlockNumber())
 
@@ -140,6 +144,24 @@ func (indexer *blockchainIndexerAsync) fetchTransactionIndexByID(txID string) (u
 	return fetchTransactionIndexByIDFromDB(txID)
 }
 
+func (indexer *blockchainIndexerAsync) fetchChaincodeDeployments() ([]*chaincodeTxPosition, error) {
+	err := indexer.indexerState.checkError()
+	if err != nil {
+		return nil, err
+	}
+	indexer.indexerState.waitForLastCommittedBlock()
+	return fetchChaincodeDeploymentsFromDB()
+}
+
+func (indexer *blockchainIndexerAsync) fetchChaincodeTransactions(chaincodeName string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*chaincodeTxPosition, error) {
+	err := indexer.indexerState.checkError()
+	if err != nil {
+		return nil, err
+	}
+	indexer.indexerState.waitForLastCommittedBlock()
+	return fetchChaincodeTransactionsFromDB(chaincodeName, startBlockNumber, startTxIndex, limit)
+}
+
 func (indexer *blockchainIndexerAsync) indexPendingBlocks() error {
 	blockchain := indexer.blockchain
 	if blockchain.getSize() == 0 {
@@ -69,6 +69,13 @@ func TestIndexesAsync_GetTransactionByID(t *testing.T) {
 	testIndexesGetTransactionByID(t)
 }
 
+func TestIndexesAsync_GetChaincodeTransactions(t *testing.T) {
+	defaultSetting := indexBlockDataSynchronously
+	indexBlockDataSynchronously = false
+	defer func() { indexBlockDataSynchronously = defaultSetting }()
+	testIndexesGetChaincodeTransactions(t)
+}
+
 func TestIndexesAsync_IndexingErrorScenario(t *testing.T) {
 	defaultSetting := indexBlockDataSynchronously
 	indexBlockDataSynchronously = false
@@ -164,6 +171,12 @@ func (noop *NoopIndexer) fetchBlockNumberByBlockHash(blockHash []byte) (uint64,
 func (noop *NoopIndexer) fetchTransactionIndexByID(txID string) (uint64, uint64, error) {
 	return 0, 0, nil
 }
+func (noop *NoopIndexer) fetchChaincodeDeployments() ([]*chaincodeTxPosition, error) {
+	return nil, nil
+}
+func (noop *NoopIndexer) fetchChaincodeTransactions(chaincodeName string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*chaincodeTxPosition, error) {
+	return nil, nil
+}
 func (noop *NoopIndexer) stop() {
 }
 
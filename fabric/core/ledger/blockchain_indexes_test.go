@@ -19,6 +19,7 @@ package ledger
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/protos"
 )
@@ -65,6 +66,13 @@ func TestIndexes_GetTransactionByID(t *testing.T) {
 	testIndexesGetTransactionByID(t)
 }
 
+func TestIndexes_GetChaincodeTransactions(t *testing.T) {
+	defaultSetting := indexBlockDataSynchronously
+	indexBlockDataSynchronously = true
+	defer func() { indexBlockDataSynchronously = defaultSetting }()
+	testIndexesGetChaincodeTransactions(t)
+}
+
 func testIndexesGetBlockByBlockNumber(t *testing.T) {
 	testDBWrapper.CleanDB(t)
 	testBlockchainWrapper := newTestBlockchainWrapper(t)
@@ -159,3 +167,95 @@ func testIndexesGetTransactionByID(t *testing.T) {
 	testutil.AssertEquals(t, testBlockchainWrapper.getTransactionByID(uuid3), tx3)
 	testutil.AssertEquals(t, testBlockchainWrapper.getTransactionByID(uuid4), tx4)
 }
+
+func buildTestChaincodeTx(t *testing.T, txType protos.Transaction_Type, chaincodeName string) *protos.Transaction {
+	tx, err := protos.NewTransaction(protos.ChaincodeID{Path: "github.com/" + chaincodeName, Name: chaincodeName}, testutil.GenerateID(t), "anyfunction", nil)
+	testutil.AssertNoError(t, err, "Error building transaction")
+	tx.Type = txType
+	return tx
+}
+
+func testIndexesGetChaincodeTransactions(t *testing.T) {
+	testDBWrapper.CleanDB(t)
+	testBlockchainWrapper := newTestBlockchainWrapper(t)
+	defer func() { testBlockchainWrapper.blockchain.indexer.stop() }()
+	chain := testBlockchainWrapper.blockchain
+
+	deploy1 := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_DEPLOY, "chaincode1")
+	invoke1a := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_INVOKE, "chaincode1")
+	testBlockchainWrapper.addNewBlock(protos.NewBlock([]*protos.Transaction{deploy1, invoke1a}, nil), []byte("stateHash1"))
+
+	deploy2 := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_DEPLOY, "chaincode0")
+	invoke1b := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_INVOKE, "chaincode1")
+	// a second deployment of a chaincode does not replace the first one
+	redeploy1 := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_DEPLOY, "chaincode1")
+	confidential := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_INVOKE, "chaincode1")
+	confidential.ConfidentialityLevel = protos.ConfidentialityLevel_CONFIDENTIAL
+	testBlockchainWrapper.addNewBlock(protos.NewBlock([]*protos.Transaction{deploy2, invoke1b, redeploy1, confidential}, nil), []byte("stateHash2"))
+
+	invoke1c := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_INVOKE, "chaincode1")
+	testBlockchainWrapper.addNewBlock(protos.NewBlock([]*protos.Transaction{invoke1c}, nil), []byte("stateHash3"))
+
+	deployments, err := chain.getChaincodeDeployments()
+	testutil.AssertNoError(t, err, "Error fetching chaincode deployments")
+	testutil.AssertEquals(t, len(deployments), 2)
+	testutil.AssertEquals(t, deployments[0].ChaincodeID.Name, "chaincode0")
+	testutil.AssertEquals(t, deployments[0].TxID, deploy2.Txid)
+	testutil.AssertEquals(t, deployments[0].BlockNumber, uint64(1))
+	testutil.AssertEquals(t, deployments[0].TxIndex, uint64(0))
+	testutil.AssertEquals(t, deployments[1].ChaincodeID.Path, "github.com/chaincode1")
+	testutil.AssertEquals(t, deployments[1].TxID, deploy1.Txid)
+	testutil.AssertEquals(t, deployments[1].BlockNumber, uint64(0))
+
+	transactions, err := chain.getChaincodeTransactions("chaincode1", 0, 0, 10)
+	testutil.AssertNoError(t, err, "Error fetching chaincode transactions")
+	expected := []*protos.Transaction{deploy1, invoke1a, invoke1b, redeploy1, invoke1c}
+	testutil.AssertEquals(t, len(transactions), len(expected))
+	for i, tx := range expected {
+		testutil.AssertEquals(t, transactions[i].Transaction, tx)
+	}
+
+	// page through the transactions
+	transactions, err = chain.getChaincodeTransactions("chaincode1", 0, 1, 2)
+	testutil.AssertNoError(t, err, "Error fetching chaincode transactions")
+	testutil.AssertEquals(t, len(transactions), 2)
+	testutil.AssertEquals(t, transactions[0].Transaction, invoke1a)
+	testutil.AssertEquals(t, transactions[1].BlockNumber, uint64(1))
+	testutil.AssertEquals(t, transactions[1].TxIndex, uint64(1))
+	transactions, err = chain.getChaincodeTransactions("chaincode1", 1, 2, 2)
+	testutil.AssertNoError(t, err, "Error fetching chaincode transactions")
+	testutil.AssertEquals(t, len(transactions), 2)
+	testutil.AssertEquals(t, transactions[0].Transaction, redeploy1)
+	testutil.AssertEquals(t, transactions[1].Transaction, invoke1c)
+
+	transactions, err = chain.getChaincodeTransactions("chaincode", 0, 0, 10)
+	testutil.AssertNoError(t, err, "Error fetching chaincode transactions")
+	testutil.AssertEquals(t, len(transactions), 0)
+}
+
+func TestIndexes_IndexChaincodesOfCommittedBlocks(t *testing.T) {
+	defaultSetting := indexBlockDataSynchronously
+	indexBlockDataSynchronously = true
+	defer func() { indexBlockDataSynchronously = defaultSetting }()
+
+	testDBWrapper.CleanDB(t)
+	testBlockchainWrapper := newTestBlockchainWrapper(t)
+
+	// commit a block without indexing it, as a peer that predates the
+	// chaincode index would have
+	chain := testBlockchainWrapper.blockchain
+	chain.indexer.stop()
+	chain.indexer = &NoopIndexer{}
+	deploy := buildTestChaincodeTx(t, protos.Transaction_CHAINCODE_DEPLOY, "chaincode1")
+	testBlockchainWrapper.addNewBlock(protos.NewBlock([]*protos.Transaction{deploy}, nil), []byte("stateHash1"))
+	openchainDB := db.GetDBHandle()
+	err := openchainDB.Delete(openchainDB.IndexesCF, chaincodeIndexCreatedKey)
+	testutil.AssertNoError(t, err, "Error deleting the chaincode index marker")
+
+	testBlockchainWrapper = newTestBlockchainWrapper(t)
+	defer func() { testBlockchainWrapper.blockchain.indexer.stop() }()
+	transactions, err := testBlockchainWrapper.blockchain.getChaincodeTransactions("chaincode1", 0, 0, 10)
+	testutil.AssertNoError(t, err, "Error fetching chaincode transactions")
+	testutil.AssertEquals(t, len(transactions), 1)
+	testutil.AssertEquals(t, transactions[0].Transaction, deploy)
+}
@@ -21,8 +21,8 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
-	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/ledger/statemgmt"
 	"github.com/hyperledger/fabric/core/ledger/statemgmt/state"
@@ -77,11 +77,53 @@ var (
 	ErrResourceNotFound = newLedgerError(ErrorTypeResourceNotFound, "ledger: resource not found")
 )
 
+// StateListener is notified after changes to the state were committed. It is
+// called synchronously by the committing goroutine and must not call back into
+// the ledger.
+type StateListener interface {
+	// StateChanged is called with the keys changed in the state of each
+	// chaincode, or with nil if the whole state was deleted
+	StateChanged(updates map[string][]string)
+}
+
+// BatchListener is notified when the transaction batch or state delta in
+// progress ends, whether it was committed or rolled back. It is called
+// synchronously by the ending goroutine and must not call back into the
+// ledger.
+type BatchListener interface {
+	BatchEnded(committed bool)
+}
+
+// ChaincodeDeployment describes the transaction that deployed a chaincode
+type ChaincodeDeployment struct {
+	ChaincodeID *protos.ChaincodeID
+	TxID        string
+	BlockNumber uint64
+	TxIndex     uint64
+}
+
+// ChaincodeTransaction is a transaction that deployed, upgraded or invoked a
+// chaincode, with its position on the blockchain
+type ChaincodeTransaction struct {
+	Transaction *protos.Transaction
+	BlockNumber uint64
+	TxIndex     uint64
+}
+
 // Ledger - the struct for openchain ledger
 type Ledger struct {
 	blockchain *blockchain
 	state      *state.State
 	currentID  interface{}
+
+	listenersLock  sync.RWMutex
+	stateListeners []StateListener
+	batchListeners []BatchListener
+
+	// committedLock guards committed, which is closed and replaced each time
+	// a block is committed
+	committedLock sync.Mutex
+	committed     chan struct{}
 }
 
 var ledger *Ledger
@@ -104,7 +146,7 @@ func GetNewLedger() (*Ledger, error) {
 	}
 
 	state := state.NewState()
-	return &Ledger{blockchain, state, nil}, nil
+	return &Ledger{blockchain: blockchain, state: state}, nil
 }
 
 /////////////////// Transaction-batch related methods ///////////////////////////////
@@ -191,6 +233,18 @@ func (ledger *Ledger) CommitTxBatch(id interface{}, transactions []*protos.Trans
 		return err
 	}
 	ledger.state.AddChangesForPersistence(newBlockNumber, writeBatch)
+	err = ledger.state.AddHistoryForPersistence(newBlockNumber, getTxIndexes(transactions), writeBatch)
+	if err != nil {
+		ledger.resetForNextTxGroup(false)
+		ledger.blockchain.blockPersistenceStatus(false)
+		return err
+	}
+	err = addTxResultsForPersistence(newBlockNumber, transactionResults, writeBatch)
+	if err != nil {
+		ledger.resetForNextTxGroup(false)
+		ledger.blockchain.blockPersistenceStatus(false)
+		return err
+	}
 	opt := gorocksdb.NewDefaultWriteOptions()
 	defer opt.Destroy()
 	dbErr := db.GetDBHandle().DB.Write(opt, writeBatch)
@@ -200,13 +254,16 @@ func (ledger *Ledger) CommitTxBatch(id interface{}, transactions []*protos.Trans
 		return dbErr
 	}
 
+	updates := ledger.state.GetUpdatedKeys()
 	ledger.resetForNextTxGroup(true)
 	ledger.blockchain.blockPersistenceStatus(true)
+	ledger.notifyStateChanged(updates)
+	ledger.notifyBlockCommitted()
 
-	sendProducerBlockEvent(block)
+	sendProducerBlockEvent(block, newBlockNumber)
 
 	//send chaincode events from transaction results
-	sendChaincodeEvents(transactionResults)
+	sendChaincodeEvents(newBlockNumber, transactionResults)
 
 	if len(transactionResults) != 0 {
 		ledgerLogger.Debug("There were some erroneous transactions. We need to send a 'TX rejected' message here.")
@@ -300,6 +357,22 @@ func (ledger *Ledger) SetStateMultipleKeys(chaincodeID string, kvs map[string][]
 	return ledger.state.SetMultipleKeys(chaincodeID, kvs)
 }
 
+// GetStateAtBlock returns the committed value of key for chaincodeID as it was
+// after block blockNumber. It requires the key history index
+// (ledger.state.keyHistory.enabled) to have been enabled at blockNumber.
+func (ledger *Ledger) GetStateAtBlock(chaincodeID string, key string, blockNumber uint64) ([]byte, error) {
+	if blockNumber >= ledger.GetBlockchainSize() {
+		return nil, ErrOutOfBounds
+	}
+	return ledger.state.GetStateAtBlock(chaincodeID, key, blockNumber)
+}
+
+// GetKeyHistory returns every committed change made to key for chaincodeID
+// since the key history index was enabled, oldest first.
+func (ledger *Ledger) GetKeyHistory(chaincodeID string, key string) ([]*state.KeyModification, error) {
+	return ledger.state.GetKeyHistory(chaincodeID, key)
+}
+
 // GetStateSnapshot returns a point-in-time view of the global state for the current block. This
 // should be used when transferring the state from one peer to another peer. You must call
 // stateSnapshot.Release() once you are done with the snapshot to free up resources.
@@ -362,7 +435,12 @@ func (ledger *Ledger) CommitStateDelta(id interface{}) error {
 		return err
 	}
 	defer ledger.resetForNextTxGroup(true)
-	return ledger.state.CommitStateDelta()
+	err = ledger.state.CommitStateDelta()
+	if err != nil {
+		return err
+	}
+	ledger.notifyStateChanged(ledger.state.GetUpdatedKeys())
+	return nil
 }
 
 // RollbackStateDelta will discard the state delta passed
@@ -380,12 +458,111 @@ func (ledger *Ledger) RollbackStateDelta(id interface{}) error {
 // This is generally only used during state synchronization when creating a
 // new state from a snapshot.
 func (ledger *Ledger) DeleteALLStateKeysAndValues() error {
-	return ledger.state.DeleteState()
+	err := ledger.state.DeleteState()
+	if err != nil {
+		return err
+	}
+	ledger.notifyStateChanged(nil)
+	return nil
+}
+
+// AddStateListener registers a listener that is notified after changes to
+// the state were committed
+func (ledger *Ledger) AddStateListener(listener StateListener) {
+	ledger.listenersLock.Lock()
+	defer ledger.listenersLock.Unlock()
+	ledger.stateListeners = append(ledger.stateListeners, listener)
+}
+
+// AddBatchListener registers a listener that is notified when the
+// transaction batch in progress ends
+func (ledger *Ledger) AddBatchListener(listener BatchListener) {
+	ledger.listenersLock.Lock()
+	defer ledger.listenersLock.Unlock()
+	ledger.batchListeners = append(ledger.batchListeners, listener)
+}
+
+// notifyStateChanged notifies the state listeners. A nil updates means that
+// the whole state changed, an empty one that nothing changed.
+func (ledger *Ledger) notifyStateChanged(updates map[string][]string) {
+	if updates != nil && len(updates) == 0 {
+		return
+	}
+	ledger.listenersLock.RLock()
+	defer ledger.listenersLock.RUnlock()
+	for _, listener := range ledger.stateListeners {
+		listener.StateChanged(updates)
+	}
 }
 
 /////////////////// blockchain related methods /////////////////////////////////////
 /////////////////////////////////////////////////////////////////////////////////////
 
+// GetTransactionStatus returns whether a transaction was committed or
+// rejected, or is still pending
+func (ledger *Ledger) GetTransactionStatus(txID string) (*TransactionStatus, error) {
+	blockNumber, txResult, err := fetchTxResultFromDB(txID)
+	if err != nil {
+		return nil, err
+	}
+	if txResult != nil {
+		if txResult.ErrorCode != 0 {
+			return &TransactionStatus{TxStatusRejected, blockNumber, txResult}, nil
+		}
+		return &TransactionStatus{TxStatusCommitted, blockNumber, txResult}, nil
+	}
+
+	// the block was received without the results of its transactions
+	blockNumber, _, err = ledger.blockchain.indexer.fetchTransactionIndexByID(txID)
+	switch err {
+	case nil:
+		return &TransactionStatus{Status: TxStatusCommitted, BlockNumber: blockNumber}, nil
+	case ErrResourceNotFound:
+		return &TransactionStatus{Status: TxStatusPending}, nil
+	default:
+		return nil, err
+	}
+}
+
+// WaitForTransaction waits until a transaction is committed or rejected, or
+// until timeout elapses, and returns its status
+func (ledger *Ledger) WaitForTransaction(txID string, timeout time.Duration) (*TransactionStatus, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		committed := ledger.getCommittedChannel()
+		status, err := ledger.GetTransactionStatus(txID)
+		if err != nil || status.Status != TxStatusPending {
+			return status, err
+		}
+		select {
+		case <-committed:
+		case <-timer.C:
+			return status, nil
+		}
+	}
+}
+
+// getCommittedChannel returns a channel that is closed when the next block is
+// committed
+func (ledger *Ledger) getCommittedChannel() <-chan struct{} {
+	ledger.committedLock.Lock()
+	defer ledger.committedLock.Unlock()
+	if ledger.committed == nil {
+		ledger.committed = make(chan struct{})
+	}
+	return ledger.committed
+}
+
+func (ledger *Ledger) notifyBlockCommitted() {
+	ledger.committedLock.Lock()
+	defer ledger.committedLock.Unlock()
+	if ledger.committed != nil {
+		close(ledger.committed)
+		ledger.committed = nil
+	}
+}
+
 // GetBlockchainInfo returns information about the blockchain ledger such as
 // height, current block hash, and previous block hash.
 func (ledger *Ledger) GetBlockchainInfo() (*protos.BlockchainInfo, error) {
@@ -401,6 +578,11 @@ func (ledger *Ledger) GetBlockByNumber(blockNumber uint64) (*protos.Block, error
 	return ledger.blockchain.getBlock(blockNumber)
 }
 
+// GetBlockNumberByHash returns the number of the block with the given hash
+func (ledger *Ledger) GetBlockNumberByHash(blockHash []byte) (uint64, error) {
+	return ledger.blockchain.indexer.fetchBlockNumberByBlockHash(blockHash)
+}
+
 // GetBlockchainSize returns number of blocks in blockchain
 func (ledger *Ledger) GetBlockchainSize() uint64 {
 	return ledger.blockchain.getSize()
@@ -411,6 +593,19 @@ func (ledger *Ledger) GetTransactionByID(txID string) (*protos.Transaction, erro
 	return ledger.blockchain.getTransactionByID(txID)
 }
 
+// GetChaincodeDeployments returns the chaincodes deployed on the blockchain,
+// ordered by chaincode name. Confidential chaincodes are not included.
+func (ledger *Ledger) GetChaincodeDeployments() ([]*ChaincodeDeployment, error) {
+	return ledger.blockchain.getChaincodeDeployments()
+}
+
+// GetChaincodeTransactions returns at most limit transactions that deployed,
+// upgraded or invoked a chaincode, in the order they were committed, starting with the
+// transaction at txIndex in block startBlockNumber
+func (ledger *Ledger) GetChaincodeTransactions(chaincodeID string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*ChaincodeTransaction, error) {
+	return ledger.blockchain.getChaincodeTransactions(chaincodeID, startBlockNumber, startTxIndex, limit)
+}
+
 // PutRawBlock puts a raw block on the chain. This function should only be
 // used for synchronization between peers.
 func (ledger *Ledger) PutRawBlock(block *protos.Block, blockNumber uint64) error {
@@ -418,7 +613,8 @@ func (ledger *Ledger) PutRawBlock(block *protos.Block, blockNumber uint64) error
 	if err != nil {
 		return err
 	}
-	sendProducerBlockEvent(block)
+	ledger.notifyBlockCommitted()
+	sendProducerBlockEvent(block, blockNumber)
 	return nil
 }
 
@@ -491,43 +687,40 @@ func (ledger *Ledger) resetForNextTxGroup(txCommited bool) {
 	ledgerLogger.Debug("resetting ledger state for next transaction batch")
 	ledger.currentID = nil
 	ledger.state.ClearInMemoryChanges(txCommited)
-}
 
-func sendProducerBlockEvent(block *protos.Block) {
+	ledger.listenersLock.RLock()
+	defer ledger.listenersLock.RUnlock()
+	for _, listener := range ledger.batchListeners {
+		listener.BatchEnded(txCommited)
+	}
+}
 
-	// Remove payload from deploy transactions. This is done to make block
-	// events more lightweight as the payload for these types of transactions
-	// can be very large.
-	blockTransactions := block.GetTransactions()
-	for _, transaction := range blockTransactions {
-		if transaction.Type == protos.Transaction_CHAINCODE_DEPLOY {
-			deploymentSpec := &protos.ChaincodeDeploymentSpec{}
-			err := proto.Unmarshal(transaction.Payload, deploymentSpec)
-			if err != nil {
-				ledgerLogger.Errorf("Error unmarshalling deployment transaction for block event: %s", err)
-				continue
-			}
-			deploymentSpec.CodePackage = nil
-			deploymentSpecBytes, err := proto.Marshal(deploymentSpec)
-			if err != nil {
-				ledgerLogger.Errorf("Error marshalling deployment transaction for block event: %s", err)
-				continue
-			}
-			transaction.Payload = deploymentSpecBytes
-		}
+// getTxIndexes maps the ID of every transaction to its index in the block
+func getTxIndexes(transactions []*protos.Transaction) map[string]uint64 {
+	txIndexes := make(map[string]uint64, len(transactions))
+	for i, tx := range transactions {
+		txIndexes[tx.Txid] = uint64(i)
 	}
+	return txIndexes
+}
 
-	producer.Send(producer.CreateBlockEvent(block))
+func sendProducerBlockEvent(block *protos.Block, blockNumber uint64) {
+	event := producer.CreateBlockEvent(block)
+	event.BlockNumber = blockNumber
+	producer.Send(event)
 }
 
 //send chaincode events created by transactions
-func sendChaincodeEvents(trs []*protos.TransactionResult) {
+func sendChaincodeEvents(blockNumber uint64, trs []*protos.TransactionResult) {
 	if trs != nil {
-		for _, tr := range trs {
+		for i, tr := range trs {
 			//we store empty chaincode events in the protobuf repeated array to make protobuf happy.
 			//when we replay off a block ignore empty events
 			if tr.ChaincodeEvent != nil && tr.ChaincodeEvent.ChaincodeID != "" {
-				producer.Send(producer.CreateChaincodeEvent(tr.ChaincodeEvent))
+				event := producer.CreateChaincodeEvent(tr.ChaincodeEvent)
+				event.BlockNumber = blockNumber
+				event.EventIndex = uint64(i + 1)
+				producer.Send(event)
 			}
 		}
 	}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/core/ledger/statemgmt"
 	"github.com/hyperledger/fabric/core/ledger/testutil"
@@ -706,6 +707,210 @@ func TestGetTransactionByID(t *testing.T) {
 	testutil.AssertNil(t, ledgerTransaction)
 }
 
+func TestGetBlockNumberByHash(t *testing.T) {
+	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
+	ledger := ledgerTestWrapper.ledger
+
+	for i := 0; i < 3; i++ {
+		ledger.BeginTxBatch(i)
+		ledger.TxBegin("txUuid" + strconv.Itoa(i))
+		ledger.SetState("chaincode1", "key1", []byte("value"+strconv.Itoa(i)))
+		ledger.TxFinished("txUuid"+strconv.Itoa(i), true)
+		transaction, _ := buildTestTx(t)
+		ledger.CommitTxBatch(i, []*protos.Transaction{transaction}, nil, []byte("proof"))
+	}
+
+	for i := uint64(0); i < 3; i++ {
+		blockHash, err := ledgerTestWrapper.GetBlockByNumber(i).GetHash()
+		testutil.AssertNoError(t, err, "Error computing block hash")
+		blockNumber, err := ledger.GetBlockNumberByHash(blockHash)
+		testutil.AssertNoError(t, err, "Error fetching block number by hash")
+		testutil.AssertEquals(t, blockNumber, i)
+	}
+
+	_, err := ledger.GetBlockNumberByHash([]byte("unknown block hash"))
+	testutil.AssertError(t, err, "Expected an error for an unknown block hash")
+}
+
+func TestKeyHistory(t *testing.T) {
+	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
+	ledger := ledgerTestWrapper.ledger
+
+	// Block 0
+	transaction0, uuid0 := buildTestTx(t)
+	ledger.BeginTxBatch(0)
+	ledger.TxBegin(uuid0)
+	ledger.SetState("chaincode1", "key1", []byte("value1A"))
+	ledger.TxFinished(uuid0, true)
+	ledger.CommitTxBatch(0, []*protos.Transaction{transaction0}, nil, []byte("proof"))
+
+	// Block 1
+	transaction1a, uuid1a := buildTestTx(t)
+	transaction1b, uuid1b := buildTestTx(t)
+	ledger.BeginTxBatch(1)
+	ledger.TxBegin(uuid1a)
+	ledger.SetState("chaincode1", "key1", []byte("value1B"))
+	ledger.TxFinished(uuid1a, true)
+	ledger.TxBegin(uuid1b)
+	ledger.DeleteState("chaincode1", "key1")
+	ledger.TxFinished(uuid1b, true)
+	ledger.CommitTxBatch(1, []*protos.Transaction{transaction1a, transaction1b}, nil, []byte("proof"))
+
+	value, err := ledger.GetStateAtBlock("chaincode1", "key1", 0)
+	testutil.AssertNoError(t, err, "Error fetching state at block 0.")
+	testutil.AssertEquals(t, value, []byte("value1A"))
+	value, err = ledger.GetStateAtBlock("chaincode1", "key1", 1)
+	testutil.AssertNoError(t, err, "Error fetching state at block 1.")
+	testutil.AssertNil(t, value)
+	_, err = ledger.GetStateAtBlock("chaincode1", "key1", 2)
+	testutil.AssertEquals(t, err, ErrOutOfBounds)
+
+	history, err := ledger.GetKeyHistory("chaincode1", "key1")
+	testutil.AssertNoError(t, err, "Error fetching key history.")
+	testutil.AssertEquals(t, len(history), 3)
+	testutil.AssertEquals(t, history[1].TxID, uuid1a)
+	testutil.AssertEquals(t, history[1].BlockNumber, uint64(1))
+	testutil.AssertEquals(t, history[1].TxIndex, uint64(0))
+	testutil.AssertEquals(t, history[1].PreviousValue, []byte("value1A"))
+	testutil.AssertEquals(t, history[2].TxIndex, uint64(1))
+	testutil.AssertEquals(t, history[2].IsDeleted(), true)
+}
+
+func TestTransactionStatus(t *testing.T) {
+	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
+	ledger := ledgerTestWrapper.ledger
+
+	transaction0, uuid0 := buildTestTx(t)
+	ledger.BeginTxBatch(0)
+	ledger.CommitTxBatch(0, []*protos.Transaction{transaction0}, nil, []byte("proof"))
+
+	// tx1 is committed, tx2 is rejected and left out of the block
+	transaction1, uuid1 := buildTestTx(t)
+	_, uuid2 := buildTestTx(t)
+	txResults := []*protos.TransactionResult{
+		{Txid: uuid1, Result: []byte("result1"), ChaincodeEvent: &protos.ChaincodeEvent{EventName: "event1"}},
+		{Txid: uuid2, ErrorCode: 1, Error: "error2"},
+	}
+	ledger.BeginTxBatch(1)
+	ledger.CommitTxBatch(1, []*protos.Transaction{transaction1}, txResults, []byte("proof"))
+
+	// the result of tx0 is not known
+	status, err := ledger.GetTransactionStatus(uuid0)
+	testutil.AssertNoError(t, err, "Error getting transaction status")
+	testutil.AssertEquals(t, status, &TransactionStatus{Status: TxStatusCommitted, BlockNumber: 0})
+
+	status, err = ledger.GetTransactionStatus(uuid1)
+	testutil.AssertNoError(t, err, "Error getting transaction status")
+	testutil.AssertEquals(t, status.Status, TxStatusCommitted)
+	testutil.AssertEquals(t, status.BlockNumber, uint64(1))
+	testutil.AssertEquals(t, status.Result.Result, []byte("result1"))
+	testutil.AssertEquals(t, status.Result.ChaincodeEvent.EventName, "event1")
+
+	status, err = ledger.GetTransactionStatus(uuid2)
+	testutil.AssertNoError(t, err, "Error getting transaction status")
+	testutil.AssertEquals(t, status.Status, TxStatusRejected)
+	testutil.AssertEquals(t, status.BlockNumber, uint64(1))
+	testutil.AssertEquals(t, status.Result.Error, "error2")
+
+	_, uuid3 := buildTestTx(t)
+	status, err = ledger.GetTransactionStatus(uuid3)
+	testutil.AssertNoError(t, err, "Error getting transaction status")
+	testutil.AssertEquals(t, status.Status, TxStatusPending)
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
+	ledger := ledgerTestWrapper.ledger
+
+	transaction0, _ := buildTestTx(t)
+	ledger.BeginTxBatch(0)
+	ledger.CommitTxBatch(0, []*protos.Transaction{transaction0}, nil, []byte("proof"))
+
+	transaction1, uuid1 := buildTestTx(t)
+	status, err := ledger.WaitForTransaction(uuid1, 10*time.Millisecond)
+	testutil.AssertNoError(t, err, "Error waiting for transaction")
+	testutil.AssertEquals(t, status.Status, TxStatusPending)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ledger.BeginTxBatch(1)
+		ledger.CommitTxBatch(1, []*protos.Transaction{transaction1}, nil, []byte("proof"))
+	}()
+	status, err = ledger.WaitForTransaction(uuid1, time.Minute)
+	testutil.AssertNoError(t, err, "Error waiting for transaction")
+	testutil.AssertEquals(t, status.Status, TxStatusCommitted)
+	testutil.AssertEquals(t, status.BlockNumber, uint64(1))
+}
+
+type testStateListener struct {
+	notifications []map[string][]string
+}
+
+func (listener *testStateListener) StateChanged(updates map[string][]string) {
+	listener.notifications = append(listener.notifications, updates)
+}
+
+func TestStateListener(t *testing.T) {
+	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
+	ledger := ledgerTestWrapper.ledger
+	listener := &testStateListener{}
+	ledger.AddStateListener(listener)
+
+	transaction, uuid := buildTestTx(t)
+	ledger.BeginTxBatch(0)
+	ledger.TxBegin(uuid)
+	ledger.SetState("chaincode2", "key1", []byte("value1"))
+	ledger.SetState("chaincode1", "key1", []byte("value2"))
+	ledger.SetState("chaincode1", "key0", []byte("value3"))
+	ledger.TxFinished(uuid, true)
+	ledger.CommitTxBatch(0, []*protos.Transaction{transaction}, nil, []byte("proof"))
+
+	// A batch that does not change the state is not notified
+	transaction, uuid = buildTestTx(t)
+	ledger.BeginTxBatch(1)
+	ledger.TxBegin(uuid)
+	ledger.TxFinished(uuid, true)
+	ledger.CommitTxBatch(1, []*protos.Transaction{transaction}, nil, []byte("proof"))
+
+	err := ledger.DeleteALLStateKeysAndValues()
+	testutil.AssertNoError(t, err, "Error deleting all state keys and values")
+	testutil.AssertEquals(t, listener.notifications, []map[string][]string{
+		{"chaincode1": {"key0", "key1"}, "chaincode2": {"key1"}},
+		nil,
+	})
+}
+
+type testBatchListener struct {
+	notifications []bool
+}
+
+func (listener *testBatchListener) BatchEnded(committed bool) {
+	listener.notifications = append(listener.notifications, committed)
+}
+
+func TestBatchListener(t *testing.T) {
+	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
+	ledger := ledgerTestWrapper.ledger
+	listener := &testBatchListener{}
+	ledger.AddBatchListener(listener)
+
+	transaction, uuid := buildTestTx(t)
+	ledger.BeginTxBatch(0)
+	ledger.TxBegin(uuid)
+	ledger.SetState("chaincode1", "key1", []byte("value1"))
+	ledger.TxFinished(uuid, true)
+	ledger.CommitTxBatch(0, []*protos.Transaction{transaction}, nil, []byte("proof"))
+
+	_, uuid = buildTestTx(t)
+	ledger.BeginTxBatch(1)
+	ledger.TxBegin(uuid)
+	ledger.SetState("chaincode1", "key1", []byte("value2"))
+	ledger.TxFinished(uuid, true)
+	ledger.RollbackTxBatch(1)
+
+	testutil.AssertEquals(t, listener.notifications, []bool{true, false})
+}
+
 func TestRangeScanIterator(t *testing.T) {
 	ledgerTestWrapper := createFreshDBAndTestLedgerWrapper(t)
 	ledger := ledgerTestWrapper.ledger
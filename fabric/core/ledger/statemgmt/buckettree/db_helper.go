@@ -19,6 +19,7 @@ package buckettree
 import (
 	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/ledger/statemgmt"
+	"github.com/tecbot/gorocksdb"
 )
 
 func fetchDataNodeFromDB(dataKey *dataKey) (*dataNode, error) {
@@ -39,6 +40,18 @@ func fetchDataNodeFromDB(dataKey *dataKey) (*dataNode, error) {
 	return unmarshalDataNode(dataKey, nodeBytes), nil
 }
 
+func fetchDataNodeFromDBSnapshot(snapshot *gorocksdb.Snapshot, dataKey *dataKey) (*dataNode, error) {
+	nodeBytes, err := db.GetDBHandle().GetFromStateCFSnapshot(snapshot, dataKey.getEncodedBytes())
+	if err != nil {
+		return nil, err
+	}
+	// key does not exist
+	if nodeBytes == nil {
+		return nil, nil
+	}
+	return unmarshalDataNode(dataKey, nodeBytes), nil
+}
+
 func fetchBucketNodeFromDB(bucketKey *bucketKey) (*bucketNode, error) {
 	openchainDB := db.GetDBHandle()
 	nodeBytes, err := openchainDB.GetFromStateCF(bucketKey.getEncodedBytes())
@@ -17,7 +17,6 @@ limitations under the License.
 package buckettree
 
 import (
-	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/ledger/statemgmt"
 	"github.com/tecbot/gorocksdb"
 )
@@ -34,8 +33,7 @@ type RangeScanIterator struct {
 	done                bool
 }
 
-func newRangeScanIterator(chaincodeID string, startKey string, endKey string) (*RangeScanIterator, error) {
-	dbItr := db.GetDBHandle().GetStateCFIterator()
+func newRangeScanIterator(dbItr *gorocksdb.Iterator, chaincodeID string, startKey string, endKey string) (*RangeScanIterator, error) {
 	itr := &RangeScanIterator{
 		dbItr:       dbItr,
 		chaincodeID: chaincodeID,
@@ -76,6 +76,18 @@ func (stateImpl *StateImpl) Get(chaincodeID string, key string) ([]byte, error)
 	return dataNode.value, nil
 }
 
+// GetFromSnapshot - method implementation for interface 'statemgmt.HashableState'
+func (stateImpl *StateImpl) GetFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, key string) ([]byte, error) {
+	dataNode, err := fetchDataNodeFromDBSnapshot(snapshot, newDataKey(chaincodeID, key))
+	if err != nil {
+		return nil, err
+	}
+	if dataNode == nil {
+		return nil, nil
+	}
+	return dataNode.value, nil
+}
+
 // PrepareWorkingSet - method implementation for interface 'statemgmt.HashableState'
 func (stateImpl *StateImpl) PrepareWorkingSet(stateDelta *statemgmt.StateDelta) error {
 	logger.Debug("Enter - PrepareWorkingSet()")
@@ -298,5 +310,10 @@ func (stateImpl *StateImpl) GetStateSnapshotIterator(snapshot *gorocksdb.Snapsho
 
 // GetRangeScanIterator - method implementation for interface 'statemgmt.HashableState'
 func (stateImpl *StateImpl) GetRangeScanIterator(chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
-	return newRangeScanIterator(chaincodeID, startKey, endKey)
+	return newRangeScanIterator(db.GetDBHandle().GetStateCFIterator(), chaincodeID, startKey, endKey)
+}
+
+// GetRangeScanIteratorFromSnapshot - method implementation for interface 'statemgmt.HashableState'
+func (stateImpl *StateImpl) GetRangeScanIteratorFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
+	return newRangeScanIterator(db.GetDBHandle().GetStateCFSnapshotIterator(snapshot), chaincodeID, startKey, endKey)
 }
@@ -31,6 +31,9 @@ type HashableState interface {
 	// Get get the value from DB
 	Get(chaincodeID string, key string) ([]byte, error)
 
+	// GetFromSnapshot get the value from a DB snapshot
+	GetFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, key string) ([]byte, error)
+
 	// PrepareWorkingSet passes a stateDelta that captures the changes that needs to be applied to the state
 	PrepareWorkingSet(stateDelta *StateDelta) error
 
@@ -61,6 +64,10 @@ type HashableState interface {
 	// for endKey parameter assumes the endKey to be the greatest key available in the db for the chaincodeID
 	GetRangeScanIterator(chaincodeID string, startKey string, endKey string) (RangeScanIterator, error)
 
+	// GetRangeScanIteratorFromSnapshot - same as GetRangeScanIterator but the key-values are read
+	// from a DB snapshot
+	GetRangeScanIteratorFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, startKey string, endKey string) (RangeScanIterator, error)
+
 	// PerfHintKeyChanged state implementation may be provided with some hints before (e.g., during tx execution)
 	// the StateDelta is prepared and passed in PrepareWorkingSet method.
 	// A state implementation may use this hint for prefetching relevant data so as if this could improve
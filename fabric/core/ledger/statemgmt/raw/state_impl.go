@@ -45,6 +45,13 @@ func (impl *StateImpl) Get(chaincodeID string, key string) ([]byte, error) {
 	return openchainDB.GetFromStateCF(compositeKey)
 }
 
+// GetFromSnapshot - method implementation for interface 'statemgmt.HashableState'
+func (impl *StateImpl) GetFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, key string) ([]byte, error) {
+	compositeKey := statemgmt.ConstructCompositeKey(chaincodeID, key)
+	openchainDB := db.GetDBHandle()
+	return openchainDB.GetFromStateCFSnapshot(snapshot, compositeKey)
+}
+
 // PrepareWorkingSet - method implementation for interface 'statemgmt.HashableState'
 func (impl *StateImpl) PrepareWorkingSet(stateDelta *statemgmt.StateDelta) error {
 	impl.stateDelta = stateDelta
@@ -96,3 +103,8 @@ func (impl *StateImpl) GetStateSnapshotIterator(snapshot *gorocksdb.Snapshot) (s
 func (impl *StateImpl) GetRangeScanIterator(chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
 	panic("Not a full-fledged state implementation. Implemented only for measuring best-case performance benchmark")
 }
+
+// GetRangeScanIteratorFromSnapshot - method implementation for interface 'statemgmt.HashableState'
+func (impl *StateImpl) GetRangeScanIteratorFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
+	panic("Not a full-fledged state implementation. Implemented only for measuring best-case performance benchmark")
+}
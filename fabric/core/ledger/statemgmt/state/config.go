@@ -28,6 +28,7 @@ var loadConfigOnce sync.Once
 var stateImplName stateImplType
 var stateImplConfigs map[string]interface{}
 var deltaHistorySize int
+var historyEnabled bool
 
 func initConfig() {
 	loadConfigOnce.Do(func() { loadConfig() })
@@ -38,8 +39,9 @@ func loadConfig() {
 	stateImplName = stateImplType(viper.GetString("ledger.state.dataStructure.name"))
 	stateImplConfigs = viper.GetStringMap("ledger.state.dataStructure.configs")
 	deltaHistorySize = viper.GetInt("ledger.state.deltaHistorySize")
-	logger.Infof("Configurations loaded. stateImplName=[%s], stateImplConfigs=%s, deltaHistorySize=[%d]",
-		stateImplName, stateImplConfigs, deltaHistorySize)
+	historyEnabled = viper.GetBool("ledger.state.keyHistory.enabled")
+	logger.Infof("Configurations loaded. stateImplName=[%s], stateImplConfigs=%s, deltaHistorySize=[%d], keyHistory.enabled=[%t]",
+		stateImplName, stateImplConfigs, deltaHistorySize, historyEnabled)
 
 	if len(stateImplName) == 0 {
 		stateImplName = defaultStateImpl
@@ -0,0 +1,316 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/db"
+	"github.com/hyperledger/fabric/core/ledger/statemgmt"
+	"github.com/tecbot/gorocksdb"
+)
+
+// The history index lives in the historyCF column family. Every change made
+// to a key by a successful transaction is stored under
+// prefixHistoryKey + chaincodeID + key + blockNumber + txIndex, so that the
+// changes of a key are adjacent and ordered. The block at which the index was
+// started is stored under historyStartBlockKey. The index only holds every
+// change from that block on, so the key is deleted whenever blocks are
+// committed without adding their changes: while the index is disabled, and
+// when the state is transferred from another peer. The index is then started
+// again at the next block committed with the index enabled.
+var prefixHistoryKey = byte(1)
+var historyStartBlockKey = []byte{0}
+
+// ErrKeyHistoryNotAvailable is returned if the key history index is disabled,
+// not started, or was started after the requested block
+var ErrKeyHistoryNotAvailable = errors.New("state: key history is not available")
+
+// KeyModification is a change made to a key by a transaction
+type KeyModification struct {
+	BlockNumber uint64
+	TxIndex     uint64
+	TxID        string
+	// Value is nil if the key was deleted
+	Value []byte
+	// PreviousValue is the value of the key before the transaction
+	PreviousValue []byte
+}
+
+// IsDeleted checks whether the key was deleted
+func (modification *KeyModification) IsDeleted() bool {
+	return modification.Value == nil
+}
+
+// txStateDelta is the state delta of a successful transaction of the
+// current batch
+type txStateDelta struct {
+	txID  string
+	delta *statemgmt.StateDelta
+}
+
+// AddHistoryForPersistence adds the changes made by every successful
+// transaction of the current batch to the history index. txIndexes maps the
+// ID of every transaction to its index in the block. It must be invoked
+// before ClearInMemoryChanges. If the index is disabled, it is stopped
+// instead.
+func (state *State) AddHistoryForPersistence(blockNumber uint64, txIndexes map[string]uint64, writeBatch *gorocksdb.WriteBatch) error {
+	cf := db.GetDBHandle().HistoryCF
+	if !state.historyEnabled {
+		writeBatch.DeleteCF(cf, historyStartBlockKey)
+		return nil
+	}
+	logger.Debug("state.addHistoryForPersistence()...start")
+	_, started, err := state.GetHistoryStartBlock()
+	if err != nil {
+		return err
+	}
+	if !started {
+		logger.Infof("Starting the key history index at block number [%d]", blockNumber)
+		writeBatch.PutCF(cf, historyStartBlockKey, encodeUint64(blockNumber))
+	}
+
+	// The previous values in a tx state delta are the values committed before
+	// the batch, a key changed by an earlier tx of the same batch has the
+	// value set by that tx instead
+	currentValues := make(map[string]map[string][]byte)
+	for _, txDelta := range state.txStateDeltas {
+		txIndex, inBlock := txIndexes[txDelta.txID]
+		if !inBlock {
+			logger.Warningf("Transaction [%s] is not part of block [%d], its changes are not added to the key history", txDelta.txID, blockNumber)
+		}
+		for _, chaincodeID := range txDelta.delta.GetUpdatedChaincodeIds(true) {
+			chaincodeValues, ok := currentValues[chaincodeID]
+			if !ok {
+				chaincodeValues = make(map[string][]byte)
+				currentValues[chaincodeID] = chaincodeValues
+			}
+			for key, updatedValue := range txDelta.delta.GetUpdates(chaincodeID) {
+				previousValue, ok := chaincodeValues[key]
+				if !ok {
+					previousValue = updatedValue.GetPreviousValue()
+				}
+				chaincodeValues[key] = updatedValue.GetValue()
+				if !inBlock {
+					continue
+				}
+				modification := &KeyModification{blockNumber, txIndex, txDelta.txID, updatedValue.GetValue(), previousValue}
+				writeBatch.PutCF(cf, encodeHistoryKey(chaincodeID, key, blockNumber, txIndex), encodeKeyModification(modification))
+			}
+		}
+	}
+	logger.Debug("state.addHistoryForPersistence()...finished")
+	return nil
+}
+
+// addHistoryResetForPersistence stops the history index, the changes of the
+// blocks whose state is transferred are not known
+func (state *State) addHistoryResetForPersistence(writeBatch *gorocksdb.WriteBatch) {
+	if state.historyEnabled {
+		logger.Info("Stopping the key history index for the state transfer, it is started again at the next block")
+	}
+	writeBatch.DeleteCF(db.GetDBHandle().HistoryCF, historyStartBlockKey)
+}
+
+// GetHistoryStartBlock returns the number of the first block in the history
+// index, and false if the index has not been started yet
+func (state *State) GetHistoryStartBlock() (uint64, bool, error) {
+	startBlockBytes, err := db.GetDBHandle().GetFromHistoryCF(historyStartBlockKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if startBlockBytes == nil {
+		return 0, false, nil
+	}
+	return decodeToUint64(startBlockBytes), true, nil
+}
+
+// GetKeyHistory returns every change made to key for chaincodeID since the
+// history index was started, ordered by block number and tx index
+func (state *State) GetKeyHistory(chaincodeID string, key string) ([]*KeyModification, error) {
+	if !state.historyEnabled {
+		return nil, ErrKeyHistoryNotAvailable
+	}
+	startBlock, started, err := state.GetHistoryStartBlock()
+	if err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, ErrKeyHistoryNotAvailable
+	}
+
+	itr := db.GetDBHandle().GetHistoryCFIterator()
+	defer itr.Close()
+
+	// The changes made before the index was last started are incomplete
+	prefix := encodeHistoryKeyPrefix(chaincodeID, key)
+	history := []*KeyModification{}
+	for itr.Seek(encodeHistoryKey(chaincodeID, key, startBlock, 0)); itr.ValidForPrefix(prefix); itr.Next() {
+		modification, err := decodeKeyModificationFromIterator(itr)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, modification)
+	}
+	return history, itr.Err()
+}
+
+// GetStateAtBlock returns the value of key for chaincodeID after the
+// transactions of block blockNumber were committed. The history index must
+// have been started at or before blockNumber.
+func (state *State) GetStateAtBlock(chaincodeID string, key string, blockNumber uint64) ([]byte, error) {
+	if !state.historyEnabled {
+		return nil, ErrKeyHistoryNotAvailable
+	}
+	startBlock, started, err := state.GetHistoryStartBlock()
+	if err != nil {
+		return nil, err
+	}
+	if !started || blockNumber < startBlock {
+		logger.Debugf("Key history for block [%d] requested, the index starts at block [%d]", blockNumber, startBlock)
+		return nil, ErrKeyHistoryNotAvailable
+	}
+
+	itr := db.GetDBHandle().GetHistoryCFIterator()
+	defer itr.Close()
+
+	// The first change after blockNumber holds the value at blockNumber as its
+	// previous value, the last change at or before blockNumber holds it as its
+	// value
+	prefix := encodeHistoryKeyPrefix(chaincodeID, key)
+	var nextModification *KeyModification
+	itr.Seek(encodeHistoryKey(chaincodeID, key, blockNumber+1, 0))
+	if itr.ValidForPrefix(prefix) {
+		if nextModification, err = decodeKeyModificationFromIterator(itr); err != nil {
+			return nil, err
+		}
+	}
+	if itr.Valid() {
+		itr.Prev()
+	} else {
+		itr.SeekToLast()
+	}
+	if itr.ValidForPrefix(prefix) {
+		modification, err := decodeKeyModificationFromIterator(itr)
+		if err != nil {
+			return nil, err
+		}
+		// A change made before the index was last started may have been
+		// followed by changes that are not in the index
+		if modification.BlockNumber >= startBlock {
+			return modification.Value, nil
+		}
+	}
+	if err = itr.Err(); err != nil {
+		return nil, err
+	}
+	if nextModification != nil {
+		return nextModification.PreviousValue, nil
+	}
+	// The key has not changed since blockNumber
+	return state.stateImpl.Get(chaincodeID, key)
+}
+
+func encodeHistoryKeyPrefix(chaincodeID string, key string) []byte {
+	buffer := proto.NewBuffer([]byte{prefixHistoryKey})
+	buffer.EncodeStringBytes(chaincodeID)
+	buffer.EncodeStringBytes(key)
+	return buffer.Bytes()
+}
+
+func encodeHistoryKey(chaincodeID string, key string, blockNumber uint64, txIndex uint64) []byte {
+	historyKey := encodeHistoryKeyPrefix(chaincodeID, key)
+	historyKey = append(historyKey, encodeUint64(blockNumber)...)
+	return append(historyKey, encodeUint64(txIndex)...)
+}
+
+func decodeHistoryKey(historyKey []byte) (uint64, uint64, error) {
+	if len(historyKey) < 17 || historyKey[0] != prefixHistoryKey {
+		return 0, 0, fmt.Errorf("Invalid history key [%x]", historyKey)
+	}
+	suffix := historyKey[len(historyKey)-16:]
+	return decodeToUint64(suffix[:8]), decodeToUint64(suffix[8:]), nil
+}
+
+func encodeKeyModification(modification *KeyModification) []byte {
+	buffer := proto.NewBuffer([]byte{})
+	buffer.EncodeStringBytes(modification.TxID)
+	encodeValueWithMarker(buffer, modification.Value)
+	encodeValueWithMarker(buffer, modification.PreviousValue)
+	return buffer.Bytes()
+}
+
+func decodeKeyModification(blockNumber uint64, txIndex uint64, modificationBytes []byte) (*KeyModification, error) {
+	buffer := proto.NewBuffer(modificationBytes)
+	txID, err := buffer.DecodeStringBytes()
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshaling key modification: %s", err)
+	}
+	value, err := decodeValueWithMarker(buffer)
+	if err != nil {
+		return nil, err
+	}
+	previousValue, err := decodeValueWithMarker(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyModification{blockNumber, txIndex, txID, value, previousValue}, nil
+}
+
+func decodeKeyModificationFromIterator(itr *gorocksdb.Iterator) (*KeyModification, error) {
+	keySlice := itr.Key()
+	defer keySlice.Free()
+	valueSlice := itr.Value()
+	defer valueSlice.Free()
+
+	blockNumber, txIndex, err := decodeHistoryKey(keySlice.Data())
+	if err != nil {
+		return nil, err
+	}
+	return decodeKeyModification(blockNumber, txIndex, valueSlice.Data())
+}
+
+// encodeValueWithMarker distinguishes a nil value (deleted key) from an
+// empty one
+func encodeValueWithMarker(buffer *proto.Buffer, value []byte) {
+	if value == nil {
+		buffer.EncodeVarint(0)
+		return
+	}
+	buffer.EncodeVarint(1)
+	buffer.EncodeRawBytes(value)
+}
+
+func decodeValueWithMarker(buffer *proto.Buffer) ([]byte, error) {
+	marker, err := buffer.DecodeVarint()
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshaling key modification: %s", err)
+	}
+	if marker == 0 {
+		return nil, nil
+	}
+	value, err := buffer.DecodeRawBytes(true)
+	if err != nil {
+		return nil, fmt.Errorf("Error unmarshaling key modification: %s", err)
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	return value, nil
+}
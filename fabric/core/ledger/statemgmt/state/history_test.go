@@ -0,0 +1,219 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package state
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger/statemgmt"
+	"github.com/hyperledger/fabric/core/ledger/testutil"
+	"github.com/tecbot/gorocksdb"
+)
+
+func (testWrapper *stateTestWrapper) persistWithHistoryAndClearInMemoryChanges(blockNumber uint64, txIDs ...string) {
+	txIndexes := make(map[string]uint64)
+	for i, txID := range txIDs {
+		txIndexes[txID] = uint64(i)
+	}
+	writeBatch := gorocksdb.NewWriteBatch()
+	defer writeBatch.Destroy()
+	testWrapper.state.AddChangesForPersistence(blockNumber, writeBatch)
+	err := testWrapper.state.AddHistoryForPersistence(blockNumber, txIndexes, writeBatch)
+	testutil.AssertNoError(testWrapper.t, err, "Error while adding key history")
+	testDBWrapper.WriteToDB(testWrapper.t, writeBatch)
+	testWrapper.state.ClearInMemoryChanges(true)
+}
+
+func (testWrapper *stateTestWrapper) getStateAtBlock(chaincodeID string, key string, blockNumber uint64) []byte {
+	value, err := testWrapper.state.GetStateAtBlock(chaincodeID, key, blockNumber)
+	testutil.AssertNoError(testWrapper.t, err, "Error while getting state at block")
+	return value
+}
+
+func TestKeyHistory(t *testing.T) {
+	stateTestWrapper, state := createFreshDBAndConstructState(t)
+	state.historyEnabled = true
+
+	// block 0
+	state.TxBegin("tx1")
+	state.Set("chaincode1", "key1", []byte("value1"))
+	state.TxFinish("tx1", true)
+	state.TxBegin("tx2")
+	state.Set("chaincode1", "key1", []byte("value2"))
+	state.Set("chaincode1", "key2", []byte("value3"))
+	state.TxFinish("tx2", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(0, "tx1", "tx2")
+
+	// block 1, the changes of the failed tx are not in the history
+	state.TxBegin("tx3")
+	state.Set("chaincode1", "key2", []byte("value4"))
+	state.TxFinish("tx3", false)
+	state.TxBegin("tx4")
+	state.Delete("chaincode1", "key1")
+	state.TxFinish("tx4", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(1, "tx3", "tx4")
+
+	history, err := state.GetKeyHistory("chaincode1", "key1")
+	testutil.AssertNoError(t, err, "Error while getting key history")
+	testutil.AssertEquals(t, history, []*KeyModification{
+		{0, 0, "tx1", []byte("value1"), nil},
+		{0, 1, "tx2", []byte("value2"), []byte("value1")},
+		{1, 1, "tx4", nil, []byte("value2")},
+	})
+	testutil.AssertEquals(t, history[2].IsDeleted(), true)
+
+	history, err = state.GetKeyHistory("chaincode1", "key2")
+	testutil.AssertNoError(t, err, "Error while getting key history")
+	testutil.AssertEquals(t, history, []*KeyModification{{0, 1, "tx2", []byte("value3"), nil}})
+
+	history, err = state.GetKeyHistory("chaincode1", "key")
+	testutil.AssertNoError(t, err, "Error while getting key history")
+	testutil.AssertEquals(t, len(history), 0)
+
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key1", 0), []byte("value2"))
+	testutil.AssertNil(t, stateTestWrapper.getStateAtBlock("chaincode1", "key1", 1))
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key2", 1), []byte("value3"))
+	testutil.AssertNil(t, stateTestWrapper.getStateAtBlock("chaincode2", "key1", 1))
+}
+
+func TestKeyHistoryStartBlock(t *testing.T) {
+	stateTestWrapper, state := createFreshDBAndConstructState(t)
+
+	// block 0 is committed before the history index is enabled
+	state.historyEnabled = false
+	state.TxBegin("tx1")
+	state.Set("chaincode1", "key1", []byte("value1"))
+	state.Set("chaincode1", "key2", []byte("value2"))
+	state.TxFinish("tx1", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(0, "tx1")
+	_, err := state.GetStateAtBlock("chaincode1", "key1", 0)
+	testutil.AssertSame(t, err, ErrKeyHistoryNotAvailable)
+
+	state.historyEnabled = true
+	state.TxBegin("tx2")
+	state.Set("chaincode1", "key1", []byte("value3"))
+	state.TxFinish("tx2", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(1, "tx2")
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(2)
+	state.TxBegin("tx3")
+	state.Set("chaincode1", "key2", []byte("value4"))
+	state.TxFinish("tx3", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(3, "tx3")
+
+	startBlock, started, err := state.GetHistoryStartBlock()
+	testutil.AssertNoError(t, err, "Error while getting history start block")
+	testutil.AssertEquals(t, started, true)
+	testutil.AssertEquals(t, startBlock, uint64(1))
+
+	_, err = state.GetStateAtBlock("chaincode1", "key1", 0)
+	testutil.AssertSame(t, err, ErrKeyHistoryNotAvailable)
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key1", 1), []byte("value3"))
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key1", 2), []byte("value3"))
+	// key2 was last changed before the index was started
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key2", 2), []byte("value2"))
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key2", 3), []byte("value4"))
+}
+
+func TestKeyHistoryStateTransfer(t *testing.T) {
+	stateTestWrapper, state := createFreshDBAndConstructState(t)
+	state.historyEnabled = true
+
+	state.TxBegin("tx1")
+	state.Set("chaincode1", "key1", []byte("value1"))
+	state.Set("chaincode1", "key2", []byte("value2"))
+	state.TxFinish("tx1", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(0, "tx1")
+
+	// block 1 is transferred from another peer, its changes are not known
+	delta := statemgmt.NewStateDelta()
+	delta.Set("chaincode1", "key1", []byte("value3"), []byte("value1"))
+	state.ApplyStateDelta(delta)
+	err := state.CommitStateDelta()
+	testutil.AssertNoError(t, err, "Error while committing state delta")
+	state.ClearInMemoryChanges(true)
+	_, started, err := state.GetHistoryStartBlock()
+	testutil.AssertNoError(t, err, "Error while getting history start block")
+	testutil.AssertEquals(t, started, false)
+	_, err = state.GetKeyHistory("chaincode1", "key1")
+	testutil.AssertSame(t, err, ErrKeyHistoryNotAvailable)
+	_, err = state.GetStateAtBlock("chaincode1", "key1", 0)
+	testutil.AssertSame(t, err, ErrKeyHistoryNotAvailable)
+
+	state.TxBegin("tx2")
+	state.Set("chaincode1", "key2", []byte("value4"))
+	state.TxFinish("tx2", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(2, "tx2")
+
+	startBlock, _, err := state.GetHistoryStartBlock()
+	testutil.AssertNoError(t, err, "Error while getting history start block")
+	testutil.AssertEquals(t, startBlock, uint64(2))
+	for _, blockNumber := range []uint64{0, 1} {
+		_, err = state.GetStateAtBlock("chaincode1", "key1", blockNumber)
+		testutil.AssertSame(t, err, ErrKeyHistoryNotAvailable)
+	}
+	// The change of key1 in block 0 is followed by the transferred one
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key1", 2), []byte("value3"))
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key2", 2), []byte("value4"))
+	history, err := state.GetKeyHistory("chaincode1", "key1")
+	testutil.AssertNoError(t, err, "Error while getting key history")
+	testutil.AssertEquals(t, len(history), 0)
+	history, err = state.GetKeyHistory("chaincode1", "key2")
+	testutil.AssertNoError(t, err, "Error while getting key history")
+	testutil.AssertEquals(t, history, []*KeyModification{{2, 0, "tx2", []byte("value4"), []byte("value2")}})
+}
+
+func TestKeyHistoryDisabled(t *testing.T) {
+	stateTestWrapper, state := createFreshDBAndConstructState(t)
+	state.historyEnabled = true
+
+	state.TxBegin("tx1")
+	state.Set("chaincode1", "key1", []byte("value1"))
+	state.TxFinish("tx1", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(0, "tx1")
+
+	// block 1 is committed while the index is disabled
+	state.historyEnabled = false
+	state.TxBegin("tx2")
+	state.Set("chaincode1", "key1", []byte("value2"))
+	state.TxFinish("tx2", true)
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(1, "tx2")
+
+	state.historyEnabled = true
+	stateTestWrapper.persistWithHistoryAndClearInMemoryChanges(2)
+	startBlock, _, err := state.GetHistoryStartBlock()
+	testutil.AssertNoError(t, err, "Error while getting history start block")
+	testutil.AssertEquals(t, startBlock, uint64(2))
+	_, err = state.GetStateAtBlock("chaincode1", "key1", 1)
+	testutil.AssertSame(t, err, ErrKeyHistoryNotAvailable)
+	testutil.AssertEquals(t, stateTestWrapper.getStateAtBlock("chaincode1", "key1", 2), []byte("value2"))
+}
+
+func TestKeyHistoryEncoding(t *testing.T) {
+	// A key that is a prefix of another key must not share its history
+	testutil.AssertNotEquals(t, encodeHistoryKeyPrefix("chaincode1", "key\x00"), encodeHistoryKeyPrefix("chaincode1", "key"))
+	historyKey := encodeHistoryKey("chaincode1", "key\x00", 5, 7)
+	blockNumber, txIndex, err := decodeHistoryKey(historyKey)
+	testutil.AssertNoError(t, err, "Error while decoding history key")
+	testutil.AssertEquals(t, blockNumber, uint64(5))
+	testutil.AssertEquals(t, txIndex, uint64(7))
+
+	modification := &KeyModification{5, 7, "tx1", []byte{}, nil}
+	decoded, err := decodeKeyModification(5, 7, encodeKeyModification(modification))
+	testutil.AssertNoError(t, err, "Error while decoding key modification")
+	testutil.AssertEquals(t, decoded, modification)
+	testutil.AssertEquals(t, decoded.IsDeleted(), false)
+}
@@ -19,6 +19,7 @@ package state
 import (
 	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/hyperledger/fabric/core/db"
 	"github.com/hyperledger/fabric/core/ledger/statemgmt"
@@ -54,6 +55,8 @@ type State struct {
 	txStateDeltaHash      map[string][]byte
 	updateStateImpl       bool
 	historyStateDeltaSize uint64
+	txStateDeltas         []*txStateDelta
+	historyEnabled        bool
 }
 
 // NewState constructs a new State. This Initializes encapsulated state implementation
@@ -75,7 +78,7 @@ func NewState() *State {
 		panic(fmt.Errorf("Error during initialization of state implementation: %s", err))
 	}
 	return &State{stateImpl, statemgmt.NewStateDelta(), statemgmt.NewStateDelta(), "", make(map[string][]byte),
-		false, uint64(deltaHistorySize)}
+		false, uint64(deltaHistorySize), nil, historyEnabled}
 }
 
 // TxBegin marks begin of a new tx. If a tx is already in progress, this call panics
@@ -99,6 +102,9 @@ func (state *State) TxFinish(txID string, txSuccessful bool) {
 			state.stateDelta.ApplyChanges(state.currentTxStateDelta)
 			state.txStateDeltaHash[txID] = state.currentTxStateDelta.ComputeCryptoHash()
 			state.updateStateImpl = true
+			if state.historyEnabled {
+				state.txStateDeltas = append(state.txStateDeltas, &txStateDelta{txID, state.currentTxStateDelta})
+			}
 		} else {
 			state.txStateDeltaHash[txID] = nil
 		}
@@ -259,9 +265,25 @@ func (state *State) GetTxStateDeltaHash() map[string][]byte {
 func (state *State) ClearInMemoryChanges(changesPersisted bool) {
 	state.stateDelta = statemgmt.NewStateDelta()
 	state.txStateDeltaHash = make(map[string][]byte)
+	state.txStateDeltas = nil
 	state.stateImpl.ClearWorkingSet(changesPersisted)
 }
 
+// GetUpdatedKeys returns the keys changed in the state of each chaincode after
+// most recent call to method clearInMemoryChanges
+func (state *State) GetUpdatedKeys() map[string][]string {
+	updates := make(map[string][]string)
+	for _, chaincodeID := range state.stateDelta.GetUpdatedChaincodeIds(true) {
+		keys := []string{}
+		for key := range state.stateDelta.GetUpdates(chaincodeID) {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		updates[chaincodeID] = keys
+	}
+	return updates
+}
+
 // getStateDelta get changes in state after most recent call to method clearInMemoryChanges
 func (state *State) getStateDelta() *statemgmt.StateDelta {
 	return state.stateDelta
@@ -330,6 +352,7 @@ func (state *State) CommitStateDelta() error {
 	writeBatch := gorocksdb.NewWriteBatch()
 	defer writeBatch.Destroy()
 	state.stateImpl.AddChangesForPersistence(writeBatch)
+	state.addHistoryResetForPersistence(writeBatch)
 	opt := gorocksdb.NewDefaultWriteOptions()
 	defer opt.Destroy()
 	return db.GetDBHandle().DB.Write(opt, writeBatch)
@@ -343,8 +366,14 @@ func (state *State) DeleteState() error {
 	err := db.GetDBHandle().DeleteState()
 	if err != nil {
 		logger.Errorf("Error deleting state: %s", err)
+		return err
 	}
-	return err
+	writeBatch := gorocksdb.NewWriteBatch()
+	defer writeBatch.Destroy()
+	state.addHistoryResetForPersistence(writeBatch)
+	opt := gorocksdb.NewDefaultWriteOptions()
+	defer opt.Destroy()
+	return db.GetDBHandle().DB.Write(opt, writeBatch)
 }
 
 func encodeStateDeltaKey(blockNumber uint64) []byte {
@@ -58,3 +58,15 @@ func (ss *StateSnapshot) GetRawKeyValue() ([]byte, []byte) {
 func (ss *StateSnapshot) GetBlockNumber() uint64 {
 	return ss.blockNumber
 }
+
+// Get returns the value for the given chaincodeID and key in this global state snapshot
+func (ss *StateSnapshot) Get(chaincodeID string, key string) ([]byte, error) {
+	return stateImpl.GetFromSnapshot(ss.dbSnapshot, chaincodeID, key)
+}
+
+// GetRangeScanIterator returns an iterator to get all the keys (and values) between startKey and endKey
+// (assuming lexical order of the keys) for a chaincodeID in this global state snapshot. The iterator
+// must be closed before the snapshot is released.
+func (ss *StateSnapshot) GetRangeScanIterator(chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
+	return stateImpl.GetRangeScanIteratorFromSnapshot(ss.dbSnapshot, chaincodeID, startKey, endKey)
+}
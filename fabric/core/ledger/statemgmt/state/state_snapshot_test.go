@@ -61,3 +61,50 @@ func TestStateSnapshot(t *testing.T) {
 	}
 	testutil.AssertEquals(t, numKeys, 6)
 }
+
+func TestStateSnapshotGet(t *testing.T) {
+	stateTestWrapper, state := createFreshDBAndConstructState(t)
+	state.TxBegin("txUuid")
+	state.Set("chaincodeID1", "key1", []byte("value1"))
+	state.Set("chaincodeID1", "key2", []byte("value2"))
+	state.Set("chaincodeID1", "key3", []byte("value3"))
+	state.Set("chaincodeID2", "key1", []byte("value4"))
+	state.TxFinish("txUuid", true)
+	stateTestWrapper.persistAndClearInMemoryChanges(0)
+
+	stateSnapshot := stateTestWrapper.getSnapshot()
+	defer stateSnapshot.Release()
+
+	// changes committed after the snapshot was taken are not visible through it
+	state.TxBegin("txUuid")
+	state.Set("chaincodeID1", "key1", []byte("value5"))
+	state.Delete("chaincodeID1", "key2")
+	state.Set("chaincodeID1", "key4", []byte("value6"))
+	state.TxFinish("txUuid", true)
+	stateTestWrapper.persistAndClearInMemoryChanges(1)
+	testutil.AssertEquals(t, stateTestWrapper.get("chaincodeID1", "key1", true), []byte("value5"))
+
+	value, err := stateSnapshot.Get("chaincodeID1", "key1")
+	testutil.AssertNoError(t, err, "Error while getting state from snapshot")
+	testutil.AssertEquals(t, value, []byte("value1"))
+	value, err = stateSnapshot.Get("chaincodeID1", "key2")
+	testutil.AssertNoError(t, err, "Error while getting state from snapshot")
+	testutil.AssertEquals(t, value, []byte("value2"))
+	value, err = stateSnapshot.Get("chaincodeID1", "key4")
+	testutil.AssertNoError(t, err, "Error while getting state from snapshot")
+	testutil.AssertNil(t, value)
+
+	itr, err := stateSnapshot.GetRangeScanIterator("chaincodeID1", "", "")
+	testutil.AssertNoError(t, err, "Error while getting range scan iterator from snapshot")
+	defer itr.Close()
+	keyValues := make(map[string][]byte)
+	for itr.Next() {
+		key, value := itr.GetKeyValue()
+		keyValues[key] = value
+	}
+	testutil.AssertEquals(t, keyValues, map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+		"key3": []byte("value3"),
+	})
+}
@@ -67,6 +67,18 @@ func (stateTrie *StateTrie) Get(chaincodeID string, key string) ([]byte, error)
 	return trieNode.value, nil
 }
 
+// GetFromSnapshot the value for a given chaincode ID and key from a DB snapshot
+func (stateTrie *StateTrie) GetFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, key string) ([]byte, error) {
+	trieNode, err := fetchTrieNodeFromDBSnapshot(snapshot, newTrieKey(chaincodeID, key))
+	if err != nil {
+		return nil, err
+	}
+	if trieNode == nil {
+		return nil, nil
+	}
+	return trieNode.value, nil
+}
+
 // PrepareWorkingSet creates the start of a new delta
 func (stateTrie *StateTrie) PrepareWorkingSet(stateDelta *statemgmt.StateDelta) error {
 	stateTrie.trieDelta = newTrieDelta(stateDelta)
@@ -186,5 +198,11 @@ func (stateTrie *StateTrie) GetStateSnapshotIterator(snapshot *gorocksdb.Snapsho
 
 // GetRangeScanIterator returns an iterator for performing a range scan between the start and end keys
 func (stateTrie *StateTrie) GetRangeScanIterator(chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
-	return newRangeScanIterator(chaincodeID, startKey, endKey)
+	return newRangeScanIterator(db.GetDBHandle().GetStateCFIterator(), chaincodeID, startKey, endKey)
+}
+
+// GetRangeScanIteratorFromSnapshot returns an iterator for performing a range scan between the start and end
+// keys of a DB snapshot
+func (stateTrie *StateTrie) GetRangeScanIteratorFromSnapshot(snapshot *gorocksdb.Snapshot, chaincodeID string, startKey string, endKey string) (statemgmt.RangeScanIterator, error) {
+	return newRangeScanIterator(db.GetDBHandle().GetStateCFSnapshotIterator(snapshot), chaincodeID, startKey, endKey)
 }
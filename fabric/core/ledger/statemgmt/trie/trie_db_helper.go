@@ -16,7 +16,10 @@ limitations under the License.
 
 package trie
 
-import "github.com/hyperledger/fabric/core/db"
+import (
+	"github.com/hyperledger/fabric/core/db"
+	"github.com/tecbot/gorocksdb"
+)
 
 func fetchTrieNodeFromDB(key *trieKey) (*trieNode, error) {
 	stateTrieLogger.Debugf("Enter fetchTrieNodeFromDB() for trieKey [%s]", key)
@@ -39,3 +42,16 @@ func fetchTrieNodeFromDB(key *trieKey) (*trieNode, error) {
 	stateTrieLogger.Debugf("Exit fetchTrieNodeFromDB() for trieKey [%s]", key)
 	return trieNode, nil
 }
+
+func fetchTrieNodeFromDBSnapshot(snapshot *gorocksdb.Snapshot, key *trieKey) (*trieNode, error) {
+	trieNodeBytes, err := db.GetDBHandle().GetFromStateCFSnapshot(snapshot, key.getEncodedBytes())
+	if err != nil {
+		stateTrieLogger.Errorf("Error in retrieving trie node from DB snapshot for triekey [%s]. Error:%s", key, err)
+		return nil, err
+	}
+
+	if trieNodeBytes == nil {
+		return nil, nil
+	}
+	return unmarshalTrieNode(key, trieNodeBytes)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ledger
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/db"
+	"github.com/hyperledger/fabric/protos"
+	"github.com/tecbot/gorocksdb"
+)
+
+// TxStatus is the status of a transaction as seen by this peer
+type TxStatus string
+
+const (
+	// TxStatusPending is the status of a transaction that was neither
+	// committed nor rejected yet
+	TxStatusPending = TxStatus("pending")
+
+	// TxStatusCommitted is the status of a transaction included in a block
+	TxStatusCommitted = TxStatus("committed")
+
+	// TxStatusRejected is the status of a transaction that failed to execute
+	// and was left out of the block of its batch
+	TxStatusRejected = TxStatus("rejected")
+)
+
+// TransactionStatus describes what became of a transaction
+type TransactionStatus struct {
+	Status TxStatus
+
+	// BlockNumber is the number of the block that includes the transaction
+	// or, if the transaction was rejected, of the block of its batch
+	BlockNumber uint64
+
+	// Result is nil if the result of the transaction is not known, for
+	// instance because the peer received the block through state transfer
+	Result *protos.TransactionResult
+}
+
+// addTxResultsForPersistence adds the results of the transactions of the batch
+// committed as block blockNumber to the write batch
+func addTxResultsForPersistence(blockNumber uint64, txResults []*protos.TransactionResult, writeBatch *gorocksdb.WriteBatch) error {
+	cf := db.GetDBHandle().IndexesCF
+	for _, txResult := range txResults {
+		if txResult == nil || txResult.Txid == "" {
+			continue
+		}
+		value, err := encodeTxResult(blockNumber, txResult)
+		if err != nil {
+			return err
+		}
+		writeBatch.PutCF(cf, encodeTxResultKey(txResult.Txid), value)
+	}
+	return nil
+}
+
+// fetchTxResultFromDB returns the result of a transaction and the number of
+// the block of its batch. The result is nil if it is not known.
+func fetchTxResultFromDB(txID string) (uint64, *protos.TransactionResult, error) {
+	value, err := db.GetDBHandle().GetFromIndexesCF(encodeTxResultKey(txID))
+	if err != nil || value == nil {
+		return 0, nil, err
+	}
+	return decodeTxResult(value)
+}
+
+func encodeTxResultKey(txID string) []byte {
+	return prependKeyPrefix(prefixTxResultKey, []byte(txID))
+}
+
+func encodeTxResult(blockNumber uint64, txResult *protos.TransactionResult) ([]byte, error) {
+	txResultBytes, err := proto.Marshal(txResult)
+	if err != nil {
+		return nil, err
+	}
+	b := proto.NewBuffer([]byte{})
+	b.EncodeVarint(blockNumber)
+	b.EncodeRawBytes(txResultBytes)
+	return b.Bytes(), nil
+}
+
+func decodeTxResult(value []byte) (uint64, *protos.TransactionResult, error) {
+	b := proto.NewBuffer(value)
+	blockNumber, err := b.DecodeVarint()
+	if err != nil {
+		return 0, nil, err
+	}
+	txResultBytes, err := b.DecodeRawBytes(false)
+	if err != nil {
+		return 0, nil, err
+	}
+	txResult := &protos.TransactionResult{}
+	err = proto.Unmarshal(txResultBytes, txResult)
+	if err != nil {
+		return 0, nil, err
+	}
+	return blockNumber, txResult, nil
+}
@@ -19,12 +19,15 @@ package rest
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/statemgmt/state"
 	pb "github.com/hyperledger/fabric/protos"
 	"github.com/spf13/viper"
 )
@@ -32,6 +35,10 @@ import (
 var (
 	// ErrNotFound is returned if a requested resource does not exist
 	ErrNotFound = errors.New("openchain: resource not found")
+
+	// ErrHistoryNotAvailable is returned if the key history index does not
+	// cover a request
+	ErrHistoryNotAvailable = errors.New("openchain: key history not available")
 )
 
 // PeerInfo defines API to peer info data
@@ -102,30 +109,75 @@ func (s *ServerOpenchain) GetBlockByNumber(ctx context.Context, num *pb.BlockNum
 	// individual transaction.
 	blockTransactions := block.GetTransactions()
 	for _, transaction := range blockTransactions {
-		if transaction.Type == pb.Transaction_CHAINCODE_DEPLOY {
-			deploymentSpec := &pb.ChaincodeDeploymentSpec{}
-			err := proto.Unmarshal(transaction.Payload, deploymentSpec)
-			if err != nil {
-				if !viper.GetBool("security.privacy") {
-					return nil, err
-				}
-				//if privacy is enabled, payload is encrypted and unmarshal will
-				//likely fail... given we were going to just set the CodePackage
-				//to nil anyway, just recover and continue
-				deploymentSpec = &pb.ChaincodeDeploymentSpec{}
-			}
-			deploymentSpec.CodePackage = nil
-			deploymentSpecBytes, err := proto.Marshal(deploymentSpec)
-			if err != nil {
-				return nil, err
-			}
-			transaction.Payload = deploymentSpecBytes
+		err := removeCodePackage(transaction)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return block, nil
 }
 
+// removeCodePackage removes the code package from the payload of a deploy or
+// upgrade transaction
+func removeCodePackage(transaction *pb.Transaction) error {
+	switch transaction.Type {
+	case pb.Transaction_CHAINCODE_DEPLOY:
+	case pb.Transaction_CHAINCODE_UPGRADE:
+		// Upgrades are not confidential, their payload is never encrypted
+		upgradeSpec := &pb.ChaincodeUpgradeSpec{}
+		err := proto.Unmarshal(transaction.Payload, upgradeSpec)
+		if err != nil {
+			return err
+		}
+		if upgradeSpec.DeploymentSpec != nil {
+			upgradeSpec.DeploymentSpec.CodePackage = nil
+		}
+		upgradeSpecBytes, err := proto.Marshal(upgradeSpec)
+		if err != nil {
+			return err
+		}
+		transaction.Payload = upgradeSpecBytes
+		return nil
+	default:
+		return nil
+	}
+	deploymentSpec := &pb.ChaincodeDeploymentSpec{}
+	err := proto.Unmarshal(transaction.Payload, deploymentSpec)
+	if err != nil {
+		if !viper.GetBool("security.privacy") {
+			return err
+		}
+		//if privacy is enabled, payload is encrypted and unmarshal will
+		//likely fail... given we were going to just set the CodePackage
+		//to nil anyway, just recover and continue
+		deploymentSpec = &pb.ChaincodeDeploymentSpec{}
+	}
+	deploymentSpec.CodePackage = nil
+	deploymentSpecBytes, err := proto.Marshal(deploymentSpec)
+	if err != nil {
+		return err
+	}
+	transaction.Payload = deploymentSpecBytes
+	return nil
+}
+
+// GetBlockNumberOfBlockEvent returns the number of a block received in a block
+// event. Block events leave out the code packages of deploy transactions, which
+// changes the hash of the block, so the block is found from the hash of the
+// previous block instead.
+func (s *ServerOpenchain) GetBlockNumberOfBlockEvent(ctx context.Context, block *pb.Block) (uint64, error) {
+	// Only the genesis block has no previous block
+	if len(block.PreviousBlockHash) == 0 {
+		return 0, nil
+	}
+	previousBlockNumber, err := s.ledger.GetBlockNumberByHash(block.PreviousBlockHash)
+	if err != nil {
+		return 0, fmt.Errorf("Error retrieving block from blockchain: %s", err)
+	}
+	return previousBlockNumber + 1, nil
+}
+
 // GetBlockCount returns the current number of blocks in the blockchain data
 // structure.
 func (s *ServerOpenchain) GetBlockCount(ctx context.Context, e *empty.Empty) (*pb.BlockCount, error) {
@@ -148,6 +200,76 @@ func (s *ServerOpenchain) GetState(ctx context.Context, chaincodeID, key string)
 	return s.ledger.GetState(chaincodeID, key, true)
 }
 
+// GetStateAtBlock returns the value for a particular chaincode ID and key
+// after the specified block was committed
+func (s *ServerOpenchain) GetStateAtBlock(ctx context.Context, chaincodeID, key string, blockNumber uint64) ([]byte, error) {
+	value, err := s.ledger.GetStateAtBlock(chaincodeID, key, blockNumber)
+	if err != nil {
+		switch err {
+		case ledger.ErrOutOfBounds:
+			return nil, ErrNotFound
+		case state.ErrKeyHistoryNotAvailable:
+			return nil, ErrHistoryNotAvailable
+		default:
+			return nil, fmt.Errorf("Error retrieving state at block %d: %s", blockNumber, err)
+		}
+	}
+	return value, nil
+}
+
+// GetKeyHistory returns every change made to a particular chaincode ID and
+// key, oldest first
+func (s *ServerOpenchain) GetKeyHistory(ctx context.Context, chaincodeID, key string) ([]*state.KeyModification, error) {
+	history, err := s.ledger.GetKeyHistory(chaincodeID, key)
+	if err != nil {
+		switch err {
+		case state.ErrKeyHistoryNotAvailable:
+			return nil, ErrHistoryNotAvailable
+		default:
+			return nil, fmt.Errorf("Error retrieving key history: %s", err)
+		}
+	}
+	return history, nil
+}
+
+// GetChaincodeDeployments returns the chaincodes deployed on the blockchain,
+// ordered by chaincode ID
+func (s *ServerOpenchain) GetChaincodeDeployments(ctx context.Context) ([]*ledger.ChaincodeDeployment, error) {
+	deployments, err := s.ledger.GetChaincodeDeployments()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving chaincode deployments: %s", err)
+	}
+	return deployments, nil
+}
+
+// GetChaincodeVersions returns the version history of a chaincode, which is
+// empty if the chaincode was never upgraded
+func (s *ServerOpenchain) GetChaincodeVersions(ctx context.Context, chaincodeID string) (*pb.ChaincodeVersionHistory, error) {
+	history, err := chaincode.GetVersionHistory(s.ledger, chaincodeID, true)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving chaincode versions: %s", err)
+	}
+	return history, nil
+}
+
+// GetChaincodeTransactions returns at most limit transactions that deployed,
+// upgraded or invoked a chaincode, starting with the transaction at txIndex in
+// block startBlockNumber. The code package is removed from deploy and upgrade
+// transactions.
+func (s *ServerOpenchain) GetChaincodeTransactions(ctx context.Context, chaincodeID string, startBlockNumber uint64, startTxIndex uint64, limit int) ([]*ledger.ChaincodeTransaction, error) {
+	transactions, err := s.ledger.GetChaincodeTransactions(chaincodeID, startBlockNumber, startTxIndex, limit)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving chaincode transactions: %s", err)
+	}
+	for _, transaction := range transactions {
+		err = removeCodePackage(transaction.Transaction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transactions, nil
+}
+
 // GetTransactionByID returns a transaction matching the specified ID
 func (s *ServerOpenchain) GetTransactionByID(ctx context.Context, txID string) (*pb.Transaction, error) {
 	transaction, err := s.ledger.GetTransactionByID(txID)
@@ -162,6 +284,24 @@ func (s *ServerOpenchain) GetTransactionByID(ctx context.Context, txID string) (
 	return transaction, nil
 }
 
+// GetTransactionStatus returns whether a transaction was committed or
+// rejected, or is still pending. If wait is positive and the transaction is
+// pending, it waits up to wait for the transaction to be committed or
+// rejected.
+func (s *ServerOpenchain) GetTransactionStatus(ctx context.Context, txID string, wait time.Duration) (*ledger.TransactionStatus, error) {
+	var status *ledger.TransactionStatus
+	var err error
+	if wait > 0 {
+		status, err = s.ledger.WaitForTransaction(txID, wait)
+	} else {
+		status, err = s.ledger.GetTransactionStatus(txID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving transaction status: %s", err)
+	}
+	return status, nil
+}
+
 // GetPeers returns a list of all peer nodes currently connected to the target peer.
 func (s *ServerOpenchain) GetPeers(ctx context.Context, e *empty.Empty) (*pb.PeersMessage, error) {
 	return s.peerInfo.GetPeers()
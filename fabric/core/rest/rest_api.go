@@ -22,12 +22,15 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -42,6 +45,7 @@ import (
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/crypto"
 	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos"
 )
 
@@ -74,6 +78,106 @@ type tcertsResult struct {
 	OK []string
 }
 
+// stateResult defines the response payload for the GetState REST interface
+// request. Block is only set if the state at a past block was requested.
+type stateResult struct {
+	ChaincodeID string  `json:"chaincodeID"`
+	Key         string  `json:"key"`
+	Block       *uint64 `json:"block,omitempty"`
+	Value       string  `json:"value"`
+}
+
+// keyModificationResult defines a single change in the response payload for
+// the GetKeyHistory REST interface request.
+type keyModificationResult struct {
+	Block   uint64 `json:"block"`
+	TxIndex uint64 `json:"txIndex"`
+	TxID    string `json:"txID"`
+	Value   string `json:"value,omitempty"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+// keyHistoryResult defines the response payload for the GetKeyHistory REST
+// interface request.
+type keyHistoryResult struct {
+	ChaincodeID string                  `json:"chaincodeID"`
+	Key         string                  `json:"key"`
+	History     []keyModificationResult `json:"history"`
+}
+
+// blockFields holds the names of the fields that can be selected with the
+// fields query parameter of the GetBlocks REST interface request.
+var blockFields = getBlockFields()
+
+func getBlockFields() map[string]bool {
+	fields := getJSONFieldNames(reflect.TypeOf(pb.Block{}))
+	fields["number"] = true
+	return fields
+}
+
+// blocksResult defines the response payload for the GetBlocks REST interface
+// request. Next is the number of the first block of the next page, if any.
+type blocksResult struct {
+	Height uint64                       `json:"height"`
+	Blocks []map[string]json.RawMessage `json:"blocks"`
+	Next   *uint64                      `json:"next,omitempty"`
+}
+
+// chaincodeResult defines a deployed chaincode in the response payload for the
+// GetChaincodes REST interface request.
+type chaincodeResult struct {
+	ChaincodeID string `json:"chaincodeID"`
+	Path        string `json:"path"`
+	TxID        string `json:"txID"`
+	Block       uint64 `json:"block"`
+	TxIndex     uint64 `json:"txIndex"`
+}
+
+// chaincodesResult defines the response payload for the GetChaincodes REST
+// interface request.
+type chaincodesResult struct {
+	Chaincodes []chaincodeResult `json:"chaincodes"`
+}
+
+// txPositionResult defines the position of a transaction on the blockchain.
+type txPositionResult struct {
+	Block   uint64 `json:"block"`
+	TxIndex uint64 `json:"txIndex"`
+}
+
+// chaincodeTransactionResult defines a single transaction in the response
+// payload for the GetChaincodeTransactions REST interface request.
+type chaincodeTransactionResult struct {
+	Block       uint64          `json:"block"`
+	TxIndex     uint64          `json:"txIndex"`
+	Transaction *pb.Transaction `json:"transaction"`
+}
+
+// chaincodeTransactionsResult defines the response payload for the
+// GetChaincodeTransactions REST interface request. Next is the position of
+// the first transaction of the next page, if any.
+type chaincodeTransactionsResult struct {
+	ChaincodeID  string                       `json:"chaincodeID"`
+	Transactions []chaincodeTransactionResult `json:"transactions"`
+	Next         *txPositionResult            `json:"next,omitempty"`
+}
+
+// maxTxStatusWait is the longest a GetTransactionStatus REST interface
+// request waits for a transaction to be committed or rejected.
+const maxTxStatusWait = 2 * time.Minute
+
+// txStatusResult defines the response payload for the GetTransactionStatus
+// REST interface request.
+type txStatusResult struct {
+	TxID           string             `json:"txID"`
+	Status         string             `json:"status"`
+	Block          *uint64            `json:"block,omitempty"`
+	Result         string             `json:"result,omitempty"`
+	ErrorCode      uint32             `json:"errorCode,omitempty"`
+	ErrorMessage   string             `json:"errorMessage,omitempty"`
+	ChaincodeEvent *pb.ChaincodeEvent `json:"chaincodeEvent,omitempty"`
+}
+
 // rpcRequest defines the JSON RPC 2.0 request payload for the /chaincode endpoint.
 type rpcRequest struct {
 	Jsonrpc *string           `json:"jsonrpc,omitempty"`
@@ -125,6 +229,8 @@ type rpcResult struct {
 	Status  string    `json:"status,omitempty"`
 	Message string    `json:"message,omitempty"`
 	Error   *rpcError `json:"error,omitempty"`
+	// Block is the number of the block the result of a query reflects
+	Block *uint64 `json:"block,omitempty"`
 }
 
 // rpcError defines the structure for an rpc error.
@@ -154,6 +260,7 @@ var (
 	ChaincodeDeployError     = &rpcError{Code: -32001, Message: "Deployment failure", Data: "Chaincode deployment has failed."}
 	ChaincodeInvokeError     = &rpcError{Code: -32002, Message: "Invocation failure", Data: "Chaincode invocation has failed."}
 	ChaincodeQueryError      = &rpcError{Code: -32003, Message: "Query failure", Data: "Chaincode query has failed."}
+	ChaincodeUpgradeError    = &rpcError{Code: -32004, Message: "Upgrade failure", Data: "Chaincode upgrade has failed."}
 )
 
 // SetOpenchainServer is a middleware function that sets the pointer to the
@@ -689,6 +796,196 @@ func (s *ServerOpenchainREST) GetBlockByNumber(rw web.ResponseWriter, req *web.R
 	encoder.Encode(block)
 }
 
+// GetBlocks returns a page of blocks in ascending order of block number. The
+// from and to query parameters select a range of blocks, limit sets the size
+// of the page and fields the block fields that are returned.
+func (s *ServerOpenchainREST) GetBlocks(rw web.ResponseWriter, req *web.Request) {
+	encoder := json.NewEncoder(rw)
+	query := req.URL.Query()
+
+	from, err := parseUintQueryParam(query, "from", 0)
+	var to uint64
+	if err == nil {
+		to, err = parseUintQueryParam(query, "to", math.MaxUint64)
+	}
+	if err == nil && to < from {
+		err = errors.New("Query parameter to must not be less than from.")
+	}
+	var limit int
+	if err == nil {
+		limit, err = parseLimitQueryParam(query)
+	}
+	var fields map[string]bool
+	if err == nil {
+		fields, err = parseFieldsQueryParam(query, blockFields)
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(restResult{Error: err.Error()})
+		return
+	}
+
+	// GetBlockCount only fails if the blockchain is empty
+	height := uint64(0)
+	if count, countErr := s.server.GetBlockCount(context.Background(), &empty.Empty{}); countErr == nil {
+		height = count.Count
+	}
+
+	result := blocksResult{Height: height, Blocks: []map[string]json.RawMessage{}}
+	number := from
+	for ; number < height && number <= to && len(result.Blocks) < limit; number++ {
+		block, err := s.server.GetBlockByNumber(context.Background(), &pb.BlockNumber{Number: number})
+		if err == ErrNotFound || (err == nil && block == nil) {
+			// a peer that synchronized its state may not have every block
+			continue
+		}
+		var blockJSON map[string]json.RawMessage
+		if err == nil {
+			blockJSON, err = projectBlock(number, block, fields)
+		}
+		if err != nil {
+			rw.WriteHeader(http.StatusInternalServerError)
+			encoder.Encode(restResult{Error: fmt.Sprintf("Error retrieving block %d: %s.", number, err)})
+			restLogger.Errorf("Error retrieving block %d: %s", number, err)
+			return
+		}
+		result.Blocks = append(result.Blocks, blockJSON)
+	}
+	if number < height && number <= to {
+		result.Next = &number
+	}
+
+	// Success
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(result)
+}
+
+// projectBlock returns the JSON encoding of a block and its number, restricted
+// to fields unless fields is nil.
+func projectBlock(number uint64, block *pb.Block, fields map[string]bool) (map[string]json.RawMessage, error) {
+	blockBytes, err := json.Marshal(block)
+	if err != nil {
+		return nil, err
+	}
+	blockJSON := make(map[string]json.RawMessage)
+	err = json.Unmarshal(blockBytes, &blockJSON)
+	if err != nil {
+		return nil, err
+	}
+	blockJSON["number"] = json.RawMessage(strconv.FormatUint(number, 10))
+	if fields != nil {
+		for field := range blockJSON {
+			if !fields[field] {
+				delete(blockJSON, field)
+			}
+		}
+	}
+	return blockJSON, nil
+}
+
+// GetChaincodes returns the chaincodes deployed on the blockchain, with the
+// transaction that deployed them.
+func (s *ServerOpenchainREST) GetChaincodes(rw web.ResponseWriter, req *web.Request) {
+	deployments, err := s.server.GetChaincodeDeployments(context.Background())
+
+	encoder := json.NewEncoder(rw)
+
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		encoder.Encode(restResult{Error: err.Error()})
+		restLogger.Errorf("Error retrieving chaincodes: %s", err)
+		return
+	}
+
+	result := chaincodesResult{Chaincodes: []chaincodeResult{}}
+	for _, deployment := range deployments {
+		result.Chaincodes = append(result.Chaincodes, chaincodeResult{
+			ChaincodeID: deployment.ChaincodeID.Name,
+			Path:        deployment.ChaincodeID.Path,
+			TxID:        deployment.TxID,
+			Block:       deployment.BlockNumber,
+			TxIndex:     deployment.TxIndex,
+		})
+	}
+
+	// Success
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(result)
+}
+
+// GetChaincodeTransactions returns a page of the transactions that deployed or
+// invoked a chaincode, in the order they were committed. The page starts with
+// the transaction at the txIndex query parameter in the block set by the from
+// query parameter, and limit sets its size.
+func (s *ServerOpenchainREST) GetChaincodeTransactions(rw web.ResponseWriter, req *web.Request) {
+	chaincodeID := req.PathParams["id"]
+
+	encoder := json.NewEncoder(rw)
+	query := req.URL.Query()
+
+	from, err := parseUintQueryParam(query, "from", 0)
+	var txIndex uint64
+	if err == nil {
+		txIndex, err = parseUintQueryParam(query, "txIndex", 0)
+	}
+	var limit int
+	if err == nil {
+		limit, err = parseLimitQueryParam(query)
+	}
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(restResult{Error: err.Error()})
+		return
+	}
+
+	// one more transaction is retrieved to find out whether there is a next page
+	transactions, err := s.server.GetChaincodeTransactions(context.Background(), chaincodeID, from, txIndex, limit+1)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		encoder.Encode(restResult{Error: err.Error()})
+		restLogger.Errorf("Error retrieving transactions of chaincode %s: %s", chaincodeID, err)
+		return
+	}
+
+	result := chaincodeTransactionsResult{ChaincodeID: chaincodeID, Transactions: []chaincodeTransactionResult{}}
+	for i, transaction := range transactions {
+		if i == limit {
+			result.Next = &txPositionResult{Block: transaction.BlockNumber, TxIndex: transaction.TxIndex}
+			break
+		}
+		result.Transactions = append(result.Transactions, chaincodeTransactionResult{
+			Block:       transaction.BlockNumber,
+			TxIndex:     transaction.TxIndex,
+			Transaction: transaction.Transaction,
+		})
+	}
+
+	// Success
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(result)
+}
+
+// GetChaincodeVersions returns the versions a chaincode went through, oldest
+// first. The history is empty if the chaincode was never upgraded.
+func (s *ServerOpenchainREST) GetChaincodeVersions(rw web.ResponseWriter, req *web.Request) {
+	chaincodeID := req.PathParams["id"]
+
+	history, err := s.server.GetChaincodeVersions(context.Background(), chaincodeID)
+
+	encoder := json.NewEncoder(rw)
+
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		encoder.Encode(restResult{Error: err.Error()})
+		restLogger.Errorf("Error retrieving versions of chaincode %s: %s", chaincodeID, err)
+		return
+	}
+
+	// Success
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(history)
+}
+
 // GetTransactionByID returns a transaction matching the specified ID
 func (s *ServerOpenchainREST) GetTransactionByID(rw web.ResponseWriter, req *web.Request) {
 	// Parse out the transaction ID
@@ -718,6 +1015,149 @@ func (s *ServerOpenchainREST) GetTransactionByID(rw web.ResponseWriter, req *web
 	}
 }
 
+// GetTransactionStatus returns whether a transaction was committed or
+// rejected, or is still pending, along with its result if it is known. If the
+// wait query parameter is set to a duration such as 30s, the request waits up
+// to that long for a pending transaction to be committed or rejected.
+func (s *ServerOpenchainREST) GetTransactionStatus(rw web.ResponseWriter, req *web.Request) {
+	// Parse out the transaction ID
+	txID := req.PathParams["id"]
+
+	encoder := json.NewEncoder(rw)
+
+	var wait time.Duration
+	if waitParam := req.URL.Query().Get("wait"); waitParam != "" {
+		var err error
+		wait, err = time.ParseDuration(waitParam)
+		if err != nil || wait < 0 {
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(restResult{Error: "Query parameter wait must be a duration, such as 30s."})
+			return
+		}
+		if wait > maxTxStatusWait {
+			wait = maxTxStatusWait
+		}
+	}
+
+	status, err := s.server.GetTransactionStatus(context.Background(), txID, wait)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		encoder.Encode(restResult{Error: fmt.Sprintf("Error retrieving status of transaction %s: %s.", txID, err)})
+		restLogger.Errorf("Error retrieving status of transaction %s: %s", txID, err)
+		return
+	}
+
+	result := txStatusResult{TxID: txID, Status: string(status.Status)}
+	if status.Status != ledger.TxStatusPending {
+		result.Block = &status.BlockNumber
+	}
+	if status.Result != nil {
+		result.Result = string(status.Result.Result)
+		result.ErrorCode = status.Result.ErrorCode
+		result.ErrorMessage = status.Result.Error
+		// transactions that did not emit an event have an empty one
+		if event := status.Result.ChaincodeEvent; event != nil && event.ChaincodeID != "" {
+			result.ChaincodeEvent = event
+		}
+	}
+
+	// Success
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(result)
+}
+
+// GetState returns the committed value of a chaincode key. If the block query
+// parameter is set, the value after that block was committed is returned.
+func (s *ServerOpenchainREST) GetState(rw web.ResponseWriter, req *web.Request) {
+	chaincodeID := req.PathParams["id"]
+	key := req.PathParams["key"]
+
+	encoder := json.NewEncoder(rw)
+
+	var value []byte
+	var err error
+	result := stateResult{ChaincodeID: chaincodeID, Key: key}
+	if blockParam := req.URL.Query().Get("block"); blockParam != "" {
+		// Check for proper Block number syntax
+		blockNumber, parseErr := strconv.ParseUint(blockParam, 10, 64)
+		if parseErr != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(restResult{Error: "Block number must be an integer (uint64)."})
+			return
+		}
+		result.Block = &blockNumber
+		value, err = s.server.GetStateAtBlock(context.Background(), chaincodeID, key, blockNumber)
+	} else {
+		value, err = s.server.GetState(context.Background(), chaincodeID, key)
+	}
+
+	if err != nil {
+		switch err {
+		case ErrNotFound:
+			rw.WriteHeader(http.StatusNotFound)
+			encoder.Encode(restResult{Error: fmt.Sprintf("Block %d is not found.", *result.Block)})
+		case ErrHistoryNotAvailable:
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(restResult{Error: fmt.Sprintf("Key history is not available for block %d.", *result.Block)})
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+			encoder.Encode(restResult{Error: fmt.Sprintf("Error retrieving state of key %s: %s.", key, err)})
+			restLogger.Errorf("Error retrieving state of key %s for chaincode %s: %s", key, chaincodeID, err)
+		}
+		return
+	}
+
+	if value == nil {
+		rw.WriteHeader(http.StatusNotFound)
+		encoder.Encode(restResult{Error: fmt.Sprintf("Key %s is not found.", key)})
+		return
+	}
+
+	// Success
+	result.Value = string(value)
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(result)
+}
+
+// GetKeyHistory returns every committed change made to a chaincode key, oldest
+// first.
+func (s *ServerOpenchainREST) GetKeyHistory(rw web.ResponseWriter, req *web.Request) {
+	chaincodeID := req.PathParams["id"]
+	key := req.PathParams["key"]
+
+	history, err := s.server.GetKeyHistory(context.Background(), chaincodeID, key)
+
+	encoder := json.NewEncoder(rw)
+
+	if err != nil {
+		switch err {
+		case ErrHistoryNotAvailable:
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(restResult{Error: "Key history is not enabled."})
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+			encoder.Encode(restResult{Error: fmt.Sprintf("Error retrieving history of key %s: %s.", key, err)})
+			restLogger.Errorf("Error retrieving history of key %s for chaincode %s: %s", key, chaincodeID, err)
+		}
+		return
+	}
+
+	result := keyHistoryResult{ChaincodeID: chaincodeID, Key: key, History: []keyModificationResult{}}
+	for _, modification := range history {
+		result.History = append(result.History, keyModificationResult{
+			Block:   modification.BlockNumber,
+			TxIndex: modification.TxIndex,
+			TxID:    modification.TxID,
+			Value:   string(modification.Value),
+			Deleted: modification.IsDeleted(),
+		})
+	}
+
+	// Success
+	rw.WriteHeader(http.StatusOK)
+	encoder.Encode(result)
+}
+
 // Deploy first builds the chaincode package and subsequently deploys it to the
 // blockchain.
 //
@@ -1244,7 +1684,7 @@ func (s *ServerOpenchainREST) ProcessChaincode(rw web.ResponseWriter, req *web.R
 		return
 	}
 
-	// Insure that the JSON method string is present and is either deploy, invoke or query
+	// Insure that the JSON method string is present and is either deploy, upgrade, invoke or query
 	if requestPayload.Method == nil {
 		// If the request is not a notification, produce a response.
 		if !notification {
@@ -1256,7 +1696,7 @@ func (s *ServerOpenchainREST) ProcessChaincode(rw web.ResponseWriter, req *web.R
 		restLogger.Error("Missing JSON RPC 2.0 method string.")
 
 		return
-	} else if (*(requestPayload.Method) != "deploy") && (*(requestPayload.Method) != "invoke") && (*(requestPayload.Method) != "query") {
+	} else if (*(requestPayload.Method) != "deploy") && (*(requestPayload.Method) != "upgrade") && (*(requestPayload.Method) != "invoke") && (*(requestPayload.Method) != "query") {
 		// If the request is not a notification, produce a response.
 		if !notification {
 			// Format the error appropriately and produce JSON RPC 2.0 response
@@ -1301,6 +1741,28 @@ func (s *ServerOpenchainREST) ProcessChaincode(rw web.ResponseWriter, req *web.R
 
 		// Process the chaincode deployment request and record the result
 		result = s.processChaincodeDeploy(ccSpec)
+	} else if *(requestPayload.Method) == "upgrade" {
+
+		//
+		// Chaincode upgrade was requested
+		//
+
+		// Payload params field must contain a ChaincodeSpec message
+		if requestPayload.Params == nil {
+			// If the request is not a notification, produce a response.
+			if !notification {
+				// Format the error appropriately and produce JSON RPC 2.0 response
+				errObj := formatRPCError(InvalidParams.Code, InvalidParams.Message, "Client must supply ChaincodeSpec for chaincode upgrade request.")
+				rw.WriteHeader(http.StatusBadRequest)
+				encoder.Encode(formatRPCResponse(errObj, requestPayload.ID))
+			}
+			restLogger.Error("Client must supply ChaincodeSpec for chaincode upgrade request.")
+
+			return
+		}
+
+		// Process the chaincode upgrade request and record the result
+		result = s.processChaincodeUpgrade(requestPayload.Params)
 	} else {
 
 		//
@@ -1502,6 +1964,124 @@ func (s *ServerOpenchainREST) processChaincodeDeploy(spec *pb.ChaincodeSpec) rpc
 	return result
 }
 
+// processChaincodeUpgrade triggers the upgrade of a deployed chaincode and
+// returns a result or an error. The name of the ChaincodeID is the name of the
+// chaincode to upgrade, and its path the path of the new code. The CtorMsg, if
+// any, is the migration function the new code is initialized with.
+func (s *ServerOpenchainREST) processChaincodeUpgrade(spec *pb.ChaincodeSpec) rpcResult {
+	restLogger.Info("REST upgrading chaincode...")
+
+	// Check that the ChaincodeID is not nil.
+	if spec.ChaincodeID == nil {
+		// Format the error appropriately for further processing
+		error := formatRPCError(InvalidParams.Code, InvalidParams.Message, "Payload must contain a ChaincodeID.")
+		restLogger.Error("Payload must contain a ChaincodeID.")
+
+		return error
+	}
+
+	// Check that the name of the upgraded chaincode is not blank.
+	if spec.ChaincodeID.Name == "" {
+		// Format the error appropriately for further processing
+		error := formatRPCError(InvalidParams.Code, InvalidParams.Message, "Chaincode name may not be blank.")
+		restLogger.Error("Chaincode name may not be blank.")
+
+		return error
+	}
+
+	// In network mode, check that the path of the new code is not blank.
+	if viper.GetString("chaincode.mode") != chaincode.DevModeUserRunsChaincode && spec.ChaincodeID.Path == "" {
+		// Format the error appropriately for further processing
+		error := formatRPCError(InvalidParams.Code, InvalidParams.Message, "Chaincode path may not be blank.")
+		restLogger.Error("Chaincode path may not be blank.")
+
+		return error
+	}
+
+	//
+	// Check if security is enabled
+	//
+
+	if core.SecurityEnabled() {
+		// User registrationID must be present inside request payload with security enabled
+		chaincodeUsr := spec.SecureContext
+		if chaincodeUsr == "" {
+			// Format the error appropriately for further processing
+			error := formatRPCError(InvalidParams.Code, InvalidParams.Message, "Must supply username for chaincode when security is enabled.")
+			restLogger.Error("Must supply username for chaincode when security is enabled.")
+
+			return error
+		}
+
+		// Retrieve the REST data storage path
+		// Returns /var/hyperledger/production/client/
+		localStore := getRESTFilePath()
+
+		// Check if the user is logged in before sending transaction. Upgrades
+		// are never confidential, whether privacy is enabled or not.
+		if _, err := os.Stat(localStore + "loginToken_" + chaincodeUsr); err == nil {
+			// No error returned, therefore token exists so user is already logged in
+			restLogger.Infof("Local user '%s' is already logged in. Retrieving login token.", chaincodeUsr)
+
+			// Read in the login token
+			token, err := ioutil.ReadFile(localStore + "loginToken_" + chaincodeUsr)
+			if err != nil {
+				// Format the error appropriately for further processing
+				error := formatRPCError(InternalError.Code, InternalError.Message, fmt.Sprintf("Fatal error when reading client login token: %s", err))
+				restLogger.Errorf("Fatal error when reading client login token: %s", err)
+
+				return error
+			}
+
+			// Add the login token to the chaincodeSpec
+			spec.SecureContext = string(token)
+		} else {
+			// Check if the token is not there and fail
+			if os.IsNotExist(err) {
+				// Format the error appropriately for further processing
+				error := formatRPCError(MissingRegistrationError.Code, MissingRegistrationError.Message, MissingRegistrationError.Data)
+				restLogger.Error(MissingRegistrationError.Data)
+
+				return error
+			}
+			// Unexpected error
+			// Format the error appropriately for further processing
+			error := formatRPCError(InternalError.Code, InternalError.Message, fmt.Sprintf("Unexpected fatal error when checking for client login token: %s", err))
+			restLogger.Errorf("Unexpected fatal error when checking for client login token: %s", err)
+
+			return error
+		}
+	}
+
+	//
+	// Trigger the chaincode upgrade through the devops service
+	//
+	chaincodeUpgradeSpec, err := s.devops.Upgrade(context.Background(), spec)
+
+	//
+	// Upgrade failed
+	//
+
+	if err != nil {
+		// Format the error appropriately for further processing
+		error := formatRPCError(ChaincodeUpgradeError.Code, ChaincodeUpgradeError.Message, fmt.Sprintf("Error when upgrading chaincode: %s", err))
+		restLogger.Errorf("Error when upgrading chaincode: %s", err)
+
+		return error
+	}
+
+	//
+	// Upgrade submitted, the chaincode keeps its name
+	//
+
+	chainID := chaincodeUpgradeSpec.ChaincodeName
+
+	result := formatRPCOK(chainID)
+	restLogger.Infof("Successfully submitted upgrade of chainCode: %s", chainID)
+
+	return result
+}
+
 // processChaincodeInvokeOrQuery triggers chaincode invoke or query and returns a result or an error
 func (s *ServerOpenchainREST) processChaincodeInvokeOrQuery(method string, spec *pb.ChaincodeInvocationSpec) rpcResult {
 	restLogger.Infof("REST %s chaincode...", method)
@@ -1666,7 +2246,8 @@ func (s *ServerOpenchainREST) processChaincodeInvokeOrQuery(method string, spec
 		//
 
 		result = formatRPCOK(val)
-		restLogger.Infof("Successfully queried chaincode: %s", val)
+		result.Block = &resp.BlockNumber
+		restLogger.Infof("Successfully queried chaincode at block %d: %s", resp.BlockNumber, val)
 	}
 
 	return result
@@ -1732,15 +2313,25 @@ func buildOpenchainRESTRouter() *web.Router {
 	router.Get("/registrar/:id/tcert", (*ServerOpenchainREST).GetTransactionCert)
 
 	router.Get("/chain", (*ServerOpenchainREST).GetBlockchainInfo)
+	router.Get("/chain/blocks", (*ServerOpenchainREST).GetBlocks)
 	router.Get("/chain/blocks/:id", (*ServerOpenchainREST).GetBlockByNumber)
 
 	// The /chaincode endpoint which superceedes the /devops endpoint from above
 	router.Post("/chaincode", (*ServerOpenchainREST).ProcessChaincode)
+	router.Get("/chaincode/:id/state/:key", (*ServerOpenchainREST).GetState)
+	router.Get("/chaincode/:id/state/:key/history", (*ServerOpenchainREST).GetKeyHistory)
+
+	router.Get("/chaincodes", (*ServerOpenchainREST).GetChaincodes)
+	router.Get("/chaincodes/:id/transactions", (*ServerOpenchainREST).GetChaincodeTransactions)
+	router.Get("/chaincodes/:id/versions", (*ServerOpenchainREST).GetChaincodeVersions)
 
 	router.Get("/transactions/:id", (*ServerOpenchainREST).GetTransactionByID)
+	router.Get("/transactions/:id/status", (*ServerOpenchainREST).GetTransactionStatus)
 
 	router.Get("/network/peers", (*ServerOpenchainREST).GetPeers)
 
+	router.Get("/events", (*ServerOpenchainREST).GetEvents)
+
 	// Add not found page
 	router.NotFound((*ServerOpenchainREST).NotFound)
 
@@ -17,6 +17,7 @@ limitations under the License.
 package rest
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
@@ -24,12 +25,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/events/producer"
 	"github.com/hyperledger/fabric/protos"
 )
 
@@ -121,6 +126,15 @@ func (d *mockDevops) Deploy(c context.Context, spec *protos.ChaincodeSpec) (*pro
 	return &protos.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: []byte{}}, nil
 }
 
+func (d *mockDevops) Upgrade(c context.Context, spec *protos.ChaincodeSpec) (*protos.ChaincodeUpgradeSpec, error) {
+	if spec.ChaincodeID.Path == "non-existing" {
+		return nil, fmt.Errorf("Upgrade failure on non-existing path")
+	}
+	name := spec.ChaincodeID.Name
+	spec.ChaincodeID.Name = "new_name_for_upgraded_code"
+	return &protos.ChaincodeUpgradeSpec{ChaincodeName: name, DeploymentSpec: &protos.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: []byte{}}}, nil
+}
+
 func (d *mockDevops) Invoke(c context.Context, cis *protos.ChaincodeInvocationSpec) (*protos.Response, error) {
 	if len(cis.ChaincodeSpec.CtorMsg.Args) == 0 {
 		return nil, fmt.Errorf("No function invoked")
@@ -277,6 +291,59 @@ func TestServerOpenchainREST_API_GetBlockByNumber(t *testing.T) {
 	}
 }
 
+func TestServerOpenchainREST_API_GetBlocks(t *testing.T) {
+	// Construct a ledger with 3 blocks.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	getBlocks := func(query string) blocksResult {
+		body := performHTTPGet(t, httpServer.URL+"/chain/blocks"+query)
+		var result blocksResult
+		err := json.Unmarshal(body, &result)
+		if err != nil {
+			t.Fatalf("Invalid JSON response: %v", err)
+		}
+		return result
+	}
+
+	result := getBlocks("?limit=2")
+	if result.Height != 3 || len(result.Blocks) != 2 || result.Next == nil || *result.Next != 2 {
+		t.Fatalf("Unexpected first page of blocks: height %v, %v blocks, next %v", result.Height, len(result.Blocks), result.Next)
+	}
+	if string(result.Blocks[1]["number"]) != "1" {
+		t.Errorf("Expected the second block of the page to be block 1 but got %s", result.Blocks[1]["number"])
+	}
+
+	result = getBlocks("?from=2&limit=2")
+	if len(result.Blocks) != 1 || result.Next != nil {
+		t.Errorf("Expected the last page to contain 1 block but got %v blocks, next %v", len(result.Blocks), result.Next)
+	}
+
+	result = getBlocks("?from=2&to=2&fields=number,transactions")
+	if len(result.Blocks) != 1 || len(result.Blocks[0]) != 2 {
+		t.Fatalf("Expected 1 block with 2 fields but got %v", result.Blocks)
+	}
+	var transactions []*protos.Transaction
+	err := json.Unmarshal(result.Blocks[0]["transactions"], &transactions)
+	if err != nil || len(transactions) != 2 {
+		t.Errorf("Expected block to contain 2 transactions but got %s", result.Blocks[0]["transactions"])
+	}
+
+	for _, query := range []string{"?fields=number,NOT_A_FIELD", "?from=2&to=1", "?limit=0", "?from=NOT_A_NUMBER"} {
+		body := performHTTPGet(t, httpServer.URL+"/chain/blocks"+query)
+		res := parseRESTResult(t, body)
+		if res.Error == "" {
+			t.Errorf("Expected an error when retrieving blocks with %s, but got none", query)
+		}
+	}
+}
+
 func TestServerOpenchainREST_API_GetTransactionByUUID(t *testing.T) {
 	startTime := time.Now().Unix()
 
@@ -322,6 +389,302 @@ func TestServerOpenchainREST_API_GetTransactionByUUID(t *testing.T) {
 	}
 }
 
+func TestServerOpenchainREST_API_GetTransactionStatus(t *testing.T) {
+	// Construct a ledger with 3 blocks and a block with a committed and a rejected transaction.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	committedTx, err := protos.NewTransaction(protos.ChaincodeID{Path: "MyContract"}, generateUUID(t), "setX", []string{"{x: \"hello\"}"})
+	if err != nil {
+		t.Fatalf("Error creating NewTransaction: %s", err)
+	}
+	rejectedTxID := generateUUID(t)
+	txResults := []*protos.TransactionResult{
+		{Txid: committedTx.Txid, Result: []byte("OK"), ChaincodeEvent: &protos.ChaincodeEvent{ChaincodeID: "MyContract", EventName: "setX"}},
+		{Txid: rejectedTxID, ErrorCode: 1, Error: "Transaction or query returned with failure"},
+	}
+	ledger.BeginTxBatch(3)
+	ledger.CommitTxBatch(3, []*protos.Transaction{committedTx}, txResults, []byte("dummy-proof"))
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	getStatus := func(query string) txStatusResult {
+		body := performHTTPGet(t, httpServer.URL+"/transactions/"+query)
+		var result txStatusResult
+		err := json.Unmarshal(body, &result)
+		if err != nil {
+			t.Fatalf("Invalid JSON response: %v", err)
+		}
+		return result
+	}
+
+	status := getStatus(committedTx.Txid + "/status")
+	if status.Status != "committed" || status.Block == nil || *status.Block != 3 || status.Result != "OK" || status.ChaincodeEvent == nil || status.ChaincodeEvent.EventName != "setX" {
+		t.Errorf("Unexpected status of committed transaction: %v", status)
+	}
+
+	status = getStatus(rejectedTxID + "/status")
+	if status.Status != "rejected" || status.Block == nil || *status.Block != 3 || status.ErrorCode != 1 || status.ErrorMessage == "" || status.ChaincodeEvent != nil {
+		t.Errorf("Unexpected status of rejected transaction: %v", status)
+	}
+
+	status = getStatus("NON-EXISTING-UUID/status?wait=10ms")
+	if status.Status != "pending" || status.Block != nil {
+		t.Errorf("Unexpected status of pending transaction: %v", status)
+	}
+
+	body := performHTTPGet(t, httpServer.URL+"/transactions/"+committedTx.Txid+"/status?wait=NOT_A_DURATION")
+	res := parseRESTResult(t, body)
+	if res.Error == "" {
+		t.Errorf("Expected an error when waiting for an invalid duration, but got none")
+	}
+}
+
+func TestServerOpenchainREST_API_GetState(t *testing.T) {
+	// Construct a ledger with 3 blocks.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	body := performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/x")
+	var state stateResult
+	err := json.Unmarshal(body, &state)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if state.Value != "hello" || state.Block != nil {
+		t.Errorf("Expected current value 'hello' but got %v", state)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/y")
+	res := parseRESTResult(t, body)
+	if res.Error == "" {
+		t.Errorf("Expected an error when retrieving non-existing key, but got none")
+	}
+
+	// The key was set in block 2
+	body = performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/x?block=2")
+	state = stateResult{}
+	err = json.Unmarshal(body, &state)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if state.Value != "hello" || state.Block == nil || *state.Block != 2 {
+		t.Errorf("Expected value 'hello' at block 2 but got %v", state)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/x?block=1")
+	res = parseRESTResult(t, body)
+	if res.Error == "" {
+		t.Errorf("Expected an error when retrieving key before it was set, but got none")
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/x?block=9")
+	res = parseRESTResult(t, body)
+	if res.Error == "" {
+		t.Errorf("Expected an error when retrieving key at non-existing block, but got none")
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/x?block=NOT_A_NUMBER")
+	res = parseRESTResult(t, body)
+	if res.Error == "" {
+		t.Errorf("Expected an error when URL doesn't have a number, but got none")
+	}
+}
+
+func TestServerOpenchainREST_API_GetKeyHistory(t *testing.T) {
+	// Construct a ledger with 3 blocks.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	block2, err := ledger.GetBlockByNumber(2)
+	if err != nil {
+		t.Fatalf("Can't fetch block 2 from ledger: %v", err)
+	}
+
+	body := performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/x/history")
+	var history keyHistoryResult
+	err = json.Unmarshal(body, &history)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(history.History) != 1 {
+		t.Fatalf("Expected 1 change of the key but got %v", len(history.History))
+	}
+	change := history.History[0]
+	if change.Block != 2 || change.TxIndex != 0 || change.TxID != block2.Transactions[0].Txid || change.Value != "hello" || change.Deleted {
+		t.Errorf("Unexpected key change %v", change)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincode/MyContract/state/y/history")
+	history = keyHistoryResult{}
+	err = json.Unmarshal(body, &history)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(history.History) != 0 {
+		t.Errorf("Expected no change of non-existing key but got %v", history.History)
+	}
+}
+
+func TestServerOpenchainREST_API_GetChaincodes(t *testing.T) {
+	// Construct a ledger with 3 blocks and a block deploying and invoking a chaincode.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	spec := &protos.ChaincodeSpec{Type: protos.ChaincodeSpec_GOLANG, ChaincodeID: &protos.ChaincodeID{Path: "github.com/mycc", Name: "mycc"}}
+	deployTx, err := protos.NewChaincodeDeployTransaction(&protos.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: []byte("code")}, generateUUID(t))
+	if err != nil {
+		t.Fatalf("Error creating deploy transaction: %s", err)
+	}
+	var invokeTxs []*protos.Transaction
+	for i := 0; i < 3; i++ {
+		invokeTx, err := protos.NewChaincodeExecute(&protos.ChaincodeInvocationSpec{ChaincodeSpec: spec}, generateUUID(t), protos.Transaction_CHAINCODE_INVOKE)
+		if err != nil {
+			t.Fatalf("Error creating invoke transaction: %s", err)
+		}
+		invokeTxs = append(invokeTxs, invokeTx)
+	}
+	ledger.BeginTxBatch(3)
+	ledger.CommitTxBatch(3, append([]*protos.Transaction{deployTx}, invokeTxs...), nil, []byte("dummy-proof"))
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	body := performHTTPGet(t, httpServer.URL+"/chaincodes")
+	var chaincodes chaincodesResult
+	err = json.Unmarshal(body, &chaincodes)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	expected := chaincodeResult{ChaincodeID: "mycc", Path: "github.com/mycc", TxID: deployTx.Txid, Block: 3, TxIndex: 0}
+	if len(chaincodes.Chaincodes) != 1 || chaincodes.Chaincodes[0] != expected {
+		t.Fatalf("Expected chaincode %v but got %v", expected, chaincodes.Chaincodes)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincodes/mycc/transactions?limit=3")
+	var transactions chaincodeTransactionsResult
+	err = json.Unmarshal(body, &transactions)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(transactions.Transactions) != 3 || transactions.Next == nil || *transactions.Next != (txPositionResult{Block: 3, TxIndex: 3}) {
+		t.Fatalf("Unexpected first page of transactions: %v, next %v", transactions.Transactions, transactions.Next)
+	}
+	if transactions.Transactions[0].Transaction.Txid != deployTx.Txid {
+		t.Errorf("Expected the first transaction to be the deploy transaction but got %v", transactions.Transactions[0].Transaction)
+	}
+	deploymentSpec := &protos.ChaincodeDeploymentSpec{}
+	err = proto.Unmarshal(transactions.Transactions[0].Transaction.Payload, deploymentSpec)
+	if err != nil || deploymentSpec.CodePackage != nil {
+		t.Errorf("Expected the code package to be removed from the deploy transaction")
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincodes/mycc/transactions?from=3&txIndex=3&limit=3")
+	transactions = chaincodeTransactionsResult{}
+	err = json.Unmarshal(body, &transactions)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(transactions.Transactions) != 1 || transactions.Next != nil || transactions.Transactions[0].Transaction.Txid != invokeTxs[2].Txid {
+		t.Errorf("Unexpected last page of transactions: %v, next %v", transactions.Transactions, transactions.Next)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincodes/othercc/transactions")
+	transactions = chaincodeTransactionsResult{}
+	err = json.Unmarshal(body, &transactions)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(transactions.Transactions) != 0 {
+		t.Errorf("Expected no transactions of a non-existing chaincode but got %v", transactions.Transactions)
+	}
+}
+
+func TestServerOpenchainREST_API_GetChaincodeVersions(t *testing.T) {
+	// Construct a ledger with 3 blocks and a block upgrading a chaincode.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	spec := &protos.ChaincodeSpec{Type: protos.ChaincodeSpec_GOLANG, ChaincodeID: &protos.ChaincodeID{Path: "github.com/mycc/v2", Name: "mycc-v2-code"}}
+	upgradeTx, err := protos.NewChaincodeUpgradeTransaction(&protos.ChaincodeUpgradeSpec{ChaincodeName: "mycc", DeploymentSpec: &protos.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: []byte("code")}}, generateUUID(t))
+	if err != nil {
+		t.Fatalf("Error creating upgrade transaction: %s", err)
+	}
+	history := &protos.ChaincodeVersionHistory{Versions: []*protos.ChaincodeVersion{
+		{Version: 1, Txid: "mycc", CodeID: &protos.ChaincodeID{Path: "github.com/mycc", Name: "mycc"}},
+		{Version: 2, Txid: upgradeTx.Txid, CodeID: spec.ChaincodeID},
+	}}
+	data, err := proto.Marshal(history)
+	if err != nil {
+		t.Fatalf("Error marshalling version history: %s", err)
+	}
+	ledger.BeginTxBatch(3)
+	ledger.TxBegin(upgradeTx.Txid)
+	ledger.SetState("__chaincode_versions", "mycc", data)
+	ledger.TxFinished(upgradeTx.Txid, true)
+	ledger.CommitTxBatch(3, []*protos.Transaction{upgradeTx}, nil, []byte("dummy-proof"))
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	body := performHTTPGet(t, httpServer.URL+"/chaincodes/mycc/versions")
+	versions := &protos.ChaincodeVersionHistory{}
+	err = json.Unmarshal(body, versions)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(versions.Versions) != 2 || versions.Versions[1].Txid != upgradeTx.Txid || versions.Versions[1].CodeID.Path != "github.com/mycc/v2" {
+		t.Errorf("Expected the version history %v but got %v", history, versions)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincodes/othercc/versions")
+	versions = &protos.ChaincodeVersionHistory{}
+	err = json.Unmarshal(body, versions)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(versions.Versions) != 0 {
+		t.Errorf("Expected no versions of a chaincode never upgraded but got %v", versions.Versions)
+	}
+
+	body = performHTTPGet(t, httpServer.URL+"/chaincodes/mycc/transactions")
+	var transactions chaincodeTransactionsResult
+	err = json.Unmarshal(body, &transactions)
+	if err != nil {
+		t.Fatalf("Invalid JSON response: %v", err)
+	}
+	if len(transactions.Transactions) != 1 || transactions.Transactions[0].Transaction.Txid != upgradeTx.Txid {
+		t.Fatalf("Expected the upgrade transaction but got %v", transactions.Transactions)
+	}
+	upgradeSpec := &protos.ChaincodeUpgradeSpec{}
+	err = proto.Unmarshal(transactions.Transactions[0].Transaction.Payload, upgradeSpec)
+	if err != nil || upgradeSpec.DeploymentSpec.CodePackage != nil {
+		t.Errorf("Expected the code package to be removed from the upgrade transaction")
+	}
+}
+
 func TestServerOpenchainREST_API_Register(t *testing.T) {
 	os.RemoveAll(getRESTFilePath())
 	initGlobalServerOpenchain(t)
@@ -475,6 +838,109 @@ func TestServerOpenchainREST_API_GetPeers(t *testing.T) {
 	}
 }
 
+// readServerSentEvents reads server-sent events up to the event ID lastID
+func readServerSentEvents(t *testing.T, scanner *bufio.Scanner, lastID string) []eventResult {
+	var events []eventResult
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "id: "+lastID {
+			return events
+		}
+		if strings.HasPrefix(line, "data: ") {
+			var event eventResult
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+				t.Fatalf("Invalid JSON event: %v", err)
+			}
+			events = append(events, event)
+		}
+	}
+	t.Fatalf("Event stream ended before event ID %s: %v", lastID, scanner.Err())
+	return nil
+}
+
+func TestServerOpenchainREST_API_GetEvents(t *testing.T) {
+	// Construct a ledger with 3 blocks and a block with chaincode events.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	commitBlockWithEvents := func(id int, events ...*protos.ChaincodeEvent) {
+		var transactions []*protos.Transaction
+		var txResults []*protos.TransactionResult
+		for _, event := range events {
+			tx, err := protos.NewTransaction(protos.ChaincodeID{Path: event.ChaincodeID}, generateUUID(t), "invoke", []string{})
+			if err != nil {
+				t.Fatalf("Error creating NewTransaction: %s", err)
+			}
+			transactions = append(transactions, tx)
+			txResults = append(txResults, &protos.TransactionResult{Txid: tx.Txid, ChaincodeEvent: event})
+		}
+		ledger.BeginTxBatch(id)
+		if err := ledger.CommitTxBatch(id, transactions, txResults, []byte("dummy-proof")); err != nil {
+			t.Fatalf("Error in commit: %s", err)
+		}
+	}
+	commitBlockWithEvents(3, &protos.ChaincodeEvent{ChaincodeID: "MyContract", EventName: "setX"}, &protos.ChaincodeEvent{ChaincodeID: "MyOtherContract", EventName: "setY"})
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	// Interests are required and must be valid
+	for _, query := range []string{"", "?interest=unknown", "?interest=chaincode:", "?interest=block&from=abc"} {
+		res := parseRESTResult(t, performHTTPGet(t, httpServer.URL+"/events"+query))
+		if res.Error == "" {
+			t.Errorf("Expected an error for query '%s', but got none", query)
+		}
+	}
+
+	// Start the event hub
+	producer.NewEventsServer(100, 0)
+
+	// Server-sent events resume after block 1, then follow the blocks committed live
+	client := &http.Client{Timeout: 10 * time.Second}
+	response, err := client.Get(httpServer.URL + "/events?interest=chaincode:MyContract&from=1")
+	if err != nil {
+		t.Fatalf("Error attempt to GET events: %v", err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("Expected server-sent events, but got content type %s", contentType)
+	}
+	scanner := bufio.NewScanner(response.Body)
+
+	events := readServerSentEvents(t, scanner, "3")
+	if len(events) != 1 || events[0].Type != "chaincode" || *events[0].BlockNumber != 3 || events[0].ChaincodeEvent.EventName != "setX" {
+		t.Errorf("Unexpected replayed events: %v", events)
+	}
+
+	commitBlockWithEvents(4, &protos.ChaincodeEvent{ChaincodeID: "MyContract", EventName: "setZ"})
+	events = readServerSentEvents(t, scanner, "4")
+	if len(events) != 1 || events[0].Type != "chaincode" || *events[0].BlockNumber != 4 || events[0].ChaincodeEvent.EventName != "setZ" {
+		t.Errorf("Unexpected live events: %v", events)
+	}
+
+	// A WebSocket client reconnecting with the Last-Event-ID of block 3 gets the block events from block 4
+	config, err := websocket.NewConfig(strings.Replace(httpServer.URL, "http", "ws", 1)+"/events?interest=block&from=0", httpServer.URL)
+	if err != nil {
+		t.Fatalf("Error creating WebSocket config: %v", err)
+	}
+	config.Header.Set("Last-Event-ID", "3")
+	ws, err := websocket.DialConfig(config)
+	if err != nil {
+		t.Fatalf("Error connecting to the event WebSocket: %v", err)
+	}
+	defer ws.Close()
+	var event eventResult
+	if err := websocket.JSON.Receive(ws, &event); err != nil {
+		t.Fatalf("Error receiving event: %v", err)
+	}
+	if event.Type != "block" || *event.BlockNumber != 4 || event.Block == nil || len(event.Block.Transactions) != 1 {
+		t.Errorf("Unexpected block event: %v", event)
+	}
+}
+
 func TestServerOpenchainREST_API_Chaincode_InvalidRequests(t *testing.T) {
 	// Construct a ledger with 3 blocks.
 	ledger := ledger.InitTestLedger(t)
@@ -665,6 +1131,102 @@ func TestServerOpenchainREST_API_Chaincode_Deploy(t *testing.T) {
 	}
 }
 
+func TestServerOpenchainREST_API_Chaincode_Upgrade(t *testing.T) {
+	// Construct a ledger with 3 blocks.
+	ledger := ledger.InitTestLedger(t)
+	buildTestLedger1(ledger, t)
+
+	initGlobalServerOpenchain(t)
+
+	// Start the HTTP REST test server
+	httpServer := httptest.NewServer(buildOpenchainRESTRouter())
+	defer httpServer.Close()
+
+	// Test upgrade without params
+	httpResponse, body := performHTTPPost(t, httpServer.URL+"/chaincode", []byte(`{"jsonrpc":"2.0","ID":123,"method":"upgrade"}`))
+	if httpResponse.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected an HTTP status code %#v but got %#v", http.StatusBadRequest, httpResponse.StatusCode)
+	}
+	res := parseRPCResponse(t, body)
+	if res.Error == nil || res.Error.Code != InvalidParams.Code {
+		t.Errorf("Expected an error when sending missing params, but got %#v", res.Error)
+	}
+
+	// Login
+	performHTTPPost(t, httpServer.URL+"/registrar", []byte(`{"enrollId":"myuser","enrollSecret":"password"}`))
+
+	// Test upgrade without chaincode name
+	requestBody := `{
+		"jsonrpc": "2.0",
+		"ID": 123,
+		"method": "upgrade",
+		"params": {
+			"type": 1,
+			"chaincodeID": {
+				"path": "github.com/hyperledger/fabric/core/rest/test_chaincode"
+			},
+			"secureContext": "myuser"
+		}
+	}`
+	httpResponse, body = performHTTPPost(t, httpServer.URL+"/chaincode", []byte(requestBody))
+	res = parseRPCResponse(t, body)
+	if res.Error == nil || res.Error.Code != InvalidParams.Code {
+		t.Errorf("Expected an error when sending without chaincode name, but got %#v", res.Error)
+	}
+
+	// Test upgrade with invalid chaincode path
+	requestBody = `{
+		"jsonrpc": "2.0",
+		"ID": 123,
+		"method": "upgrade",
+		"params": {
+			"type": 1,
+			"chaincodeID": {
+				"name": "mycc",
+				"path": "non-existing"
+			},
+			"secureContext": "myuser"
+		}
+	}`
+	httpResponse, body = performHTTPPost(t, httpServer.URL+"/chaincode", []byte(requestBody))
+	res = parseRPCResponse(t, body)
+	if res.Error == nil || res.Error.Code != ChaincodeUpgradeError.Code {
+		t.Errorf("Expected an error when sending non-existing chaincode path, but got %#v", res.Error)
+	}
+
+	// Test upgrade with real chaincode path and a migration function
+	requestBody = `{
+		"jsonrpc": "2.0",
+		"ID": 123,
+		"method": "upgrade",
+		"params": {
+			"type": 1,
+			"chaincodeID": {
+				"name": "mycc",
+				"path": "github.com/hyperledger/fabric/core/rest/test_chaincode"
+			},
+			"ctorMsg": {
+				"args": ["migrate"]
+			},
+			"secureContext": "myuser"
+		}
+	}`
+	httpResponse, body = performHTTPPost(t, httpServer.URL+"/chaincode", []byte(requestBody))
+	if httpResponse.StatusCode != http.StatusOK {
+		t.Errorf("Expected an HTTP status code %#v but got %#v", http.StatusOK, httpResponse.StatusCode)
+	}
+	res = parseRPCResponse(t, body)
+	if res.Error != nil {
+		t.Errorf("Expected success but got %#v", res.Error)
+	}
+	if res.Result.Status != "OK" {
+		t.Errorf("Expected OK but got %#v", res.Result.Status)
+	}
+	if res.Result.Message != "mycc" {
+		t.Errorf("Expected the upgraded chaincode to keep its name 'mycc' but got '%#v'", res.Result.Message)
+	}
+}
+
 func TestServerOpenchainREST_API_Chaincode_Invoke(t *testing.T) {
 	// Construct a ledger with 3 blocks.
 	ledger := ledger.InitTestLedger(t)
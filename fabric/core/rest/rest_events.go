@@ -0,0 +1,334 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gocraft/web"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric/events/producer"
+	pb "github.com/hyperledger/fabric/protos"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// eventBufferSize is the number of events buffered for a client of the
+	// event stream. Clients that fall further behind are disconnected and
+	// resume from the last block they received when they reconnect.
+	eventBufferSize = 1000
+
+	// eventKeepAliveInterval is how often a comment is sent on an idle
+	// server-sent event stream to keep it open
+	eventKeepAliveInterval = 30 * time.Second
+)
+
+// eventResult is an event sent on the event stream. Block and chaincode
+// events have the number of the block that committed them.
+type eventResult struct {
+	Type           string             `json:"type"`
+	BlockNumber    *uint64            `json:"blockNumber,omitempty"`
+	Block          *pb.Block          `json:"block,omitempty"`
+	ChaincodeEvent *pb.ChaincodeEvent `json:"chaincodeEvent,omitempty"`
+	Rejection      *pb.Rejection      `json:"rejection,omitempty"`
+}
+
+// eventWriter sends events to a client of the event stream
+type eventWriter interface {
+	// writeBlockEvents sends the events of a committed block, after which a
+	// client that reconnects resumes with the next block
+	writeBlockEvents(blockNumber uint64, events []*eventResult) error
+	// writeEvent sends an event that is not part of a committed block
+	writeEvent(event *eventResult) error
+	keepAlive() error
+}
+
+// sseEventWriter sends events as server-sent events. The events of a block are
+// followed by the block number as event ID, which the client sends back in the
+// Last-Event-ID header when it reconnects.
+type sseEventWriter struct {
+	rw web.ResponseWriter
+}
+
+func (w *sseEventWriter) write(event *eventResult) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w.rw, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+func (w *sseEventWriter) writeBlockEvents(blockNumber uint64, events []*eventResult) error {
+	for _, event := range events {
+		if err := w.write(event); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w.rw, "id: %d\n\n", blockNumber); err != nil {
+		return err
+	}
+	w.rw.Flush()
+	return nil
+}
+
+func (w *sseEventWriter) writeEvent(event *eventResult) error {
+	if err := w.write(event); err != nil {
+		return err
+	}
+	w.rw.Flush()
+	return nil
+}
+
+func (w *sseEventWriter) keepAlive() error {
+	if _, err := fmt.Fprint(w.rw, ": keep-alive\n\n"); err != nil {
+		return err
+	}
+	w.rw.Flush()
+	return nil
+}
+
+// webSocketEventWriter sends each event as a JSON message. Clients resume from
+// the block number of the last block or chaincode event they received.
+type webSocketEventWriter struct {
+	ws *websocket.Conn
+}
+
+func (w *webSocketEventWriter) writeBlockEvents(blockNumber uint64, events []*eventResult) error {
+	for _, event := range events {
+		if err := w.writeEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *webSocketEventWriter) writeEvent(event *eventResult) error {
+	return websocket.JSON.Send(w.ws, event)
+}
+
+func (w *webSocketEventWriter) keepAlive() error {
+	return nil
+}
+
+// eventStream streams the events a client is interested in. Block and
+// chaincode events are taken from the committed blocks, so that a client can
+// resume after the last block it received without missing any event.
+// Rejections are only streamed live, as rejected transactions are not part of
+// any block.
+type eventStream struct {
+	server     *ServerOpenchain
+	interests  []*pb.Interest
+	wantBlocks bool
+	sub        *producer.Subscription
+}
+
+// newEventStream subscribes to the events of the event hub needed to stream
+// the events matching the interests
+func newEventStream(server *ServerOpenchain, interests []*pb.Interest) (*eventStream, error) {
+	stream := &eventStream{server: server, interests: interests}
+
+	// Block events are always needed, as chaincode events are taken from the
+	// blocks that commit them
+	subscribed := []*pb.Interest{{EventType: pb.EventType_BLOCK}}
+	for _, interest := range interests {
+		switch interest.EventType {
+		case pb.EventType_BLOCK:
+			stream.wantBlocks = true
+		case pb.EventType_REJECTION:
+			subscribed = append(subscribed, interest)
+		}
+	}
+
+	sub, err := producer.Subscribe(subscribed, eventBufferSize)
+	if err != nil {
+		return nil, err
+	}
+	stream.sub = sub
+	return stream, nil
+}
+
+// close releases the subscription of the stream
+func (stream *eventStream) close() {
+	stream.sub.Close()
+}
+
+// run sends the events to the client until it goes away, falls too far behind
+// or cannot be sent to. If from is set, the events of the blocks committed
+// after block from are sent first.
+func (stream *eventStream) run(from *uint64, out eventWriter, closed <-chan bool) error {
+	// The subscription was made before reading the blockchain, so no block is
+	// missed between the two. nextBlock is the first block that was not sent,
+	// and the events of the blocks before it are skipped.
+	var nextBlock uint64
+	if from != nil {
+		count, err := stream.server.GetBlockCount(context.Background(), &empty.Empty{})
+		if err != nil {
+			return err
+		}
+		for nextBlock = *from + 1; nextBlock < count.Count; nextBlock++ {
+			block, err := stream.server.GetBlockByNumber(context.Background(), &pb.BlockNumber{Number: nextBlock})
+			if err != nil {
+				return err
+			}
+			if err := out.writeBlockEvents(nextBlock, stream.getBlockEvents(nextBlock, block)); err != nil {
+				return err
+			}
+		}
+	}
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		var err error
+		select {
+		case e := <-stream.sub.Events():
+			switch event := e.Event.(type) {
+			case *pb.Event_Block:
+				var blockNumber uint64
+				blockNumber, err = stream.server.GetBlockNumberOfBlockEvent(context.Background(), event.Block)
+				if err == nil && blockNumber >= nextBlock {
+					nextBlock = blockNumber + 1
+					err = out.writeBlockEvents(blockNumber, stream.getBlockEvents(blockNumber, event.Block))
+				}
+			case *pb.Event_Rejection:
+				err = out.writeEvent(&eventResult{Type: "rejection", Rejection: event.Rejection})
+			}
+		case <-stream.sub.Overflow():
+			err = fmt.Errorf("Client fell more than %d events behind", eventBufferSize)
+		case <-keepAlive.C:
+			err = out.keepAlive()
+		case <-closed:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// getBlockEvents returns the events of a committed block that the client is
+// interested in
+func (stream *eventStream) getBlockEvents(blockNumber uint64, block *pb.Block) []*eventResult {
+	var events []*eventResult
+	if stream.wantBlocks {
+		events = append(events, &eventResult{Type: "block", BlockNumber: &blockNumber, Block: block})
+	}
+	for _, chaincodeEvent := range block.GetNonHashData().GetChaincodeEvents() {
+		if stream.isInterestedIn(chaincodeEvent) {
+			events = append(events, &eventResult{Type: "chaincode", BlockNumber: &blockNumber, ChaincodeEvent: chaincodeEvent})
+		}
+	}
+	return events
+}
+
+// isInterestedIn matches a chaincode event against the interests the way the
+// event hub does. An interest without event name matches all the events of
+// its chaincode.
+func (stream *eventStream) isInterestedIn(chaincodeEvent *pb.ChaincodeEvent) bool {
+	// transactions that did not emit an event have an empty one
+	if chaincodeEvent.ChaincodeID == "" {
+		return false
+	}
+	for _, interest := range stream.interests {
+		chaincodeReg := interest.GetChaincodeRegInfo()
+		if chaincodeReg != nil && chaincodeReg.ChaincodeID == chaincodeEvent.ChaincodeID &&
+			(chaincodeReg.EventName == "" || chaincodeReg.EventName == chaincodeEvent.EventName) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEvents streams the events matching the interest query parameters as
+// server-sent events or, if the client asks to upgrade the connection, over a
+// WebSocket. A client that reconnects with the number of the last block it
+// received, in the from query parameter or the Last-Event-ID header, first
+// receives the events of the blocks committed since.
+func (s *ServerOpenchainREST) GetEvents(rw web.ResponseWriter, req *web.Request) {
+	encoder := json.NewEncoder(rw)
+
+	query := req.URL.Query()
+	interests, err := parseInterestQueryParams(query)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(restResult{Error: err.Error()})
+		return
+	}
+
+	// Browsers reconnect to the URL they first connected to, so the
+	// Last-Event-ID header takes precedence over the from query parameter
+	var from *uint64
+	if lastEventID := req.Header.Get("Last-Event-ID"); lastEventID != "" {
+		blockNumber, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(restResult{Error: "Header Last-Event-ID must be a block number."})
+			return
+		}
+		from = &blockNumber
+	} else if query.Get("from") != "" {
+		blockNumber, err := parseUintQueryParam(query, "from", 0)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			encoder.Encode(restResult{Error: err.Error()})
+			return
+		}
+		from = &blockNumber
+	}
+
+	stream, err := newEventStream(s.server, interests)
+	if err != nil {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		encoder.Encode(restResult{Error: fmt.Sprintf("Error subscribing to events: %s.", err)})
+		restLogger.Errorf("Error subscribing to events: %s", err)
+		return
+	}
+	defer stream.close()
+
+	if strings.EqualFold(req.Header.Get("Upgrade"), "websocket") {
+		websocket.Server{Handler: func(ws *websocket.Conn) {
+			// Messages from the client are ignored. Reading them tells when
+			// the client goes away.
+			closed := make(chan bool)
+			go func() {
+				var message []byte
+				for websocket.Message.Receive(ws, &message) == nil {
+				}
+				close(closed)
+			}()
+			if err := stream.run(from, &webSocketEventWriter{ws: ws}, closed); err != nil {
+				restLogger.Warningf("Closing event stream: %s", err)
+			}
+		}}.ServeHTTP(rw, req.Request)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.WriteHeader(http.StatusOK)
+	rw.Flush()
+	if err := stream.run(from, &sseEventWriter{rw: rw}, rw.CloseNotify()); err != nil {
+		restLogger.Warningf("Closing event stream: %s", err)
+	}
+}
@@ -16,7 +16,26 @@ limitations under the License.
 
 package rest
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+const (
+	// defaultPageSize is the number of items returned by a paged request that
+	// does not set the limit query parameter
+	defaultPageSize = 10
+
+	// maxPageSize is the largest number of items returned by a paged request
+	maxPageSize = 100
+)
 
 // isJSON is a helper function to determine if a given string is proper JSON.
 func isJSON(s string) bool {
@@ -54,3 +73,99 @@ func formatRPCResponse(res rpcResult, id *rpcID) rpcResponse {
 
 	return response
 }
+
+// parseUintQueryParam returns the value of an integer query parameter, or
+// defaultValue if the parameter is not set.
+func parseUintQueryParam(query url.Values, name string, defaultValue uint64) (uint64, error) {
+	param := query.Get(name)
+	if param == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Query parameter %s must be an integer (uint64).", name)
+	}
+	return value, nil
+}
+
+// parseLimitQueryParam returns the page size requested with the limit query
+// parameter. Requests for more than maxPageSize items get maxPageSize items.
+func parseLimitQueryParam(query url.Values) (int, error) {
+	limit, err := parseUintQueryParam(query, "limit", defaultPageSize)
+	if err != nil {
+		return 0, err
+	}
+	if limit == 0 {
+		return 0, errors.New("Query parameter limit must be greater than zero.")
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+	return int(limit), nil
+}
+
+// parseFieldsQueryParam parses a comma separated list of field names. It
+// returns nil if no field is selected.
+func parseFieldsQueryParam(query url.Values, validFields map[string]bool) (map[string]bool, error) {
+	var fields map[string]bool
+	for _, field := range strings.Split(query.Get("fields"), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !validFields[field] {
+			return nil, fmt.Errorf("Unknown field %s.", field)
+		}
+		if fields == nil {
+			fields = make(map[string]bool)
+		}
+		fields[field] = true
+	}
+	return fields, nil
+}
+
+// parseInterestQueryParams parses the interest query parameters of an event
+// stream. Each is block, rejection, chaincode:<chaincodeID> for all the events
+// of a chaincode or chaincode:<chaincodeID>:<eventName> for one of them.
+func parseInterestQueryParams(query url.Values) ([]*pb.Interest, error) {
+	var interests []*pb.Interest
+	seen := make(map[string]bool)
+	for _, param := range query["interest"] {
+		if seen[param] {
+			continue
+		}
+		seen[param] = true
+		parts := strings.SplitN(param, ":", 3)
+		switch {
+		case param == "block":
+			interests = append(interests, &pb.Interest{EventType: pb.EventType_BLOCK})
+		case param == "rejection":
+			interests = append(interests, &pb.Interest{EventType: pb.EventType_REJECTION})
+		case parts[0] == "chaincode" && len(parts) > 1 && parts[1] != "":
+			chaincodeReg := &pb.ChaincodeReg{ChaincodeID: parts[1]}
+			if len(parts) == 3 {
+				chaincodeReg.EventName = parts[2]
+			}
+			interests = append(interests, &pb.Interest{EventType: pb.EventType_CHAINCODE, RegInfo: &pb.Interest_ChaincodeRegInfo{ChaincodeRegInfo: chaincodeReg}})
+		default:
+			return nil, errors.New("Query parameter interest must be block, rejection, chaincode:<chaincodeID> or chaincode:<chaincodeID>:<eventName>.")
+		}
+	}
+	if len(interests) == 0 {
+		return nil, errors.New("At least one interest query parameter is required.")
+	}
+	return interests, nil
+}
+
+// getJSONFieldNames returns the names of the fields of a struct type when it
+// is encoded to JSON.
+func getJSONFieldNames(structType reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < structType.NumField(); i++ {
+		name := strings.Split(structType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -36,6 +36,11 @@ type EventsClient struct {
 	regTimeout  time.Duration
 	stream      ehpb.Events_ChatClient
 	adapter     EventAdapter
+
+	//set by EnableReplay
+	consumerID string
+	replay     bool
+	startBlock uint64
 }
 
 //NewEventsClient Returns a new grpc.ClientConn to the configured local PEER.
@@ -48,7 +53,18 @@ func NewEventsClient(peerAddress string, regTimeout time.Duration, adapter Event
 		regTimeout = 60 * time.Second
 		err = fmt.Errorf("regTimeout > 60, setting to 60 sec")
 	}
-	return &EventsClient{sync.RWMutex{}, peerAddress, regTimeout, nil, adapter}, err
+	return &EventsClient{peerAddress: peerAddress, regTimeout: regTimeout, adapter: adapter}, err
+}
+
+//EnableReplay makes the client receive the events of the blocks committed from
+//startBlock before the live events, and acknowledge the events it processed
+//as consumerID. A client that starts again with the same consumerID resumes
+//after the last event it acknowledged rather than from startBlock. Call
+//before Start.
+func (ec *EventsClient) EnableReplay(consumerID string, startBlock uint64) {
+	ec.consumerID = consumerID
+	ec.replay = true
+	ec.startBlock = startBlock
 }
 
 //newEventsClientConnectionWithAddress Returns a new grpc.ClientConn to the configured local PEER.
@@ -75,10 +91,23 @@ func (ec *EventsClient) RegisterAsync(ies []*ehpb.Interest) error {
 	return err
 }
 
+// Ack - acknowledges a BLOCK or CHAINCODE event and the ones before it so that
+// they are not replayed again
+func (ec *EventsClient) Ack(event *ehpb.Event) error {
+	emsg := &ehpb.Event{Event: &ehpb.Event_Ack{Ack: &ehpb.Ack{BlockNumber: event.BlockNumber, EventIndex: event.EventIndex}}}
+	var err error
+	if err = ec.send(emsg); err != nil {
+		err = fmt.Errorf("error on ack send %s\n", err)
+	}
+	return err
+}
+
 // register - registers interest in a event
 func (ec *EventsClient) register(ies []*ehpb.Interest) error {
+	emsg := &ehpb.Event{Event: &ehpb.Event_Register{Register: &ehpb.Register{Events: ies, ConsumerID: ec.consumerID, Replay: ec.replay, StartBlock: ec.startBlock}}}
 	var err error
-	if err = ec.RegisterAsync(ies); err != nil {
+	if err = ec.send(emsg); err != nil {
+		fmt.Printf("error on Register send %s\n", err)
 		return err
 	}
 
@@ -188,6 +217,11 @@ func (ec *EventsClient) processEvents() error {
 			if !cont {
 				return err
 			}
+			if ec.consumerID != "" && (in.GetBlock() != nil || in.GetChaincodeEvent() != nil) {
+				if err := ec.Ack(in); err != nil {
+					return err
+				}
+			}
 		}
 	}
 }
@@ -245,6 +245,147 @@ func TestUnregister(t *testing.T) {
 
 }
 
+func TestSubscription(t *testing.T) {
+	sub, err := producer.Subscribe([]*ehpb.Interest{&ehpb.Interest{EventType: ehpb.EventType_BLOCK}}, 1)
+	if err != nil {
+		t.Fatalf("Error subscribing %s", err)
+	}
+	defer sub.Close()
+
+	//the second block overflows the buffer of one event
+	for i := 0; i < 2; i++ {
+		if err = producer.Send(createTestBlock()); err != nil {
+			t.Fatalf("Error sending message %s", err)
+		}
+	}
+
+	select {
+	case <-sub.Overflow():
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out on overflow")
+	}
+	select {
+	case e := <-sub.Events():
+		if e.GetBlock() == nil {
+			t.Fatalf("expected a block event, got %v", e)
+		}
+	default:
+		t.Fatalf("expected the first block event to be buffered")
+	}
+	select {
+	case e := <-sub.Events():
+		t.Fatalf("should NOT have received %v after overflowing", e)
+	default:
+	}
+}
+
+type replayBlocks struct {
+	sync.Mutex
+	blocks []*ehpb.Block
+}
+
+func (r *replayBlocks) GetBlockchainSize() uint64 {
+	r.Lock()
+	defer r.Unlock()
+	return uint64(len(r.blocks))
+}
+
+func (r *replayBlocks) GetBlockByNumber(blockNumber uint64) (*ehpb.Block, error) {
+	r.Lock()
+	defer r.Unlock()
+	if blockNumber >= uint64(len(r.blocks)) {
+		return nil, fmt.Errorf("no block %d", blockNumber)
+	}
+	return r.blocks[blockNumber], nil
+}
+
+func (r *replayBlocks) add(eventNames ...string) {
+	r.Lock()
+	defer r.Unlock()
+	block := &ehpb.Block{NonHashData: &ehpb.NonHashData{}}
+	for _, name := range eventNames {
+		//transactions without event have an empty one
+		ccEvent := &ehpb.ChaincodeEvent{}
+		if name != "" {
+			ccEvent = &ehpb.ChaincodeEvent{ChaincodeID: "replaycc", EventName: name}
+		}
+		block.NonHashData.ChaincodeEvents = append(block.NonHashData.ChaincodeEvents, ccEvent)
+	}
+	r.blocks = append(r.blocks, block)
+}
+
+type replayAdapter struct {
+	events chan *ehpb.Event
+}
+
+func (a *replayAdapter) GetInterestedEvents() ([]*ehpb.Interest, error) {
+	return []*ehpb.Interest{
+		&ehpb.Interest{EventType: ehpb.EventType_CHAINCODE, RegInfo: &ehpb.Interest_ChaincodeRegInfo{ChaincodeRegInfo: &ehpb.ChaincodeReg{ChaincodeID: "replaycc"}}},
+	}, nil
+}
+
+func (a *replayAdapter) Recv(msg *ehpb.Event) (bool, error) {
+	a.events <- msg
+	return true, nil
+}
+
+func (a *replayAdapter) Disconnected(err error) {
+}
+
+func expectChaincodeEvents(t *testing.T, a *replayAdapter, expected ...string) {
+	for _, name := range expected {
+		select {
+		case e := <-a.events:
+			if e.GetChaincodeEvent() == nil || e.GetChaincodeEvent().EventName != name {
+				t.Fatalf("expected chaincode event %s, got %v", name, e)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for chaincode event %s", name)
+		}
+	}
+	select {
+	case e := <-a.events:
+		t.Fatalf("unexpected event %v", e)
+	case <-time.After(time.Second):
+	}
+}
+
+func TestReplay(t *testing.T) {
+	blocks := &replayBlocks{}
+	blocks.add()
+	blocks.add("b1e1", "", "b1e3")
+	blocks.add("b2e1")
+	producer.EnableReplay(blocks, nil)
+
+	a := &replayAdapter{events: make(chan *ehpb.Event, 10)}
+	client, _ := consumer.NewEventsClient(peerAddress, 5*time.Second, a)
+	client.EnableReplay("replayer", 1)
+	if err := client.Start(); err != nil {
+		t.Fatalf("could not start chat %s", err)
+	}
+
+	//the events of blocks 1 and 2 are replayed before the live events
+	expectChaincodeEvents(t, a, "b1e1", "b1e3", "b2e1")
+	live := producer.CreateChaincodeEvent(&ehpb.ChaincodeEvent{ChaincodeID: "replaycc", EventName: "b3e1"})
+	live.BlockNumber = 3
+	live.EventIndex = 1
+	if err := producer.Send(live); err != nil {
+		t.Fatalf("Error sending message %s", err)
+	}
+	expectChaincodeEvents(t, a, "b3e1")
+	client.Stop()
+
+	//the consumer resumes after the last event it acknowledged
+	blocks.add("b3e1", "b3e2")
+	client, _ = consumer.NewEventsClient(peerAddress, 5*time.Second, a)
+	client.EnableReplay("replayer", 0)
+	if err := client.Start(); err != nil {
+		t.Fatalf("could not start chat %s", err)
+	}
+	defer client.Stop()
+	expectChaincodeEvents(t, a, "b3e2")
+}
+
 func BenchmarkMessages(b *testing.B) {
 	numMessages := 10000
 
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package persist
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/db"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+// AckStore keeps the positions acknowledged by the event hub consumers in the
+// Persist column family of the database, so that they survive restarts
+type AckStore struct{}
+
+// GetAck returns the last position acknowledged by a consumer, or nil if it
+// did not acknowledge any event
+func (s *AckStore) GetAck(consumerID string) (*pb.Ack, error) {
+	db := db.GetDBHandle()
+	raw, err := db.Get(db.PersistCF, []byte("eventhub.ack."+consumerID))
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	ack := &pb.Ack{}
+	if err := proto.Unmarshal(raw, ack); err != nil {
+		return nil, err
+	}
+	return ack, nil
+}
+
+// PutAck stores the last position acknowledged by a consumer
+func (s *AckStore) PutAck(consumerID string, ack *pb.Ack) error {
+	raw, err := proto.Marshal(ack)
+	if err != nil {
+		return err
+	}
+	db := db.GetDBHandle()
+	return db.Put(db.PersistCF, []byte("eventhub.ack."+consumerID), raw)
+}
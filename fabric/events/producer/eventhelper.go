@@ -17,14 +17,56 @@ limitations under the License.
 package producer
 
 import (
+	"github.com/golang/protobuf/proto"
 	ehpb "github.com/hyperledger/fabric/protos"
 )
 
-//CreateBlockEvent creates a Event from a Block
+//CreateBlockEvent creates a Event from a Block. The code packages of the
+//deploy and upgrade transactions of the block are removed.
 func CreateBlockEvent(te *ehpb.Block) *ehpb.Event {
+	removeCodePackages(te)
 	return &ehpb.Event{Event: &ehpb.Event_Block{Block: te}}
 }
 
+//removeCodePackages removes the payload from deploy and upgrade transactions.
+//This is done to make block events more lightweight as the payload for these
+//types of transactions can be very large.
+func removeCodePackages(block *ehpb.Block) {
+	for _, transaction := range block.GetTransactions() {
+		if transaction.Type == ehpb.Transaction_CHAINCODE_DEPLOY {
+			deploymentSpec := &ehpb.ChaincodeDeploymentSpec{}
+			err := proto.Unmarshal(transaction.Payload, deploymentSpec)
+			if err != nil {
+				producerLogger.Errorf("Error unmarshalling deployment transaction for block event: %s", err)
+				continue
+			}
+			deploymentSpec.CodePackage = nil
+			deploymentSpecBytes, err := proto.Marshal(deploymentSpec)
+			if err != nil {
+				producerLogger.Errorf("Error marshalling deployment transaction for block event: %s", err)
+				continue
+			}
+			transaction.Payload = deploymentSpecBytes
+		} else if transaction.Type == ehpb.Transaction_CHAINCODE_UPGRADE {
+			upgradeSpec := &ehpb.ChaincodeUpgradeSpec{}
+			err := proto.Unmarshal(transaction.Payload, upgradeSpec)
+			if err != nil {
+				producerLogger.Errorf("Error unmarshalling upgrade transaction for block event: %s", err)
+				continue
+			}
+			if upgradeSpec.DeploymentSpec != nil {
+				upgradeSpec.DeploymentSpec.CodePackage = nil
+			}
+			upgradeSpecBytes, err := proto.Marshal(upgradeSpec)
+			if err != nil {
+				producerLogger.Errorf("Error marshalling upgrade transaction for block event: %s", err)
+				continue
+			}
+			transaction.Payload = upgradeSpecBytes
+		}
+	}
+}
+
 //CreateChaincodeEvent creates a Event from a ChaincodeEvent
 func CreateChaincodeEvent(te *ehpb.ChaincodeEvent) *ehpb.Event {
 	return &ehpb.Event{Event: &ehpb.Event_ChaincodeEvent{ChaincodeEvent: te}}
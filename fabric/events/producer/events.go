@@ -203,6 +203,11 @@ type eventProcessor struct {
 	//if 0, if buffer full, will block and guarantee the event will be sent out
 	//if > 0, if buffer full, blocks till timeout
 	timeout int
+
+	//blocks the events are replayed from and acknowledgements of the
+	//consumers, set by EnableReplay
+	blocks BlockSource
+	acks   AckStore
 }
 
 //global eventProcessor singleton created by initializeEvents. Openchain producers
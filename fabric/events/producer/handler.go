@@ -19,16 +19,31 @@ package producer
 import (
 	"fmt"
 	"strconv"
+	"sync"
 
 	pb "github.com/hyperledger/fabric/protos"
 )
 
+//eventStream is the stream a handler sends events to, either a gRPC Chat
+//stream or a Subscription
+type eventStream interface {
+	Send(*pb.Event) error
+}
+
 type handler struct {
-	ChatStream       pb.Events_ChatServer
+	sync.Mutex
+	ChatStream       eventStream
 	interestedEvents map[string]*pb.Interest
+
+	//consumerID identifies the consumer for acknowledgements
+	consumerID string
+
+	//live events are held back in pending while past events are replayed
+	replaying bool
+	pending   []*pb.Event
 }
 
-func newEventHandler(stream pb.Events_ChatServer) (*handler, error) {
+func newEventHandler(stream eventStream) (*handler, error) {
 	d := &handler{
 		ChatStream: stream,
 	}
@@ -96,31 +111,62 @@ func (d *handler) deregisterAll() {
 // HandleMessage handles the Openchain messages for the Peer.
 func (d *handler) HandleMessage(msg *pb.Event) error {
 	//producerLogger.Debug("Handling Event")
+	var replayStart *position
 	switch msg.Event.(type) {
 	case *pb.Event_Register:
 		eventsObj := msg.GetRegister()
 		if err := d.register(eventsObj.Events); err != nil {
 			return fmt.Errorf("Could not register events %s", err)
 		}
+		if eventsObj.ConsumerID != "" {
+			d.consumerID = eventsObj.ConsumerID
+		}
+		if eventsObj.Replay {
+			var err error
+			if replayStart, err = d.startReplay(eventsObj); err != nil {
+				return fmt.Errorf("Could not replay events %s", err)
+			}
+		}
 	case *pb.Event_Unregister:
 		eventsObj := msg.GetUnregister()
 		if err := d.deregister(eventsObj.Events); err != nil {
 			return fmt.Errorf("Could not unregister events %s", err)
 		}
+	case *pb.Event_Ack:
+		if err := d.ack(msg.GetAck()); err != nil {
+			return fmt.Errorf("Could not acknowledge events %s", err)
+		}
+		//acknowledgements are not answered
+		return nil
 	case nil:
 	default:
 		return fmt.Errorf("Invalide type from client %T", msg.Event)
 	}
 	//TODO return supported events.. for now just return the received msg
-	if err := d.ChatStream.Send(msg); err != nil {
+	if err := d.send(msg); err != nil {
 		return fmt.Errorf("Error sending response to %v:  %s", msg, err)
 	}
 
+	if replayStart != nil {
+		return d.replay(replayStart)
+	}
 	return nil
 }
 
+func (d *handler) send(msg *pb.Event) error {
+	d.Lock()
+	defer d.Unlock()
+	return d.ChatStream.Send(msg)
+}
+
 // SendMessage sends a message to the remote PEER through the stream
 func (d *handler) SendMessage(msg *pb.Event) error {
+	d.Lock()
+	defer d.Unlock()
+	if d.replaying {
+		d.pending = append(d.pending, msg)
+		return nil
+	}
 	err := d.ChatStream.Send(msg)
 	if err != nil {
 		return fmt.Errorf("Error Sending message through ChatStream: %s", err)
@@ -0,0 +1,222 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"fmt"
+	"sync"
+
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+//---- event replay ----
+//
+//A consumer that registers with replay first receives the BLOCK and CHAINCODE
+//events of the committed blocks, rebuilt from the blocks and the chaincode
+//events stored with them, then the live events. Each of these events carries
+//its position, and consumers with a consumerID acknowledge the positions they
+//processed so that they resume after them when they register again.
+
+//BlockSource gives access to the committed blocks events are replayed from
+type BlockSource interface {
+	GetBlockchainSize() uint64
+	GetBlockByNumber(blockNumber uint64) (*pb.Block, error)
+}
+
+//AckStore keeps the last position acknowledged by each consumer
+type AckStore interface {
+	//GetAck returns nil if the consumer did not acknowledge any event
+	GetAck(consumerID string) (*pb.Ack, error)
+	PutAck(consumerID string, ack *pb.Ack) error
+}
+
+//EnableReplay lets consumers register with replay. Events are replayed from
+//the blocks of blocks, and the acknowledgements of the consumers are kept in
+//acks, or in memory if acks is nil.
+func EnableReplay(blocks BlockSource, acks AckStore) {
+	if gEventProcessor == nil {
+		return
+	}
+	if acks == nil {
+		acks = &memAckStore{acks: make(map[string]*pb.Ack)}
+	}
+
+	gEventProcessor.Lock()
+	defer gEventProcessor.Unlock()
+	gEventProcessor.blocks = blocks
+	gEventProcessor.acks = acks
+}
+
+func getReplaySources() (BlockSource, AckStore) {
+	gEventProcessor.RLock()
+	defer gEventProcessor.RUnlock()
+	return gEventProcessor.blocks, gEventProcessor.acks
+}
+
+//memAckStore keeps the acknowledgements in memory, until the peer stops
+type memAckStore struct {
+	sync.Mutex
+	acks map[string]*pb.Ack
+}
+
+func (s *memAckStore) GetAck(consumerID string) (*pb.Ack, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.acks[consumerID], nil
+}
+
+func (s *memAckStore) PutAck(consumerID string, ack *pb.Ack) error {
+	s.Lock()
+	defer s.Unlock()
+	s.acks[consumerID] = ack
+	return nil
+}
+
+//position is the position of a BLOCK or CHAINCODE event
+type position struct {
+	blockNumber uint64
+	eventIndex  uint64
+}
+
+//isReplayable tells whether an event is one that is replayed, and so has a
+//position
+func isReplayable(e *pb.Event) bool {
+	return e.GetBlock() != nil || e.GetChaincodeEvent() != nil
+}
+
+//startReplay holds back the live events for the handler until the replay
+//requested by a registration is done, and returns the position of the first
+//event to replay
+func (d *handler) startReplay(reg *pb.Register) (*position, error) {
+	blocks, acks := getReplaySources()
+	if blocks == nil {
+		return nil, fmt.Errorf("event replay is not enabled")
+	}
+
+	start := &position{blockNumber: reg.StartBlock}
+	if d.consumerID != "" {
+		ack, err := acks.GetAck(d.consumerID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading acknowledgement of consumer %s: %s", d.consumerID, err)
+		}
+		if ack != nil {
+			start = &position{blockNumber: ack.BlockNumber, eventIndex: ack.EventIndex + 1}
+		}
+	}
+
+	d.Lock()
+	d.replaying = true
+	d.Unlock()
+	return start, nil
+}
+
+//replay sends the events of the blocks committed from position start, then
+//the live events held back meanwhile that were not replayed
+func (d *handler) replay(start *position) error {
+	blocks, _ := getReplaySources()
+
+	//the interests were registered before reading the size of the blockchain,
+	//so the events of the blocks that follow are held back
+	end := blocks.GetBlockchainSize()
+	err := d.replayBlocks(blocks, start, end)
+
+	d.Lock()
+	defer d.Unlock()
+	pending := d.pending
+	d.pending = nil
+	d.replaying = false
+	if err != nil {
+		return fmt.Errorf("Error replaying events: %s", err)
+	}
+	for _, e := range pending {
+		if isReplayable(e) && e.BlockNumber < end {
+			continue
+		}
+		if err := d.ChatStream.Send(e); err != nil {
+			return fmt.Errorf("Error Sending message through ChatStream: %s", err)
+		}
+	}
+	producerLogger.Debugf("replayed events from block %d, event %d to block %d", start.blockNumber, start.eventIndex, end)
+	return nil
+}
+
+func (d *handler) replayBlocks(blocks BlockSource, start *position, end uint64) error {
+	for blockNumber := start.blockNumber; blockNumber < end; blockNumber++ {
+		block, err := blocks.GetBlockByNumber(blockNumber)
+		if err != nil {
+			return err
+		}
+		for _, e := range d.getBlockEvents(blockNumber, block) {
+			if blockNumber == start.blockNumber && e.EventIndex < start.eventIndex {
+				continue
+			}
+			if err := d.ChatStream.Send(e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//getBlockEvents rebuilds the events of a committed block the handler is
+//interested in
+func (d *handler) getBlockEvents(blockNumber uint64, block *pb.Block) []*pb.Event {
+	var events []*pb.Event
+	if _, ok := d.interestedEvents[getInterestKey(pb.Interest{EventType: pb.EventType_BLOCK})]; ok {
+		e := CreateBlockEvent(block)
+		e.BlockNumber = blockNumber
+		events = append(events, e)
+	}
+	for i, ccEvent := range block.GetNonHashData().GetChaincodeEvents() {
+		if d.isInterestedIn(ccEvent) {
+			e := CreateChaincodeEvent(ccEvent)
+			e.BlockNumber = blockNumber
+			e.EventIndex = uint64(i + 1)
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+//isInterestedIn matches a chaincode event against the interests of the
+//handler like chaincodeHandlerList does
+func (d *handler) isInterestedIn(ccEvent *pb.ChaincodeEvent) bool {
+	//empty chaincode events are stored for transactions without event
+	if ccEvent.ChaincodeID == "" {
+		return false
+	}
+	for _, interest := range d.interestedEvents {
+		ccReg := interest.GetChaincodeRegInfo()
+		if interest.EventType == pb.EventType_CHAINCODE && ccReg != nil && ccReg.ChaincodeID == ccEvent.ChaincodeID &&
+			(ccReg.EventName == "" || ccReg.EventName == ccEvent.EventName) {
+			return true
+		}
+	}
+	return false
+}
+
+//ack keeps the position acknowledged by the consumer
+func (d *handler) ack(ack *pb.Ack) error {
+	if d.consumerID == "" {
+		return fmt.Errorf("acknowledgement from a consumer without consumerID")
+	}
+	_, acks := getReplaySources()
+	if acks == nil {
+		return fmt.Errorf("event replay is not enabled")
+	}
+	return acks.PutAck(d.consumerID, ack)
+}
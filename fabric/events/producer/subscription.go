@@ -0,0 +1,108 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package producer
+
+import (
+	"fmt"
+	"sync"
+
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+//Subscription receives the events matching its interests in process, without
+//a Chat stream. It lets other servers, such as the REST server, bridge the
+//event hub to their own clients.
+//
+//Events are buffered and never block the event processor. When the buffer is
+//full the subscription overflows: the event is dropped, no further event is
+//delivered and the Overflow channel is closed so that the subscriber can close
+//the subscription and recover the events it missed.
+type Subscription struct {
+	sync.Mutex
+	handler    *handler
+	events     chan *pb.Event
+	overflow   chan struct{}
+	overflowed bool
+	closed     bool
+}
+
+//Subscribe registers the interests and returns a subscription buffering up to
+//bufferSize events. The subscription must be closed once no longer used.
+func Subscribe(interests []*pb.Interest, bufferSize int) (*Subscription, error) {
+	if gEventProcessor == nil {
+		return nil, fmt.Errorf("event hub is not running")
+	}
+	if len(interests) == 0 {
+		return nil, fmt.Errorf("no interest provided for subscribing")
+	}
+
+	sub := &Subscription{events: make(chan *pb.Event, bufferSize), overflow: make(chan struct{})}
+	h, err := newEventHandler(sub)
+	if err != nil {
+		return nil, err
+	}
+	sub.handler = h
+	for _, interest := range interests {
+		if err := registerHandler(interest, h); err != nil {
+			h.Stop()
+			return nil, err
+		}
+		h.interestedEvents[getInterestKey(*interest)] = interest
+	}
+	return sub, nil
+}
+
+//Events returns the channel the events of the subscription are delivered on
+func (sub *Subscription) Events() <-chan *pb.Event {
+	return sub.events
+}
+
+//Overflow returns a channel that is closed when the subscription overflows
+func (sub *Subscription) Overflow() <-chan struct{} {
+	return sub.overflow
+}
+
+//Close deregisters the interests of the subscription
+func (sub *Subscription) Close() {
+	//the lock is released before deregistering, as the event processor holds
+	//the lock of the handler lists while it calls Send
+	sub.Lock()
+	closed := sub.closed
+	sub.closed = true
+	sub.Unlock()
+	if !closed {
+		sub.handler.Stop()
+	}
+}
+
+//Send queues an event for the subscriber. It is called by the event processor
+//for the events matching the interests of the subscription.
+func (sub *Subscription) Send(e *pb.Event) error {
+	sub.Lock()
+	defer sub.Unlock()
+	if sub.overflowed || sub.closed {
+		return nil
+	}
+	select {
+	case sub.events <- e:
+		return nil
+	default:
+		sub.overflowed = true
+		close(sub.overflow)
+		return fmt.Errorf("subscription buffer full, dropping event")
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/events/consumer"
+	pb "github.com/hyperledger/fabric/protos"
+)
+
+// defaultEventNames are the events emitted by chaincode_fds and chaincode_sla
+const defaultEventNames = "FdsEntryCreated,FdsLedgerStatusChanged,FdsEntryDeleted,SlaContractSubmitted,SlaApprovalRequested,SlaContractClosed"
+
+// webhookEvent is the body POSTed to the webhook for every chaincode event
+type webhookEvent struct {
+	ChaincodeID string          `json:"chaincodeId"`
+	TxID        string          `json:"txId"`
+	EventName   string          `json:"eventName"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+type adapter struct {
+	cEvent       chan *pb.Event_ChaincodeEvent
+	chaincodeIDs []string
+	eventNames   map[string]bool
+}
+
+// GetInterestedEvents implements consumer.EventAdapter interface for registering interested events
+func (a *adapter) GetInterestedEvents() ([]*pb.Interest, error) {
+	interests := []*pb.Interest{}
+	for _, chaincodeID := range a.chaincodeIDs {
+		for eventName := range a.eventNames {
+			interests = append(interests, &pb.Interest{EventType: pb.EventType_CHAINCODE,
+				RegInfo: &pb.Interest_ChaincodeRegInfo{
+					ChaincodeRegInfo: &pb.ChaincodeReg{
+						ChaincodeID: chaincodeID,
+						EventName:   eventName}}})
+		}
+	}
+	return interests, nil
+}
+
+// Recv implements consumer.EventAdapter interface for receiving events
+func (a *adapter) Recv(msg *pb.Event) (bool, error) {
+	if o, e := msg.Event.(*pb.Event_ChaincodeEvent); e {
+		if a.eventNames[o.ChaincodeEvent.EventName] {
+			a.cEvent <- o
+		}
+		return true, nil
+	}
+	return false, fmt.Errorf("Receive unkown type event: %v", msg)
+}
+
+// Disconnected implements consumer.EventAdapter interface for disconnecting
+func (a *adapter) Disconnected(err error) {
+	fmt.Printf("Disconnected...exiting\n")
+	os.Exit(1)
+}
+
+func createEventClient(eventAddress string, chaincodeIDs []string, eventNames map[string]bool) *adapter {
+	var obcEHClient *consumer.EventsClient
+
+	adapter := &adapter{cEvent: make(chan *pb.Event_ChaincodeEvent), chaincodeIDs: chaincodeIDs, eventNames: eventNames}
+	obcEHClient, _ = consumer.NewEventsClient(eventAddress, 5, adapter)
+	if err := obcEHClient.Start(); err != nil {
+		fmt.Printf("could not start chat %s\n", err)
+		obcEHClient.Stop()
+		return nil
+	}
+
+	return adapter
+}
+
+// newWebhookEvent converts a chaincode event to the webhook body. Payloads
+// that are not JSON are sent as a JSON string.
+func newWebhookEvent(ce *pb.ChaincodeEvent) ([]byte, error) {
+	payload := json.RawMessage(ce.Payload)
+	var v interface{}
+	if json.Unmarshal(ce.Payload, &v) != nil {
+		quoted, err := json.Marshal(string(ce.Payload))
+		if err != nil {
+			return nil, err
+		}
+		payload = quoted
+	}
+	return json.Marshal(webhookEvent{ChaincodeID: ce.ChaincodeID, TxID: ce.TxID, EventName: ce.EventName, Payload: payload})
+}
+
+// postEvent POSTs body to the webhook, retrying with a linear backoff
+func postEvent(client *http.Client, webhookURL string, body []byte, retries int) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+		var resp *http.Response
+		resp, err = client.Post(webhookURL, "application/json", bytes.NewReader(body))
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode/100 == 2 {
+			return nil
+		}
+		err = fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return err
+}
+
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func main() {
+	var eventAddress string
+	var chaincodeIDList string
+	var eventNameList string
+	var webhookURL string
+	var retries int
+	flag.StringVar(&eventAddress, "events-address", "0.0.0.0:7053", "address of events server")
+	flag.StringVar(&chaincodeIDList, "events-from-chaincode", "", "comma separated chaincode IDs to listen to")
+	flag.StringVar(&eventNameList, "event-names", defaultEventNames, "comma separated chaincode event names to forward")
+	flag.StringVar(&webhookURL, "webhook-url", "http://localhost:3000/chaincode_events", "URL events are POSTed to")
+	flag.IntVar(&retries, "webhook-retries", 3, "number of retries when the webhook fails")
+	flag.Parse()
+
+	chaincodeIDs := splitList(chaincodeIDList)
+	if len(chaincodeIDs) == 0 {
+		fmt.Printf("-events-from-chaincode is required\n")
+		os.Exit(1)
+	}
+	eventNames := map[string]bool{}
+	for _, eventName := range splitList(eventNameList) {
+		eventNames[eventName] = true
+	}
+	if len(eventNames) == 0 {
+		fmt.Printf("-event-names must not be empty\n")
+		os.Exit(1)
+	}
+
+	fmt.Printf("Event Address: %s\n", eventAddress)
+	fmt.Printf("Webhook URL: %s\n", webhookURL)
+
+	a := createEventClient(eventAddress, chaincodeIDs, eventNames)
+	if a == nil {
+		fmt.Printf("Error creating event client\n")
+		return
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	for ce := range a.cEvent {
+		body, err := newWebhookEvent(ce.ChaincodeEvent)
+		if err != nil {
+			fmt.Printf("Could not encode chaincode event %v: %s\n", ce.ChaincodeEvent, err)
+			continue
+		}
+		if err = postEvent(client, webhookURL, body, retries); err != nil {
+			fmt.Printf("Could not post %s of transaction %s: %s\n", ce.ChaincodeEvent.EventName, ce.ChaincodeEvent.TxID, err)
+			continue
+		}
+		fmt.Printf("Posted %s of transaction %s\n", ce.ChaincodeEvent.EventName, ce.ChaincodeEvent.TxID)
+	}
+}
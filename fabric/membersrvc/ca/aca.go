@@ -20,12 +20,11 @@ import (
 	"encoding/asn1"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
 	"crypto/x509"
 
-	"database/sql"
-
 	"github.com/hyperledger/fabric/flogging"
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
@@ -45,13 +44,13 @@ var (
 // ACA is the attribute certificate authority.
 type ACA struct {
 	*CA
+	eca        *ECA
 	gRPCServer *grpc.Server
-}
 
-// ACAA serves the administrator GRPC interface of the ACA.
-//
-type ACAA struct {
-	aca *ACA
+	// Nonces of the admin requests, remembered until the requests are stale
+	started     time.Time
+	nonceMutex  sync.Mutex
+	adminNonces map[string]time.Time
 }
 
 //IsAttributeOID returns if the oid passed as parameter is or not linked with an attribute
@@ -69,13 +68,6 @@ func IsAttributeOID(oid asn1.ObjectIdentifier) bool {
 	return ACAAttribute[l-1] < oid[l-1]
 }
 
-func initializeACATables(db *sql.DB) error {
-	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS Attributes (row INTEGER PRIMARY KEY, id VARCHAR(64), affiliation VARCHAR(64), attributeName VARCHAR(64), validFrom DATETIME, validTo DATETIME,  attributeValue BLOB)"); err != nil {
-		return err
-	}
-	return nil
-}
-
 //AttributeOwner is the struct that contains the data related with the user who owns the attribute.
 type AttributeOwner struct {
 	id          string
@@ -209,20 +201,52 @@ func (attrPair *AttributePair) ToACAAttribute() *pb.ACAAttribute {
 	if attrPair.validFrom.IsZero() {
 		from = nil
 	} else {
-		from = &timestamp.Timestamp{Seconds: attrPair.validFrom.Unix(), Nanos: int32(attrPair.validFrom.UnixNano())}
+		from = &timestamp.Timestamp{Seconds: attrPair.validFrom.Unix(), Nanos: int32(attrPair.validFrom.Nanosecond())}
 	}
 	if attrPair.validTo.IsZero() {
 		to = nil
 	} else {
-		to = &timestamp.Timestamp{Seconds: attrPair.validTo.Unix(), Nanos: int32(attrPair.validTo.UnixNano())}
+		to = &timestamp.Timestamp{Seconds: attrPair.validTo.Unix(), Nanos: int32(attrPair.validTo.Nanosecond())}
 
 	}
 	return &pb.ACAAttribute{AttributeName: attrPair.attributeName, AttributeValue: attrPair.attributeValue, ValidFrom: from, ValidTo: to}
 }
 
+// toAttributeRecord converts the receiver to the format of the store.
+func (attrPair *AttributePair) toAttributeRecord() *AttributeRecord {
+	return &AttributeRecord{
+		ID:          attrPair.GetID(),
+		Affiliation: attrPair.GetAffiliation(),
+		Name:        attrPair.attributeName,
+		Value:       attrPair.attributeValue,
+		ValidFrom:   attrPair.validFrom,
+		ValidTo:     attrPair.validTo,
+	}
+}
+
+// toAttributeChangeRecord converts the receiver to a change of the audit trail
+// made by registrar.
+func (attrPair *AttributePair) toAttributeChangeRecord(registrar, action string) *AttributeChangeRecord {
+	return &AttributeChangeRecord{
+		Timestamp:   time.Now().Unix(),
+		Registrar:   registrar,
+		ID:          attrPair.GetID(),
+		Affiliation: attrPair.GetAffiliation(),
+		Name:        attrPair.attributeName,
+		Action:      action,
+		Value:       attrPair.attributeValue,
+		ValidFrom:   attrPair.validFrom,
+		ValidTo:     attrPair.validTo,
+	}
+}
+
+func newAttributePair(owner *AttributeOwner, record *AttributeRecord) *AttributePair {
+	return &AttributePair{owner, record.Name, record.Value, record.ValidFrom, record.ValidTo}
+}
+
 // NewACA sets up a new ACA.
 func NewACA() *ACA {
-	aca := &ACA{CA: NewCA("aca", initializeACATables)}
+	aca := &ACA{CA: NewCA("aca"), started: time.Now(), adminNonces: make(map[string]time.Time)}
 	flogging.LoggingInit("aca")
 	return aca
 }
@@ -283,50 +307,38 @@ func (aca *ACA) PopulateAttributes(attrs []*AttributePair) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	tx, dberr := aca.db.Begin()
-	if dberr != nil {
-		return dberr
-	}
 	for _, attr := range attrs {
 		acaLogger.Debugf("attr: %+v", attr)
-		if err := aca.populateAttribute(tx, attr); err != nil {
-			dberr = tx.Rollback()
-			if dberr != nil {
-				return dberr
-			}
+		if err := aca.populateAttribute(attr); err != nil {
 			return err
 		}
 	}
-	dberr = tx.Commit()
-	if dberr != nil {
-		return dberr
-	}
 	return nil
 }
 
-func (aca *ACA) populateAttribute(tx *sql.Tx, attr *AttributePair) error {
-	var count int
-	err := tx.QueryRow("SELECT count(row) AS cant FROM Attributes WHERE id=? AND affiliation =? AND attributeName =?",
-		attr.GetID(), attr.GetAffiliation(), attr.GetAttributeName()).Scan(&count)
+func (aca *ACA) populateAttribute(attr *AttributePair) error {
+	// Attributes changed through the admin service take precedence over the
+	// configuration
+	changes, err := aca.store.ReadAttributeChanges(attr.GetID(), attr.GetAffiliation())
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if change.Name == attr.GetAttributeName() {
+			return nil
+		}
+	}
 
+	record, err := aca.store.ReadAttribute(attr.GetID(), attr.GetAffiliation(), attr.GetAttributeName())
 	if err != nil {
 		return err
 	}
 
-	if count > 0 {
-		_, err = tx.Exec("UPDATE Attributes SET validFrom = ?, validTo = ?,  attributeValue = ? WHERE  id=? AND affiliation =? AND attributeName =? AND validFrom < ?",
-			attr.GetValidFrom(), attr.GetValidTo(), attr.GetAttributeValue(), attr.GetID(), attr.GetAffiliation(), attr.GetAttributeName(), attr.GetValidFrom())
-		if err != nil {
-			return err
-		}
-	} else {
-		_, err = tx.Exec("INSERT INTO Attributes (validFrom , validTo,  attributeValue, id, affiliation, attributeName) VALUES (?,?,?,?,?,?)",
-			attr.GetValidFrom(), attr.GetValidTo(), attr.GetAttributeValue(), attr.GetID(), attr.GetAffiliation(), attr.GetAttributeName())
-		if err != nil {
-			return err
-		}
+	// An attribute is only replaced by one becoming valid later
+	if record != nil && !record.ValidFrom.Before(attr.GetValidFrom()) {
+		return nil
 	}
-	return nil
+	return aca.store.PutAttribute(attr.toAttributeRecord(), nil)
 }
 
 func (aca *ACA) fetchAndPopulateAttributes(id, affiliation string) error {
@@ -343,31 +355,110 @@ func (aca *ACA) fetchAndPopulateAttributes(id, affiliation string) error {
 }
 
 func (aca *ACA) findAttribute(owner *AttributeOwner, attributeName string) (*AttributePair, error) {
-	var count int
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	record, err := aca.store.ReadAttribute(owner.GetID(), owner.GetAffiliation(), attributeName)
+	if err != nil || record == nil {
+		return nil, err
+	}
+
+	return newAttributePair(owner, record), nil
+}
+
+// readAttributeOwner returns the owner the attributes of the user id are stored
+// for, from the enrollment ID of the user.
+func (aca *ACA) readAttributeOwner(id string) (*AttributeOwner, error) {
+	user, err := aca.eca.readUser(id)
+	if err != nil {
+		return nil, err
+	}
+
+	id, affiliation, err := aca.parseEnrollID(user.EnrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	return &AttributeOwner{id, affiliation}, nil
+}
+
+// setAttribute adds or updates an attribute on behalf of registrar, and
+// records the change in the audit trail.
+func (aca *ACA) setAttribute(registrar string, attr *AttributePair) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if err := aca.store.PutAttribute(attr.toAttributeRecord(), attr.toAttributeChangeRecord(registrar, attributeSet)); err != nil {
+		return err
+	}
+
+	acaLogger.Infof("Attribute %s of %s [%s] changed by %s: %s", attr.GetAttributeName(), attr.GetID(), attr.GetAffiliation(), registrar, attributeSet)
+	return nil
+}
+
+// revokeAttribute removes an attribute on behalf of registrar, and records the
+// change in the audit trail.
+func (aca *ACA) revokeAttribute(registrar string, owner *AttributeOwner, attributeName string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
 
+	attr := &AttributePair{owner: owner, attributeName: attributeName}
+	found, err := aca.store.DeleteAttribute(owner.GetID(), owner.GetAffiliation(), attributeName, attr.toAttributeChangeRecord(registrar, attributeRevoke))
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New("Attribute " + attributeName + " not found.")
+	}
+
+	acaLogger.Infof("Attribute %s of %s [%s] changed by %s: %s", attributeName, owner.GetID(), owner.GetAffiliation(), registrar, attributeRevoke)
+	return nil
+}
+
+// readAttributes reads all the attributes of owner, including the expired
+// ones.
+func (aca *ACA) readAttributes(owner *AttributeOwner) ([]*AttributePair, error) {
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	err := aca.db.QueryRow("SELECT count(row) AS cant FROM Attributes WHERE id=? AND affiliation =? AND attributeName =?",
-		owner.GetID(), owner.GetAffiliation(), attributeName).Scan(&count)
+	records, err := aca.store.ReadAttributes(owner.GetID(), owner.GetAffiliation())
 	if err != nil {
 		return nil, err
 	}
 
-	if count == 0 {
-		return nil, nil
+	var attributes []*AttributePair
+	for _, record := range records {
+		attributes = append(attributes, newAttributePair(owner, record))
 	}
+	return attributes, nil
+}
 
-	var attName string
-	var attValue []byte
-	var validFrom, validTo time.Time
-	err = aca.db.QueryRow("SELECT attributeName, attributeValue, validFrom, validTo AS cant FROM Attributes WHERE id=? AND affiliation =? AND attributeName =?",
-		owner.GetID(), owner.GetAffiliation(), attributeName).Scan(&attName, &attValue, &validFrom, &validTo)
+// readAttributeChanges reads the audit trail of the attributes of owner, or of
+// the attribute attributeName only if it is not empty.
+func (aca *ACA) readAttributeChanges(owner *AttributeOwner, attributeName string) ([]*pb.ACAAttrChange, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	records, err := aca.store.ReadAttributeChanges(owner.GetID(), owner.GetAffiliation())
 	if err != nil {
 		return nil, err
 	}
 
-	return &AttributePair{owner, attName, attValue, validFrom, validTo}, nil
+	var changes []*pb.ACAAttrChange
+	for _, record := range records {
+		if attributeName != "" && record.Name != attributeName {
+			continue
+		}
+		attr := &AttributePair{owner, record.Name, record.Value, record.ValidFrom, record.ValidTo}
+		changes = append(changes, &pb.ACAAttrChange{
+			Ts:          &timestamp.Timestamp{Seconds: record.Timestamp},
+			Registrar:   &pb.Identity{Id: record.Registrar},
+			Owner:       &pb.Identity{Id: owner.GetID()},
+			Affiliation: owner.GetAffiliation(),
+			Action:      record.Action,
+			Attribute:   attr.ToACAAttribute(),
+		})
+	}
+	return changes, nil
 }
 
 func (aca *ACA) startACAP(srv *grpc.Server) {
@@ -375,10 +466,23 @@ func (aca *ACA) startACAP(srv *grpc.Server) {
 	acaLogger.Info("ACA PUBLIC gRPC API server started")
 }
 
+// startACAA registers the admin API on srv, which only carries TLS when
+// security.tls_enabled is set; it returns false when the API is not served.
+func (aca *ACA) startACAA(srv *grpc.Server) bool {
+	if !viper.GetBool("security.tls_enabled") {
+		acaLogger.Warning("ACA ADMIN gRPC API not started: it is only served over TLS [security.tls_enabled == false]")
+		return false
+	}
+	pb.RegisterACAAServer(srv, &ACAA{aca})
+	acaLogger.Info("ACA ADMIN gRPC API server started")
+	return true
+}
+
 // Start starts the ACA.
 func (aca *ACA) Start(srv *grpc.Server) {
 	acaLogger.Info("Staring ACA services...")
 	aca.startACAP(srv)
+	aca.startACAA(srv)
 	aca.gRPCServer = srv
 	acaLogger.Info("ACA services started")
 }
@@ -28,10 +28,13 @@ import (
 	"crypto/x509"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/core/crypto/primitives"
 	pb "github.com/hyperledger/fabric/membersrvc/protos"
+	"github.com/spf13/viper"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
 var identity = "test_user0"
@@ -129,26 +132,15 @@ func TestFetchAttributes_MultipleInvocations(t *testing.T) {
 }
 
 func readAttributesFromDB(id string, affiliation string) (map[string][]byte, int, error) {
-	var attributeName string
-	var attributeValue []byte
-
-	query := "SELECT attributeName, attributeValue FROM attributes WHERE id=? AND affiliation=?"
-
-	rows, err := aca.db.Query(query, id, affiliation)
+	attrs, err := aca.store.ReadAttributes(id, affiliation)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	defer rows.Close()
-
 	count := 0
 	attributesMap := make(map[string][]byte)
-	for rows.Next() {
-		err := rows.Scan(&attributeName, &attributeValue)
-		if err != nil {
-			return nil, 0, err
-		}
-		attributesMap[attributeName] = attributeValue
+	for _, attr := range attrs {
+		attributesMap[attr.Name] = attr.Value
 		count++
 	}
 
@@ -622,3 +614,135 @@ func contains(s []string, e string) bool {
 	}
 	return false
 }
+
+func TestSetAndRevokeAttribute(t *testing.T) {
+	owner, err := aca.readAttributeOwner(identity)
+	if err != nil {
+		t.Fatalf("Error reading attribute owner: %v", err)
+	}
+	if owner.GetAffiliation() != "bank_a" {
+		t.Fatalf("Expected affiliation bank_a, got %s", owner.GetAffiliation())
+	}
+
+	attr := &AttributePair{owner: owner, attributeName: "role", attributeValue: []byte("approver"), validFrom: time.Now().Add(-time.Hour)}
+	if err = aca.setAttribute("admin", attr); err != nil {
+		t.Fatalf("Error setting attribute: %v", err)
+	}
+
+	found, err := aca.findAttribute(owner, "role")
+	if err != nil {
+		t.Fatalf("Error reading attribute: %v", err)
+	}
+	if found == nil || string(found.GetAttributeValue()) != "approver" || !found.IsValidFor(time.Now()) {
+		t.Fatalf("Attribute should have been set.")
+	}
+
+	if err = aca.revokeAttribute("admin", owner, "role"); err != nil {
+		t.Fatalf("Error revoking attribute: %v", err)
+	}
+	if found, _ = aca.findAttribute(owner, "role"); found != nil {
+		t.Fatalf("Attribute should have been revoked.")
+	}
+	if err = aca.revokeAttribute("admin", owner, "role"); err == nil {
+		t.Fatalf("Revoking a missing attribute should fail.")
+	}
+
+	changes, err := aca.readAttributeChanges(owner, "role")
+	if err != nil {
+		t.Fatalf("Error reading attribute changes: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Action != attributeSet || changes[1].Action != attributeRevoke {
+		t.Fatalf("Expected the attribute to be set then revoked, got %v", changes)
+	}
+	if changes[0].Registrar.Id != "admin" || string(changes[0].Attribute.AttributeValue) != "approver" {
+		t.Fatalf("Unexpected audit trail entry %v", changes[0])
+	}
+}
+
+func TestSetAttributeOverridesConfiguration(t *testing.T) {
+	owner := &AttributeOwner{identity, "bank_a"}
+	attr := &AttributePair{owner: owner, attributeName: "business_unit", attributeValue: []byte("Marketing")}
+	if err := aca.setAttribute("admin", attr); err != nil {
+		t.Fatalf("Error setting attribute: %v", err)
+	}
+
+	if err := aca.fetchAndPopulateAttributes(owner.GetID(), owner.GetAffiliation()); err != nil {
+		t.Fatalf("Error fetching attributes: %v", err)
+	}
+
+	found, err := aca.findAttribute(owner, "business_unit")
+	if err != nil {
+		t.Fatalf("Error reading attribute: %v", err)
+	}
+	if found == nil || string(found.GetAttributeValue()) != "Marketing" {
+		t.Fatalf("The attribute set by the registrar should take precedence over the configuration.")
+	}
+}
+
+func TestSetAttributeNotSigned(t *testing.T) {
+	acaa := &ACAA{aca}
+
+	req := &pb.ACAAttrAdminReq{
+		Id:        &pb.Identity{Id: "admin"},
+		Owner:     &pb.Identity{Id: identity},
+		Attribute: &pb.ACAAttribute{AttributeName: "role", AttributeValue: []byte("approver")}}
+	if _, err := acaa.SetAttribute(context.Background(), req); err == nil {
+		t.Fatalf("Unsigned requests should be rejected.")
+	}
+}
+
+func TestCheckFreshness(t *testing.T) {
+	now, _ := ptypes.TimestampProto(time.Now())
+	if err := aca.checkFreshness("admin", nil, []byte("nonce-1")); err == nil {
+		t.Fatalf("Requests without timestamp should be rejected.")
+	}
+	if err := aca.checkFreshness("admin", now, nil); err == nil {
+		t.Fatalf("Requests without nonce should be rejected.")
+	}
+
+	stale, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	if err := aca.checkFreshness("admin", stale, []byte("nonce-1")); err == nil {
+		t.Fatalf("Stale requests should be rejected.")
+	}
+	future, _ := ptypes.TimestampProto(time.Now().Add(time.Hour))
+	if err := aca.checkFreshness("admin", future, []byte("nonce-1")); err == nil {
+		t.Fatalf("Requests signed ahead of the clock should be rejected.")
+	}
+	beforeStart, _ := ptypes.TimestampProto(aca.started.Add(-time.Second))
+	if err := aca.checkFreshness("admin", beforeStart, []byte("nonce-1")); err == nil {
+		t.Fatalf("Requests signed before the ACA started should be rejected.")
+	}
+
+	if err := aca.checkFreshness("admin", now, []byte("nonce-1")); err != nil {
+		t.Fatalf("Fresh request should be accepted: %v", err)
+	}
+	if err := aca.checkFreshness("admin", now, []byte("nonce-1")); err == nil {
+		t.Fatalf("Replayed requests should be rejected.")
+	}
+	if err := aca.checkFreshness("admin2", now, []byte("nonce-1")); err != nil {
+		t.Fatalf("Nonces of other registrars should not be rejected: %v", err)
+	}
+}
+
+func TestACAANotServedWithoutTLS(t *testing.T) {
+	tlsEnabled := viper.GetBool("security.tls_enabled")
+	defer viper.Set("security.tls_enabled", tlsEnabled)
+
+	viper.Set("security.tls_enabled", false)
+	srv := grpc.NewServer()
+	if aca.startACAA(srv) {
+		t.Fatalf("The ACA admin API should not be started without TLS.")
+	}
+	if _, ok := srv.GetServiceInfo()["protos.ACAA"]; ok {
+		t.Fatalf("The ACA admin API should not be served without TLS.")
+	}
+
+	viper.Set("security.tls_enabled", true)
+	srv = grpc.NewServer()
+	if !aca.startACAA(srv) {
+		t.Fatalf("The ACA admin API should be started with TLS.")
+	}
+	if _, ok := srv.GetServiceInfo()["protos.ACAA"]; !ok {
+		t.Fatalf("The ACA admin API should be served with TLS.")
+	}
+}
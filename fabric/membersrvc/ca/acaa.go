@@ -0,0 +1,230 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/op/go-logging"
+	"github.com/spf13/viper"
+	"golang.org/x/net/context"
+
+	pb "github.com/hyperledger/fabric/membersrvc/protos"
+)
+
+var acaaLogger = logging.MustGetLogger("acaa")
+
+// Actions recorded in the audit trail of the attributes.
+const (
+	attributeSet    = "set"
+	attributeRevoke = "revoke"
+)
+
+// ACAA serves the administrator GRPC interface of the ACA.
+//
+type ACAA struct {
+	aca *ACA
+}
+
+// SetAttribute adds an attribute to a user, or updates it if the user already
+// has it.
+//
+func (acaa *ACAA) SetAttribute(ctx context.Context, in *pb.ACAAttrAdminReq) (*pb.CAStatus, error) {
+	acaaLogger.Debug("gRPC ACAA:SetAttribute")
+
+	owner, err := acaa.checkRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Attribute == nil || in.Attribute.AttributeName == "" {
+		return nil, errors.New("Attribute name is missing.")
+	}
+
+	attr := &AttributePair{owner: owner, attributeName: in.Attribute.AttributeName, attributeValue: in.Attribute.AttributeValue}
+	if attr.validFrom, err = timestampToTime(in.Attribute.ValidFrom); err != nil {
+		return nil, err
+	}
+	if attr.validTo, err = timestampToTime(in.Attribute.ValidTo); err != nil {
+		return nil, err
+	}
+	if !attr.validTo.IsZero() && !attr.validTo.After(attr.validFrom) {
+		return nil, errors.New("Attribute expires before it becomes valid.")
+	}
+
+	if err = acaa.aca.setAttribute(in.Id.Id, attr); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
+}
+
+// RevokeAttribute removes an attribute of a user.
+//
+func (acaa *ACAA) RevokeAttribute(ctx context.Context, in *pb.ACAAttrAdminReq) (*pb.CAStatus, error) {
+	acaaLogger.Debug("gRPC ACAA:RevokeAttribute")
+
+	owner, err := acaa.checkRequest(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Attribute == nil || in.Attribute.AttributeName == "" {
+		return nil, errors.New("Attribute name is missing.")
+	}
+
+	if err = acaa.aca.revokeAttribute(in.Id.Id, owner, in.Attribute.AttributeName); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
+}
+
+// ReadAttributes reads the attributes of a user, including the ones that are
+// not valid yet or expired.
+//
+func (acaa *ACAA) ReadAttributes(ctx context.Context, in *pb.ACAAttrAdminReq) (*pb.ACAAttrSet, error) {
+	acaaLogger.Debug("gRPC ACAA:ReadAttributes")
+
+	owner, err := acaa.checkRequest(in)
+	if err != nil {
+		return nil, err
+	}
+
+	// Include the attributes of the configuration not read yet
+	if err = acaa.aca.fetchAndPopulateAttributes(owner.GetID(), owner.GetAffiliation()); err != nil {
+		return nil, err
+	}
+
+	attrs, err := acaa.aca.readAttributes(owner)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &pb.ACAAttrSet{}
+	for _, attr := range attrs {
+		set.Attributes = append(set.Attributes, attr.ToACAAttribute())
+	}
+	return set, nil
+}
+
+// ReadAttributeChanges reads the audit trail of the attributes of a user, or of
+// a single attribute if the request names one.
+//
+func (acaa *ACAA) ReadAttributeChanges(ctx context.Context, in *pb.ACAAttrAdminReq) (*pb.ACAAttrChangeSet, error) {
+	acaaLogger.Debug("gRPC ACAA:ReadAttributeChanges")
+
+	owner, err := acaa.checkRequest(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var attributeName string
+	if in.Attribute != nil {
+		attributeName = in.Attribute.AttributeName
+	}
+	changes, err := acaa.aca.readAttributeChanges(owner, attributeName)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ACAAttrChangeSet{Changes: changes}, nil
+}
+
+// checkRequest checks that a request was signed by a registrar allowed to
+// register the owner of the attributes, and returns that owner.
+//
+func (acaa *ACAA) checkRequest(in *pb.ACAAttrAdminReq) (*AttributeOwner, error) {
+	if in.Id == nil || in.Owner == nil {
+		return nil, errors.New("Invalid attribute request.")
+	}
+
+	eca := acaa.aca.eca
+	if eca == nil {
+		return nil, errors.New("Registrars are unknown to the ACA.")
+	}
+
+	sig := in.Sig
+	in.Sig = nil
+	if err := verifyEnrollmentSignature(eca, in.Id.Id, in, sig); err != nil {
+		return nil, err
+	}
+	if err := acaa.aca.checkFreshness(in.Id.Id, in.Ts, in.Nonce); err != nil {
+		return nil, err
+	}
+
+	owner, err := acaa.aca.readAttributeOwner(in.Owner.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err = eca.canRegister(in.Id.Id, role2String(eca.readRole(in.Owner.Id)), ""); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// adminRequestWindow returns how long a signed admin request is accepted,
+// and how far its timestamp may be ahead of the clock of the ACA.
+//
+func adminRequestWindow() time.Duration {
+	if viper.IsSet("aca.admin.window") {
+		return viper.GetDuration("aca.admin.window")
+	}
+	return 5 * time.Minute
+}
+
+// checkFreshness rejects admin requests that were signed outside of the
+// request window, or whose nonce was already used by the registrar, so that a
+// signed request cannot be replayed.  The nonces are only remembered until
+// the requests are stale, and not across restarts, so requests signed before
+// the ACA started are rejected too.
+//
+func (aca *ACA) checkFreshness(registrar string, ts *timestamp.Timestamp, nonce []byte) error {
+	if ts == nil || len(nonce) == 0 {
+		return errors.New("Request has no timestamp or nonce.")
+	}
+	signed, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	window := adminRequestWindow()
+	if signed.Before(now.Add(-window)) || signed.After(now.Add(window)) || signed.Before(aca.started) {
+		return errors.New("Request is stale.")
+	}
+
+	aca.nonceMutex.Lock()
+	defer aca.nonceMutex.Unlock()
+
+	for key, expiry := range aca.adminNonces {
+		if expiry.Before(now) {
+			delete(aca.adminNonces, key)
+		}
+	}
+	key := registrar + "\x00" + string(nonce)
+	if _, seen := aca.adminNonces[key]; seen {
+		return errors.New("Request was already received.")
+	}
+	aca.adminNonces[key] = signed.Add(window)
+	return nil
+}
+
+func timestampToTime(ts *timestamp.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	return ptypes.Timestamp(ts)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"net/http"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+
+	pb "github.com/hyperledger/fabric/membersrvc/protos"
+)
+
+// NewAttributeHandler returns an HTTP handler serving the admin interface of
+// the ACA.  Each call of the ACAA is served at /aca/attributes/<call> and takes
+// a signed ACAAttrAdminReq, JSON encoded, as body:
+//
+//	POST /aca/attributes/set      SetAttribute
+//	POST /aca/attributes/revoke   RevokeAttribute
+//	POST /aca/attributes/read     ReadAttributes
+//	POST /aca/attributes/changes  ReadAttributeChanges
+//
+// The signature covers the protobuf encoding of the request, including its
+// timestamp and nonce, as for the gRPC calls.  The handler is only served over
+// TLS, on aca.admin.address.
+//
+func NewAttributeHandler(aca *ACA) http.Handler {
+	acaa := &ACAA{aca}
+	calls := map[string]func(context.Context, *pb.ACAAttrAdminReq) (proto.Message, error){
+		"set": func(ctx context.Context, in *pb.ACAAttrAdminReq) (proto.Message, error) {
+			return acaa.SetAttribute(ctx, in)
+		},
+		"revoke": func(ctx context.Context, in *pb.ACAAttrAdminReq) (proto.Message, error) {
+			return acaa.RevokeAttribute(ctx, in)
+		},
+		"read": func(ctx context.Context, in *pb.ACAAttrAdminReq) (proto.Message, error) {
+			return acaa.ReadAttributes(ctx, in)
+		},
+		"changes": func(ctx context.Context, in *pb.ACAAttrAdminReq) (proto.Message, error) {
+			return acaa.ReadAttributeChanges(ctx, in)
+		},
+	}
+
+	mux := http.NewServeMux()
+	for name, call := range calls {
+		call := call
+		mux.HandleFunc("/aca/attributes/"+name, func(rw http.ResponseWriter, req *http.Request) {
+			if req.Method != "POST" {
+				http.Error(rw, "Method not allowed.", http.StatusMethodNotAllowed)
+				return
+			}
+
+			in := &pb.ACAAttrAdminReq{}
+			if err := jsonpb.Unmarshal(req.Body, in); err != nil {
+				http.Error(rw, "Invalid request: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			out, err := call(context.Background(), in)
+			if err != nil {
+				acaaLogger.Debugf("%s: %s", req.URL.Path, err)
+				http.Error(rw, err.Error(), http.StatusForbidden)
+				return
+			}
+
+			rw.Header().Set("Content-Type", "application/json")
+			if err = (&jsonpb.Marshaler{}).Marshal(rw, out); err != nil {
+				acaaLogger.Errorf("Error encoding response: %s", err)
+			}
+		})
+	}
+	return mux
+}
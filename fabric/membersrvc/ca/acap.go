@@ -20,6 +20,7 @@ import (
 	"encoding/asn1"
 	"errors"
 	"math/big"
+	"time"
 
 	"crypto/ecdsa"
 	"crypto/x509"
@@ -177,9 +178,10 @@ func (acap *ACAP) RequestAttributes(ctx context.Context, in *pb.ACAAttrReq) (*pb
 	var verifyCounter int
 	var attributes = make([]AttributePair, 0)
 	owner := &AttributeOwner{id, affiliation}
+	now := time.Now()
 	for _, attrPair := range in.Attributes {
 		verifiedPair, _ := acap.aca.findAttribute(owner, attrPair.AttributeName)
-		if verifiedPair != nil {
+		if verifiedPair != nil && verifiedPair.IsValidFor(now) {
 			verifyCounter++
 			attributes = append(attributes, *verifiedPair)
 		}
@@ -21,7 +21,6 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
-	"database/sql"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -38,9 +37,9 @@ import (
 	gp "google/protobuf"
 
 	"github.com/hyperledger/fabric/core/crypto/primitives"
+	"github.com/hyperledger/fabric/core/util"
 	"github.com/hyperledger/fabric/flogging"
 	pb "github.com/hyperledger/fabric/membersrvc/protos"
-	_ "github.com/mattn/go-sqlite3" // This blank import is required to load sqlite3 driver
 	"github.com/op/go-logging"
 	"github.com/spf13/viper"
 )
@@ -49,7 +48,7 @@ var caLogger = logging.MustGetLogger("ca")
 
 // CA is the base certificate authority.
 type CA struct {
-	db *sql.DB
+	store Store
 
 	path string
 
@@ -84,6 +83,8 @@ var (
 	caCountry      string
 	rootPath       string
 	caDir          string
+	storeBackend   string
+	crlValidity    time.Duration
 )
 
 // NewCertificateSpec creates a new certificate spec
@@ -114,17 +115,17 @@ func NewDefaultPeriodCertificateSpecWithCommonName(id string, commonName string,
 	return NewCertificateSpec(id, commonName, serialNumber, pub, usage, &notBefore, &notAfter, opt...)
 }
 
-// NewDefaultCertificateSpec creates a new certificate spec with serialNumber = 1, notBefore a minute ago and not after 90 days from notBefore.
+// NewDefaultCertificateSpec creates a new certificate spec with a random serialNumber, notBefore a minute ago and not after 90 days from notBefore.
 //
 func NewDefaultCertificateSpec(id string, pub interface{}, usage x509.KeyUsage, opt ...pkix.Extension) *CertificateSpec {
-	serialNumber := big.NewInt(1)
+	serialNumber := util.GenerateIntUUID()
 	return NewDefaultPeriodCertificateSpec(id, serialNumber, pub, usage, opt...)
 }
 
-// NewDefaultCertificateSpecWithCommonName creates a new certificate spec with serialNumber = 1, notBefore a minute ago and not after 90 days from notBefore and a specific commonName.
+// NewDefaultCertificateSpecWithCommonName creates a new certificate spec with a random serialNumber, notBefore a minute ago and not after 90 days from notBefore and a specific commonName.
 //
 func NewDefaultCertificateSpecWithCommonName(id string, commonName string, pub interface{}, usage x509.KeyUsage, opt ...pkix.Extension) *CertificateSpec {
-	serialNumber := big.NewInt(1)
+	serialNumber := util.GenerateIntUUID()
 	return NewDefaultPeriodCertificateSpecWithCommonName(id, commonName, serialNumber, pub, usage, opt...)
 }
 
@@ -134,6 +135,14 @@ func CacheConfiguration() {
 	caCountry = viper.GetString("pki.ca.subject.country")
 	rootPath = viper.GetString("server.rootpath")
 	caDir = viper.GetString("server.cadir")
+	storeBackend = viper.GetString("server.store.backend")
+	if storeBackend == "" {
+		storeBackend = "sqlite3"
+	}
+	crlValidity = viper.GetDuration("pki.crl.validity")
+	if crlValidity <= 0 {
+		crlValidity = 24 * time.Hour
+	}
 }
 
 // GetID returns the spec's ID field/value
@@ -208,27 +217,16 @@ func (spec *CertificateSpec) GetExtensions() *[]pkix.Extension {
 	return spec.ext
 }
 
-// TableInitializer is a function type for table initialization
-type TableInitializer func(*sql.DB) error
-
-func initializeCommonTables(db *sql.DB) error {
-	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS Certificates (row INTEGER PRIMARY KEY, id VARCHAR(64), timestamp INTEGER, usage INTEGER, cert BLOB, hash BLOB, kdfkey BLOB)"); err != nil {
-		return err
-	}
-	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS Users (row INTEGER PRIMARY KEY, id VARCHAR(64), enrollmentId VARCHAR(100), role INTEGER, metadata VARCHAR(256), token BLOB, state INTEGER, key BLOB)"); err != nil {
-		return err
-	}
-	if _, err := db.Exec("CREATE TABLE IF NOT EXISTS AffiliationGroups (row INTEGER PRIMARY KEY, name VARCHAR(64), parent INTEGER, FOREIGN KEY(parent) REFERENCES AffiliationGroups(row))"); err != nil {
-		return err
-	}
-	return nil
+// caStatePath returns the CA state directory.
+func caStatePath() string {
+	return filepath.Join(rootPath, caDir)
 }
 
 // NewCA sets up a new CA.
-func NewCA(name string, initTables TableInitializer) *CA {
+func NewCA(name string) *CA {
 	ca := new(CA)
 	flogging.LoggingInit("ca")
-	ca.path = filepath.Join(rootPath, caDir)
+	ca.path = caStatePath()
 
 	if _, err := os.Stat(ca.path); err != nil {
 		caLogger.Info("Fresh start; creating databases, key pairs, and certificates.")
@@ -239,19 +237,11 @@ func NewCA(name string, initTables TableInitializer) *CA {
 	}
 
 	// open or create certificate database
-	db, err := sql.Open("sqlite3", ca.path+"/"+name+".db")
+	store, err := OpenStore(storeBackend, name)
 	if err != nil {
 		caLogger.Panic(err)
 	}
-
-	if err = db.Ping(); err != nil {
-		caLogger.Panic(err)
-	}
-
-	if err = initTables(db); err != nil {
-		caLogger.Panic(err)
-	}
-	ca.db = db
+	ca.store = store
 
 	// read or create signing key pair
 	priv, err := ca.readCAPrivateKey(name)
@@ -278,7 +268,7 @@ func NewCA(name string, initTables TableInitializer) *CA {
 
 // Stop Close closes down the CA.
 func (ca *CA) Stop() error {
-	err := ca.db.Close()
+	err := ca.store.Close()
 	if err == nil {
 		caLogger.Debug("Shutting down CA - Successfully")
 	} else {
@@ -395,9 +385,9 @@ func (ca *CA) persistCertificate(id string, timestamp int64, usage x509.KeyUsage
 
 	hash := primitives.NewHash()
 	hash.Write(certRaw)
-	var err error
 
-	if _, err = ca.db.Exec("INSERT INTO Certificates (id, timestamp, usage, cert, hash, kdfkey) VALUES (?, ?, ?, ?, ?, ?)", id, timestamp, usage, certRaw, hash.Sum(nil), kdfKey); err != nil {
+	err := ca.store.CreateCertificate(&CertificateRecord{ID: id, Timestamp: timestamp, Usage: int(usage), Cert: certRaw, Hash: hash.Sum(nil), KDFKey: kdfKey})
+	if err != nil {
 		caLogger.Error(err)
 	}
 	return err
@@ -461,48 +451,30 @@ func (ca *CA) readCertificateByKeyUsage(id string, usage x509.KeyUsage) ([]byte,
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	var raw []byte
-	err := ca.db.QueryRow("SELECT cert FROM Certificates WHERE id=? AND usage=?", id, usage).Scan(&raw)
-
-	if err != nil {
-		caLogger.Debugf("readCertificateByKeyUsage() Error: %v", err)
+	certs, err := ca.store.ReadCertificates(id)
+	if err == nil {
+		for _, cert := range certs {
+			if cert.Usage == int(usage) {
+				return cert.Cert, nil
+			}
+		}
+		err = errors.New("No certificate for the given identity and usage was found.")
 	}
+	caLogger.Debugf("readCertificateByKeyUsage() Error: %v", err)
 
-	return raw, err
+	return nil, err
 }
 
-func (ca *CA) readCertificateByTimestamp(id string, ts int64) ([]byte, error) {
-	caLogger.Debug("Reading certificate for " + id + ".")
-
-	mutex.RLock()
-	defer mutex.RUnlock()
-
-	var raw []byte
-	err := ca.db.QueryRow("SELECT cert FROM Certificates WHERE id=? AND timestamp=?", id, ts).Scan(&raw)
-
-	return raw, err
-}
-
-func (ca *CA) readCertificates(id string, opt ...int64) (*sql.Rows, error) {
+// readCertificates reads the certificates of id in the order they were
+// created.
+//
+func (ca *CA) readCertificates(id string) ([]*CertificateRecord, error) {
 	caLogger.Debug("Reading certificatess for " + id + ".")
 
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	if len(opt) > 0 && opt[0] != 0 {
-		return ca.db.Query("SELECT cert, kdfkey FROM Certificates WHERE id=? AND timestamp=? ORDER BY usage", id, opt[0])
-	}
-
-	return ca.db.Query("SELECT cert, kdfkey FROM Certificates WHERE id=?", id)
-}
-
-func (ca *CA) readCertificateSets(id string, start, end int64) (*sql.Rows, error) {
-	caLogger.Debug("Reading certificate sets for " + id + ".")
-
-	mutex.RLock()
-	defer mutex.RUnlock()
-
-	return ca.db.Query("SELECT cert, kdfKey, timestamp FROM Certificates WHERE id=? AND timestamp BETWEEN ? AND ? ORDER BY timestamp", id, start, end)
+	return ca.store.ReadCertificates(id)
 }
 
 func (ca *CA) readCertificateByHash(hash []byte) ([]byte, error) {
@@ -511,11 +483,23 @@ func (ca *CA) readCertificateByHash(hash []byte) ([]byte, error) {
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	var raw []byte
-	row := ca.db.QueryRow("SELECT cert FROM Certificates WHERE hash=?", hash)
-	err := row.Scan(&raw)
+	cert, err := ca.store.ReadCertificateByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, errors.New("No certificate for the given hash was found.")
+	}
+	return cert.Cert, nil
+}
 
-	return raw, err
+// deleteCertificates deletes the certificates of id.
+//
+func (ca *CA) deleteCertificates(id string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return ca.store.DeleteCertificates(id)
 }
 
 func (ca *CA) isValidAffiliation(affiliation string) (bool, error) {
@@ -524,14 +508,18 @@ func (ca *CA) isValidAffiliation(affiliation string) (bool, error) {
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	var count int
-	var err error
-	err = ca.db.QueryRow("SELECT count(row) FROM AffiliationGroups WHERE name=?", affiliation).Scan(&count)
+	groups, err := ca.store.ReadAffiliationGroups()
 	if err != nil {
 		caLogger.Debug("Affiliation <" + affiliation + "> is INVALID.")
 
 		return false, err
 	}
+	var count int
+	for _, group := range groups {
+		if group.Name == affiliation {
+			count++
+		}
+	}
 	caLogger.Debug("Affiliation <" + affiliation + "> is VALID.")
 
 	return count == 1, nil
@@ -641,13 +629,15 @@ func (ca *CA) registerUserWithEnrollID(id string, enrollID string, role pb.Role,
 		tok = randomString(12)
 	}
 
-	var row int
-	err := ca.db.QueryRow("SELECT row FROM Users WHERE id=?", id).Scan(&row)
-	if err == nil {
+	user, err := ca.store.ReadUser(id)
+	if err != nil {
+		return "", err
+	}
+	if user != nil {
 		return "", errors.New("User is already registered")
 	}
 
-	_, err = ca.db.Exec("INSERT INTO Users (id, enrollmentId, token, role, metadata, state) VALUES (?, ?, ?, ?, ?, ?)", id, enrollID, tok, role, memberMetadata, 0)
+	err = ca.store.CreateUser(&UserRecord{ID: id, EnrollmentID: enrollID, Token: []byte(tok), Role: int(role), Metadata: memberMetadata})
 
 	if err != nil {
 		caLogger.Error(err)
@@ -664,25 +654,25 @@ func (ca *CA) registerAffiliationGroup(name string, parentName string) error {
 
 	caLogger.Debug("Registering affiliation group " + name + " parent " + parentName + ".")
 
-	var parentID int
-	var err error
-	var count int
-	err = ca.db.QueryRow("SELECT count(row) FROM AffiliationGroups WHERE name=?", name).Scan(&count)
+	groups, err := ca.store.ReadAffiliationGroups()
 	if err != nil {
 		return err
 	}
-	if count > 0 {
-		return errors.New("Affiliation group is already registered")
-	}
 
-	if strings.Compare(parentName, "") != 0 {
-		err = ca.db.QueryRow("SELECT row FROM AffiliationGroups WHERE name=?", parentName).Scan(&parentID)
-		if err != nil {
-			return err
+	var parentID int64
+	for _, group := range groups {
+		if group.Name == name {
+			return errors.New("Affiliation group is already registered")
+		}
+		if strings.Compare(parentName, "") != 0 && group.Name == parentName {
+			parentID = group.ID
 		}
 	}
+	if strings.Compare(parentName, "") != 0 && parentID == 0 {
+		return errors.New("Affiliation group " + parentName + " is not registered")
+	}
 
-	_, err = ca.db.Exec("INSERT INTO AffiliationGroups (name, parent) VALUES (?, ?)", name, parentID)
+	err = ca.store.CreateAffiliationGroup(&AffiliationGroupRecord{Name: name, ParentID: parentID})
 
 	if err != nil {
 		caLogger.Error(err)
@@ -700,40 +690,57 @@ func (ca *CA) deleteUser(id string) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	var row int
-	err := ca.db.QueryRow("SELECT row FROM Users WHERE id=?", id).Scan(&row)
-	if err == nil {
-		_, err = ca.db.Exec("DELETE FROM Certificates Where id=?", id)
-		if err != nil {
-			caLogger.Error(err)
-		}
-
-		_, err = ca.db.Exec("DELETE FROM Users WHERE row=?", row)
-		if err != nil {
-			caLogger.Error(err)
-		}
+	err := ca.store.DeleteUser(id)
+	if err != nil {
+		caLogger.Error(err)
 	}
 
 	return err
 }
 
-// readUser reads a token given an id
+// readUser reads a user given an id
 //
-func (ca *CA) readUser(id string) *sql.Row {
+func (ca *CA) readUser(id string) (*UserRecord, error) {
 	caLogger.Debug("Reading token for " + id + ".")
 
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	return ca.db.QueryRow("SELECT role, token, state, key, enrollmentId FROM Users WHERE id=?", id)
+	user, err := ca.store.ReadUser(id)
+	if err == nil && user == nil {
+		err = errors.New("User " + id + " is not registered.")
+	}
+	return user, err
+}
+
+// updateUser updates the token, state and key of a user
+//
+func (ca *CA) updateUser(user *UserRecord) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	return ca.store.UpdateUser(user)
 }
 
 // readUsers reads users of a given Role
 //
-func (ca *CA) readUsers(role int) (*sql.Rows, error) {
+func (ca *CA) readUsers(role int) ([]*UserRecord, error) {
 	caLogger.Debug("Reading users matching role " + strconv.FormatInt(int64(role), 2) + ".")
 
-	return ca.db.Query("SELECT id, role FROM Users WHERE role&?!=0", role)
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	users, err := ca.store.ReadUsers()
+	if err != nil {
+		return nil, err
+	}
+	var matching []*UserRecord
+	for _, user := range users {
+		if user.Role&role != 0 {
+			matching = append(matching, user)
+		}
+	}
+	return matching, nil
 }
 
 // readRole returns the user Role given a user id
@@ -744,29 +751,27 @@ func (ca *CA) readRole(id string) int {
 	mutex.RLock()
 	defer mutex.RUnlock()
 
-	var role int
-	ca.db.QueryRow("SELECT role FROM Users WHERE id=?", id).Scan(&role)
+	user, err := ca.store.ReadUser(id)
+	if err != nil || user == nil {
+		return 0
+	}
 
-	return role
+	return user.Role
 }
 
 func (ca *CA) readAffiliationGroups() ([]*AffiliationGroup, error) {
 	caLogger.Debug("Reading affilition groups.")
 
-	rows, err := ca.db.Query("SELECT row, name, parent FROM AffiliationGroups")
+	mutex.RLock()
+	records, err := ca.store.ReadAffiliationGroups()
+	mutex.RUnlock()
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 	groups := make(map[int64]*AffiliationGroup)
 
-	for rows.Next() {
-		group := new(AffiliationGroup)
-		var id int64
-		if e := rows.Scan(&id, &group.name, &group.parentID); e != nil {
-			return nil, err
-		}
-		groups[id] = group
+	for _, record := range records {
+		groups[record.ID] = &AffiliationGroup{name: record.Name, parentID: record.ParentID}
 	}
 
 	groupList := make([]*AffiliationGroup, len(groups))
@@ -818,12 +823,15 @@ func (ca *CA) canRegister(registrar string, newMemberRole string, newMemberMetad
 	defer mutex.RUnlock()
 
 	// Read the user metadata associated with 'registrar'
-	var registrarMetadataStr string
-	err := ca.db.QueryRow("SELECT metadata FROM Users WHERE id=?", registrar).Scan(&registrarMetadataStr)
+	user, err := ca.store.ReadUser(registrar)
+	if err == nil && user == nil {
+		err = errors.New("member " + registrar + " is not registered")
+	}
 	if err != nil {
 		caLogger.Debugf("CA.canRegister: db error: %s\n", err.Error())
 		return err
 	}
+	registrarMetadataStr := user.Metadata
 	caLogger.Debugf("CA.canRegister: registrar=%s, registrarMD=%s, newMemberRole=%s, newMemberMD=%s",
 		registrar, registrarMetadataStr, newMemberRole, newMemberMetadataStr)
 	// If isn't a registrar at all, then error
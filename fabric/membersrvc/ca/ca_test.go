@@ -21,8 +21,6 @@ import (
 	"os"
 	"testing"
 
-	"database/sql"
-
 	"github.com/hyperledger/fabric/core/crypto"
 	"github.com/hyperledger/fabric/core/crypto/primitives"
 	"github.com/spf13/viper"
@@ -48,7 +46,7 @@ func TestNewCA(t *testing.T) {
 	CacheConfiguration() // Cache configuration
 
 	//Create new CA
-	ca := NewCA(name, initializeTables)
+	ca := NewCA(name)
 	if ca == nil {
 		t.Error("could not create new CA")
 	}
@@ -91,8 +89,3 @@ func TestNewCA(t *testing.T) {
 	}
 
 }
-
-// Empty initializer for CA
-func initializeTables(db *sql.DB) error {
-	return nil
-}
@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/crypto/primitives"
+	pb "github.com/hyperledger/fabric/membersrvc/protos"
+)
+
+// revokeCertificate records the revocation of a certificate issued to id.
+// Revoking a certificate twice is not an error.
+//
+func (ca *CA) revokeCertificate(id string, serialNumber *big.Int) error {
+	caLogger.Debugf("Revoking certificate %s of %s", serialNumber, id)
+
+	// Certificates issued before serial numbers were unique all have the
+	// serial number 1, which cannot be revoked without revoking them all
+	if serialNumber.Cmp(big.NewInt(1)) <= 0 {
+		return errors.New("Certificate has no unique serial number and cannot be revoked.")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	err := ca.store.CreateRevokedCertificate(&RevokedCertificateRecord{ID: id, SerialNumber: serialNumber.String(), RevocationTime: time.Now().Unix()})
+	if err != nil {
+		caLogger.Error(err)
+	}
+	return err
+}
+
+func (ca *CA) readRevokedCertificates() ([]pkix.RevokedCertificate, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	records, err := ca.store.ReadRevokedCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	var revoked []pkix.RevokedCertificate
+	for _, record := range records {
+		serialNumber, ok := new(big.Int).SetString(record.SerialNumber, 10)
+		if !ok {
+			return nil, errors.New("Invalid serial number " + record.SerialNumber + ".")
+		}
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: serialNumber, RevocationTime: time.Unix(record.RevocationTime, 0).UTC()})
+	}
+	return revoked, nil
+}
+
+// readCertificateOwner returns the id a certificate issued by the CA was
+// issued to.
+//
+func (ca *CA) readCertificateOwner(raw []byte) (string, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	hash := primitives.NewHash()
+	hash.Write(raw)
+
+	cert, err := ca.store.ReadCertificateByHash(hash.Sum(nil))
+	if err != nil {
+		return "", err
+	}
+	if cert == nil {
+		return "", errors.New("Certificate was not issued by this CA.")
+	}
+	return cert.ID, nil
+}
+
+// publishCRL creates a new certificate revocation list signed by the CA and
+// stores it as the last published one.
+//
+func (ca *CA) publishCRL() ([]byte, error) {
+	revoked, err := ca.readRevokedCertificates()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	raw, err := ca.cert.CreateCRL(rand.Reader, ca.priv, revoked, now, now.Add(crlValidity))
+	if err != nil {
+		caLogger.Error(err)
+		return nil, err
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if err = ca.store.CreateCRL(&CRLRecord{Timestamp: now.Unix(), CRL: raw}); err != nil {
+		caLogger.Error(err)
+		return nil, err
+	}
+	caLogger.Debugf("Published CRL with %d revoked certificates", len(revoked))
+	return raw, nil
+}
+
+// readCRL returns the last published certificate revocation list. A new one is
+// published if there is none yet or the last one expired.
+//
+func (ca *CA) readCRL() ([]byte, error) {
+	mutex.RLock()
+	last, err := ca.store.ReadLastCRL()
+	mutex.RUnlock()
+
+	if err != nil {
+		return nil, err
+	}
+	if last == nil {
+		return ca.publishCRL()
+	}
+
+	raw := last.CRL
+	crl, err := x509.ParseCRL(raw)
+	if err != nil {
+		return nil, err
+	}
+	if crl.HasExpired(time.Now()) {
+		return ca.publishCRL()
+	}
+	return raw, nil
+}
+
+// verifyEnrollmentSignature checks that a request, marshaled without its
+// signature, was signed with the enrollment key of id.
+//
+func verifyEnrollmentSignature(eca *ECA, id string, in proto.Message, sig *pb.Signature) error {
+	if sig == nil {
+		return errors.New("Request is not signed.")
+	}
+
+	raw, err := eca.readCertificateByKeyUsage(id, x509.KeyUsageDigitalSignature)
+	if err != nil {
+		return err
+	}
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return err
+	}
+
+	r, s := big.NewInt(0), big.NewInt(0)
+	r.UnmarshalText(sig.R)
+	s.UnmarshalText(sig.S)
+
+	hash := primitives.NewHash()
+	raw, _ = proto.Marshal(in)
+	hash.Write(raw)
+	if ecdsa.Verify(cert.PublicKey.(*ecdsa.PublicKey), hash.Sum(nil), r, s) == false {
+		return errors.New("Signature verification failed.")
+	}
+	return nil
+}
+
+// checkRevocationAccess checks that requester may revoke the certificates of
+// owner.  Members may revoke their own certificates and, if admin is set,
+// registrars those of the members they may register.
+//
+func checkRevocationAccess(eca *ECA, requester, owner string, admin bool) error {
+	if requester == owner {
+		return nil
+	}
+	if !admin {
+		return errors.New("Access denied.")
+	}
+	return eca.canRegister(requester, role2String(eca.readRole(owner)), "")
+}
+
+// isRegistrar tells whether id may register members.
+//
+func (ca *CA) isRegistrar(id string) bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	user, err := ca.store.ReadUser(id)
+	return err == nil && user != nil && user.Metadata != ""
+}
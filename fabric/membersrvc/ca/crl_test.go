@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/util"
+)
+
+func isRevoked(t *testing.T, ca *CA, raw []byte, serialNumber *big.Int) bool {
+	crl, err := x509.ParseCRL(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ca.cert.CheckCRLSignature(crl); err != nil {
+		t.Fatal(err)
+	}
+	for _, cert := range crl.TBSCertList.RevokedCertificates {
+		if cert.SerialNumber.Cmp(serialNumber) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRevokeAndPublishCRL(t *testing.T) {
+	serialNumber := util.GenerateIntUUID()
+
+	raw, err := eca.readCRL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isRevoked(t, eca.CA, raw, serialNumber) {
+		t.Fatal("Certificate should not be revoked yet")
+	}
+
+	if err = eca.revokeCertificate("test_user1", serialNumber); err != nil {
+		t.Fatal(err)
+	}
+	// revoking a certificate twice is not an error
+	if err = eca.revokeCertificate("test_user1", serialNumber); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = eca.publishCRL(); err != nil {
+		t.Fatal(err)
+	}
+	raw, err = eca.readCRL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isRevoked(t, eca.CA, raw, serialNumber) {
+		t.Fatal("Certificate should be revoked")
+	}
+}
+
+func TestRevokeCertificateWithoutUniqueSerialNumber(t *testing.T) {
+	if err := eca.revokeCertificate("test_user1", big.NewInt(1)); err == nil {
+		t.Fatal("Certificates with serial number 1 should not be revocable")
+	}
+}
+
+func TestCRLHandler(t *testing.T) {
+	ts := httptest.NewServer(NewCRLHandler(eca, tca))
+	defer ts.Close()
+
+	for _, path := range []string{"/eca/crl", "/tca/crl"} {
+		resp, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("GET %s returned %d", path, resp.StatusCode)
+		}
+		if _, err = x509.ParseCRL(body); err != nil {
+			t.Fatalf("GET %s did not return a CRL: %s", path, err)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/unknown/crl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("GET /unknown/crl returned %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"encoding/pem"
+	"net/http"
+)
+
+// NewCRLHandler returns an HTTP handler serving the last certificate revocation
+// lists published by the ECA and the TCA at /eca/crl and /tca/crl.  The CRLs
+// are DER encoded, or PEM encoded with the format=pem query parameter.
+//
+func NewCRLHandler(eca *ECA, tca *TCA) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eca/crl", func(rw http.ResponseWriter, req *http.Request) {
+		serveCRL(eca.CA, rw, req)
+	})
+	mux.HandleFunc("/tca/crl", func(rw http.ResponseWriter, req *http.Request) {
+		serveCRL(tca.CA, rw, req)
+	})
+	return mux
+}
+
+func serveCRL(ca *CA, rw http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		http.Error(rw, "Method not allowed.", http.StatusMethodNotAllowed)
+		return
+	}
+
+	raw, err := ca.readCRL()
+	if err != nil {
+		caLogger.Errorf("Error reading CRL: %s", err)
+		http.Error(rw, "Error reading CRL.", http.StatusInternalServerError)
+		return
+	}
+
+	if req.URL.Query().Get("format") == "pem" {
+		rw.Header().Set("Content-Type", "application/x-pem-file")
+		rw.Write(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: raw}))
+		return
+	}
+	rw.Header().Set("Content-Type", "application/pkix-crl")
+	rw.Write(raw)
+}
@@ -20,7 +20,6 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/x509"
-	"database/sql"
 	"encoding/asn1"
 	"encoding/base64"
 	"encoding/pem"
@@ -44,26 +43,32 @@ var (
 	ECertSubjectRole = asn1.ObjectIdentifier{2, 1, 3, 4, 5, 6, 7}
 )
 
+// enrollmentRevoked is the state of a user whose enrollment was revoked, after
+// the states 0 (registered), 1 (challenged) and 2 (enrolled)
+const enrollmentRevoked = 3
+
 // ECA is the enrollment certificate authority.
 //
 type ECA struct {
 	*CA
 	aca             *ACA
+	tca             *TCA
 	obcKey          []byte
 	obcPriv, obcPub []byte
 	gRPCServer      *grpc.Server
 }
 
-func initializeECATables(db *sql.DB) error {
-	return initializeCommonTables(db)
-}
-
 // NewECA sets up a new ECA.
 //
 func NewECA(aca *ACA) *ECA {
-	eca := &ECA{CA: NewCA("eca", initializeECATables), aca: aca}
+	eca := &ECA{CA: NewCA("eca"), aca: aca}
 	flogging.LoggingInit("eca")
 
+	// The attributes of the users are managed by the registrars of the ECA
+	if aca != nil {
+		aca.eca = eca
+	}
+
 	{
 		// read or create global symmetric encryption key
 		var cooked string
@@ -212,6 +217,55 @@ func (eca *ECA) Stop() {
 	}
 }
 
+// revokeEnrollment revokes the enrollment certificates of id along with the
+// transaction certificates issued for them, and keeps id from enrolling again.
+//
+func (eca *ECA) revokeEnrollment(id string) error {
+	ecaLogger.Infof("Revoking enrollment of %s", id)
+
+	user, err := eca.readUser(id)
+	if err != nil {
+		return err
+	}
+	certs, err := eca.readCertificates(id)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range certs {
+		cert, err := x509.ParseCertificate(record.Cert)
+		if err != nil {
+			return err
+		}
+		if err = eca.revokeCertificate(id, cert.SerialNumber); err != nil {
+			// the enrollment is still revoked, and no longer gets new
+			// transaction certificates
+			ecaLogger.Warningf("Could not revoke certificate of %s: %s", id, err)
+		}
+	}
+
+	user.State = enrollmentRevoked
+	if err = eca.updateUser(user); err != nil {
+		ecaLogger.Error(err)
+		return err
+	}
+
+	if eca.tca != nil {
+		if err = eca.tca.revokeEnrollment(id); err != nil {
+			return err
+		}
+	}
+	_, err = eca.publishCRL()
+	return err
+}
+
+// isEnrollmentRevoked tells whether the enrollment of id was revoked.
+//
+func (eca *ECA) isEnrollmentRevoked(id string) bool {
+	user, err := eca.readUser(id)
+	return err == nil && user.State == enrollmentRevoked
+}
+
 func (eca *ECA) startECAP(srv *grpc.Server) {
 	pb.RegisterECAPServer(srv, &ECAP{eca})
 	ecaLogger.Info("ECA PUBLIC gRPC API server started")
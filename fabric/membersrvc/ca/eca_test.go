@@ -517,9 +517,9 @@ func TestCreateCertificatePairBadIdentity(t *testing.T) {
 		Sig:  nil}
 
 	_, err := ecap.CreateCertificatePair(context.Background(), req)
-	if err.Error() != "Identity lookup error: sql: no rows in result set" {
+	if err.Error() != "Identity lookup error: User badIdentity is not registered." {
 		t.Log(err.Error())
-		t.Fatal("The expected error of 'Identity lookup error: sql: no rows in result set' was not returned for bad identity")
+		t.Fatal("The expected error of 'Identity lookup error: User badIdentity is not registered.' was not returned for bad identity")
 	}
 }
 
@@ -546,7 +546,7 @@ func TestRevokeCertificatePair(t *testing.T) {
 	ecap := &ECAP{eca}
 
 	_, err := ecap.RevokeCertificatePair(context.Background(), &pb.ECertRevokeReq{})
-	if err.Error() != "ECAP:RevokeCertificate method not (yet) implemented" {
+	if err.Error() != "Invalid revocation request." {
 		t.Fatalf("Expected error was not returned: [%s]", err.Error())
 	}
 }
@@ -556,7 +556,7 @@ func TestRevokeCertificate(t *testing.T) {
 	ecaa := &ECAA{eca}
 
 	_, err := ecaa.RevokeCertificate(context.Background(), &pb.ECertRevokeReq{})
-	if err.Error() != "ECAA:RevokeCertificate method not (yet) implemented" {
+	if err.Error() != "Invalid revocation request." {
 		t.Fatalf("Expected error was not returned: [%s]", err.Error())
 	}
 }
@@ -565,7 +565,7 @@ func TestPublishCRL(t *testing.T) {
 	ecaa := &ECAA{eca}
 
 	_, err := ecaa.PublishCRL(context.Background(), &pb.ECertCRLReq{})
-	if err.Error() != "ECAA:PublishCRL method not (yet) implemented" {
+	if err.Error() != "Access denied." {
 		t.Fatalf("Expected error was not returned: [%s]", err.Error())
 	}
 }
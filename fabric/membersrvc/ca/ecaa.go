@@ -147,39 +147,76 @@ func (ecaa *ECAA) ReadUserSet(ctx context.Context, in *pb.ReadUserSetReq) (*pb.U
 		return nil, errors.New("Signature verification failed.")
 	}
 
-	rows, err := ecaa.eca.readUsers(int(in.Role))
+	records, err := ecaa.eca.readUsers(int(in.Role))
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
 	var users []*pb.User
-	if err == nil {
-		for rows.Next() {
-			var id string
-			var role int
-
-			err = rows.Scan(&id, &role)
-			users = append(users, &pb.User{Id: &pb.Identity{Id: id}, Role: pb.Role(role)})
-		}
-		err = rows.Err()
+	for _, user := range records {
+		users = append(users, &pb.User{Id: &pb.Identity{Id: user.ID}, Role: pb.Role(user.Role)})
 	}
 
-	return &pb.UserSet{Users: users}, err
+	return &pb.UserSet{Users: users}, nil
 }
 
-// RevokeCertificate revokes a certificate from the ECA.  Not yet implemented.
+// RevokeCertificate revokes the enrollment certificates of a member, along with
+// the transaction certificates issued for them.  Registrars may revoke the
+// certificates of the members they may register.
 //
-func (ecaa *ECAA) RevokeCertificate(context.Context, *pb.ECertRevokeReq) (*pb.CAStatus, error) {
+func (ecaa *ECAA) RevokeCertificate(ctx context.Context, in *pb.ECertRevokeReq) (*pb.CAStatus, error) {
 	ecaaLogger.Debug("gRPC ECAA:RevokeCertificate")
 
-	return nil, errors.New("ECAA:RevokeCertificate method not (yet) implemented")
+	return revokeEnrollmentCertificate(ecaa.eca, in, true)
 }
 
-// PublishCRL requests the creation of a certificate revocation list from the ECA.  Not yet implemented.
+// PublishCRL requests the creation of a certificate revocation list from the ECA.
 //
-func (ecaa *ECAA) PublishCRL(context.Context, *pb.ECertCRLReq) (*pb.CAStatus, error) {
+func (ecaa *ECAA) PublishCRL(ctx context.Context, in *pb.ECertCRLReq) (*pb.CAStatus, error) {
 	ecaaLogger.Debug("gRPC ECAA:CreateCRL")
 
-	return nil, errors.New("ECAA:PublishCRL method not (yet) implemented")
+	if in.Id == nil || !ecaa.eca.isRegistrar(in.Id.Id) {
+		return nil, errors.New("Access denied.")
+	}
+
+	sig := in.Sig
+	in.Sig = nil
+	if err := verifyEnrollmentSignature(ecaa.eca, in.Id.Id, in, sig); err != nil {
+		return nil, err
+	}
+
+	if _, err := ecaa.eca.publishCRL(); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
+}
+
+// revokeEnrollmentCertificate revokes the enrollment of the owner of the
+// certificate in a revocation request.  Members may only revoke their own
+// certificates, unless admin is set.
+//
+func revokeEnrollmentCertificate(eca *ECA, in *pb.ECertRevokeReq, admin bool) (*pb.CAStatus, error) {
+	if in.Id == nil || in.Cert == nil {
+		return nil, errors.New("Invalid revocation request.")
+	}
+	requester := in.Id.Id
+
+	sig := in.Sig
+	in.Sig = nil
+	if err := verifyEnrollmentSignature(eca, requester, in, sig); err != nil {
+		return nil, err
+	}
+
+	owner, err := eca.readCertificateOwner(in.Cert.Cert)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRevocationAccess(eca, requester, owner, admin); err != nil {
+		return nil, err
+	}
+
+	if err = eca.revokeEnrollment(owner); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
 }
@@ -104,22 +104,22 @@ func (ecap *ECAP) CreateCertificatePair(ctx context.Context, in *pb.ECertCreateR
 	ecapLogger.Debug("gRPC ECAP:CreateCertificate")
 
 	// validate token
-	var tok, prev []byte
-	var role, state int
-	var enrollID string
-
 	id := in.Id.Id
-	err := ecap.eca.readUser(id).Scan(&role, &tok, &state, &prev, &enrollID)
+	user, err := ecap.eca.readUser(id)
 
 	if err != nil {
 		errMsg := "Identity lookup error: " + err.Error()
 		ecapLogger.Debug(errMsg)
 		return nil, errors.New(errMsg)
 	}
+	tok, prev, role, state, enrollID := user.Token, user.Key, user.Role, user.State, user.EnrollmentID
 	if !bytes.Equal(tok, in.Tok.Tok) {
 		ecapLogger.Debugf("id or token mismatch: id=%s", id)
 		return nil, errors.New("Identity or token does not match.")
 	}
+	if state == enrollmentRevoked {
+		return nil, errors.New("Enrollment was revoked.")
+	}
 
 	ekey, err := x509.ParsePKIXPublicKey(in.Enc.Key)
 	if err != nil {
@@ -132,9 +132,8 @@ func (ecap *ECAP) CreateCertificatePair(ctx context.Context, in *pb.ECertCreateR
 		// initial request, create encryption challenge
 		tok = []byte(randomString(12))
 
-		mutex.Lock()
-		_, err = ecap.eca.db.Exec("UPDATE Users SET token=?, state=?, key=? WHERE id=?", tok, 1, in.Enc.Key, id)
-		mutex.Unlock()
+		user.Token, user.State, user.Key = tok, 1, in.Enc.Key
+		err = ecap.eca.updateUser(user)
 
 		if err != nil {
 			ecapLogger.Error(err)
@@ -200,20 +199,15 @@ func (ecap *ECAP) CreateCertificatePair(ctx context.Context, in *pb.ECertCreateR
 		spec = NewDefaultCertificateSpecWithCommonName(id, enrollID, ekey.(*ecdsa.PublicKey), x509.KeyUsageDataEncipherment, pkix.Extension{Id: ECertSubjectRole, Critical: true, Value: []byte(strconv.Itoa(ecap.eca.readRole(id)))})
 		eraw, err := ecap.eca.createCertificateFromSpec(spec, ts, nil, true)
 		if err != nil {
-			mutex.Lock()
-			ecap.eca.db.Exec("DELETE FROM Certificates Where id=?", id)
-			mutex.Unlock()
+			ecap.eca.deleteCertificates(id)
 			ecapLogger.Error(err)
 			return nil, err
 		}
 
-		mutex.Lock()
-		_, err = ecap.eca.db.Exec("UPDATE Users SET state=? WHERE id=?", 2, id)
-		mutex.Unlock()
+		user.State = 2
+		err = ecap.eca.updateUser(user)
 		if err != nil {
-			mutex.Lock()
-			ecap.eca.db.Exec("DELETE FROM Certificates Where id=?", id)
-			mutex.Unlock()
+			ecap.eca.deleteCertificates(id)
 			ecapLogger.Error(err)
 			return nil, err
 		}
@@ -246,25 +240,16 @@ func (ecap *ECAP) CreateCertificatePair(ctx context.Context, in *pb.ECertCreateR
 func (ecap *ECAP) ReadCertificatePair(ctx context.Context, in *pb.ECertReadReq) (*pb.CertPair, error) {
 	ecapLogger.Debug("gRPC ECAP:ReadCertificate")
 
-	rows, err := ecap.eca.readCertificates(in.Id.Id)
-	defer rows.Close()
-
-	hasResults := false
-	var certs [][]byte
-	if err == nil {
-		for rows.Next() {
-			hasResults = true
-			var raw []byte
-			err = rows.Scan(&raw)
-			certs = append(certs, raw)
-		}
-		err = rows.Err()
+	certs, err := ecap.eca.readCertificates(in.Id.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	if !hasResults {
+	// The signing certificate is created before the encryption certificate
+	if len(certs) < 2 {
 		return nil, errors.New("No certificates for the given identity were found.")
 	}
-	return &pb.CertPair{Sign: certs[0], Enc: certs[1]}, err
+	return &pb.CertPair{Sign: certs[0].Cert, Enc: certs[1].Cert}, nil
 }
 
 // ReadCertificateByHash reads a single enrollment certificate by hash from the ECA.
@@ -276,10 +261,23 @@ func (ecap *ECAP) ReadCertificateByHash(ctx context.Context, hash *pb.Hash) (*pb
 	return &pb.Cert{Cert: raw}, err
 }
 
-// RevokeCertificatePair revokes a certificate pair from the ECA.  Not yet implemented.
+// RevokeCertificatePair revokes the enrollment certificate pair of the caller,
+// along with the transaction certificates issued for it.
 //
-func (ecap *ECAP) RevokeCertificatePair(context.Context, *pb.ECertRevokeReq) (*pb.CAStatus, error) {
+func (ecap *ECAP) RevokeCertificatePair(ctx context.Context, in *pb.ECertRevokeReq) (*pb.CAStatus, error) {
 	ecapLogger.Debug("gRPC ECAP:RevokeCertificate")
 
-	return nil, errors.New("ECAP:RevokeCertificate method not (yet) implemented")
+	return revokeEnrollmentCertificate(ecap.eca, in, false)
+}
+
+// ReadCRL reads the last certificate revocation list published by the ECA.
+//
+func (ecap *ECAP) ReadCRL(ctx context.Context, in *pb.Empty) (*pb.CRL, error) {
+	ecapLogger.Debug("gRPC ECAP:ReadCRL")
+
+	raw, err := ecap.eca.readCRL()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CRL{Crl: raw}, nil
 }
@@ -0,0 +1,315 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+)
+
+// Store persists the state of a CA: its users and affiliation groups, the
+// certificates it issued and revoked, and the attributes of the users.
+//
+// Reads of a single record return nil, and no error, if there is no such
+// record.  Lists of records are returned in the order the records were
+// created.
+//
+type Store interface {
+	// CreateUser creates a user, failing if the user is already registered.
+	CreateUser(user *UserRecord) error
+	ReadUser(id string) (*UserRecord, error)
+	ReadUsers() ([]*UserRecord, error)
+	// UpdateUser updates the token, state and key of a user.
+	UpdateUser(user *UserRecord) error
+	// DeleteUser deletes a user along with its certificates.
+	DeleteUser(id string) error
+
+	// CreateAffiliationGroup creates an affiliation group and sets its ID.
+	CreateAffiliationGroup(group *AffiliationGroupRecord) error
+	ReadAffiliationGroups() ([]*AffiliationGroupRecord, error)
+
+	CreateCertificate(cert *CertificateRecord) error
+	ReadCertificates(id string) ([]*CertificateRecord, error)
+	ReadCertificateByHash(hash []byte) (*CertificateRecord, error)
+	DeleteCertificates(id string) error
+
+	// CreateRevokedCertificate records the revocation of a certificate, unless
+	// a certificate with the same serial number was already revoked.
+	CreateRevokedCertificate(cert *RevokedCertificateRecord) error
+	ReadRevokedCertificates() ([]*RevokedCertificateRecord, error)
+	CreateCRL(crl *CRLRecord) error
+	ReadLastCRL() (*CRLRecord, error)
+
+	// CreateCertificateSet records a set of transaction certificates along
+	// with the serial numbers of its certificates.
+	CreateCertificateSet(set *CertificateSetRecord, certs []*TCertificateRecord) error
+	ReadCertificateSets(enrollmentID string) ([]*CertificateSetRecord, error)
+	ReadTCertificates(enrollmentID string) ([]*TCertificateRecord, error)
+	ReadTCertificate(serialNumber string) (*TCertificateRecord, error)
+
+	// PutAttribute creates or updates an attribute.  The change, if not nil,
+	// is recorded in the audit trail along with the attribute.
+	PutAttribute(attr *AttributeRecord, change *AttributeChangeRecord) error
+	ReadAttribute(id, affiliation, name string) (*AttributeRecord, error)
+	// ReadAttributes reads the attributes of a user sorted by name.
+	ReadAttributes(id, affiliation string) ([]*AttributeRecord, error)
+	// DeleteAttribute deletes an attribute and tells whether it existed.  The
+	// change, if not nil, is recorded in the audit trail if it did.
+	DeleteAttribute(id, affiliation, name string, change *AttributeChangeRecord) (bool, error)
+	ReadAttributeChanges(id, affiliation string) ([]*AttributeChangeRecord, error)
+
+	// Dump reads the whole content of the store.
+	Dump() (*StoreDump, error)
+	// Load adds the content of a dump to the store.
+	Load(dump *StoreDump) error
+	Close() error
+}
+
+// UserRecord is a user registered with a CA.
+//
+type UserRecord struct {
+	ID           string
+	EnrollmentID string
+	Role         int
+	Metadata     string
+	Token        []byte
+	State        int
+	Key          []byte
+}
+
+// AffiliationGroupRecord is an affiliation group.  The ID of the parent is 0
+// for the root groups.
+//
+type AffiliationGroupRecord struct {
+	ID       int64
+	Name     string
+	ParentID int64
+}
+
+// CertificateRecord is a certificate issued by a CA.
+//
+type CertificateRecord struct {
+	ID        string
+	Timestamp int64
+	Usage     int
+	Cert      []byte
+	Hash      []byte
+	KDFKey    []byte
+}
+
+// RevokedCertificateRecord is a certificate revoked by a CA.
+//
+type RevokedCertificateRecord struct {
+	ID             string
+	SerialNumber   string
+	RevocationTime int64
+}
+
+// CRLRecord is a certificate revocation list published by a CA.
+//
+type CRLRecord struct {
+	Timestamp int64
+	CRL       []byte
+}
+
+// CertificateSetRecord is a set of transaction certificates issued by the TCA.
+//
+type CertificateSetRecord struct {
+	EnrollmentID string
+	Timestamp    int64
+	Nonce        []byte
+	KDFKey       []byte
+}
+
+// TCertificateRecord is a transaction certificate of a set.
+//
+type TCertificateRecord struct {
+	EnrollmentID string
+	Timestamp    int64
+	SerialNumber string
+}
+
+// AttributeRecord is an attribute of a user.
+//
+type AttributeRecord struct {
+	ID          string
+	Affiliation string
+	Name        string
+	Value       []byte
+	ValidFrom   time.Time
+	ValidTo     time.Time
+}
+
+// AttributeChangeRecord is a change of an attribute made through the ACAA.
+//
+type AttributeChangeRecord struct {
+	Timestamp   int64
+	Registrar   string
+	ID          string
+	Affiliation string
+	Name        string
+	Action      string
+	Value       []byte
+	ValidFrom   time.Time
+	ValidTo     time.Time
+}
+
+// StoreDump is the content of a store, used to migrate the state of a CA from
+// a storage backend to another.
+//
+type StoreDump struct {
+	Users               []*UserRecord
+	AffiliationGroups   []*AffiliationGroupRecord
+	Certificates        []*CertificateRecord
+	RevokedCertificates []*RevokedCertificateRecord
+	CRLs                []*CRLRecord
+	CertificateSets     []*CertificateSetRecord
+	TCertificates       []*TCertificateRecord
+	Attributes          []*AttributeRecord
+	AttributeChanges    []*AttributeChangeRecord
+}
+
+// isEmpty tells whether the dump has no records.
+//
+func (dump *StoreDump) isEmpty() bool {
+	return len(dump.Users) == 0 && len(dump.AffiliationGroups) == 0 && len(dump.Certificates) == 0 &&
+		len(dump.RevokedCertificates) == 0 && len(dump.CRLs) == 0 && len(dump.CertificateSets) == 0 &&
+		len(dump.TCertificates) == 0 && len(dump.Attributes) == 0 && len(dump.AttributeChanges) == 0
+}
+
+// StoreOpener opens or creates the store at path, without its file extension.
+//
+type StoreOpener func(path string) (Store, error)
+
+var storeBackends = map[string]StoreOpener{
+	"sqlite3": openSQLiteStore,
+	"bolt":    openBoltStore,
+}
+
+// RegisterStoreBackend makes a storage backend available under name, to be
+// selected with server.store.backend.
+//
+func RegisterStoreBackend(name string, open StoreOpener) {
+	storeBackends[name] = open
+}
+
+// storeNames are the names of the stores of the CAs.
+var storeNames = []string{"eca", "tca", "tlsca", "aca"}
+
+// OpenStore opens or creates the store of the CA name in the CA state
+// directory, using backend.
+//
+func OpenStore(backend, name string) (Store, error) {
+	return openStore(backend, caStatePath(), name)
+}
+
+func openStore(backend, dir, name string) (Store, error) {
+	open, ok := storeBackends[backend]
+	if !ok {
+		return nil, errors.New("Unknown storage backend " + backend + ".")
+	}
+	return open(dir + "/" + name)
+}
+
+// ExportStores writes the content of the stores of the CAs, using the
+// configured storage backend, to w as JSON.  The CAs must not be running.
+//
+func ExportStores(w io.Writer) error {
+	return exportStores(storeBackend, caStatePath(), w)
+}
+
+// ImportStores loads the content of the stores of the CAs, as written by
+// ExportStores, into the empty stores of the configured storage backend.
+//
+func ImportStores(r io.Reader) error {
+	return importStores(storeBackend, caStatePath(), r)
+}
+
+func exportStores(backend, dir string, w io.Writer) error {
+	dumps := make(map[string]*StoreDump)
+	for _, name := range storeNames {
+		store, err := openStore(backend, dir, name)
+		if err != nil {
+			return err
+		}
+		dumps[name], err = store.Dump()
+		store.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	raw, err := json.MarshalIndent(dumps, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(raw)
+	return err
+}
+
+func importStores(backend, dir string, r io.Reader) error {
+	dumps := make(map[string]*StoreDump)
+	if err := json.NewDecoder(r).Decode(&dumps); err != nil {
+		return err
+	}
+
+	for name := range dumps {
+		if !isStoreName(name) {
+			return errors.New("Unknown CA " + name + ".")
+		}
+	}
+
+	for _, name := range storeNames {
+		dump, ok := dumps[name]
+		if !ok {
+			continue
+		}
+		if err := importStore(backend, dir, name, dump); err != nil {
+			return err
+		}
+		caLogger.Infof("Imported the store of the %s", name)
+	}
+	return nil
+}
+
+func importStore(backend, dir, name string, dump *StoreDump) error {
+	store, err := openStore(backend, dir, name)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	existing, err := store.Dump()
+	if err != nil {
+		return err
+	}
+	if !existing.isEmpty() {
+		return errors.New("The store of the " + name + " is not empty.")
+	}
+	return store.Load(dump)
+}
+
+func isStoreName(name string) bool {
+	for _, storeName := range storeNames {
+		if name == storeName {
+			return true
+		}
+	}
+	return false
+}
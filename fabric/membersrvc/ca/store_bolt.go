@@ -0,0 +1,723 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+// boltStore is the store of a CA in an embedded bolt key-value database.
+//
+// Each kind of record is kept in its own bucket, JSON encoded, under keys
+// increasing in the order the records are created.  Index buckets map the
+// fields records are looked up by to these keys.  Keys of the indexes over
+// several records are made of the field and the key of the record, so that
+// the records are found by prefix.
+//
+type boltStore struct {
+	db   *bolt.DB
+	path string
+}
+
+// Bolt locks its database file, so the stores opened on the same file by a
+// process share the database, which is closed along with the last of them.
+var (
+	boltMutex sync.Mutex
+	boltDBs   = make(map[string]*boltDB)
+)
+
+type boltDB struct {
+	*bolt.DB
+	stores int
+}
+
+var (
+	boltUsers               = []byte("Users")
+	boltUsersByID           = []byte("UsersByID")
+	boltAffiliationGroups   = []byte("AffiliationGroups")
+	boltCertificates        = []byte("Certificates")
+	boltCertificatesByID    = []byte("CertificatesByID")
+	boltCertificatesByHash  = []byte("CertificatesByHash")
+	boltRevokedCertificates = []byte("RevokedCertificates")
+	boltRevokedBySerial     = []byte("RevokedCertificatesBySerialNumber")
+	boltCRLs                = []byte("CRLs")
+	boltCertificateSets     = []byte("TCertificateSets")
+	boltCertificateSetsByID = []byte("TCertificateSetsByEnrollmentID")
+	boltTCertificates       = []byte("TCertificates")
+	boltTCertificatesByID   = []byte("TCertificatesByEnrollmentID")
+	boltTCertificatesBySN   = []byte("TCertificatesBySerialNumber")
+	boltAttributes          = []byte("Attributes")
+	boltAttributesByName    = []byte("AttributesByName")
+	boltAttributeChanges    = []byte("AttributeChanges")
+	boltAttributeChangesBy  = []byte("AttributeChangesByOwner")
+
+	boltBuckets = [][]byte{
+		boltUsers, boltUsersByID, boltAffiliationGroups, boltCertificates, boltCertificatesByID,
+		boltCertificatesByHash, boltRevokedCertificates, boltRevokedBySerial, boltCRLs,
+		boltCertificateSets, boltCertificateSetsByID, boltTCertificates, boltTCertificatesByID,
+		boltTCertificatesBySN, boltAttributes, boltAttributesByName, boltAttributeChanges,
+		boltAttributeChangesBy,
+	}
+)
+
+func openBoltStore(path string) (Store, error) {
+	path += ".bolt"
+
+	boltMutex.Lock()
+	defer boltMutex.Unlock()
+
+	if db, ok := boltDBs[path]; ok {
+		db.stores++
+		return &boltStore{db.DB, path}, nil
+	}
+
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: 10 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range boltBuckets {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	boltDBs[path] = &boltDB{db, 1}
+	return &boltStore{db, path}, nil
+}
+
+// boltKey returns the key of the record number seq of a bucket.
+func boltKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+// boltIndexKey joins the fields of an index key.
+func boltIndexKey(fields ...[]byte) []byte {
+	return bytes.Join(fields, []byte{0})
+}
+
+// boltInsert adds a record to a bucket and returns its key.
+func boltInsert(tx *bolt.Tx, bucket []byte, record interface{}) ([]byte, error) {
+	b := tx.Bucket(bucket)
+	seq, err := b.NextSequence()
+	if err != nil {
+		return nil, err
+	}
+	key := boltKey(seq)
+	return key, boltPut(tx, bucket, key, record)
+}
+
+func boltPut(tx *bolt.Tx, bucket, key []byte, record interface{}) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(bucket).Put(key, value)
+}
+
+// boltGet reads the record under key, and tells whether there is one.
+func boltGet(tx *bolt.Tx, bucket, key []byte, record interface{}) (bool, error) {
+	if key == nil {
+		return false, nil
+	}
+	value := tx.Bucket(bucket).Get(key)
+	if value == nil {
+		return false, nil
+	}
+	return true, json.Unmarshal(value, record)
+}
+
+// boltLookup reads the record an index maps indexKey to.
+func boltLookup(tx *bolt.Tx, index, indexKey, bucket []byte, record interface{}) (bool, error) {
+	return boltGet(tx, bucket, tx.Bucket(index).Get(indexKey), record)
+}
+
+// boltEach calls fn with the records of a bucket.
+func boltEach(tx *bolt.Tx, bucket []byte, fn func(value []byte) error) error {
+	return tx.Bucket(bucket).ForEach(func(key, value []byte) error {
+		return fn(value)
+	})
+}
+
+// boltEachIndexed calls fn with the keys of the records an index maps the keys
+// starting with prefix to.
+func boltEachIndexed(tx *bolt.Tx, index, prefix []byte, fn func(key []byte) error) error {
+	prefix = boltIndexKey(prefix, nil)
+	c := tx.Bucket(index).Cursor()
+	for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *boltStore) CreateUser(user *UserRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(boltUsersByID).Get([]byte(user.ID)) != nil {
+			return errors.New("User is already registered")
+		}
+		return boltInsertUser(tx, user)
+	})
+}
+
+func boltInsertUser(tx *bolt.Tx, user *UserRecord) error {
+	key, err := boltInsert(tx, boltUsers, user)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltUsersByID).Put([]byte(user.ID), key)
+}
+
+func (s *boltStore) ReadUser(id string) (*UserRecord, error) {
+	var user *UserRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		record := &UserRecord{}
+		found, err := boltLookup(tx, boltUsersByID, []byte(id), boltUsers, record)
+		if found {
+			user = record
+		}
+		return err
+	})
+	return user, err
+}
+
+func (s *boltStore) ReadUsers() ([]*UserRecord, error) {
+	var users []*UserRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return boltEach(tx, boltUsers, func(value []byte) error {
+			user := &UserRecord{}
+			users = append(users, user)
+			return json.Unmarshal(value, user)
+		})
+	})
+	return users, err
+}
+
+func (s *boltStore) UpdateUser(user *UserRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := tx.Bucket(boltUsersByID).Get([]byte(user.ID))
+		record := &UserRecord{}
+		found, err := boltGet(tx, boltUsers, key, record)
+		if err != nil || !found {
+			return err
+		}
+		record.Token, record.State, record.Key = user.Token, user.State, user.Key
+		return boltPut(tx, boltUsers, key, record)
+	})
+}
+
+func (s *boltStore) DeleteUser(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := boltDeleteCertificates(tx, id); err != nil {
+			return err
+		}
+		key := append([]byte(nil), tx.Bucket(boltUsersByID).Get([]byte(id))...)
+		if len(key) == 0 {
+			return nil
+		}
+		if err := tx.Bucket(boltUsers).Delete(key); err != nil {
+			return err
+		}
+		return tx.Bucket(boltUsersByID).Delete([]byte(id))
+	})
+}
+
+func (s *boltStore) CreateAffiliationGroup(group *AffiliationGroupRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		seq, err := tx.Bucket(boltAffiliationGroups).NextSequence()
+		if err != nil {
+			return err
+		}
+		group.ID = int64(seq)
+		return boltPut(tx, boltAffiliationGroups, boltKey(seq), group)
+	})
+}
+
+func (s *boltStore) ReadAffiliationGroups() ([]*AffiliationGroupRecord, error) {
+	var groups []*AffiliationGroupRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return boltEach(tx, boltAffiliationGroups, func(value []byte) error {
+			group := &AffiliationGroupRecord{}
+			groups = append(groups, group)
+			return json.Unmarshal(value, group)
+		})
+	})
+	return groups, err
+}
+
+func (s *boltStore) CreateCertificate(cert *CertificateRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return boltInsertCertificate(tx, cert)
+	})
+}
+
+func boltInsertCertificate(tx *bolt.Tx, cert *CertificateRecord) error {
+	key, err := boltInsert(tx, boltCertificates, cert)
+	if err != nil {
+		return err
+	}
+	if err = tx.Bucket(boltCertificatesByID).Put(boltIndexKey([]byte(cert.ID), key), key); err != nil {
+		return err
+	}
+	// The first certificate with a hash is the one found by hash
+	if len(cert.Hash) == 0 || tx.Bucket(boltCertificatesByHash).Get(cert.Hash) != nil {
+		return nil
+	}
+	return tx.Bucket(boltCertificatesByHash).Put(cert.Hash, key)
+}
+
+func (s *boltStore) ReadCertificates(id string) ([]*CertificateRecord, error) {
+	var certs []*CertificateRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return boltEachIndexed(tx, boltCertificatesByID, []byte(id), func(key []byte) error {
+			cert := &CertificateRecord{}
+			certs = append(certs, cert)
+			_, err := boltGet(tx, boltCertificates, key, cert)
+			return err
+		})
+	})
+	return certs, err
+}
+
+func (s *boltStore) ReadCertificateByHash(hash []byte) (*CertificateRecord, error) {
+	var cert *CertificateRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		record := &CertificateRecord{}
+		found, err := boltLookup(tx, boltCertificatesByHash, hash, boltCertificates, record)
+		if found {
+			cert = record
+		}
+		return err
+	})
+	return cert, err
+}
+
+func (s *boltStore) DeleteCertificates(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return boltDeleteCertificates(tx, id)
+	})
+}
+
+func boltDeleteCertificates(tx *bolt.Tx, id string) error {
+	var keys [][]byte
+	err := boltEachIndexed(tx, boltCertificatesByID, []byte(id), func(key []byte) error {
+		keys = append(keys, append([]byte(nil), key...))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		cert := &CertificateRecord{}
+		if _, err = boltGet(tx, boltCertificates, key, cert); err != nil {
+			return err
+		}
+		if bytes.Equal(tx.Bucket(boltCertificatesByHash).Get(cert.Hash), key) {
+			if err = tx.Bucket(boltCertificatesByHash).Delete(cert.Hash); err != nil {
+				return err
+			}
+		}
+		if err = tx.Bucket(boltCertificatesByID).Delete(boltIndexKey([]byte(id), key)); err != nil {
+			return err
+		}
+		if err = tx.Bucket(boltCertificates).Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *boltStore) CreateRevokedCertificate(cert *RevokedCertificateRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if tx.Bucket(boltRevokedBySerial).Get([]byte(cert.SerialNumber)) != nil {
+			return nil
+		}
+		return boltInsertRevokedCertificate(tx, cert)
+	})
+}
+
+func boltInsertRevokedCertificate(tx *bolt.Tx, cert *RevokedCertificateRecord) error {
+	key, err := boltInsert(tx, boltRevokedCertificates, cert)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltRevokedBySerial).Put([]byte(cert.SerialNumber), key)
+}
+
+func (s *boltStore) ReadRevokedCertificates() ([]*RevokedCertificateRecord, error) {
+	var certs []*RevokedCertificateRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return boltEach(tx, boltRevokedCertificates, func(value []byte) error {
+			cert := &RevokedCertificateRecord{}
+			certs = append(certs, cert)
+			return json.Unmarshal(value, cert)
+		})
+	})
+	return certs, err
+}
+
+func (s *boltStore) CreateCRL(crl *CRLRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		_, err := boltInsert(tx, boltCRLs, crl)
+		return err
+	})
+}
+
+func (s *boltStore) ReadLastCRL() (*CRLRecord, error) {
+	var crl *CRLRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		_, value := tx.Bucket(boltCRLs).Cursor().Last()
+		if value == nil {
+			return nil
+		}
+		crl = &CRLRecord{}
+		return json.Unmarshal(value, crl)
+	})
+	return crl, err
+}
+
+func (s *boltStore) CreateCertificateSet(set *CertificateSetRecord, certs []*TCertificateRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := boltInsertCertificateSet(tx, set); err != nil {
+			return err
+		}
+		for _, cert := range certs {
+			if err := boltInsertTCertificate(tx, cert); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func boltInsertCertificateSet(tx *bolt.Tx, set *CertificateSetRecord) error {
+	key, err := boltInsert(tx, boltCertificateSets, set)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltCertificateSetsByID).Put(boltIndexKey([]byte(set.EnrollmentID), key), key)
+}
+
+func boltInsertTCertificate(tx *bolt.Tx, cert *TCertificateRecord) error {
+	key, err := boltInsert(tx, boltTCertificates, cert)
+	if err != nil {
+		return err
+	}
+	if err = tx.Bucket(boltTCertificatesByID).Put(boltIndexKey([]byte(cert.EnrollmentID), key), key); err != nil {
+		return err
+	}
+	return tx.Bucket(boltTCertificatesBySN).Put([]byte(cert.SerialNumber), key)
+}
+
+func (s *boltStore) ReadCertificateSets(enrollmentID string) ([]*CertificateSetRecord, error) {
+	var sets []*CertificateSetRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return boltEachIndexed(tx, boltCertificateSetsByID, []byte(enrollmentID), func(key []byte) error {
+			set := &CertificateSetRecord{}
+			sets = append(sets, set)
+			_, err := boltGet(tx, boltCertificateSets, key, set)
+			return err
+		})
+	})
+	return sets, err
+}
+
+func (s *boltStore) ReadTCertificates(enrollmentID string) ([]*TCertificateRecord, error) {
+	var certs []*TCertificateRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return boltEachIndexed(tx, boltTCertificatesByID, []byte(enrollmentID), func(key []byte) error {
+			cert := &TCertificateRecord{}
+			certs = append(certs, cert)
+			_, err := boltGet(tx, boltTCertificates, key, cert)
+			return err
+		})
+	})
+	return certs, err
+}
+
+func (s *boltStore) ReadTCertificate(serialNumber string) (*TCertificateRecord, error) {
+	var cert *TCertificateRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		record := &TCertificateRecord{}
+		found, err := boltLookup(tx, boltTCertificatesBySN, []byte(serialNumber), boltTCertificates, record)
+		if found {
+			cert = record
+		}
+		return err
+	})
+	return cert, err
+}
+
+func boltAttributeKey(id, affiliation, name string) []byte {
+	return boltIndexKey([]byte(id), []byte(affiliation), []byte(name))
+}
+
+func (s *boltStore) PutAttribute(attr *AttributeRecord, change *AttributeChangeRecord) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		key := tx.Bucket(boltAttributesByName).Get(boltAttributeKey(attr.ID, attr.Affiliation, attr.Name))
+		var err error
+		if key != nil {
+			err = boltPut(tx, boltAttributes, key, attr)
+		} else {
+			err = boltInsertAttribute(tx, attr)
+		}
+		if err != nil || change == nil {
+			return err
+		}
+		return boltInsertAttributeChange(tx, change)
+	})
+}
+
+func boltInsertAttribute(tx *bolt.Tx, attr *AttributeRecord) error {
+	key, err := boltInsert(tx, boltAttributes, attr)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltAttributesByName).Put(boltAttributeKey(attr.ID, attr.Affiliation, attr.Name), key)
+}
+
+func boltInsertAttributeChange(tx *bolt.Tx, change *AttributeChangeRecord) error {
+	key, err := boltInsert(tx, boltAttributeChanges, change)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltAttributeChangesBy).Put(boltIndexKey([]byte(change.ID), []byte(change.Affiliation), key), key)
+}
+
+func (s *boltStore) ReadAttribute(id, affiliation, name string) (*AttributeRecord, error) {
+	var attr *AttributeRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		record := &AttributeRecord{}
+		found, err := boltLookup(tx, boltAttributesByName, boltAttributeKey(id, affiliation, name), boltAttributes, record)
+		if found {
+			attr = record
+		}
+		return err
+	})
+	return attr, err
+}
+
+func (s *boltStore) ReadAttributes(id, affiliation string) ([]*AttributeRecord, error) {
+	var attrs []*AttributeRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// The index is sorted by attribute name
+		prefix := boltIndexKey([]byte(id), []byte(affiliation))
+		return boltEachIndexed(tx, boltAttributesByName, prefix, func(key []byte) error {
+			attr := &AttributeRecord{}
+			attrs = append(attrs, attr)
+			_, err := boltGet(tx, boltAttributes, key, attr)
+			return err
+		})
+	})
+	return attrs, err
+}
+
+func (s *boltStore) DeleteAttribute(id, affiliation, name string, change *AttributeChangeRecord) (bool, error) {
+	var found bool
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		indexKey := boltAttributeKey(id, affiliation, name)
+		key := append([]byte(nil), tx.Bucket(boltAttributesByName).Get(indexKey)...)
+		if len(key) == 0 {
+			return nil
+		}
+		found = true
+		if err := tx.Bucket(boltAttributes).Delete(key); err != nil {
+			return err
+		}
+		if err := tx.Bucket(boltAttributesByName).Delete(indexKey); err != nil {
+			return err
+		}
+		if change == nil {
+			return nil
+		}
+		return boltInsertAttributeChange(tx, change)
+	})
+	return found && err == nil, err
+}
+
+func (s *boltStore) ReadAttributeChanges(id, affiliation string) ([]*AttributeChangeRecord, error) {
+	var changes []*AttributeChangeRecord
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := boltIndexKey([]byte(id), []byte(affiliation))
+		return boltEachIndexed(tx, boltAttributeChangesBy, prefix, func(key []byte) error {
+			change := &AttributeChangeRecord{}
+			changes = append(changes, change)
+			_, err := boltGet(tx, boltAttributeChanges, key, change)
+			return err
+		})
+	})
+	return changes, err
+}
+
+func (s *boltStore) Dump() (*StoreDump, error) {
+	dump := &StoreDump{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		buckets := []struct {
+			name []byte
+			add  func(value []byte) error
+		}{
+			{boltUsers, func(value []byte) error {
+				user := &UserRecord{}
+				dump.Users = append(dump.Users, user)
+				return json.Unmarshal(value, user)
+			}},
+			{boltAffiliationGroups, func(value []byte) error {
+				group := &AffiliationGroupRecord{}
+				dump.AffiliationGroups = append(dump.AffiliationGroups, group)
+				return json.Unmarshal(value, group)
+			}},
+			{boltCertificates, func(value []byte) error {
+				cert := &CertificateRecord{}
+				dump.Certificates = append(dump.Certificates, cert)
+				return json.Unmarshal(value, cert)
+			}},
+			{boltRevokedCertificates, func(value []byte) error {
+				cert := &RevokedCertificateRecord{}
+				dump.RevokedCertificates = append(dump.RevokedCertificates, cert)
+				return json.Unmarshal(value, cert)
+			}},
+			{boltCRLs, func(value []byte) error {
+				crl := &CRLRecord{}
+				dump.CRLs = append(dump.CRLs, crl)
+				return json.Unmarshal(value, crl)
+			}},
+			{boltCertificateSets, func(value []byte) error {
+				set := &CertificateSetRecord{}
+				dump.CertificateSets = append(dump.CertificateSets, set)
+				return json.Unmarshal(value, set)
+			}},
+			{boltTCertificates, func(value []byte) error {
+				cert := &TCertificateRecord{}
+				dump.TCertificates = append(dump.TCertificates, cert)
+				return json.Unmarshal(value, cert)
+			}},
+			{boltAttributes, func(value []byte) error {
+				attr := &AttributeRecord{}
+				dump.Attributes = append(dump.Attributes, attr)
+				return json.Unmarshal(value, attr)
+			}},
+			{boltAttributeChanges, func(value []byte) error {
+				change := &AttributeChangeRecord{}
+				dump.AttributeChanges = append(dump.AttributeChanges, change)
+				return json.Unmarshal(value, change)
+			}},
+		}
+		for _, bucket := range buckets {
+			if err := boltEach(tx, bucket.name, bucket.add); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+func (s *boltStore) Load(dump *StoreDump) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, user := range dump.Users {
+			if err := boltInsertUser(tx, user); err != nil {
+				return err
+			}
+		}
+		// The IDs of the groups are kept as the children refer to their
+		// parent by ID
+		groups := tx.Bucket(boltAffiliationGroups)
+		for _, group := range dump.AffiliationGroups {
+			if group.ID <= 0 {
+				return errors.New("Invalid affiliation group " + group.Name + ".")
+			}
+			if err := boltPut(tx, boltAffiliationGroups, boltKey(uint64(group.ID)), group); err != nil {
+				return err
+			}
+			if uint64(group.ID) > groups.Sequence() {
+				if err := groups.SetSequence(uint64(group.ID)); err != nil {
+					return err
+				}
+			}
+		}
+		for _, cert := range dump.Certificates {
+			if err := boltInsertCertificate(tx, cert); err != nil {
+				return err
+			}
+		}
+		for _, cert := range dump.RevokedCertificates {
+			if err := boltInsertRevokedCertificate(tx, cert); err != nil {
+				return err
+			}
+		}
+		for _, crl := range dump.CRLs {
+			if _, err := boltInsert(tx, boltCRLs, crl); err != nil {
+				return err
+			}
+		}
+		for _, set := range dump.CertificateSets {
+			if err := boltInsertCertificateSet(tx, set); err != nil {
+				return err
+			}
+		}
+		for _, cert := range dump.TCertificates {
+			if err := boltInsertTCertificate(tx, cert); err != nil {
+				return err
+			}
+		}
+		for _, attr := range dump.Attributes {
+			if err := boltInsertAttribute(tx, attr); err != nil {
+				return err
+			}
+		}
+		for _, change := range dump.AttributeChanges {
+			if err := boltInsertAttributeChange(tx, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *boltStore) Close() error {
+	boltMutex.Lock()
+	defer boltMutex.Unlock()
+
+	db, ok := boltDBs[s.path]
+	if !ok || db.DB != s.db {
+		return errors.New("Store is already closed.")
+	}
+	if db.stores--; db.stores > 0 {
+		return nil
+	}
+	delete(boltDBs, s.path)
+	return db.Close()
+}
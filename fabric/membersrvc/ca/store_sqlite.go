@@ -0,0 +1,579 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"database/sql"
+	"errors"
+
+	_ "github.com/mattn/go-sqlite3" // This blank import is required to load sqlite3 driver
+)
+
+// sqliteStore is the store of a CA in an embedded sqlite database.
+//
+type sqliteStore struct {
+	db *sql.DB
+}
+
+func openSQLiteStore(path string) (Store, error) {
+	db, err := sql.Open("sqlite3", path+".db")
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err = initializeTables(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteStore{db}, nil
+}
+
+func initializeTables(db *sql.DB) error {
+	tables := []string{
+		"Certificates (row INTEGER PRIMARY KEY, id VARCHAR(64), timestamp INTEGER, usage INTEGER, cert BLOB, hash BLOB, kdfkey BLOB)",
+		"Users (row INTEGER PRIMARY KEY, id VARCHAR(64), enrollmentId VARCHAR(100), role INTEGER, metadata VARCHAR(256), token BLOB, state INTEGER, key BLOB)",
+		"AffiliationGroups (row INTEGER PRIMARY KEY, name VARCHAR(64), parent INTEGER, FOREIGN KEY(parent) REFERENCES AffiliationGroups(row))",
+		"RevokedCertificates (row INTEGER PRIMARY KEY, id VARCHAR(64), serialNumber VARCHAR(64), revocationTime INTEGER)",
+		"CRLs (row INTEGER PRIMARY KEY, timestamp INTEGER, crl BLOB)",
+		"TCertificateSets (row INTEGER PRIMARY KEY, enrollmentID VARCHAR(64), timestamp INTEGER, nonce BLOB, kdfkey BLOB)",
+		"TCertificates (row INTEGER PRIMARY KEY, enrollmentID VARCHAR(64), timestamp INTEGER, serialNumber VARCHAR(64))",
+		"Attributes (row INTEGER PRIMARY KEY, id VARCHAR(64), affiliation VARCHAR(64), attributeName VARCHAR(64), validFrom DATETIME, validTo DATETIME,  attributeValue BLOB)",
+		"AttributeChanges (row INTEGER PRIMARY KEY, timestamp INTEGER, registrar VARCHAR(64), id VARCHAR(64), affiliation VARCHAR(64), attributeName VARCHAR(64), action VARCHAR(16), validFrom DATETIME, validTo DATETIME, attributeValue BLOB)",
+	}
+	for _, table := range tables {
+		if _, err := db.Exec("CREATE TABLE IF NOT EXISTS " + table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	userColumns               = "id, enrollmentId, role, metadata, token, state, key"
+	certificateColumns        = "id, timestamp, usage, cert, hash, kdfkey"
+	certificateSetColumns     = "enrollmentID, timestamp, nonce, kdfkey"
+	tcertificateColumns       = "enrollmentID, timestamp, serialNumber"
+	attributeColumns          = "id, affiliation, attributeName, attributeValue, validFrom, validTo"
+	attributeChangeColumns    = "timestamp, registrar, id, affiliation, attributeName, action, attributeValue, validFrom, validTo"
+	revokedCertificateColumns = "id, serialNumber, revocationTime"
+)
+
+// scanner is implemented by sql.Row and sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// execer is implemented by sql.DB and sql.Tx.
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+func scanUser(row scanner) (*UserRecord, error) {
+	user := &UserRecord{}
+	var metadata sql.NullString
+	err := row.Scan(&user.ID, &user.EnrollmentID, &user.Role, &metadata, &user.Token, &user.State, &user.Key)
+	user.Metadata = metadata.String
+	return user, err
+}
+
+func scanCertificate(row scanner) (*CertificateRecord, error) {
+	cert := &CertificateRecord{}
+	err := row.Scan(&cert.ID, &cert.Timestamp, &cert.Usage, &cert.Cert, &cert.Hash, &cert.KDFKey)
+	return cert, err
+}
+
+func scanCertificateSet(row scanner) (*CertificateSetRecord, error) {
+	set := &CertificateSetRecord{}
+	err := row.Scan(&set.EnrollmentID, &set.Timestamp, &set.Nonce, &set.KDFKey)
+	return set, err
+}
+
+func scanTCertificate(row scanner) (*TCertificateRecord, error) {
+	cert := &TCertificateRecord{}
+	err := row.Scan(&cert.EnrollmentID, &cert.Timestamp, &cert.SerialNumber)
+	return cert, err
+}
+
+func scanAttribute(row scanner) (*AttributeRecord, error) {
+	attr := &AttributeRecord{}
+	err := row.Scan(&attr.ID, &attr.Affiliation, &attr.Name, &attr.Value, &attr.ValidFrom, &attr.ValidTo)
+	return attr, err
+}
+
+func scanAttributeChange(row scanner) (*AttributeChangeRecord, error) {
+	change := &AttributeChangeRecord{}
+	err := row.Scan(&change.Timestamp, &change.Registrar, &change.ID, &change.Affiliation, &change.Name, &change.Action, &change.Value, &change.ValidFrom, &change.ValidTo)
+	return change, err
+}
+
+// queryAll reads the records returned by a query with scan, calling add for
+// each of them.
+func queryAll(db *sql.DB, add func(scanner) error, query string, args ...interface{}) error {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err = add(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *sqliteStore) CreateUser(user *UserRecord) error {
+	existing, err := s.ReadUser(user.ID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.New("User is already registered")
+	}
+	return insertUser(s.db, user)
+}
+
+func insertUser(db execer, user *UserRecord) error {
+	_, err := db.Exec("INSERT INTO Users ("+userColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		user.ID, user.EnrollmentID, user.Role, user.Metadata, user.Token, user.State, user.Key)
+	return err
+}
+
+func (s *sqliteStore) ReadUser(id string) (*UserRecord, error) {
+	user, err := scanUser(s.db.QueryRow("SELECT "+userColumns+" FROM Users WHERE id=?", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *sqliteStore) ReadUsers() ([]*UserRecord, error) {
+	var users []*UserRecord
+	err := queryAll(s.db, func(row scanner) error {
+		user, err := scanUser(row)
+		users = append(users, user)
+		return err
+	}, "SELECT "+userColumns+" FROM Users ORDER BY row")
+	return users, err
+}
+
+func (s *sqliteStore) UpdateUser(user *UserRecord) error {
+	_, err := s.db.Exec("UPDATE Users SET token=?, state=?, key=? WHERE id=?", user.Token, user.State, user.Key, user.ID)
+	return err
+}
+
+func (s *sqliteStore) DeleteUser(id string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM Certificates WHERE id=?", id); err == nil {
+		_, err = tx.Exec("DELETE FROM Users WHERE id=?", id)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqliteStore) CreateAffiliationGroup(group *AffiliationGroupRecord) error {
+	res, err := s.db.Exec("INSERT INTO AffiliationGroups (name, parent) VALUES (?, ?)", group.Name, group.ParentID)
+	if err != nil {
+		return err
+	}
+	group.ID, err = res.LastInsertId()
+	return err
+}
+
+func (s *sqliteStore) ReadAffiliationGroups() ([]*AffiliationGroupRecord, error) {
+	var groups []*AffiliationGroupRecord
+	err := queryAll(s.db, func(row scanner) error {
+		group := &AffiliationGroupRecord{}
+		groups = append(groups, group)
+		return row.Scan(&group.ID, &group.Name, &group.ParentID)
+	}, "SELECT row, name, parent FROM AffiliationGroups ORDER BY row")
+	return groups, err
+}
+
+func (s *sqliteStore) CreateCertificate(cert *CertificateRecord) error {
+	return insertCertificate(s.db, cert)
+}
+
+func insertCertificate(db execer, cert *CertificateRecord) error {
+	_, err := db.Exec("INSERT INTO Certificates ("+certificateColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		cert.ID, cert.Timestamp, cert.Usage, cert.Cert, cert.Hash, cert.KDFKey)
+	return err
+}
+
+func (s *sqliteStore) ReadCertificates(id string) ([]*CertificateRecord, error) {
+	return s.readCertificates("SELECT "+certificateColumns+" FROM Certificates WHERE id=? ORDER BY row", id)
+}
+
+func (s *sqliteStore) readCertificates(query string, args ...interface{}) ([]*CertificateRecord, error) {
+	var certs []*CertificateRecord
+	err := queryAll(s.db, func(row scanner) error {
+		cert, err := scanCertificate(row)
+		certs = append(certs, cert)
+		return err
+	}, query, args...)
+	return certs, err
+}
+
+func (s *sqliteStore) ReadCertificateByHash(hash []byte) (*CertificateRecord, error) {
+	cert, err := scanCertificate(s.db.QueryRow("SELECT "+certificateColumns+" FROM Certificates WHERE hash=?", hash))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (s *sqliteStore) DeleteCertificates(id string) error {
+	_, err := s.db.Exec("DELETE FROM Certificates WHERE id=?", id)
+	return err
+}
+
+func (s *sqliteStore) CreateRevokedCertificate(cert *RevokedCertificateRecord) error {
+	var count int
+	err := s.db.QueryRow("SELECT count(row) FROM RevokedCertificates WHERE serialNumber=?", cert.SerialNumber).Scan(&count)
+	if err != nil || count > 0 {
+		return err
+	}
+	return insertRevokedCertificate(s.db, cert)
+}
+
+func insertRevokedCertificate(db execer, cert *RevokedCertificateRecord) error {
+	_, err := db.Exec("INSERT INTO RevokedCertificates ("+revokedCertificateColumns+") VALUES (?, ?, ?)",
+		cert.ID, cert.SerialNumber, cert.RevocationTime)
+	return err
+}
+
+func (s *sqliteStore) ReadRevokedCertificates() ([]*RevokedCertificateRecord, error) {
+	var certs []*RevokedCertificateRecord
+	err := queryAll(s.db, func(row scanner) error {
+		cert := &RevokedCertificateRecord{}
+		certs = append(certs, cert)
+		return row.Scan(&cert.ID, &cert.SerialNumber, &cert.RevocationTime)
+	}, "SELECT "+revokedCertificateColumns+" FROM RevokedCertificates ORDER BY row")
+	return certs, err
+}
+
+func (s *sqliteStore) CreateCRL(crl *CRLRecord) error {
+	return insertCRL(s.db, crl)
+}
+
+func insertCRL(db execer, crl *CRLRecord) error {
+	_, err := db.Exec("INSERT INTO CRLs (timestamp, crl) VALUES (?, ?)", crl.Timestamp, crl.CRL)
+	return err
+}
+
+func (s *sqliteStore) ReadLastCRL() (*CRLRecord, error) {
+	crl := &CRLRecord{}
+	err := s.db.QueryRow("SELECT timestamp, crl FROM CRLs ORDER BY row DESC LIMIT 1").Scan(&crl.Timestamp, &crl.CRL)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return crl, nil
+}
+
+func (s *sqliteStore) readCRLs() ([]*CRLRecord, error) {
+	var crls []*CRLRecord
+	err := queryAll(s.db, func(row scanner) error {
+		crl := &CRLRecord{}
+		crls = append(crls, crl)
+		return row.Scan(&crl.Timestamp, &crl.CRL)
+	}, "SELECT timestamp, crl FROM CRLs ORDER BY row")
+	return crls, err
+}
+
+func (s *sqliteStore) CreateCertificateSet(set *CertificateSetRecord, certs []*TCertificateRecord) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = insertCertificateSet(tx, set); err == nil {
+		for _, cert := range certs {
+			if err = insertTCertificate(tx, cert); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertCertificateSet(db execer, set *CertificateSetRecord) error {
+	_, err := db.Exec("INSERT INTO TCertificateSets ("+certificateSetColumns+") VALUES (?, ?, ?, ?)",
+		set.EnrollmentID, set.Timestamp, set.Nonce, set.KDFKey)
+	return err
+}
+
+func insertTCertificate(db execer, cert *TCertificateRecord) error {
+	_, err := db.Exec("INSERT INTO TCertificates ("+tcertificateColumns+") VALUES (?, ?, ?)",
+		cert.EnrollmentID, cert.Timestamp, cert.SerialNumber)
+	return err
+}
+
+func (s *sqliteStore) ReadCertificateSets(enrollmentID string) ([]*CertificateSetRecord, error) {
+	return s.readCertificateSets("SELECT "+certificateSetColumns+" FROM TCertificateSets WHERE enrollmentID=? ORDER BY row", enrollmentID)
+}
+
+func (s *sqliteStore) readCertificateSets(query string, args ...interface{}) ([]*CertificateSetRecord, error) {
+	var sets []*CertificateSetRecord
+	err := queryAll(s.db, func(row scanner) error {
+		set, err := scanCertificateSet(row)
+		sets = append(sets, set)
+		return err
+	}, query, args...)
+	return sets, err
+}
+
+func (s *sqliteStore) ReadTCertificates(enrollmentID string) ([]*TCertificateRecord, error) {
+	return s.readTCertificates("SELECT "+tcertificateColumns+" FROM TCertificates WHERE enrollmentID=? ORDER BY row", enrollmentID)
+}
+
+func (s *sqliteStore) readTCertificates(query string, args ...interface{}) ([]*TCertificateRecord, error) {
+	var certs []*TCertificateRecord
+	err := queryAll(s.db, func(row scanner) error {
+		cert, err := scanTCertificate(row)
+		certs = append(certs, cert)
+		return err
+	}, query, args...)
+	return certs, err
+}
+
+func (s *sqliteStore) ReadTCertificate(serialNumber string) (*TCertificateRecord, error) {
+	cert, err := scanTCertificate(s.db.QueryRow("SELECT "+tcertificateColumns+" FROM TCertificates WHERE serialNumber=?", serialNumber))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (s *sqliteStore) PutAttribute(attr *AttributeRecord, change *AttributeChangeRecord) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	var res sql.Result
+	res, err = tx.Exec("UPDATE Attributes SET validFrom=?, validTo=?, attributeValue=? WHERE id=? AND affiliation=? AND attributeName=?",
+		attr.ValidFrom, attr.ValidTo, attr.Value, attr.ID, attr.Affiliation, attr.Name)
+	if err == nil {
+		var n int64
+		if n, err = res.RowsAffected(); err == nil && n == 0 {
+			err = insertAttribute(tx, attr)
+		}
+	}
+	if err == nil && change != nil {
+		err = insertAttributeChange(tx, change)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertAttribute(db execer, attr *AttributeRecord) error {
+	_, err := db.Exec("INSERT INTO Attributes ("+attributeColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		attr.ID, attr.Affiliation, attr.Name, attr.Value, attr.ValidFrom, attr.ValidTo)
+	return err
+}
+
+func insertAttributeChange(db execer, change *AttributeChangeRecord) error {
+	_, err := db.Exec("INSERT INTO AttributeChanges ("+attributeChangeColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		change.Timestamp, change.Registrar, change.ID, change.Affiliation, change.Name, change.Action, change.Value, change.ValidFrom, change.ValidTo)
+	return err
+}
+
+func (s *sqliteStore) ReadAttribute(id, affiliation, name string) (*AttributeRecord, error) {
+	attr, err := scanAttribute(s.db.QueryRow("SELECT "+attributeColumns+" FROM Attributes WHERE id=? AND affiliation=? AND attributeName=?", id, affiliation, name))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attr, nil
+}
+
+func (s *sqliteStore) ReadAttributes(id, affiliation string) ([]*AttributeRecord, error) {
+	return s.readAttributes("SELECT "+attributeColumns+" FROM Attributes WHERE id=? AND affiliation=? ORDER BY attributeName", id, affiliation)
+}
+
+func (s *sqliteStore) readAttributes(query string, args ...interface{}) ([]*AttributeRecord, error) {
+	var attrs []*AttributeRecord
+	err := queryAll(s.db, func(row scanner) error {
+		attr, err := scanAttribute(row)
+		attrs = append(attrs, attr)
+		return err
+	}, query, args...)
+	return attrs, err
+}
+
+func (s *sqliteStore) DeleteAttribute(id, affiliation, name string, change *AttributeChangeRecord) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	var res sql.Result
+	var n int64
+	res, err = tx.Exec("DELETE FROM Attributes WHERE id=? AND affiliation=? AND attributeName=?", id, affiliation, name)
+	if err == nil {
+		n, err = res.RowsAffected()
+	}
+	if err == nil && n > 0 && change != nil {
+		err = insertAttributeChange(tx, change)
+	}
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	return n > 0, tx.Commit()
+}
+
+func (s *sqliteStore) ReadAttributeChanges(id, affiliation string) ([]*AttributeChangeRecord, error) {
+	return s.readAttributeChanges("SELECT "+attributeChangeColumns+" FROM AttributeChanges WHERE id=? AND affiliation=? ORDER BY row", id, affiliation)
+}
+
+func (s *sqliteStore) readAttributeChanges(query string, args ...interface{}) ([]*AttributeChangeRecord, error) {
+	var changes []*AttributeChangeRecord
+	err := queryAll(s.db, func(row scanner) error {
+		change, err := scanAttributeChange(row)
+		changes = append(changes, change)
+		return err
+	}, query, args...)
+	return changes, err
+}
+
+func (s *sqliteStore) Dump() (*StoreDump, error) {
+	dump := &StoreDump{}
+	var err error
+	if dump.Users, err = s.ReadUsers(); err != nil {
+		return nil, err
+	}
+	if dump.AffiliationGroups, err = s.ReadAffiliationGroups(); err != nil {
+		return nil, err
+	}
+	if dump.Certificates, err = s.readCertificates("SELECT " + certificateColumns + " FROM Certificates ORDER BY row"); err != nil {
+		return nil, err
+	}
+	if dump.RevokedCertificates, err = s.ReadRevokedCertificates(); err != nil {
+		return nil, err
+	}
+	if dump.CRLs, err = s.readCRLs(); err != nil {
+		return nil, err
+	}
+	if dump.CertificateSets, err = s.readCertificateSets("SELECT " + certificateSetColumns + " FROM TCertificateSets ORDER BY row"); err != nil {
+		return nil, err
+	}
+	if dump.TCertificates, err = s.readTCertificates("SELECT " + tcertificateColumns + " FROM TCertificates ORDER BY row"); err != nil {
+		return nil, err
+	}
+	if dump.Attributes, err = s.readAttributes("SELECT " + attributeColumns + " FROM Attributes ORDER BY row"); err != nil {
+		return nil, err
+	}
+	if dump.AttributeChanges, err = s.readAttributeChanges("SELECT " + attributeChangeColumns + " FROM AttributeChanges ORDER BY row"); err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+func (s *sqliteStore) Load(dump *StoreDump) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err = loadSQLite(tx, dump); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func loadSQLite(tx *sql.Tx, dump *StoreDump) error {
+	for _, user := range dump.Users {
+		if err := insertUser(tx, user); err != nil {
+			return err
+		}
+	}
+	// The IDs of the groups are kept as the children refer to their parent by ID
+	for _, group := range dump.AffiliationGroups {
+		if _, err := tx.Exec("INSERT INTO AffiliationGroups (row, name, parent) VALUES (?, ?, ?)", group.ID, group.Name, group.ParentID); err != nil {
+			return err
+		}
+	}
+	for _, cert := range dump.Certificates {
+		if err := insertCertificate(tx, cert); err != nil {
+			return err
+		}
+	}
+	for _, cert := range dump.RevokedCertificates {
+		if err := insertRevokedCertificate(tx, cert); err != nil {
+			return err
+		}
+	}
+	for _, crl := range dump.CRLs {
+		if err := insertCRL(tx, crl); err != nil {
+			return err
+		}
+	}
+	for _, set := range dump.CertificateSets {
+		if err := insertCertificateSet(tx, set); err != nil {
+			return err
+		}
+	}
+	for _, cert := range dump.TCertificates {
+		if err := insertTCertificate(tx, cert); err != nil {
+			return err
+		}
+	}
+	for _, attr := range dump.Attributes {
+		if err := insertAttribute(tx, attr); err != nil {
+			return err
+		}
+	}
+	for _, change := range dump.AttributeChanges {
+		if err := insertAttributeChange(tx, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,150 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ca
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func fillStore(t *testing.T, store Store) {
+	users := []*UserRecord{
+		{ID: "admin", EnrollmentID: "admin", Role: 15, Metadata: `{"registrar":{"roles":["client"]}}`, Token: []byte("secret"), State: 2, Key: []byte{1, 2, 3}},
+		{ID: "alice", EnrollmentID: "alice", Role: 1, Token: []byte("password"), State: 0},
+	}
+	for _, user := range users {
+		if err := store.CreateUser(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bank := &AffiliationGroupRecord{Name: "bank_a"}
+	if err := store.CreateAffiliationGroup(bank); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateAffiliationGroup(&AffiliationGroupRecord{Name: "00001", ParentID: bank.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	certs := []*CertificateRecord{
+		{ID: "admin", Timestamp: 1, Usage: 1, Cert: []byte("sign"), Hash: []byte("hash1")},
+		{ID: "admin", Timestamp: 1, Usage: 4, Cert: []byte("enc"), Hash: []byte("hash2")},
+		{ID: "alice", Timestamp: 2, Usage: 1, Cert: []byte("tcert"), Hash: []byte("hash3"), KDFKey: []byte("kdf")},
+	}
+	for _, cert := range certs {
+		if err := store.CreateCertificate(cert); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.CreateRevokedCertificate(&RevokedCertificateRecord{ID: "alice", SerialNumber: "12345678901234567890", RevocationTime: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.CreateCRL(&CRLRecord{Timestamp: 4, CRL: []byte("crl")}); err != nil {
+		t.Fatal(err)
+	}
+
+	set := &CertificateSetRecord{EnrollmentID: "alice", Timestamp: 5, Nonce: []byte("nonce"), KDFKey: []byte("kdf")}
+	tcerts := []*TCertificateRecord{
+		{EnrollmentID: "alice", Timestamp: 5, SerialNumber: "2"},
+		{EnrollmentID: "alice", Timestamp: 5, SerialNumber: "3"},
+	}
+	if err := store.CreateCertificateSet(set, tcerts); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2017, 1, 1, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	if err := store.PutAttribute(&AttributeRecord{ID: "alice", Affiliation: "bank_a", Name: "company", Value: []byte("ACompany"), ValidFrom: from}, nil); err != nil {
+		t.Fatal(err)
+	}
+	change := &AttributeChangeRecord{Timestamp: 6, Registrar: "admin", ID: "alice", Affiliation: "bank_a", Name: "position", Action: "set", Value: []byte("Software Engineer"), ValidFrom: from, ValidTo: to}
+	if err := store.PutAttribute(&AttributeRecord{ID: "alice", Affiliation: "bank_a", Name: "position", Value: []byte("Software Engineer"), ValidFrom: from, ValidTo: to}, change); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func fillStores(t *testing.T, backend, dir string) {
+	for _, name := range storeNames {
+		store, err := openStore(backend, dir, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fillStore(t, store)
+		store.Close()
+	}
+}
+
+func testExportImport(t *testing.T, from, to string) {
+	fromDir, err := ioutil.TempDir("", "ca-store-from")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fromDir)
+	toDir, err := ioutil.TempDir("", "ca-store-to")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(toDir)
+
+	fillStores(t, from, fromDir)
+
+	exported := new(bytes.Buffer)
+	if err := exportStores(from, fromDir, exported); err != nil {
+		t.Fatalf("Failed exporting the %s stores: %s", from, err)
+	}
+	if err := importStores(to, toDir, bytes.NewReader(exported.Bytes())); err != nil {
+		t.Fatalf("Failed importing into the %s stores: %s", to, err)
+	}
+
+	reexported := new(bytes.Buffer)
+	if err := exportStores(to, toDir, reexported); err != nil {
+		t.Fatalf("Failed exporting the %s stores: %s", to, err)
+	}
+	if !bytes.Equal(exported.Bytes(), reexported.Bytes()) {
+		t.Fatalf("The %s stores differ from the %s stores they were imported from.", to, from)
+	}
+
+	// The imported stores keep working, and cannot be imported into again.
+	store, err := openStore(to, toDir, "eca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := store.ReadUser("alice")
+	if err != nil || user == nil || string(user.Token) != "password" {
+		t.Errorf("Failed reading an imported user: %v, %v", user, err)
+	}
+	if err := store.CreateUser(&UserRecord{ID: "bob", EnrollmentID: "bob", Role: 1}); err != nil {
+		t.Errorf("Failed creating a user in an imported store: %s", err)
+	}
+	store.Close()
+
+	if err := importStores(to, toDir, bytes.NewReader(exported.Bytes())); err == nil {
+		t.Error("Importing into a non-empty store should fail.")
+	}
+}
+
+func TestExportImportSQLiteToBolt(t *testing.T) {
+	testExportImport(t, "sqlite3", "bolt")
+}
+
+func TestExportImportBoltToSQLite(t *testing.T) {
+	testExportImport(t, "bolt", "sqlite3")
+}
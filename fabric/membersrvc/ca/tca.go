@@ -20,11 +20,11 @@ import (
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/x509"
-	"database/sql"
 	"encoding/asn1"
 	"encoding/base64"
 	"errors"
 	"io/ioutil"
+	"math/big"
 
 	"github.com/hyperledger/fabric/core/crypto/primitives"
 	"github.com/hyperledger/fabric/flogging"
@@ -45,6 +45,10 @@ var (
 	// TCertAttributesHeaders is the ASN1 object identifier of attributes header.
 	TCertAttributesHeaders = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 9}
 
+	// TCertAffiliation is the ASN1 object identifier of the affiliation of the
+	// enrollment.
+	TCertAffiliation = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 6}
+
 	// Padding for encryption.
 	Padding = []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255}
 
@@ -70,26 +74,14 @@ type TCertSet struct {
 	Key          []byte
 }
 
-func initializeTCATables(db *sql.DB) error {
-	var err error
-
-	err = initializeCommonTables(db)
-	if err != nil {
-		return err
-	}
-
-	if _, err = db.Exec("CREATE TABLE IF NOT EXISTS TCertificateSets (row INTEGER PRIMARY KEY, enrollmentID VARCHAR(64), timestamp INTEGER, nonce BLOB, kdfkey BLOB)"); err != nil {
-		return err
-	}
-
-	return err
-}
-
 // NewTCA sets up a new TCA.
 func NewTCA(eca *ECA) *TCA {
-	tca := &TCA{NewCA("tca", initializeTCATables), eca, nil, nil, nil, nil}
+	tca := &TCA{NewCA("tca"), eca, nil, nil, nil, nil}
 	flogging.LoggingInit("tca")
 
+	// Revoking an enrollment revokes the transaction certificates issued for it
+	eca.tca = tca
+
 	err := tca.readHmacKey()
 	if err != nil {
 		tcaLogger.Panic(err)
@@ -249,45 +241,105 @@ func (tca *TCA) getCertificateSets(enrollmentID string) ([]*TCertSet, error) {
 	defer mutex.RUnlock()
 
 	var sets = []*TCertSet{}
-	var err error
 
-	var rows *sql.Rows
-	rows, err = tca.retrieveCertificateSets(enrollmentID)
+	records, err := tca.store.ReadCertificateSets(enrollmentID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var enrollID string
-	var timestamp int64
-	var nonce []byte
-	var kdfKey []byte
 
-	for rows.Next() {
-		if err = rows.Scan(&enrollID, &timestamp, &nonce, &kdfKey); err != nil {
-			return nil, err
-		}
-		sets = append(sets, &TCertSet{Ts: timestamp, EnrollmentID: enrollID, Key: kdfKey})
-	}
-	if err = rows.Err(); err != nil {
-		return nil, err
+	for _, set := range records {
+		sets = append(sets, &TCertSet{Ts: set.Timestamp, EnrollmentID: set.EnrollmentID, Key: set.KDFKey})
 	}
 
 	return sets, nil
 }
 
-func (tca *TCA) persistCertificateSet(enrollmentID string, timestamp int64, nonce []byte, kdfKey []byte) error {
+func (tca *TCA) persistCertificateSet(enrollmentID string, timestamp int64, nonce []byte, kdfKey []byte, serialNumbers []*big.Int) error {
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	var err error
+	// The serial numbers are kept to revoke the certificates of the set
+	var certs []*TCertificateRecord
+	for _, serialNumber := range serialNumbers {
+		certs = append(certs, &TCertificateRecord{EnrollmentID: enrollmentID, Timestamp: timestamp, SerialNumber: serialNumber.String()})
+	}
 
-	if _, err = tca.db.Exec("INSERT INTO TCertificateSets (enrollmentID, timestamp, nonce, kdfkey) VALUES (?, ?, ?, ?)", enrollmentID, timestamp, nonce, kdfKey); err != nil {
+	err := tca.store.CreateCertificateSet(&CertificateSetRecord{EnrollmentID: enrollmentID, Timestamp: timestamp, Nonce: nonce, KDFKey: kdfKey}, certs)
+	if err != nil {
 		tcaLogger.Error(err)
 	}
 	return err
 }
 
-func (tca *TCA) retrieveCertificateSets(enrollmentID string) (*sql.Rows, error) {
-	return tca.db.Query("SELECT enrollmentID, timestamp, nonce, kdfkey FROM TCertificateSets WHERE enrollmentID=?", enrollmentID)
+// readTCertificates reads the transaction certificates issued to enrollmentID.
+func (tca *TCA) readTCertificates(enrollmentID string) ([]*TCertificateRecord, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	return tca.store.ReadTCertificates(enrollmentID)
+}
+
+// readCertificateOwner returns the enrollment ID a transaction certificate was
+// issued to.
+func (tca *TCA) readCertificateOwner(serialNumber *big.Int) (string, error) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	cert, err := tca.store.ReadTCertificate(serialNumber.String())
+	if err != nil {
+		return "", err
+	}
+	if cert == nil {
+		return "", errors.New("Certificate was not issued by this CA.")
+	}
+	return cert.EnrollmentID, nil
+}
+
+// revokeCertificates revokes the transaction certificates issued to
+// enrollmentID in the set issued at timestamp, or in all the sets if timestamp
+// is 0, and publishes a new CRL.
+func (tca *TCA) revokeCertificates(enrollmentID string, timestamp int64) error {
+	certs, err := tca.readTCertificates(enrollmentID)
+	if err != nil {
+		return err
+	}
+
+	for _, cert := range certs {
+		if timestamp != 0 && cert.Timestamp != timestamp {
+			continue
+		}
+		if serialNumber, ok := new(big.Int).SetString(cert.SerialNumber, 10); ok {
+			if err = tca.revokeCertificate(enrollmentID, serialNumber); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = tca.publishCRL()
+	return err
+}
+
+// revokeEnrollment revokes all the transaction certificates issued to
+// enrollmentID.
+func (tca *TCA) revokeEnrollment(enrollmentID string) error {
+	return tca.revokeCertificates(enrollmentID, 0)
+}
+
+// revokeCertificateSet revokes the transaction certificates of the set issued
+// to enrollmentID at timestamp, or of the last set issued if timestamp is 0.
+func (tca *TCA) revokeCertificateSet(enrollmentID string, timestamp int64) error {
+	if timestamp == 0 {
+		certs, err := tca.readTCertificates(enrollmentID)
+		if err != nil {
+			return err
+		}
+		if len(certs) == 0 {
+			return errors.New("No certificate sets for the given identity were found.")
+		}
+		for _, cert := range certs {
+			if cert.Timestamp > timestamp {
+				timestamp = cert.Timestamp
+			}
+		}
+	}
+	return tca.revokeCertificates(enrollmentID, timestamp)
 }
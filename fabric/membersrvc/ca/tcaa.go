@@ -31,23 +31,39 @@ type TCAA struct {
 	tca *TCA
 }
 
-// RevokeCertificate revokes a certificate from the TCA.  Not yet implemented.
-func (tcaa *TCAA) RevokeCertificate(context.Context, *pb.TCertRevokeReq) (*pb.CAStatus, error) {
+// RevokeCertificate revokes a transaction certificate.  Registrars may revoke
+// the certificates of the members they may register.
+func (tcaa *TCAA) RevokeCertificate(ctx context.Context, in *pb.TCertRevokeReq) (*pb.CAStatus, error) {
 	tcaaLogger.Debug("grpc TCAA:RevokeCertificate")
 
-	return nil, errors.New("not yet implemented")
+	return revokeTransactionCertificate(tcaa.tca, in, true)
 }
 
-// RevokeCertificateSet revokes a certificate set from the TCA.  Not yet implemented.
-func (tcaa *TCAA) RevokeCertificateSet(context.Context, *pb.TCertRevokeSetReq) (*pb.CAStatus, error) {
+// RevokeCertificateSet revokes a transaction certificate set of the owner given
+// in the request.  Registrars may revoke the certificates of the members they
+// may register.
+func (tcaa *TCAA) RevokeCertificateSet(ctx context.Context, in *pb.TCertRevokeSetReq) (*pb.CAStatus, error) {
 	tcaaLogger.Debug("grpc TCAA:RevokeCertificateSet")
 
-	return nil, errors.New("not yet implemented")
+	return revokeTransactionCertificateSet(tcaa.tca, in, true)
 }
 
-// PublishCRL requests the creation of a certificate revocation list from the TCA.  Not yet implemented.
-func (tcaa *TCAA) PublishCRL(context.Context, *pb.TCertCRLReq) (*pb.CAStatus, error) {
+// PublishCRL requests the creation of a certificate revocation list from the TCA.
+func (tcaa *TCAA) PublishCRL(ctx context.Context, in *pb.TCertCRLReq) (*pb.CAStatus, error) {
 	tcaaLogger.Debug("grpc TCAA:CreateCRL")
 
-	return nil, errors.New("not yet implemented")
+	if in.Id == nil || !tcaa.tca.eca.isRegistrar(in.Id.Id) {
+		return nil, errors.New("Access denied.")
+	}
+
+	sig := in.Sig
+	in.Sig = nil
+	if err := verifyEnrollmentSignature(tcaa.tca.eca, in.Id.Id, in, sig); err != nil {
+		return nil, err
+	}
+
+	if _, err := tcaa.tca.publishCRL(); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
 }
@@ -153,6 +153,9 @@ func (tcap *TCAP) CreateCertificateSet(ctx context.Context, in *pb.TCertCreateSe
 	tcapLogger.Debugf("grpc TCAP:CreateCertificateSet")
 
 	id := in.Id.Id
+	if tcap.tca.eca.isEnrollmentRevoked(id) {
+		return nil, errors.New("Enrollment was revoked.")
+	}
 	raw, err := tcap.tca.eca.readCertificateByKeyUsage(id, x509.KeyUsageDigitalSignature)
 	if err != nil {
 		return nil, err
@@ -213,6 +216,7 @@ func (tcap *TCAP) createCertificateSet(ctx context.Context, raw []byte, in *pb.T
 
 	// the batch of TCerts
 	var set []*pb.TCert
+	var serialNumbers []*big.Int
 
 	for i := 0; i < num; i++ {
 		tcertid := util.GenerateIntUUID()
@@ -259,9 +263,10 @@ func (tcap *TCAP) createCertificateSet(ctx context.Context, raw []byte, in *pb.T
 		}
 
 		set = append(set, &pb.TCert{Cert: raw, Prek0: preK0})
+		serialNumbers = append(serialNumbers, tcertid)
 	}
 
-	tcap.tca.persistCertificateSet(id, timestamp, nonce, kdfKey)
+	tcap.tca.persistCertificateSet(id, timestamp, nonce, kdfKey, serialNumbers)
 
 	return &pb.TCertCreateSetResp{Certs: &pb.CertSet{Ts: in.Ts, Id: in.Id, Key: kdfKey, Certs: set}}, nil
 }
@@ -326,6 +331,12 @@ func (tcap *TCAP) generateExtensions(tcertid *big.Int, tidx []byte, enrollmentCe
 	// Append the encrypted EnrollmentID to the extensions
 	extensions = append(extensions, pkix.Extension{Id: TCertEncEnrollmentID, Critical: false, Value: encEnrollmentID})
 
+	// Append the affiliation of the enrollment, for the peers to enforce the
+	// access control lists of the chaincodes
+	if _, affiliation, err := tcap.tca.parseEnrollID(enrollmentCert.Subject.CommonName); err == nil {
+		extensions = append(extensions, pkix.Extension{Id: TCertAffiliation, Critical: false, Value: []byte(affiliation)})
+	}
+
 	// Append the attributes header if there was attributes to include in the TCert
 	if len(attrs) > 0 {
 		headerValue, err := attributes.BuildAttributesHeader(attrsHeader)
@@ -344,18 +355,97 @@ func (tcap *TCAP) generateExtensions(tcertid *big.Int, tidx []byte, enrollmentCe
 	return extensions, preK0, nil
 }
 
-// RevokeCertificate revokes a certificate from the TCA.  Not yet implemented.
-func (tcap *TCAP) RevokeCertificate(context.Context, *pb.TCertRevokeReq) (*pb.CAStatus, error) {
+// RevokeCertificate revokes a transaction certificate of the caller.
+func (tcap *TCAP) RevokeCertificate(ctx context.Context, in *pb.TCertRevokeReq) (*pb.CAStatus, error) {
 	tcapLogger.Debugf("grpc TCAP:RevokeCertificate")
 
-	return nil, errors.New("not yet implemented")
+	return revokeTransactionCertificate(tcap.tca, in, false)
 }
 
-// RevokeCertificateSet revokes a certificate set from the TCA.  Not yet implemented.
-func (tcap *TCAP) RevokeCertificateSet(context.Context, *pb.TCertRevokeSetReq) (*pb.CAStatus, error) {
+// RevokeCertificateSet revokes a transaction certificate set of the caller.
+func (tcap *TCAP) RevokeCertificateSet(ctx context.Context, in *pb.TCertRevokeSetReq) (*pb.CAStatus, error) {
 	tcapLogger.Debugf("grpc TCAP:RevokeCertificateSet")
 
-	return nil, errors.New("not yet implemented")
+	return revokeTransactionCertificateSet(tcap.tca, in, false)
+}
+
+// ReadCRL reads the last certificate revocation list published by the TCA.
+func (tcap *TCAP) ReadCRL(ctx context.Context, in *pb.Empty) (*pb.CRL, error) {
+	tcapLogger.Debugf("grpc TCAP:ReadCRL")
+
+	raw, err := tcap.tca.readCRL()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CRL{Crl: raw}, nil
+}
+
+// revokeTransactionCertificate revokes the transaction certificate in a
+// revocation request.  Members may only revoke their own certificates, unless
+// admin is set.
+func revokeTransactionCertificate(tca *TCA, in *pb.TCertRevokeReq, admin bool) (*pb.CAStatus, error) {
+	if in.Id == nil || in.Cert == nil {
+		return nil, errors.New("Invalid revocation request.")
+	}
+	requester := in.Id.Id
+
+	sig := in.Sig
+	in.Sig = nil
+	if err := verifyEnrollmentSignature(tca.eca, requester, in, sig); err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(in.Cert.Cert)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := tca.readCertificateOwner(cert.SerialNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkRevocationAccess(tca.eca, requester, owner, admin); err != nil {
+		return nil, err
+	}
+
+	if err = tca.revokeCertificate(owner, cert.SerialNumber); err != nil {
+		return nil, err
+	}
+	if _, err = tca.publishCRL(); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
+}
+
+// revokeTransactionCertificateSet revokes the transaction certificate set in a
+// revocation request.  Members may only revoke their own certificate sets,
+// unless admin is set.
+func revokeTransactionCertificateSet(tca *TCA, in *pb.TCertRevokeSetReq, admin bool) (*pb.CAStatus, error) {
+	if in.Id == nil {
+		return nil, errors.New("Invalid revocation request.")
+	}
+	requester := in.Id.Id
+	owner := requester
+	if in.Owner != nil && in.Owner.Id != "" {
+		owner = in.Owner.Id
+	}
+
+	sig := in.Sig
+	in.Sig = nil
+	if err := verifyEnrollmentSignature(tca.eca, requester, in, sig); err != nil {
+		return nil, err
+	}
+	if err := checkRevocationAccess(tca.eca, requester, owner, admin); err != nil {
+		return nil, err
+	}
+
+	var ts int64
+	if in.Ts != nil {
+		ts = in.Ts.Seconds
+	}
+	if err := tca.revokeCertificateSet(owner, ts); err != nil {
+		return nil, err
+	}
+	return &pb.CAStatus{Status: pb.CAStatus_OK}, nil
 }
 
 func isEnabledAttributesEncryption() bool {
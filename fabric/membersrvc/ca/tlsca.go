@@ -19,7 +19,6 @@ package ca
 import (
 	"crypto/ecdsa"
 	"crypto/x509"
-	"database/sql"
 	"errors"
 	"math/big"
 
@@ -54,14 +53,10 @@ type TLSCAA struct {
 	tlsca *TLSCA
 }
 
-func initializeTLSCATables(db *sql.DB) error {
-	return initializeCommonTables(db)
-}
-
 // NewTLSCA sets up a new TLSCA.
 //
 func NewTLSCA(eca *ECA) *TLSCA {
-	tlsca := &TLSCA{NewCA("tlsca", initializeTLSCATables), eca, nil}
+	tlsca := &TLSCA{NewCA("tlsca"), eca, nil}
 	flogging.LoggingInit("tlsca")
 
 	return tlsca
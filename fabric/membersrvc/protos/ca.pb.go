@@ -6,9 +6,11 @@
 Package protos is a generated protocol buffer package.
 
 It is generated from these files:
+
 	ca.proto
 
 It has these top-level messages:
+
 	CAStatus
 	Empty
 	Identity
@@ -52,6 +54,11 @@ It has these top-level messages:
 	ACAFetchAttrResp
 	FetchAttrsResult
 	ACAAttribute
+	CRL
+	ACAAttrAdminReq
+	ACAAttrSet
+	ACAAttrChange
+	ACAAttrChangeSet
 */
 package protos
 
@@ -839,9 +846,10 @@ func (m *TCertRevokeReq) GetSig() *Signature {
 }
 
 type TCertRevokeSetReq struct {
-	Id  *Identity                  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
-	Ts  *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=ts" json:"ts,omitempty"`
-	Sig *Signature                 `protobuf:"bytes,3,opt,name=sig" json:"sig,omitempty"`
+	Id    *Identity                  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Ts    *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=ts" json:"ts,omitempty"`
+	Sig   *Signature                 `protobuf:"bytes,3,opt,name=sig" json:"sig,omitempty"`
+	Owner *Identity                  `protobuf:"bytes,4,opt,name=owner" json:"owner,omitempty"`
 }
 
 func (m *TCertRevokeSetReq) Reset()                    { *m = TCertRevokeSetReq{} }
@@ -870,6 +878,13 @@ func (m *TCertRevokeSetReq) GetSig() *Signature {
 	return nil
 }
 
+func (m *TCertRevokeSetReq) GetOwner() *Identity {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
 type TCertCRLReq struct {
 	Id  *Identity  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	Sig *Signature `protobuf:"bytes,2,opt,name=sig" json:"sig,omitempty"`
@@ -1264,6 +1279,145 @@ func (m *ACAAttribute) GetValidTo() *google_protobuf.Timestamp {
 	return nil
 }
 
+// Certificate revocation list issued by either the ECA or TCA.
+//
+type CRL struct {
+	Crl []byte `protobuf:"bytes,1,opt,name=crl,proto3" json:"crl,omitempty"`
+}
+
+func (m *CRL) Reset()                    { *m = CRL{} }
+func (m *CRL) String() string            { return proto.CompactTextString(m) }
+func (*CRL) ProtoMessage()               {}
+func (*CRL) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+// ACAAttrAdminReq is sent by a registrar to the ACA admin service to manage
+// the attributes of a user.
+//
+type ACAAttrAdminReq struct {
+	Id        *Identity                  `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Owner     *Identity                  `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+	Attribute *ACAAttribute              `protobuf:"bytes,3,opt,name=attribute" json:"attribute,omitempty"`
+	Ts        *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=ts" json:"ts,omitempty"`
+	Nonce     []byte                     `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Sig       *Signature                 `protobuf:"bytes,4,opt,name=sig" json:"sig,omitempty"`
+}
+
+func (m *ACAAttrAdminReq) Reset()                    { *m = ACAAttrAdminReq{} }
+func (m *ACAAttrAdminReq) String() string            { return proto.CompactTextString(m) }
+func (*ACAAttrAdminReq) ProtoMessage()               {}
+func (*ACAAttrAdminReq) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *ACAAttrAdminReq) GetId() *Identity {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ACAAttrAdminReq) GetOwner() *Identity {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ACAAttrAdminReq) GetAttribute() *ACAAttribute {
+	if m != nil {
+		return m.Attribute
+	}
+	return nil
+}
+
+func (m *ACAAttrAdminReq) GetTs() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *ACAAttrAdminReq) GetSig() *Signature {
+	if m != nil {
+		return m.Sig
+	}
+	return nil
+}
+
+type ACAAttrSet struct {
+	Attributes []*ACAAttribute `protobuf:"bytes,1,rep,name=attributes" json:"attributes,omitempty"`
+}
+
+func (m *ACAAttrSet) Reset()                    { *m = ACAAttrSet{} }
+func (m *ACAAttrSet) String() string            { return proto.CompactTextString(m) }
+func (*ACAAttrSet) ProtoMessage()               {}
+func (*ACAAttrSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *ACAAttrSet) GetAttributes() []*ACAAttribute {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+// ACAAttrChange is an entry of the audit trail of the attributes of a user.
+//
+type ACAAttrChange struct {
+	Ts          *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=ts" json:"ts,omitempty"`
+	Registrar   *Identity                  `protobuf:"bytes,2,opt,name=registrar" json:"registrar,omitempty"`
+	Owner       *Identity                  `protobuf:"bytes,3,opt,name=owner" json:"owner,omitempty"`
+	Affiliation string                     `protobuf:"bytes,4,opt,name=affiliation" json:"affiliation,omitempty"`
+	Action      string                     `protobuf:"bytes,5,opt,name=action" json:"action,omitempty"`
+	Attribute   *ACAAttribute              `protobuf:"bytes,6,opt,name=attribute" json:"attribute,omitempty"`
+}
+
+func (m *ACAAttrChange) Reset()                    { *m = ACAAttrChange{} }
+func (m *ACAAttrChange) String() string            { return proto.CompactTextString(m) }
+func (*ACAAttrChange) ProtoMessage()               {}
+func (*ACAAttrChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *ACAAttrChange) GetTs() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Ts
+	}
+	return nil
+}
+
+func (m *ACAAttrChange) GetRegistrar() *Identity {
+	if m != nil {
+		return m.Registrar
+	}
+	return nil
+}
+
+func (m *ACAAttrChange) GetOwner() *Identity {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *ACAAttrChange) GetAttribute() *ACAAttribute {
+	if m != nil {
+		return m.Attribute
+	}
+	return nil
+}
+
+type ACAAttrChangeSet struct {
+	Changes []*ACAAttrChange `protobuf:"bytes,1,rep,name=changes" json:"changes,omitempty"`
+}
+
+func (m *ACAAttrChangeSet) Reset()                    { *m = ACAAttrChangeSet{} }
+func (m *ACAAttrChangeSet) String() string            { return proto.CompactTextString(m) }
+func (*ACAAttrChangeSet) ProtoMessage()               {}
+func (*ACAAttrChangeSet) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *ACAAttrChangeSet) GetChanges() []*ACAAttrChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CAStatus)(nil), "protos.CAStatus")
 	proto.RegisterType((*Empty)(nil), "protos.Empty")
@@ -1307,6 +1461,11 @@ func init() {
 	proto.RegisterType((*ACAFetchAttrResp)(nil), "protos.ACAFetchAttrResp")
 	proto.RegisterType((*FetchAttrsResult)(nil), "protos.FetchAttrsResult")
 	proto.RegisterType((*ACAAttribute)(nil), "protos.ACAAttribute")
+	proto.RegisterType((*CRL)(nil), "protos.CRL")
+	proto.RegisterType((*ACAAttrAdminReq)(nil), "protos.ACAAttrAdminReq")
+	proto.RegisterType((*ACAAttrSet)(nil), "protos.ACAAttrSet")
+	proto.RegisterType((*ACAAttrChange)(nil), "protos.ACAAttrChange")
+	proto.RegisterType((*ACAAttrChangeSet)(nil), "protos.ACAAttrChangeSet")
 	proto.RegisterEnum("protos.CryptoType", CryptoType_name, CryptoType_value)
 	proto.RegisterEnum("protos.Role", Role_name, Role_value)
 	proto.RegisterEnum("protos.CAStatus_StatusCode", CAStatus_StatusCode_name, CAStatus_StatusCode_value)
@@ -1331,6 +1490,7 @@ type ECAPClient interface {
 	ReadCertificatePair(ctx context.Context, in *ECertReadReq, opts ...grpc.CallOption) (*CertPair, error)
 	ReadCertificateByHash(ctx context.Context, in *Hash, opts ...grpc.CallOption) (*Cert, error)
 	RevokeCertificatePair(ctx context.Context, in *ECertRevokeReq, opts ...grpc.CallOption) (*CAStatus, error)
+	ReadCRL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CRL, error)
 }
 
 type eCAPClient struct {
@@ -1386,6 +1546,15 @@ func (c *eCAPClient) RevokeCertificatePair(ctx context.Context, in *ECertRevokeR
 	return out, nil
 }
 
+func (c *eCAPClient) ReadCRL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CRL, error) {
+	out := new(CRL)
+	err := grpc.Invoke(ctx, "/protos.ECAP/ReadCRL", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ECAP service
 
 type ECAPServer interface {
@@ -1394,6 +1563,7 @@ type ECAPServer interface {
 	ReadCertificatePair(context.Context, *ECertReadReq) (*CertPair, error)
 	ReadCertificateByHash(context.Context, *Hash) (*Cert, error)
 	RevokeCertificatePair(context.Context, *ECertRevokeReq) (*CAStatus, error)
+	ReadCRL(context.Context, *Empty) (*CRL, error)
 }
 
 func RegisterECAPServer(s *grpc.Server, srv ECAPServer) {
@@ -1490,6 +1660,24 @@ func _ECAP_RevokeCertificatePair_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ECAP_ReadCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ECAPServer).ReadCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.ECAP/ReadCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ECAPServer).ReadCRL(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ECAP_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.ECAP",
 	HandlerType: (*ECAPServer)(nil),
@@ -1514,6 +1702,10 @@ var _ECAP_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeCertificatePair",
 			Handler:    _ECAP_RevokeCertificatePair_Handler,
 		},
+		{
+			MethodName: "ReadCRL",
+			Handler:    _ECAP_ReadCRL_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: fileDescriptor0,
@@ -1689,6 +1881,7 @@ type TCAPClient interface {
 	CreateCertificateSet(ctx context.Context, in *TCertCreateSetReq, opts ...grpc.CallOption) (*TCertCreateSetResp, error)
 	RevokeCertificate(ctx context.Context, in *TCertRevokeReq, opts ...grpc.CallOption) (*CAStatus, error)
 	RevokeCertificateSet(ctx context.Context, in *TCertRevokeSetReq, opts ...grpc.CallOption) (*CAStatus, error)
+	ReadCRL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CRL, error)
 }
 
 type tCAPClient struct {
@@ -1735,6 +1928,15 @@ func (c *tCAPClient) RevokeCertificateSet(ctx context.Context, in *TCertRevokeSe
 	return out, nil
 }
 
+func (c *tCAPClient) ReadCRL(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CRL, error) {
+	out := new(CRL)
+	err := grpc.Invoke(ctx, "/protos.TCAP/ReadCRL", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for TCAP service
 
 type TCAPServer interface {
@@ -1742,6 +1944,7 @@ type TCAPServer interface {
 	CreateCertificateSet(context.Context, *TCertCreateSetReq) (*TCertCreateSetResp, error)
 	RevokeCertificate(context.Context, *TCertRevokeReq) (*CAStatus, error)
 	RevokeCertificateSet(context.Context, *TCertRevokeSetReq) (*CAStatus, error)
+	ReadCRL(context.Context, *Empty) (*CRL, error)
 }
 
 func RegisterTCAPServer(s *grpc.Server, srv TCAPServer) {
@@ -1820,6 +2023,24 @@ func _TCAP_RevokeCertificateSet_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TCAP_ReadCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TCAPServer).ReadCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.TCAP/ReadCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TCAPServer).ReadCRL(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TCAP_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.TCAP",
 	HandlerType: (*TCAPServer)(nil),
@@ -1840,6 +2061,10 @@ var _TCAP_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevokeCertificateSet",
 			Handler:    _TCAP_RevokeCertificateSet_Handler,
 		},
+		{
+			MethodName: "ReadCRL",
+			Handler:    _TCAP_ReadCRL_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: fileDescriptor0,
@@ -2332,122 +2557,300 @@ var _ACAP_serviceDesc = grpc.ServiceDesc{
 	Metadata: fileDescriptor0,
 }
 
+// Client API for ACAA service
+
+type ACAAClient interface {
+	SetAttribute(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*CAStatus, error)
+	RevokeAttribute(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*CAStatus, error)
+	ReadAttributes(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*ACAAttrSet, error)
+	ReadAttributeChanges(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*ACAAttrChangeSet, error)
+}
+
+type aCAAClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewACAAClient(cc *grpc.ClientConn) ACAAClient {
+	return &aCAAClient{cc}
+}
+
+func (c *aCAAClient) SetAttribute(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*CAStatus, error) {
+	out := new(CAStatus)
+	err := grpc.Invoke(ctx, "/protos.ACAA/SetAttribute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aCAAClient) RevokeAttribute(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*CAStatus, error) {
+	out := new(CAStatus)
+	err := grpc.Invoke(ctx, "/protos.ACAA/RevokeAttribute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aCAAClient) ReadAttributes(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*ACAAttrSet, error) {
+	out := new(ACAAttrSet)
+	err := grpc.Invoke(ctx, "/protos.ACAA/ReadAttributes", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aCAAClient) ReadAttributeChanges(ctx context.Context, in *ACAAttrAdminReq, opts ...grpc.CallOption) (*ACAAttrChangeSet, error) {
+	out := new(ACAAttrChangeSet)
+	err := grpc.Invoke(ctx, "/protos.ACAA/ReadAttributeChanges", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for ACAA service
+
+type ACAAServer interface {
+	SetAttribute(context.Context, *ACAAttrAdminReq) (*CAStatus, error)
+	RevokeAttribute(context.Context, *ACAAttrAdminReq) (*CAStatus, error)
+	ReadAttributes(context.Context, *ACAAttrAdminReq) (*ACAAttrSet, error)
+	ReadAttributeChanges(context.Context, *ACAAttrAdminReq) (*ACAAttrChangeSet, error)
+}
+
+func RegisterACAAServer(s *grpc.Server, srv ACAAServer) {
+	s.RegisterService(&_ACAA_serviceDesc, srv)
+}
+
+func _ACAA_SetAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACAAttrAdminReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ACAAServer).SetAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.ACAA/SetAttribute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ACAAServer).SetAttribute(ctx, req.(*ACAAttrAdminReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ACAA_RevokeAttribute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACAAttrAdminReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ACAAServer).RevokeAttribute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.ACAA/RevokeAttribute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ACAAServer).RevokeAttribute(ctx, req.(*ACAAttrAdminReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ACAA_ReadAttributes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACAAttrAdminReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ACAAServer).ReadAttributes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.ACAA/ReadAttributes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ACAAServer).ReadAttributes(ctx, req.(*ACAAttrAdminReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ACAA_ReadAttributeChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ACAAttrAdminReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ACAAServer).ReadAttributeChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.ACAA/ReadAttributeChanges",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ACAAServer).ReadAttributeChanges(ctx, req.(*ACAAttrAdminReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _ACAA_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.ACAA",
+	HandlerType: (*ACAAServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetAttribute",
+			Handler:    _ACAA_SetAttribute_Handler,
+		},
+		{
+			MethodName: "RevokeAttribute",
+			Handler:    _ACAA_RevokeAttribute_Handler,
+		},
+		{
+			MethodName: "ReadAttributes",
+			Handler:    _ACAA_ReadAttributes_Handler,
+		},
+		{
+			MethodName: "ReadAttributeChanges",
+			Handler:    _ACAA_ReadAttributeChanges_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: fileDescriptor0,
+}
+
 func init() { proto.RegisterFile("ca.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1818 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x59, 0x4f, 0x6f, 0xdb, 0xc6,
-	0x12, 0x7f, 0x14, 0x25, 0x5b, 0x1a, 0xc9, 0x32, 0xbd, 0x76, 0x62, 0x45, 0x0f, 0x78, 0x09, 0x98,
-	0x97, 0xbc, 0xbc, 0xa0, 0xb5, 0x53, 0xbb, 0x48, 0x8b, 0xa6, 0x41, 0xc1, 0xc8, 0x74, 0xa3, 0x46,
-	0x96, 0x5c, 0x8a, 0x4a, 0x7b, 0x13, 0x64, 0x9b, 0xb6, 0x09, 0xcb, 0x92, 0x4c, 0x52, 0x01, 0x84,
-	0xdc, 0x0b, 0xb4, 0x05, 0x7a, 0xe8, 0x77, 0x28, 0x50, 0xf4, 0x1b, 0x14, 0x28, 0xd0, 0x6b, 0xff,
-	0x24, 0x40, 0x2f, 0x3d, 0xf6, 0x5c, 0xa0, 0xa7, 0x7e, 0x82, 0xa6, 0xb3, 0xcb, 0x25, 0x45, 0x52,
-	0x7f, 0xcc, 0x38, 0x2e, 0xd2, 0x1e, 0x0c, 0x2d, 0x77, 0x66, 0x77, 0x67, 0x7e, 0x33, 0x3b, 0x7f,
-	0xd6, 0x90, 0xde, 0x6d, 0xad, 0xf4, 0xac, 0xae, 0xd3, 0x25, 0x33, 0xec, 0xc7, 0x2e, 0x5e, 0x3e,
-	0xe8, 0x76, 0x0f, 0xda, 0xc6, 0x2a, 0xfb, 0xdc, 0xe9, 0xef, 0xaf, 0x3a, 0xe6, 0xb1, 0x61, 0x3b,
-	0xad, 0xe3, 0x9e, 0xcb, 0x28, 0x1f, 0x42, 0xba, 0xa4, 0xd4, 0x9d, 0x96, 0xd3, 0xb7, 0xc9, 0x3a,
-	0xcc, 0xd8, 0x6c, 0x54, 0x10, 0xae, 0x08, 0x37, 0xf2, 0x6b, 0xff, 0x76, 0x79, 0xec, 0x15, 0x8f,
-	0x63, 0xc5, 0xfd, 0x29, 0x75, 0xf7, 0x0c, 0x8d, 0xb3, 0xca, 0xff, 0x03, 0x18, 0xce, 0x92, 0x19,
-	0x48, 0xd4, 0x1e, 0x48, 0xff, 0x22, 0x0b, 0x30, 0xd7, 0xa8, 0x3e, 0xa8, 0xd6, 0x3e, 0xa8, 0x36,
-	0x55, 0x4d, 0xab, 0x69, 0x92, 0x20, 0xcf, 0x42, 0x4a, 0x3d, 0xee, 0x39, 0x03, 0xb9, 0x08, 0xe9,
-	0xf2, 0x9e, 0xd1, 0x71, 0x4c, 0x67, 0x40, 0xf2, 0x90, 0x30, 0xf7, 0xd8, 0x71, 0x19, 0x0d, 0x47,
-	0xf2, 0x25, 0x48, 0xe9, 0xdd, 0x23, 0xa3, 0x43, 0x24, 0x10, 0x9d, 0xee, 0x11, 0xa3, 0xe4, 0x34,
-	0x3a, 0xc4, 0x65, 0xc9, 0xfb, 0x2d, 0xfb, 0x90, 0x10, 0x48, 0x1e, 0xe2, 0x2f, 0x27, 0xb1, 0xb1,
-	0xac, 0x42, 0x66, 0xbb, 0xbf, 0xd3, 0x36, 0x77, 0x1f, 0x18, 0x03, 0x72, 0x1d, 0x92, 0xce, 0xa0,
-	0x67, 0x70, 0x25, 0x88, 0xaf, 0x84, 0x35, 0xe8, 0x39, 0x5d, 0x1d, 0x29, 0x1a, 0xa3, 0xd3, 0x23,
-	0x8e, 0x8c, 0x41, 0x21, 0xe1, 0x1e, 0x81, 0x43, 0x79, 0x13, 0x60, 0xdb, 0x32, 0x1f, 0xb5, 0x1c,
-	0xe3, 0xc5, 0xf6, 0xa9, 0x41, 0xa6, 0x6e, 0x1e, 0x74, 0x10, 0x15, 0xcb, 0x88, 0xbd, 0x4d, 0x0e,
-	0x04, 0x8b, 0x6f, 0x22, 0x58, 0xf4, 0xcb, 0x2e, 0x88, 0xee, 0x97, 0x2d, 0x9b, 0x90, 0xd1, 0x8c,
-	0x03, 0xd3, 0x76, 0xac, 0x96, 0x45, 0xae, 0xf8, 0x98, 0x65, 0xd7, 0x24, 0x6f, 0x3b, 0x0f, 0x51,
-	0x8a, 0x22, 0x59, 0x82, 0x94, 0xd5, 0x6d, 0x1b, 0x36, 0x6e, 0x27, 0x22, 0xb0, 0xee, 0x07, 0xf9,
-	0x2f, 0xcc, 0xed, 0x19, 0x6d, 0xe3, 0x00, 0xd5, 0xd3, 0x18, 0x55, 0x64, 0xd4, 0xf0, 0xa4, 0xfc,
-	0x54, 0x80, 0x79, 0xf7, 0x2c, 0xc3, 0x6a, 0xd8, 0x86, 0xa5, 0x19, 0x27, 0x31, 0x4e, 0xbc, 0x02,
-	0x49, 0x7a, 0x08, 0x93, 0x3f, 0xbf, 0x96, 0xf3, 0x78, 0xe8, 0x96, 0x1a, 0xa3, 0x20, 0x47, 0xb6,
-	0xb5, 0xbf, 0x6f, 0xb6, 0xcd, 0x96, 0x63, 0x76, 0x3b, 0x85, 0x24, 0x33, 0x79, 0x70, 0x8a, 0xac,
-	0x42, 0xc6, 0xf2, 0x94, 0x2c, 0xa4, 0xd8, 0x61, 0x0b, 0xfe, 0x46, 0x1e, 0x41, 0x1b, 0xf2, 0x90,
-	0xab, 0x20, 0xda, 0xe6, 0x41, 0x61, 0x26, 0xcc, 0xea, 0x23, 0xaf, 0x51, 0xaa, 0xfc, 0x18, 0xf2,
-	0x9a, 0xd1, 0xda, 0xa3, 0xaa, 0xd4, 0x0d, 0x87, 0x6a, 0x23, 0x83, 0x68, 0x19, 0x27, 0x13, 0xd5,
-	0xa1, 0xc4, 0x18, 0xfa, 0xf0, 0xc3, 0xc5, 0xa9, 0x87, 0xbf, 0x07, 0x49, 0x7a, 0xf0, 0x79, 0x00,
-	0x28, 0xbf, 0x0a, 0xb3, 0x5c, 0x09, 0xd4, 0x20, 0xd5, 0xc7, 0x21, 0xbd, 0xa7, 0x22, 0xee, 0xe8,
-	0x73, 0x33, 0x7b, 0xb9, 0x24, 0xf9, 0x77, 0x01, 0xf2, 0x6a, 0xc9, 0xb0, 0x9c, 0x92, 0x65, 0x50,
-	0xe3, 0xa2, 0x52, 0x37, 0x21, 0xe1, 0xd8, 0x5c, 0x8a, 0xe2, 0x8a, 0x1b, 0x19, 0x56, 0xbc, 0xc8,
-	0xb0, 0xa2, 0x7b, 0x91, 0x41, 0x43, 0x2e, 0x2e, 0x71, 0x62, 0x8a, 0xc4, 0x97, 0xdd, 0x1b, 0xea,
-	0x02, 0x30, 0xe7, 0xb1, 0xb0, 0xdb, 0xcb, 0x2e, 0x2c, 0xb9, 0x06, 0x49, 0xc4, 0xc0, 0x35, 0x75,
-	0x00, 0x22, 0xff, 0xa2, 0x6a, 0x8c, 0x4c, 0x81, 0x34, 0x3a, 0xbb, 0x51, 0x83, 0x0f, 0xb9, 0x28,
-	0x35, 0x9e, 0xa9, 0x7f, 0x46, 0xd7, 0x0d, 0xa9, 0x6c, 0xf7, 0xf0, 0xf6, 0xa5, 0x76, 0x71, 0xc6,
-	0x8e, 0x82, 0x4f, 0xd9, 0xb6, 0x5b, 0x26, 0xc2, 0xc5, 0xc8, 0x78, 0x40, 0x6a, 0xf7, 0xb0, 0x65,
-	0x76, 0xb8, 0xca, 0x11, 0x7d, 0x5c, 0x1a, 0x29, 0xc0, 0x6c, 0xef, 0xc8, 0x65, 0x4b, 0xb1, 0xab,
-	0xe9, 0x7d, 0x9e, 0x0e, 0xc6, 0x5b, 0x90, 0xdd, 0x37, 0x9c, 0xdd, 0x43, 0x14, 0xaa, 0xdf, 0x76,
-	0x38, 0x26, 0x05, 0x8f, 0x71, 0x93, 0x92, 0x14, 0xc7, 0xb1, 0x6c, 0x97, 0xae, 0x05, 0x99, 0xe5,
-	0x5b, 0x90, 0x63, 0x6a, 0x51, 0x3f, 0x8e, 0x75, 0x1d, 0xe5, 0x01, 0xb7, 0xbd, 0x66, 0x3c, 0x42,
-	0x11, 0x62, 0x5f, 0x61, 0x0a, 0x05, 0x07, 0x20, 0x17, 0x04, 0x4a, 0x63, 0x94, 0x78, 0x2e, 0xaf,
-	0x43, 0xd6, 0xb5, 0x81, 0x56, 0x89, 0x77, 0x2e, 0xdf, 0x35, 0x31, 0x75, 0xd7, 0x2f, 0xd1, 0x9b,
-	0xf5, 0xbf, 0xd2, 0x9b, 0x51, 0x8a, 0x5e, 0x7f, 0x27, 0xaa, 0x5b, 0xc0, 0x0b, 0x91, 0xea, 0x89,
-	0x9a, 0x9c, 0x2a, 0xea, 0x3a, 0xcc, 0xeb, 0x11, 0x27, 0xf4, 0xa0, 0x15, 0x26, 0x41, 0x2b, 0xff,
-	0x24, 0xc0, 0x42, 0x60, 0x15, 0x8f, 0x54, 0xe7, 0xab, 0x22, 0xe6, 0xa9, 0x4e, 0xff, 0x98, 0xa9,
-	0x38, 0xa7, 0xd1, 0x21, 0xb9, 0x0d, 0xd0, 0x42, 0xa7, 0x33, 0x77, 0xfa, 0x0e, 0xa6, 0x83, 0x24,
-	0x0b, 0x26, 0x17, 0x7d, 0xe7, 0xa5, 0xe2, 0x28, 0x1e, 0x59, 0x0b, 0x70, 0x7a, 0x38, 0xa4, 0xa6,
-	0xe2, 0x70, 0x9b, 0x5b, 0xcc, 0xdf, 0x82, 0x26, 0x20, 0x7f, 0x93, 0x6a, 0xeb, 0xd8, 0xe0, 0x79,
-	0x3f, 0x3c, 0x29, 0xdf, 0x01, 0x12, 0x45, 0x02, 0x21, 0xbc, 0x16, 0xbe, 0xc7, 0xf3, 0x41, 0x0c,
-	0x29, 0x8f, 0x4b, 0x95, 0x7f, 0x11, 0x40, 0xd2, 0xbd, 0xbb, 0x82, 0xf3, 0x36, 0x85, 0xf1, 0x16,
-	0xa4, 0x76, 0x30, 0x69, 0x74, 0x62, 0x20, 0xe9, 0x32, 0x92, 0x57, 0x68, 0x4c, 0xf2, 0xd0, 0x9c,
-	0xc6, 0x4f, 0xd9, 0xbc, 0x84, 0x22, 0xc6, 0x49, 0x28, 0xc9, 0xd3, 0x12, 0xca, 0x74, 0x50, 0x07,
-	0x1c, 0xd4, 0x97, 0x70, 0xb1, 0x3f, 0xf2, 0x5c, 0xd4, 0x3d, 0x9b, 0xbb, 0xe8, 0xe9, 0xc7, 0xbb,
-	0x4e, 0x9c, 0x88, 0xe5, 0xc4, 0x71, 0x23, 0x8c, 0x7e, 0xfe, 0x11, 0xe6, 0x2b, 0xea, 0x39, 0x95,
-	0xfa, 0x3f, 0x23, 0xc6, 0x34, 0xd1, 0x14, 0x61, 0x59, 0xe3, 0x44, 0x19, 0x72, 0x03, 0xd2, 0x56,
-	0xb7, 0xeb, 0x94, 0x26, 0x79, 0x83, 0x4f, 0x95, 0xd7, 0xd0, 0xcf, 0xdc, 0x03, 0xe2, 0x27, 0x9d,
-	0xc7, 0x3e, 0x80, 0x2f, 0xc1, 0x3b, 0xb1, 0x3b, 0xa0, 0x4b, 0x68, 0x77, 0xe0, 0x83, 0x90, 0xe3,
-	0xc1, 0xf5, 0x35, 0x6c, 0x2a, 0x26, 0x11, 0x69, 0xad, 0xdc, 0xb3, 0x8c, 0xa3, 0x5b, 0xbc, 0xf4,
-	0x76, 0x3f, 0xe4, 0xcf, 0x04, 0x98, 0xe5, 0xa1, 0xe5, 0xfc, 0xa3, 0x30, 0xed, 0x16, 0x44, 0xbf,
-	0x5b, 0x60, 0xa5, 0x07, 0x0b, 0x6d, 0x6e, 0x00, 0x9e, 0x0b, 0x05, 0x60, 0x2f, 0xb0, 0xad, 0x62,
-	0x9f, 0xe6, 0xca, 0x43, 0x6f, 0x49, 0xd2, 0x36, 0x1c, 0xaf, 0xfa, 0x1b, 0x09, 0x85, 0x8c, 0x88,
-	0x45, 0x43, 0xda, 0xab, 0x71, 0xa8, 0xde, 0xac, 0x12, 0xe3, 0x7a, 0xb3, 0xb2, 0x4b, 0x72, 0xcb,
-	0x2e, 0xde, 0xb5, 0xe0, 0x50, 0xfe, 0x55, 0x00, 0x50, 0x4a, 0x0a, 0x8d, 0xd7, 0xe7, 0xef, 0xfb,
-	0x58, 0xb2, 0x1a, 0xcc, 0xef, 0xc4, 0x31, 0x76, 0x76, 0x49, 0x67, 0x4e, 0x47, 0xd8, 0x38, 0xd8,
-	0x9e, 0x37, 0x4c, 0x8e, 0x9f, 0x43, 0x1e, 0xf9, 0x0b, 0x11, 0xb2, 0xbe, 0xa6, 0x78, 0x73, 0x6e,
-	0x47, 0x1a, 0xdf, 0xff, 0x78, 0xab, 0x03, 0x4c, 0x63, 0x7a, 0xdf, 0x18, 0xbe, 0x1b, 0x12, 0x4d,
-	0x8c, 0x21, 0xda, 0x27, 0x89, 0x50, 0x3f, 0xbd, 0x08, 0xf3, 0x9b, 0x8d, 0x4a, 0xa5, 0x59, 0x6f,
-	0x94, 0x4a, 0x6a, 0xbd, 0x8e, 0x63, 0x6c, 0xae, 0x2f, 0x02, 0xd9, 0x56, 0x34, 0xbd, 0xac, 0x84,
-	0xe6, 0x05, 0xb2, 0x0c, 0x8b, 0xd5, 0x5a, 0x53, 0xd1, 0x75, 0xad, 0x7c, 0xaf, 0xa1, 0xab, 0xf5,
-	0xe6, 0x66, 0xad, 0x51, 0xdd, 0x90, 0xd2, 0x68, 0xff, 0xfc, 0xa6, 0x52, 0xae, 0x34, 0x34, 0xb5,
-	0xb9, 0x55, 0xae, 0x3e, 0x54, 0x2a, 0xd2, 0x1e, 0xc9, 0xc2, 0x2c, 0x9f, 0x93, 0xa8, 0x53, 0x66,
-	0xef, 0x29, 0x1b, 0x4d, 0x4d, 0x7d, 0xbf, 0xa1, 0xd6, 0x75, 0xe9, 0x3b, 0x81, 0xce, 0x50, 0x72,
-	0xb3, 0x8a, 0x7f, 0x7a, 0x5d, 0xfa, 0x3e, 0x3c, 0x53, 0xde, 0x90, 0x7e, 0x10, 0x50, 0xb8, 0xbc,
-	0x3f, 0xa3, 0x96, 0x54, 0x4d, 0x97, 0x7e, 0xa4, 0x42, 0x10, 0x7f, 0xb2, 0x5e, 0x7e, 0xb7, 0xaa,
-	0xe8, 0xf4, 0x88, 0x27, 0x02, 0x16, 0xcf, 0x8b, 0x3e, 0x61, 0x28, 0xa3, 0xf4, 0xd4, 0xdf, 0x87,
-	0x89, 0xa7, 0x7c, 0x48, 0xc5, 0x7b, 0x2a, 0x14, 0x13, 0x92, 0x20, 0x7f, 0x8e, 0x05, 0x3d, 0x9a,
-	0xc0, 0xaf, 0x8e, 0x9f, 0xd7, 0x2d, 0x7d, 0xa7, 0x4b, 0x4c, 0x76, 0xba, 0xe7, 0xb6, 0xd0, 0xc7,
-	0x98, 0x28, 0xc2, 0x42, 0xa1, 0x07, 0xdd, 0x89, 0x78, 0xd0, 0xd5, 0x80, 0x07, 0x85, 0x38, 0xc7,
-	0xb9, 0x11, 0x5e, 0xc5, 0x2d, 0xdb, 0xcd, 0x4f, 0x19, 0x4d, 0x3c, 0xb6, 0x0f, 0xe4, 0xeb, 0x21,
-	0x27, 0x40, 0x53, 0x71, 0x3b, 0xa3, 0xf1, 0x83, 0x76, 0x63, 0xb2, 0x44, 0x7b, 0x87, 0xc9, 0xb2,
-	0x44, 0x39, 0xcf, 0x57, 0x96, 0x27, 0x02, 0xe4, 0xf8, 0x7d, 0x79, 0x8e, 0x72, 0x0f, 0x1b, 0xb4,
-	0xbc, 0x3f, 0xf1, 0xb0, 0xd5, 0xee, 0x1b, 0x3c, 0x24, 0x45, 0x66, 0xc9, 0x9b, 0x90, 0x79, 0xd4,
-	0x6a, 0x9b, 0x7b, 0x9b, 0x56, 0xf7, 0x98, 0xdb, 0x69, 0x9a, 0xf9, 0x87, 0xcc, 0xe4, 0x75, 0x98,
-	0x65, 0x1f, 0x7a, 0x97, 0x67, 0xd5, 0x69, 0xeb, 0x3c, 0xd6, 0x9b, 0xff, 0x07, 0x18, 0x3e, 0xd1,
-	0x90, 0x0c, 0xa4, 0xd4, 0xd2, 0x46, 0x5d, 0x41, 0xa5, 0x67, 0x41, 0xd4, 0x70, 0x20, 0xd0, 0x01,
-	0x9d, 0x49, 0xdc, 0xdc, 0x82, 0x24, 0xad, 0xe3, 0x48, 0x1a, 0x92, 0xd5, 0x5a, 0x55, 0x45, 0x1e,
-	0x80, 0x99, 0x52, 0xa5, 0xac, 0x56, 0x75, 0x64, 0xc3, 0xd9, 0x6d, 0x55, 0xd5, 0xa4, 0x04, 0x99,
-	0x83, 0x0c, 0x3a, 0x77, 0x79, 0x43, 0xd1, 0x6b, 0x9a, 0x94, 0xa4, 0xe8, 0x29, 0x8d, 0x8d, 0x32,
-	0xfd, 0x48, 0xe3, 0x01, 0xa2, 0x52, 0xa9, 0x48, 0xcf, 0x9e, 0x89, 0x6b, 0x5f, 0x27, 0x20, 0xa9,
-	0x96, 0x94, 0x6d, 0xac, 0x5b, 0x17, 0x68, 0xf6, 0x2d, 0x29, 0xd4, 0x51, 0xcd, 0x7d, 0x73, 0x17,
-	0x33, 0x3d, 0xf1, 0xd3, 0x03, 0x7b, 0x4c, 0x2b, 0x86, 0x7c, 0x9a, 0xdc, 0x87, 0x0b, 0x6e, 0x41,
-	0x10, 0x58, 0xc1, 0x32, 0x80, 0x1f, 0x46, 0xc3, 0x4f, 0x02, 0xc5, 0xe5, 0xb1, 0xf3, 0xe8, 0xd0,
-	0x77, 0x61, 0x91, 0x9d, 0x1d, 0xd9, 0x67, 0x29, 0xc4, 0xcf, 0x6b, 0x83, 0xe2, 0x48, 0x57, 0x4d,
-	0xd6, 0xe1, 0x42, 0x64, 0xf9, 0xbd, 0x01, 0x7b, 0xbd, 0xf3, 0xe5, 0xa5, 0x5f, 0x11, 0xe9, 0x15,
-	0xba, 0x88, 0x56, 0x0e, 0xd3, 0xa5, 0xf7, 0xab, 0x8b, 0xc0, 0xb9, 0xfc, 0x81, 0x72, 0xed, 0x37,
-	0x81, 0x61, 0xa7, 0x60, 0x48, 0xcf, 0x05, 0x5f, 0xb1, 0xc8, 0x72, 0xf8, 0x25, 0xc9, 0x7f, 0xdb,
-	0x2a, 0x86, 0x9b, 0x75, 0x5c, 0x97, 0x0d, 0x3c, 0x17, 0x0d, 0x4f, 0x0e, 0xbf, 0x21, 0x15, 0xe7,
-	0x83, 0x4f, 0x2e, 0x94, 0xf1, 0x2e, 0xb5, 0x55, 0x44, 0xf6, 0xf8, 0x72, 0x23, 0x5e, 0xc0, 0xea,
-	0x40, 0xfb, 0x10, 0xab, 0x5a, 0xb2, 0x18, 0xb6, 0x0a, 0xab, 0x73, 0xc7, 0x28, 0xfb, 0x29, 0x3a,
-	0x8a, 0x7e, 0x36, 0x47, 0xd9, 0x82, 0xa5, 0x11, 0x47, 0xa1, 0x6a, 0x5c, 0x0a, 0xa5, 0xdb, 0x60,
-	0x33, 0x5a, 0x2c, 0x4e, 0x22, 0x31, 0x6f, 0x99, 0xa6, 0xbd, 0x7e, 0x9a, 0xf6, 0x25, 0x58, 0x1a,
-	0x59, 0x3e, 0x2a, 0x4d, 0xb0, 0xef, 0x18, 0x83, 0xc6, 0xb7, 0x02, 0x43, 0x43, 0xf9, 0x3b, 0x08,
-	0x33, 0xc9, 0x9e, 0xfa, 0x54, 0x7b, 0xfe, 0x21, 0xc0, 0x0c, 0xad, 0xa0, 0xcf, 0x78, 0xf5, 0x17,
-	0x46, 0x2c, 0x4a, 0xfc, 0x07, 0xa6, 0x68, 0x67, 0x53, 0xbc, 0x34, 0x81, 0x82, 0xc6, 0x7c, 0x83,
-	0x3e, 0x00, 0x87, 0xee, 0x6e, 0x00, 0xbd, 0x50, 0x53, 0x10, 0x11, 0xe1, 0x9d, 0x71, 0xc0, 0x17,
-	0x46, 0x96, 0x4e, 0xbe, 0xbd, 0x65, 0xae, 0xbf, 0xf2, 0xe2, 0x5b, 0x7d, 0x83, 0xde, 0xa0, 0x9c,
-	0x0d, 0xc9, 0xb7, 0xe9, 0x8a, 0x93, 0x3e, 0x26, 0x04, 0x65, 0x58, 0x63, 0x92, 0x91, 0x92, 0xf0,
-	0xa4, 0xb8, 0x38, 0xa6, 0x4c, 0x24, 0x1b, 0x58, 0xb1, 0x79, 0x79, 0x96, 0xaf, 0x5d, 0x1e, 0x5f,
-	0x0c, 0x9c, 0x14, 0x0b, 0x93, 0xaa, 0x84, 0x1d, 0xf7, 0xff, 0x37, 0xeb, 0x7f, 0x06, 0x00, 0x00,
-	0xff, 0xff, 0x9f, 0x47, 0x1c, 0x48, 0xd2, 0x19, 0x00, 0x00,
+	// 2058 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0x4f, 0x6f, 0x23, 0x49,
+	0x15, 0x4f, 0xff, 0x71, 0x1c, 0x3f, 0x3b, 0x76, 0xa7, 0x92, 0xd9, 0xe9, 0x31, 0x68, 0x27, 0xea,
+	0x30, 0xd9, 0x30, 0x82, 0x24, 0x38, 0x28, 0xc0, 0xee, 0x8e, 0x50, 0x8f, 0xe3, 0xb0, 0x66, 0x3c,
+	0x4e, 0x28, 0xb7, 0x07, 0x6e, 0x56, 0xc7, 0xae, 0x38, 0xad, 0x38, 0xb6, 0xd3, 0xdd, 0x1e, 0x64,
+	0xed, 0x27, 0x58, 0x0e, 0x1c, 0xf8, 0x0e, 0x48, 0x88, 0x03, 0x07, 0xce, 0x48, 0x5c, 0x81, 0xdd,
+	0x91, 0xb8, 0x70, 0x42, 0x48, 0xdc, 0x90, 0x38, 0xf1, 0x09, 0x58, 0x54, 0x55, 0xdd, 0xed, 0xee,
+	0x8e, 0xdb, 0xe9, 0xc9, 0x06, 0x2d, 0x9c, 0xdc, 0x55, 0xef, 0x55, 0xbd, 0xf7, 0x7e, 0xef, 0xd5,
+	0xab, 0x57, 0xcf, 0xb0, 0xd2, 0x35, 0x77, 0xc7, 0xf6, 0xc8, 0x1d, 0xa1, 0x65, 0xf6, 0xe3, 0x94,
+	0x1f, 0xf7, 0x47, 0xa3, 0xfe, 0x80, 0xec, 0xb1, 0xe1, 0xd9, 0xe4, 0x7c, 0xcf, 0xb5, 0xae, 0x88,
+	0xe3, 0x9a, 0x57, 0x63, 0xce, 0xa8, 0x5d, 0xc0, 0x4a, 0x55, 0x6f, 0xb9, 0xa6, 0x3b, 0x71, 0xd0,
+	0x01, 0x2c, 0x3b, 0xec, 0x4b, 0x15, 0x36, 0x85, 0x9d, 0x62, 0xe5, 0x2b, 0x9c, 0xc7, 0xd9, 0xf5,
+	0x39, 0x76, 0xf9, 0x4f, 0x75, 0xd4, 0x23, 0xd8, 0x63, 0xd5, 0xde, 0x03, 0x98, 0xcd, 0xa2, 0x65,
+	0x10, 0x4f, 0x5e, 0x28, 0x4b, 0x68, 0x0d, 0x56, 0xdb, 0xcd, 0x17, 0xcd, 0x93, 0x1f, 0x37, 0x3b,
+	0x35, 0x8c, 0x4f, 0xb0, 0x22, 0x68, 0x59, 0xc8, 0xd4, 0xae, 0xc6, 0xee, 0x54, 0x2b, 0xc3, 0x4a,
+	0xbd, 0x47, 0x86, 0xae, 0xe5, 0x4e, 0x51, 0x11, 0x44, 0xab, 0xc7, 0xc4, 0xe5, 0xb0, 0x68, 0xf5,
+	0xb4, 0x47, 0x90, 0x31, 0x46, 0x97, 0x64, 0x88, 0x14, 0x90, 0xdc, 0xd1, 0x25, 0xa3, 0x14, 0x30,
+	0xfd, 0xd4, 0xca, 0x20, 0x7f, 0x64, 0x3a, 0x17, 0x08, 0x81, 0x7c, 0x61, 0x3a, 0x17, 0x1e, 0x89,
+	0x7d, 0x6b, 0x35, 0xc8, 0x9d, 0x4e, 0xce, 0x06, 0x56, 0xf7, 0x05, 0x99, 0xa2, 0x6d, 0x90, 0xdd,
+	0xe9, 0x98, 0x78, 0x46, 0xa0, 0xc0, 0x08, 0x7b, 0x3a, 0x76, 0x47, 0xc6, 0x74, 0x4c, 0x30, 0xa3,
+	0x53, 0x11, 0x97, 0x64, 0xaa, 0x8a, 0x5c, 0xc4, 0x25, 0x99, 0x6a, 0xc7, 0x00, 0xa7, 0xb6, 0xf5,
+	0xda, 0x74, 0xc9, 0x17, 0xdb, 0xe7, 0x04, 0x72, 0x2d, 0xab, 0x3f, 0x34, 0xdd, 0x89, 0x4d, 0x52,
+	0x6f, 0x53, 0x00, 0xc1, 0xf6, 0x36, 0x11, 0x6c, 0x3a, 0x72, 0x54, 0x89, 0x8f, 0x1c, 0xcd, 0x82,
+	0x1c, 0x26, 0x7d, 0xcb, 0x71, 0x6d, 0xd3, 0x46, 0x9b, 0x01, 0x66, 0xf9, 0x8a, 0xe2, 0x6f, 0xe7,
+	0x23, 0x4a, 0x51, 0x44, 0x1b, 0x90, 0xb1, 0x47, 0x03, 0xe2, 0xa8, 0xe2, 0xa6, 0xb4, 0x93, 0xc3,
+	0x7c, 0x80, 0xbe, 0x06, 0xab, 0x3d, 0x32, 0x20, 0x7d, 0xd3, 0x25, 0x98, 0x51, 0x25, 0x46, 0x8d,
+	0x4e, 0x6a, 0x6f, 0x04, 0x28, 0x71, 0x59, 0xc4, 0x6e, 0x3b, 0xc4, 0xc6, 0xe4, 0x3a, 0x85, 0xc4,
+	0x4d, 0x90, 0xa9, 0x10, 0xa6, 0x7f, 0xb1, 0x52, 0xf0, 0x79, 0xe8, 0x96, 0x98, 0x51, 0xd0, 0x26,
+	0xe4, 0xcd, 0xf3, 0x73, 0x6b, 0x60, 0x99, 0xae, 0x35, 0x1a, 0xaa, 0x32, 0x73, 0x79, 0x78, 0x0a,
+	0xed, 0x41, 0xce, 0xf6, 0x8d, 0x54, 0x33, 0x4c, 0xd8, 0x5a, 0xb0, 0x91, 0x4f, 0xc0, 0x33, 0x1e,
+	0xb4, 0x05, 0x92, 0x63, 0xf5, 0xd5, 0xe5, 0x28, 0x6b, 0x80, 0x3c, 0xa6, 0x54, 0xed, 0x63, 0x28,
+	0x62, 0x62, 0xf6, 0xa8, 0x29, 0x2d, 0xe2, 0x52, 0x6b, 0x34, 0x90, 0x6c, 0x72, 0x9d, 0x68, 0x0e,
+	0x25, 0xa6, 0xb0, 0xc7, 0x13, 0x2e, 0x2d, 0x14, 0xfe, 0x43, 0x90, 0xa9, 0xe0, 0xfb, 0x00, 0x50,
+	0xfb, 0x26, 0x64, 0x3d, 0x23, 0x90, 0x06, 0x99, 0x89, 0x43, 0x6c, 0x7a, 0x4e, 0xa5, 0x9d, 0xfc,
+	0x8c, 0x9b, 0xf9, 0x8b, 0x93, 0xb4, 0x7f, 0x09, 0x50, 0xac, 0x55, 0x89, 0xed, 0x56, 0x6d, 0x42,
+	0x9d, 0x4b, 0xae, 0xd1, 0x53, 0x10, 0x5d, 0xc7, 0xd3, 0xa2, 0xbc, 0xcb, 0x33, 0xc3, 0xae, 0x9f,
+	0x19, 0x76, 0x0d, 0x3f, 0x33, 0x60, 0xd1, 0x75, 0x3c, 0x8d, 0xc5, 0x05, 0x1a, 0x3f, 0xe6, 0x27,
+	0x94, 0x03, 0xb0, 0xea, 0xb3, 0xb0, 0xd3, 0xcb, 0x0e, 0x2c, 0x7a, 0x02, 0xb2, 0x63, 0xf5, 0xb9,
+	0xab, 0x43, 0x10, 0x05, 0x07, 0x15, 0x33, 0x32, 0x05, 0x92, 0x0c, 0xbb, 0x6a, 0x26, 0x89, 0x8b,
+	0x52, 0xd3, 0xb9, 0xfa, 0x2f, 0x02, 0x94, 0x22, 0x26, 0x3b, 0x63, 0xb4, 0x0d, 0x99, 0x2e, 0xb1,
+	0x03, 0xb3, 0x03, 0x53, 0x28, 0xdb, 0xa9, 0x69, 0xd9, 0x98, 0x93, 0xd1, 0x16, 0x64, 0xba, 0x17,
+	0xa6, 0x35, 0x54, 0xc5, 0x79, 0xf6, 0x70, 0x1a, 0x52, 0x21, 0x3b, 0xbe, 0xe4, 0x6c, 0x19, 0x76,
+	0x34, 0xfd, 0xe1, 0xed, 0x60, 0xbc, 0x0f, 0xf9, 0x73, 0xe2, 0x76, 0x2f, 0x30, 0x71, 0x26, 0x03,
+	0xd7, 0xc3, 0x44, 0xf5, 0x19, 0x8f, 0x29, 0x49, 0x77, 0x5d, 0xdb, 0xe1, 0x74, 0x1c, 0x66, 0xd6,
+	0xf6, 0xa1, 0xc0, 0xcc, 0xa2, 0x71, 0x9c, 0xea, 0x38, 0x6a, 0x53, 0xcf, 0xf7, 0x98, 0xbc, 0x1e,
+	0x5d, 0x92, 0xd4, 0x47, 0x98, 0x42, 0xe1, 0x01, 0x50, 0x08, 0x03, 0x85, 0x19, 0x25, 0x5d, 0xc8,
+	0x1b, 0x90, 0xe7, 0x3e, 0xc0, 0x8d, 0x74, 0x72, 0xbd, 0x5d, 0xc5, 0x85, 0xbb, 0xfe, 0x4a, 0x80,
+	0xa2, 0xf1, 0xdf, 0x8c, 0xe6, 0x2d, 0x90, 0xc6, 0x93, 0x33, 0x55, 0x4a, 0x8c, 0xc2, 0xf1, 0xe4,
+	0xcc, 0x57, 0x55, 0x5e, 0xa8, 0xea, 0x01, 0x94, 0x8c, 0x58, 0x10, 0xfa, 0xd0, 0x0a, 0x49, 0xd0,
+	0x6a, 0x7f, 0x16, 0x60, 0x2d, 0xb4, 0xca, 0xcb, 0x54, 0xf7, 0x6b, 0xa2, 0x02, 0xd2, 0x70, 0x72,
+	0xc5, 0x4c, 0x5c, 0xc5, 0xf4, 0x13, 0x1d, 0x02, 0x98, 0xae, 0x6b, 0x5b, 0x67, 0x13, 0x97, 0x38,
+	0xaa, 0xcc, 0x92, 0xc9, 0x3b, 0x41, 0xf0, 0x52, 0x75, 0x74, 0x9f, 0x8c, 0x43, 0x9c, 0x3e, 0x0e,
+	0x99, 0x85, 0x38, 0x1c, 0x42, 0x31, 0xba, 0x05, 0xbd, 0x80, 0x82, 0x4d, 0x9a, 0xe6, 0x15, 0xf1,
+	0xee, 0xfd, 0xe8, 0xa4, 0xf6, 0x01, 0xa0, 0x38, 0x12, 0xce, 0x18, 0x3d, 0x89, 0x9e, 0xe3, 0x52,
+	0x18, 0x43, 0xca, 0xc3, 0xa9, 0xda, 0xdf, 0x04, 0x50, 0x0c, 0xff, 0xac, 0xb4, 0x88, 0xeb, 0x50,
+	0x18, 0xf7, 0x21, 0x73, 0x46, 0xfa, 0xd6, 0x30, 0x05, 0x92, 0x9c, 0x11, 0x7d, 0x83, 0xe6, 0x24,
+	0x1f, 0xcd, 0x45, 0xfc, 0x94, 0xcd, 0xbf, 0x50, 0xa4, 0x34, 0x17, 0x8a, 0x7c, 0xdb, 0x85, 0xb2,
+	0x18, 0xd4, 0xa9, 0x07, 0xea, 0x97, 0x70, 0xb0, 0x7f, 0xe3, 0x87, 0x28, 0x97, 0xed, 0x85, 0xe8,
+	0xed, 0xe2, 0x79, 0x10, 0x8b, 0xa9, 0x82, 0x38, 0x8d, 0x22, 0x34, 0xa5, 0x8f, 0x7e, 0x3a, 0x24,
+	0xb6, 0x2a, 0x27, 0x48, 0xe5, 0x64, 0x9a, 0x89, 0x8c, 0xfb, 0xcf, 0x44, 0xbf, 0xa6, 0x11, 0xd6,
+	0x68, 0xfd, 0x7f, 0xe4, 0xa2, 0x0e, 0xac, 0xc5, 0x74, 0x4d, 0x93, 0x8d, 0xd0, 0x0e, 0xac, 0xd8,
+	0xa3, 0x91, 0x5b, 0x4d, 0x8a, 0x9a, 0x80, 0xaa, 0x55, 0xa0, 0xe8, 0x09, 0x48, 0x7f, 0x39, 0x7d,
+	0x1c, 0x00, 0xf8, 0x25, 0x44, 0x71, 0x19, 0x64, 0xba, 0x84, 0xbe, 0x22, 0x02, 0x10, 0x0a, 0x5e,
+	0x12, 0xfe, 0x16, 0x64, 0x8c, 0x24, 0x22, 0xad, 0xa9, 0xc7, 0x36, 0xb9, 0xdc, 0xf7, 0x4a, 0x74,
+	0x3e, 0xd0, 0x7e, 0x2e, 0x40, 0xd6, 0x4b, 0x41, 0xf7, 0x9f, 0xad, 0xe9, 0xab, 0x42, 0x0a, 0x5e,
+	0x15, 0xac, 0x44, 0x61, 0x29, 0x90, 0x27, 0xea, 0xd5, 0x48, 0xa2, 0xf6, 0x13, 0xe0, 0x1e, 0xac,
+	0x78, 0xfa, 0xd0, 0xd3, 0x24, 0x3b, 0xc4, 0xf5, 0xab, 0xc4, 0x1b, 0x29, 0x93, 0x11, 0xb5, 0x7d,
+	0x58, 0xf1, 0x6b, 0x21, 0x6a, 0x37, 0xab, 0xd8, 0x3c, 0xbb, 0xe9, 0x37, 0x52, 0x78, 0x79, 0xe6,
+	0xbd, 0x6e, 0xc8, 0xb0, 0xab, 0xfd, 0x43, 0x00, 0xd0, 0xab, 0x3a, 0xcd, 0xeb, 0xf7, 0x1f, 0xfb,
+	0x1a, 0x64, 0x08, 0x8b, 0x3b, 0x69, 0x8e, 0x9f, 0x39, 0xe9, 0xce, 0xd7, 0xd6, 0x1e, 0xe4, 0x1c,
+	0x3f, 0x1a, 0x92, 0xf3, 0xec, 0x8c, 0x47, 0xfb, 0xa5, 0x04, 0xf9, 0xc0, 0x52, 0x67, 0x8c, 0x0e,
+	0x63, 0x0f, 0xe4, 0x77, 0xfd, 0xd5, 0x21, 0xa6, 0x39, 0x6f, 0xe4, 0x14, 0xb1, 0x1b, 0x51, 0x4d,
+	0x4a, 0xa1, 0xda, 0xcf, 0xc4, 0xc8, 0xbb, 0x7b, 0x1d, 0x4a, 0xc7, 0xed, 0x46, 0xa3, 0xd3, 0x6a,
+	0x57, 0xab, 0xb5, 0x56, 0xeb, 0xb8, 0xdd, 0x50, 0x96, 0xd0, 0x3b, 0x80, 0x4e, 0x75, 0x6c, 0xd4,
+	0xf5, 0xc8, 0xbc, 0x80, 0x1e, 0xc2, 0x7a, 0xf3, 0xa4, 0xa3, 0x1b, 0x06, 0xae, 0x3f, 0x6f, 0x1b,
+	0xb5, 0x56, 0xe7, 0xf8, 0xa4, 0xdd, 0x3c, 0x52, 0x56, 0x10, 0x82, 0xe2, 0xb1, 0x5e, 0x6f, 0xb4,
+	0x71, 0xad, 0xf3, 0xb2, 0xde, 0x7c, 0xa5, 0x37, 0x94, 0x1e, 0xca, 0x43, 0xd6, 0x9b, 0x53, 0x68,
+	0x50, 0xe6, 0x9f, 0xeb, 0x47, 0x1d, 0x5c, 0xfb, 0x51, 0xbb, 0xd6, 0x32, 0x94, 0x3f, 0x08, 0x74,
+	0x86, 0x92, 0x3b, 0xcd, 0x7a, 0xa3, 0x63, 0xb4, 0x94, 0x3f, 0x46, 0x67, 0xea, 0x47, 0xca, 0x9f,
+	0x04, 0xb4, 0x0e, 0xc5, 0x60, 0xa6, 0x56, 0xad, 0x61, 0x43, 0xf9, 0x94, 0x2a, 0x81, 0x82, 0xc9,
+	0x56, 0xfd, 0x07, 0x4d, 0xdd, 0xa0, 0x22, 0x3e, 0x13, 0x90, 0x0a, 0xeb, 0x01, 0x61, 0xa6, 0xa3,
+	0xf2, 0x26, 0xd8, 0x87, 0xa9, 0xa7, 0xff, 0x84, 0xaa, 0xf7, 0x46, 0x28, 0x8b, 0x8a, 0xa0, 0xfd,
+	0x42, 0x80, 0x92, 0x5e, 0xd5, 0x83, 0x2a, 0xfa, 0x6d, 0xc3, 0x32, 0x08, 0x3a, 0x31, 0x39, 0xe8,
+	0xde, 0xda, 0x43, 0x9f, 0x08, 0xa0, 0x44, 0x95, 0x72, 0xc6, 0xe8, 0x83, 0x58, 0x04, 0x6d, 0x85,
+	0x22, 0x28, 0xc2, 0x39, 0x2f, 0x8c, 0x14, 0x90, 0x5e, 0x3a, 0xfc, 0x7e, 0xca, 0x61, 0xe9, 0xca,
+	0xe9, 0x6b, 0xdb, 0x91, 0x20, 0xc8, 0x43, 0xd6, 0xf3, 0xb3, 0xb2, 0x14, 0xf1, 0x1b, 0xd3, 0x25,
+	0xfe, 0xc6, 0x48, 0xd6, 0x25, 0xce, 0x79, 0xbf, 0xba, 0x7c, 0x26, 0x40, 0xc1, 0x3b, 0x2f, 0x6f,
+	0x51, 0x16, 0xa2, 0x6d, 0x28, 0x06, 0x13, 0xaf, 0xcc, 0xc1, 0x84, 0x78, 0x29, 0x29, 0x36, 0x8b,
+	0xbe, 0x0b, 0xb9, 0xd7, 0xe6, 0xc0, 0xea, 0x1d, 0xdb, 0xa3, 0x2b, 0x55, 0xba, 0xd5, 0xfd, 0x33,
+	0x66, 0xf4, 0x6d, 0xc8, 0xb2, 0x81, 0x31, 0x52, 0xe5, 0x5b, 0xd7, 0xf9, 0xac, 0x1a, 0x02, 0xa9,
+	0x8a, 0x1b, 0x28, 0x0f, 0x52, 0xd7, 0x1e, 0xf0, 0xcc, 0xa9, 0xfd, 0x95, 0xc7, 0x23, 0x35, 0x51,
+	0xef, 0x5d, 0x59, 0x43, 0x1a, 0x8f, 0x5f, 0x5d, 0x74, 0xc3, 0xa1, 0xc7, 0x7e, 0x4d, 0x93, 0x90,
+	0x1b, 0xd1, 0x7b, 0x90, 0x0b, 0x0c, 0xf5, 0xcc, 0xda, 0x88, 0x65, 0x1f, 0x8e, 0x26, 0x8f, 0xfb,
+	0x4c, 0xaa, 0xb8, 0xdf, 0x80, 0xcc, 0x70, 0x34, 0xec, 0x12, 0xf6, 0xae, 0x2e, 0x60, 0x3e, 0x40,
+	0xef, 0x2e, 0xae, 0x2c, 0xb4, 0xc3, 0x20, 0xfd, 0xd3, 0x5b, 0x6f, 0x27, 0x92, 0x8c, 0xf9, 0x55,
+	0x33, 0x57, 0x33, 0xed, 0x53, 0x01, 0x56, 0xbd, 0x89, 0xea, 0x85, 0x39, 0xec, 0x53, 0x9f, 0xa6,
+	0x3a, 0xa3, 0x68, 0x2b, 0xdc, 0x19, 0x12, 0x6f, 0x83, 0x30, 0xa1, 0x0e, 0x47, 0xeb, 0x73, 0x3a,
+	0x50, 0xa8, 0x08, 0xcb, 0x66, 0x97, 0x8d, 0x33, 0x6c, 0x1c, 0xc1, 0x79, 0x39, 0x19, 0x67, 0xed,
+	0x7d, 0x50, 0xbc, 0x31, 0x37, 0x86, 0x62, 0xb1, 0x0d, 0xd9, 0x2e, 0x1b, 0xf8, 0x40, 0x3c, 0x88,
+	0x2d, 0xe5, 0xac, 0x4f, 0xbf, 0x0e, 0x30, 0x6b, 0xff, 0xa1, 0x1c, 0x64, 0x6a, 0xd5, 0xa3, 0x96,
+	0xae, 0x2c, 0xa1, 0x2c, 0x48, 0xb8, 0xa5, 0x2b, 0x02, 0xfd, 0xa0, 0x33, 0xe2, 0xd3, 0x97, 0x20,
+	0xd3, 0x37, 0x02, 0x5a, 0x01, 0xb9, 0x79, 0xd2, 0xac, 0x29, 0x4b, 0x08, 0x60, 0xb9, 0xda, 0xa8,
+	0xd7, 0x9a, 0x86, 0x22, 0xd0, 0xd9, 0xd3, 0x5a, 0x0d, 0x2b, 0x22, 0x5a, 0x85, 0xdc, 0x2b, 0xbd,
+	0x51, 0x3f, 0xd2, 0x8d, 0x13, 0xac, 0xc8, 0xf4, 0xc4, 0xe9, 0xed, 0xa3, 0x3a, 0x1d, 0xac, 0xa0,
+	0x1c, 0x48, 0x7a, 0xa3, 0xa1, 0x7c, 0xfe, 0xb9, 0x54, 0xf9, 0xbb, 0x08, 0x72, 0xad, 0xaa, 0x9f,
+	0xa2, 0x7d, 0x58, 0xa3, 0x15, 0x5b, 0x55, 0xa7, 0xc9, 0xcd, 0x3a, 0xb7, 0xba, 0xa6, 0x4b, 0x50,
+	0x50, 0x52, 0xb0, 0x46, 0x6d, 0x39, 0x92, 0x07, 0xd1, 0x47, 0xf0, 0x80, 0x17, 0x91, 0xa1, 0x15,
+	0xac, 0x6a, 0x08, 0xae, 0xde, 0x68, 0xbb, 0xa9, 0xfc, 0x70, 0xee, 0xbc, 0x33, 0x46, 0xcf, 0x60,
+	0x9d, 0xc9, 0x8e, 0xed, 0xb3, 0x11, 0xe1, 0xf7, 0xea, 0xc9, 0xf2, 0x8d, 0x8e, 0x0d, 0x3a, 0x80,
+	0x07, 0xb1, 0xe5, 0xcf, 0xa7, 0xac, 0x33, 0x1c, 0xe8, 0x4b, 0x47, 0x31, 0xed, 0x75, 0x78, 0xc0,
+	0xab, 0xcd, 0xc5, 0xda, 0x07, 0x15, 0x69, 0x59, 0x89, 0x37, 0xbf, 0xd1, 0x13, 0xc8, 0x32, 0xb9,
+	0xb8, 0x11, 0x07, 0x2a, 0x1f, 0xf0, 0xe2, 0x46, 0xe5, 0x9f, 0x02, 0x83, 0x58, 0x47, 0x87, 0x50,
+	0x08, 0x37, 0x52, 0xd1, 0xc3, 0x68, 0x33, 0x33, 0x68, 0xaf, 0x96, 0xa3, 0xfd, 0x22, 0x74, 0x08,
+	0xf9, 0x50, 0xc7, 0x72, 0xa6, 0x60, 0xb4, 0x8d, 0x59, 0x2e, 0x85, 0xbb, 0x7e, 0x94, 0xf1, 0x19,
+	0xac, 0x71, 0xf5, 0xc3, 0x2e, 0x4d, 0x6f, 0xde, 0x01, 0x00, 0x7b, 0x62, 0x38, 0x17, 0xd4, 0xc2,
+	0xf5, 0xa8, 0xf3, 0x70, 0x63, 0xee, 0xa2, 0xca, 0x6f, 0x45, 0x90, 0x8d, 0xbb, 0xc5, 0xd3, 0x4b,
+	0xd8, 0xb8, 0x11, 0x4f, 0xd4, 0x8c, 0x47, 0x91, 0x4a, 0x2e, 0xdc, 0x0f, 0x29, 0x97, 0x93, 0x48,
+	0xce, 0xf8, 0x16, 0xeb, 0x8d, 0xdb, 0xac, 0xaf, 0xc2, 0xc6, 0x8d, 0xe5, 0x37, 0xb5, 0x09, 0x3f,
+	0x7d, 0xef, 0x1e, 0x21, 0xbf, 0x17, 0x18, 0x68, 0xfa, 0xff, 0x84, 0xce, 0x09, 0x6e, 0x37, 0x16,
+	0xba, 0xfd, 0xdf, 0x02, 0x2c, 0xd3, 0x37, 0xdc, 0x1d, 0x13, 0xc9, 0xda, 0x0d, 0xc7, 0xa3, 0xa0,
+	0x15, 0x1a, 0x7f, 0x5b, 0x97, 0x1f, 0x25, 0x50, 0x9c, 0x31, 0xfa, 0x0e, 0x94, 0x62, 0x99, 0x00,
+	0xbd, 0x13, 0xe3, 0xf6, 0xd3, 0x48, 0x54, 0x85, 0xef, 0xcf, 0x03, 0x5e, 0xbd, 0xb1, 0x34, 0x11,
+	0xfa, 0x4a, 0xdd, 0xb3, 0x5f, 0xff, 0xe2, 0x5b, 0xfd, 0x4e, 0x00, 0x59, 0xbf, 0x1b, 0x92, 0x1f,
+	0xd2, 0x15, 0xd7, 0x13, 0xe2, 0xcc, 0x1e, 0x3c, 0x0e, 0x42, 0x37, 0x1e, 0x25, 0xd7, 0xe5, 0xf5,
+	0x39, 0x0f, 0x15, 0x74, 0x04, 0xa5, 0xa0, 0xd2, 0xf3, 0xd6, 0x3e, 0x9c, 0x5f, 0x8e, 0x5e, 0x97,
+	0xd5, 0xa4, 0x3a, 0xb5, 0xf2, 0x89, 0xc8, 0xd4, 0xd7, 0xd1, 0xf7, 0xa0, 0xd0, 0x22, 0x33, 0x45,
+	0x22, 0x7b, 0x85, 0x2b, 0xa1, 0x39, 0x31, 0xf8, 0x21, 0x94, 0x38, 0x42, 0x77, 0x5a, 0xfd, 0x8c,
+	0xff, 0xc3, 0x93, 0x60, 0x46, 0x64, 0x71, 0x1c, 0x1b, 0x7a, 0x5a, 0xea, 0xb0, 0x11, 0x59, 0xce,
+	0xef, 0xe8, 0x05, 0x9b, 0xa8, 0x73, 0x2f, 0xf5, 0x16, 0x71, 0xcf, 0xf8, 0xbf, 0xae, 0x07, 0xff,
+	0x19, 0x00, 0x1d, 0x31, 0x20, 0x34, 0x88, 0x1d, 0x00, 0x00,
 }
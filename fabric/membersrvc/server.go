@@ -18,6 +18,7 @@ package main
 
 import (
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -68,6 +69,16 @@ func main() {
 	// cache configure
 	ca.CacheConfiguration()
 
+	// The state of the CAs is exported to a file to migrate it to another
+	// storage backend, into which it is then imported
+	if len(os.Args) == 3 && (os.Args[1] == "export" || os.Args[1] == "import") {
+		if err := migrate(os.Args[1], os.Args[2]); err != nil {
+			logger.Errorf("Fail to %s the state of the CAs: %s", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	logger.Infof("CA Server (" + metadata.Version + ")")
 
 	aca := ca.NewACA()
@@ -107,6 +118,30 @@ func main() {
 	tca.Start(srv)
 	tlsca.Start(srv)
 
+	if crlAddress := viper.GetString("server.crl.address"); crlAddress != "" {
+		logger.Infof("Serving CRLs on %s", crlAddress)
+		go func() {
+			if err := http.ListenAndServe(crlAddress, ca.NewCRLHandler(eca, tca)); err != nil {
+				logger.Errorf("Fail to serve CRLs: %s", err)
+			}
+		}()
+	}
+
+	// The admin interface of the ACA is only served over TLS
+	if adminAddress := viper.GetString("aca.admin.address"); adminAddress != "" && viper.GetBool("aca.enabled") {
+		certFile, keyFile := viper.GetString("server.tls.cert.file"), viper.GetString("server.tls.key.file")
+		if certFile == "" || keyFile == "" {
+			logger.Errorf("Not serving the ACA admin interface on %s: server.tls.cert.file and server.tls.key.file are required", adminAddress)
+		} else {
+			logger.Infof("Serving the ACA admin interface on %s", adminAddress)
+			go func() {
+				if err := http.ListenAndServeTLS(adminAddress, certFile, keyFile, ca.NewAttributeHandler(aca)); err != nil {
+					logger.Errorf("Fail to serve the ACA admin interface: %s", err)
+				}
+			}()
+		}
+	}
+
 	if sock, err := net.Listen("tcp", viper.GetString("server.port")); err != nil {
 		logger.Errorf("Fail to start CA Server: %s", err)
 		os.Exit(1)
@@ -115,3 +150,23 @@ func main() {
 		sock.Close()
 	}
 }
+
+// migrate exports the state of the CAs to file, or imports it from file,
+// using the storage backend selected by server.store.backend.
+func migrate(command, file string) error {
+	if command == "export" {
+		f, err := os.Create(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return ca.ExportStores(f)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return ca.ImportStores(f)
+}
@@ -50,6 +50,7 @@ func Cmd() *cobra.Command {
 		fmt.Sprint("Name of a custom ID generation algorithm (hashing and decoding) e.g. sha256base64"))
 
 	chaincodeCmd.AddCommand(deployCmd())
+	chaincodeCmd.AddCommand(upgradeCmd())
 	chaincodeCmd.AddCommand(invokeCmd())
 	chaincodeCmd.AddCommand(queryCmd())
 
@@ -66,6 +67,7 @@ var (
 	chaincodeQueryRaw       bool
 	chaincodeQueryHex       bool
 	chaincodeAttributesJSON string
+	chaincodeACLJSON        string
 	customIDGenAlg          string
 )
 
@@ -17,16 +17,21 @@ limitations under the License.
 package chaincode
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"golang.org/x/net/context"
 
 	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos"
 	"github.com/spf13/cobra"
 )
 
 // Cmd returns the cobra command for Chaincode Deploy
 func deployCmd() *cobra.Command {
+	chaincodeDeployCmd.Flags().StringVarP(&chaincodeACLJSON, "acl", "", common.UndefinedParamValue,
+		fmt.Sprintf("Access control list of the %s in JSON format", chainFuncName))
+
 	return chaincodeDeployCmd
 }
 
@@ -49,6 +54,13 @@ func chaincodeDeploy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if chaincodeACLJSON != common.UndefinedParamValue {
+		spec.Acl = &pb.ChaincodeACL{}
+		if err = json.Unmarshal([]byte(chaincodeACLJSON), spec.Acl); err != nil {
+			return fmt.Errorf("Chaincode ACL error: %s", err)
+		}
+	}
+
 	devopsClient, err := common.GetDevopsClient(cmd)
 	if err != nil {
 		return fmt.Errorf("Error building %s: %s", chainFuncName, err)
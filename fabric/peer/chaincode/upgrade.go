@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos"
+	"github.com/spf13/cobra"
+)
+
+// Cmd returns the cobra command for Chaincode Upgrade
+func upgradeCmd() *cobra.Command {
+	chaincodeUpgradeCmd.Flags().StringVarP(&chaincodeACLJSON, "acl", "", common.UndefinedParamValue,
+		fmt.Sprintf("Access control list of the new code of the %s in JSON format", chainFuncName))
+
+	return chaincodeUpgradeCmd
+}
+
+var chaincodeUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: fmt.Sprintf("Upgrade a deployed chaincode to new code, keeping its state."),
+	Long: fmt.Sprintf(`Upgrade the chaincode given by the name parameter to the code at the path parameter.
+The chaincode keeps its name and its state, and the constructor message is the migration function the new code is initialized with.`),
+	ValidArgs: []string{"1"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return chaincodeUpgrade(cmd, args)
+	},
+}
+
+// chaincodeUpgrade upgrades a deployed chaincode. On success, the name of the
+// upgraded chaincode, which is unchanged, is printed to STDOUT.
+func chaincodeUpgrade(cmd *cobra.Command, args []string) error {
+	if chaincodeName == common.UndefinedParamValue {
+		return errors.New("Must supply the name of the chaincode to upgrade")
+	}
+	spec, err := getChaincodeSpecification(cmd)
+	if err != nil {
+		return err
+	}
+	// Confidential chaincodes cannot be upgraded, upgrades are always public
+	spec.ConfidentialityLevel = pb.ConfidentialityLevel_PUBLIC
+
+	if chaincodeACLJSON != common.UndefinedParamValue {
+		spec.Acl = &pb.ChaincodeACL{}
+		if err = json.Unmarshal([]byte(chaincodeACLJSON), spec.Acl); err != nil {
+			return fmt.Errorf("Chaincode ACL error: %s", err)
+		}
+	}
+
+	devopsClient, err := common.GetDevopsClient(cmd)
+	if err != nil {
+		return fmt.Errorf("Error building %s: %s", chainFuncName, err)
+	}
+
+	chaincodeUpgradeSpec, err := devopsClient.Upgrade(context.Background(), spec)
+	if err != nil {
+		return fmt.Errorf("Error upgrading %s: %s\n", chainFuncName, err)
+	}
+	logger.Infof("Upgrade result: %s", chaincodeUpgradeSpec.DeploymentSpec.ChaincodeSpec)
+	fmt.Printf("Upgrade chaincode: %s\n", chaincodeUpgradeSpec.ChaincodeName)
+
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger/fabric/core/peer"
+	pb "github.com/hyperledger/fabric/protos"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func reconfigureCmd() *cobra.Command {
+	return nodeReconfigureCmd
+}
+
+var nodeReconfigureCmd = &cobra.Command{
+	Use:   "reconfigure <replica ID>...",
+	Short: "Votes to change the validator set.",
+	Long: `Votes, on behalf of the running validating peer, to change the validator set to the given replica IDs (vpN has replica ID N).
+The validator set is changed once a quorum of the current validators voted for the same set.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		replicas, err := parseReplicas(args)
+		if err != nil {
+			return err
+		}
+		return reconfigure(replicas)
+	},
+}
+
+// parseReplicas returns the replica IDs given on the command line
+func parseReplicas(args []string) ([]uint64, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Must supply the replica IDs of the new validator set")
+	}
+	replicas := make([]uint64, len(args))
+	for i, arg := range args {
+		id, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid replica ID %q: %s", arg, err)
+		}
+		replicas[i] = id
+	}
+	return replicas, nil
+}
+
+func reconfigure(replicas []uint64) error {
+	clientConn, err := peer.NewPeerClientConnection()
+	if err != nil {
+		return fmt.Errorf("Error trying to connect to local peer: %s", err)
+	}
+	defer clientConn.Close()
+
+	serverClient := pb.NewAdminClient(clientConn)
+	if _, err = serverClient.ReconfigureConsensus(context.Background(), &pb.ConsensusMembership{Replicas: replicas}); err != nil {
+		return fmt.Errorf("Error voting to change the validator set: %s", err)
+	}
+	logger.Infof("Voted to change the validator set to %v", replicas)
+	return nil
+}
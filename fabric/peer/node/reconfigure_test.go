@@ -0,0 +1,45 @@
+/*
+Copyright IBM Corp. 2016 All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+		 http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconfigureCmd(t *testing.T) {
+	require := require.New(t)
+	cmd := reconfigureCmd()
+
+	require.NotNil(cmd)
+	require.Equal("reconfigure", cmd.Name())
+	require.NotNil(cmd.RunE)
+}
+
+func TestParseReplicas(t *testing.T) {
+	require := require.New(t)
+
+	replicas, err := parseReplicas([]string{"0", "1", "2", "3", "5"})
+	require.NoError(err)
+	require.Equal([]uint64{0, 1, 2, 3, 5}, replicas)
+
+	_, err = parseReplicas(nil)
+	require.Error(err)
+	_, err = parseReplicas([]string{"0", "vp1"})
+	require.Error(err)
+}
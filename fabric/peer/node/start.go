@@ -30,15 +30,18 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/consensus/helper"
+	"github.com/hyperledger/fabric/consensus/util/metrics"
 	"github.com/hyperledger/fabric/core"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/core/crypto"
 	"github.com/hyperledger/fabric/core/db"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/genesis"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/rest"
 	"github.com/hyperledger/fabric/core/system_chaincode"
+	"github.com/hyperledger/fabric/events/persist"
 	"github.com/hyperledger/fabric/events/producer"
 	pb "github.com/hyperledger/fabric/protos"
 	"github.com/spf13/cobra"
@@ -155,6 +158,13 @@ func serve(args []string) error {
 		if makeGenesisError != nil {
 			return makeGenesisError
 		}
+
+		// Event hub consumers can replay the events of the committed blocks
+		ledgerPtr, ledgerErr := ledger.GetLedger()
+		if ledgerErr != nil {
+			return ledgerErr
+		}
+		producer.EnableReplay(ledgerPtr, &persist.AckStore{})
 		logger.Debugf("Running as validating peer - installing consensus %s",
 			viper.GetString("peer.validator.consensus"))
 
@@ -174,7 +184,7 @@ func serve(args []string) error {
 	pb.RegisterPeerServer(grpcServer, peerServer)
 
 	// Register the Admin server
-	pb.RegisterAdminServer(grpcServer, core.NewAdminServer())
+	pb.RegisterAdminServer(grpcServer, core.NewAdminServerWithConsensus(helper.GetMetrics, helper.Reconfigure))
 
 	// Register Devops server
 	serverDevops := core.NewDevopsServer(peerServer)
@@ -240,10 +250,32 @@ func serve(args []string) error {
 		}()
 	}
 
+	if viper.GetBool("peer.metrics.enabled") {
+		go func() {
+			metricsListenAddress := viper.GetString("peer.metrics.listenAddress")
+			logger.Infof("Starting metrics server with listenAddress = %s", metricsListenAddress)
+			mux := http.NewServeMux()
+			mux.HandleFunc("/metrics", serveMetrics)
+			if metricsErr := http.ListenAndServe(metricsListenAddress, mux); metricsErr != nil {
+				logger.Errorf("Error starting metrics server: %s", metricsErr)
+			}
+		}()
+	}
+
 	// Block until grpc server exits
 	return <-serve
 }
 
+// serveMetrics writes the metrics of the consensus plugin in the Prometheus text format
+func serveMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metrics.ContentType)
+	if registry := helper.GetMetrics(); registry != nil {
+		if err := registry.WriteText(w); err != nil {
+			logger.Warningf("Error writing metrics: %s", err)
+		}
+	}
+}
+
 func registerChaincodeSupport(chainname chaincode.ChainName, grpcServer *grpc.Server,
 	secHelper crypto.Peer) {
 
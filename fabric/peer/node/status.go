@@ -18,6 +18,8 @@ package node
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/core/peer"
@@ -57,6 +59,22 @@ func status() (err error) {
 		fmt.Println(&pb.ServerStatus{Status: pb.ServerStatus_UNKNOWN})
 		return err
 	}
-	fmt.Println(status)
+	fmt.Println(&pb.ServerStatus{Status: status.Status})
+	for _, metric := range status.ConsensusMetrics {
+		fmt.Println(formatMetric(metric))
+	}
 	return nil
 }
+
+// formatMetric prints a consensus metric the way the /metrics endpoint does
+func formatMetric(metric *pb.ConsensusMetric) string {
+	var labels []string
+	for name, value := range metric.Labels {
+		labels = append(labels, fmt.Sprintf("%s=%q", name, value))
+	}
+	if len(labels) == 0 {
+		return fmt.Sprintf("%s %v", metric.Name, metric.Value)
+	}
+	sort.Strings(labels)
+	return fmt.Sprintf("%s{%s} %v", metric.Name, strings.Join(labels, ","), metric.Value)
+}
@@ -31,6 +31,12 @@ It has these top-level messages:
 	RangeQueryStateClose
 	RangeQueryStateKeyValue
 	RangeQueryStateResponse
+	ChaincodeACL
+	ChaincodeACLEntry
+	ChaincodeACLAttribute
+	ChaincodeUpgradeSpec
+	ChaincodeVersion
+	ChaincodeVersionHistory
 	Secret
 	SigmaInput
 	ExecuteWithBinding
@@ -215,6 +215,9 @@ type ChaincodeSpec struct {
 	ConfidentialityLevel ConfidentialityLevel `protobuf:"varint,6,opt,name=confidentialityLevel,enum=protos.ConfidentialityLevel" json:"confidentialityLevel,omitempty"`
 	Metadata             []byte               `protobuf:"bytes,7,opt,name=metadata,proto3" json:"metadata,omitempty"`
 	Attributes           []string             `protobuf:"bytes,8,rep,name=attributes" json:"attributes,omitempty"`
+	// Access control list of the chaincode, only used when deploying or
+	// upgrading it.
+	Acl *ChaincodeACL `protobuf:"bytes,9,opt,name=acl" json:"acl,omitempty"`
 }
 
 func (m *ChaincodeSpec) Reset()                    { *m = ChaincodeSpec{} }
@@ -236,6 +239,13 @@ func (m *ChaincodeSpec) GetCtorMsg() *ChaincodeInput {
 	return nil
 }
 
+func (m *ChaincodeSpec) GetAcl() *ChaincodeACL {
+	if m != nil {
+		return m.Acl
+	}
+	return nil
+}
+
 // Specify the deployment of a chaincode.
 // TODO: Define `codePackage`.
 type ChaincodeDeploymentSpec struct {
@@ -420,6 +430,126 @@ func (m *RangeQueryStateResponse) GetKeysAndValues() []*RangeQueryStateKeyValue
 	return nil
 }
 
+// Access control list of a chaincode, declared when the chaincode is deployed
+// and enforced by the peers before the chaincode is invoked or queried. The
+// entry of the function "*" applies to the functions without an entry of
+// their own. Functions without an entry can be called by anyone.
+type ChaincodeACL struct {
+	Entries []*ChaincodeACLEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *ChaincodeACL) Reset()                    { *m = ChaincodeACL{} }
+func (m *ChaincodeACL) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeACL) ProtoMessage()               {}
+func (*ChaincodeACL) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{13} }
+
+func (m *ChaincodeACL) GetEntries() []*ChaincodeACLEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// Requirements for calling a function of a chaincode. The certificate of the
+// caller must carry all the attributes and, if any affiliation is given,
+// belong to one of the affiliations.
+type ChaincodeACLEntry struct {
+	Function     string                   `protobuf:"bytes,1,opt,name=function" json:"function,omitempty"`
+	Attributes   []*ChaincodeACLAttribute `protobuf:"bytes,2,rep,name=attributes" json:"attributes,omitempty"`
+	Affiliations []string                 `protobuf:"bytes,3,rep,name=affiliations" json:"affiliations,omitempty"`
+}
+
+func (m *ChaincodeACLEntry) Reset()                    { *m = ChaincodeACLEntry{} }
+func (m *ChaincodeACLEntry) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeACLEntry) ProtoMessage()               {}
+func (*ChaincodeACLEntry) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{14} }
+
+func (m *ChaincodeACLEntry) GetAttributes() []*ChaincodeACLAttribute {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+// Attribute the certificate of the caller must carry. Any value is accepted
+// if the value is empty.
+type ChaincodeACLAttribute struct {
+	Name  string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *ChaincodeACLAttribute) Reset()                    { *m = ChaincodeACLAttribute{} }
+func (m *ChaincodeACLAttribute) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeACLAttribute) ProtoMessage()               {}
+func (*ChaincodeACLAttribute) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{15} }
+
+// Specify the upgrade of a deployed chaincode: the name of the chaincode and
+// the deployment of its new code. The name of the new code is the hash
+// generated for it, and its constructor is called against the state of the
+// chaincode to migrate it.
+type ChaincodeUpgradeSpec struct {
+	ChaincodeName  string                   `protobuf:"bytes,1,opt,name=chaincodeName" json:"chaincodeName,omitempty"`
+	DeploymentSpec *ChaincodeDeploymentSpec `protobuf:"bytes,2,opt,name=deploymentSpec" json:"deploymentSpec,omitempty"`
+}
+
+func (m *ChaincodeUpgradeSpec) Reset()                    { *m = ChaincodeUpgradeSpec{} }
+func (m *ChaincodeUpgradeSpec) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeUpgradeSpec) ProtoMessage()               {}
+func (*ChaincodeUpgradeSpec) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{16} }
+
+func (m *ChaincodeUpgradeSpec) GetDeploymentSpec() *ChaincodeDeploymentSpec {
+	if m != nil {
+		return m.DeploymentSpec
+	}
+	return nil
+}
+
+// Version of the code of a chaincode. The first version is the code the
+// chaincode was deployed with, the next ones the code of its upgrades.
+type ChaincodeVersion struct {
+	Version uint64 `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	// ID of the deploy or upgrade transaction
+	Txid      string                     `protobuf:"bytes,2,opt,name=txid" json:"txid,omitempty"`
+	CodeID    *ChaincodeID               `protobuf:"bytes,3,opt,name=codeID" json:"codeID,omitempty"`
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+}
+
+func (m *ChaincodeVersion) Reset()                    { *m = ChaincodeVersion{} }
+func (m *ChaincodeVersion) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeVersion) ProtoMessage()               {}
+func (*ChaincodeVersion) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{17} }
+
+func (m *ChaincodeVersion) GetCodeID() *ChaincodeID {
+	if m != nil {
+		return m.CodeID
+	}
+	return nil
+}
+
+func (m *ChaincodeVersion) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+// Version history of an upgraded chaincode.
+type ChaincodeVersionHistory struct {
+	Versions []*ChaincodeVersion `protobuf:"bytes,1,rep,name=versions" json:"versions,omitempty"`
+}
+
+func (m *ChaincodeVersionHistory) Reset()                    { *m = ChaincodeVersionHistory{} }
+func (m *ChaincodeVersionHistory) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeVersionHistory) ProtoMessage()               {}
+func (*ChaincodeVersionHistory) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{18} }
+
+func (m *ChaincodeVersionHistory) GetVersions() []*ChaincodeVersion {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeID)(nil), "protos.ChaincodeID")
 	proto.RegisterType((*ChaincodeInput)(nil), "protos.ChaincodeInput")
@@ -434,6 +564,12 @@ func init() {
 	proto.RegisterType((*RangeQueryStateClose)(nil), "protos.RangeQueryStateClose")
 	proto.RegisterType((*RangeQueryStateKeyValue)(nil), "protos.RangeQueryStateKeyValue")
 	proto.RegisterType((*RangeQueryStateResponse)(nil), "protos.RangeQueryStateResponse")
+	proto.RegisterType((*ChaincodeACL)(nil), "protos.ChaincodeACL")
+	proto.RegisterType((*ChaincodeACLEntry)(nil), "protos.ChaincodeACLEntry")
+	proto.RegisterType((*ChaincodeACLAttribute)(nil), "protos.ChaincodeACLAttribute")
+	proto.RegisterType((*ChaincodeUpgradeSpec)(nil), "protos.ChaincodeUpgradeSpec")
+	proto.RegisterType((*ChaincodeVersion)(nil), "protos.ChaincodeVersion")
+	proto.RegisterType((*ChaincodeVersionHistory)(nil), "protos.ChaincodeVersionHistory")
 	proto.RegisterEnum("protos.ConfidentialityLevel", ConfidentialityLevel_name, ConfidentialityLevel_value)
 	proto.RegisterEnum("protos.ChaincodeSpec_Type", ChaincodeSpec_Type_name, ChaincodeSpec_Type_value)
 	proto.RegisterEnum("protos.ChaincodeDeploymentSpec_ExecutionEnvironment", ChaincodeDeploymentSpec_ExecutionEnvironment_name, ChaincodeDeploymentSpec_ExecutionEnvironment_value)
@@ -547,79 +683,91 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("chaincode.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 1173 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x56, 0x4b, 0x6f, 0xdb, 0x46,
-	0x10, 0x8e, 0x5e, 0x7e, 0x8c, 0x5e, 0xcc, 0x5a, 0x71, 0x04, 0xf7, 0x91, 0x80, 0x48, 0x03, 0xa3,
-	0x07, 0x25, 0x55, 0xd3, 0xa2, 0x40, 0x8b, 0xa0, 0x0c, 0xb9, 0x71, 0x19, 0xcb, 0x94, 0xb2, 0xa2,
-	0x8d, 0xe4, 0x64, 0xd0, 0xd4, 0x9a, 0x26, 0x22, 0x93, 0x04, 0xb9, 0x32, 0xec, 0x5b, 0xcf, 0x3d,
-	0xf5, 0x37, 0xf4, 0x47, 0xf4, 0xd0, 0x1f, 0xd4, 0xdf, 0xd1, 0xdd, 0xe5, 0x43, 0xcf, 0x04, 0x01,
-	0x7a, 0xd2, 0xce, 0xcc, 0x37, 0xb3, 0xb3, 0xdf, 0x7e, 0x3b, 0x14, 0xb4, 0xdd, 0x2b, 0xc7, 0x0f,
-	0xdc, 0x70, 0x42, 0x7b, 0x51, 0x1c, 0xb2, 0x10, 0x6d, 0xc9, 0x9f, 0xe4, 0xa0, 0x53, 0x04, 0xe8,
-	0x0d, 0x0d, 0x58, 0x1a, 0x3d, 0x78, 0xe4, 0x85, 0xa1, 0x37, 0xa5, 0xcf, 0xa4, 0x75, 0x31, 0xbb,
-	0x7c, 0xc6, 0xfc, 0x6b, 0x9a, 0x30, 0xe7, 0x3a, 0x4a, 0x01, 0xea, 0x0f, 0x50, 0xd7, 0xf3, 0x44,
-	0xd3, 0x40, 0x08, 0xaa, 0x91, 0xc3, 0xae, 0xba, 0xa5, 0xc7, 0xa5, 0xc3, 0x5d, 0x22, 0xd7, 0xc2,
-	0x17, 0x38, 0xd7, 0xb4, 0x5b, 0x4e, 0x7d, 0x62, 0xad, 0x3e, 0x81, 0xd6, 0x3c, 0x2d, 0x88, 0x66,
-	0x4c, 0xa0, 0x9c, 0xd8, 0x4b, 0x78, 0x66, 0xe5, 0xb0, 0x41, 0xe4, 0x5a, 0xfd, 0xbb, 0x02, 0xcd,
-	0x02, 0x36, 0x8e, 0xa8, 0x8b, 0x7a, 0x50, 0x65, 0x77, 0x11, 0x95, 0xf5, 0x5b, 0xfd, 0x83, 0xb4,
-	0x89, 0xa4, 0xb7, 0x04, 0xea, 0xd9, 0x1c, 0x41, 0x24, 0x0e, 0xf1, 0xf6, 0xdc, 0x79, 0x7b, 0xb2,
-	0x85, 0x7a, 0x7f, 0x6f, 0x2d, 0xcd, 0x34, 0xc8, 0x22, 0x0e, 0x3d, 0x87, 0x6d, 0x97, 0x85, 0xf1,
-	0x49, 0xe2, 0x75, 0x2b, 0x32, 0x65, 0x7f, 0x3d, 0x45, 0x74, 0x4d, 0x72, 0x18, 0xea, 0xc2, 0xb6,
-	0xa0, 0x26, 0x9c, 0xb1, 0x6e, 0x95, 0x67, 0xd4, 0x48, 0x6e, 0xa2, 0x27, 0xd0, 0x4c, 0xa8, 0x3b,
-	0x8b, 0xa9, 0x1e, 0x06, 0x8c, 0xde, 0xb2, 0x6e, 0x4d, 0xf2, 0xb0, 0xec, 0x44, 0x23, 0xe8, 0xb8,
-	0x61, 0x70, 0xe9, 0x4f, 0x38, 0xf7, 0xbe, 0x33, 0xf5, 0xd9, 0xdd, 0x80, 0xdf, 0xc3, 0xb4, 0xbb,
-	0x25, 0x0f, 0xfa, 0x65, 0xb1, 0xfd, 0x06, 0x0c, 0xd9, 0x98, 0x89, 0x0e, 0x60, 0xe7, 0x9a, 0x32,
-	0x67, 0xe2, 0x30, 0xa7, 0xbb, 0xcd, 0xab, 0x34, 0x48, 0x61, 0xa3, 0xaf, 0x01, 0x1c, 0xc6, 0x62,
-	0xff, 0x62, 0xc6, 0x68, 0xd2, 0xdd, 0xe1, 0x94, 0xef, 0x92, 0x05, 0x8f, 0xfa, 0x12, 0xaa, 0x82,
-	0x44, 0xd4, 0x84, 0xdd, 0x53, 0xcb, 0xc0, 0xaf, 0x4d, 0x0b, 0x1b, 0xca, 0x3d, 0x04, 0xb0, 0x75,
-	0x34, 0x1c, 0x68, 0xd6, 0x91, 0x52, 0x42, 0x3b, 0x50, 0xb5, 0x86, 0x06, 0x56, 0xca, 0x68, 0x1b,
-	0x2a, 0xba, 0x46, 0x94, 0x8a, 0x70, 0xbd, 0xd1, 0xce, 0x34, 0xa5, 0xaa, 0xfe, 0x53, 0x86, 0x87,
-	0x05, 0x53, 0x06, 0x8d, 0xa6, 0xe1, 0xdd, 0x35, 0x6f, 0x4f, 0x5e, 0xe1, 0xcf, 0xd0, 0x74, 0x17,
-	0xaf, 0x4b, 0xde, 0x65, 0xbd, 0xff, 0x60, 0xe3, 0x5d, 0x92, 0x65, 0x2c, 0xfa, 0x15, 0x9a, 0xf4,
-	0xf2, 0x92, 0xba, 0xcc, 0xbf, 0xa1, 0x86, 0xc3, 0x68, 0x76, 0xa3, 0x07, 0xbd, 0x54, 0xa7, 0xbd,
-	0x5c, 0xa7, 0x3d, 0x3b, 0xd7, 0x29, 0x59, 0x4e, 0x40, 0x8f, 0xb9, 0x22, 0x78, 0xb5, 0x91, 0xe3,
-	0x7e, 0x70, 0x3c, 0x2a, 0xaf, 0xb7, 0x41, 0x16, 0x5d, 0xc8, 0x82, 0x6d, 0x7a, 0x4b, 0x5d, 0x1c,
-	0xdc, 0xc8, 0xab, 0x6c, 0xf5, 0x5f, 0xac, 0xb5, 0xb6, 0x7c, 0xa4, 0x1e, 0xe6, 0xf0, 0x19, 0xf3,
-	0xc3, 0x80, 0xe7, 0xf8, 0x71, 0x18, 0x88, 0x00, 0xc9, 0x8b, 0xa8, 0x3d, 0xe8, 0x6c, 0x02, 0x08,
-	0x36, 0x8d, 0xa1, 0x7e, 0x8c, 0x49, 0xca, 0xec, 0xf8, 0xfd, 0xd8, 0xc6, 0x27, 0x4a, 0x49, 0xfd,
-	0xbd, 0xb4, 0x40, 0x9e, 0x19, 0xdc, 0x84, 0xae, 0x23, 0x52, 0xff, 0x3f, 0x79, 0x87, 0xd0, 0xf6,
-	0x27, 0x47, 0x34, 0xa0, 0xb1, 0x2c, 0xa8, 0x4d, 0xbd, 0xec, 0x4d, 0xae, 0xba, 0xd5, 0x3f, 0xcb,
-	0xd0, 0x9d, 0x97, 0x12, 0x42, 0xe5, 0xb2, 0xca, 0xa5, 0xca, 0xc5, 0xe3, 0x3a, 0xd3, 0x29, 0x8d,
-	0x75, 0x1a, 0x33, 0xd9, 0x40, 0x83, 0x2c, 0x78, 0xe6, 0xf1, 0xb1, 0xef, 0x05, 0x72, 0x87, 0x22,
-	0x2e, 0x3c, 0xe2, 0xa9, 0x44, 0xce, 0xdd, 0x34, 0x74, 0x26, 0x19, 0xfb, 0xb9, 0x29, 0x22, 0x17,
-	0x7e, 0x30, 0xf1, 0x03, 0x4f, 0x32, 0xcf, 0x23, 0x99, 0xb9, 0x24, 0xe6, 0xda, 0x8a, 0x98, 0x9f,
-	0x42, 0x2b, 0x72, 0x62, 0xce, 0xe8, 0x49, 0x8e, 0xd8, 0x92, 0x88, 0x15, 0x2f, 0xfa, 0x05, 0xea,
-	0xec, 0xb6, 0xd0, 0x85, 0x7c, 0x13, 0x9f, 0x56, 0xce, 0x22, 0x5c, 0xfd, 0xab, 0x06, 0x4a, 0x41,
-	0xc9, 0x09, 0x4d, 0x12, 0x21, 0x95, 0xef, 0x96, 0xc6, 0xd1, 0x57, 0x6b, 0xb7, 0x90, 0xe1, 0x16,
-	0x27, 0xd2, 0x4f, 0xb0, 0x5b, 0xcc, 0xd0, 0xcf, 0x50, 0xef, 0x1c, 0xfc, 0x09, 0xde, 0xf8, 0xec,
-	0x64, 0xb7, 0xfe, 0x44, 0x92, 0xc6, 0x27, 0xac, 0x58, 0xa3, 0x37, 0xd0, 0x4e, 0x96, 0x2f, 0x4e,
-	0x12, 0x57, 0xef, 0x3f, 0x5e, 0xd7, 0xca, 0x32, 0x8e, 0xac, 0x26, 0xa2, 0x97, 0xd0, 0x2a, 0x94,
-	0x84, 0xc5, 0xd7, 0x41, 0x32, 0xbc, 0x69, 0x2a, 0xca, 0x28, 0x59, 0x41, 0xab, 0xff, 0x96, 0x37,
-	0xcf, 0x93, 0x06, 0xec, 0x10, 0x7c, 0x64, 0x72, 0xdd, 0x13, 0x3e, 0x51, 0x5a, 0x00, 0xb9, 0xc5,
-	0xa3, 0x65, 0x31, 0x4e, 0x4c, 0xcb, 0xb4, 0xf9, 0x60, 0xd9, 0x85, 0x1a, 0xc1, 0x9a, 0xf1, 0x5e,
-	0xa9, 0xa2, 0x36, 0xd4, 0x6d, 0xa2, 0x59, 0x63, 0x4d, 0xb7, 0xcd, 0xa1, 0xa5, 0xd4, 0x44, 0x49,
-	0x7d, 0x78, 0x32, 0x1a, 0x60, 0x9b, 0x27, 0x6d, 0x09, 0x28, 0x26, 0x64, 0x48, 0x94, 0x6d, 0x11,
-	0x39, 0xc2, 0xf6, 0xf9, 0xd8, 0xd6, 0x6c, 0xac, 0xec, 0x08, 0x73, 0x74, 0x9a, 0x9b, 0xbb, 0xc2,
-	0x34, 0xf0, 0x20, 0x33, 0x01, 0x75, 0x40, 0x31, 0xad, 0xb3, 0xe1, 0x31, 0x3e, 0xd7, 0x7f, 0xd3,
-	0x4c, 0x4b, 0x17, 0xa3, 0xad, 0x8e, 0x14, 0x68, 0x64, 0xde, 0xb7, 0xa7, 0x98, 0xbc, 0x57, 0x1a,
-	0x69, 0xcb, 0xe3, 0xd1, 0xd0, 0x1a, 0x63, 0xa5, 0x29, 0x76, 0x4b, 0x03, 0x2d, 0xb4, 0x07, 0x6d,
-	0xb9, 0x3c, 0x9f, 0x77, 0xd3, 0x16, 0xdd, 0xa6, 0xce, 0xb4, 0x27, 0x05, 0x3d, 0x80, 0xfb, 0xbc,
-	0xfb, 0xa3, 0xac, 0x5e, 0xb6, 0xfb, 0x7d, 0x2e, 0xef, 0xfd, 0x35, 0xf7, 0xb9, 0x85, 0xdf, 0xd9,
-	0x0a, 0x42, 0x5f, 0xc0, 0xc3, 0xf5, 0x98, 0x3e, 0x18, 0xf2, 0x06, 0xf6, 0xc4, 0x29, 0x8e, 0x31,
-	0x1e, 0x69, 0x03, 0xf3, 0x0c, 0x2b, 0x1d, 0xf5, 0x47, 0x68, 0x8c, 0x66, 0x6c, 0xcc, 0xf8, 0xa0,
-	0x33, 0x83, 0xcb, 0x90, 0xf7, 0x5f, 0xf9, 0x40, 0xef, 0xb2, 0xaf, 0xb1, 0x58, 0xf2, 0x73, 0xd6,
-	0x6e, 0x9c, 0xe9, 0x8c, 0x66, 0xef, 0x32, 0x35, 0x54, 0x0c, 0x6d, 0xe2, 0x04, 0x1e, 0x7d, 0x3b,
-	0xa3, 0xf1, 0x9d, 0x4c, 0x17, 0x2f, 0x8e, 0xcb, 0x2e, 0x66, 0xc7, 0x45, 0x7e, 0x61, 0xa3, 0x7d,
-	0xd8, 0xa2, 0xc1, 0x44, 0x44, 0xd2, 0xf9, 0x91, 0x59, 0xea, 0x37, 0xb0, 0xb7, 0x52, 0xc6, 0x12,
-	0xf2, 0x69, 0x41, 0x99, 0x7f, 0x7b, 0xd3, 0x22, 0x65, 0xdf, 0x50, 0x9f, 0x42, 0x67, 0x05, 0xa6,
-	0x4f, 0xc3, 0x84, 0xae, 0xe1, 0x34, 0x7e, 0xf2, 0x65, 0x1c, 0xdf, 0xe4, 0x4c, 0x34, 0xfc, 0xd9,
-	0x07, 0xfb, 0xa3, 0xb4, 0x56, 0x83, 0xd0, 0x24, 0x0a, 0x03, 0xbe, 0x1d, 0x86, 0x26, 0x4f, 0x4c,
-	0xb4, 0x60, 0x22, 0x6b, 0xa6, 0x7f, 0x3d, 0xea, 0xfd, 0x47, 0xb9, 0xa8, 0x3f, 0xb2, 0x37, 0x59,
-	0xce, 0x12, 0xcf, 0xf2, 0xca, 0x49, 0x4e, 0xc2, 0x38, 0xdd, 0x7a, 0x87, 0xe4, 0x66, 0x76, 0x9e,
-	0x4a, 0x7e, 0x9e, 0x6f, 0x5f, 0x40, 0x67, 0xd3, 0xf7, 0x5b, 0x0c, 0xff, 0xd1, 0xe9, 0xab, 0x81,
-	0xa9, 0xf3, 0x27, 0xc1, 0x15, 0xa7, 0x0f, 0xad, 0xd7, 0xa6, 0x81, 0x2d, 0xdb, 0xd4, 0x06, 0x4a,
-	0xa9, 0xff, 0x6e, 0x61, 0xee, 0x8c, 0x67, 0x51, 0x14, 0xf2, 0x11, 0x6b, 0x70, 0x15, 0x52, 0xcf,
-	0x4f, 0x18, 0x8d, 0x51, 0xf7, 0x63, 0x53, 0xe7, 0xe0, 0xa3, 0x11, 0xf5, 0xde, 0x61, 0xe9, 0x79,
-	0xe9, 0x55, 0x17, 0xf6, 0xc3, 0xd8, 0xeb, 0x5d, 0xf1, 0x97, 0x19, 0x4f, 0xe9, 0xc4, 0xa3, 0x71,
-	0x96, 0x70, 0x91, 0xfe, 0x29, 0xfc, 0xfe, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x6b, 0x55, 0xfc,
-	0xa4, 0x2e, 0x0a, 0x00, 0x00,
+	// 1371 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x4b, 0x6f, 0xdb, 0xc6,
+	0x13, 0x8f, 0x1e, 0xd6, 0x63, 0xf4, 0x62, 0xd6, 0xb2, 0xcd, 0xbf, 0xff, 0x4d, 0x62, 0xb0, 0x69,
+	0x60, 0x04, 0xa8, 0x92, 0xb8, 0x49, 0x5b, 0xb4, 0x45, 0x50, 0x46, 0xdc, 0x38, 0x8c, 0x65, 0x4a,
+	0x59, 0xc9, 0x46, 0x72, 0x32, 0x68, 0x6a, 0x25, 0x13, 0x91, 0x49, 0x82, 0x5c, 0x09, 0xd6, 0xad,
+	0x97, 0x5e, 0x7a, 0xea, 0x67, 0xe8, 0xc7, 0xe8, 0x87, 0xe9, 0xb1, 0x9f, 0xa3, 0xd8, 0xe5, 0x43,
+	0xd4, 0xc3, 0x4d, 0x80, 0x9e, 0xb4, 0x33, 0xf3, 0x9b, 0xd9, 0xd9, 0x99, 0xe1, 0xcc, 0x08, 0x1a,
+	0xd6, 0x95, 0x69, 0x3b, 0x96, 0x3b, 0xa4, 0x2d, 0xcf, 0x77, 0x99, 0x8b, 0x0a, 0xe2, 0x27, 0xd8,
+	0x6f, 0x26, 0x02, 0x3a, 0xa3, 0x0e, 0x0b, 0xa5, 0xfb, 0x0f, 0xc6, 0xae, 0x3b, 0x9e, 0xd0, 0x27,
+	0x82, 0xba, 0x9c, 0x8e, 0x9e, 0x30, 0xfb, 0x9a, 0x06, 0xcc, 0xbc, 0xf6, 0x42, 0x80, 0xf2, 0x02,
+	0x2a, 0xed, 0x58, 0x51, 0xd7, 0x10, 0x82, 0xbc, 0x67, 0xb2, 0x2b, 0x39, 0x73, 0x90, 0x39, 0x2c,
+	0x13, 0x71, 0xe6, 0x3c, 0xc7, 0xbc, 0xa6, 0x72, 0x36, 0xe4, 0xf1, 0xb3, 0xf2, 0x10, 0xea, 0x0b,
+	0x35, 0xc7, 0x9b, 0x32, 0x8e, 0x32, 0xfd, 0x71, 0x20, 0x67, 0x0e, 0x72, 0x87, 0x55, 0x22, 0xce,
+	0xca, 0x5f, 0x39, 0xa8, 0x25, 0xb0, 0xbe, 0x47, 0x2d, 0xd4, 0x82, 0x3c, 0x9b, 0x7b, 0x54, 0xd8,
+	0xaf, 0x1f, 0xed, 0x87, 0x4e, 0x04, 0xad, 0x25, 0x50, 0x6b, 0x30, 0xf7, 0x28, 0x11, 0x38, 0xf4,
+	0x02, 0x2a, 0xd6, 0xc2, 0x3d, 0xe1, 0x42, 0xe5, 0x68, 0x7b, 0x4d, 0x4d, 0xd7, 0x48, 0x1a, 0x87,
+	0x9e, 0x42, 0xd1, 0x62, 0xae, 0x7f, 0x1a, 0x8c, 0xe5, 0x9c, 0x50, 0xd9, 0x5d, 0x57, 0xe1, 0x5e,
+	0x93, 0x18, 0x86, 0x64, 0x28, 0xf2, 0xd0, 0xb8, 0x53, 0x26, 0xe7, 0x0f, 0x32, 0x87, 0x5b, 0x24,
+	0x26, 0xd1, 0x43, 0xa8, 0x05, 0xd4, 0x9a, 0xfa, 0xb4, 0xed, 0x3a, 0x8c, 0xde, 0x30, 0x79, 0x4b,
+	0xc4, 0x61, 0x99, 0x89, 0x7a, 0xd0, 0xb4, 0x5c, 0x67, 0x64, 0x0f, 0xa9, 0xc3, 0x6c, 0x73, 0x62,
+	0xb3, 0x79, 0x87, 0xce, 0xe8, 0x44, 0x2e, 0x88, 0x87, 0x7e, 0x91, 0x5c, 0xbf, 0x01, 0x43, 0x36,
+	0x6a, 0xa2, 0x7d, 0x28, 0x5d, 0x53, 0x66, 0x0e, 0x4d, 0x66, 0xca, 0xc5, 0x83, 0xcc, 0x61, 0x95,
+	0x24, 0x34, 0xba, 0x0f, 0x60, 0x32, 0xe6, 0xdb, 0x97, 0x53, 0x46, 0x03, 0xb9, 0x74, 0x90, 0x3b,
+	0x2c, 0x93, 0x14, 0x07, 0x3d, 0x82, 0x9c, 0x69, 0x4d, 0xe4, 0xb2, 0x78, 0x7b, 0x73, 0xed, 0xed,
+	0x6a, 0xbb, 0x43, 0x38, 0x40, 0x79, 0x09, 0x79, 0x1e, 0x6c, 0x54, 0x83, 0xf2, 0x99, 0xa1, 0xe1,
+	0xd7, 0xba, 0x81, 0x35, 0xe9, 0x0e, 0x02, 0x28, 0x1c, 0x77, 0x3b, 0xaa, 0x71, 0x2c, 0x65, 0x50,
+	0x09, 0xf2, 0x46, 0x57, 0xc3, 0x52, 0x16, 0x15, 0x21, 0xd7, 0x56, 0x89, 0x94, 0xe3, 0xac, 0xb7,
+	0xea, 0xb9, 0x2a, 0xe5, 0x95, 0x3f, 0xb3, 0xb0, 0x97, 0x58, 0xd5, 0xa8, 0x37, 0x71, 0xe7, 0xd7,
+	0xd4, 0x61, 0x22, 0xd5, 0x3f, 0x42, 0xcd, 0x4a, 0xa7, 0x55, 0xe4, 0xbc, 0x72, 0xb4, 0xb3, 0x31,
+	0xe7, 0x64, 0x19, 0x8b, 0x7e, 0x86, 0x1a, 0x1d, 0x8d, 0xa8, 0xc5, 0xec, 0x19, 0xd5, 0x4c, 0x46,
+	0xa3, 0xcc, 0xef, 0xb7, 0xc2, 0x7a, 0x6e, 0xc5, 0xf5, 0xdc, 0x1a, 0xc4, 0xf5, 0x4c, 0x96, 0x15,
+	0xd0, 0x01, 0x54, 0xb8, 0xb5, 0x9e, 0x69, 0x7d, 0x34, 0xc7, 0x54, 0x94, 0x41, 0x95, 0xa4, 0x59,
+	0xc8, 0x80, 0x22, 0xbd, 0xa1, 0x16, 0x76, 0x66, 0x22, 0xe5, 0xf5, 0xa3, 0xe7, 0x6b, 0xae, 0x2d,
+	0x3f, 0xa9, 0x85, 0x6f, 0xa8, 0x35, 0x65, 0xb6, 0xeb, 0x60, 0x67, 0x66, 0xfb, 0xae, 0xc3, 0x05,
+	0x24, 0x36, 0xa2, 0xb4, 0xa0, 0xb9, 0x09, 0xc0, 0xa3, 0xa9, 0x75, 0xdb, 0x27, 0x98, 0x84, 0x91,
+	0xed, 0x7f, 0xe8, 0x0f, 0xf0, 0xa9, 0x94, 0x51, 0x7e, 0xc9, 0xa4, 0x82, 0xa7, 0x3b, 0x33, 0xd7,
+	0x32, 0xb9, 0xea, 0x7f, 0x0f, 0xde, 0x21, 0x34, 0xec, 0xe1, 0x31, 0x75, 0xa8, 0x2f, 0x0c, 0xaa,
+	0x93, 0x71, 0xf4, 0xed, 0xae, 0xb2, 0x95, 0xdf, 0xb3, 0x20, 0x2f, 0x4c, 0xf1, 0x82, 0xb6, 0xd9,
+	0x3c, 0x2e, 0xe9, 0xfb, 0x00, 0x96, 0x39, 0x99, 0x50, 0xbf, 0x4d, 0x7d, 0x26, 0x1c, 0xa8, 0x92,
+	0x14, 0x67, 0x21, 0xef, 0xdb, 0x63, 0x47, 0xce, 0xa6, 0xe5, 0x9c, 0xc3, 0x3f, 0x29, 0xcf, 0x9c,
+	0x4f, 0x5c, 0x73, 0x18, 0x45, 0x3f, 0x26, 0xb9, 0xe4, 0xd2, 0x76, 0x86, 0xb6, 0x33, 0x16, 0x91,
+	0xaf, 0x92, 0x98, 0x5c, 0x2a, 0xfa, 0xad, 0x95, 0xa2, 0x7f, 0x04, 0x75, 0xcf, 0xf4, 0xa9, 0xc3,
+	0x4e, 0x63, 0x44, 0x41, 0x20, 0x56, 0xb8, 0xe8, 0x27, 0xa8, 0xb0, 0x9b, 0xa4, 0x2e, 0xe4, 0xe2,
+	0x27, 0x2b, 0x27, 0x0d, 0x57, 0xfe, 0xd8, 0x02, 0x29, 0x09, 0xc9, 0x29, 0x0d, 0x02, 0x5e, 0x2a,
+	0xcf, 0x96, 0xda, 0xd6, 0xbd, 0xb5, 0x2c, 0x44, 0xb8, 0x74, 0xe7, 0xfa, 0x1e, 0xca, 0x49, 0xaf,
+	0xfd, 0x8c, 0xea, 0x5d, 0x80, 0xff, 0x25, 0x6e, 0x08, 0xf2, 0xec, 0xc6, 0x1e, 0x8a, 0xa0, 0x95,
+	0x89, 0x38, 0xa3, 0xb7, 0xd0, 0x08, 0x96, 0x13, 0x27, 0x02, 0x57, 0x39, 0x3a, 0x58, 0xaf, 0x95,
+	0x65, 0x1c, 0x59, 0x55, 0x44, 0x2f, 0xa1, 0x9e, 0x54, 0x12, 0xe6, 0x53, 0x44, 0x2e, 0xdc, 0xd2,
+	0x3d, 0x85, 0x94, 0xac, 0xa0, 0x95, 0xbf, 0xb3, 0x9b, 0xfb, 0x49, 0x15, 0x4a, 0x04, 0x1f, 0xeb,
+	0xfd, 0x01, 0x26, 0x52, 0x06, 0xd5, 0x01, 0x62, 0x0a, 0x6b, 0x52, 0x96, 0xb7, 0x13, 0xdd, 0xd0,
+	0x07, 0x52, 0x0e, 0x95, 0x61, 0x8b, 0x60, 0x55, 0xfb, 0x20, 0xe5, 0x51, 0x03, 0x2a, 0x03, 0xa2,
+	0x1a, 0x7d, 0xb5, 0x3d, 0xd0, 0xbb, 0x86, 0xb4, 0xc5, 0x4d, 0xb6, 0xbb, 0xa7, 0xbd, 0x0e, 0x1e,
+	0x60, 0x4d, 0x2a, 0x70, 0x28, 0x26, 0xa4, 0x4b, 0xa4, 0x22, 0x97, 0x1c, 0xe3, 0xc1, 0x45, 0x7f,
+	0xa0, 0x0e, 0xb0, 0x54, 0xe2, 0x64, 0xef, 0x2c, 0x26, 0xcb, 0x9c, 0xd4, 0x70, 0x27, 0x22, 0x01,
+	0x35, 0x41, 0xd2, 0x8d, 0xf3, 0xee, 0x09, 0xbe, 0x68, 0xbf, 0x51, 0x75, 0xa3, 0xcd, 0x5b, 0x5b,
+	0x05, 0x49, 0x50, 0x8d, 0xb8, 0xef, 0xce, 0x30, 0xf9, 0x20, 0x55, 0x43, 0x97, 0xfb, 0xbd, 0xae,
+	0xd1, 0xc7, 0x52, 0x8d, 0xdf, 0x16, 0x0a, 0xea, 0x68, 0x1b, 0x1a, 0xe2, 0x78, 0xb1, 0xf0, 0xa6,
+	0xc1, 0xbd, 0x0d, 0x99, 0xa1, 0x4f, 0x12, 0xda, 0x81, 0xbb, 0x44, 0x35, 0x8e, 0x23, 0x7b, 0xd1,
+	0xed, 0x77, 0xd1, 0x3e, 0xec, 0xae, 0xb1, 0x2f, 0x0c, 0xfc, 0x7e, 0x20, 0x21, 0xf4, 0x7f, 0xd8,
+	0x5b, 0x97, 0xb5, 0x3b, 0xdd, 0x3e, 0x96, 0xb6, 0xf9, 0x2b, 0x4e, 0x30, 0xee, 0xa9, 0x1d, 0xfd,
+	0x1c, 0x4b, 0x4d, 0xe5, 0x5b, 0xa8, 0xf6, 0xa6, 0xac, 0xcf, 0x4c, 0x46, 0x75, 0x67, 0xe4, 0x22,
+	0x09, 0x72, 0x1f, 0xe9, 0x3c, 0x9a, 0xda, 0xfc, 0x88, 0x9a, 0xb0, 0x35, 0x33, 0x27, 0x53, 0x1a,
+	0x7d, 0x97, 0x21, 0xa1, 0x60, 0x68, 0x10, 0xd3, 0x19, 0xd3, 0x77, 0x53, 0xea, 0xcf, 0x85, 0x3a,
+	0xff, 0xe2, 0x02, 0x66, 0xfa, 0xec, 0x24, 0xd1, 0x4f, 0x68, 0xb4, 0x0b, 0x05, 0xea, 0x0c, 0xb9,
+	0x24, 0xec, 0x1f, 0x11, 0xa5, 0x7c, 0x05, 0xdb, 0x2b, 0x66, 0x0c, 0x5e, 0x3e, 0x75, 0xc8, 0xea,
+	0x5a, 0x64, 0x24, 0x6b, 0x6b, 0xca, 0x23, 0x68, 0xae, 0xc0, 0xda, 0x13, 0x37, 0xa0, 0x6b, 0x38,
+	0x15, 0xf6, 0x56, 0x70, 0x27, 0x74, 0x7e, 0xce, 0x1d, 0xfe, 0xec, 0x87, 0xfd, 0x96, 0x59, 0xb3,
+	0x41, 0x68, 0xe0, 0xb9, 0x4e, 0x40, 0x11, 0x86, 0xda, 0x47, 0x3a, 0x0f, 0x54, 0x67, 0x28, 0x6c,
+	0x86, 0x2b, 0x4a, 0xe5, 0xe8, 0x41, 0x5c, 0xd4, 0xb7, 0xdc, 0x4d, 0x96, 0xb5, 0xf8, 0x67, 0x79,
+	0x65, 0x06, 0xa7, 0xae, 0x1f, 0x5e, 0x5d, 0x22, 0x31, 0x19, 0xbd, 0x27, 0x97, 0xbc, 0xe7, 0x07,
+	0xa8, 0xa6, 0x47, 0x2d, 0x7a, 0x0c, 0x45, 0xea, 0x30, 0xdf, 0x4e, 0xae, 0xfe, 0xdf, 0xa6, 0x89,
+	0x8c, 0x1d, 0xe6, 0xcf, 0x15, 0x07, 0xee, 0xae, 0x31, 0x91, 0x04, 0xa5, 0xd1, 0xd4, 0xb1, 0x78,
+	0xd7, 0x0e, 0x43, 0x81, 0x9e, 0x2d, 0x2d, 0x00, 0x59, 0x61, 0xf5, 0xde, 0x26, 0xab, 0x6a, 0x8c,
+	0x42, 0x4d, 0xa8, 0x9a, 0xa3, 0x91, 0x3d, 0xb1, 0x45, 0xf7, 0x0f, 0xe4, 0x1c, 0xdf, 0x1a, 0x94,
+	0xe7, 0xb0, 0xb3, 0x19, 0x5e, 0x8d, 0xb6, 0xbe, 0xf0, 0xbe, 0x5a, 0x3a, 0xea, 0x65, 0x65, 0x04,
+	0xcd, 0x44, 0xeb, 0xcc, 0x1b, 0xfb, 0x66, 0x34, 0x79, 0x76, 0x52, 0x63, 0xcb, 0x58, 0x68, 0x7f,
+	0x07, 0xf5, 0xe1, 0xd2, 0x24, 0x8d, 0x1a, 0xe2, 0x83, 0x4f, 0x0c, 0x5c, 0xe5, 0xd7, 0x4c, 0xaa,
+	0x19, 0x9f, 0x53, 0x3f, 0xb0, 0x5d, 0x07, 0x35, 0xa0, 0x38, 0x0b, 0x8f, 0xc2, 0x7c, 0x9e, 0xbb,
+	0x2a, 0xda, 0xa2, 0xf0, 0x0d, 0x7d, 0x09, 0x85, 0x68, 0x5b, 0xcc, 0xdd, 0xba, 0x2d, 0xa2, 0xaf,
+	0xd3, 0xdd, 0x39, 0xff, 0xa9, 0xee, 0xac, 0x60, 0xd8, 0x5b, 0x75, 0xe3, 0x8d, 0x1d, 0x30, 0xd7,
+	0x9f, 0xa3, 0xc7, 0x50, 0x8a, 0xbc, 0x89, 0xb3, 0x2b, 0xaf, 0x5d, 0x18, 0xa9, 0x3c, 0x7e, 0x0e,
+	0xcd, 0x4d, 0x0b, 0x20, 0xdf, 0x0a, 0x7a, 0x67, 0xaf, 0x3a, 0x7a, 0x5b, 0xba, 0xc3, 0x5b, 0x51,
+	0xbb, 0x6b, 0xbc, 0xd6, 0x35, 0x6c, 0x0c, 0x74, 0xb5, 0x23, 0x65, 0x8e, 0xde, 0xa7, 0x62, 0xd0,
+	0x9f, 0x7a, 0x9e, 0xeb, 0x33, 0xa4, 0x41, 0x89, 0xd0, 0xb1, 0x1d, 0x30, 0xea, 0x23, 0xf9, 0xb6,
+	0x71, 0xb4, 0x7f, 0xab, 0x44, 0xb9, 0x73, 0x98, 0x79, 0x9a, 0x79, 0x25, 0xc3, 0xae, 0xeb, 0x8f,
+	0x5b, 0x57, 0x73, 0x8f, 0xfa, 0x13, 0x3a, 0x1c, 0x53, 0x3f, 0x52, 0xb8, 0x0c, 0xff, 0x55, 0x7c,
+	0xf3, 0xcf, 0x00, 0x1b, 0xf7, 0x80, 0x43, 0x6f, 0x0c, 0x00, 0x00,
 }
@@ -155,6 +155,8 @@ type DevopsClient interface {
 	Build(ctx context.Context, in *ChaincodeSpec, opts ...grpc.CallOption) (*ChaincodeDeploymentSpec, error)
 	// Deploy the chaincode package to the chain.
 	Deploy(ctx context.Context, in *ChaincodeSpec, opts ...grpc.CallOption) (*ChaincodeDeploymentSpec, error)
+	// Upgrade a deployed chaincode to the chaincode package, keeping its state.
+	Upgrade(ctx context.Context, in *ChaincodeSpec, opts ...grpc.CallOption) (*ChaincodeUpgradeSpec, error)
 	// Invoke chaincode.
 	Invoke(ctx context.Context, in *ChaincodeInvocationSpec, opts ...grpc.CallOption) (*Response, error)
 	// Query chaincode.
@@ -204,6 +206,15 @@ func (c *devopsClient) Deploy(ctx context.Context, in *ChaincodeSpec, opts ...gr
 	return out, nil
 }
 
+func (c *devopsClient) Upgrade(ctx context.Context, in *ChaincodeSpec, opts ...grpc.CallOption) (*ChaincodeUpgradeSpec, error) {
+	out := new(ChaincodeUpgradeSpec)
+	err := grpc.Invoke(ctx, "/protos.Devops/Upgrade", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *devopsClient) Invoke(ctx context.Context, in *ChaincodeInvocationSpec, opts ...grpc.CallOption) (*Response, error) {
 	out := new(Response)
 	err := grpc.Invoke(ctx, "/protos.Devops/Invoke", in, out, c.cc, opts...)
@@ -268,6 +279,8 @@ type DevopsServer interface {
 	Build(context.Context, *ChaincodeSpec) (*ChaincodeDeploymentSpec, error)
 	// Deploy the chaincode package to the chain.
 	Deploy(context.Context, *ChaincodeSpec) (*ChaincodeDeploymentSpec, error)
+	// Upgrade a deployed chaincode to the chaincode package, keeping its state.
+	Upgrade(context.Context, *ChaincodeSpec) (*ChaincodeUpgradeSpec, error)
 	// Invoke chaincode.
 	Invoke(context.Context, *ChaincodeInvocationSpec) (*Response, error)
 	// Query chaincode.
@@ -340,6 +353,24 @@ func _Devops_Deploy_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Devops_Upgrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChaincodeSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DevopsServer).Upgrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Devops/Upgrade",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DevopsServer).Upgrade(ctx, req.(*ChaincodeSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Devops_Invoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChaincodeInvocationSpec)
 	if err := dec(in); err != nil {
@@ -464,6 +495,10 @@ var _Devops_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Deploy",
 			Handler:    _Devops_Deploy_Handler,
 		},
+		{
+			MethodName: "Upgrade",
+			Handler:    _Devops_Upgrade_Handler,
+		},
 		{
 			MethodName: "Invoke",
 			Handler:    _Devops_Invoke_Handler,
@@ -496,41 +531,42 @@ var _Devops_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("devops.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 572 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
-	0x10, 0xae, 0xdb, 0x26, 0xa5, 0x93, 0xd0, 0x5a, 0x23, 0xa0, 0x91, 0x0f, 0x80, 0x7c, 0x40, 0x95,
-	0x90, 0x2a, 0x51, 0x44, 0x0f, 0x88, 0x22, 0xe5, 0xc7, 0x69, 0x23, 0x55, 0xa5, 0xd8, 0x89, 0x80,
-	0x03, 0x42, 0x1b, 0x7b, 0x49, 0x2d, 0x9c, 0x5d, 0xe3, 0x5d, 0x57, 0xed, 0x23, 0xf0, 0x42, 0xbc,
-	0x0a, 0xaf, 0x43, 0x76, 0xd7, 0x36, 0x24, 0x4d, 0xd5, 0x0a, 0x4e, 0xd9, 0x99, 0xf9, 0xbe, 0xf9,
-	0xf2, 0x8d, 0x67, 0x17, 0x9a, 0x11, 0xbd, 0xe0, 0xa9, 0xd8, 0x4b, 0x33, 0x2e, 0x39, 0xd6, 0xf5,
-	0x8f, 0x70, 0xb6, 0xc3, 0x73, 0x12, 0xb3, 0x90, 0x47, 0xd4, 0x14, 0x9c, 0xe6, 0x57, 0x32, 0xce,
-	0xe2, 0xd0, 0x44, 0xee, 0x31, 0xd4, 0x03, 0x1a, 0x66, 0x54, 0xa2, 0x03, 0xf7, 0x28, 0xcb, 0x78,
-	0x92, 0x0c, 0xa2, 0x96, 0xf5, 0xd4, 0xda, 0xdd, 0xf4, 0xab, 0x18, 0x5d, 0x68, 0x9a, 0xb3, 0xc1,
-	0xb6, 0x56, 0x75, 0x7d, 0x2e, 0xe7, 0x46, 0x00, 0x41, 0x3c, 0x99, 0x92, 0x01, 0x4b, 0x73, 0x89,
-	0xcf, 0xa0, 0x2e, 0x0c, 0x56, 0xf5, 0x6a, 0xec, 0x6f, 0x19, 0x3d, 0xb1, 0x67, 0xd0, 0x7e, 0x51,
-	0x55, 0xaa, 0x24, 0x4d, 0x87, 0x5d, 0x9a, 0x99, 0xae, 0x4d, 0xbf, 0x8a, 0x11, 0x61, 0x3d, 0x22,
-	0x92, 0xb4, 0xd6, 0x74, 0x5e, 0x9f, 0xdd, 0x1f, 0x16, 0xa0, 0x77, 0x49, 0xc3, 0x5c, 0xd2, 0x0f,
-	0xb1, 0x3c, 0xef, 0xc4, 0x2c, 0x8a, 0xd9, 0x04, 0x3f, 0xc1, 0x4e, 0xe5, 0x73, 0xc0, 0x2e, 0x78,
-	0x48, 0x64, 0xcc, 0x59, 0x90, 0xd2, 0xb0, 0xd0, 0x7f, 0x52, 0xea, 0x77, 0x97, 0xc3, 0xfc, 0x9b,
-	0xf8, 0xd8, 0x82, 0x8d, 0xb1, 0x51, 0x29, 0xfe, 0x60, 0x19, 0xba, 0x9f, 0xa1, 0xa1, 0x1d, 0xbf,
-	0xcb, 0xa5, 0xb2, 0xfc, 0x00, 0x6a, 0x32, 0x54, 0x3e, 0x2c, 0x0d, 0x33, 0x81, 0xca, 0x0a, 0x05,
-	0x2a, 0xc8, 0x26, 0x50, 0x03, 0x25, 0x82, 0xbd, 0xf0, 0x94, 0xa0, 0xea, 0x6c, 0x2c, 0xce, 0xe5,
-	0xdc, 0x5f, 0x16, 0x34, 0x3a, 0x79, 0x9c, 0x44, 0x3e, 0x15, 0x79, 0x22, 0xf1, 0x60, 0x36, 0x52,
-	0x49, 0x64, 0x2e, 0xb4, 0xc0, 0xd6, 0xfe, 0xe3, 0xd2, 0xd2, 0x5f, 0xa0, 0xbd, 0x40, 0x23, 0xba,
-	0x33, 0x1b, 0x7e, 0x81, 0x46, 0x1b, 0xd6, 0xa6, 0x62, 0x52, 0x7c, 0x33, 0x75, 0xc4, 0x23, 0xd8,
-	0x8a, 0x68, 0x9a, 0xf0, 0xab, 0x29, 0x65, 0x52, 0x0f, 0x69, 0xed, 0x86, 0x21, 0xf5, 0xe6, 0x60,
-	0xfe, 0x02, 0xcd, 0x7d, 0x35, 0xfb, 0xe6, 0x95, 0x20, 0xde, 0x87, 0xcd, 0xd1, 0x69, 0xcf, 0xeb,
-	0x0f, 0x4e, 0xbd, 0x9e, 0xbd, 0x82, 0x0d, 0xd8, 0x08, 0x46, 0xdd, 0xae, 0x17, 0x04, 0xb6, 0xa5,
-	0x82, 0x7e, 0x7b, 0x70, 0x32, 0xf2, 0x3d, 0x7b, 0xd5, 0x7d, 0x0b, 0x38, 0xcc, 0x08, 0x13, 0x24,
-	0x54, 0x53, 0xf6, 0xe9, 0xf7, 0x9c, 0x0a, 0x89, 0xbb, 0xb0, 0x2d, 0xff, 0x64, 0x47, 0x79, 0x5c,
-	0xee, 0xe1, 0x62, 0x7a, 0xff, 0xe7, 0x3a, 0xd4, 0x7b, 0x7a, 0xd9, 0xf1, 0x39, 0xd4, 0x4e, 0xf8,
-	0x24, 0x66, 0xb8, 0xb0, 0x60, 0x8e, 0x5d, 0xc6, 0xb3, 0xc1, 0xa4, 0x9c, 0x09, 0xea, 0xae, 0x60,
-	0x1b, 0x6a, 0x7a, 0x56, 0xf8, 0xf0, 0x9a, 0x51, 0x65, 0xc7, 0xb9, 0xcd, 0xff, 0xac, 0x45, 0x47,
-	0x29, 0xab, 0xdc, 0x7f, 0xf4, 0x38, 0x84, 0xba, 0xda, 0xb1, 0x6f, 0x14, 0x6f, 0xdb, 0xca, 0xa5,
-	0x2e, 0xde, 0x40, 0xed, 0x7d, 0x4e, 0xb3, 0xab, 0x7f, 0x63, 0x1f, 0xc2, 0x8e, 0xf7, 0xf1, 0xec,
-	0xcb, 0x11, 0x95, 0xed, 0x34, 0x4d, 0x62, 0x83, 0x36, 0xf7, 0xed, 0x2e, 0x23, 0x3c, 0x00, 0x5b,
-	0xd1, 0xcf, 0x32, 0x9a, 0x92, 0x8c, 0xf6, 0x79, 0x36, 0xbc, 0xbc, 0x13, 0xef, 0x75, 0xc9, 0xe3,
-	0x51, 0x1e, 0x52, 0x7d, 0x6d, 0x10, 0x2b, 0x5e, 0xf5, 0x6e, 0x2c, 0xe5, 0x1e, 0xc3, 0x23, 0xc5,
-	0x5d, 0x72, 0xed, 0x9d, 0x12, 0x7d, 0xbd, 0xb6, 0xac, 0xd3, 0xd8, 0x3c, 0x8a, 0x2f, 0x7f, 0x07,
-	0x00, 0x00, 0xff, 0xff, 0xb8, 0xae, 0x0a, 0x87, 0x2b, 0x05, 0x00, 0x00,
+	// 583 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xd1, 0x4f, 0x13, 0x4f,
+	0x10, 0xa6, 0x40, 0x8f, 0x1f, 0xd3, 0xfe, 0xa0, 0x99, 0xa8, 0x34, 0x8d, 0x51, 0x73, 0x0f, 0x86,
+	0xc4, 0x84, 0x44, 0x8c, 0x3c, 0x18, 0x21, 0x81, 0xf6, 0x80, 0x26, 0x04, 0xf1, 0x8e, 0x46, 0x7d,
+	0x30, 0x66, 0xb9, 0x1b, 0xcb, 0xc5, 0x63, 0x77, 0xbd, 0xdd, 0x23, 0xf0, 0x27, 0xf8, 0x17, 0xfa,
+	0x9f, 0xf8, 0x6c, 0x76, 0xf7, 0xee, 0x10, 0x68, 0x03, 0xd1, 0xa7, 0xee, 0xcc, 0x7c, 0xdf, 0x7c,
+	0xfd, 0xe6, 0x66, 0x17, 0xda, 0x09, 0x9d, 0x0b, 0xa9, 0xd6, 0x64, 0x2e, 0xb4, 0x40, 0xcf, 0xfe,
+	0xa8, 0xde, 0x72, 0x7c, 0xca, 0x52, 0x1e, 0x8b, 0x84, 0x5c, 0xa1, 0xd7, 0xfe, 0xca, 0x4e, 0xf2,
+	0x34, 0x76, 0x91, 0xbf, 0x0f, 0x5e, 0x44, 0x71, 0x4e, 0x1a, 0x7b, 0xf0, 0x1f, 0xf1, 0x5c, 0x64,
+	0xd9, 0x30, 0xe9, 0x36, 0x9e, 0x35, 0x56, 0x17, 0xc3, 0x3a, 0x46, 0x1f, 0xda, 0xee, 0xec, 0xb0,
+	0xdd, 0x59, 0x5b, 0xbf, 0x96, 0xf3, 0x13, 0x80, 0x28, 0x1d, 0x9f, 0xb1, 0x21, 0x97, 0x85, 0xc6,
+	0xe7, 0xe0, 0x29, 0x87, 0x35, 0xbd, 0x5a, 0xeb, 0x4b, 0x4e, 0x4f, 0xad, 0x39, 0x74, 0xe8, 0xa9,
+	0x5a, 0x95, 0x49, 0x79, 0xdc, 0xa7, 0xdc, 0x75, 0x6d, 0x87, 0x75, 0x8c, 0x08, 0xf3, 0x09, 0xd3,
+	0xac, 0x3b, 0x67, 0xf3, 0xf6, 0xec, 0xff, 0x68, 0x00, 0x06, 0x17, 0x14, 0x17, 0x9a, 0x3e, 0xa4,
+	0xfa, 0x74, 0x27, 0xe5, 0x49, 0xca, 0xc7, 0xf8, 0x09, 0x56, 0x6a, 0x9f, 0x43, 0x7e, 0x2e, 0x62,
+	0xa6, 0x53, 0xc1, 0x23, 0x49, 0x71, 0xa9, 0xff, 0xb4, 0xd2, 0xef, 0x4f, 0x86, 0x85, 0xd3, 0xf8,
+	0xd8, 0x85, 0x85, 0x13, 0xa7, 0x52, 0xfe, 0xc1, 0x2a, 0xf4, 0x3f, 0x43, 0xcb, 0x3a, 0x7e, 0x57,
+	0x68, 0x63, 0xf9, 0x01, 0x34, 0x75, 0x6c, 0x7c, 0x34, 0x2c, 0xcc, 0x05, 0x26, 0xab, 0x0c, 0xa8,
+	0x24, 0xbb, 0xc0, 0x0c, 0x94, 0x29, 0xfe, 0x32, 0x30, 0x82, 0xa6, 0xb3, 0xb3, 0x78, 0x2d, 0xe7,
+	0xff, 0x6c, 0x40, 0x6b, 0xa7, 0x48, 0xb3, 0x24, 0x24, 0x55, 0x64, 0x1a, 0x37, 0xc0, 0x53, 0x9a,
+	0xe9, 0x42, 0x59, 0x81, 0xa5, 0xf5, 0x27, 0x95, 0xa5, 0x3f, 0x40, 0x6b, 0x91, 0x45, 0xf4, 0x45,
+	0x42, 0x61, 0x89, 0xc6, 0x0e, 0xcc, 0x9d, 0xa9, 0x71, 0xf9, 0xcd, 0xcc, 0x11, 0xf7, 0x60, 0x29,
+	0x21, 0x99, 0x89, 0xcb, 0x33, 0xe2, 0xda, 0x0e, 0x69, 0x6e, 0xca, 0x90, 0x06, 0xd7, 0x60, 0xe1,
+	0x0d, 0x9a, 0xff, 0x1a, 0xe0, 0x4a, 0x10, 0xff, 0x87, 0xc5, 0xd1, 0xe1, 0x20, 0xd8, 0x1d, 0x1e,
+	0x06, 0x83, 0xce, 0x0c, 0xb6, 0x60, 0x21, 0x1a, 0xf5, 0xfb, 0x41, 0x14, 0x75, 0x1a, 0x26, 0xd8,
+	0xdd, 0x1e, 0x1e, 0x8c, 0xc2, 0xa0, 0x33, 0xeb, 0x6f, 0x01, 0x1e, 0xe7, 0x8c, 0x2b, 0x16, 0x9b,
+	0x29, 0x87, 0xf4, 0xbd, 0x20, 0xa5, 0x71, 0x15, 0x96, 0xf5, 0x55, 0x76, 0x54, 0xa4, 0xd5, 0x1e,
+	0xde, 0x4c, 0xaf, 0xff, 0x9a, 0x07, 0x6f, 0x60, 0x97, 0x1d, 0x5f, 0x40, 0xf3, 0x40, 0x8c, 0x53,
+	0x8e, 0x37, 0x16, 0xac, 0xd7, 0xa9, 0xe2, 0x90, 0x94, 0x14, 0x5c, 0x91, 0x3f, 0x83, 0xdb, 0xd0,
+	0xb4, 0xb3, 0xc2, 0x87, 0xb7, 0x8c, 0x1a, 0x3b, 0xbd, 0xbb, 0xfc, 0xfb, 0x33, 0xb8, 0x03, 0x9e,
+	0xcb, 0xfd, 0x43, 0x8f, 0x4d, 0xf0, 0xcc, 0x8e, 0x7d, 0x23, 0xbc, 0x6b, 0x2b, 0x27, 0xba, 0x78,
+	0x0b, 0xcd, 0xf7, 0x05, 0xe5, 0x97, 0x7f, 0xc7, 0xde, 0x84, 0x95, 0xe0, 0xe3, 0xd1, 0x97, 0x3d,
+	0xd2, 0xdb, 0x52, 0x66, 0xa9, 0x43, 0xbb, 0xfb, 0x76, 0x9f, 0x11, 0x6e, 0x40, 0xc7, 0xd0, 0x8f,
+	0x72, 0x92, 0x2c, 0xa7, 0x5d, 0x91, 0x1f, 0x5f, 0xdc, 0x8b, 0xf7, 0xa6, 0xe2, 0x89, 0xa4, 0x88,
+	0xc9, 0x5e, 0x1b, 0xc4, 0x9a, 0x57, 0xbf, 0x1b, 0x13, 0xb9, 0xfb, 0xf0, 0xc8, 0x70, 0x27, 0x5c,
+	0xfb, 0x5e, 0x85, 0xbe, 0x5d, 0x9b, 0xd8, 0x69, 0x0b, 0x16, 0x46, 0x72, 0x9c, 0xb3, 0x84, 0xa6,
+	0x7d, 0xbe, 0xc7, 0xb7, 0xd2, 0x25, 0xc1, 0x54, 0x4f, 0xdc, 0xa3, 0xfa, 0xea, 0xf7, 0x00, 0xed,
+	0x43, 0xc6, 0x62, 0x6b, 0x05, 0x00, 0x00,
 }
@@ -158,6 +158,14 @@ func _Interest_OneofSizer(msg proto.Message) (n int) {
 // string type - "register"
 type Register struct {
 	Events []*Interest `protobuf:"bytes,1,rep,name=events" json:"events,omitempty"`
+	// consumerID identifies a consumer whose acknowledgements are kept by the
+	// event hub
+	ConsumerID string `protobuf:"bytes,2,opt,name=consumerID" json:"consumerID,omitempty"`
+	// if replay is set, the BLOCK and CHAINCODE events committed after the
+	// last event acknowledged by the consumer, or from startBlock if it did
+	// not acknowledge any, are sent before the live events
+	Replay     bool   `protobuf:"varint,3,opt,name=replay" json:"replay,omitempty"`
+	StartBlock uint64 `protobuf:"varint,4,opt,name=startBlock" json:"startBlock,omitempty"`
 }
 
 func (m *Register) Reset()                    { *m = Register{} }
@@ -218,7 +226,13 @@ type Event struct {
 	//	*Event_ChaincodeEvent
 	//	*Event_Rejection
 	//	*Event_Unregister
+	//	*Event_Ack
 	Event isEvent_Event `protobuf_oneof:"Event"`
+	// position of a BLOCK or CHAINCODE event: the number of the block that
+	// committed it and the index of the event in the block, 0 for the block
+	// and i+1 for the chaincode event of the i-th transaction
+	BlockNumber uint64 `protobuf:"varint,7,opt,name=blockNumber" json:"blockNumber,omitempty"`
+	EventIndex  uint64 `protobuf:"varint,8,opt,name=eventIndex" json:"eventIndex,omitempty"`
 }
 
 func (m *Event) Reset()                    { *m = Event{} }
@@ -245,12 +259,16 @@ type Event_Rejection struct {
 type Event_Unregister struct {
 	Unregister *Unregister `protobuf:"bytes,5,opt,name=unregister,oneof"`
 }
+type Event_Ack struct {
+	Ack *Ack `protobuf:"bytes,6,opt,name=ack,oneof"`
+}
 
 func (*Event_Register) isEvent_Event()       {}
 func (*Event_Block) isEvent_Event()          {}
 func (*Event_ChaincodeEvent) isEvent_Event() {}
 func (*Event_Rejection) isEvent_Event()      {}
 func (*Event_Unregister) isEvent_Event()     {}
+func (*Event_Ack) isEvent_Event()            {}
 
 func (m *Event) GetEvent() isEvent_Event {
 	if m != nil {
@@ -294,6 +312,13 @@ func (m *Event) GetUnregister() *Unregister {
 	return nil
 }
 
+func (m *Event) GetAck() *Ack {
+	if x, ok := m.GetEvent().(*Event_Ack); ok {
+		return x.Ack
+	}
+	return nil
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _Event_OneofMarshaler, _Event_OneofUnmarshaler, _Event_OneofSizer, []interface{}{
@@ -302,6 +327,7 @@ func (*Event) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error,
 		(*Event_ChaincodeEvent)(nil),
 		(*Event_Rejection)(nil),
 		(*Event_Unregister)(nil),
+		(*Event_Ack)(nil),
 	}
 }
 
@@ -334,6 +360,11 @@ func _Event_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.Unregister); err != nil {
 			return err
 		}
+	case *Event_Ack:
+		b.EncodeVarint(6<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ack); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("Event.Event has unexpected type %T", x)
@@ -384,6 +415,14 @@ func _Event_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer)
 		err := b.DecodeMessage(msg)
 		m.Event = &Event_Unregister{msg}
 		return true, err
+	case 6: // Event.ack
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Ack)
+		err := b.DecodeMessage(msg)
+		m.Event = &Event_Ack{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -418,6 +457,11 @@ func _Event_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(5<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *Event_Ack:
+		s := proto.Size(x.Ack)
+		n += proto.SizeVarint(6<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -425,6 +469,18 @@ func _Event_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// Ack is sent by consumers to acknowledge that they processed the events up
+// to the event at the given position
+type Ack struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=blockNumber" json:"blockNumber,omitempty"`
+	EventIndex  uint64 `protobuf:"varint,2,opt,name=eventIndex" json:"eventIndex,omitempty"`
+}
+
+func (m *Ack) Reset()                    { *m = Ack{} }
+func (m *Ack) String() string            { return proto.CompactTextString(m) }
+func (*Ack) ProtoMessage()               {}
+func (*Ack) Descriptor() ([]byte, []int) { return fileDescriptor4, []int{6} }
+
 func init() {
 	proto.RegisterType((*ChaincodeReg)(nil), "protos.ChaincodeReg")
 	proto.RegisterType((*Interest)(nil), "protos.Interest")
@@ -432,6 +488,7 @@ func init() {
 	proto.RegisterType((*Rejection)(nil), "protos.Rejection")
 	proto.RegisterType((*Unregister)(nil), "protos.Unregister")
 	proto.RegisterType((*Event)(nil), "protos.Event")
+	proto.RegisterType((*Ack)(nil), "protos.Ack")
 	proto.RegisterEnum("protos.EventType", EventType_name, EventType_value)
 }
 
@@ -544,33 +601,40 @@ var _Events_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("events.proto", fileDescriptor4) }
 
 var fileDescriptor4 = []byte{
-	// 445 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
-	0x14, 0x6d, 0xda, 0xb5, 0xab, 0x6f, 0xd3, 0x29, 0xbb, 0x4c, 0x28, 0x8a, 0x78, 0x98, 0x82, 0x90,
-	0xaa, 0x3d, 0x14, 0x08, 0x15, 0xcf, 0x90, 0x2c, 0x62, 0x81, 0x91, 0x4a, 0x26, 0xfc, 0x80, 0x34,
-	0x78, 0x23, 0x7c, 0x24, 0x93, 0x1d, 0x10, 0xfc, 0x05, 0x1e, 0xf9, 0xc5, 0x78, 0x8e, 0x9d, 0x64,
-	0xf4, 0x69, 0x4f, 0xf5, 0xbd, 0xe7, 0x9c, 0x7b, 0x8f, 0x4f, 0x1d, 0xb0, 0xd9, 0x4f, 0x56, 0x35,
-	0x62, 0x7d, 0xc3, 0xeb, 0xa6, 0xc6, 0x99, 0xfa, 0x11, 0xde, 0x49, 0xf1, 0x39, 0x2f, 0xab, 0xa2,
-	0xfe, 0xc4, 0x14, 0xdc, 0xa2, 0x9e, 0x7d, 0x95, 0xef, 0x78, 0x59, 0xb4, 0x95, 0x9f, 0x82, 0x1d,
-	0x19, 0x16, 0x65, 0xd7, 0x78, 0x0a, 0x8b, 0x4e, 0x95, 0x9c, 0xbb, 0xd6, 0xa9, 0xb5, 0x22, 0x74,
-	0xd8, 0xc2, 0x47, 0x40, 0xd4, 0xb8, 0x34, 0xff, 0xce, 0xdc, 0xb1, 0xc2, 0xfb, 0x86, 0xff, 0xc7,
-	0x82, 0x79, 0x52, 0x35, 0x8c, 0x33, 0xd1, 0xe0, 0x53, 0x4d, 0xcd, 0x7e, 0xdf, 0x30, 0x35, 0xea,
-	0x28, 0x38, 0x6e, 0xf7, 0x8a, 0x75, 0x6c, 0x00, 0xda, 0x73, 0x30, 0x04, 0xa7, 0x18, 0xb8, 0x49,
-	0xaa, 0xab, 0x5a, 0xad, 0x58, 0x04, 0x27, 0x46, 0x37, 0x74, 0x7b, 0x31, 0xa2, 0x7b, 0xfc, 0x90,
-	0xc0, 0xa1, 0x3e, 0xfa, 0x1b, 0x98, 0xcb, 0x63, 0x29, 0xa4, 0x1d, 0x5c, 0xc1, 0xac, 0x0d, 0x49,
-	0x1a, 0x99, 0xc8, 0x81, 0x8e, 0x19, 0x68, 0xdc, 0x52, 0x8d, 0xfb, 0x97, 0x40, 0x28, 0xfb, 0xc2,
-	0x8a, 0xa6, 0xac, 0x2b, 0x7c, 0x0c, 0xe3, 0xe6, 0x97, 0xf2, 0xbe, 0x08, 0x1e, 0x18, 0x49, 0xc6,
-	0xf3, 0x4a, 0xe4, 0x8a, 0x40, 0x25, 0x8c, 0x1e, 0xcc, 0x19, 0xe7, 0x35, 0x7f, 0x2f, 0xae, 0x75,
-	0x22, 0x5d, 0xed, 0xbf, 0x04, 0xf8, 0x58, 0xf1, 0xfb, 0xbb, 0xf8, 0x3b, 0x86, 0xa9, 0xca, 0x08,
-	0xd7, 0x30, 0x37, 0x7a, 0x6d, 0xa4, 0x53, 0x99, 0xdb, 0xc9, 0x20, 0x3a, 0x0e, 0x3e, 0x81, 0xe9,
-	0xee, 0x5b, 0x5d, 0x7c, 0xd5, 0xc9, 0x2d, 0x0d, 0x39, 0xbc, 0x6d, 0x4a, 0x66, 0x8b, 0xe2, 0x2b,
-	0x38, 0xea, 0xb2, 0x53, 0x8b, 0xdc, 0x89, 0xe2, 0x3f, 0xdc, 0x4b, 0x5a, 0xa1, 0x52, 0xf8, 0x1f,
-	0x1f, 0x9f, 0x03, 0xe1, 0x26, 0x28, 0xf7, 0x40, 0x89, 0x8f, 0x7b, 0x67, 0x1a, 0x90, 0xba, 0x9e,
-	0x85, 0x1b, 0x80, 0x1f, 0x5d, 0x1a, 0xee, 0x54, 0x69, 0xd0, 0x68, 0xfa, 0x9c, 0xa4, 0x68, 0xc0,
-	0x0b, 0x0f, 0x75, 0x14, 0x67, 0x21, 0x90, 0xee, 0xdd, 0xa0, 0x2d, 0xff, 0xdd, 0xf8, 0x4d, 0xf2,
-	0x21, 0x8b, 0xa9, 0x33, 0x42, 0x02, 0xd3, 0xf0, 0x72, 0x1b, 0xbd, 0x73, 0x2c, 0x5c, 0x02, 0x89,
-	0x2e, 0x5e, 0x27, 0x69, 0xb4, 0x3d, 0x8f, 0x9d, 0xf1, 0x6d, 0x49, 0xe3, 0xb7, 0x71, 0x94, 0x25,
-	0xdb, 0xd4, 0x99, 0x04, 0x1b, 0x98, 0xa9, 0x19, 0x02, 0xcf, 0xe0, 0x40, 0xde, 0xb1, 0xc1, 0xe5,
-	0x9d, 0x37, 0xe9, 0xdd, 0x2d, 0xfd, 0xd1, 0xca, 0x7a, 0x66, 0xed, 0xda, 0x6f, 0xea, 0xc5, 0xbf,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x15, 0xb7, 0x5f, 0x65, 0x6a, 0x03, 0x00, 0x00,
+	// 555 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0xdf, 0x8f, 0x93, 0x40,
+	0x10, 0x86, 0xfe, 0x84, 0xa1, 0xbd, 0xe0, 0xdc, 0xe5, 0x42, 0x1a, 0xa3, 0x0d, 0xc6, 0x84, 0xdc,
+	0x43, 0x55, 0xbc, 0xf8, 0x6c, 0xa1, 0x44, 0xd0, 0xb3, 0x4d, 0xd6, 0xfa, 0x07, 0x50, 0x6e, 0xaf,
+	0x57, 0x7b, 0x85, 0x66, 0xa1, 0xa6, 0xf7, 0x2f, 0x18, 0xff, 0x5d, 0xdf, 0x0d, 0x0b, 0x0b, 0xd4,
+	0x7b, 0xf2, 0xa9, 0x9d, 0xf9, 0xbe, 0x6f, 0x76, 0xe6, 0x9b, 0x5d, 0x60, 0x40, 0x7f, 0xd2, 0x38,
+	0x4b, 0x27, 0x7b, 0x96, 0x64, 0x09, 0xf6, 0xf8, 0x4f, 0x3a, 0xba, 0x88, 0xee, 0xc3, 0x4d, 0x1c,
+	0x25, 0xb7, 0x94, 0xc3, 0x05, 0x3a, 0x1a, 0xdc, 0x85, 0x2b, 0xb6, 0x89, 0x8a, 0xc8, 0x9c, 0xc3,
+	0xc0, 0x15, 0x2c, 0x42, 0xd7, 0x38, 0x06, 0xad, 0x52, 0x05, 0x33, 0x43, 0x1e, 0xcb, 0x96, 0x4a,
+	0x9a, 0x29, 0x7c, 0x0e, 0x2a, 0x2f, 0x37, 0x0f, 0x77, 0xd4, 0x68, 0x71, 0xbc, 0x4e, 0x98, 0xbf,
+	0x64, 0x50, 0x82, 0x38, 0xa3, 0x8c, 0xa6, 0x19, 0xbe, 0x29, 0xa9, 0xcb, 0xc7, 0x3d, 0xe5, 0xa5,
+	0xce, 0xec, 0x67, 0xc5, 0xb9, 0xe9, 0xc4, 0x13, 0x00, 0xa9, 0x39, 0xe8, 0x80, 0x1e, 0x35, 0xba,
+	0x09, 0xe2, 0xbb, 0x84, 0x1f, 0xa1, 0xd9, 0x17, 0x42, 0xd7, 0xec, 0xd6, 0x97, 0xc8, 0x13, 0xbe,
+	0xa3, 0x42, 0xbf, 0xfc, 0x6b, 0xfe, 0x96, 0x41, 0x21, 0x74, 0xbd, 0x49, 0x33, 0xca, 0xd0, 0x82,
+	0x5e, 0xe1, 0x92, 0x21, 0x8f, 0xdb, 0x96, 0x66, 0xeb, 0xa2, 0xa2, 0x68, 0x97, 0x94, 0x38, 0xbe,
+	0x00, 0x88, 0x92, 0x38, 0x3d, 0xec, 0x28, 0x0b, 0x66, 0xe5, 0x88, 0x8d, 0x0c, 0x5e, 0x42, 0x8f,
+	0xd1, 0xfd, 0x43, 0xf8, 0x68, 0xb4, 0xc7, 0xb2, 0xa5, 0x90, 0x32, 0xca, 0x75, 0x69, 0x16, 0xb2,
+	0xcc, 0x79, 0x48, 0xa2, 0xad, 0xd1, 0x19, 0xcb, 0x56, 0x87, 0x34, 0x32, 0xe6, 0x0d, 0xa8, 0x84,
+	0xfe, 0xa0, 0x51, 0xb6, 0x49, 0x62, 0x7c, 0x05, 0xad, 0xec, 0xc8, 0x4d, 0xd1, 0xec, 0x73, 0xd1,
+	0xca, 0x92, 0x85, 0x71, 0x1a, 0x72, 0x02, 0x69, 0x65, 0x47, 0x1c, 0x81, 0x42, 0x19, 0x4b, 0xd8,
+	0xd7, 0x74, 0x5d, 0xf6, 0x51, 0xc5, 0xe6, 0x07, 0x80, 0xef, 0x31, 0xfb, 0xef, 0xe9, 0xcc, 0x3f,
+	0x2d, 0xe8, 0x72, 0xf3, 0x71, 0x02, 0x8a, 0xd0, 0x97, 0x8d, 0x54, 0x2a, 0xe1, 0x9a, 0x2f, 0x91,
+	0x8a, 0x83, 0xaf, 0xa1, 0xbb, 0xe2, 0xa3, 0x15, 0x2b, 0x19, 0x0a, 0x32, 0x9f, 0xce, 0x97, 0x48,
+	0x81, 0xe2, 0x47, 0x38, 0xab, 0x96, 0xc2, 0x0f, 0xe2, 0x36, 0x69, 0xf6, 0xe5, 0x93, 0x15, 0x72,
+	0xd4, 0x97, 0xc8, 0x3f, 0x7c, 0x7c, 0x07, 0x2a, 0x13, 0x46, 0x71, 0x1f, 0xb5, 0xfa, 0xde, 0x54,
+	0x0e, 0xfa, 0x12, 0xa9, 0x59, 0x78, 0x0d, 0x70, 0xa8, 0xdc, 0x30, 0xba, 0x5c, 0x83, 0x42, 0x53,
+	0xfb, 0xe4, 0x4b, 0xa4, 0xc1, 0xc3, 0x97, 0xd0, 0x0e, 0xa3, 0xad, 0xd1, 0xe3, 0x74, 0x4d, 0xd0,
+	0xa7, 0x7c, 0x9a, 0x1c, 0xc9, 0x9f, 0x03, 0x1f, 0x6a, 0x7e, 0xd8, 0xad, 0x28, 0x33, 0xfa, 0x7c,
+	0xa7, 0xcd, 0x54, 0xbe, 0x74, 0x6e, 0x6c, 0x10, 0xdf, 0xd2, 0xa3, 0xa1, 0x14, 0x4b, 0xaf, 0x33,
+	0x4e, 0xbf, 0x74, 0xdb, 0x9c, 0x40, 0x7b, 0x1a, 0x6d, 0xf1, 0xfc, 0xb4, 0x62, 0xee, 0x7b, 0x07,
+	0xf1, 0xa4, 0x48, 0x6e, 0x6f, 0xe7, 0xca, 0x01, 0xb5, 0x7a, 0x23, 0x38, 0x00, 0x85, 0x78, 0x9f,
+	0x82, 0x6f, 0x4b, 0x8f, 0xe8, 0x12, 0xaa, 0xd0, 0x75, 0x6e, 0x16, 0xee, 0x17, 0x5d, 0xc6, 0x21,
+	0xa8, 0xae, 0x3f, 0x0d, 0xe6, 0xee, 0x62, 0xe6, 0xe9, 0xad, 0x3c, 0x24, 0xde, 0x67, 0xcf, 0x5d,
+	0x06, 0x8b, 0xb9, 0xde, 0xb6, 0xaf, 0xa1, 0xe7, 0x15, 0x77, 0xfa, 0x0a, 0x3a, 0xee, 0x7d, 0x98,
+	0xe1, 0xf0, 0xe4, 0xfd, 0x8d, 0x4e, 0x43, 0x53, 0xb2, 0xe4, 0xb7, 0xf2, 0xaa, 0xf8, 0x7e, 0xbc,
+	0xff, 0x3b, 0x00, 0x14, 0x1b, 0x8c, 0x28, 0x56, 0x04, 0x00, 0x00,
 }
@@ -31,6 +31,9 @@ const (
 	Transaction_CHAINCODE_QUERY Transaction_Type = 3
 	// terminate a chaincode; not implemented yet
 	Transaction_CHAINCODE_TERMINATE Transaction_Type = 4
+	// replace the code of a deployed chaincode and call the `Init`
+	// function of the new code against the state of the chaincode
+	Transaction_CHAINCODE_UPGRADE Transaction_Type = 5
 )
 
 var Transaction_Type_name = map[int32]string{
@@ -39,6 +42,7 @@ var Transaction_Type_name = map[int32]string{
 	2: "CHAINCODE_INVOKE",
 	3: "CHAINCODE_QUERY",
 	4: "CHAINCODE_TERMINATE",
+	5: "CHAINCODE_UPGRADE",
 }
 var Transaction_Type_value = map[string]int32{
 	"UNDEFINED":           0,
@@ -46,6 +50,7 @@ var Transaction_Type_value = map[string]int32{
 	"CHAINCODE_INVOKE":    2,
 	"CHAINCODE_QUERY":     3,
 	"CHAINCODE_TERMINATE": 4,
+	"CHAINCODE_UPGRADE":   5,
 }
 
 func (x Transaction_Type) String() string {
@@ -441,6 +446,8 @@ func (m *Message) GetTimestamp() *google_protobuf.Timestamp {
 type Response struct {
 	Status Response_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.Response_StatusCode" json:"status,omitempty"`
 	Msg    []byte              `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	// The block the result of a query reflects
+	BlockNumber uint64 `protobuf:"varint,3,opt,name=blockNumber" json:"blockNumber,omitempty"`
 }
 
 func (m *Response) Reset()                    { *m = Response{} }
@@ -759,97 +766,98 @@ var _Peer_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("fabric.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 1463 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x57, 0xcd, 0x6e, 0xdb, 0xc6,
-	0x13, 0x8f, 0x3e, 0x1d, 0x8d, 0x64, 0x99, 0xde, 0x38, 0x09, 0xe3, 0x04, 0xf9, 0x07, 0xfc, 0xb7,
-	0x40, 0x50, 0xb4, 0x4a, 0xe1, 0xa0, 0x48, 0x10, 0xa0, 0x45, 0x14, 0x89, 0x8e, 0x85, 0x28, 0x94,
-	0xb3, 0x94, 0x1d, 0xa4, 0x87, 0x1a, 0xb4, 0xb4, 0xb6, 0x89, 0x48, 0xa4, 0x4a, 0x52, 0x41, 0x7d,
-	0xed, 0xa9, 0xe8, 0x6b, 0xf4, 0xd8, 0x63, 0x9f, 0xa1, 0x1f, 0x8f, 0xd0, 0xb7, 0xe8, 0xa5, 0x0f,
-	0xd0, 0xd9, 0x59, 0x92, 0x22, 0x65, 0x25, 0x4d, 0x7a, 0xb1, 0x39, 0x5f, 0xbb, 0xf3, 0xf1, 0x9b,
-	0x99, 0x15, 0x34, 0x4e, 0x9c, 0xe3, 0xc0, 0x1d, 0xb5, 0x66, 0x81, 0x1f, 0xf9, 0xac, 0x4a, 0xff,
-	0xc2, 0xed, 0x8d, 0xd1, 0x99, 0xe3, 0x7a, 0x23, 0x7f, 0x2c, 0x94, 0x60, 0x7b, 0x2b, 0x65, 0x88,
-	0x37, 0xc2, 0x8b, 0x62, 0xee, 0xff, 0x4e, 0x7d, 0xff, 0x74, 0x22, 0xee, 0x11, 0x75, 0x3c, 0x3f,
-	0xb9, 0x17, 0xb9, 0x53, 0x11, 0x46, 0xce, 0x74, 0xa6, 0x14, 0x8c, 0x3f, 0xcb, 0x50, 0x1f, 0x06,
-	0x8e, 0x17, 0x3a, 0xa3, 0xc8, 0xf5, 0x3d, 0xf6, 0x29, 0x94, 0xa3, 0xf3, 0x99, 0xd0, 0x0b, 0x77,
-	0x0a, 0x77, 0x9b, 0x3b, 0xba, 0xd2, 0x0a, 0x5b, 0x19, 0x95, 0xd6, 0x10, 0xe5, 0x9c, 0xb4, 0xd8,
-	0x1d, 0xa8, 0xa7, 0xd7, 0xf6, 0xba, 0x7a, 0x11, 0x8d, 0x1a, 0x3c, 0xcb, 0x62, 0x3a, 0xac, 0xcd,
-	0x9c, 0xf3, 0x89, 0xef, 0x8c, 0xf5, 0x12, 0x49, 0x13, 0x92, 0x6d, 0xc3, 0xe5, 0xa9, 0x88, 0x9c,
-	0xb1, 0x13, 0x39, 0x7a, 0x99, 0x44, 0x29, 0xcd, 0x18, 0x7a, 0xf1, 0x9d, 0x3b, 0xd6, 0x2b, 0xc8,
-	0xaf, 0x71, 0xfa, 0x66, 0x0f, 0xa1, 0x96, 0x3a, 0xaf, 0x57, 0x51, 0x50, 0xdf, 0xd9, 0x6e, 0xa9,
-	0xf0, 0x5a, 0x49, 0x78, 0xad, 0x61, 0xa2, 0xc1, 0x17, 0xca, 0x6c, 0x1f, 0xb6, 0x46, 0xbe, 0x77,
-	0xe2, 0x8e, 0x31, 0x2f, 0xae, 0x33, 0x71, 0xa3, 0xf3, 0x3e, 0xe6, 0x68, 0xa2, 0xaf, 0x51, 0x8c,
-	0xb7, 0x92, 0x18, 0x3b, 0x2b, 0x74, 0xf8, 0x4a, 0x4b, 0xb6, 0x0b, 0xb7, 0x97, 0xf8, 0xfb, 0xf2,
-	0x8c, 0x91, 0x3f, 0x39, 0x14, 0x41, 0x88, 0x49, 0xd2, 0x2f, 0x93, 0xe7, 0xff, 0xa2, 0xc5, 0xb6,
-	0xa0, 0xe2, 0xf9, 0xde, 0x48, 0xe8, 0x35, 0x4a, 0x80, 0x22, 0x98, 0x01, 0x8d, 0xc8, 0x3f, 0x44,
-	0x03, 0xcc, 0x85, 0x1f, 0x84, 0x3a, 0x90, 0x30, 0xc7, 0x93, 0x19, 0x1a, 0x89, 0x20, 0xd2, 0xeb,
-	0x24, 0xa3, 0x6f, 0x76, 0x0b, 0x6a, 0xa1, 0x7b, 0xea, 0x39, 0xd1, 0x3c, 0x10, 0x7a, 0x83, 0x04,
-	0x0b, 0x86, 0xe1, 0x43, 0x59, 0x56, 0x8e, 0xad, 0x43, 0xed, 0xc0, 0xea, 0x9a, 0xbb, 0x3d, 0xcb,
-	0xec, 0x6a, 0x97, 0xd0, 0x05, 0xad, 0xb3, 0xd7, 0xee, 0x59, 0x9d, 0x41, 0xd7, 0x3c, 0xea, 0x9a,
-	0xfb, 0xfd, 0xc1, 0x2b, 0xad, 0x90, 0xe7, 0xf6, 0xac, 0xc3, 0xc1, 0x33, 0x53, 0x2b, 0xb2, 0x2b,
-	0xb0, 0xb1, 0xe0, 0xbe, 0x38, 0x30, 0xf9, 0x2b, 0xad, 0xc4, 0xae, 0xc3, 0x95, 0x05, 0x73, 0x68,
-	0xf2, 0xe7, 0x3d, 0xab, 0x3d, 0x34, 0xb5, 0xb2, 0xf1, 0x0c, 0xb4, 0x0c, 0x6c, 0x9e, 0x4c, 0xfc,
-	0xd1, 0x6b, 0xf6, 0x00, 0x43, 0x5b, 0xf0, 0x42, 0x84, 0x59, 0x09, 0xeb, 0x78, 0x65, 0x05, 0xcc,
-	0x78, 0x4e, 0xd1, 0xf8, 0xa5, 0x00, 0x9b, 0x59, 0xa9, 0x08, 0xe7, 0x93, 0x28, 0xc5, 0x49, 0x21,
-	0x83, 0x93, 0x6b, 0x50, 0x0d, 0x48, 0x1a, 0xc3, 0x31, 0xa6, 0x64, 0x76, 0x44, 0x10, 0xf8, 0x41,
-	0x07, 0x81, 0x49, 0x58, 0x5c, 0xe7, 0x0b, 0x86, 0xac, 0x04, 0x11, 0x04, 0xc5, 0x1a, 0x57, 0x04,
-	0xfb, 0x0a, 0x9a, 0x29, 0x98, 0x4d, 0xd9, 0x56, 0x84, 0xc8, 0xfa, 0xce, 0xb5, 0x14, 0x33, 0x39,
-	0x29, 0x5f, 0xd2, 0x36, 0x7e, 0x2d, 0x42, 0x45, 0x05, 0x8e, 0x7d, 0xf0, 0x26, 0x86, 0x46, 0x81,
-	0xee, 0x4e, 0xc8, 0x3c, 0xae, 0x8b, 0x1f, 0x82, 0xeb, 0xe5, 0x64, 0x96, 0xde, 0x33, 0x99, 0x04,
-	0x94, 0xc8, 0x89, 0xc4, 0x9e, 0x13, 0x9e, 0xc5, 0xbd, 0xb7, 0x60, 0xe0, 0x08, 0xd8, 0x9c, 0x05,
-	0xe2, 0x8d, 0xeb, 0xcf, 0x43, 0xf2, 0x9d, 0xb4, 0x2a, 0xa4, 0x75, 0x51, 0x20, 0xb5, 0x11, 0xe4,
-	0xa1, 0xf0, 0xc2, 0x79, 0xf8, 0x3c, 0xe9, 0xe7, 0xaa, 0xd2, 0xbe, 0x20, 0x60, 0x5f, 0x40, 0x1d,
-	0x31, 0x2e, 0x0d, 0xbb, 0x52, 0x6f, 0x8d, 0xc2, 0x4d, 0x3d, 0xb6, 0x16, 0x22, 0x9e, 0xd5, 0x33,
-	0xbe, 0x2f, 0x40, 0x93, 0xae, 0xa4, 0xfc, 0xf6, 0xbc, 0x13, 0x5f, 0x96, 0xf9, 0x4c, 0xb8, 0xa7,
-	0x67, 0x11, 0xe5, 0xb3, 0xcc, 0x63, 0x8a, 0x7d, 0x02, 0xda, 0x68, 0x1e, 0x04, 0x98, 0xfd, 0x85,
-	0xf3, 0x0a, 0x08, 0x17, 0xf8, 0xab, 0x23, 0x2d, 0xbd, 0x25, 0x52, 0xe3, 0xe7, 0x02, 0xd4, 0x33,
-	0x1e, 0xb2, 0xaf, 0x61, 0x1b, 0x65, 0xce, 0xa4, 0x2f, 0xc6, 0xa7, 0x02, 0x51, 0x34, 0x9d, 0xba,
-	0x51, 0x5a, 0x27, 0xf2, 0xea, 0xdd, 0x95, 0x7c, 0x87, 0x35, 0x7b, 0x0c, 0x1b, 0x79, 0x28, 0x85,
-	0x18, 0x44, 0xe9, 0x1d, 0xc8, 0x5b, 0x56, 0x37, 0x30, 0xd3, 0xfb, 0x42, 0x04, 0xed, 0xf1, 0x18,
-	0xf1, 0x4f, 0xf3, 0xe2, 0xcc, 0x0f, 0xa3, 0xa4, 0x53, 0xe4, 0xb7, 0xe4, 0xcd, 0xfc, 0x40, 0xf5,
-	0x49, 0x85, 0xd3, 0xb7, 0x71, 0x0b, 0xaa, 0xd2, 0x0c, 0x27, 0x37, 0x4a, 0x3d, 0x67, 0x2a, 0x12,
-	0x0b, 0xf9, 0x6d, 0xfc, 0x56, 0x80, 0x86, 0x14, 0x9b, 0xde, 0x78, 0xe6, 0xbb, 0x5e, 0xc4, 0x6e,
-	0x43, 0x11, 0xe7, 0xbe, 0x8a, 0xb5, 0x99, 0xb8, 0xa6, 0x0e, 0xe0, 0x45, 0x97, 0xc6, 0xbf, 0xa3,
-	0x3c, 0xa0, 0x5b, 0x6a, 0x3c, 0x21, 0xd9, 0x67, 0xf1, 0xa2, 0x29, 0xd1, 0x10, 0xbe, 0x91, 0xb5,
-	0x4d, 0x4e, 0xcf, 0x6e, 0x1a, 0xec, 0xcf, 0xd9, 0x6b, 0x17, 0xef, 0x52, 0x70, 0x55, 0x84, 0xf1,
-	0x60, 0xf5, 0x4c, 0x43, 0xf2, 0xb0, 0xdd, 0xef, 0x75, 0xdb, 0xc3, 0x01, 0xc7, 0x61, 0xb6, 0x09,
-	0xeb, 0xd6, 0xc0, 0x3a, 0x5a, 0xb0, 0x8a, 0xc6, 0x23, 0x15, 0x07, 0x02, 0x33, 0x0c, 0x9d, 0x53,
-	0x81, 0xa8, 0xa9, 0xcc, 0x24, 0x1d, 0x0f, 0xa4, 0xad, 0x55, 0xee, 0x70, 0xa5, 0x62, 0xb4, 0xa0,
-	0x49, 0xb6, 0x71, 0x6a, 0x05, 0xf5, 0x93, 0x93, 0x10, 0x74, 0x42, 0x8d, 0x2f, 0x18, 0xc6, 0x0f,
-	0x98, 0xb4, 0x3d, 0x31, 0x99, 0xf8, 0xc9, 0x65, 0x0f, 0xa1, 0x31, 0xcb, 0x9c, 0x1b, 0xa7, 0x6f,
-	0xf5, 0x9d, 0x39, 0x4d, 0x39, 0x8f, 0x8e, 0x73, 0x6d, 0x10, 0x0f, 0x8c, 0x14, 0x15, 0xf9, 0x26,
-	0xe1, 0x4b, 0xda, 0xc6, 0x5f, 0x25, 0x58, 0x4b, 0xbc, 0xb8, 0x9b, 0xdb, 0xf4, 0xe9, 0xed, 0xb1,
-	0x38, 0x9b, 0xfb, 0xff, 0x3e, 0xa1, 0xde, 0xbe, 0xfd, 0x73, 0xbb, 0xaa, 0xbc, 0xbc, 0xab, 0x7e,
-	0x2f, 0xae, 0x2e, 0x6c, 0x13, 0xa0, 0xdb, 0xb3, 0x3b, 0x47, 0x7b, 0x66, 0xbf, 0x3f, 0xc0, 0xca,
-	0xe2, 0x42, 0x22, 0x5a, 0xfe, 0x19, 0x58, 0x96, 0xd9, 0x19, 0xe2, 0x96, 0x62, 0xd0, 0x24, 0xe6,
-	0x53, 0x73, 0x78, 0xb4, 0x6f, 0x9a, 0xdc, 0xc6, 0x25, 0x95, 0x18, 0x2a, 0xba, 0xcc, 0x36, 0xa0,
-	0x4e, 0xb4, 0x65, 0xbe, 0x7c, 0x6e, 0x3f, 0xd5, 0x2a, 0xec, 0x2a, 0x6c, 0xd2, 0x16, 0x3b, 0x1a,
-	0xf2, 0xb6, 0x65, 0xb7, 0x3b, 0xc3, 0xde, 0xc0, 0xd2, 0xaa, 0xf2, 0x02, 0xfb, 0x95, 0xa5, 0xce,
-	0x7a, 0xd2, 0x1f, 0x74, 0x9e, 0xd9, 0x5a, 0x5d, 0x1a, 0x13, 0x33, 0x66, 0x34, 0xe4, 0xb6, 0x5c,
-	0x30, 0x8e, 0xda, 0xdd, 0x2e, 0x3a, 0xbb, 0xce, 0x6e, 0xc2, 0x75, 0xe2, 0xda, 0x43, 0xdc, 0x87,
-	0x74, 0x82, 0x6d, 0xb5, 0xf7, 0xed, 0xbd, 0xc1, 0x50, 0x6b, 0xca, 0xad, 0x99, 0x11, 0xa6, 0x82,
-	0x0d, 0x76, 0x03, 0xae, 0x2e, 0x59, 0x75, 0xcd, 0xfe, 0xb0, 0x6d, 0x6b, 0x9a, 0xf4, 0x31, 0x23,
-	0x8a, 0xd9, 0x9b, 0xac, 0x01, 0x97, 0xb9, 0x69, 0xef, 0x0f, 0x2c, 0xdb, 0xd4, 0xb6, 0x64, 0xc6,
-	0x3a, 0xf2, 0xd3, 0xb2, 0x0f, 0x6c, 0xed, 0xaa, 0xf1, 0x63, 0x01, 0xa5, 0x22, 0x9c, 0xc9, 0x49,
-	0xcc, 0xee, 0x43, 0x55, 0x8e, 0xf9, 0x79, 0x18, 0x17, 0xfd, 0x66, 0x52, 0xf4, 0x44, 0xa3, 0x65,
-	0x93, 0x58, 0x6e, 0x44, 0x1e, 0xab, 0x32, 0x0d, 0x4a, 0xd3, 0xf0, 0x34, 0x9e, 0xa1, 0xf2, 0x13,
-	0xbb, 0x0e, 0x16, 0x7a, 0xcb, 0x25, 0x6a, 0xc0, 0x9a, 0x7d, 0xd0, 0xe9, 0x98, 0xb6, 0xad, 0xfd,
-	0x51, 0x90, 0xd4, 0x6e, 0xbb, 0xd7, 0x3f, 0xe0, 0xa6, 0xf6, 0x77, 0xc9, 0x78, 0x01, 0x40, 0x00,
-	0x95, 0xd6, 0x82, 0xfd, 0x1f, 0x2a, 0x04, 0xcf, 0x18, 0xff, 0xeb, 0x39, 0x0c, 0x73, 0x25, 0xc3,
-	0x01, 0x03, 0xb4, 0x99, 0xba, 0x62, 0x82, 0xfb, 0x42, 0x39, 0x91, 0xe1, 0x18, 0xdf, 0x40, 0xd3,
-	0x3e, 0xf7, 0x46, 0xca, 0xc6, 0xf1, 0x10, 0xd7, 0x1f, 0xc1, 0xfa, 0xc8, 0xc7, 0x41, 0x3f, 0x71,
-	0xe4, 0xb2, 0xeb, 0x8d, 0xe3, 0xfd, 0x90, 0x67, 0xca, 0x79, 0x82, 0xa7, 0xc4, 0xc3, 0xaf, 0xcc,
-	0x15, 0x21, 0x63, 0x15, 0x9e, 0xc2, 0x6a, 0x99, 0xcb, 0x4f, 0xc3, 0xc1, 0x58, 0x93, 0xf3, 0x43,
-	0x5c, 0x18, 0x95, 0x40, 0x5e, 0x12, 0xbb, 0x9c, 0xb6, 0x5d, 0xde, 0x05, 0xae, 0x94, 0xd8, 0xc7,
-	0x50, 0xa5, 0x20, 0x92, 0xd9, 0xbd, 0x14, 0x61, 0x2c, 0x34, 0x1e, 0x83, 0x2e, 0xed, 0x29, 0x29,
-	0xb6, 0xe7, 0xcc, 0xc2, 0x33, 0x3f, 0xe2, 0xe2, 0xdb, 0x39, 0xf6, 0xd0, 0xfb, 0x05, 0x63, 0xfc,
-	0x84, 0x8f, 0xa3, 0x0b, 0x47, 0xc8, 0x10, 0xc7, 0x94, 0xb5, 0x82, 0x1a, 0x99, 0x44, 0xc8, 0x67,
-	0x77, 0x28, 0x0f, 0x97, 0xaf, 0x4e, 0x15, 0x7b, 0x4a, 0xcb, 0xe7, 0x3c, 0xf9, 0x64, 0xcd, 0xa7,
-	0xc7, 0x22, 0x88, 0xd3, 0x90, 0x65, 0xb1, 0x47, 0xb0, 0x16, 0x28, 0xd7, 0xa8, 0x69, 0xeb, 0x3b,
-	0x77, 0xb2, 0x29, 0x58, 0x15, 0x02, 0x4f, 0x0c, 0x8c, 0x5d, 0xb8, 0x96, 0x2a, 0x51, 0xf1, 0xc2,
-	0x24, 0xca, 0x0f, 0x4a, 0xab, 0xf1, 0x12, 0x7b, 0x32, 0x7f, 0xce, 0x07, 0xd6, 0x05, 0x9f, 0x0e,
-	0x94, 0x0b, 0x55, 0x17, 0x7c, 0x21, 0x2a, 0x6a, 0x67, 0x0e, 0x65, 0x39, 0x7b, 0x59, 0x0b, 0xca,
-	0xb8, 0x5d, 0x23, 0xb6, 0xb1, 0x34, 0x13, 0xb7, 0x97, 0x19, 0xc6, 0xa5, 0xbb, 0x85, 0xcf, 0x0b,
-	0xec, 0x4b, 0x60, 0xf8, 0xb0, 0x1f, 0x21, 0x2f, 0xfb, 0x4b, 0x6a, 0xd5, 0x3b, 0x6c, 0x5b, 0x5b,
-	0xee, 0x38, 0xe3, 0xd2, 0xb1, 0xfa, 0x49, 0x77, 0xff, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc8,
-	0xc4, 0xd0, 0x63, 0xe9, 0x0d, 0x00, 0x00,
+	// 1476 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xcb, 0x6e, 0xdb, 0x46,
+	0x17, 0x0e, 0x75, 0xb3, 0x75, 0x24, 0xcb, 0xf4, 0xc4, 0x71, 0x18, 0x27, 0xc8, 0x2f, 0xf0, 0xff,
+	0x7f, 0xc0, 0x08, 0x52, 0xa5, 0x70, 0x10, 0x24, 0x08, 0xd0, 0x22, 0x8a, 0x48, 0xc7, 0x42, 0x64,
+	0x4a, 0x19, 0xca, 0x0e, 0xd2, 0x45, 0x0d, 0x9a, 0x1a, 0x4b, 0x44, 0x24, 0x8e, 0xca, 0x19, 0x19,
+	0xf5, 0xb6, 0x8b, 0xa2, 0xef, 0xd1, 0x65, 0xbb, 0xeb, 0x33, 0xf4, 0xf2, 0x36, 0xed, 0xa2, 0x0f,
+	0x50, 0xcc, 0xf0, 0x2e, 0x2b, 0xb7, 0x6e, 0xec, 0x39, 0xdf, 0xb9, 0xcc, 0xb9, 0xcd, 0x39, 0x14,
+	0xd4, 0xcf, 0x9d, 0xb3, 0xc0, 0x73, 0x5b, 0xf3, 0x80, 0x72, 0x8a, 0x2a, 0xf2, 0x1f, 0xdb, 0xdd,
+	0x74, 0x27, 0x8e, 0xe7, 0xbb, 0x74, 0x44, 0x42, 0xc6, 0xee, 0x76, 0x02, 0x90, 0x0b, 0xe2, 0xf3,
+	0x08, 0xfd, 0xcf, 0x98, 0xd2, 0xf1, 0x94, 0x3c, 0x90, 0xd4, 0xd9, 0xe2, 0xfc, 0x01, 0xf7, 0x66,
+	0x84, 0x71, 0x67, 0x36, 0x0f, 0x05, 0xf4, 0xbf, 0x4a, 0x50, 0x1b, 0x06, 0x8e, 0xcf, 0x1c, 0x97,
+	0x7b, 0xd4, 0x47, 0xf7, 0xa1, 0xc4, 0x2f, 0xe7, 0x44, 0x53, 0x9a, 0xca, 0x5e, 0x63, 0x5f, 0x0b,
+	0xa5, 0x58, 0x2b, 0x23, 0xd2, 0x1a, 0x5e, 0xce, 0x09, 0x96, 0x52, 0xa8, 0x09, 0xb5, 0xe4, 0xda,
+	0xae, 0xa1, 0x15, 0x9a, 0xca, 0x5e, 0x1d, 0x67, 0x21, 0xa4, 0xc1, 0xda, 0xdc, 0xb9, 0x9c, 0x52,
+	0x67, 0xa4, 0x15, 0x25, 0x37, 0x26, 0xd1, 0x2e, 0xac, 0xcf, 0x08, 0x77, 0x46, 0x0e, 0x77, 0xb4,
+	0x92, 0x64, 0x25, 0x34, 0x42, 0x50, 0xe2, 0xdf, 0x7a, 0x23, 0xad, 0xdc, 0x54, 0xf6, 0xaa, 0x58,
+	0x9e, 0xd1, 0x13, 0xa8, 0x26, 0xce, 0x6b, 0x95, 0xa6, 0xb2, 0x57, 0xdb, 0xdf, 0x6d, 0x85, 0xe1,
+	0xb5, 0xe2, 0xf0, 0x5a, 0xc3, 0x58, 0x02, 0xa7, 0xc2, 0x68, 0x00, 0xdb, 0x2e, 0xf5, 0xcf, 0xbd,
+	0x11, 0xf1, 0xb9, 0xe7, 0x4c, 0x3d, 0x7e, 0xd9, 0x23, 0x17, 0x64, 0xaa, 0xad, 0xc9, 0x18, 0xef,
+	0xc4, 0x31, 0x76, 0x56, 0xc8, 0xe0, 0x95, 0x9a, 0xe8, 0x00, 0xee, 0x2e, 0xe1, 0x03, 0x61, 0xc3,
+	0xa5, 0xd3, 0x13, 0x12, 0x30, 0x8f, 0xfa, 0xda, 0xba, 0xf4, 0xfc, 0x03, 0x52, 0x68, 0x1b, 0xca,
+	0x3e, 0xf5, 0x5d, 0xa2, 0x55, 0x65, 0x02, 0x42, 0x02, 0xe9, 0x50, 0xe7, 0xf4, 0xc4, 0x99, 0x7a,
+	0x23, 0x87, 0xd3, 0x80, 0x69, 0x20, 0x99, 0x39, 0x4c, 0x64, 0xc8, 0x25, 0x01, 0xd7, 0x6a, 0x92,
+	0x27, 0xcf, 0xe8, 0x0e, 0x54, 0x99, 0x37, 0xf6, 0x1d, 0xbe, 0x08, 0x88, 0x56, 0x97, 0x8c, 0x14,
+	0xd0, 0xbf, 0x57, 0xa0, 0x24, 0x4a, 0x87, 0x36, 0xa0, 0x7a, 0x6c, 0x19, 0xe6, 0x41, 0xd7, 0x32,
+	0x0d, 0xf5, 0x1a, 0xda, 0x06, 0xb5, 0x73, 0xd8, 0xee, 0x5a, 0x9d, 0xbe, 0x61, 0x9e, 0x1a, 0xe6,
+	0xa0, 0xd7, 0x7f, 0xa3, 0x2a, 0x79, 0xb4, 0x6b, 0x9d, 0xf4, 0x5f, 0x9a, 0x6a, 0x01, 0x5d, 0x87,
+	0xcd, 0x14, 0x7d, 0x75, 0x6c, 0xe2, 0x37, 0x6a, 0x11, 0xdd, 0x84, 0xeb, 0x29, 0x38, 0x34, 0xf1,
+	0x51, 0xd7, 0x6a, 0x0f, 0x4d, 0xb5, 0x84, 0x6e, 0xc0, 0x56, 0xca, 0x38, 0x1e, 0xbc, 0xc0, 0x6d,
+	0xc3, 0x54, 0xcb, 0xfa, 0x4b, 0x50, 0x33, 0xed, 0xf4, 0x7c, 0x4a, 0xdd, 0xb7, 0xe8, 0x31, 0xd4,
+	0x79, 0x8a, 0x31, 0x4d, 0x69, 0x16, 0xf7, 0x6a, 0xfb, 0xd7, 0x57, 0xb4, 0x1f, 0xce, 0x09, 0xea,
+	0xbf, 0x28, 0xb0, 0x95, 0xe5, 0x12, 0xb6, 0x98, 0xf2, 0xa4, 0x7f, 0x94, 0x4c, 0xff, 0xec, 0x40,
+	0x25, 0x90, 0xdc, 0xa8, 0x4d, 0x23, 0x4a, 0x64, 0x8d, 0x04, 0x01, 0x0d, 0x3a, 0x74, 0x44, 0x64,
+	0x8f, 0x6e, 0xe0, 0x14, 0x10, 0x15, 0x92, 0x84, 0x6c, 0xd1, 0x2a, 0x0e, 0x09, 0xf4, 0x25, 0x34,
+	0x92, 0x26, 0x37, 0xc5, 0x73, 0x93, 0x9d, 0x5a, 0xdb, 0xdf, 0x49, 0x7a, 0x29, 0xc7, 0xc5, 0x4b,
+	0xd2, 0xfa, 0xaf, 0x05, 0x28, 0x87, 0x81, 0x6b, 0xb0, 0x76, 0x11, 0xb5, 0x8c, 0x22, 0xef, 0x8e,
+	0xc9, 0x7c, 0xbf, 0x17, 0x3e, 0xa5, 0xdf, 0x97, 0x93, 0x59, 0xfc, 0xc8, 0x64, 0xca, 0x06, 0xe2,
+	0x0e, 0x27, 0x87, 0x0e, 0x9b, 0x44, 0x6f, 0x32, 0x05, 0xd0, 0x7d, 0xd8, 0x9a, 0x07, 0xe4, 0xc2,
+	0xa3, 0x0b, 0x26, 0x7d, 0x97, 0x52, 0x65, 0x29, 0x75, 0x95, 0x21, 0xa4, 0x5d, 0xea, 0x33, 0xe2,
+	0xb3, 0x05, 0x3b, 0x8a, 0xdf, 0x79, 0x25, 0x94, 0xbe, 0xc2, 0x40, 0x8f, 0xa0, 0xe6, 0x53, 0x5f,
+	0x28, 0x1a, 0x42, 0x6e, 0xad, 0xa9, 0x64, 0x3d, 0xb6, 0x52, 0x16, 0xce, 0xca, 0xe9, 0xdf, 0x29,
+	0xd0, 0x90, 0x57, 0xca, 0xfc, 0x76, 0xfd, 0x73, 0x2a, 0xca, 0x3c, 0x21, 0xde, 0x78, 0xc2, 0x65,
+	0x3e, 0x4b, 0x38, 0xa2, 0xd0, 0x3d, 0x50, 0xdd, 0x45, 0x10, 0x10, 0x9f, 0xa7, 0xce, 0x87, 0x8d,
+	0x70, 0x05, 0x5f, 0x1d, 0x69, 0xf1, 0x1d, 0x91, 0xea, 0x3f, 0x29, 0x50, 0xcb, 0x78, 0x88, 0xbe,
+	0x82, 0xdd, 0x29, 0x75, 0x9d, 0x69, 0x8f, 0x8c, 0xc6, 0x24, 0xe8, 0xd0, 0xd9, 0xcc, 0xe3, 0x49,
+	0x9d, 0x34, 0xe5, 0x83, 0x95, 0x7c, 0x8f, 0x36, 0x7a, 0x06, 0x9b, 0xf9, 0x56, 0x62, 0x5a, 0xa1,
+	0x59, 0x7c, 0x4f, 0xe7, 0x2d, 0x8b, 0xeb, 0x8f, 0xa0, 0x36, 0x20, 0x24, 0x68, 0x8f, 0x46, 0x01,
+	0x61, 0x72, 0x8e, 0x4c, 0x28, 0xe3, 0xf1, 0x4b, 0x11, 0x67, 0x81, 0xcd, 0x69, 0x10, 0xbe, 0x93,
+	0x32, 0x96, 0x67, 0xfd, 0x0e, 0x54, 0x84, 0x5a, 0xd7, 0x10, 0x5c, 0xdf, 0x99, 0x91, 0x58, 0x43,
+	0x9c, 0xf5, 0xdf, 0x14, 0xa8, 0x0b, 0xb6, 0xe9, 0x8f, 0xe6, 0xd4, 0xf3, 0x39, 0xba, 0x0b, 0x85,
+	0xae, 0x11, 0xc5, 0xda, 0x88, 0x5d, 0x0b, 0x0d, 0xe0, 0x82, 0x27, 0xd7, 0x82, 0x13, 0x7a, 0x20,
+	0x6f, 0xa9, 0xe2, 0x98, 0x44, 0x9f, 0x45, 0x0b, 0xa8, 0x28, 0x87, 0xf3, 0xad, 0xac, 0x6e, 0x6c,
+	0x3d, 0xbb, 0x81, 0xb6, 0xa1, 0x3c, 0x7f, 0xeb, 0x75, 0x8d, 0xa8, 0x5d, 0x43, 0x42, 0x7f, 0xbc,
+	0x7a, 0xd4, 0x6d, 0x40, 0xf5, 0xa4, 0xdd, 0xeb, 0x1a, 0xed, 0x61, 0x1f, 0xab, 0x0a, 0xda, 0x82,
+	0x0d, 0xab, 0x6f, 0x9d, 0xa6, 0x50, 0x41, 0x7f, 0x1a, 0xc6, 0xc1, 0x8e, 0x08, 0x63, 0xce, 0x98,
+	0xa0, 0x7b, 0x50, 0x9e, 0x0b, 0x3a, 0x1a, 0x48, 0xdb, 0xab, 0xdc, 0xc1, 0xa1, 0x88, 0xde, 0x82,
+	0x86, 0xd4, 0x8d, 0x52, 0x4b, 0xe4, 0x7b, 0x72, 0x62, 0x42, 0x5a, 0xa8, 0xe2, 0x14, 0xd0, 0x7f,
+	0x50, 0xa0, 0x7e, 0x48, 0xa6, 0x53, 0x1a, 0x5f, 0xf6, 0x04, 0xea, 0xf3, 0x8c, 0xdd, 0x28, 0x7d,
+	0xab, 0xef, 0xcc, 0x49, 0x8a, 0x79, 0x74, 0x96, 0x7b, 0x06, 0xd1, 0xc0, 0x48, 0xba, 0x22, 0xff,
+	0x48, 0xf0, 0x92, 0xb4, 0xfe, 0x67, 0x11, 0xd6, 0x62, 0x2f, 0xf6, 0x72, 0x5f, 0x00, 0xc9, 0xed,
+	0x11, 0x3b, 0x9b, 0xfb, 0x7f, 0x3f, 0xa1, 0xde, 0xfd, 0x55, 0x90, 0xdb, 0x61, 0xa5, 0xe5, 0x1d,
+	0xf6, 0x7b, 0x61, 0x75, 0x61, 0x1b, 0x00, 0x46, 0xd7, 0xee, 0x9c, 0x1e, 0x9a, 0xbd, 0x5e, 0x5f,
+	0x55, 0xc4, 0x9e, 0x92, 0xb4, 0xf8, 0xd3, 0xb7, 0x2c, 0xb3, 0x33, 0x54, 0x0b, 0x08, 0x41, 0x43,
+	0x82, 0x2f, 0xcc, 0xe1, 0xe9, 0xc0, 0x34, 0xb1, 0xad, 0x16, 0x13, 0xc5, 0x90, 0x2e, 0xa1, 0x4d,
+	0xa8, 0x49, 0xda, 0x32, 0x5f, 0x1f, 0xd9, 0x2f, 0xd4, 0x72, 0xb2, 0xc3, 0x4e, 0x87, 0xb8, 0x6d,
+	0xd9, 0xed, 0xce, 0xb0, 0xdb, 0xb7, 0xd4, 0x8a, 0xb8, 0xc0, 0x7e, 0x63, 0x85, 0xb6, 0x9e, 0xf7,
+	0xfa, 0x9d, 0x97, 0xb6, 0x5a, 0x13, 0xca, 0x12, 0x8c, 0x80, 0xba, 0x58, 0xa2, 0x29, 0x70, 0xda,
+	0x36, 0x0c, 0xd3, 0x50, 0x37, 0xd0, 0x6d, 0xb8, 0x29, 0x51, 0x7b, 0xd8, 0x1e, 0x9a, 0xd2, 0x82,
+	0x6d, 0xb5, 0x07, 0xf6, 0x61, 0x7f, 0xa8, 0x36, 0xc4, 0x32, 0xcd, 0x30, 0x13, 0xc6, 0x26, 0xba,
+	0x05, 0x37, 0x96, 0xb4, 0x0c, 0xb3, 0x37, 0x6c, 0xdb, 0xaa, 0x2a, 0x7c, 0xcc, 0xb0, 0x22, 0x78,
+	0x0b, 0xd5, 0x61, 0x1d, 0x9b, 0xf6, 0xa0, 0x6f, 0xd9, 0xa6, 0xba, 0x2d, 0x32, 0xd6, 0x11, 0x47,
+	0xcb, 0x3e, 0xb6, 0xd5, 0x1b, 0xfa, 0xcf, 0x0a, 0xac, 0x63, 0xc2, 0xe6, 0x62, 0x12, 0xa3, 0x87,
+	0x50, 0x11, 0x63, 0x7e, 0xc1, 0xa2, 0xa2, 0xdf, 0x8e, 0x8b, 0x1e, 0x4b, 0xb4, 0x6c, 0xc9, 0x16,
+	0x1b, 0x11, 0x47, 0xa2, 0x48, 0x85, 0xe2, 0x8c, 0x8d, 0xa3, 0x19, 0x2a, 0x8e, 0xe2, 0x6b, 0x50,
+	0xf6, 0x95, 0xb5, 0x98, 0x9d, 0x91, 0x40, 0x56, 0xb6, 0x84, 0xb3, 0x90, 0xfe, 0x18, 0x20, 0xb5,
+	0xb4, 0x5c, 0xc4, 0x3a, 0xac, 0xd9, 0xc7, 0x9d, 0x8e, 0x69, 0xdb, 0xea, 0x1f, 0x8a, 0xa0, 0x0e,
+	0xda, 0xdd, 0xde, 0x31, 0x36, 0xd5, 0xbf, 0x8b, 0xfa, 0x2b, 0x00, 0xd9, 0xc2, 0x42, 0x9b, 0xa0,
+	0xff, 0x42, 0x59, 0x5a, 0x8d, 0x5e, 0xc8, 0x46, 0xae, 0xcb, 0x71, 0xc8, 0x43, 0x77, 0x01, 0xe4,
+	0xee, 0x32, 0xc8, 0x94, 0x3b, 0x91, 0x9b, 0x19, 0x44, 0xff, 0x1a, 0x1a, 0xf6, 0xa5, 0xef, 0x86,
+	0x3a, 0x8e, 0x3f, 0x26, 0xe8, 0x7f, 0xb0, 0xe1, 0xd2, 0x20, 0x20, 0x53, 0x47, 0xac, 0xc3, 0xee,
+	0x28, 0xda, 0x20, 0x79, 0x50, 0x4c, 0x1c, 0xc6, 0x9d, 0x68, 0x3c, 0x96, 0x70, 0x48, 0x88, 0x6c,
+	0x10, 0x7f, 0x14, 0xc5, 0x2c, 0x8e, 0xba, 0x03, 0x90, 0xd8, 0x67, 0xe8, 0x3e, 0x94, 0x03, 0x71,
+	0x89, 0xa6, 0xe4, 0x1f, 0x66, 0xde, 0x05, 0x1c, 0x0a, 0xa1, 0xff, 0x43, 0x45, 0x06, 0x11, 0x4f,
+	0xf7, 0xa5, 0x08, 0x23, 0xa6, 0xfe, 0x0c, 0x34, 0xa1, 0x2f, 0x93, 0x62, 0xfb, 0xce, 0x9c, 0x4d,
+	0x28, 0xc7, 0xe4, 0x9b, 0x05, 0x61, 0xfc, 0xe3, 0x82, 0xd1, 0x7f, 0x54, 0x60, 0xeb, 0x8a, 0x09,
+	0x11, 0xe2, 0x48, 0x66, 0x4d, 0x09, 0x87, 0xaa, 0x24, 0xc4, 0x07, 0x3b, 0x13, 0xc6, 0xc5, 0xf7,
+	0x6a, 0x18, 0x7b, 0x42, 0x7f, 0xb8, 0xf4, 0xe8, 0x29, 0xac, 0x05, 0xa1, 0x6b, 0xf2, 0x59, 0xd7,
+	0xf6, 0x9b, 0xd9, 0x14, 0xac, 0x0a, 0x01, 0xc7, 0x0a, 0xfa, 0x01, 0xec, 0x24, 0x42, 0xb2, 0x78,
+	0x2c, 0x8e, 0xf2, 0x93, 0xd2, 0xaa, 0xbf, 0x86, 0xcd, 0x25, 0x3b, 0x9f, 0x58, 0x97, 0x1d, 0xa8,
+	0xc8, 0x5c, 0x84, 0x75, 0xa9, 0xe3, 0x88, 0xda, 0x5f, 0x40, 0x49, 0x4c, 0x67, 0xd4, 0x82, 0x52,
+	0x67, 0xe2, 0x70, 0xb4, 0xb9, 0x34, 0x35, 0x77, 0x97, 0x01, 0xfd, 0xda, 0x9e, 0xf2, 0xb9, 0x82,
+	0xbe, 0x00, 0x34, 0x08, 0xa8, 0x4b, 0x18, 0xcb, 0xfe, 0x06, 0x5b, 0xf5, 0xa5, 0xb6, 0xab, 0x2e,
+	0xbf, 0x49, 0xfd, 0xda, 0x59, 0xf8, 0x63, 0xf0, 0xe1, 0x3f, 0x03, 0x00, 0x1a, 0xc3, 0x48, 0xca,
+	0x23, 0x0e, 0x00, 0x00,
 }
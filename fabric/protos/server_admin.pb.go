@@ -53,7 +53,8 @@ func (x ServerStatus_StatusCode) String() string {
 func (ServerStatus_StatusCode) EnumDescriptor() ([]byte, []int) { return fileDescriptor6, []int{0, 0} }
 
 type ServerStatus struct {
-	Status ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
+	Status           ServerStatus_StatusCode `protobuf:"varint,1,opt,name=status,enum=protos.ServerStatus_StatusCode" json:"status,omitempty"`
+	ConsensusMetrics []*ConsensusMetric      `protobuf:"bytes,2,rep,name=consensus_metrics,json=consensusMetrics" json:"consensus_metrics,omitempty"`
 }
 
 func (m *ServerStatus) Reset()                    { *m = ServerStatus{} }
@@ -61,8 +62,45 @@ func (m *ServerStatus) String() string            { return proto.CompactTextStri
 func (*ServerStatus) ProtoMessage()               {}
 func (*ServerStatus) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{0} }
 
+func (m *ServerStatus) GetConsensusMetrics() []*ConsensusMetric {
+	if m != nil {
+		return m.ConsensusMetrics
+	}
+	return nil
+}
+
+// The current value of a metric published by the consensus plugin
+type ConsensusMetric struct {
+	Name   string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Value  float64           `protobuf:"fixed64,3,opt,name=value" json:"value,omitempty"`
+}
+
+func (m *ConsensusMetric) Reset()                    { *m = ConsensusMetric{} }
+func (m *ConsensusMetric) String() string            { return proto.CompactTextString(m) }
+func (*ConsensusMetric) ProtoMessage()               {}
+func (*ConsensusMetric) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{1} }
+
+func (m *ConsensusMetric) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type ConsensusMembership struct {
+	Replicas []uint64 `protobuf:"varint,1,rep,packed,name=replicas" json:"replicas,omitempty"`
+}
+
+func (m *ConsensusMembership) Reset()                    { *m = ConsensusMembership{} }
+func (m *ConsensusMembership) String() string            { return proto.CompactTextString(m) }
+func (*ConsensusMembership) ProtoMessage()               {}
+func (*ConsensusMembership) Descriptor() ([]byte, []int) { return fileDescriptor6, []int{2} }
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
+	proto.RegisterType((*ConsensusMetric)(nil), "protos.ConsensusMetric")
+	proto.RegisterType((*ConsensusMembership)(nil), "protos.ConsensusMembership")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -81,6 +119,8 @@ type AdminClient interface {
 	GetStatus(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ServerStatus, error)
 	StartServer(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ServerStatus, error)
 	StopServer(ctx context.Context, in *google_protobuf1.Empty, opts ...grpc.CallOption) (*ServerStatus, error)
+	// Vote to change the validator set of the consensus plugin.
+	ReconfigureConsensus(ctx context.Context, in *ConsensusMembership, opts ...grpc.CallOption) (*ServerStatus, error)
 }
 
 type adminClient struct {
@@ -118,6 +158,15 @@ func (c *adminClient) StopServer(ctx context.Context, in *google_protobuf1.Empty
 	return out, nil
 }
 
+func (c *adminClient) ReconfigureConsensus(ctx context.Context, in *ConsensusMembership, opts ...grpc.CallOption) (*ServerStatus, error) {
+	out := new(ServerStatus)
+	err := grpc.Invoke(ctx, "/protos.Admin/ReconfigureConsensus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -125,6 +174,8 @@ type AdminServer interface {
 	GetStatus(context.Context, *google_protobuf1.Empty) (*ServerStatus, error)
 	StartServer(context.Context, *google_protobuf1.Empty) (*ServerStatus, error)
 	StopServer(context.Context, *google_protobuf1.Empty) (*ServerStatus, error)
+	// Vote to change the validator set of the consensus plugin.
+	ReconfigureConsensus(context.Context, *ConsensusMembership) (*ServerStatus, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -185,6 +236,24 @@ func _Admin_StopServer_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_ReconfigureConsensus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsensusMembership)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ReconfigureConsensus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/ReconfigureConsensus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ReconfigureConsensus(ctx, req.(*ConsensusMembership))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -201,6 +270,10 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StopServer",
 			Handler:    _Admin_StopServer_Handler,
 		},
+		{
+			MethodName: "ReconfigureConsensus",
+			Handler:    _Admin_ReconfigureConsensus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: fileDescriptor6,
@@ -209,21 +282,32 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("server_admin.proto", fileDescriptor6) }
 
 var fileDescriptor6 = []byte{
-	// 248 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xe2, 0x12, 0x2a, 0x4e, 0x2d, 0x2a,
-	0x4b, 0x2d, 0x8a, 0x4f, 0x4c, 0xc9, 0xcd, 0xcc, 0xd3, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
-	0x03, 0x53, 0xc5, 0x52, 0xd2, 0xe9, 0xf9, 0xf9, 0xe9, 0x39, 0xa9, 0xfa, 0x60, 0x6e, 0x52, 0x69,
-	0x9a, 0x7e, 0x6a, 0x6e, 0x41, 0x49, 0x25, 0x44, 0x91, 0xd2, 0x22, 0x46, 0x2e, 0x9e, 0x60, 0xb0,
-	0xde, 0xe0, 0x92, 0xc4, 0x92, 0xd2, 0x62, 0x21, 0x73, 0x2e, 0xb6, 0x62, 0x30, 0x4b, 0x82, 0x51,
-	0x81, 0x51, 0x83, 0xcf, 0x48, 0x1e, 0xa2, 0xb0, 0x58, 0x0f, 0x59, 0x95, 0x1e, 0x84, 0x72, 0xce,
-	0x4f, 0x49, 0x0d, 0x82, 0x2a, 0x57, 0x8a, 0xe4, 0xe2, 0x42, 0x88, 0x0a, 0xf1, 0x72, 0x71, 0x86,
-	0xfa, 0xb9, 0xb8, 0xba, 0x79, 0xfa, 0xb9, 0xba, 0x08, 0x30, 0x08, 0x71, 0x73, 0xb1, 0x07, 0x87,
-	0x38, 0x06, 0x85, 0x00, 0x39, 0x8c, 0x10, 0x8e, 0x7f, 0x40, 0x00, 0x90, 0xc3, 0x24, 0xc4, 0xc5,
-	0xc5, 0x16, 0xe0, 0x18, 0x1a, 0x0c, 0x64, 0x33, 0x0b, 0x71, 0x72, 0xb1, 0xba, 0x06, 0x05, 0xf9,
-	0x07, 0x09, 0xb0, 0x80, 0xd4, 0x84, 0xfa, 0x79, 0xfb, 0xf9, 0x87, 0xfb, 0x09, 0xb0, 0x1a, 0x1d,
-	0x64, 0xe4, 0x62, 0x75, 0x04, 0xf9, 0x4c, 0xc8, 0x9a, 0x8b, 0xd3, 0x3d, 0xb5, 0x04, 0xea, 0x54,
-	0x31, 0x3d, 0x88, 0xcf, 0xf4, 0x60, 0x3e, 0xd3, 0x73, 0x05, 0xf9, 0x4c, 0x4a, 0x04, 0x9b, 0x93,
-	0x95, 0x18, 0x84, 0x6c, 0xb9, 0xb8, 0x81, 0xec, 0xa2, 0x12, 0x88, 0x30, 0xc9, 0xda, 0x6d, 0x40,
-	0x1e, 0xcc, 0x2f, 0x20, 0x4f, 0x77, 0x12, 0x24, 0x36, 0x8c, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff,
-	0x5b, 0x2d, 0xff, 0xc2, 0xaa, 0x01, 0x00, 0x00,
+	// 429 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xae, 0xed, 0x24, 0x90, 0x09, 0x3f, 0x66, 0x88, 0x20, 0x4a, 0x0f, 0x44, 0xe6, 0x92, 0x93,
+	0x2b, 0xc2, 0x81, 0x9f, 0xc2, 0x21, 0xaa, 0x0d, 0x42, 0x05, 0x27, 0x5a, 0x37, 0x42, 0x9c, 0x2a,
+	0xc7, 0x9d, 0x06, 0x0b, 0xff, 0x69, 0x77, 0x5d, 0x29, 0x2f, 0xc1, 0xcb, 0xf0, 0x52, 0x3c, 0x06,
+	0xf2, 0xae, 0xdd, 0x06, 0xd4, 0x1e, 0xe8, 0x69, 0xe7, 0x1b, 0x7f, 0xdf, 0xec, 0x78, 0xbe, 0x59,
+	0x40, 0x41, 0xfc, 0x82, 0xf8, 0x69, 0x74, 0x96, 0x25, 0xb9, 0x5b, 0xf2, 0x42, 0x16, 0xd8, 0x53,
+	0x87, 0x18, 0xef, 0x6f, 0x8a, 0x62, 0x93, 0xd2, 0x81, 0x82, 0xeb, 0xea, 0xfc, 0x80, 0xb2, 0x52,
+	0x6e, 0x35, 0xc9, 0xf9, 0x6d, 0xc0, 0xbd, 0x50, 0x69, 0x43, 0x19, 0xc9, 0x4a, 0xe0, 0x2b, 0xe8,
+	0x09, 0x15, 0x8d, 0x8c, 0x89, 0x31, 0x7d, 0x30, 0x7b, 0xa6, 0x89, 0xc2, 0xdd, 0x65, 0xb9, 0xfa,
+	0x38, 0x2a, 0xce, 0x88, 0x35, 0x74, 0xf4, 0xe0, 0x51, 0x5c, 0xe4, 0x82, 0x72, 0x51, 0x89, 0xd3,
+	0x8c, 0x24, 0x4f, 0x62, 0x31, 0x32, 0x27, 0xd6, 0x74, 0x30, 0x7b, 0xda, 0xd6, 0x38, 0x6a, 0x09,
+	0x5f, 0xd4, 0x77, 0x66, 0xc7, 0x7f, 0x27, 0x84, 0xf3, 0x0d, 0xe0, 0xaa, 0x36, 0xde, 0x87, 0xfe,
+	0x2a, 0xf0, 0xfc, 0x0f, 0x9f, 0x02, 0xdf, 0xb3, 0xf7, 0x70, 0x00, 0x77, 0xc2, 0x93, 0x39, 0x3b,
+	0xf1, 0x3d, 0xdb, 0xd0, 0x60, 0xb1, 0x5c, 0xfa, 0x9e, 0x6d, 0x22, 0x40, 0x6f, 0x39, 0x5f, 0x85,
+	0xbe, 0x67, 0x5b, 0xd8, 0x87, 0xae, 0xcf, 0xd8, 0x82, 0xd9, 0x9d, 0x9a, 0xb3, 0x0a, 0x8e, 0x83,
+	0xc5, 0xd7, 0xc0, 0xee, 0x3a, 0xbf, 0x0c, 0x78, 0xf8, 0x4f, 0x03, 0x88, 0xd0, 0xc9, 0xa3, 0x8c,
+	0xd4, 0xbf, 0xf6, 0x99, 0x8a, 0xf1, 0x10, 0x7a, 0x69, 0xb4, 0xa6, 0xb4, 0xed, 0xfe, 0xf9, 0x0d,
+	0xdd, 0xbb, 0x9f, 0x15, 0xcb, 0xcf, 0x25, 0xdf, 0xb2, 0x46, 0x82, 0x43, 0xe8, 0x5e, 0x44, 0x69,
+	0x45, 0x23, 0x6b, 0x62, 0x4c, 0x0d, 0xa6, 0xc1, 0xf8, 0x0d, 0x0c, 0x76, 0xc8, 0x68, 0x83, 0xf5,
+	0x83, 0xb6, 0xcd, 0xa5, 0x75, 0x78, 0x25, 0x33, 0x55, 0x4e, 0x83, 0xb7, 0xe6, 0x6b, 0xc3, 0x79,
+	0x01, 0x8f, 0x77, 0xee, 0xcd, 0xd6, 0xc4, 0xc5, 0xf7, 0xa4, 0xc4, 0x31, 0xdc, 0xe5, 0x54, 0xa6,
+	0x49, 0x1c, 0xd5, 0x46, 0x59, 0xd3, 0x0e, 0xbb, 0xc4, 0xb3, 0x9f, 0x26, 0x74, 0xe7, 0xf5, 0x22,
+	0xe0, 0x21, 0xf4, 0x3f, 0x92, 0x6c, 0x9c, 0x7d, 0xe2, 0xea, 0x45, 0x70, 0xdb, 0x45, 0x70, 0xfd,
+	0x7a, 0x11, 0xc6, 0xc3, 0xeb, 0x1c, 0x76, 0xf6, 0xf0, 0x3d, 0x0c, 0x42, 0x19, 0x71, 0xa9, 0xd3,
+	0xff, 0x2d, 0x7f, 0x57, 0x3b, 0x59, 0x94, 0xb7, 0x54, 0x1f, 0xc3, 0x90, 0x51, 0x5c, 0xe4, 0xe7,
+	0xc9, 0xa6, 0xe2, 0x74, 0x39, 0x01, 0xdc, 0xbf, 0xc6, 0x8c, 0x76, 0x28, 0x37, 0x15, 0x5b, 0xeb,
+	0x97, 0xf0, 0xf2, 0xcf, 0x00, 0xb6, 0x0d, 0x79, 0xa9, 0x26, 0x03, 0x00, 0x00,
 }
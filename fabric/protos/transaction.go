@@ -91,6 +91,27 @@ func NewChaincodeDeployTransaction(chaincodeDeploymentSpec *ChaincodeDeploymentS
 	return transaction, nil
 }
 
+// NewChaincodeUpgradeTransaction is used to upgrade a deployed chaincode. The
+// chaincode ID of the transaction is the one of the upgraded chaincode.
+func NewChaincodeUpgradeTransaction(chaincodeUpgradeSpec *ChaincodeUpgradeSpec, uuid string) (*Transaction, error) {
+	transaction := new(Transaction)
+	transaction.Type = Transaction_CHAINCODE_UPGRADE
+	transaction.Txid = uuid
+	transaction.Timestamp = util.CreateUtcTimestamp()
+	data, err := proto.Marshal(&ChaincodeID{Name: chaincodeUpgradeSpec.ChaincodeName})
+	if err != nil {
+		return nil, fmt.Errorf("Could not marshal chaincode : %s", err)
+	}
+	transaction.ChaincodeID = data
+	data, err = proto.Marshal(chaincodeUpgradeSpec)
+	if err != nil {
+		logger.Errorf("Error mashalling payload for chaincode upgrade: %s", err)
+		return nil, fmt.Errorf("Could not marshal payload for chaincode upgrade: %s", err)
+	}
+	transaction.Payload = data
+	return transaction, nil
+}
+
 // NewChaincodeExecute is used to invoke chaincode.
 func NewChaincodeExecute(chaincodeInvocationSpec *ChaincodeInvocationSpec, uuid string, typ Transaction_Type) (*Transaction, error) {
 	transaction := new(Transaction)
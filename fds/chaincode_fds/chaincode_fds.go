@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
-	"strings"
+	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
@@ -32,6 +34,35 @@ type FdsFraudEntry struct {
 	LedgerStatusUpdateReason string `json:"ledgerStatusUpdateReason"`
 }
 
+// 위험도 점수 가중치 (fdsSetScoreWeights 로 변경)
+// entry 점수 = 일치하는 식별자(CID/MAC/UUID) 점수의 합 x 최신도 x 출처(ProducedBy) 가중치
+type FdsScoreWeights struct {
+	Cid               float64            `json:"cid"`               // CID 가 일치하는 entry 의 점수
+	Mac               float64            `json:"mac"`               // MAC 이 일치하는 entry 의 점수
+	Uuid              float64            `json:"uuid"`              // UUID 가 일치하는 entry 의 점수
+	HalfLifeDays      float64            `json:"halfLifeDays"`      // 이 기간(일)이 지날 때마다 entry 점수가 절반으로 감소
+	ProducedBy        map[string]float64 `json:"producedBy"`        // 출처별 가중치
+	DefaultProducedBy float64            `json:"defaultProducedBy"` // ProducedBy 에 없는 출처의 가중치
+	MaxScore          float64            `json:"maxScore"`          // 위험도 점수 상한
+}
+
+// (cid, mac, uuid) 의 위험도 점수
+type FdsRiskScore struct {
+	Cid        string  `json:"cid"`
+	Mac        string  `json:"mac"`
+	Uuid       string  `json:"uuid"`
+	AsOfDate   string  `json:"asOfDate"`
+	Score      float64 `json:"score"`
+	NumEntries int     `json:"numEntries"` // 식별자를 공유하는 블랙리스트 entry 수
+	Eids       []int   `json:"eids"`
+}
+
+// fraud entry 가 등록/수정/삭제될 때 발생하는 chaincode event 의 payload
+type FdsEvent struct {
+	TxId    string          `json:"txId"`
+	Entries []FdsFraudEntry `json:"entries"` // 등록/수정/삭제된 fraud entry
+}
+
 // registerFraudEntry 의 필드갯수
 const NUM_FIELDS = 8
 
@@ -50,14 +81,47 @@ const IND_LSUPDATEREASON = 10
 
 // key-value store 의 키 구분자
 const PREFIX_EID = "FDS_EID_"
+
+// 이전 버전의 "|" 구분 EID 목록 키 (fdsMigrateIndexes 에서만 사용)
 const PREFIX_CID = "FDS_CID_"
 const PREFIX_MAC = "FDS_MAC_"
 const PREFIX_UUID = "FDS_UUID_"
 
+// 보조 인덱스 이름: (인덱스, 값, EID) 마다 하나의 composite key 로 저장
+const INDEX_CID = "FDS_CID"
+const INDEX_MAC = "FDS_MAC"
+const INDEX_UUID = "FDS_UUID"
+const INDEX_FINALDATE = "FDS_FINALDATE"
+const INDEX_REGISTEREDBY = "FDS_REGISTEREDBY"
+
 const LS_BLACKLIST = 9
 const LS_WHITELIST = 1
 
+// chaincode event 이름
+const EVENT_ENTRY_CREATED = "FdsEntryCreated"
+const EVENT_LEDGER_STATUS_CHANGED = "FdsLedgerStatusChanged"
+const EVENT_ENTRY_DELETED = "FdsEntryDeleted"
+
+// 호출자의 트랜잭션 인증서(TCert) 속성
+const FDS_ATTR_ROLE = "role"   // 역할
+const FDS_ROLE_ADMIN = "admin" // 관리자 역할 (fdsSetScoreWeights)
+
 const FDS_NEXTEID_KEY = "FDS_NEXTEID"
+const FDS_SCORE_WEIGHTS_KEY = "FDS_SCORE_WEIGHTS"
+
+// FinalDate, FinalTime 형식 (railscode_fds 의 Date.today, Time.now.localtime)
+const FINALDATE_LAYOUT = "2006-01-02"
+const FINALTIME_LAYOUT = "2006-01-02 15:04:05 -0700"
+
+var defaultScoreWeights = FdsScoreWeights{
+	Cid:               40,
+	Mac:               30,
+	Uuid:              30,
+	HalfLifeDays:      90,
+	ProducedBy:        map[string]float64{},
+	DefaultProducedBy: 1,
+	MaxScore:          100,
+}
 
 const FIELDSEP = "|"
 const ENTRYSEP = "$"
@@ -66,21 +130,12 @@ const ENTRYSEP = "$"
 //  Helper 함수
 // ===========================================================
 
-func stringArrayToByteArray(slist []string) []byte {
-	return []byte(strings.Join(slist, FIELDSEP))
-}
-
-func byteArrayToStringArray(b []byte) []string {
-	if string(b) == "" {
-		return []string{}
-	}
-	return strings.Split(string(b), FIELDSEP)
-}
+// fraud entry 목록을 EID 순서로 정렬
+type fraudEntriesByEid []FdsFraudEntry
 
-func appendToEIDList(b []byte, eid string) []byte {
-	eidKeys := byteArrayToStringArray(b)
-	return stringArrayToByteArray(append(eidKeys, eid))
-}
+func (entries fraudEntriesByEid) Len() int           { return len(entries) }
+func (entries fraudEntriesByEid) Swap(i, j int)      { entries[i], entries[j] = entries[j], entries[i] }
+func (entries fraudEntriesByEid) Less(i, j int) bool { return entries[i].Eid < entries[j].Eid }
 
 func printFraudEntries(entries []FdsFraudEntry) {
 	fmt.Println("[")
@@ -95,15 +150,20 @@ func printFraudEntries(entries []FdsFraudEntry) {
 //  SimpleChaincode 함수
 // ===========================================================
 
-func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+// 단일 진입점 (Init(stub)/Invoke(stub)) 형태로 구현되어 있으므로, 현재 peer 에서는
+// shim.NewChaincodeAdapter 로 감싸서 실행합니다. 조회 함수도 Invoke 로 처리됩니다.
+
+func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface) ([]byte, error) {
+	_, args := stub.GetFunctionAndParameters()
 	if len(args) != 0 {
 		return nil, errors.New("Initializing requires 0 argument but given" + strconv.Itoa(len(args)))
 	}
 	t.fdsSetNextEid(stub, 1)
-	return nil, nil
+	return nil, t.fdsPutScoreWeights(stub, defaultScoreWeights)
 }
 
-func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface) ([]byte, error) {
+	function, args := stub.GetFunctionAndParameters()
 	switch function {
 	case "fdsCreateFraudEntry":
 		return t.fdsCreateFraudEntry(stub, args)
@@ -117,12 +177,12 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 		return t.fdsDeleteFraudEntryWithMac(stub, args)
 	case "fdsDeleteFraudEntryWithUuid":
 		return t.fdsDeleteFraudEntryWithUuid(stub, args)
-	}
-	return nil, errors.New("Invalid invoke function name")
-}
+	case "fdsMigrateIndexes":
+		return t.fdsMigrateIndexes(stub, args)
+	case "fdsSetScoreWeights":
+		return t.fdsSetScoreWeights(stub, args)
 
-func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
-	switch function {
+	// 조회
 	case "fdsGetAllFraudEntries":
 		return t.fdsGetAllFraudEntries(stub, args)
 	case "fdsGetFraudEntriesWithCid":
@@ -131,15 +191,23 @@ func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function strin
 		return t.fdsGetFraudEntriesWithMac(stub, args)
 	case "fdsGetFraudEntriesWithUuid":
 		return t.fdsGetFraudEntriesWithUuid(stub, args)
+	case "fdsGetFraudEntriesWithFinalDate":
+		return t.fdsGetFraudEntriesWithFinalDate(stub, args)
+	case "fdsGetFraudEntriesWithRegisteredBy":
+		return t.fdsGetFraudEntriesWithRegisteredBy(stub, args)
+	case "fdsGetRiskScore":
+		return t.fdsGetRiskScore(stub, args)
+	case "fdsGetScoreWeights":
+		return t.fdsGetScoreWeights(stub, args)
 	case "listkvs": // use with argument "eid"/"cid"/"mac"/"uuid"
 		return t.listkvs(stub, args)
 	}
-	return nil, errors.New("Invalid query function name")
+	return nil, errors.New("Invalid invoke function name")
 }
 
 func main() {
 	t := new(SimpleChaincode)
-	err := shim.Start(t)
+	err := shim.Start(shim.NewChaincodeAdapter(t))
 	if err != nil {
 		fmt.Printf("Error starting Simple chaincode: %s", err)
 	}
@@ -149,6 +217,15 @@ func main() {
 //   EID 조회/수정 함수
 // ===========================================================
 
+// 호출자의 트랜잭션 인증서(TCert)에 관리자 역할 속성이 있는지 확인합니다.
+func fdsVerifyAdmin(stub shim.ChaincodeStubInterface, action string) error {
+	ok, err := stub.VerifyAttribute(FDS_ATTR_ROLE, []byte(FDS_ROLE_ADMIN))
+	if err != nil || !ok {
+		return errors.New(action + " requires the " + FDS_ROLE_ADMIN + " role")
+	}
+	return nil
+}
+
 func (t *SimpleChaincode) fdsGetNextEid(stub shim.ChaincodeStubInterface) int {
 	nextEidInBytes, _ := stub.GetState(FDS_NEXTEID_KEY)
 	nextEidInInt, _ := strconv.Atoi(string(nextEidInBytes))
@@ -166,7 +243,6 @@ func (t *SimpleChaincode) fdsSetNextEid(stub shim.ChaincodeStubInterface, nextEi
 
 func (t *SimpleChaincode) fdsCreateFraudEntry(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	var entryInBytes []byte
-	var eidsInBytes []byte
 	var err error
 
 	if len(args) != NUM_FIELDS {
@@ -178,9 +254,6 @@ func (t *SimpleChaincode) fdsCreateFraudEntry(stub shim.ChaincodeStubInterface,
 	// 	t.fdsSetNextEid(stub, 1)
 	// }
 	eidKey := PREFIX_EID + strconv.Itoa(nextEid)
-	cidKey := PREFIX_CID + args[IND_CID]
-	macKey := PREFIX_MAC + args[IND_MAC]
-	uuidKey := PREFIX_UUID + args[IND_UUID]
 
 	entry := FdsFraudEntry{nextEid, args[0], args[1], args[2], args[3], args[4], args[5], args[6], args[7], LS_BLACKLIST, "", ""}
 	entryInBytes, err = json.Marshal(entry)
@@ -193,35 +266,13 @@ func (t *SimpleChaincode) fdsCreateFraudEntry(stub shim.ChaincodeStubInterface,
 		return nil, err
 	}
 
-	eidsInBytes, err = stub.GetState(cidKey)
-	if err != nil {
-		return nil, errors.New("Failed to get state for" + cidKey)
-	}
-	err = stub.PutState(cidKey, appendToEIDList(eidsInBytes, eidKey))
-	if err != nil {
-		return nil, err
-	}
-
-	eidsInBytes, err = stub.GetState(macKey)
-	if err != nil {
-		return nil, errors.New("Failed to get state for" + macKey)
-	}
-	err = stub.PutState(macKey, appendToEIDList(eidsInBytes, eidKey))
-	if err != nil {
-		return nil, err
-	}
-
-	eidsInBytes, err = stub.GetState(uuidKey)
-	if err != nil {
-		return nil, errors.New("Failed to get state for" + uuidKey)
-	}
-	err = stub.PutState(uuidKey, appendToEIDList(eidsInBytes, eidKey))
+	err = t.fdsPutIndexEntries(stub, entry)
 	if err != nil {
 		return nil, err
 	}
 
 	t.fdsSetNextEid(stub, nextEid+1)
-	return nil, nil
+	return nil, t.fdsSetEvent(stub, EVENT_ENTRY_CREATED, []FdsFraudEntry{entry})
 }
 
 // ===========================================================
@@ -268,114 +319,225 @@ func (t *SimpleChaincode) fdsUpdateLedgerStatusWithEid(stub shim.ChaincodeStubIn
 	if err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return nil, t.fdsSetEvent(stub, EVENT_LEDGER_STATUS_CHANGED, []FdsFraudEntry{entry})
 }
 
 // ===========================================================
-//  FdsFraudEntry 삭제 함수
+//  FdsFraudEntry 인덱스 함수
 // ===========================================================
 
-func (t *SimpleChaincode) fdsDeleteFraudEntryWithEid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var err error
+// fraud entry 의 CID/MAC/UUID/FinalDate/RegisteredBy 인덱스를 등록
+func (t *SimpleChaincode) fdsPutIndexEntries(stub shim.ChaincodeStubInterface, entry FdsFraudEntry) error {
+	eid := strconv.Itoa(entry.Eid)
+	for _, index := range fdsIndexedValues(entry) {
+		if err := shim.PutIndexEntry(stub, index.name, index.value, eid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	if len(args) != 1 {
-		return nil, errors.New("Removing with EID requires 1 argument but given" + strconv.Itoa(len(args)))
+// fraud entry 의 CID/MAC/UUID/FinalDate/RegisteredBy 인덱스를 삭제
+func (t *SimpleChaincode) fdsDelIndexEntries(stub shim.ChaincodeStubInterface, entry FdsFraudEntry) error {
+	eid := strconv.Itoa(entry.Eid)
+	for _, index := range fdsIndexedValues(entry) {
+		if err := shim.DelIndexEntry(stub, index.name, index.value, eid); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	eidKey := PREFIX_EID + args[0]
+type fdsIndexedValue struct {
+	name  string
+	value string
+}
 
-	err = stub.DelState(eidKey)
-	if err != nil {
-		return nil, errors.New("Failed to delete state for" + eidKey)
+// fraud entry 의 인덱스 이름과 값 (peer 마다 같은 순서로 기록되도록 map 대신 slice 사용)
+func fdsIndexedValues(entry FdsFraudEntry) []fdsIndexedValue {
+	return []fdsIndexedValue{
+		{INDEX_CID, entry.Cid},
+		{INDEX_MAC, entry.Mac},
+		{INDEX_UUID, entry.Uuid},
+		{INDEX_FINALDATE, entry.FinalDate},
+		{INDEX_REGISTEREDBY, entry.RegisteredBy},
 	}
-	return nil, nil
 }
 
-func (t *SimpleChaincode) fdsDeleteFraudEntryWithCid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var eidsInBytes []byte
-	var err error
+// EID 로 fraud entry 를 조회. 삭제된 entry 는 nil 을 리턴
+func (t *SimpleChaincode) fdsGetFraudEntry(stub shim.ChaincodeStubInterface, eid string) (*FdsFraudEntry, error) {
+	eidKey := PREFIX_EID + eid
 
-	if len(args) != 1 {
-		return nil, errors.New("Removing with CID requires 1 argument but given" + strconv.Itoa(len(args)))
+	entryInBytes, err := stub.GetState(eidKey)
+	if err != nil {
+		return nil, errors.New("Failed to get state for" + eidKey)
+	}
+	if entryInBytes == nil {
+		return nil, nil
 	}
 
-	cidKey := PREFIX_CID + args[0]
+	var entry FdsFraudEntry
+	err = json.Unmarshal(entryInBytes, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
 
-	eidsInBytes, err = stub.GetState(cidKey)
+// 인덱스에 등록된 fraud entry 목록을 EID 순서로 조회
+func (t *SimpleChaincode) fdsGetFraudEntriesWithIndex(stub shim.ChaincodeStubInterface, indexName string, value string) ([]FdsFraudEntry, error) {
+	eids, err := shim.GetIndexEntries(stub, indexName, value)
 	if err != nil {
-		return nil, errors.New("Failed to get state for" + cidKey)
+		return nil, errors.New("Failed to get index entries for " + indexName + " " + value)
 	}
+	return t.fdsGetFraudEntriesWithEids(stub, eids)
+}
 
-	eidKeys := byteArrayToStringArray(eidsInBytes)
-	for _, eidKey := range eidKeys {
-		err = stub.DelState(eidKey)
+// EID 목록의 fraud entry 를 EID 순서로 조회. 삭제된 entry 는 건너뜀
+func (t *SimpleChaincode) fdsGetFraudEntriesWithEids(stub shim.ChaincodeStubInterface, eids []string) ([]FdsFraudEntry, error) {
+	entries := []FdsFraudEntry{}
+	for _, eid := range eids {
+		entry, err := t.fdsGetFraudEntry(stub, eid)
 		if err != nil {
-			return nil, errors.New("Failed to delete state for" + eidKey)
+			return nil, err
 		}
+		if entry == nil {
+			continue
+		}
+		entries = append(entries, *entry)
 	}
-	err = stub.DelState(cidKey)
+	sort.Sort(fraudEntriesByEid(entries))
+	return entries, nil
+}
+
+// 인덱스에 등록된 fraud entry 를 모두 삭제
+func (t *SimpleChaincode) fdsDeleteFraudEntriesWithIndex(stub shim.ChaincodeStubInterface, indexName string, value string) error {
+	entries, err := t.fdsGetFraudEntriesWithIndex(stub, indexName, value)
 	if err != nil {
-		return nil, errors.New("Failed to delete state for" + cidKey)
+		return err
 	}
-	return nil, nil
+	for _, entry := range entries {
+		err = t.fdsDeleteFraudEntry(stub, entry)
+		if err != nil {
+			return err
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	return t.fdsSetEvent(stub, EVENT_ENTRY_DELETED, entries)
 }
 
-func (t *SimpleChaincode) fdsDeleteFraudEntryWithMac(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var eidsInBytes []byte
-	var err error
+// fraud entry 와 해당 인덱스를 삭제
+func (t *SimpleChaincode) fdsDeleteFraudEntry(stub shim.ChaincodeStubInterface, entry FdsFraudEntry) error {
+	eidKey := PREFIX_EID + strconv.Itoa(entry.Eid)
 
-	if len(args) != 1 {
-		return nil, errors.New("Removing with MAC requires 1 argument but given" + strconv.Itoa(len(args)))
+	err := stub.DelState(eidKey)
+	if err != nil {
+		return errors.New("Failed to delete state for" + eidKey)
 	}
+	return t.fdsDelIndexEntries(stub, entry)
+}
 
-	macKey := PREFIX_MAC + args[0]
-
-	eidsInBytes, err = stub.GetState(macKey)
+// chaincode event 를 설정. 트랜잭션마다 마지막으로 설정한 event 하나만 전달됨
+func (t *SimpleChaincode) fdsSetEvent(stub shim.ChaincodeStubInterface, name string, entries []FdsFraudEntry) error {
+	eventInBytes, err := json.Marshal(FdsEvent{stub.GetTxID(), entries})
 	if err != nil {
-		return nil, errors.New("Failed to get state for" + macKey)
+		return err
 	}
+	return stub.SetEvent(name, eventInBytes)
+}
 
-	eidKeys := byteArrayToStringArray(eidsInBytes)
-	for _, eidKey := range eidKeys {
-		err = stub.DelState(eidKey)
+// 이전 버전의 "|" 구분 EID 목록 키를 composite key 인덱스로 옮김
+// 등록된 fraud entry 로부터 인덱스를 다시 만들기 때문에 여러 번 호출해도 결과가 같음
+func (t *SimpleChaincode) fdsMigrateIndexes(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 0 {
+		return nil, errors.New("Migrating indexes requires 0 argument but given" + strconv.Itoa(len(args)))
+	}
+
+	nextEid := t.fdsGetNextEid(stub)
+	for i := 1; i < nextEid; i++ {
+		entry, err := t.fdsGetFraudEntry(stub, strconv.Itoa(i))
 		if err != nil {
-			return nil, errors.New("Failed to delete state for" + eidKey)
+			return nil, err
+		}
+		if entry == nil {
+			continue
+		}
+		err = t.fdsPutIndexEntries(stub, *entry)
+		if err != nil {
+			return nil, err
 		}
 	}
-	err = stub.DelState(macKey)
-	if err != nil {
-		return nil, errors.New("Failed to delete state for" + macKey)
+
+	for _, prefix := range []string{PREFIX_CID, PREFIX_MAC, PREFIX_UUID} {
+		iter, err := stub.RangeQueryState(prefix, prefix+"\U0010FFFF")
+		if err != nil {
+			return nil, errors.New("Failed to range query state for" + prefix)
+		}
+		legacyKeys := []string{}
+		for iter.HasNext() {
+			key, _, err := iter.Next()
+			if err != nil {
+				iter.Close()
+				return nil, err
+			}
+			legacyKeys = append(legacyKeys, key)
+		}
+		iter.Close()
+
+		for _, key := range legacyKeys {
+			err = stub.DelState(key)
+			if err != nil {
+				return nil, errors.New("Failed to delete state for" + key)
+			}
+		}
 	}
 	return nil, nil
 }
 
-func (t *SimpleChaincode) fdsDeleteFraudEntryWithUuid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var eidsInBytes []byte
-	var err error
+// ===========================================================
+//  FdsFraudEntry 삭제 함수
+// ===========================================================
 
+func (t *SimpleChaincode) fdsDeleteFraudEntryWithEid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	if len(args) != 1 {
-		return nil, errors.New("Removing with UUID requires 1 argument but given" + strconv.Itoa(len(args)))
+		return nil, errors.New("Removing with EID requires 1 argument but given" + strconv.Itoa(len(args)))
 	}
 
-	uuidKey := PREFIX_UUID + args[0]
-
-	eidsInBytes, err = stub.GetState(uuidKey)
+	entry, err := t.fdsGetFraudEntry(stub, args[0])
 	if err != nil {
-		return nil, errors.New("Failed to get state for" + uuidKey)
+		return nil, err
 	}
-
-	eidKeys := byteArrayToStringArray(eidsInBytes)
-	for _, eidKey := range eidKeys {
-		err = stub.DelState(eidKey)
-		if err != nil {
-			return nil, errors.New("Failed to delete state for" + eidKey)
-		}
+	if entry == nil {
+		return nil, nil
 	}
-	err = stub.DelState(uuidKey)
+	err = t.fdsDeleteFraudEntry(stub, *entry)
 	if err != nil {
-		return nil, errors.New("Failed to delete state for" + uuidKey)
+		return nil, err
 	}
-	return nil, nil
+	return nil, t.fdsSetEvent(stub, EVENT_ENTRY_DELETED, []FdsFraudEntry{*entry})
+}
+
+func (t *SimpleChaincode) fdsDeleteFraudEntryWithCid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Removing with CID requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	return nil, t.fdsDeleteFraudEntriesWithIndex(stub, INDEX_CID, args[0])
+}
+
+func (t *SimpleChaincode) fdsDeleteFraudEntryWithMac(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Removing with MAC requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	return nil, t.fdsDeleteFraudEntriesWithIndex(stub, INDEX_MAC, args[0])
+}
+
+func (t *SimpleChaincode) fdsDeleteFraudEntryWithUuid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Removing with UUID requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	return nil, t.fdsDeleteFraudEntriesWithIndex(stub, INDEX_UUID, args[0])
 }
 
 // ===========================================================
@@ -383,45 +545,62 @@ func (t *SimpleChaincode) fdsDeleteFraudEntryWithUuid(stub shim.ChaincodeStubInt
 // ===========================================================
 
 func (t *SimpleChaincode) fdsGetFraudEntriesWithCid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var eidsInBytes []byte
-	var entryInBytes []byte
-	var entriesInBytes []byte
-	var err error
-
 	if len(args) != 1 {
 		return nil, errors.New("Looking up with CID requires 1 argument but given" + strconv.Itoa(len(args)))
 	}
+	return t.fdsQueryFraudEntriesWithIndex(stub, INDEX_CID, args[0])
+}
 
-	cidKey := PREFIX_CID + args[0]
+func (t *SimpleChaincode) fdsGetFraudEntriesWithMac(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Looking up with MAC requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	return t.fdsQueryFraudEntriesWithIndex(stub, INDEX_MAC, args[0])
+}
 
-	eidsInBytes, err = stub.GetState(cidKey)
+func (t *SimpleChaincode) fdsGetFraudEntriesWithUuid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Looking up with UUID requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	return t.fdsQueryFraudEntriesWithIndex(stub, INDEX_UUID, args[0])
+}
+
+func (t *SimpleChaincode) fdsQueryFraudEntriesWithIndex(stub shim.ChaincodeStubInterface, indexName string, value string) ([]byte, error) {
+	entries, err := t.fdsGetFraudEntriesWithIndex(stub, indexName, value)
 	if err != nil {
-		jsonResp := "{\"Error\":\"Failed to get state for " + cidKey + "\"}"
-		return nil, errors.New(jsonResp)
+		return nil, err
 	}
-	//fmt.Println("EIDS looked up with", cidKey, ":", string(eidsInBytes))
 
-	eidKeys := byteArrayToStringArray(eidsInBytes)
-	entries := make([]FdsFraudEntry, len(eidKeys))
-	for i, eidKey := range eidKeys {
-		entryInBytes, err = stub.GetState(eidKey)
-		if err != nil {
-			return nil, errors.New("Failed to delete state for" + eidKey)
-		}
-		if entryInBytes == nil {
-			continue
-		}
-		//fmt.Println("ENTRY looked up with", eidKey, ":", string(entryInBytes))
+	entriesInBytes, err := json.Marshal(entries)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Query response:")
+	printFraudEntries(entries)
+	return entriesInBytes, nil
+}
 
-		var entry FdsFraudEntry
-		err = json.Unmarshal(entryInBytes, &entry)
-		if err != nil {
-			return nil, err
+// FinalDate 가 시작일과 종료일 사이(종료일 포함)인 fraud entry 를 조회
+func (t *SimpleChaincode) fdsGetFraudEntriesWithFinalDate(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, errors.New("Looking up with FinalDate requires 2 argument but given" + strconv.Itoa(len(args)))
+	}
+	for _, date := range args {
+		if _, err := time.Parse(FINALDATE_LAYOUT, date); err != nil {
+			return nil, errors.New("Invalid date " + date + ", expecting " + FINALDATE_LAYOUT)
 		}
-		entries[i] = entry
 	}
 
-	entriesInBytes, err = json.Marshal(entries)
+	eids, err := shim.GetIndexEntriesByRange(stub, INDEX_FINALDATE, args[0], args[1])
+	if err != nil {
+		return nil, errors.New("Failed to get index entries for " + INDEX_FINALDATE)
+	}
+	entries, err := t.fdsGetFraudEntriesWithEids(stub, eids)
+	if err != nil {
+		return nil, err
+	}
+
+	entriesInBytes, err := json.Marshal(entries)
 	if err != nil {
 		return nil, err
 	}
@@ -430,36 +609,35 @@ func (t *SimpleChaincode) fdsGetFraudEntriesWithCid(stub shim.ChaincodeStubInter
 	return entriesInBytes, nil
 }
 
-func (t *SimpleChaincode) fdsGetFraudEntriesWithMac(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var eidsInBytes []byte
+func (t *SimpleChaincode) fdsGetFraudEntriesWithRegisteredBy(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Looking up with RegisteredBy requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	return t.fdsQueryFraudEntriesWithIndex(stub, INDEX_REGISTEREDBY, args[0])
+}
+
+func (t *SimpleChaincode) fdsGetAllFraudEntries(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	var entryInBytes []byte
 	var entriesInBytes []byte
 	var err error
 
-	if len(args) != 1 {
-		return nil, errors.New("Looking up with MAC requires 1 argument but given" + strconv.Itoa(len(args)))
+	if len(args) != 0 {
+		return nil, errors.New("Looking up all entries requires 0 argument but given" + strconv.Itoa(len(args)))
 	}
 
-	macKey := PREFIX_MAC + args[0]
-
-	eidsInBytes, err = stub.GetState(macKey)
-	if err != nil {
-		jsonResp := "{\"Error\":\"Failed to get state for " + macKey + "\"}"
-		return nil, errors.New(jsonResp)
-	}
-	//fmt.Println("EIDS looked up with", macKey, ":", string(eidsInBytes))
+	nextEid := t.fdsGetNextEid(stub)
+	entries := make([]FdsFraudEntry, nextEid-1)
+	for i := 0; i < nextEid-1; i++ {
+		eidKey := PREFIX_EID + strconv.Itoa(i+1)
 
-	eidKeys := byteArrayToStringArray(eidsInBytes)
-	entries := make([]FdsFraudEntry, len(eidKeys))
-	for i, eidKey := range eidKeys {
 		entryInBytes, err = stub.GetState(eidKey)
+		//fmt.Println("ENTRY looked up with", eidKey, ":", string(entryInBytes))
 		if err != nil {
 			return nil, errors.New("Failed to delete state for" + eidKey)
 		}
 		if entryInBytes == nil {
 			continue
 		}
-		//fmt.Println("ENTRY looked up with", eidKey, ":", string(entryInBytes))
 
 		var entry FdsFraudEntry
 		err = json.Unmarshal(entryInBytes, &entry)
@@ -478,92 +656,171 @@ func (t *SimpleChaincode) fdsGetFraudEntriesWithMac(stub shim.ChaincodeStubInter
 	return entriesInBytes, nil
 }
 
-func (t *SimpleChaincode) fdsGetFraudEntriesWithUuid(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var eidsInBytes []byte
-	var entryInBytes []byte
-	var entriesInBytes []byte
-	var err error
+// ===========================================================
+//   위험도 점수 함수
+// ===========================================================
 
-	if len(args) != 1 {
-		return nil, errors.New("Looking up with UUID requires 1 argument but given" + strconv.Itoa(len(args)))
+func (t *SimpleChaincode) fdsGetScoreWeightsFromState(stub shim.ChaincodeStubInterface) (FdsScoreWeights, error) {
+	weightsInBytes, err := stub.GetState(FDS_SCORE_WEIGHTS_KEY)
+	if err != nil {
+		return FdsScoreWeights{}, errors.New("Failed to get state for" + FDS_SCORE_WEIGHTS_KEY)
+	}
+	if weightsInBytes == nil { // 가중치가 등록되기 전에 배포된 ledger
+		return defaultScoreWeights, nil
 	}
 
-	uuidKey := PREFIX_UUID + args[0]
-
-	eidsInBytes, err = stub.GetState(uuidKey)
+	var weights FdsScoreWeights
+	err = json.Unmarshal(weightsInBytes, &weights)
 	if err != nil {
-		jsonResp := "{\"Error\":\"Failed to get state for " + uuidKey + "\"}"
-		return nil, errors.New(jsonResp)
+		return FdsScoreWeights{}, err
 	}
-	//fmt.Println("EIDS looked up with", uuidKey, ":", string(eidsInBytes))
+	return weights, nil
+}
 
-	eidKeys := byteArrayToStringArray(eidsInBytes)
-	entries := make([]FdsFraudEntry, len(eidKeys))
-	for i, eidKey := range eidKeys {
-		entryInBytes, err = stub.GetState(eidKey)
-		if err != nil {
-			return nil, errors.New("Failed to delete state for" + eidKey)
-		}
-		if entryInBytes == nil {
-			continue
+func (t *SimpleChaincode) fdsPutScoreWeights(stub shim.ChaincodeStubInterface, weights FdsScoreWeights) error {
+	if weights.Cid < 0 || weights.Mac < 0 || weights.Uuid < 0 || weights.DefaultProducedBy < 0 || weights.MaxScore < 0 {
+		return errors.New("Score weights must not be negative")
+	}
+	if weights.HalfLifeDays <= 0 {
+		return errors.New("Score weights require a positive halfLifeDays")
+	}
+	for producedBy, weight := range weights.ProducedBy {
+		if weight < 0 {
+			return errors.New("Score weight for " + producedBy + " must not be negative")
 		}
-		//fmt.Println("ENTRY looked up with", eidKey, ":", string(entryInBytes))
+	}
 
-		var entry FdsFraudEntry
-		err = json.Unmarshal(entryInBytes, &entry)
-		if err != nil {
-			return nil, err
-		}
-		entries[i] = entry
+	weightsInBytes, err := json.Marshal(weights)
+	if err != nil {
+		return err
 	}
+	return stub.PutState(FDS_SCORE_WEIGHTS_KEY, weightsInBytes)
+}
 
-	entriesInBytes, err = json.Marshal(entries)
+// 위험도 점수 가중치를 변경 (가중치 JSON 1개)
+// 호출자의 TCert 에 관리자 역할 속성이 있어야 합니다.
+func (t *SimpleChaincode) fdsSetScoreWeights(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Setting score weights requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
+	err := fdsVerifyAdmin(stub, "fdsSetScoreWeights")
 	if err != nil {
 		return nil, err
 	}
-	fmt.Println("Query response:")
-	printFraudEntries(entries)
-	return entriesInBytes, nil
-}
 
-func (t *SimpleChaincode) fdsGetAllFraudEntries(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var entryInBytes []byte
-	var entriesInBytes []byte
-	var err error
+	var weights FdsScoreWeights
+	err = json.Unmarshal([]byte(args[0]), &weights)
+	if err != nil {
+		return nil, errors.New("Failed to unmarshal score weights " + args[0])
+	}
+	if weights.ProducedBy == nil {
+		weights.ProducedBy = map[string]float64{}
+	}
+	return nil, t.fdsPutScoreWeights(stub, weights)
+}
 
+func (t *SimpleChaincode) fdsGetScoreWeights(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	if len(args) != 0 {
-		return nil, errors.New("Looking up all entries requires 0 argument but given" + strconv.Itoa(len(args)))
+		return nil, errors.New("Looking up score weights requires 0 argument but given" + strconv.Itoa(len(args)))
 	}
 
-	nextEid := t.fdsGetNextEid(stub)
-	entries := make([]FdsFraudEntry, nextEid-1)
-	for i := 0; i < nextEid-1; i++ {
-		eidKey := PREFIX_EID + strconv.Itoa(i+1)
+	weights, err := t.fdsGetScoreWeightsFromState(stub)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(weights)
+}
 
-		entryInBytes, err = stub.GetState(eidKey)
-		//fmt.Println("ENTRY looked up with", eidKey, ":", string(entryInBytes))
-		if err != nil {
-			return nil, errors.New("Failed to delete state for" + eidKey)
-		}
-		if entryInBytes == nil {
+// (cid, mac, uuid) 와 식별자를 하나라도 공유하는 블랙리스트 entry 로 위험도 점수를 계산
+// args: cid, mac, uuid, 기준일자 (FINALDATE_LAYOUT, 최신도 계산에 사용)
+func (t *SimpleChaincode) fdsGetRiskScore(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 4 {
+		return nil, errors.New("Scoring requires 4 argument but given" + strconv.Itoa(len(args)))
+	}
+	asOf, err := time.Parse(FINALDATE_LAYOUT, args[3])
+	if err != nil {
+		return nil, errors.New("Invalid date " + args[3] + ", expecting " + FINALDATE_LAYOUT)
+	}
+
+	weights, err := t.fdsGetScoreWeightsFromState(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	// 식별자별로 일치하는 entry 를 모음
+	matched := map[int][]string{}
+	entriesByEid := map[int]FdsFraudEntry{}
+	for _, lookup := range []struct{ indexName, value string }{
+		{INDEX_CID, args[0]},
+		{INDEX_MAC, args[1]},
+		{INDEX_UUID, args[2]},
+	} {
+		if lookup.value == "" {
 			continue
 		}
-
-		var entry FdsFraudEntry
-		err = json.Unmarshal(entryInBytes, &entry)
+		entries, err := t.fdsGetFraudEntriesWithIndex(stub, lookup.indexName, lookup.value)
 		if err != nil {
 			return nil, err
 		}
-		entries[i] = entry
+		for _, entry := range entries {
+			if entry.LedgerStatus != LS_BLACKLIST {
+				continue
+			}
+			entriesByEid[entry.Eid] = entry
+			matched[entry.Eid] = append(matched[entry.Eid], lookup.indexName)
+		}
 	}
 
-	entriesInBytes, err = json.Marshal(entries)
-	if err != nil {
-		return nil, err
+	riskScore := FdsRiskScore{Cid: args[0], Mac: args[1], Uuid: args[2], AsOfDate: args[3], Eids: []int{}}
+	for eid := range entriesByEid {
+		riskScore.Eids = append(riskScore.Eids, eid)
 	}
-	fmt.Println("Query response:")
-	printFraudEntries(entries)
-	return entriesInBytes, nil
+	sort.Ints(riskScore.Eids)
+	for _, eid := range riskScore.Eids {
+		riskScore.Score += fdsEntryScore(weights, entriesByEid[eid], matched[eid], asOf)
+	}
+	riskScore.NumEntries = len(riskScore.Eids)
+	riskScore.Score = math.Min(riskScore.Score, weights.MaxScore)
+
+	return json.Marshal(riskScore)
+}
+
+// entry 하나의 점수 = 일치하는 식별자 점수의 합 x 최신도 x 출처 가중치
+func fdsEntryScore(weights FdsScoreWeights, entry FdsFraudEntry, matchedIndexes []string, asOf time.Time) float64 {
+	score := 0.0
+	for _, indexName := range matchedIndexes {
+		switch indexName {
+		case INDEX_CID:
+			score += weights.Cid
+		case INDEX_MAC:
+			score += weights.Mac
+		case INDEX_UUID:
+			score += weights.Uuid
+		}
+	}
+
+	// 최신도: HalfLifeDays 마다 절반. 날짜를 알 수 없거나 기준일 이후의 entry 는 감소 없음
+	if registered, ok := fdsEntryTime(entry); ok && registered.Before(asOf) {
+		ageInDays := asOf.Sub(registered).Hours() / 24
+		score *= math.Pow(0.5, ageInDays/weights.HalfLifeDays)
+	}
+
+	sourceWeight, ok := weights.ProducedBy[entry.ProducedBy]
+	if !ok {
+		sourceWeight = weights.DefaultProducedBy
+	}
+	return score * sourceWeight
+}
+
+// entry 의 등록 시각. FinalTime 을 우선 사용하고, 없으면 FinalDate 를 사용
+func fdsEntryTime(entry FdsFraudEntry) (time.Time, bool) {
+	if finalTime, err := time.Parse(FINALTIME_LAYOUT, entry.FinalTime); err == nil {
+		return finalTime, true
+	}
+	if finalDate, err := time.Parse(FINALDATE_LAYOUT, entry.FinalDate); err == nil {
+		return finalDate, true
+	}
+	return time.Time{}, false
 }
 
 // ===========================================================
@@ -571,24 +828,34 @@ func (t *SimpleChaincode) fdsGetAllFraudEntries(stub shim.ChaincodeStubInterface
 // ===========================================================
 
 func (t *SimpleChaincode) listkvs(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var start, end string
+	var iter shim.StateRangeQueryIteratorInterface
+	var err error
+
+	if len(args) != 1 {
+		return nil, errors.New("Listing key-values requires 1 argument but given" + strconv.Itoa(len(args)))
+	}
 
 	switch args[0] {
 	case "eid":
-		start, end = PREFIX_EID, "FDS_F"
+		iter, err = stub.RangeQueryState(PREFIX_EID, "FDS_F")
 	case "cid":
-		start, end = PREFIX_CID, "FDS_D"
+		iter, err = shim.GetStateByPartialCompositeKey(stub, INDEX_CID, []string{})
 	case "mac":
-		start, end = PREFIX_MAC, "FDS_N"
+		iter, err = shim.GetStateByPartialCompositeKey(stub, INDEX_MAC, []string{})
 	case "uuid":
-		start, end = PREFIX_UUID, "FDS_V"
+		iter, err = shim.GetStateByPartialCompositeKey(stub, INDEX_UUID, []string{})
+	default:
+		return nil, errors.New("Listing key-values requires one of eid, cid, mac and uuid but given " + args[0])
+	}
+	if err != nil {
+		return nil, err
 	}
+	defer iter.Close()
 
-	iter, _ := stub.RangeQueryState(start, end)
 	fmt.Println("START OF ITERATION")
 	for iter.HasNext() {
 		key, value, _ := iter.Next()
-		fmt.Println("\t" + key + "\t:\t" + string(value))
+		fmt.Printf("\t%q\t:\t%s\n", key, string(value))
 	}
 	fmt.Println("END OF ITERATION")
 	return nil, nil
@@ -5,6 +5,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"testing"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
@@ -58,16 +59,42 @@ func checkInvoke(t *testing.T, stub *shim.MockStub, fnName string, args []string
 	}
 }
 
+func checkIndex(t *testing.T, stub *shim.MockStub, indexName string, value string, eids []string) {
+	indexed, err := shim.GetIndexEntries(stub, indexName, value)
+	if err != nil {
+		fmt.Println("Index", indexName, value, "failed", err)
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(indexed, eids) {
+		fmt.Printf("Index [%v %v] : %v did not match the expected value %v.\n", indexName, value, indexed, eids)
+		t.FailNow()
+	}
+}
+
+func checkEvent(t *testing.T, stub *shim.MockStub, name string, entries []FdsFraudEntry) {
+	if stub.ChaincodeEvent == nil {
+		fmt.Println("Event", name, "was not set")
+		t.FailNow()
+	}
+	eventInBytes, _ := json.Marshal(FdsEvent{"1", entries}) // checkInvoke 의 TxID
+	if stub.ChaincodeEvent.EventName != name || string(stub.ChaincodeEvent.Payload) != string(eventInBytes) {
+		fmt.Printf("Event %v(%v) did not match the expected event %v(%v).\n", stub.ChaincodeEvent.EventName, string(stub.ChaincodeEvent.Payload), name, string(eventInBytes))
+		t.FailNow()
+	}
+}
+
 // ===========================================================
 //   Test Init
 // ===========================================================
 
 func TestChaincodeFds_Init(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	checkInit(t, stub, []string{})
 	checkState(t, stub, FDS_NEXTEID_KEY, "1")
+	weightsInBytes, _ := json.Marshal(defaultScoreWeights)
+	checkState(t, stub, FDS_SCORE_WEIGHTS_KEY, string(weightsInBytes))
 }
 
 // ===========================================================
@@ -77,17 +104,20 @@ func TestChaincodeFds_Init(t *testing.T) {
 /*
  * Get Fraud Entry with CID/MAC/UUID (cid: "cid", mac: "mac", uuid: "uuid")
  *
- *    Key            |   Value
- *  -----------------+---------------------------------------------------------------------------------------------------------------------------------
- *   [FDS_CID_cid]   |   "FDS_EID_1|FDS_EID_2"
- *   [FDS_MAC_mac]   |   "FDS_EID_1|FDS_EID_2"
- *   [FDS_UUID_uuid] |   "FDS_EID_1|FDS_EID_2"
- *   [FDS_EID_1]     |   {1 "cid" "mac" "uuid" "finaldate1" "finaltime1" "fdsproducedby1" "fdsregisteredby1" "fdsreason1" 9 "" ""} (in json string)
- *   [FDS_EID_2]     |   {2 "cid" "mac" "uuid" "finaldate2" "finaltime2" "fdsproducedby2" "fdsregisteredby2" "fdsreason2" 9 "" ""} (in json string)
+ *    Key                 |   Value
+ *  ----------------------+----------------------------------------------------------------------------------------------------------------------------
+ *   [FDS_CID cid 1]      |   (index entry)
+ *   [FDS_CID cid 2]      |   (index entry)
+ *   [FDS_MAC mac 1]      |   (index entry)
+ *   [FDS_MAC mac 2]      |   (index entry)
+ *   [FDS_UUID uuid 1]    |   (index entry)
+ *   [FDS_UUID uuid 2]    |   (index entry)
+ *   [FDS_EID_1]          |   {1 "cid" "mac" "uuid" "finaldate1" "finaltime1" "fdsproducedby1" "fdsregisteredby1" "fdsreason1" 9 "" ""} (in json string)
+ *   [FDS_EID_2]          |   {2 "cid" "mac" "uuid" "finaldate2" "finaltime2" "fdsproducedby2" "fdsregisteredby2" "fdsreason2" 9 "" ""} (in json string)
  */
 func TestChaincodeFds_Query_fdsGetFraudEntriesWith(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry1 := FdsFraudEntry{1, "cid", "mac", "uuid", "finaldate1", "finaltime1", "fdsproducedby1", "fdsregisteredby1", "fdsreason1", LS_BLACKLIST, "", ""}
 	entry2 := FdsFraudEntry{2, "cid", "mac", "uuid", "finaldate2", "finaltime2", "fdsproducedby2", "fdsregisteredby2", "fdsreason2", LS_BLACKLIST, "", ""}
@@ -105,9 +135,9 @@ func TestChaincodeFds_Query_fdsGetFraudEntriesWith(t *testing.T) {
 	checkQuery(t, stub, "fdsGetFraudEntriesWithMac", []string{"mac"}, string(entriesInBytes))
 	checkQuery(t, stub, "fdsGetFraudEntriesWithUuid", []string{"uuid"}, string(entriesInBytes))
 
-	checkState(t, stub, "FDS_CID_cid", "FDS_EID_1|FDS_EID_2")
-	checkState(t, stub, "FDS_MAC_mac", "FDS_EID_1|FDS_EID_2")
-	checkState(t, stub, "FDS_UUID_uuid", "FDS_EID_1|FDS_EID_2")
+	checkIndex(t, stub, INDEX_CID, "cid", []string{"1", "2"})
+	checkIndex(t, stub, INDEX_MAC, "mac", []string{"1", "2"})
+	checkIndex(t, stub, INDEX_UUID, "uuid", []string{"1", "2"})
 	checkState(t, stub, "FDS_EID_1", string(entry1InBytes))
 	checkState(t, stub, "FDS_EID_2", string(entry2InBytes))
 }
@@ -115,24 +145,24 @@ func TestChaincodeFds_Query_fdsGetFraudEntriesWith(t *testing.T) {
 /*
  * Get All Fraud Entries
  *
- *    Key             |   Value
- *  ------------------+-----------------------------------------------------------------------------------------------------------------------------------
- *   [FDS_CID_cid1]   |   "FDS_EID_1"
- *   [FDS_CID_cid2]   |   "FDS_EID_2"
- *   [FDS_CID_cid3]   |   "FDS_EID_3"
- *   [FDS_MAC_mac1]   |   "FDS_EID_1"
- *   [FDS_MAC_mac2]   |   "FDS_EID_2"
- *   [FDS_MAC_mac3]   |   "FDS_EID_3"
- *   [FDS_UUID_uuid1] |   "FDS_EID_1"
- *   [FDS_UUID_uuid2] |   "FDS_EID_2"
- *   [FDS_UUID_uuid3] |   "FDS_EID_3"
- *   [FDS_EID_1]      |   {1 "cid1" "mac1" "uuid1" "finaldate1" "finaltime1" "fdsproducedby1" "fdsregisteredby1" "fdsreason1" 9 "" ""} (in json string)
- *   [FDS_EID_2]      |   {2 "cid2" "mac2" "uuid2" "finaldate2" "finaltime2" "fdsproducedby2" "fdsregisteredby2" "fdsreason2" 9 "" ""} (in json string)
- *   [FDS_EID_3]      |   {3 "cid3" "mac3" "uuid3" "finaldate3" "finaltime3" "fdsproducedby3" "fdsregisteredby3" "fdsreason3" 9 "" ""} (in json string)
+ *    Key                 |   Value
+ *  ----------------------+-------------------------------------------------------------------------------------------------------------------------------
+ *   [FDS_CID cid1 1]     |   (index entry)
+ *   [FDS_CID cid2 2]     |   (index entry)
+ *   [FDS_CID cid3 3]     |   (index entry)
+ *   [FDS_MAC mac1 1]     |   (index entry)
+ *   [FDS_MAC mac2 2]     |   (index entry)
+ *   [FDS_MAC mac3 3]     |   (index entry)
+ *   [FDS_UUID uuid1 1]   |   (index entry)
+ *   [FDS_UUID uuid2 2]   |   (index entry)
+ *   [FDS_UUID uuid3 3]   |   (index entry)
+ *   [FDS_EID_1]          |   {1 "cid1" "mac1" "uuid1" "finaldate1" "finaltime1" "fdsproducedby1" "fdsregisteredby1" "fdsreason1" 9 "" ""} (in json string)
+ *   [FDS_EID_2]          |   {2 "cid2" "mac2" "uuid2" "finaldate2" "finaltime2" "fdsproducedby2" "fdsregisteredby2" "fdsreason2" 9 "" ""} (in json string)
+ *   [FDS_EID_3]          |   {3 "cid3" "mac3" "uuid3" "finaldate3" "finaltime3" "fdsproducedby3" "fdsregisteredby3" "fdsreason3" 9 "" ""} (in json string)
  */
 func TestChaincodeFds_Query_fdsGetAllFraudEntries(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry1 := FdsFraudEntry{1, "cid1", "mac1", "uuid1", "finaldate1", "finaltime1", "fdsproducedby1", "fdsregisteredby1", "fdsreason1", LS_BLACKLIST, "", ""}
 	entry2 := FdsFraudEntry{2, "cid2", "mac2", "uuid2", "finaldate2", "finaltime2", "fdsproducedby2", "fdsregisteredby2", "fdsreason2", LS_BLACKLIST, "", ""}
@@ -151,6 +181,13 @@ func TestChaincodeFds_Query_fdsGetAllFraudEntries(t *testing.T) {
 
 	checkQuery(t, stub, "fdsGetAllFraudEntries", []string{}, string(entriesInBytes))
 
+	// 단일 진입점 API 에서는 조회도 Invoke 로 처리됨
+	bytes, err := stub.MockInvoke("4", "fdsGetAllFraudEntries", []string{})
+	if err != nil || string(bytes) != string(entriesInBytes) {
+		fmt.Printf("Invoke value fdsGetAllFraudEntries = %v (%v) did not match the expected value %v.\n", string(bytes), err, string(entriesInBytes))
+		t.FailNow()
+	}
+
 	checkState(t, stub, "FDS_EID_1", string(entry1InBytes))
 	checkState(t, stub, "FDS_EID_2", string(entry2InBytes))
 	checkState(t, stub, "FDS_EID_3", string(entry3InBytes))
@@ -179,7 +216,7 @@ func TestChaincodeFds_Query_fdsGetAllFraudEntries(t *testing.T) {
  */
 func TestChaincodeFds_Invoke_fdsUpdateLedgerStatusWithEid(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry := FdsFraudEntry{1, "cid", "mac", "uuid", "finaldate", "finaltime", "fdsproducedby", "fdsregisteredby", "fdsreason", LS_WHITELIST, "ledgerstatusupdatetime", "ledgerstatusupdatereason"}
 	entryInBytes, _ := json.Marshal(entry)
@@ -205,7 +242,7 @@ func TestChaincodeFds_Invoke_fdsUpdateLedgerStatusWithEid(t *testing.T) {
  */
 func TestChaincodeFds_Invoke_fdsDeleteFraudEntryWithEid(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry2 := FdsFraudEntry{2, "cid2", "mac2", "uuid2", "finaldate2", "finaltime2", "fdsproducedby2", "fdsregisteredby2", "fdsreason2", LS_BLACKLIST, "", ""}
 	entries := []FdsFraudEntry{FdsFraudEntry{}, entry2}
@@ -230,7 +267,7 @@ func TestChaincodeFds_Invoke_fdsDeleteFraudEntryWithEid(t *testing.T) {
  */
 func TestChaincodeFds_Invoke_DeleteFruadEntryWithCid(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry3 := FdsFraudEntry{3, "cid3", "mac3", "uuid3", "finaldate3", "finaltime3", "fdsproducedby3", "fdsregisteredby3", "fdsreason3", LS_BLACKLIST, "", ""}
 	entries := []FdsFraudEntry{FdsFraudEntry{}, FdsFraudEntry{}, entry3}
@@ -243,6 +280,10 @@ func TestChaincodeFds_Invoke_DeleteFruadEntryWithCid(t *testing.T) {
 	checkInvoke(t, stub, "fdsDeleteFraudEntryWithCid", []string{"cid"})
 
 	checkQuery(t, stub, "fdsGetAllFraudEntries", []string{}, string(entriesInBytes))
+	checkIndex(t, stub, INDEX_CID, "cid", []string{})
+	checkIndex(t, stub, INDEX_MAC, "mac1", []string{})
+	checkIndex(t, stub, INDEX_UUID, "uuid2", []string{})
+	checkIndex(t, stub, INDEX_CID, "cid3", []string{"3"})
 }
 
 /*
@@ -256,7 +297,7 @@ func TestChaincodeFds_Invoke_DeleteFruadEntryWithCid(t *testing.T) {
  */
 func TestChaincodeFds_Invoke_DeleteFruadEntryWithMac(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry3 := FdsFraudEntry{3, "cid3", "mac3", "uuid3", "finaldate3", "finaltime3", "fdsproducedby3", "fdsregisteredby3", "fdsreason3", LS_BLACKLIST, "", ""}
 	entries := []FdsFraudEntry{FdsFraudEntry{}, FdsFraudEntry{}, entry3}
@@ -282,7 +323,7 @@ func TestChaincodeFds_Invoke_DeleteFruadEntryWithMac(t *testing.T) {
  */
 func TestChaincodeFds_Invoke_DeleteFruadEntryWithUuid(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("fds_chaincode", scc)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
 
 	entry3 := FdsFraudEntry{3, "cid3", "mac3", "uuid3", "finaldate3", "finaltime3", "fdsproducedby3", "fdsregisteredby3", "fdsreason3", LS_BLACKLIST, "", ""}
 	entries := []FdsFraudEntry{FdsFraudEntry{}, FdsFraudEntry{}, entry3}
@@ -296,3 +337,298 @@ func TestChaincodeFds_Invoke_DeleteFruadEntryWithUuid(t *testing.T) {
 
 	checkQuery(t, stub, "fdsGetAllFraudEntries", []string{}, string(entriesInBytes))
 }
+
+// ===========================================================
+//  Test Invoke: 인덱스 마이그레이션
+// ===========================================================
+
+/*
+ * Migrate "|"-joined EID lists to composite key indexes
+ *
+ *    Key               |   <Before>                  |   <After>
+ *  --------------------+-----------------------------+----------------------
+ *   [FDS_CID_cid]      |   "FDS_EID_1|FDS_EID_10"    |   [deleted]
+ *   [FDS_MAC_mac]      |   "FDS_EID_1|FDS_EID_10"    |   [deleted]
+ *   [FDS_UUID_uuid]    |   "FDS_EID_1|FDS_EID_10"    |   [deleted]
+ *   [FDS_CID cid 1]    |                             |   (index entry)
+ *   [FDS_CID cid 10]   |                             |   (index entry)
+ */
+func TestChaincodeFds_Invoke_fdsMigrateIndexes(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	entry1 := FdsFraudEntry{1, "cid", "mac", "uuid", "finaldate1", "finaltime1", "fdsproducedby1", "fdsregisteredby1", "fdsreason1", LS_BLACKLIST, "", ""}
+	entry10 := FdsFraudEntry{10, "cid", "mac", "uuid", "finaldate10", "finaltime10", "fdsproducedby10", "fdsregisteredby10", "fdsreason10", LS_BLACKLIST, "", ""}
+	entry1InBytes, _ := json.Marshal(entry1)
+	entry10InBytes, _ := json.Marshal(entry10)
+	entriesInBytes, _ := json.Marshal([]FdsFraudEntry{entry1, entry10})
+
+	checkInit(t, stub, []string{})
+
+	// 이전 버전의 ledger
+	stub.MockTransactionStart("legacy")
+	stub.PutState("FDS_EID_1", entry1InBytes)
+	stub.PutState("FDS_EID_10", entry10InBytes)
+	stub.PutState("FDS_CID_cid", []byte("FDS_EID_1|FDS_EID_10"))
+	stub.PutState("FDS_MAC_mac", []byte("FDS_EID_1|FDS_EID_10"))
+	stub.PutState("FDS_UUID_uuid", []byte("FDS_EID_1|FDS_EID_10"))
+	stub.PutState(FDS_NEXTEID_KEY, []byte("11"))
+	stub.MockTransactionEnd("legacy")
+
+	checkInvoke(t, stub, "fdsMigrateIndexes", []string{})
+	checkInvoke(t, stub, "fdsMigrateIndexes", []string{})
+
+	for _, key := range []string{"FDS_CID_cid", "FDS_MAC_mac", "FDS_UUID_uuid"} {
+		if stub.State[key] != nil {
+			fmt.Println("State", key, "was not deleted by fdsMigrateIndexes")
+			t.FailNow()
+		}
+	}
+	checkIndex(t, stub, INDEX_CID, "cid", []string{"1", "10"})
+	checkIndex(t, stub, INDEX_MAC, "mac", []string{"1", "10"})
+	checkIndex(t, stub, INDEX_UUID, "uuid", []string{"1", "10"})
+	checkQuery(t, stub, "fdsGetFraudEntriesWithCid", []string{"cid"}, string(entriesInBytes))
+}
+
+// ===========================================================
+//   Test Query: 기간/등록기관별 조회 및 위험도 점수
+// ===========================================================
+
+func TestChaincodeFds_Query_fdsGetFraudEntriesWithFinalDateAndRegisteredBy(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	entry1 := FdsFraudEntry{1, "cid1", "mac1", "uuid1", "2017-02-28", "2017-02-28 10:00:00 +0900", "fdsproducedby", "bank1", "fdsreason", LS_BLACKLIST, "", ""}
+	entry2 := FdsFraudEntry{2, "cid2", "mac2", "uuid2", "2017-03-01", "2017-03-01 10:00:00 +0900", "fdsproducedby", "bank2", "fdsreason", LS_BLACKLIST, "", ""}
+	entry3 := FdsFraudEntry{3, "cid3", "mac3", "uuid3", "2017-03-02", "2017-03-02 10:00:00 +0900", "fdsproducedby", "bank1", "fdsreason", LS_BLACKLIST, "", ""}
+	entry4 := FdsFraudEntry{4, "cid4", "mac4", "uuid4", "2017-03-03", "2017-03-03 10:00:00 +0900", "fdsproducedby", "bank1", "fdsreason", LS_BLACKLIST, "", ""}
+
+	checkInit(t, stub, []string{})
+	for _, entry := range []FdsFraudEntry{entry1, entry2, entry3, entry4} {
+		checkInvoke(t, stub, "fdsCreateFraudEntry", []string{entry.Cid, entry.Mac, entry.Uuid, entry.FinalDate, entry.FinalTime, entry.ProducedBy, entry.RegisteredBy, entry.Reason})
+	}
+
+	entriesInBytes, _ := json.Marshal([]FdsFraudEntry{entry2, entry3})
+	checkQuery(t, stub, "fdsGetFraudEntriesWithFinalDate", []string{"2017-03-01", "2017-03-02"}, string(entriesInBytes))
+	entriesInBytes, _ = json.Marshal([]FdsFraudEntry{entry1, entry3, entry4})
+	checkQuery(t, stub, "fdsGetFraudEntriesWithRegisteredBy", []string{"bank1"}, string(entriesInBytes))
+	checkQuery(t, stub, "fdsGetFraudEntriesWithFinalDate", []string{"2017-03-04", "2017-03-31"}, "[]")
+
+	if _, err := stub.MockQuery("fdsGetFraudEntriesWithFinalDate", []string{"20170301", "2017-03-02"}); err == nil {
+		fmt.Println("Query fdsGetFraudEntriesWithFinalDate accepted an invalid date")
+		t.FailNow()
+	}
+
+	checkInvoke(t, stub, "fdsDeleteFraudEntryWithEid", []string{"3"})
+	checkIndex(t, stub, INDEX_FINALDATE, "2017-03-02", []string{})
+	checkIndex(t, stub, INDEX_REGISTEREDBY, "bank1", []string{"1", "4"})
+}
+
+/*
+ * Risk score of (cid, mac, uuid) as of 2017-03-02
+ *
+ *   EID | matched | FinalDate/FinalTime            | ProducedBy | LedgerStatus | score (default weights)
+ *  -----+---------+--------------------------------+------------+--------------+------------------------
+ *   1   | cid     | 2017-03-02 10:00:00 +0900      | bank       | BL           | 40
+ *   2   | mac     | 2016-12-02 (90 days ago)       | card       | BL           | 30 x 0.5 = 15
+ *   3   | cid     | 2017-03-01 10:00:00 +0900      | bank       | WL           | (excluded)
+ *   4   | -       | 2017-03-02                     | bank       | BL           | (not matched)
+ */
+func TestChaincodeFds_Query_fdsGetRiskScore(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid", "mac1", "uuid1", "2017-03-02", "2017-03-02 10:00:00 +0900", "bank", "bank1", "fdsreason"})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid2", "mac", "uuid2", "2016-12-02", "", "card", "card1", "fdsreason"})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid", "mac3", "uuid3", "2017-03-01", "2017-03-01 10:00:00 +0900", "bank", "bank1", "fdsreason"})
+	checkInvoke(t, stub, "fdsUpdateLedgerStatusWithEid", []string{"3", "WL", "ledgerstatusupdatetime", "ledgerstatusupdatereason"})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid4", "mac4", "uuid4", "2017-03-02", "", "bank", "bank1", "fdsreason"})
+
+	scoreInBytes, _ := json.Marshal(FdsRiskScore{"cid", "mac", "uuid", "2017-03-02", 55, 2, []int{1, 2}})
+	checkQuery(t, stub, "fdsGetRiskScore", []string{"cid", "mac", "uuid", "2017-03-02"}, string(scoreInBytes))
+
+	// 출처별 가중치와 점수 상한 변경
+	weights := defaultScoreWeights
+	weights.ProducedBy = map[string]float64{"bank": 0.5}
+	weights.MaxScore = 30
+	weightsInBytes, _ := json.Marshal(weights)
+	stub.SetAttributes(map[string]string{FDS_ATTR_ROLE: FDS_ROLE_ADMIN})
+	checkInvoke(t, stub, "fdsSetScoreWeights", []string{string(weightsInBytes)})
+	checkQuery(t, stub, "fdsGetScoreWeights", []string{}, string(weightsInBytes))
+
+	scoreInBytes, _ = json.Marshal(FdsRiskScore{"cid", "", "", "2017-03-02", 20, 1, []int{1}})
+	checkQuery(t, stub, "fdsGetRiskScore", []string{"cid", "", "", "2017-03-02"}, string(scoreInBytes))
+	scoreInBytes, _ = json.Marshal(FdsRiskScore{"cid", "mac", "uuid", "2017-03-02", 30, 2, []int{1, 2}})
+	checkQuery(t, stub, "fdsGetRiskScore", []string{"cid", "mac", "uuid", "2017-03-02"}, string(scoreInBytes))
+
+	for _, invalid := range []string{`{"halfLifeDays": 0}`, `{"cid": -1, "halfLifeDays": 90}`, `{"halfLifeDays": 90, "producedBy": {"bank": -1}}`, "not json"} {
+		if _, err := stub.MockInvoke("1", "fdsSetScoreWeights", []string{invalid}); err == nil {
+			fmt.Println("Invoke fdsSetScoreWeights accepted invalid weights", invalid)
+			t.FailNow()
+		}
+	}
+	checkQuery(t, stub, "fdsGetScoreWeights", []string{}, string(weightsInBytes))
+}
+
+// ===========================================================
+//   Test Event: 등록/수정/삭제 event
+// ===========================================================
+
+func TestChaincodeFds_Invoke_Events(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	entry1 := FdsFraudEntry{1, "cid", "mac1", "uuid1", "finaldate1", "finaltime1", "fdsproducedby1", "fdsregisteredby1", "fdsreason1", LS_BLACKLIST, "", ""}
+	entry2 := FdsFraudEntry{2, "cid", "mac2", "uuid2", "finaldate2", "finaltime2", "fdsproducedby2", "fdsregisteredby2", "fdsreason2", LS_BLACKLIST, "", ""}
+	entry3 := FdsFraudEntry{3, "cid3", "mac3", "uuid3", "finaldate3", "finaltime3", "fdsproducedby3", "fdsregisteredby3", "fdsreason3", LS_BLACKLIST, "", ""}
+
+	checkInit(t, stub, []string{})
+	for _, entry := range []FdsFraudEntry{entry1, entry2, entry3} {
+		checkInvoke(t, stub, "fdsCreateFraudEntry", []string{entry.Cid, entry.Mac, entry.Uuid, entry.FinalDate, entry.FinalTime, entry.ProducedBy, entry.RegisteredBy, entry.Reason})
+		checkEvent(t, stub, EVENT_ENTRY_CREATED, []FdsFraudEntry{entry})
+	}
+
+	checkInvoke(t, stub, "fdsUpdateLedgerStatusWithEid", []string{"3", "WL", "ledgerstatusupdatetime", "ledgerstatusupdatereason"})
+	entry3.LedgerStatus = LS_WHITELIST
+	entry3.LedgerStatusUpdateTime = "ledgerstatusupdatetime"
+	entry3.LedgerStatusUpdateReason = "ledgerstatusupdatereason"
+	checkEvent(t, stub, EVENT_LEDGER_STATUS_CHANGED, []FdsFraudEntry{entry3})
+
+	checkInvoke(t, stub, "fdsDeleteFraudEntryWithCid", []string{"cid"})
+	checkEvent(t, stub, EVENT_ENTRY_DELETED, []FdsFraudEntry{entry1, entry2})
+	checkInvoke(t, stub, "fdsDeleteFraudEntryWithEid", []string{"3"})
+	checkEvent(t, stub, EVENT_ENTRY_DELETED, []FdsFraudEntry{entry3})
+
+	// 삭제된 entry 가 없으면 event 도 없음
+	checkInvoke(t, stub, "fdsDeleteFraudEntryWithMac", []string{"mac1"})
+	if stub.ChaincodeEvent != nil {
+		fmt.Println("Event", stub.ChaincodeEvent.EventName, "was set without deleting any entry")
+		t.FailNow()
+	}
+}
+
+// ===========================================================
+//  Test Invoke: 실패한 transaction 의 rollback
+// ===========================================================
+
+/*
+ * UUID 인덱스 등록에 실패하면 (uuid 에 "\x00" 포함) 그 전에 쓴 EID/CID/MAC 도 모두 rollback
+ *
+ *   Key                 |   <Before>      |   <After>
+ *  ---------------------+-----------------+-----------------
+ *   [FDS_NEXTEID]       |   2             |   2
+ *   [FDS_EID_1]         |   Fraud Entry 1 |   Fraud Entry 1
+ *   [FDS_EID_2]         |   -             |   - (rollback)
+ *   [FDS_CID cid 2]     |   -             |   - (rollback)
+ *   [FDS_MAC mac 2]     |   -             |   - (rollback)
+ */
+func TestChaincodeFds_Invoke_Rollback(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid", "mac", "uuid", "finaldate1", "finaltime1", "fdsproducedby1", "fdsregisteredby1", "fdsreason1"})
+	numKeys := len(stub.State)
+
+	_, err := stub.MockInvoke("2", "fdsCreateFraudEntry", []string{"cid", "mac", "uuid\x00", "finaldate2", "finaltime2", "fdsproducedby2", "fdsregisteredby2", "fdsreason2"})
+	if err == nil {
+		fmt.Println("Invoke fdsCreateFraudEntry with an invalid uuid did not fail")
+		t.FailNow()
+	}
+	if !stub.LastTransaction().RolledBack {
+		fmt.Println("Transaction 2 was not rolled back")
+		t.FailNow()
+	}
+	if _, ok := stub.LastTransaction().WriteSet[PREFIX_EID+"2"]; !ok {
+		fmt.Println("Transaction 2 did not write", PREFIX_EID+"2", "before failing")
+		t.FailNow()
+	}
+
+	checkState(t, stub, FDS_NEXTEID_KEY, "2")
+	if stub.State[PREFIX_EID+"2"] != nil || len(stub.State) != numKeys {
+		fmt.Println("Writes of the failed transaction were not rolled back")
+		t.FailNow()
+	}
+	checkIndex(t, stub, INDEX_CID, "cid", []string{"1"})
+	checkIndex(t, stub, INDEX_MAC, "mac", []string{"1"})
+	if stub.ChaincodeEvent != nil {
+		fmt.Println("Event", stub.ChaincodeEvent.EventName, "was set by the failed transaction")
+		t.FailNow()
+	}
+}
+
+/*
+ * fdsUpdateLedgerStatusWithEid 는 EID 1 만 읽고 씀
+ */
+func TestChaincodeFds_Invoke_fdsUpdateLedgerStatusWithEid_ReadWriteSet(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid", "mac", "uuid", "finaldate", "finaltime", "fdsproducedby", "fdsregisteredby", "fdsreason"})
+	checkInvoke(t, stub, "fdsUpdateLedgerStatusWithEid", []string{"1", "WL", "ledgerstatusupdatetime", "ledgerstatusupdatereason"})
+
+	tx := stub.LastTransaction()
+	if !reflect.DeepEqual(tx.ReadSet, []string{PREFIX_EID + "1"}) {
+		fmt.Printf("Read set %v did not match the expected read set %v.\n", tx.ReadSet, []string{PREFIX_EID + "1"})
+		t.FailNow()
+	}
+	if len(tx.WriteSet) != 1 || tx.WriteSet[PREFIX_EID+"1"] == nil {
+		fmt.Printf("Write set %v did not match the expected write set [%v].\n", tx.WriteSet, PREFIX_EID+"1")
+		t.FailNow()
+	}
+}
+
+// ===========================================================
+//   Test listkvs: 인자가 없거나 알 수 없는 키 종류는 오류
+// ===========================================================
+
+func TestChaincodeFds_Query_listkvs(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "fdsCreateFraudEntry", []string{"cid", "mac", "uuid", "finaldate", "finaltime", "fdsproducedby", "fdsregisteredby", "fdsreason"})
+
+	for _, keyType := range []string{"eid", "cid", "mac", "uuid"} {
+		if _, err := stub.MockQuery("listkvs", []string{keyType}); err != nil {
+			fmt.Println("Query listkvs", keyType, "failed", err)
+			t.FailNow()
+		}
+	}
+	for _, args := range [][]string{{}, {"eid", "cid"}, {"ls"}, {""}} {
+		if _, err := stub.MockQuery("listkvs", args); err == nil {
+			fmt.Println("Query listkvs", args, "did not fail")
+			t.FailNow()
+		}
+	}
+}
+
+// ===========================================================
+//   Test fdsSetScoreWeights: 관리자 역할이 없는 호출자는 가중치를 변경할 수 없음
+// ===========================================================
+
+func TestChaincodeFds_Invoke_fdsSetScoreWeights_Unauthorized(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("fds_chaincode", shim.NewChaincodeAdapter(scc))
+
+	checkInit(t, stub, []string{})
+	defaultWeightsInBytes, _ := json.Marshal(defaultScoreWeights)
+
+	weights := defaultScoreWeights
+	weights.Cid = 100
+	weightsInBytes, _ := json.Marshal(weights)
+	for _, attributes := range []map[string]string{nil, {}, {FDS_ATTR_ROLE: "bank"}, {"admin": FDS_ROLE_ADMIN}} {
+		stub.SetAttributes(attributes)
+		if _, err := stub.MockInvoke("1", "fdsSetScoreWeights", []string{string(weightsInBytes)}); err == nil {
+			fmt.Println("Invoke fdsSetScoreWeights was accepted for a caller with attributes", attributes)
+			t.FailNow()
+		}
+	}
+	checkQuery(t, stub, "fdsGetScoreWeights", []string{}, string(defaultWeightsInBytes))
+
+	stub.SetAttributes(map[string]string{FDS_ATTR_ROLE: FDS_ROLE_ADMIN})
+	checkInvoke(t, stub, "fdsSetScoreWeights", []string{string(weightsInBytes)})
+	checkQuery(t, stub, "fdsGetScoreWeights", []string{}, string(weightsInBytes))
+}
@@ -19,12 +19,17 @@ limitations under the License.
 package shim
 
 import (
+	"bytes"
 	"container/list"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/attr"
+	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/ecdsa"
+	pb "github.com/hyperledger/fabric/protos"
 	"github.com/op/go-logging"
 )
 
@@ -56,6 +61,60 @@ type MockStub struct {
 	// stores a transaction uuid while being Invoked / Deployed
 	// TODO if a chaincode uses recursion this may need to be a stack of TxIDs or possibly a reference counting map
 	TxID string
+
+	// TxTimestamp is returned by GetTxTimestamp, set it with SetTxTimestamp
+	TxTimestamp *timestamp.Timestamp
+
+	// ChaincodeEvent is the event set by the last transaction, or nil
+	ChaincodeEvent *pb.ChaincodeEvent
+
+	// The security context of the caller, set it with SetCallerCertificate
+	// and SetSecurityContext
+	CallerCert     []byte
+	CallerMetadata []byte
+	Binding        []byte
+	Payload        []byte
+
+	// Attributes, when not nil, are returned by ReadCertAttribute and checked
+	// by VerifyAttribute(s) instead of the attributes of CallerCert. Set them
+	// with SetAttributes.
+	Attributes map[string][]byte
+
+	// Transactions records the read and write set of every transaction, in
+	// the order they were started
+	Transactions []*MockTransaction
+
+	// the transaction in progress and the previous values of the keys it
+	// wrote, used to roll it back
+	currentTx *MockTransaction
+	undoLog   map[string]mockUndoEntry
+
+	// the peer chaincodes invoked by the transaction in progress. As on a
+	// peer, their writes are part of this transaction and are kept or rolled
+	// back when it ends.
+	invoked []*MockStub
+}
+
+// MockTransaction is the read and write set of a mocked transaction.
+type MockTransaction struct {
+	TxID string
+
+	// ReadSet holds the keys read with GetState or a range query, in the
+	// order they were first read
+	ReadSet []string
+
+	// WriteSet holds the final value of every key written, nil if the key
+	// was deleted
+	WriteSet map[string][]byte
+
+	// RolledBack is true if the writes were discarded because the chaincode
+	// returned an error
+	RolledBack bool
+}
+
+type mockUndoEntry struct {
+	value   []byte
+	existed bool
 }
 
 func (stub *MockStub) GetTxID() string {
@@ -75,16 +134,108 @@ func (stub *MockStub) GetStringArgs() []string {
 	return strargs
 }
 
+func (stub *MockStub) GetFunctionAndParameters() (function string, params []string) {
+	return getFunctionAndParameters(stub.GetStringArgs())
+}
+
 // Used to indicate to a chaincode that it is part of a transaction.
 // This is important when chaincodes invoke each other.
 // MockStub doesn't support concurrent transactions at present.
 func (stub *MockStub) MockTransactionStart(txid string) {
 	stub.TxID = txid
+	stub.ChaincodeEvent = nil
+	stub.currentTx = &MockTransaction{TxID: txid, ReadSet: []string{}, WriteSet: make(map[string][]byte)}
+	stub.undoLog = make(map[string]mockUndoEntry)
+	stub.Transactions = append(stub.Transactions, stub.currentTx)
+}
+
+// Set the timestamp returned by GetTxTimestamp for the following transactions.
+func (stub *MockStub) SetTxTimestamp(t time.Time) {
+	stub.TxTimestamp = &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// Set the TCert and metadata of the caller for the following transactions.
+// ReadCertAttribute and VerifyAttribute(s) read the attributes embedded in cert
+// exactly as on a peer, unless attributes are set with SetAttributes.
+func (stub *MockStub) SetCallerCertificate(cert []byte, metadata []byte) {
+	stub.CallerCert = cert
+	stub.CallerMetadata = metadata
+}
+
+// Set the binding and payload returned by GetBinding and GetPayload for the
+// following transactions.
+func (stub *MockStub) SetSecurityContext(binding []byte, payload []byte) {
+	stub.Binding = binding
+	stub.Payload = payload
 }
 
-// End a mocked transaction, clearing the UUID.
+// Set the TCert attributes of the caller for the following transactions
+// without having to issue a certificate. Pass nil to read the attributes from
+// the caller certificate again.
+func (stub *MockStub) SetAttributes(attributes map[string]string) {
+	if attributes == nil {
+		stub.Attributes = nil
+		return
+	}
+	stub.Attributes = make(map[string][]byte)
+	for name, value := range attributes {
+		stub.Attributes[name] = []byte(value)
+	}
+}
+
+// End a mocked transaction, clearing the UUID. The writes of the transaction
+// are kept.
 func (stub *MockStub) MockTransactionEnd(uuid string) {
+	invoked := stub.invoked
+	stub.invoked = nil
+	for _, otherStub := range invoked {
+		otherStub.MockTransactionEnd(uuid)
+	}
 	stub.TxID = ""
+	stub.currentTx = nil
+	stub.undoLog = nil
+}
+
+// Roll back a mocked transaction, restoring every key it and the peer
+// chaincodes it invoked wrote and dropping its event, as a peer discards the
+// state delta of a failed transaction.
+func (stub *MockStub) MockTransactionRollback(uuid string) {
+	invoked := stub.invoked
+	stub.invoked = nil
+	for _, otherStub := range invoked {
+		otherStub.MockTransactionRollback(uuid)
+	}
+	if stub.currentTx != nil {
+		mockLogger.Debug("MockStub", stub.Name, "Rolling back", uuid)
+		for key, entry := range stub.undoLog {
+			if entry.existed {
+				stub.putState(key, entry.value)
+			} else {
+				stub.delState(key)
+			}
+		}
+		stub.currentTx.RolledBack = true
+	}
+	stub.ChaincodeEvent = nil
+	stub.MockTransactionEnd(uuid)
+}
+
+// LastTransaction returns the read and write set of the last transaction, or
+// nil if no transaction was started.
+func (stub *MockStub) LastTransaction() *MockTransaction {
+	if len(stub.Transactions) == 0 {
+		return nil
+	}
+	return stub.Transactions[len(stub.Transactions)-1]
+}
+
+// endTransaction ends the transaction, rolling it back if err is not nil.
+func (stub *MockStub) endTransaction(uuid string, err error) {
+	if err != nil {
+		stub.MockTransactionRollback(uuid)
+		return
+	}
+	stub.MockTransactionEnd(uuid)
 }
 
 // Register a peer chaincode with this MockStub
@@ -95,23 +246,37 @@ func (stub *MockStub) MockPeerChaincode(invokableChaincodeName string, otherStub
 }
 
 // Initialise this chaincode,  also starts and ends a transaction.
+// The transaction is rolled back if Init returns an error.
 func (stub *MockStub) MockInit(uuid string, function string, args []string) ([]byte, error) {
 	stub.args = getBytes(function, args)
 	stub.MockTransactionStart(uuid)
 	bytes, err := stub.cc.Init(stub, function, args)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(uuid, err)
 	return bytes, err
 }
 
 // Invoke this chaincode, also starts and ends a transaction.
+// The transaction is rolled back if Invoke returns an error.
 func (stub *MockStub) MockInvoke(uuid string, function string, args []string) ([]byte, error) {
 	stub.args = getBytes(function, args)
 	stub.MockTransactionStart(uuid)
 	bytes, err := stub.cc.Invoke(stub, function, args)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(uuid, err)
 	return bytes, err
 }
 
+// mockNestedInvoke invokes this chaincode as part of the caller's transaction
+// uuid. The transaction is not ended here but by the caller, so the writes
+// are rolled back if the caller's transaction fails, even when this invoke
+// succeeded.
+func (stub *MockStub) mockNestedInvoke(uuid string, function string, args []string) ([]byte, error) {
+	stub.args = getBytes(function, args)
+	if stub.currentTx == nil || stub.currentTx.TxID != uuid {
+		stub.MockTransactionStart(uuid)
+	}
+	return stub.cc.Invoke(stub, function, args)
+}
+
 // Query this chaincode
 func (stub *MockStub) MockQuery(function string, args []string) ([]byte, error) {
 	stub.args = getBytes(function, args)
@@ -124,6 +289,9 @@ func (stub *MockStub) MockQuery(function string, args []string) ([]byte, error)
 func (stub *MockStub) GetState(key string) ([]byte, error) {
 	value := stub.State[key]
 	mockLogger.Debug("MockStub", stub.Name, "Getting", key, value)
+	if stub.currentTx != nil {
+		stub.currentTx.recordRead(key)
+	}
 	return value, nil
 }
 
@@ -134,6 +302,13 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 		return errors.New("Cannot PutState without a transactions - call stub.MockTransactionStart()?")
 	}
 
+	stub.recordWrite(key, value)
+	stub.putState(key, value)
+	return nil
+}
+
+// putState writes to State and Keys without recording the write.
+func (stub *MockStub) putState(key string, value []byte) {
 	mockLogger.Debug("MockStub", stub.Name, "Putting", key, value)
 	stub.State[key] = value
 
@@ -166,84 +341,135 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 		stub.Keys.PushFront(key)
 		mockLogger.Debug("MockStub", stub.Name, "Key", key, "is first element in list")
 	}
-
-	return nil
 }
 
 // DelState removes the specified `key` and its value from the ledger.
 func (stub *MockStub) DelState(key string) error {
+	if stub.TxID == "" {
+		mockLogger.Error("Cannot DelState without a transactions - call stub.MockTransactionStart()?")
+		return errors.New("Cannot DelState without a transactions - call stub.MockTransactionStart()?")
+	}
+
+	stub.recordWrite(key, nil)
+	stub.delState(key)
+	return nil
+}
+
+// delState removes key from State and Keys without recording the write.
+func (stub *MockStub) delState(key string) {
 	mockLogger.Debug("MockStub", stub.Name, "Deleting", key, stub.State[key])
 	delete(stub.State, key)
 
 	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
 		if strings.Compare(key, elem.Value.(string)) == 0 {
 			stub.Keys.Remove(elem)
+			break
 		}
 	}
+}
 
-	return nil
+// recordWrite adds key to the write set and, on its first write in the
+// transaction, remembers its previous value for MockTransactionRollback.
+func (stub *MockStub) recordWrite(key string, value []byte) {
+	if stub.currentTx == nil {
+		return
+	}
+	stub.currentTx.WriteSet[key] = value
+	if _, ok := stub.undoLog[key]; !ok {
+		previous, existed := stub.State[key]
+		stub.undoLog[key] = mockUndoEntry{previous, existed}
+	}
+}
+
+func (tx *MockTransaction) recordRead(key string) {
+	for _, read := range tx.ReadSet {
+		if read == key {
+			return
+		}
+	}
+	tx.ReadSet = append(tx.ReadSet, key)
 }
 
 func (stub *MockStub) RangeQueryState(startKey, endKey string) (StateRangeQueryIteratorInterface, error) {
 	return NewMockStateRangeQueryIterator(stub, startKey, endKey), nil
 }
 
-// Not implemented
+// CreateTable creates a new table given the table name and column definitions
 func (stub *MockStub) CreateTable(name string, columnDefinitions []*ColumnDefinition) error {
-	return nil
+	return createTable(stub, name, columnDefinitions)
 }
 
-// Not implemented
+// GetTable returns the table for the specified table name or ErrTableNotFound
+// if the table does not exist.
 func (stub *MockStub) GetTable(tableName string) (*Table, error) {
-	return nil, nil
+	return getTable(stub, tableName)
 }
 
-// Not implemented
+// DeleteTable deletes an entire table and all associated rows.
 func (stub *MockStub) DeleteTable(tableName string) error {
-	return nil
+	return deleteTable(stub, tableName)
 }
 
-// Not implemented
+// InsertRow inserts a new row into the specified table.
 func (stub *MockStub) InsertRow(tableName string, row Row) (bool, error) {
-	return false, nil
+	return insertRowInternal(stub, tableName, row, false)
 }
 
-// Not implemented
+// ReplaceRow updates the row in the specified table.
 func (stub *MockStub) ReplaceRow(tableName string, row Row) (bool, error) {
-	return false, nil
+	return insertRowInternal(stub, tableName, row, true)
 }
 
-// Not implemented
+// GetRow fetches a row from the specified table for the given key.
 func (stub *MockStub) GetRow(tableName string, key []Column) (Row, error) {
-	var r Row
-	return r, nil
+	return getRow(stub, tableName, key)
 }
 
-// Not implemented
+// GetRows returns multiple rows based on a partial key.
 func (stub *MockStub) GetRows(tableName string, key []Column) (<-chan Row, error) {
-	return nil, nil
+	return getRows(stub, tableName, key)
 }
 
-// Not implemented
+// DeleteRow deletes the row for the given key from the specified table.
 func (stub *MockStub) DeleteRow(tableName string, key []Column) error {
-	return nil
+	return deleteRow(stub, tableName, key)
 }
 
 // Invokes a peered chaincode.
 // E.g. stub1.InvokeChaincode("stub2Hash", funcArgs)
 // Before calling this make sure to create another MockStub stub2, call stub2.MockInit(uuid, func, args)
 // and register it with stub1 by calling stub1.MockPeerChaincode("stub2Hash", stub2)
+// The writes of stub2 are kept or rolled back together with the transaction
+// of stub1.
 func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte) ([]byte, error) {
 	// TODO "args" here should possibly be a serialized pb.ChaincodeInput
 	function, params := getFuncArgs(args)
 	otherStub := stub.Invokables[chaincodeName]
 	mockLogger.Debug("MockStub", stub.Name, "Invoking peer chaincode", otherStub.Name, args)
 	//	function, strings := getFuncArgs(args)
-	bytes, err := otherStub.MockInvoke(stub.TxID, function, params)
+	if stub.currentTx == nil {
+		return otherStub.MockInvoke(stub.TxID, function, params)
+	}
+	bytes, err := otherStub.mockNestedInvoke(stub.TxID, function, params)
+	stub.joinTransaction(otherStub)
 	mockLogger.Debug("MockStub", stub.Name, "Invoked peer chaincode", otherStub.Name, "got", bytes, err)
 	return bytes, err
 }
 
+// joinTransaction ends the transaction of otherStub together with the
+// transaction in progress.
+func (stub *MockStub) joinTransaction(otherStub *MockStub) {
+	if otherStub == stub {
+		return
+	}
+	for _, invoked := range stub.invoked {
+		if invoked == otherStub {
+			return
+		}
+	}
+	stub.invoked = append(stub.invoked, otherStub)
+}
+
 func (stub *MockStub) QueryChaincode(chaincodeName string, args [][]byte) ([]byte, error) {
 	// TODO "args" here should possibly be a serialized pb.ChaincodeInput
 	mockLogger.Debug("MockStub", stub.Name, "Looking for peer chaincode", chaincodeName)
@@ -259,53 +485,79 @@ func (stub *MockStub) QueryChaincode(chaincodeName string, args [][]byte) ([]byt
 	return bytes, err
 }
 
-// Not implemented
+// ReadCertAttribute returns the value of the attribute set with SetAttributes,
+// or reads it from the caller certificate. A missing attribute is an error,
+// as on a peer.
 func (stub *MockStub) ReadCertAttribute(attributeName string) ([]byte, error) {
-	return nil, nil
+	if stub.Attributes == nil {
+		attributesHandler, err := attr.NewAttributesHandlerImpl(stub)
+		if err != nil {
+			return nil, err
+		}
+		return attributesHandler.GetValue(attributeName)
+	}
+	value, ok := stub.Attributes[attributeName]
+	if !ok {
+		return nil, fmt.Errorf("Failed attribute '%s' doesn't exists in the TCert.", attributeName)
+	}
+	return value, nil
 }
 
-// Not implemented
+// VerifyAttribute checks that the caller has the attribute attributeName with
+// the value attributeValue.
 func (stub *MockStub) VerifyAttribute(attributeName string, attributeValue []byte) (bool, error) {
-	return false, nil
+	value, err := stub.ReadCertAttribute(attributeName)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Compare(value, attributeValue) == 0, nil
 }
 
-// Not implemented
+// VerifyAttributes checks VerifyAttribute for every attribute in attrs.
 func (stub *MockStub) VerifyAttributes(attrs ...*attr.Attribute) (bool, error) {
-	return false, nil
+	for _, attribute := range attrs {
+		ok, err := stub.VerifyAttribute(attribute.Name, attribute.Value)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
 }
 
-// Not implemented
+// VerifySignature verifies the signature with the same verifier as a peer.
 func (stub *MockStub) VerifySignature(certificate, signature, message []byte) (bool, error) {
-	return false, nil
+	return ecdsa.NewX509ECDSASignatureVerifier().Verify(certificate, signature, message)
 }
 
-// Not implemented
+// GetCallerCertificate returns the certificate set with SetCallerCertificate.
 func (stub *MockStub) GetCallerCertificate() ([]byte, error) {
-	return nil, nil
+	return stub.CallerCert, nil
 }
 
-// Not implemented
+// GetCallerMetadata returns the metadata set with SetCallerCertificate.
 func (stub *MockStub) GetCallerMetadata() ([]byte, error) {
-	return nil, nil
+	return stub.CallerMetadata, nil
 }
 
-// Not implemented
+// GetBinding returns the binding set with SetSecurityContext.
 func (stub *MockStub) GetBinding() ([]byte, error) {
-	return nil, nil
+	return stub.Binding, nil
 }
 
-// Not implemented
+// GetPayload returns the payload set with SetSecurityContext.
 func (stub *MockStub) GetPayload() ([]byte, error) {
-	return nil, nil
+	return stub.Payload, nil
 }
 
-// Not implemented
+// GetTxTimestamp returns the timestamp set with SetTxTimestamp, or nil.
 func (stub *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
-	return nil, nil
+	return stub.TxTimestamp, nil
 }
 
-// Not implemented
+// SetEvent records the event in ChaincodeEvent. As on a peer, only the last
+// event set by a transaction is kept.
 func (stub *MockStub) SetEvent(name string, payload []byte) error {
+	stub.ChaincodeEvent = &pb.ChaincodeEvent{EventName: name, Payload: payload}
 	return nil
 }
 
@@ -344,17 +596,12 @@ func (iter *MockStateRangeQueryIterator) HasNext() bool {
 	}
 
 	if iter.Current == nil {
-		mockLogger.Error("HasNext() couldn't get Current")
-		return false
-	}
-
-	if iter.Current.Next() == nil {
 		// we've reached the end of the underlying values
 		mockLogger.Debug("HasNext() but no next")
 		return false
 	}
 
-	if iter.EndKey == iter.Current.Value {
+	if strings.Compare(iter.Current.Value.(string), iter.EndKey) > 0 {
 		// we've reached the end of the specified range
 		mockLogger.Debug("HasNext() at end of specified range")
 		return false
@@ -376,13 +623,8 @@ func (iter *MockStateRangeQueryIterator) Next() (string, []byte, error) {
 		return "", nil, errors.New("MockStateRangeQueryIterator.Next() called when it does not HaveNext()")
 	}
 
-	iter.Current = iter.Current.Next()
-
-	if iter.Current == nil {
-		mockLogger.Error("MockStateRangeQueryIterator.Next() went past end of range")
-		return "", nil, errors.New("MockStateRangeQueryIterator.Next() went past end of range")
-	}
 	key := iter.Current.Value.(string)
+	iter.Current = iter.Current.Next()
 	value, err := iter.Stub.GetState(key)
 	return key, value, err
 }
@@ -417,7 +659,11 @@ func NewMockStateRangeQueryIterator(stub *MockStub, startKey string, endKey stri
 	iter.Stub = stub
 	iter.StartKey = startKey
 	iter.EndKey = endKey
+	// Current points at the next key to return: the first key not less than startKey
 	iter.Current = stub.Keys.Front()
+	for iter.Current != nil && strings.Compare(iter.Current.Value.(string), startKey) < 0 {
+		iter.Current = iter.Current.Next()
+	}
 
 	iter.Print()
 
@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/chaincode/shim/crypto/attr"
 )
 
 // ===========================================================
@@ -55,6 +56,29 @@ type SlaContract struct {
 	ServiceItems    []SlaServiceItem `json:  "ServiceItems"`    // SLA평가항목
 }
 
+// Sla Contract 상태 전이 이력 구조체를 설정합니다.
+type SlaContractHistory struct {
+	TxId      string `json:"TxId"`      // 트랜잭션ID
+	Timestamp string `json:"Timestamp"` // 트랜잭션 생성시각 (RFC3339)
+	Action    string `json:"Action"`    // 호출 함수  const SLA_CONTRACT_ACTION.... 사용할 것
+	From      string `json:"From"`      // 이전 진행단계
+	To        string `json:"To"`        // 새 진행단계
+	UserId    string `json:"UserId"`    // 결재사용자ID
+}
+
+// 계약 상태 전이 때 발생하는 chaincode event 의 payload
+type SlaContractEvent struct {
+	TxId         string       `json:"TxId"`                   // 트랜잭션ID
+	Timestamp    string       `json:"Timestamp"`              // 트랜잭션 생성시각 (RFC3339)
+	RegId        string       `json:"RegId"`                  // 계약ID
+	Name         string       `json:"Name"`                   // 계약명
+	Client       string       `json:"Client"`                 // 고객사명
+	Action       string       `json:"Action"`                 // 호출 함수
+	Progression  string       `json:"Progression"`            // 새 진행단계
+	UserId       string       `json:"UserId"`                 // 결재사용자ID
+	NextApprover *SlaApproval `json:"NextApprover,omitempty"` // 다음 결재자 (결재 진행 중인 경우)
+}
+
 // Sla Approval 구조체를 설정합니다.
 type SlaApproval struct {
 	ApprovalUserId     string `json:  "ApprovalUserId"`     // 결재사용자ID
@@ -78,37 +102,61 @@ type SlaServiceItem struct {
 
 // Sla EvaluationRoot구조체를 설정합니다.
 type SlaEvalutionRoot struct {
-	RegId        string           `json:  "RegId"`        // SLA계약등록번호
-	ContractId   string           `json:  "ContractId"`   // SLA계약명
-	Status       string           `json:  "Status"`       // SLA계약명
-	Evaluations  []SlaEvaluation  `json:  "Evaluations"`  // SLA평가등록번호
+	RegId        string           `json:  "RegId"`        // SLA평가등록번호
+	ContractId   string           `json:  "ContractId"`   // SLA계약등록번호
+	Status       string           `json:  "Status"`       // 평가상태   const SLA_EVALUATION_ROOT_STATUS.... 사용할 것
+	Evaluations  []string         `json:  "Evaluations"`  // 개별 SLA평가등록번호 목록
 	ServiceItems []SlaServiceItem `json:  "ServiceItems"` // SLA평가항목
 }
 
 // Sla Evaluation 구조체를 설정합니다.
 type SlaEvaluation struct {
-	RegId                 string        `json:  "SlaContractRegId"` // SLA계약등록번호
-	EvaluationRootId      string        `json:  "SlaContractName"`  // SLA계약명
-	ScoresForServiceItems string        `json:  "SlaContractName"`  // SLA평가점수항목
-	Approvals             []SlaApproval `json:  "Approvals"`        // SLA결재선정보
+	RegId                 string         `json:  "SlaContractRegId"`     // 개별 SLA평가등록번호
+	EvaluationRootId      string         `json:  "SlaContractName"`      // SLA평가등록번호
+	ContractId            string         `json:  "ContractId"`           // SLA계약등록번호
+	ServiceItem           SlaServiceItem `json:  "ServiceItem"`          // 평가대상 SLA평가항목
+	ScoresForServiceItems string         `json:  "SlaContractName"`      // SLA평가점수항목
+	Progression           string         `json:  "Progression"`          // 진행단계   const SLA_EVALUATION_PROGRESSION.... 사용할 것
+	Approvals             []SlaApproval  `json:  "Approvals"`            // SLA결재선정보
+	PaymentRequestUserId  string         `json:  "PaymentRequestUserId"` // 지급요청자ID
+	PaymentRequestDate    string         `json:  "PaymentRequestDate"`   // 지급요청일자
+	PaymentUserId         string         `json:  "PaymentUserId"`        // 지급자ID
+	PaymentDate           string         `json:  "PaymentDate"`          // 지급일자
+	PaymentComment        string         `json:  "PaymentComment"`       // 지급의견
 }
 
 // key-value store 의 키 구분자
 const FIELDSEP = "|"
 
 // const ENTRYSEP = ","
+
+// 이전 버전의 "|" 구분 ID목록 키 (slaMigrateIndexes 에서만 사용)
 const SLA_ALL_DATA = "SLA_ALL_DATA"
+const SLA_ALL_EVALUATION_DATA = "SLA_ALL_EVALUATION_DATA"
+
+// 보조 인덱스: (인덱스, 값, ID) 마다 하나의 composite key 로 저장
+const SLA_INDEX_ALL = "SLA_ALL"                         // 전체 조회
+const SLA_INDEX_ALL_CONTRACTS = "CONTRACT"              // 전체 계약
+const SLA_INDEX_ALL_EVALUATIONS = "EVALUATION"          // 전체 평가
+const SLA_INDEX_CONTRACT_NAME = "SLA_CONTRACT_NAME"     // 계약명 --> 계약ID
+const SLA_INDEX_CONTRACT_CLIENT = "SLA_CONTRACT_CLIENT" // 고객사명 --> 계약ID
 
 const CONTRACT_TEMP_ID_PREFIX = "SLA_CONT_TEMP_"
 const CONTRACT_ID_PREFIX = "SLA_CONT_"
 const EVALUATION_TEMP_ID_PREFIX = "SLA_EVAL_TEMP_"
 const EVALUATION_ID_PREFIX = "SLA_EVAL_"
+const CONTRACT_HISTORY_PREFIX = "SLA_HISTORY_"                 // 계약ID --> 상태 전이 이력
+const EVALUATION_ROOT_OF_CONTRACT_PREFIX = "SLA_EVAL_ROOT_OF_" // 계약ID --> 평가ID
 
 const SLA_CONTRACT_TEMP_ID_COUNT_KEY = "SLA_CONTRACT_TEMP_ID_COUNT"
 const SLA_CONTRACT_ID_COUNT_KEY = "SLA_CONTRACT_ID_COUNT"
 const SLA_EVALUATION_TEMP_ID_COUNT_KEY = "SLA_EVALUATION_TEMP_ID_COUNT"
 const SLA_EVALUATION_ID_COUNT_KEY = "SLA_EVALUATION_ID_COUNT"
-const CURRENT_YEAR_KEY = "CURRENT_YEAR"
+const CURRENT_YEAR_KEY = "CURRENT_YEAR"               // 계약 카운트의 연도
+const SLA_EVALUATION_YEAR_KEY = "SLA_EVALUATION_YEAR" // 평가 카운트의 연도
+
+// 연도와 일자는 한국 표준시(KST) 기준
+var slaTimeZone = time.FixedZone("KST", 9*60*60)
 
 //  계약 상태: SlaContract -> PROGRESSION
 // -------------------------------------------------------------------------------------------------------
@@ -122,6 +170,7 @@ const CURRENT_YEAR_KEY = "CURRENT_YEAR"
 // -------------------------------------------------------------------------------------------------------
 //  * 반려될 경우 (slaRejectContract) 해당 상태에 머무른다
 // -------------------------------------------------------------------------------------------------------
+const SLA_CONTRACT_PROGRESSION_NONE = ""     // KVS에 저장되지 않은 계약
 const SLA_CONTRACT_PROGRESSION_TEMP = "TEMP" // "TEMP": 임시저장
 const SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED = "IN_PROGRESS_INTERNAL_REVIEW_REQUESTED"
 const SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED = "IN_PROGRESS_CLIENT_REVIEW_REQUESTED"
@@ -134,11 +183,229 @@ const SLA_APPROVAL_STATE_TEMP = "TEMP" // "TEMP": 임시저장
 const SLA_APPROVAL_STATE_SUBMITTED = "SUBMITTED"
 const SLA_APPROVAL_STATE_APPROVED = "APPROVED"
 const SLA_APPROVAL_STATE_REJECTED = "REJECTED"
+const SLA_APPROVAL_STATE_ANY = "*" // 상태 전이표에서 결재상태를 확인하지 않음
+
+// 트랜잭션 인증서(TCert) 속성: membersrvc 의 ACA 가 발급
+const SLA_ATTR_USER_ID = "userId"        // 결재사용자ID
+const SLA_ATTR_COMPANY = "company"       // 결재회사명
+const SLA_ATTR_DEPARTMENT = "department" // 결재부서명
+const SLA_ATTR_ROLE = "role"             // 역할
+const SLA_ROLE_PAYER = "payer"           // 지급자 역할 (slaSubmitPayment, slaClosePayment, slaCloseEvaluation)
+
+// 계약 상태를 변경하는 함수
+const SLA_CONTRACT_ACTION_CREATE_TEMP = "slaCreateTempContract"
+const SLA_CONTRACT_ACTION_SUBMIT = "slaSubmitContract"
+const SLA_CONTRACT_ACTION_UPDATE = "slaUpdateContract"
+const SLA_CONTRACT_ACTION_APPROVE = "slaApproveContract"
+const SLA_CONTRACT_ACTION_REJECT = "slaRejectContract"
+const SLA_CONTRACT_ACTION_CLOSE = "slaCloseContract"
+const SLA_CONTRACT_ACTION_ABANDON = "slaAbandonContract"
+
+// 계약 상태 전이 때 발생하는 chaincode event 이름
+// 트랜잭션마다 마지막으로 설정한 event 하나만 전달되므로 상태 전이마다 하나의 event 를 설정
+const SLA_EVENT_CONTRACT_SUBMITTED = "SlaContractSubmitted" // 내부결재 요청 (다음 결재자 포함)
+const SLA_EVENT_APPROVAL_REQUESTED = "SlaApprovalRequested" // 승인 후 다음 결재자에게 결재 요청
+const SLA_EVENT_CONTRACT_CLOSED = "SlaContractClosed"       // 계약 등록 완료
+
+// 계약 상태 전이
+type slaContractTransition struct {
+	Action        string // 호출 함수
+	From          string // 현재 진행단계
+	FromApproval  string // 현재 결재자의 결재상태: SLA_APPROVAL_STATE_REJECTED, ""(반려되지 않음), SLA_APPROVAL_STATE_ANY
+	To            string // 새 진행단계
+	ApprovalIndex int    // 상태를 변경할 결재 순번 (-1: 변경 없음)
+	ApprovalState string // 변경할 결재상태
+}
+
+// 계약 상태 전이표: 표에 없는 전이는 SlaIllegalTransitionError 로 거부된다
+var slaContractTransitionTable = []slaContractTransition{
+	// 임시저장
+	{SLA_CONTRACT_ACTION_CREATE_TEMP, SLA_CONTRACT_PROGRESSION_NONE, "", SLA_CONTRACT_PROGRESSION_TEMP, -1, ""},
+	{SLA_CONTRACT_ACTION_CREATE_TEMP, SLA_CONTRACT_PROGRESSION_TEMP, "", SLA_CONTRACT_PROGRESSION_TEMP, -1, ""},
+
+	// 결재 요청: 최초 생성 / 임시저장 후 / 반려 후
+	{SLA_CONTRACT_ACTION_SUBMIT, SLA_CONTRACT_PROGRESSION_NONE, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, 0, SLA_APPROVAL_STATE_SUBMITTED},
+	{SLA_CONTRACT_ACTION_SUBMIT, SLA_CONTRACT_PROGRESSION_TEMP, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, 0, SLA_APPROVAL_STATE_SUBMITTED},
+	{SLA_CONTRACT_ACTION_SUBMIT, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, SLA_APPROVAL_STATE_REJECTED, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, 0, SLA_APPROVAL_STATE_SUBMITTED},
+	{SLA_CONTRACT_ACTION_SUBMIT, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, SLA_APPROVAL_STATE_REJECTED, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, 0, SLA_APPROVAL_STATE_SUBMITTED},
+	{SLA_CONTRACT_ACTION_SUBMIT, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, SLA_APPROVAL_STATE_REJECTED, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, 0, SLA_APPROVAL_STATE_SUBMITTED},
+
+	// 계약 수정: 진행단계는 유지
+	{SLA_CONTRACT_ACTION_UPDATE, SLA_CONTRACT_PROGRESSION_TEMP, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_TEMP, -1, ""},
+	{SLA_CONTRACT_ACTION_UPDATE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, -1, ""},
+	{SLA_CONTRACT_ACTION_UPDATE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, -1, ""},
+	{SLA_CONTRACT_ACTION_UPDATE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, -1, ""},
+
+	// 승인: 반려되지 않은 결재만 승인 가능
+	{SLA_CONTRACT_ACTION_APPROVE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, 1, SLA_APPROVAL_STATE_APPROVED},
+	{SLA_CONTRACT_ACTION_APPROVE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, 2, SLA_APPROVAL_STATE_APPROVED},
+	{SLA_CONTRACT_ACTION_APPROVE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_CLOSED, 3, SLA_APPROVAL_STATE_APPROVED},
+
+	// 반려: 진행단계는 유지
+	{SLA_CONTRACT_ACTION_REJECT, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, 1, SLA_APPROVAL_STATE_REJECTED},
+	{SLA_CONTRACT_ACTION_REJECT, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, 2, SLA_APPROVAL_STATE_REJECTED},
+	{SLA_CONTRACT_ACTION_REJECT, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, 3, SLA_APPROVAL_STATE_REJECTED},
+
+	// 최종 승인
+	{SLA_CONTRACT_ACTION_CLOSE, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, "", SLA_CONTRACT_PROGRESSION_CLOSED, 3, SLA_APPROVAL_STATE_APPROVED},
+
+	// 폐기: 최종 승인 전이라면 언제든지
+	{SLA_CONTRACT_ACTION_ABANDON, SLA_CONTRACT_PROGRESSION_TEMP, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_ABANDONED, -1, ""},
+	{SLA_CONTRACT_ACTION_ABANDON, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_ABANDONED, -1, ""},
+	{SLA_CONTRACT_ACTION_ABANDON, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_ABANDONED, -1, ""},
+	{SLA_CONTRACT_ACTION_ABANDON, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, SLA_APPROVAL_STATE_ANY, SLA_CONTRACT_PROGRESSION_ABANDONED, -1, ""},
+}
+
+// SlaIllegalTransitionError 는 현재 상태에서 허용되지 않는 함수가 호출되었을 때 리턴됩니다.
+type SlaIllegalTransitionError struct {
+	Action        string // 호출 함수
+	RegId         string // SLA계약등록번호
+	Progression   string // 현재 진행단계
+	ApprovalState string // 현재 결재자의 결재상태
+}
+
+func (e *SlaIllegalTransitionError) Error() string {
+	progression := e.Progression
+	if progression == SLA_CONTRACT_PROGRESSION_NONE {
+		progression = "NONE"
+	}
+	if e.ApprovalState != "" {
+		progression += "/" + e.ApprovalState
+	}
+	return e.Action + " cannot have the current progression of \"" + progression + "\" for " + e.RegId
+}
+
+// SlaUnauthorizedError 는 트랜잭션 인증서(TCert)의 속성이 호출 권한과 일치하지 않을 때 리턴됩니다.
+type SlaUnauthorizedError struct {
+	Action string // 호출 함수
+	RegId  string // SLA계약등록번호 또는 평가등록번호
+	UserId string // 요청한 사용자ID
+}
+
+func (e *SlaUnauthorizedError) Error() string {
+	return e.Action + " is not authorized for " + e.UserId + " on " + e.RegId
+}
+
+// SlaContractNotFoundError 는 KVS에 저장되지 않은 계약에 대해 함수가 호출되었을 때 리턴됩니다.
+type SlaContractNotFoundError struct {
+	RegId string // SLA계약등록번호
+}
+
+func (e *SlaContractNotFoundError) Error() string {
+	return "No contract found with " + e.RegId
+}
+
+//  평가 상태: SlaEvaluation -> PROGRESSION
+// -------------------------------------------------------------------------------------------------------
+//  1. [slaCreateEvaluationsFromContract] 평가 생성상태: 						SLA_EVALUATION_PROGRESSION_TEMP
+//  2. [slaInitEvaluationValues / slaUpdateEvaluationValues] 평가점수 입력상태: 	SLA_EVALUATION_PROGRESSION_VALUES_ENTERED
+//  3. [slaSubmitEvaluation] 내부 검토 요청상태: 								SLA_EVALUATION_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED
+//  4. [slaApproveEvaluation] 고객현업 검토 요청상태:							SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED
+//  5. [slaApproveEvaluation] 고객관리자 검토 요청상태: 						SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED
+//  6. [slaApproveEvaluation] 평가 승인 완료상태: 								SLA_EVALUATION_PROGRESSION_APPROVED
+//  7. [slaSubmitPayment] 지급 요청상태: 										SLA_EVALUATION_PROGRESSION_PAYMENT_REQUESTED
+//  8. [slaClosePayment] 지급 완료상태: 										SLA_EVALUATION_PROGRESSION_PAID
+//  9. [slaCloseEvaluation] 평가 종료상태: 										SLA_EVALUATION_PROGRESSION_CLOSED
+// -------------------------------------------------------------------------------------------------------
+//  * 반려될 경우 (slaRejectEvaluation) 평가점수 입력상태로 돌아간다
+//  * 마지막 개별 평가가 종료되면 전체 평가(SlaEvalutionRoot)도 종료된다
+// -------------------------------------------------------------------------------------------------------
+const SLA_EVALUATION_PROGRESSION_TEMP = "TEMP"
+const SLA_EVALUATION_PROGRESSION_VALUES_ENTERED = "VALUES_ENTERED"
+const SLA_EVALUATION_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED = "IN_PROGRESS_INTERNAL_REVIEW_REQUESTED"
+const SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED = "IN_PROGRESS_CLIENT_REVIEW_REQUESTED"
+const SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED = "IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED"
+const SLA_EVALUATION_PROGRESSION_APPROVED = "APPROVED"
+const SLA_EVALUATION_PROGRESSION_PAYMENT_REQUESTED = "PAYMENT_REQUESTED"
+const SLA_EVALUATION_PROGRESSION_PAID = "PAID"
+const SLA_EVALUATION_PROGRESSION_CLOSED = "CLOSED"
+
+// 전체 평가 상태: SlaEvalutionRoot -> Status
+const SLA_EVALUATION_ROOT_STATUS_IN_PROGRESS = "IN_PROGRESS"
+const SLA_EVALUATION_ROOT_STATUS_CLOSED = "CLOSED"
+
+// 계약/평가 결재선의 최소 인원 (기안자, 내부관리자, 고객현업, 고객관리자)
+const SLA_NUM_APPROVALS = 4
 
 // ===========================================================
 // Utility 함수
 // ===========================================================
 
+// 호출자의 트랜잭션 인증서(TCert)에서 사용자ID 속성을 읽습니다. (속성이 없으면 "")
+func slaReadCallerUserId(stub shim.ChaincodeStubInterface) string {
+	userId, err := stub.ReadCertAttribute(SLA_ATTR_USER_ID)
+	if err != nil {
+		return ""
+	}
+	return string(userId)
+}
+
+// 진행단계별로 현재 결재자의 결재 순번을 찾습니다. (결재자가 없으면 -1)
+func slaReviewApprovalIndex(progression string) int {
+	switch progression {
+	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED:
+		return 1
+	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED:
+		return 2
+	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED:
+		return 3
+	}
+	return -1
+}
+
+// 호출자의 트랜잭션 인증서(TCert)에 결재자의 사용자ID, 회사명, 부서명 속성이 있는지 확인합니다.
+func slaVerifyApprover(stub shim.ChaincodeStubInterface, action string, regId string, userId string, approval SlaApproval) error {
+	if userId == "" || userId != approval.ApprovalUserId {
+		return &SlaUnauthorizedError{action, regId, userId}
+	}
+
+	ok, err := stub.VerifyAttributes(
+		&attr.Attribute{Name: SLA_ATTR_USER_ID, Value: []byte(approval.ApprovalUserId)},
+		&attr.Attribute{Name: SLA_ATTR_COMPANY, Value: []byte(approval.ApprovalCompany)},
+		&attr.Attribute{Name: SLA_ATTR_DEPARTMENT, Value: []byte(approval.ApprovalDepartment)},
+	)
+	if err != nil || !ok {
+		return &SlaUnauthorizedError{action, regId, userId}
+	}
+	return nil
+}
+
+// 호출자의 트랜잭션 인증서(TCert)에 지급자 역할과 사용자ID 속성이 있는지 확인합니다.
+func slaVerifyPayer(stub shim.ChaincodeStubInterface, action string, regId string, userId string) error {
+	if userId == "" {
+		return &SlaUnauthorizedError{action, regId, userId}
+	}
+
+	ok, err := stub.VerifyAttributes(
+		&attr.Attribute{Name: SLA_ATTR_USER_ID, Value: []byte(userId)},
+		&attr.Attribute{Name: SLA_ATTR_ROLE, Value: []byte(SLA_ROLE_PAYER)},
+	)
+	if err != nil || !ok {
+		return &SlaUnauthorizedError{action, regId, userId}
+	}
+	return nil
+}
+
+// 계약 상태 전이표에서 현재 상태에 대한 전이를 찾습니다.
+func slaFindContractTransition(action string, slaContractRegId string, currentContract SlaContract) (slaContractTransition, error) {
+	approvalState := ""
+	approvalIndex := slaReviewApprovalIndex(currentContract.Progression)
+	if approvalIndex >= 0 && approvalIndex < len(currentContract.Approvals) &&
+		currentContract.Approvals[approvalIndex].ApprovalState == SLA_APPROVAL_STATE_REJECTED {
+		approvalState = SLA_APPROVAL_STATE_REJECTED
+	}
+
+	for _, transition := range slaContractTransitionTable {
+		if transition.Action != action || transition.From != currentContract.Progression {
+			continue
+		}
+		if transition.FromApproval == SLA_APPROVAL_STATE_ANY || transition.FromApproval == approvalState {
+			return transition, nil
+		}
+	}
+	return slaContractTransition{}, &SlaIllegalTransitionError{action, slaContractRegId, currentContract.Progression, approvalState}
+}
+
 func padLeft(str string, padLength int) string {
 	pad := "0"
 
@@ -154,23 +421,32 @@ func padLeft(str string, padLength int) string {
 //  Initialization 함수
 // ===========================================================
 
+// SimpleChaincode 는 단일 진입점 (Init(stub)/Invoke(stub)) 형태로 구현되어 있으므로,
+// 현재 peer 에서는 shim.NewChaincodeAdapter 로 감싸서 실행합니다. 조회 함수도 Invoke 로 처리됩니다.
+
 // 초기화를 처리합니다.
-func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+func (t *SimpleChaincode) Init(stub shim.ChaincodeStubInterface) ([]byte, error) {
 
-	year, _, _ := time.Now().Date()
+	txTime, err := slaGetTxTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	year := txTime.Year()
 
 	stub.PutState(SLA_CONTRACT_ID_COUNT_KEY, []byte(strconv.Itoa(1)))
 	stub.PutState(SLA_CONTRACT_TEMP_ID_COUNT_KEY, []byte(strconv.Itoa(1)))
 	stub.PutState(SLA_EVALUATION_ID_COUNT_KEY, []byte(strconv.Itoa(1)))
 	stub.PutState(SLA_EVALUATION_TEMP_ID_COUNT_KEY, []byte(strconv.Itoa(1)))
 	stub.PutState(CURRENT_YEAR_KEY, []byte(strconv.Itoa(year))) // 현재 year
+	stub.PutState(SLA_EVALUATION_YEAR_KEY, []byte(strconv.Itoa(year)))
 	// err = stub.PutState(SLA_ALL_DATA, []byte(""))
 	return nil, nil
 }
 
-// 기능 이벤트를 호출합니다.
-func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+// 기능 이벤트와 쿼리 이벤트를 처리합니다.
+func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface) ([]byte, error) {
 
+	function, args := stub.GetFunctionAndParameters()
 	switch function {
 
 	// 최초 생성 + 임시 저장
@@ -197,17 +473,15 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 
 	// 최종 폐
 	case "slaAbandonContract": // 요청자가 계약 폐기 (임시저장 후 / 승인거절 후)
-		return t.slaUpdateContract(stub, args)
+		return t.slaAbandonContract(stub, args)
 
 	// 전체 평가 생성
 	case "slaCreateEvaluationTemplateFromContract": // 최초 평가 생성 (계약등록 최종 승인 후),
-		_, err1 := t.slaCreateEvaluationRootFromContract(stub, args)
-		_, err2 := t.slaCreateEvaluationsFromContract(stub, args)
-
-		if err1 == nil {
-			err1 = err2
+		evaluationRootIdInBytes, err := t.slaCreateEvaluationRootFromContract(stub, args)
+		if err != nil {
+			return nil, err
 		}
-		return nil, err1
+		return t.slaCreateEvaluationsFromContract(stub, []string{string(evaluationRootIdInBytes)})
 
 	// 개별 평가 진행
 	case "slaInitEvaluationValues": // 개별 평가의 평가점수 입력
@@ -241,15 +515,11 @@ func (t *SimpleChaincode) Invoke(stub shim.ChaincodeStubInterface, function stri
 	case "slaCloseEvaluationRoot": // 마지막 개별 평가가 마무리될 경우, 자동 호출
 		return t.slaCloseEvaluationRoot(stub, args)
 
-	}
-	return nil, errors.New("Invalid invoke function name. Expecting \"slaCreateContract\" \"slaUpdateContract\" \"slaApproveContract\" \"slaRejectContract\"")
-}
-
-// 쿼리 이벤트를 처리합니다.
-func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
-
-	switch function {
+	// 이전 버전 ledger 의 인덱스 변환 (1회)
+	case "slaMigrateIndexes":
+		return t.slaMigrateIndexes(stub, args)
 
+	// 조회
 	case "slaGetContractTempId":
 		return t.slaGetContractTempId(stub, args)
 
@@ -268,30 +538,33 @@ func (t *SimpleChaincode) Query(stub shim.ChaincodeStubInterface, function strin
 	case "slaGetContractsWithClient":
 		return t.slaGetContractsWithClient(stub, args)
 
+	case "slaGetContractHistory":
+		return t.slaGetContractHistory(stub, args)
+
 	case "slaGetEvaluationId":
 		return t.slaGetEvaluationId(stub, args)
 
 	case "slaGetAllEvaluations":
-		return t.slaGetAllContracts(stub, args)
+		return t.slaGetAllEvaluations(stub, args)
 
 	case "slaGetEvaluationWithId":
-		return t.slaGetContractWithId(stub, args)
+		return t.slaGetEvaluationWithId(stub, args)
 
 	case "slaGetEvaluationsWithName":
-		return t.slaGetContractsWithName(stub, args)
+		return t.slaGetEvaluationsWithName(stub, args)
 
 	case "slaGetEvaluationsWithClient":
-		return t.slaGetContractsWithClient(stub, args)
+		return t.slaGetEvaluationsWithClient(stub, args)
 
 	}
-	return nil, errors.New("Invalid Query function name. Expecting \"slaGetAllContracts\" \"slaGetContractWithId\" \"slaGetContractsWithName\" \"slaGetContractsWithClient\"")
+	return nil, errors.New("Invalid invoke function name. Expecting \"slaCreateContract\" \"slaUpdateContract\" \"slaApproveContract\" \"slaRejectContract\" \"slaGetAllContracts\" \"slaGetContractWithId\" \"slaGetContractsWithName\" \"slaGetContractsWithClient\"")
 }
 
 // 메인함수를 처리합니다.
 func main() {
 
 	// 블록체인 이벤트를 호출합니다.
-	err := shim.Start(new(SimpleChaincode))
+	err := shim.Start(shim.NewChaincodeAdapter(new(SimpleChaincode)))
 	if err != nil {
 		fmt.Printf("Er4ror starting Simple chaincode: %s", err)
 	}
@@ -316,8 +589,11 @@ func (t *SimpleChaincode) slaGetContractTempId(stub shim.ChaincodeStubInterface,
 	currentCount, _ := strconv.Atoi(string(currentCountInBytes))
 
 	// 계약번호 채번을 생성합니다.
-	currentYear, _, _ := time.Now().Date()
-	contractTempId = CONTRACT_TEMP_ID_PREFIX + strconv.Itoa(currentYear) + "_" + padLeft(strconv.Itoa(currentCount), 5)
+	txTime, err := slaGetTxTime(stub)
+	if err != nil {
+		return nil, err
+	}
+	contractTempId = CONTRACT_TEMP_ID_PREFIX + strconv.Itoa(txTime.Year()) + "_" + padLeft(strconv.Itoa(currentCount), 5)
 
 	return []byte(contractTempId), nil
 }
@@ -326,16 +602,11 @@ func (t *SimpleChaincode) slaGetContractId(stub shim.ChaincodeStubInterface, arg
 	var contractId string
 	var err error
 
-	// 1.저장된 해당 계약 카운트를 호출
-	currentCountInBytes, err := stub.GetState(SLA_CONTRACT_ID_COUNT_KEY)
+	// 1.트랜잭션 연도의 계약 카운트를 호출
+	currentYear, currentCount, err := slaGetYearlyCount(stub, SLA_CONTRACT_ID_COUNT_KEY, CURRENT_YEAR_KEY)
 	if err != nil {
-		return nil, errors.New("Failed to SLA_CONTRACT_ID_COUNT_KEY with " + SLA_CONTRACT_ID_COUNT_KEY)
-	}
-	if currentCountInBytes == nil { // if not initialized
-		err = stub.PutState(SLA_CONTRACT_ID_COUNT_KEY, []byte(strconv.Itoa(1)))
+		return nil, err
 	}
-	currentCount, _ := strconv.Atoi(string(currentCountInBytes))
-	currentYear, _, _ := time.Now().Date()
 
 	// 계약번호 채번을 생성합니다.
 	contractId = CONTRACT_ID_PREFIX + strconv.Itoa(currentYear) + "_" + padLeft(strconv.Itoa(currentCount), 5)
@@ -356,8 +627,19 @@ func (t *SimpleChaincode) slaCreateTempContract(stub shim.ChaincodeStubInterface
 		return nil, errors.New("Failed to registerContractByIdToJSON with " + args[0])
 	}
 
-	// 계약상태를 SLA_CONTRACT_PROGRESSION_TEMP 로 변경
-	targetContract.Progression = SLA_CONTRACT_PROGRESSION_TEMP
+	// 저장된 계약의 상태를 확인하여 계약상태를 SLA_CONTRACT_PROGRESSION_TEMP 로 변경
+	currentContract, err := t.slaGetContractIfExists(stub, targetContract.RegId)
+	if err != nil {
+		return nil, err
+	}
+	transition, err := slaFindContractTransition(SLA_CONTRACT_ACTION_CREATE_TEMP, targetContract.RegId, currentContract)
+	if err != nil {
+		return nil, err
+	}
+	err = t.slaApplyContractTransition(stub, transition, &targetContract, "", "")
+	if err != nil {
+		return nil, err
+	}
 
 	// JSON 데이터를 정렬하여 디코딩(Unmarshal)합니다: golang struct --> string
 	targetContractInJson, err := json.MarshalIndent(targetContract, "", "  ")
@@ -371,29 +653,15 @@ func (t *SimpleChaincode) slaCreateTempContract(stub shim.ChaincodeStubInterface
 		return nil, errors.New("Failed to put state with" + args[0])
 	}
 
-	// 전체 조회 등록합니다.
-	{
-		var err error
-
-		// 데이터를 전체 조회합니다.
-		contractALLIdsInBytes, err := stub.GetState(SLA_ALL_DATA) // 리턴값 ([]byte, error)
-		if err != nil {
-			return nil, errors.New("Failed to get state with" + string(contractALLIdsInBytes))
-		}
-		contractALLIdsInString := string(contractALLIdsInBytes)
+	// 이미 임시저장된 계약을 다시 저장하는 경우에는 전체 조회와 계약카운트를 변경하지 않음
+	if transition.From == SLA_CONTRACT_PROGRESSION_TEMP {
+		return nil, nil
+	}
 
-		// 최초이 없을경우 "계약명"-"계약ID목록" 등록 or 기존에 포함되어 있으면
-		if contractALLIdsInString == "" {
-			err = stub.PutState(SLA_ALL_DATA, []byte(targetContract.RegId))
-			if err != nil {
-				return nil, err
-			}
-		} else if !strings.Contains(contractALLIdsInString, targetContract.RegId) { // 계약번호가 존재하지 않을 때만 추가
-			err = stub.PutState(SLA_ALL_DATA, []byte(contractALLIdsInString+FIELDSEP+targetContract.RegId))
-			if err != nil {
-				return nil, err
-			}
-		}
+	// 전체 조회 등록합니다.
+	err = shim.PutIndexEntry(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, targetContract.RegId)
+	if err != nil {
+		return nil, err
 	}
 
 	// 계약카운트 증가
@@ -418,25 +686,42 @@ func (t *SimpleChaincode) slaCreateTempContract(stub shim.ChaincodeStubInterface
 
 }
 
-// 최초 생성 + 내부결제를 요청합니다.
+// 최초 생성 + 내부결제를 요청합니다. (임시저장 후 / 반려 후 재요청 포함)
 // KVS: 계약ID, 전체계약, 계약명, 고객명에 따른 KVS 저장
 func (t *SimpleChaincode) slaSubmitContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	var err error
 	var targetContract SlaContract
 
-	// todo 동일한 ID의 계약이 있을경우 튕겨 내야함.... submit 두번 할 수 없도록 해야함....
-
 	// JSON 데이터를 디코딩(Unmarshal)합니다: string -> []byte -> golang struct
 	err = json.Unmarshal([]byte(args[0]), &targetContract)
 	if err != nil {
 		return nil, errors.New("Failed to slaSubmitContract with " + args[0])
 	}
 
+	// 저장된 계약의 상태를 확인: 이미 결재 진행 중인 계약은 반려된 경우에만 다시 요청할 수 있음
+	currentContract, err := t.slaGetContractIfExists(stub, targetContract.RegId)
+	if err != nil {
+		return nil, err
+	}
+	transition, err := slaFindContractTransition(SLA_CONTRACT_ACTION_SUBMIT, targetContract.RegId, currentContract)
+	if err != nil {
+		return nil, err
+	}
+
+	// 재요청인 경우 반려된 결재를 초기화
+	for i := 1; i < len(targetContract.Approvals); i++ {
+		if targetContract.Approvals[i].ApprovalState == SLA_APPROVAL_STATE_REJECTED {
+			targetContract.Approvals[i].ApprovalState = SLA_APPROVAL_STATE_TEMP
+		}
+	}
+
 	// 상태 변경: 계약상태 + Approvals[0]의 상태
-	// 계약상태를 SLA_CONTRACT_PROGRESSION_TEMP 로 변경
-	targetContract.Progression = SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED
+	// 계약상태를 SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED 로,
 	// 첫번째 Approval의 state를 "SUBMITTED"로 변경
-	targetContract.Approvals[0].ApprovalState = SLA_APPROVAL_STATE_SUBMITTED
+	err = t.slaApplyContractTransition(stub, transition, &targetContract, "", "")
+	if err != nil {
+		return nil, err
+	}
 
 	// JSON 데이터를 정렬하여 디코딩(Unmarshal)합니다: golang struct --> string
 	targetContractInJson, err := json.MarshalIndent(targetContract, "", "  ")
@@ -450,105 +735,25 @@ func (t *SimpleChaincode) slaSubmitContract(stub shim.ChaincodeStubInterface, ar
 		return nil, errors.New("Failed to put state with" + args[0])
 	}
 
-	// A02. 계약명 등록합니다.
-	{
-		var err error
-
-		// 계약명으로 기존내역를 조회합니다.
-		contractIDsInBytes, err := stub.GetState(targetContract.Name)
-		if err != nil {
-			return nil, errors.New("Failed to get state with" + targetContract.Name)
-		}
-		contractIDsInString := string(contractIDsInBytes)
-
-		//기존내역이 없을경우 "계약명"-"계약ID목록" 등록
-		if contractIDsInString == "" {
-			err = stub.PutState(targetContract.Name, []byte(targetContract.RegId))
-		} else {
-			err = stub.PutState(targetContract.Name, []byte(contractIDsInString+FIELDSEP+targetContract.RegId))
-		}
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// A03. 고객사명 등록합니다.
-	{
-		var err error
-
-		//계약명으로 기존내역조회
-		contractIDsInBytes, _ := stub.GetState(targetContract.Client) // 리턴값 ([]byte, error)
-		if err != nil {
-			return nil, errors.New("Failed to get state with" + targetContract.Client)
-		}
-		contractIDsInString := string(contractIDsInBytes)
-
-		//기존내역이 없을경우 "고객사명"-"계약ID목록" 등록
-		if contractIDsInString == "" {
-			err = stub.PutState(targetContract.Client, []byte(targetContract.RegId))
-		} else {
-			err = stub.PutState(targetContract.Client, []byte(contractIDsInString+FIELDSEP+targetContract.RegId))
-		}
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// A04. 전체 조회 등록합니다.  단 임시저장 때 계약번호를 딴 경우가 있으므로 이미 있는경우에는 건너 뛴다
-	{
-		var err error
-
-		// 데이터를 전체 조회합니다.
-		contractALLIdsInBytes, err := stub.GetState(SLA_ALL_DATA) // 리턴값 ([]byte, error)
-		if err != nil {
-			return nil, errors.New("Failed to get state with" + string(contractALLIdsInBytes))
-		}
-		contractALLIdsInString := string(contractALLIdsInBytes)
-
-		// 최초이 없을경우 "계약명"-"계약ID목록" 등록 or
-		if contractALLIdsInString == "" {
-			err = stub.PutState(SLA_ALL_DATA, []byte(targetContract.RegId))
-			if err != nil {
-				return nil, err
-			}
-		} else if !strings.Contains(contractALLIdsInString, targetContract.RegId) { // 계약번호가 존재하지 않을 때만 추가
-			err = stub.PutState(SLA_ALL_DATA, []byte(contractALLIdsInString+FIELDSEP+targetContract.RegId))
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	// 계약 카운트 증가  <-- moved from slaGetContractId
-	currentCountInBytes, err := stub.GetState(SLA_CONTRACT_ID_COUNT_KEY)
-	if err != nil {
-		return nil, errors.New("Failed to SLA_CONTRACT_ID_COUNT_KEY with " + SLA_CONTRACT_ID_COUNT_KEY)
+	// 반려 후 재요청인 경우 계약명, 고객사명, 전체 조회, 계약 카운트는 이미 등록되어 있음
+	if transition.From != SLA_CONTRACT_PROGRESSION_NONE && transition.From != SLA_CONTRACT_PROGRESSION_TEMP {
+		return nil, nil
 	}
-	if currentCountInBytes == nil { // if not initialized
-		err = stub.PutState(SLA_CONTRACT_ID_COUNT_KEY, []byte(strconv.Itoa(1)))
-	}
-	currentCount, _ := strconv.Atoi(string(currentCountInBytes))
-	currentYear, _, _ := time.Now().Date()
 
-	// 새로운 연도일 경우, 계약 카운트를 초기화 --> "slaSubmitContract" 로 이동
-	kvsCurrentYearInBytes, err := stub.GetState(CURRENT_YEAR_KEY)
+	// A02. 계약명, 고객사명, 전체 조회 등록합니다.
+	// 임시저장 때 이미 전체 조회에 등록된 경우에도 같은 키를 다시 쓰므로 중복되지 않음
+	err = t.slaPutContractIndexes(stub, targetContract)
 	if err != nil {
-		return nil, errors.New("Failed to kvsCurrentYearInBytes with " + CURRENT_YEAR_KEY)
-	}
-	if kvsCurrentYearInBytes == nil { // if not initialized
-		err = stub.PutState(CURRENT_YEAR_KEY, []byte(strconv.Itoa(currentYear)))
+		return nil, err
 	}
-	if string(kvsCurrentYearInBytes) != strconv.Itoa(currentYear) { // new year starts
-		err = stub.PutState(CURRENT_YEAR_KEY, []byte(strconv.Itoa(currentYear))) // 새로운 현재 연도
-		err = stub.PutState(SLA_CONTRACT_ID_COUNT_KEY, []byte(strconv.Itoa(1)))  // 카운트는 1부터
-	}
-	nextCount := currentCount + 1
-	stub.PutState(SLA_CONTRACT_ID_COUNT_KEY, []byte(strconv.Itoa(nextCount)))
 
-	return nil, nil
+	// 계약 카운트 증가  <-- moved from slaGetContractId
+	// 새로운 연도일 경우, 계약 카운트를 초기화
+	return nil, slaIncreaseYearlyCount(stub, SLA_CONTRACT_ID_COUNT_KEY, CURRENT_YEAR_KEY)
 }
 
-// 1.계약을 업데이트 합니다.
+// 1.계약을 업데이트 합니다. (임시저장 후 / 결재 진행 중)
+// 진행단계와 결재상태는 저장된 계약의 값을 그대로 유지합니다.
 func (t *SimpleChaincode) slaUpdateContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 
 	var err error
@@ -562,290 +767,1029 @@ func (t *SimpleChaincode) slaUpdateContract(stub shim.ChaincodeStubInterface, ar
 		return nil, errors.New("Failed to slaUpdateContract with " + args[0])
 	}
 
-	// 계약ID 통해 데이터를 업데이트 합니다.
-	err = stub.PutState(targetContract.RegId, []byte(args[0]))
+	// 저장된 계약의 상태를 확인
+	currentContract, err := t.slaGetContract(stub, targetContract.RegId)
 	if err != nil {
-		return nil, errors.New("Failed to put state with" + args[0])
+		return nil, err
 	}
-
-	return nil, nil
-}
-
-// 2.계약을 승인합니다.
-func (t *SimpleChaincode) slaApproveContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-
-	//계약번호 > 계약 시퀀스 >  결제 정보
-	var err error
-	var targetContract SlaContract
-
-	// 1. 해당 계약 찾기 및 Struct 생성
-	slaContractRegId := args[0]
-	targetContractInBytes, err := stub.GetState(slaContractRegId)
+	transition, err := slaFindContractTransition(SLA_CONTRACT_ACTION_UPDATE, targetContract.RegId, currentContract)
 	if err != nil {
-		return nil, errors.New("Failed to get state with " + string(targetContractInBytes))
+		return nil, err
 	}
 
-	// JSON 데이터를 디코딩(Unmarshal)합니다: string -> []byte -> golang struct
-	err = json.Unmarshal([]byte(targetContractInBytes), &targetContract)
+	// 결재 요청 후에는 결재선(결재자의 사용자ID, 회사, 부서, 이름)을 변경할 수 없으며,
+	// 결재선은 저장된 값을 그대로 유지
+	if currentContract.Progression != SLA_CONTRACT_PROGRESSION_TEMP {
+		if len(targetContract.Approvals) != len(currentContract.Approvals) {
+			return nil, errors.New("slaUpdateContract cannot change approvals of " + targetContract.RegId + " in progression " + currentContract.Progression)
+		}
+		for i, approval := range currentContract.Approvals {
+			target := targetContract.Approvals[i]
+			if target.ApprovalUserId != approval.ApprovalUserId ||
+				target.ApprovalCompany != approval.ApprovalCompany ||
+				target.ApprovalDepartment != approval.ApprovalDepartment ||
+				target.ApprovalName != approval.ApprovalName {
+				return nil, errors.New("slaUpdateContract cannot change approvals of " + targetContract.RegId + " in progression " + currentContract.Progression)
+			}
+		}
+		targetContract.Approvals = currentContract.Approvals
+	}
+	err = t.slaApplyContractTransition(stub, transition, &targetContract, "", "")
 	if err != nil {
-		return nil, errors.New("Failed to slaApproveContract with " + string(targetContractInBytes))
+		return nil, err
 	}
 
-	// 2. 현재의 진행단계(Progression)를 확인하여 현재 진행단계를 찾고 변경할 Approval을 찾음
-	var newProgression string       // new progression for target contract
-	var targetApproval *SlaApproval // approval to update for target contract
-
-	switch targetContract.Progression {
-	case SLA_CONTRACT_PROGRESSION_TEMP:
-		return nil, errors.New("Reject cannot have the current progression of \"TEMP\" ")
-	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED:
-		newProgression = SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED
-		targetApproval = &(targetContract.Approvals[1]) // approval to change
-	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED:
-		newProgression = SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED
-		targetApproval = &(targetContract.Approvals[2]) // approval to change
-	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED:
-		newProgression = SLA_CONTRACT_PROGRESSION_CLOSED
-		targetApproval = &(targetContract.Approvals[3]) // approval to change
-	case SLA_CONTRACT_PROGRESSION_CLOSED:
-		return nil, errors.New("Approval cannot have the current progression of \"CLOSED\" ")
-	case SLA_CONTRACT_PROGRESSION_ABANDONED:
-		return nil, errors.New("Approval cannot have the current progression of \"ABANDONED\" ")
-	default:
-		return nil, errors.New("Approval cannot have the current progression of " + targetContract.Progression)
+	// 결재 진행 중에 계약명 또는 고객사명이 바뀌면 인덱스를 갱신
+	if currentContract.Progression != SLA_CONTRACT_PROGRESSION_TEMP &&
+		(currentContract.Name != targetContract.Name || currentContract.Client != targetContract.Client) {
+		err = t.slaDelContractIndexes(stub, currentContract)
+		if err != nil {
+			return nil, err
+		}
+		err = t.slaPutContractIndexes(stub, targetContract)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	//3. 해당 결재 내용으로 변경
-	targetContract.Progression = newProgression                   // 새 진행단계 (Progression)
-	targetApproval.ApprovalUserId = args[1]                       // 결재사용자ID
-	targetApproval.ApprovalState = SLA_APPROVAL_STATE_APPROVED    // 승인상태
-	targetApproval.ApprovalDate = time.Now().Format("2006-01-02") // 현재일자
-	targetApproval.ApprovalComment = args[2]                      // 의견내용
-
-	targetContractInJson, _ := json.MarshalIndent(targetContract, "", "  ")
-
-	// 4. 변경된 계약을 KVS에 저장합니다.
-	err = stub.PutState(slaContractRegId, []byte(targetContractInJson))
+	// 계약ID 통해 데이터를 업데이트 합니다.
+	err = t.slaPutContract(stub, targetContract)
 	if err != nil {
-		return nil, errors.New("Failed to put state with" + string(targetContractInJson))
+		return nil, err
 	}
 
 	return nil, nil
 }
 
-// 3.계약을 반려합니다.
+// 2.계약을 승인합니다. 호출자의 TCert 속성이 결재 차례인 결재자와 일치해야 합니다.
+func (t *SimpleChaincode) slaApproveContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, errors.New("Incorrect number of arguments. Expecting contract id, user id and comment to slaApproveContract")
+	}
+	return nil, t.slaProgressContract(stub, SLA_CONTRACT_ACTION_APPROVE, args[0], args[1], args[2])
+}
+
+// 3.계약을 반려합니다. 반려된 계약은 해당 진행단계에 머무릅니다.
+// 호출자의 TCert 속성이 결재 차례인 결재자와 일치해야 합니다.
 func (t *SimpleChaincode) slaRejectContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	// 계약번호 > 계약 시퀀스 >  결제 정보
+	if len(args) != 3 {
+		return nil, errors.New("Incorrect number of arguments. Expecting contract id, user id and comment to slaRejectContract")
+	}
+	return nil, t.slaProgressContract(stub, SLA_CONTRACT_ACTION_REJECT, args[0], args[1], args[2])
+}
 
-	// 계약번호 > 계약 시퀀스 >  결제 정보
-	var err error
-	var targetContract SlaContract
+// 4.계약을 마무리(종료)합니다.
+// 현재의 진행단계(Progression)가 고객관리자에게 승인요청하는 최종 직전 단계여야함
+func (t *SimpleChaincode) slaCloseContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, errors.New("Incorrect number of arguments. Expecting contract id, user id and comment to slaCloseContract")
+	}
+	return nil, t.slaProgressContract(stub, SLA_CONTRACT_ACTION_CLOSE, args[0], args[1], args[2])
+}
+
+// 5.계약을 폐기합니다.
+func (t *SimpleChaincode) slaAbandonContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) < 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting contract id to slaAbandonContract")
+	}
+	return nil, t.slaProgressContract(stub, SLA_CONTRACT_ACTION_ABANDON, args[0], "", "")
+}
 
+// 저장된 계약에 상태 전이를 적용하고 KVS에 저장합니다.
+func (t *SimpleChaincode) slaProgressContract(stub shim.ChaincodeStubInterface, action string, slaContractRegId string, userId string, comment string) error {
 	// 1. 해당 계약 찾기 및 Struct 생성
-	SlaContractRegId := args[0]
-	targetContractInBytes, err := stub.GetState(SlaContractRegId)
+	targetContract, err := t.slaGetContract(stub, slaContractRegId)
 	if err != nil {
-		return nil, errors.New("Failed to get state with " + string(targetContractInBytes))
+		return err
 	}
-	// 저장된 JSON형태의 데이터를 go의 struct 형태로 디코딩(Unmarshal)합니다.
-	err = json.Unmarshal([]byte(targetContractInBytes), &targetContract)
+
+	// 2. 현재의 진행단계(Progression)와 결재상태로 상태 전이를 찾음
+	transition, err := slaFindContractTransition(action, slaContractRegId, targetContract)
 	if err != nil {
-		return nil, errors.New("Failed to slaApproveContract with " + string(targetContractInBytes))
+		return err
 	}
 
-	// 2. 현재의 진행단계(Progression)를 확인하여 변경할 Approval을 찾음
-	// * 현재의 진행단계(Pregression)은 현재 상태 그대로 유지
-	var targetApproval *SlaApproval // approval to update for target contract
-
-	switch targetContract.Progression {
-	case SLA_CONTRACT_PROGRESSION_TEMP:
-		return nil, errors.New("Reject cannot have the current progression of \"TEMP\" ")
-	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED:
-		targetApproval = &(targetContract.Approvals[1]) // approval to change
-	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED:
-		targetApproval = &(targetContract.Approvals[2]) // approval to change
-	case SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED:
-		targetApproval = &(targetContract.Approvals[3]) // approval to change
-	case SLA_CONTRACT_PROGRESSION_CLOSED:
-		return nil, errors.New("Reject cannot have the current progression of \"CLOSED\" ")
-	case SLA_CONTRACT_PROGRESSION_ABANDONED:
-		return nil, errors.New("Reject cannot have the current progression of \"ABANDONED\" ")
-	default:
-		return nil, errors.New("Reject cannot have the current progression of " + targetContract.Progression)
+	// 3. 승인/반려/종료는 결재 차례인 결재자만 가능 (사용자ID가 비어 있어도 반드시 확인)
+	if transition.ApprovalIndex >= 0 && transition.ApprovalIndex < len(targetContract.Approvals) {
+		err = slaVerifyApprover(stub, action, slaContractRegId, userId, targetContract.Approvals[transition.ApprovalIndex])
+		if err != nil {
+			return err
+		}
 	}
 
-	// 3. 데이터 내용 변경
-	targetApproval.ApprovalUserId = args[1]                       // 결재사용자ID
-	targetApproval.ApprovalState = SLA_APPROVAL_STATE_REJECTED    // 승인상태
-	targetApproval.ApprovalDate = time.Now().Format("2006-01-02") // 현재일자
-	targetApproval.ApprovalComment = args[2]                      // 의견내용
-
-	targetContractInJson, _ := json.MarshalIndent(targetContract, "", "  ")
-
-	// 4. 변경된 계약을 KVS에 저장합니다.
-	err = stub.PutState(SlaContractRegId, []byte(targetContractInJson))
-
+	// 4. 해당 결재 내용으로 변경
+	err = t.slaApplyContractTransition(stub, transition, &targetContract, userId, comment)
 	if err != nil {
-		return nil, errors.New("Failed to put state with" + string(targetContractInJson))
+		return err
 	}
 
-	return nil, nil
+	// 5. 변경된 계약을 KVS에 저장합니다.
+	return t.slaPutContract(stub, targetContract)
 }
 
-// 4.계약을 마무리(종료)합니다.
-func (t *SimpleChaincode) slaCloseContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	//계약번호 > 계약 시퀀스 >  결제 정보
-	var err error
-	var targetContract SlaContract
+// 계약의 상태 전이를 적용하고 상태 전이 이력을 기록합니다.
+func (t *SimpleChaincode) slaApplyContractTransition(stub shim.ChaincodeStubInterface, transition slaContractTransition, targetContract *SlaContract, userId string, comment string) error {
+	if transition.ApprovalIndex >= len(targetContract.Approvals) {
+		return errors.New(transition.Action + " requires at least " + strconv.Itoa(transition.ApprovalIndex+1) + " approvals for " + targetContract.RegId)
+	}
+	// 기안자(0번) 이외의 결재상태는 확인된 결재자만 변경할 수 있음
+	if transition.ApprovalIndex > 0 && userId == "" {
+		return &SlaUnauthorizedError{transition.Action, targetContract.RegId, userId}
+	}
 
-	// 1. 해당 계약 찾기 및 Struct 생성
-	slaContractRegId := args[0]
-	targetContractInBytes, err := stub.GetState(slaContractRegId)
+	txTimestamp, err := slaGetTxTimestamp(stub)
 	if err != nil {
-		return nil, errors.New("Failed to get state with " + string(targetContractInBytes))
+		return err
 	}
-
-	// JSON 데이터를 디코딩(Unmarshal)합니다: string -> []byte -> golang struct
-	err = json.Unmarshal([]byte(targetContractInBytes), &targetContract)
+	txDate, err := slaGetTxDate(stub)
 	if err != nil {
-		return nil, errors.New("Failed to slaCloseContract with " + string(targetContractInBytes))
+		return err
 	}
 
-	// 2. 현재의 진행단계(Progression)가 고객관리자에게 승인요청하는 최종 직전 단계여야함
-	if targetContract.Progression != SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED {
-		return nil, errors.New("slaCloseContract cannot have the current progression of " + targetContract.Progression)
+	targetContract.Progression = transition.To // 새 진행단계 (Progression)
+	if transition.ApprovalIndex >= 0 {
+		targetApproval := &(targetContract.Approvals[transition.ApprovalIndex]) // approval to change
+		targetApproval.ApprovalState = transition.ApprovalState                 // 결재상태
+		if userId != "" {
+			targetApproval.ApprovalUserId = userId   // 결재사용자ID
+			targetApproval.ApprovalDate = txDate     // 트랜잭션 일자
+			targetApproval.ApprovalComment = comment // 의견내용
+		}
 	}
 
-	// 3. 최종 진행단계(Progression)를 확인하여 현재 진행단계를 찾고 변경할 Approval을 찾음
-	targetApproval := &(targetContract.Approvals[3]) // approval to update for target contract
+	err = t.slaAppendContractHistory(stub, targetContract.RegId, SlaContractHistory{
+		TxId:      stub.GetTxID(),
+		Timestamp: txTimestamp,
+		Action:    transition.Action,
+		From:      transition.From,
+		To:        transition.To,
+		UserId:    userId,
+	})
+	if err != nil {
+		return err
+	}
+	return slaSetContractEvent(stub, transition, *targetContract, userId, txTimestamp)
+}
 
-	// 4. 해당 결재 내용으로 변경
-	targetContract.Progression = SLA_CONTRACT_PROGRESSION_CLOSED  // 새 진행단계 (Progression)
-	targetApproval.ApprovalUserId = args[1]                       // 결재사용자ID
-	targetApproval.ApprovalState = SLA_APPROVAL_STATE_APPROVED    // 승인상태
-	targetApproval.ApprovalDate = time.Now().Format("2006-01-02") // 현재일자
-	targetApproval.ApprovalComment = args[2]                      // 의견내용
+// 계약 상태 전이에 해당하는 chaincode event 를 설정합니다. 해당하는 event 가 없으면 설정하지 않습니다.
+func slaSetContractEvent(stub shim.ChaincodeStubInterface, transition slaContractTransition, targetContract SlaContract, userId string, txTimestamp string) error {
+	var eventName string
+	switch {
+	case transition.To == SLA_CONTRACT_PROGRESSION_CLOSED:
+		eventName = SLA_EVENT_CONTRACT_CLOSED
+	case transition.Action == SLA_CONTRACT_ACTION_SUBMIT:
+		eventName = SLA_EVENT_CONTRACT_SUBMITTED
+	case transition.Action == SLA_CONTRACT_ACTION_APPROVE:
+		eventName = SLA_EVENT_APPROVAL_REQUESTED
+	default:
+		return nil
+	}
 
-	targetContractInJson, _ := json.MarshalIndent(targetContract, "", "  ")
+	event := SlaContractEvent{
+		TxId:        stub.GetTxID(),
+		Timestamp:   txTimestamp,
+		RegId:       targetContract.RegId,
+		Name:        targetContract.Name,
+		Client:      targetContract.Client,
+		Action:      transition.Action,
+		Progression: transition.To,
+		UserId:      userId,
+	}
+	// 결재 진행 중이면 다음 결재 순번의 결재자
+	nextIndex := transition.ApprovalIndex + 1
+	if eventName != SLA_EVENT_CONTRACT_CLOSED && nextIndex < len(targetContract.Approvals) {
+		event.NextApprover = &targetContract.Approvals[nextIndex]
+	}
 
-	// 5. 변경된 계약을 KVS에 저장합니다.
-	err = stub.PutState(slaContractRegId, []byte(targetContractInJson))
+	eventInJson, err := json.Marshal(event)
 	if err != nil {
-		return nil, errors.New("Failed to put state with" + string(targetContractInJson))
+		return errors.New("Failed to marshal contract event of " + targetContract.RegId)
 	}
-	return nil, nil
+	return stub.SetEvent(eventName, eventInJson)
 }
 
-// 5.계약을 폐기합니다.
-func (t *SimpleChaincode) slaAbandonContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var err error
-	var targetContract SlaContract
+// 계약의 상태 전이 이력을 추가합니다.
+func (t *SimpleChaincode) slaAppendContractHistory(stub shim.ChaincodeStubInterface, slaContractRegId string, history SlaContractHistory) error {
+	var histories []SlaContractHistory
 
-	// 1. 해당 계약 찾기 및 Struct 생성
-	slaContractRegId := args[0]
-	targetContractInBytes, err := stub.GetState(slaContractRegId)
+	historiesInBytes, err := stub.GetState(CONTRACT_HISTORY_PREFIX + slaContractRegId)
 	if err != nil {
-		return nil, errors.New("Failed to get state with " + string(targetContractInBytes))
+		return errors.New("Failed to get state with " + CONTRACT_HISTORY_PREFIX + slaContractRegId)
+	}
+	if historiesInBytes != nil {
+		err = json.Unmarshal(historiesInBytes, &histories)
+		if err != nil {
+			return errors.New("Failed to unmarshal contract history with " + string(historiesInBytes))
+		}
 	}
 
-	// 2. JSON 데이터를 디코딩(Unmarshal)합니다: string -> []byte -> golang struct
-	err = json.Unmarshal([]byte(targetContractInBytes), &targetContract)
+	historiesInJson, err := json.MarshalIndent(append(histories, history), "", "  ")
 	if err != nil {
-		return nil, errors.New("Failed to slaCloseContract with " + string(targetContractInBytes))
+		return errors.New("Failed to marshal contract history of " + slaContractRegId)
 	}
+	return stub.PutState(CONTRACT_HISTORY_PREFIX+slaContractRegId, historiesInJson)
+}
 
-	// 3. 해당 결재 내용으로 변경
-	targetContract.Progression = SLA_CONTRACT_PROGRESSION_ABANDONED // 새 진행단계 (Progression)
-
-	// 4. Json 형태로 저장: golang struct -> string
-	targetContractInJson, _ := json.MarshalIndent(targetContract, "", "  ")
+// 트랜잭션 생성 시각을 조회합니다.
+// 모든 피어가 같은 결과를 얻도록 time.Now() 대신 트랜잭션 시각을 사용하며, 일자와 연도는 slaTimeZone 기준입니다.
+func slaGetTxTime(stub shim.ChaincodeStubInterface) (time.Time, error) {
+	txTimestamp, err := stub.GetTxTimestamp()
+	if err != nil {
+		return time.Time{}, errors.New("Failed to get transaction timestamp of " + stub.GetTxID())
+	}
+	if txTimestamp == nil {
+		return time.Time{}, errors.New("No transaction timestamp for " + stub.GetTxID())
+	}
+	return time.Unix(txTimestamp.Seconds, int64(txTimestamp.Nanos)).In(slaTimeZone), nil
+}
 
-	// 5. 변경된 계약을 KVS에 저장합니다.
-	err = stub.PutState(slaContractRegId, []byte(targetContractInJson))
+// 트랜잭션 생성 일자를 "2006-01-02" 형식으로 조회합니다.
+func slaGetTxDate(stub shim.ChaincodeStubInterface) (string, error) {
+	txTime, err := slaGetTxTime(stub)
 	if err != nil {
-		return nil, errors.New("Failed to put state with" + string(targetContractInJson))
+		return "", err
 	}
-	return nil, nil
+	return txTime.Format("2006-01-02"), nil
 }
 
-// --------------------
+// 트랜잭션 생성 시각을 RFC3339 형식으로 조회합니다.
+func slaGetTxTimestamp(stub shim.ChaincodeStubInterface) (string, error) {
+	txTime, err := slaGetTxTime(stub)
+	if err != nil {
+		return "", err
+	}
+	return txTime.UTC().Format(time.RFC3339), nil
+}
 
-func (t *SimpleChaincode) slaGetEvaluationId(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+// 트랜잭션 연도와 해당 연도의 채번 카운트를 조회합니다.
+// KVS에 저장된 카운트의 연도(yearKey)가 트랜잭션 연도와 다르면 카운트는 1부터 시작합니다.
+// 연도가 저장되지 않은 이전 버전의 카운트는 이미 채번된 ID와 겹치지 않도록 그대로 이어갑니다.
+func slaGetYearlyCount(stub shim.ChaincodeStubInterface, countKey string, yearKey string) (int, int, error) {
+	txTime, err := slaGetTxTime(stub)
+	if err != nil {
+		return 0, 0, err
+	}
+	currentYear := txTime.Year()
+
+	countYearInBytes, err := stub.GetState(yearKey)
+	if err != nil {
+		return 0, 0, errors.New("Failed to get state with " + yearKey)
+	}
+	if countYearInBytes != nil && string(countYearInBytes) != strconv.Itoa(currentYear) { // new year starts
+		return currentYear, 1, nil
+	}
+
+	currentCountInBytes, err := stub.GetState(countKey)
+	if err != nil {
+		return 0, 0, errors.New("Failed to get state with " + countKey)
+	}
+	currentCount, _ := strconv.Atoi(string(currentCountInBytes))
+	if currentCount == 0 { // if not initialized
+		currentCount = 1
+	}
+	return currentYear, currentCount, nil
+}
+
+// 트랜잭션 연도의 채번 카운트를 증가시키고 카운트의 연도를 기록합니다.
+func slaIncreaseYearlyCount(stub shim.ChaincodeStubInterface, countKey string, yearKey string) error {
+	currentYear, currentCount, err := slaGetYearlyCount(stub, countKey, yearKey)
+	if err != nil {
+		return err
+	}
+	err = stub.PutState(yearKey, []byte(strconv.Itoa(currentYear)))
+	if err != nil {
+		return err
+	}
+	return stub.PutState(countKey, []byte(strconv.Itoa(currentCount+1)))
+}
+
+// 저장된 계약을 조회합니다. 계약이 없으면 SlaContractNotFoundError 를 리턴합니다.
+func (t *SimpleChaincode) slaGetContract(stub shim.ChaincodeStubInterface, slaContractRegId string) (SlaContract, error) {
+	targetContract, err := t.slaGetContractIfExists(stub, slaContractRegId)
+	if err != nil {
+		return targetContract, err
+	}
+	if targetContract.Progression == SLA_CONTRACT_PROGRESSION_NONE {
+		return targetContract, &SlaContractNotFoundError{RegId: slaContractRegId}
+	}
+	return targetContract, nil
+}
+
+// 저장된 계약을 조회합니다. 계약이 없으면 진행단계가 SLA_CONTRACT_PROGRESSION_NONE 인 빈 계약을 리턴합니다.
+func (t *SimpleChaincode) slaGetContractIfExists(stub shim.ChaincodeStubInterface, slaContractRegId string) (SlaContract, error) {
+	var targetContract SlaContract
+
+	targetContractInBytes, err := stub.GetState(slaContractRegId)
+	if err != nil {
+		return targetContract, errors.New("Failed to get state with " + slaContractRegId)
+	}
+	if targetContractInBytes == nil {
+		return targetContract, nil
+	}
+
+	// JSON 데이터를 디코딩(Unmarshal)합니다: string -> []byte -> golang struct
+	err = json.Unmarshal(targetContractInBytes, &targetContract)
+	if err != nil {
+		return targetContract, errors.New("Failed to unmarshal contract with " + string(targetContractInBytes))
+	}
+	return targetContract, nil
+}
+
+// 계약을 KVS에 저장합니다.
+func (t *SimpleChaincode) slaPutContract(stub shim.ChaincodeStubInterface, targetContract SlaContract) error {
+	targetContractInJson, err := json.MarshalIndent(targetContract, "", "  ")
+	if err != nil {
+		return errors.New("Failed to marshal contract " + targetContract.RegId)
+	}
+	err = stub.PutState(targetContract.RegId, targetContractInJson)
+	if err != nil {
+		return errors.New("Failed to put state with" + string(targetContractInJson))
+	}
+	return nil
+}
+
+// 결재 요청된 계약을 계약명, 고객사명, 전체 조회 인덱스에 등록합니다.
+func (t *SimpleChaincode) slaPutContractIndexes(stub shim.ChaincodeStubInterface, targetContract SlaContract) error {
+	err := shim.PutIndexEntry(stub, SLA_INDEX_CONTRACT_NAME, targetContract.Name, targetContract.RegId)
+	if err != nil {
+		return err
+	}
+	err = shim.PutIndexEntry(stub, SLA_INDEX_CONTRACT_CLIENT, targetContract.Client, targetContract.RegId)
+	if err != nil {
+		return err
+	}
+	return shim.PutIndexEntry(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, targetContract.RegId)
+}
+
+// 계약명, 고객사명 인덱스에서 계약을 삭제합니다.
+func (t *SimpleChaincode) slaDelContractIndexes(stub shim.ChaincodeStubInterface, targetContract SlaContract) error {
+	err := shim.DelIndexEntry(stub, SLA_INDEX_CONTRACT_NAME, targetContract.Name, targetContract.RegId)
+	if err != nil {
+		return err
+	}
+	return shim.DelIndexEntry(stub, SLA_INDEX_CONTRACT_CLIENT, targetContract.Client, targetContract.RegId)
+}
+
+// 이전 버전의 "|" 구분 ID목록 키를 composite key 인덱스로 옮깁니다.
+// 계약명/고객사명 키는 값이 계약ID목록인 경우에만 삭제하므로 여러 번 호출해도 결과가 같습니다.
+func (t *SimpleChaincode) slaMigrateIndexes(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 0 {
+		return nil, errors.New("Incorrect number of arguments. Expecting no argument to slaMigrateIndexes")
+	}
+
+	// 1. 전체 계약
+	contractIDsInBytes, err := stub.GetState(SLA_ALL_DATA)
+	if err != nil {
+		return nil, errors.New("Failed to get state with " + SLA_ALL_DATA)
+	}
+	legacyKeys := map[string]bool{}
+	for _, contractId := range slaSplitLegacyIds(contractIDsInBytes) {
+		targetContract, err := t.slaGetContract(stub, contractId)
+		if _, ok := err.(*SlaContractNotFoundError); ok {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// 계약명, 고객사명은 결재 요청 이후에만 등록되어 있었음
+		if targetContract.Progression == SLA_CONTRACT_PROGRESSION_TEMP {
+			err = shim.PutIndexEntry(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, contractId)
+		} else {
+			err = t.slaPutContractIndexes(stub, targetContract)
+			legacyKeys[targetContract.Name] = true
+			legacyKeys[targetContract.Client] = true
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. 전체 평가
+	evaluationRootIdsInBytes, err := stub.GetState(SLA_ALL_EVALUATION_DATA)
+	if err != nil {
+		return nil, errors.New("Failed to get state with " + SLA_ALL_EVALUATION_DATA)
+	}
+	for _, evaluationRootId := range slaSplitLegacyIds(evaluationRootIdsInBytes) {
+		err = shim.PutIndexEntry(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_EVALUATIONS, evaluationRootId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 3. 이전 키 삭제: 계약명이 시스템 키와 같은 경우를 피하기 위해 값이 계약ID목록인 키만 삭제
+	for legacyKey := range legacyKeys {
+		legacyIdsInBytes, err := stub.GetState(legacyKey)
+		if err != nil {
+			return nil, errors.New("Failed to get state with " + legacyKey)
+		}
+		if !slaIsLegacyContractIdList(legacyIdsInBytes) {
+			continue
+		}
+		err = stub.DelState(legacyKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = stub.DelState(SLA_ALL_DATA)
+	if err != nil {
+		return nil, err
+	}
+	err = stub.DelState(SLA_ALL_EVALUATION_DATA)
+	if err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
+// 이전 버전의 "|" 구분 ID목록을 배열로 전환합니다.
+func slaSplitLegacyIds(idsInBytes []byte) []string {
+	if len(idsInBytes) == 0 {
+		return []string{}
+	}
+	return strings.Split(string(idsInBytes), FIELDSEP)
+}
+
+// 이전 버전의 계약명/고객사명 키의 값이 계약ID목록인지 확인합니다.
+func slaIsLegacyContractIdList(idsInBytes []byte) bool {
+	ids := slaSplitLegacyIds(idsInBytes)
+	if len(ids) == 0 {
+		return false
+	}
+	for _, id := range ids {
+		if !strings.HasPrefix(id, CONTRACT_ID_PREFIX) {
+			return false
+		}
+	}
+	return true
+}
+
+// 계약의 상태 전이 이력을 조회합니다.
+func (t *SimpleChaincode) slaGetContractHistory(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting contract id to slaGetContractHistory")
+	}
+
+	historiesInBytes, err := stub.GetState(CONTRACT_HISTORY_PREFIX + args[0])
+	if err != nil {
+		return nil, errors.New("Failed to get state with " + CONTRACT_HISTORY_PREFIX + args[0])
+	}
+	if historiesInBytes == nil {
+		return []byte("[]"), nil
+	}
+	return historiesInBytes, nil
+}
+
+// ===========================================================
+//  SLA 평가 등록/진행 함수
+// ===========================================================
+
+// 평가ID를 채번합니다.
+func (t *SimpleChaincode) slaGetEvaluationId(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var evaluationId string
+
+	// 트랜잭션 연도의 평가 카운트를 호출
+	currentYear, currentCount, err := slaGetYearlyCount(stub, SLA_EVALUATION_ID_COUNT_KEY, SLA_EVALUATION_YEAR_KEY)
+	if err != nil {
+		return nil, err
+	}
+
+	// 평가번호 채번을 생성합니다.
+	evaluationId = EVALUATION_ID_PREFIX + strconv.Itoa(currentYear) + "_" + padLeft(strconv.Itoa(currentCount), 5)
+
+	return []byte(evaluationId), nil
+}
+
+// 최종 승인(CLOSED)된 계약으로부터 전체 평가(SlaEvalutionRoot)를 생성합니다.
+// KVS: 평가ID, 계약ID-평가ID, 전체평가에 대한 KVS 저장
 func (t *SimpleChaincode) slaCreateEvaluationRootFromContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	var err error
+	var targetContract SlaContract
+
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting contract id to slaCreateEvaluationRootFromContract")
+	}
+	slaContractRegId := args[0]
+
+	// 1. 해당 계약 찾기 및 Struct 생성
+	targetContractInBytes, err := stub.GetState(slaContractRegId)
+	if err != nil {
+		return nil, errors.New("Failed to get state with " + slaContractRegId)
+	}
+	if targetContractInBytes == nil {
+		return nil, errors.New("No contract found with " + slaContractRegId)
+	}
+	err = json.Unmarshal(targetContractInBytes, &targetContract)
+	if err != nil {
+		return nil, errors.New("Failed to slaCreateEvaluationRootFromContract with " + string(targetContractInBytes))
+	}
+
+	// 2. 최종 승인된 계약만 평가할 수 있음
+	if targetContract.Progression != SLA_CONTRACT_PROGRESSION_CLOSED {
+		return nil, errors.New("slaCreateEvaluationRootFromContract cannot have the current progression of " + targetContract.Progression)
+	}
+	if len(targetContract.ServiceItems) == 0 {
+		return nil, errors.New("No service items to evaluate in " + slaContractRegId)
+	}
+
+	// 3. 계약당 하나의 전체 평가만 생성
+	evaluationRootIdInBytes, err := stub.GetState(EVALUATION_ROOT_OF_CONTRACT_PREFIX + slaContractRegId)
+	if err != nil {
+		return nil, errors.New("Failed to get state with " + EVALUATION_ROOT_OF_CONTRACT_PREFIX + slaContractRegId)
+	}
+	if evaluationRootIdInBytes != nil {
+		return nil, errors.New("Evaluation " + string(evaluationRootIdInBytes) + " already exists for " + slaContractRegId)
+	}
+
+	// 4. 평가ID 채번 및 전체 평가 등록
+	evaluationRootIdInBytes, err = t.slaGetEvaluationId(stub, []string{})
+	if err != nil {
+		return nil, err
+	}
+	evaluationRoot := SlaEvalutionRoot{
+		RegId:        string(evaluationRootIdInBytes),
+		ContractId:   slaContractRegId,
+		Status:       SLA_EVALUATION_ROOT_STATUS_IN_PROGRESS,
+		Evaluations:  []string{},
+		ServiceItems: targetContract.ServiceItems,
+	}
+	err = t.slaPutEvaluationRoot(stub, evaluationRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. 계약ID-평가ID 등록
+	err = stub.PutState(EVALUATION_ROOT_OF_CONTRACT_PREFIX+slaContractRegId, evaluationRootIdInBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. 전체 조회 등록
+	err = shim.PutIndexEntry(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_EVALUATIONS, evaluationRoot.RegId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 7. 평가 카운트 증가 (새로운 연도일 경우 초기화)
+	err = slaIncreaseYearlyCount(stub, SLA_EVALUATION_ID_COUNT_KEY, SLA_EVALUATION_YEAR_KEY)
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluationRootIdInBytes, nil
 }
+
+// 전체 평가의 평가항목마다 개별 평가(SlaEvaluation)를 생성합니다.
+// 개별 평가ID: 평가ID + "_" + 평가항목 순번 (예: SLA_EVAL_2017_00001_001)
 func (t *SimpleChaincode) slaCreateEvaluationsFromContract(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	var err error
+	var targetContract SlaContract
+
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id to slaCreateEvaluationsFromContract")
+	}
+
+	// 1. 전체 평가 및 계약 찾기
+	evaluationRoot, err := t.slaGetEvaluationRoot(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(evaluationRoot.Evaluations) != 0 {
+		return nil, errors.New("Evaluations already exist for " + evaluationRoot.RegId)
+	}
+
+	targetContractInBytes, err := stub.GetState(evaluationRoot.ContractId)
+	if err != nil {
+		return nil, errors.New("Failed to get state with " + evaluationRoot.ContractId)
+	}
+	err = json.Unmarshal(targetContractInBytes, &targetContract)
+	if err != nil {
+		return nil, errors.New("Failed to slaCreateEvaluationsFromContract with " + string(targetContractInBytes))
+	}
+	if len(targetContract.Approvals) < SLA_NUM_APPROVALS {
+		return nil, errors.New("Evaluation requires " + strconv.Itoa(SLA_NUM_APPROVALS) + " approvals but contract has " + strconv.Itoa(len(targetContract.Approvals)))
+	}
+
+	// 2. 평가항목별 개별 평가 등록 (결재선은 계약의 결재선을 그대로 사용)
+	for i, serviceItem := range evaluationRoot.ServiceItems {
+		evaluation := SlaEvaluation{
+			RegId:            evaluationRoot.RegId + "_" + padLeft(strconv.Itoa(i+1), 3),
+			EvaluationRootId: evaluationRoot.RegId,
+			ContractId:       evaluationRoot.ContractId,
+			ServiceItem:      serviceItem,
+			Progression:      SLA_EVALUATION_PROGRESSION_TEMP,
+			Approvals:        make([]SlaApproval, len(targetContract.Approvals)),
+		}
+		for j, approval := range targetContract.Approvals {
+			approval.ApprovalState = SLA_APPROVAL_STATE_TEMP
+			approval.ApprovalDate = ""
+			approval.ApprovalComment = ""
+			evaluation.Approvals[j] = approval
+		}
+
+		err = t.slaPutEvaluation(stub, evaluation)
+		if err != nil {
+			return nil, err
+		}
+		evaluationRoot.Evaluations = append(evaluationRoot.Evaluations, evaluation.RegId)
+	}
+
+	// 3. 개별 평가ID 목록을 전체 평가에 저장
+	err = t.slaPutEvaluationRoot(stub, evaluationRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(evaluationRoot.RegId), nil
 }
 
+// 개별 평가의 평가점수를 최초 입력합니다. 호출자의 TCert 속성이 결재선의 평가자(기안자)와 일치해야 합니다.
 func (t *SimpleChaincode) slaInitEvaluationValues(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 2 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id and score to slaInitEvaluationValues")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_TEMP {
+		return nil, errors.New("slaInitEvaluationValues cannot have the current progression of " + evaluation.Progression)
+	}
+
+	// 결재선의 평가자만 점수 입력 가능
+	err = slaVerifyApprover(stub, "slaInitEvaluationValues", evaluation.RegId, slaReadCallerUserId(stub), evaluation.Approvals[0])
+	if err != nil {
+		return nil, err
+	}
+	if _, err = strconv.ParseFloat(args[1], 64); err != nil {
+		return nil, errors.New("Invalid evaluation score " + args[1])
+	}
+
+	evaluation.ScoresForServiceItems = args[1]
+	evaluation.Progression = SLA_EVALUATION_PROGRESSION_VALUES_ENTERED
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 개별 평가의 평가점수를 수정합니다. (점수 입력 후 / 반려 후) 호출자의 TCert 속성이 결재선의 평가자(기안자)와 일치해야 합니다.
 func (t *SimpleChaincode) slaUpdateEvaluationValues(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 2 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id and score to slaUpdateEvaluationValues")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_VALUES_ENTERED {
+		return nil, errors.New("slaUpdateEvaluationValues cannot have the current progression of " + evaluation.Progression)
+	}
+
+	// 결재선의 평가자만 점수 입력 가능
+	err = slaVerifyApprover(stub, "slaUpdateEvaluationValues", evaluation.RegId, slaReadCallerUserId(stub), evaluation.Approvals[0])
+	if err != nil {
+		return nil, err
+	}
+	if _, err = strconv.ParseFloat(args[1], 64); err != nil {
+		return nil, errors.New("Invalid evaluation score " + args[1])
+	}
+
+	evaluation.ScoresForServiceItems = args[1]
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 개별 평가의 내부결재를 요청합니다. 호출자의 TCert 속성이 결재선의 평가자(기안자)와 일치해야 합니다.
 func (t *SimpleChaincode) slaSubmitEvaluation(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id to slaSubmitEvaluation")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_VALUES_ENTERED {
+		return nil, errors.New("slaSubmitEvaluation cannot have the current progression of " + evaluation.Progression)
+	}
+
+	// 결재선의 평가자만 결재요청 가능
+	err = slaVerifyApprover(stub, "slaSubmitEvaluation", evaluation.RegId, slaReadCallerUserId(stub), evaluation.Approvals[0])
+	if err != nil {
+		return nil, err
+	}
+
+	txDate, err := slaGetTxDate(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	// 상태 변경: 평가상태 + Approvals[0]의 상태
+	evaluation.Progression = SLA_EVALUATION_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED
+	evaluation.Approvals[0].ApprovalState = SLA_APPROVAL_STATE_SUBMITTED
+	evaluation.Approvals[0].ApprovalDate = txDate
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 개별 평가를 승인합니다. 호출자의 TCert 속성이 결재 차례인 결재자와 일치해야 합니다.
 func (t *SimpleChaincode) slaApproveEvaluation(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 3 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id, user id and comment to slaApproveEvaluation")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// 현재의 진행단계(Progression)를 확인하여 변경할 Approval과 다음 진행단계를 찾음
+	approvalIndex, newProgression, err := slaEvaluationReviewStep(evaluation.Progression)
+	if err != nil {
+		return nil, errors.New("Approval " + err.Error())
+	}
+	targetApproval := &(evaluation.Approvals[approvalIndex])
+
+	// 결재 차례인 결재자만 승인 가능
+	err = slaVerifyApprover(stub, "slaApproveEvaluation", evaluation.RegId, args[1], *targetApproval)
+	if err != nil {
+		return nil, err
+	}
+	txDate, err := slaGetTxDate(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation.Progression = newProgression                    // 새 진행단계 (Progression)
+	targetApproval.ApprovalUserId = args[1]                    // 결재사용자ID
+	targetApproval.ApprovalState = SLA_APPROVAL_STATE_APPROVED // 승인상태
+	targetApproval.ApprovalDate = txDate                       // 트랜잭션 일자
+	targetApproval.ApprovalComment = args[2]                   // 의견내용
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 개별 평가를 반려합니다. 반려된 평가는 평가점수 입력상태로 돌아갑니다.
 func (t *SimpleChaincode) slaRejectEvaluation(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 3 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id, user id and comment to slaRejectEvaluation")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	approvalIndex, _, err := slaEvaluationReviewStep(evaluation.Progression)
+	if err != nil {
+		return nil, errors.New("Reject " + err.Error())
+	}
+	targetApproval := &(evaluation.Approvals[approvalIndex])
+
+	// 결재 차례인 결재자만 반려 가능
+	err = slaVerifyApprover(stub, "slaRejectEvaluation", evaluation.RegId, args[1], *targetApproval)
+	if err != nil {
+		return nil, err
+	}
+	txDate, err := slaGetTxDate(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation.Progression = SLA_EVALUATION_PROGRESSION_VALUES_ENTERED // 평가점수 입력상태로
+	targetApproval.ApprovalUserId = args[1]                            // 결재사용자ID
+	targetApproval.ApprovalState = SLA_APPROVAL_STATE_REJECTED         // 반려상태
+	targetApproval.ApprovalDate = txDate                               // 트랜잭션 일자
+	targetApproval.ApprovalComment = args[2]                           // 의견내용
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 승인된 개별 평가의 지급을 요청합니다. 호출자의 TCert 에 지급자 역할(role=payer) 속성이 있어야 합니다.
 func (t *SimpleChaincode) slaSubmitPayment(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 2 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id and user id to slaSubmitPayment")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_APPROVED {
+		return nil, errors.New("slaSubmitPayment cannot have the current progression of " + evaluation.Progression)
+	}
+
+	// 지급자 역할을 가진 사용자만 지급 요청 가능
+	err = slaVerifyPayer(stub, "slaSubmitPayment", evaluation.RegId, args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	txDate, err := slaGetTxDate(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation.Progression = SLA_EVALUATION_PROGRESSION_PAYMENT_REQUESTED
+	evaluation.PaymentRequestUserId = args[1]
+	evaluation.PaymentRequestDate = txDate
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 개별 평가의 지급을 완료합니다. 호출자의 TCert 에 지급자 역할(role=payer) 속성이 있어야 합니다.
 func (t *SimpleChaincode) slaClosePayment(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+	if len(args) != 3 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id, user id and comment to slaClosePayment")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_PAYMENT_REQUESTED {
+		return nil, errors.New("slaClosePayment cannot have the current progression of " + evaluation.Progression)
+	}
+
+	// 지급자 역할을 가진 사용자만 지급 완료 가능
+	err = slaVerifyPayer(stub, "slaClosePayment", evaluation.RegId, args[1])
+	if err != nil {
+		return nil, err
+	}
+	txDate, err := slaGetTxDate(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation.Progression = SLA_EVALUATION_PROGRESSION_PAID
+	evaluation.PaymentUserId = args[1]
+	evaluation.PaymentDate = txDate
+	evaluation.PaymentComment = args[2]
+
+	return nil, t.slaPutEvaluation(stub, evaluation)
 }
+
+// 개별 평가를 종료합니다. 호출자의 TCert 에 지급자 역할(role=payer) 속성이 있어야 합니다.
+// 마지막 개별 평가가 종료되면 전체 평가도 종료합니다.
 func (t *SimpleChaincode) slaCloseEvaluation(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
-}
-func (t *SimpleChaincode) slaCloseEvaluationRoot(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id to slaCloseEvaluation")
+	}
+
+	evaluation, err := t.slaGetEvaluation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_PAID {
+		return nil, errors.New("slaCloseEvaluation cannot have the current progression of " + evaluation.Progression)
+	}
+
+	// 지급자 역할을 가진 사용자만 평가 종료 가능
+	err = slaVerifyPayer(stub, "slaCloseEvaluation", evaluation.RegId, slaReadCallerUserId(stub))
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation.Progression = SLA_EVALUATION_PROGRESSION_CLOSED
+	err = t.slaPutEvaluation(stub, evaluation)
+	if err != nil {
+		return nil, err
+	}
+
+	// 전체 평가의 모든 개별 평가가 종료되었으면 전체 평가를 종료
+	allClosed, err := t.slaAreAllEvaluationsClosed(stub, evaluation.EvaluationRootId)
+	if err != nil {
+		return nil, err
+	}
+	if allClosed {
+		return t.slaCloseEvaluationRoot(stub, []string{evaluation.EvaluationRootId})
+	}
 	return nil, nil
 }
 
-// ===========================================================
-//  SLAChaincodeStub 검색 함수
-// ===========================================================
+// 전체 평가를 종료합니다. (마지막 개별 평가가 종료될 경우, 자동 호출)
+func (t *SimpleChaincode) slaCloseEvaluationRoot(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting evaluation id to slaCloseEvaluationRoot")
+	}
 
-// SLA 데이터 전체를 조회합니다.  (abandon 포함)
-func (t *SimpleChaincode) slaGetAllContracts(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var err error
+	evaluationRoot, err := t.slaGetEvaluationRoot(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+	if evaluationRoot.Status != SLA_EVALUATION_ROOT_STATUS_IN_PROGRESS {
+		return nil, errors.New("slaCloseEvaluationRoot cannot have the current status of " + evaluationRoot.Status)
+	}
 
-	// 전체 계약ID목록 조회
-	contractIDsInBytes, err := stub.GetState(SLA_ALL_DATA)
+	allClosed, err := t.slaAreAllEvaluationsClosed(stub, evaluationRoot.RegId)
 	if err != nil {
-		return nil, errors.New("Failed to get state with " + SLA_ALL_DATA)
+		return nil, err
+	}
+	if !allClosed {
+		return nil, errors.New("Not all evaluations of " + evaluationRoot.RegId + " are closed")
 	}
-	contractIDsInString := string(contractIDsInBytes)
 
-	// 계약ID목록의 형태를 스트링에서 배열로 전환
-	contractIDs := strings.Split(contractIDsInString, FIELDSEP)
+	evaluationRoot.Status = SLA_EVALUATION_ROOT_STATUS_CLOSED
+	return nil, t.slaPutEvaluationRoot(stub, evaluationRoot)
+}
 
-	// 리턴값 초기화
-	contractList := make([]string, len(contractIDs))
+// 결재 진행단계별로 변경할 Approval의 순번과 승인 후 진행단계를 찾습니다.
+func slaEvaluationReviewStep(progression string) (int, string, error) {
+	switch progression {
+	case SLA_EVALUATION_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED:
+		return 1, SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED, nil
+	case SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED:
+		return 2, SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED, nil
+	case SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED:
+		return 3, SLA_EVALUATION_PROGRESSION_APPROVED, nil
+	}
+	return 0, "", errors.New("cannot have the current progression of " + progression)
+}
 
-	// 계약 전체 ID목록 조회
-	for i, _ := range contractIDs {
-		contractInBytes, _ := stub.GetState(contractIDs[i])
+// 전체 평가에 속한 개별 평가가 모두 종료되었는지 확인합니다.
+func (t *SimpleChaincode) slaAreAllEvaluationsClosed(stub shim.ChaincodeStubInterface, evaluationRootId string) (bool, error) {
+	evaluationRoot, err := t.slaGetEvaluationRoot(stub, evaluationRootId)
+	if err != nil {
+		return false, err
+	}
+	for _, evaluationId := range evaluationRoot.Evaluations {
+		evaluation, err := t.slaGetEvaluation(stub, evaluationId)
 		if err != nil {
-			return nil, errors.New("Failed to get state with " + contractIDs[i])
+			return false, err
+		}
+		if evaluation.Progression != SLA_EVALUATION_PROGRESSION_CLOSED {
+			return false, nil
 		}
-		contractList[i] = string(contractInBytes)
 	}
-	// 계약 전체신규_20170221 String
-	ContractsInJson, err := json.MarshalIndent(contractList, "", "  ")
+	return true, nil
+}
+
+func (t *SimpleChaincode) slaGetEvaluationRoot(stub shim.ChaincodeStubInterface, evaluationRootId string) (SlaEvalutionRoot, error) {
+	var evaluationRoot SlaEvalutionRoot
+
+	evaluationRootInBytes, err := stub.GetState(evaluationRootId)
 	if err != nil {
-		return nil, errors.New("Failed to json.MarshalIndent with " + strings.Join(contractList, ","))
+		return evaluationRoot, errors.New("Failed to get state with " + evaluationRootId)
+	}
+	if evaluationRootInBytes == nil {
+		return evaluationRoot, errors.New("No evaluation found with " + evaluationRootId)
 	}
+	err = json.Unmarshal(evaluationRootInBytes, &evaluationRoot)
+	if err != nil {
+		return evaluationRoot, errors.New("Failed to unmarshal evaluation with " + string(evaluationRootInBytes))
+	}
+	return evaluationRoot, nil
+}
 
-	return []byte(ContractsInJson), nil
+func (t *SimpleChaincode) slaPutEvaluationRoot(stub shim.ChaincodeStubInterface, evaluationRoot SlaEvalutionRoot) error {
+	evaluationRootInJson, err := json.MarshalIndent(evaluationRoot, "", "  ")
+	if err != nil {
+		return errors.New("Failed to marshal evaluation " + evaluationRoot.RegId)
+	}
+	err = stub.PutState(evaluationRoot.RegId, evaluationRootInJson)
+	if err != nil {
+		return errors.New("Failed to put state with " + evaluationRoot.RegId)
+	}
+	return nil
+}
 
+func (t *SimpleChaincode) slaGetEvaluation(stub shim.ChaincodeStubInterface, evaluationId string) (SlaEvaluation, error) {
+	var evaluation SlaEvaluation
+
+	evaluationInBytes, err := stub.GetState(evaluationId)
+	if err != nil {
+		return evaluation, errors.New("Failed to get state with " + evaluationId)
+	}
+	if evaluationInBytes == nil {
+		return evaluation, errors.New("No evaluation found with " + evaluationId)
+	}
+	err = json.Unmarshal(evaluationInBytes, &evaluation)
+	if err != nil {
+		return evaluation, errors.New("Failed to unmarshal evaluation with " + string(evaluationInBytes))
+	}
+	return evaluation, nil
+}
+
+func (t *SimpleChaincode) slaPutEvaluation(stub shim.ChaincodeStubInterface, evaluation SlaEvaluation) error {
+	evaluationInJson, err := json.MarshalIndent(evaluation, "", "  ")
+	if err != nil {
+		return errors.New("Failed to marshal evaluation " + evaluation.RegId)
+	}
+	err = stub.PutState(evaluation.RegId, evaluationInJson)
+	if err != nil {
+		return errors.New("Failed to put state with " + evaluation.RegId)
+	}
+	return nil
+}
+
+// ===========================================================
+//  SLAChaincodeStub 검색 함수
+// ===========================================================
+
+// SLA 데이터 전체를 조회합니다.  (abandon 포함)
+func (t *SimpleChaincode) slaGetAllContracts(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return t.slaGetContractsWithIndex(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS)
 }
 
 // ID으로 조회합니다.
@@ -865,31 +1809,34 @@ func (t *SimpleChaincode) slaGetContractWithId(stub shim.ChaincodeStubInterface,
 
 // 계약명으로 조회합니다.
 func (t *SimpleChaincode) slaGetContractsWithName(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var err error
-
 	if len(args) != 1 {
 		return nil, errors.New("Incorrect number of arguments. Expecting name of the value to slaGetContractsWithName")
 	}
-	contractName := args[0]
+	return t.slaGetContractsWithIndex(stub, SLA_INDEX_CONTRACT_NAME, args[0])
+}
 
-	// 계약명으로 계약ID목록 조회
-	contractIDsInBytes, err := stub.GetState(contractName)
-	if err != nil {
-		return nil, errors.New("Failed to get state with " + contractName)
+// 고객사명으로 조회합니다.
+func (t *SimpleChaincode) slaGetContractsWithClient(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting name of the value to slaGetContractsWithClient")
 	}
-	contractIDsInString := string(contractIDsInBytes)
+	return t.slaGetContractsWithIndex(stub, SLA_INDEX_CONTRACT_CLIENT, args[0])
+}
 
-	// 계약ID목록의 형태를 스트링에서 배열로 전환합니다.
-	contractIDs := strings.Split(contractIDsInString, FIELDSEP)
+// 인덱스에 등록된 계약ID목록으로 계약내용을 추출하여 계약목록을 작성합니다.
+func (t *SimpleChaincode) slaGetContractsWithIndex(stub shim.ChaincodeStubInterface, indexName string, value string) ([]byte, error) {
+	contractIDs, err := shim.GetIndexEntries(stub, indexName, value)
+	if err != nil {
+		return nil, errors.New("Failed to get index entries with " + indexName + " " + value)
+	}
 
 	// 리턴값 초기화
 	contractList := make([]string, len(contractIDs))
 
-	// 계약ID목록으로 계약내용을 추출하여 계약목록 작성
-	for i, _ := range contractIDs {
-		contractInBytes, _ := stub.GetState(contractIDs[i])
+	for i, contractId := range contractIDs {
+		contractInBytes, err := stub.GetState(contractId)
 		if err != nil {
-			return nil, errors.New("Failed to get state with " + contractIDs[i])
+			return nil, errors.New("Failed to get state with " + contractId)
 		}
 		contractList[i] = string(contractInBytes)
 	}
@@ -902,54 +1849,89 @@ func (t *SimpleChaincode) slaGetContractsWithName(stub shim.ChaincodeStubInterfa
 	return []byte(ContractsInJson), nil
 }
 
-// 고객사명으로 조회합니다.
-func (t *SimpleChaincode) slaGetContractsWithClient(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var err error
+// SLA 개별 평가 전체를 조회합니다.
+func (t *SimpleChaincode) slaGetAllEvaluations(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	// 전체 평가ID목록 조회
+	evaluationRootIds, err := shim.GetIndexEntries(stub, SLA_INDEX_ALL, SLA_INDEX_ALL_EVALUATIONS)
+	if err != nil {
+		return nil, errors.New("Failed to get index entries with " + SLA_INDEX_ALL + " " + SLA_INDEX_ALL_EVALUATIONS)
+	}
+	return t.slaGetEvaluationsWithRootIds(stub, evaluationRootIds)
+}
 
+// 평가ID 또는 개별 평가ID로 조회합니다.
+func (t *SimpleChaincode) slaGetEvaluationWithId(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	if len(args) != 1 {
-		return nil, errors.New("Incorrect number of arguments. Expecting name of the value to slaGetContractsWithClient")
+		return nil, errors.New("Incorrect number of arguments. Expecting name of the Value to slaGetEvaluationWithId")
 	}
-	clientName := args[0]
 
-	// 계약명으로 계약ID목록 조회
-	contractIDsInBytes, err := stub.GetState(clientName)
+	evaluationInBytes, err := stub.GetState(args[0])
 	if err != nil {
-		return nil, errors.New("Failed to get state with " + clientName)
+		return nil, errors.New("Failed to get state with" + args[0])
 	}
-	contractIDsInString := string(contractIDsInBytes)
 
-	// 계약ID목록의 형태를 스트링에서 배열로 전환합니다.
-	contractIDs := strings.Split(contractIDsInString, FIELDSEP)
+	return evaluationInBytes, nil
+}
 
-	// 리턴값 초기화
-	contractList := make([]string, len(contractIDs))
+// 계약명으로 개별 평가를 조회합니다.
+func (t *SimpleChaincode) slaGetEvaluationsWithName(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting name of the value to slaGetEvaluationsWithName")
+	}
+	return t.slaGetEvaluationsWithContractIndex(stub, SLA_INDEX_CONTRACT_NAME, args[0])
+}
 
-	// 계약ID목록으로 계약내용을 추출하여 계약목록 작성
-	for i, _ := range contractIDs {
-		contractInBytes, _ := stub.GetState(contractIDs[i])
-		if err != nil {
-			return nil, errors.New("Failed to get state with " + contractIDs[i])
-		}
-		contractList[i] = string(contractInBytes)
+// 고객사명으로 개별 평가를 조회합니다.
+func (t *SimpleChaincode) slaGetEvaluationsWithClient(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, errors.New("Incorrect number of arguments. Expecting name of the value to slaGetEvaluationsWithClient")
 	}
-	// 계약 전체신규_20170221 String
-	ContractsInJson, err := json.MarshalIndent(contractList, "", "  ")
+	return t.slaGetEvaluationsWithContractIndex(stub, SLA_INDEX_CONTRACT_CLIENT, args[0])
+}
+
+// 계약명/고객사명으로 등록된 계약ID목록에서 각 계약의 개별 평가를 조회합니다.
+func (t *SimpleChaincode) slaGetEvaluationsWithContractIndex(stub shim.ChaincodeStubInterface, indexName string, value string) ([]byte, error) {
+	contractIDs, err := shim.GetIndexEntries(stub, indexName, value)
 	if err != nil {
-		return nil, errors.New("Failed to json.MarshalIndent with " + strings.Join(contractList, ","))
+		return nil, errors.New("Failed to get index entries with " + indexName + " " + value)
 	}
 
-	return []byte(ContractsInJson), nil
+	var evaluationRootIds []string
+	for _, contractId := range contractIDs {
+		evaluationRootIdInBytes, err := stub.GetState(EVALUATION_ROOT_OF_CONTRACT_PREFIX + contractId)
+		if err != nil {
+			return nil, errors.New("Failed to get state with " + EVALUATION_ROOT_OF_CONTRACT_PREFIX + contractId)
+		}
+		if evaluationRootIdInBytes == nil { // 아직 평가가 생성되지 않은 계약
+			continue
+		}
+		evaluationRootIds = append(evaluationRootIds, string(evaluationRootIdInBytes))
+	}
+	return t.slaGetEvaluationsWithRootIds(stub, evaluationRootIds)
 }
 
-func (t *SimpleChaincode) slaGetAllEvaluations(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
-}
-func (t *SimpleChaincode) slaGetEvaluationWithId(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
-}
-func (t *SimpleChaincode) slaGetEvaluationsWithName(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
-}
-func (t *SimpleChaincode) slaGetEvaluationsWithClient(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return nil, nil
+// 평가ID목록에 속한 개별 평가내용으로 평가목록을 작성합니다.
+func (t *SimpleChaincode) slaGetEvaluationsWithRootIds(stub shim.ChaincodeStubInterface, evaluationRootIds []string) ([]byte, error) {
+	evaluationList := []string{}
+
+	for _, evaluationRootId := range evaluationRootIds {
+		evaluationRoot, err := t.slaGetEvaluationRoot(stub, evaluationRootId)
+		if err != nil {
+			return nil, err
+		}
+		for _, evaluationId := range evaluationRoot.Evaluations {
+			evaluationInBytes, err := stub.GetState(evaluationId)
+			if err != nil {
+				return nil, errors.New("Failed to get state with " + evaluationId)
+			}
+			evaluationList = append(evaluationList, string(evaluationInBytes))
+		}
+	}
+
+	evaluationsInJson, err := json.MarshalIndent(evaluationList, "", "  ")
+	if err != nil {
+		return nil, errors.New("Failed to json.MarshalIndent with " + strings.Join(evaluationList, ","))
+	}
+
+	return evaluationsInJson, nil
 }
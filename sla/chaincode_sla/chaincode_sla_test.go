@@ -3,14 +3,27 @@ package main
 // go test chaincode_sla_test.go chaincode_sla.go
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
 
+// 테스트 트랜잭션 시각 (KST)
+var testTxTime = time.Date(2017, 3, 2, 9, 30, 0, 0, slaTimeZone)
+
+// 트랜잭션 시각이 testTxTime 으로 설정된 MockStub 을 생성합니다.
+func newMockStub(scc *SimpleChaincode) *shim.MockStub {
+	stub := shim.NewMockStub("sla_chaincode", shim.NewChaincodeAdapter(scc))
+	stub.SetTxTimestamp(testTxTime)
+	return stub
+}
+
 func checkInit(t *testing.T, stub *shim.MockStub, args []string) {
 	_, err := stub.MockInit("1", "init", args)
 	if err != nil {
@@ -31,6 +44,18 @@ func checkState(t *testing.T, stub *shim.MockStub, name string, value string) {
 	}
 }
 
+func checkIndex(t *testing.T, stub *shim.MockStub, indexName string, value string, ids []string) {
+	indexed, err := shim.GetIndexEntries(stub, indexName, value)
+	if err != nil {
+		fmt.Println("Index", indexName, value, "failed", err)
+		t.FailNow()
+	}
+	if !reflect.DeepEqual(indexed, ids) {
+		fmt.Println("Index", indexName, value, indexed, "was not the expected value", ids)
+		t.FailNow()
+	}
+}
+
 func checkQuery(t *testing.T, stub *shim.MockStub, fnName string, args []string, value string) {
 	bytes, err := stub.MockQuery(fnName, args)
 	if err != nil {
@@ -71,9 +96,40 @@ func checkInvokeWithReturnValue(t *testing.T, stub *shim.MockStub, fnName string
 	}
 }
 
+func approverAttributes(userId string, company string, department string) map[string]string {
+	return map[string]string{SLA_ATTR_USER_ID: userId, SLA_ATTR_COMPANY: company, SLA_ATTR_DEPARTMENT: department}
+}
+
+func payerAttributes(userId string) map[string]string {
+	return map[string]string{SLA_ATTR_USER_ID: userId, SLA_ATTR_ROLE: SLA_ROLE_PAYER}
+}
+
+// 트랜잭션 인증서(TCert) 속성을 설정하고 호출합니다.
+func mockInvokeWithAttributes(stub *shim.MockStub, txId string, attributes map[string]string, fnName string, args []string) ([]byte, error) {
+	stub.SetAttributes(attributes)
+	defer stub.SetAttributes(nil)
+	return stub.MockInvoke(txId, fnName, args)
+}
+
+func checkInvokeWithAttributes(t *testing.T, stub *shim.MockStub, attributes map[string]string, fnName string, args []string) {
+	_, err := mockInvokeWithAttributes(stub, "1", attributes, fnName, args)
+	if err != nil {
+		fmt.Println("Invoke", fnName, "failed", err)
+		t.FailNow()
+	}
+}
+
+func checkInvokeUnauthorized(t *testing.T, stub *shim.MockStub, attributes map[string]string, fnName string, args []string) {
+	_, err := mockInvokeWithAttributes(stub, "1", attributes, fnName, args)
+	if _, ok := err.(*SlaUnauthorizedError); !ok {
+		fmt.Println("Invoke", fnName, "was expected to fail with SlaUnauthorizedError but got", err)
+		t.FailNow()
+	}
+}
+
 func TestChaincodeSla_Init(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 
@@ -86,7 +142,7 @@ func TestChaincodeSla_Init(t *testing.T) {
 
 func TestChaincodeSla_Query_slaGetContractTempId(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 
@@ -95,7 +151,7 @@ func TestChaincodeSla_Query_slaGetContractTempId(t *testing.T) {
 
 func TestChaincodeSla_Query_slaGetContractTempIdBeforeAndAfterCreatingTempContract(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkState(t, stub, SLA_CONTRACT_TEMP_ID_COUNT_KEY, "1")
@@ -154,7 +210,7 @@ func TestChaincodeSla_Query_slaGetContractTempIdBeforeAndAfterCreatingTempContra
 
 func TestChaincodeSla_Query_slaGetContractId(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 
@@ -164,7 +220,7 @@ func TestChaincodeSla_Query_slaGetContractId(t *testing.T) {
 
 func TestChaincodeSla_Query_slaGetContractIdBeforeAndAfterSubmitingContract(t *testing.T) {
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkState(t, stub, SLA_CONTRACT_ID_COUNT_KEY, "1")
@@ -227,7 +283,7 @@ func TestChaincodeSla_Query_slaGetContractIdBeforeAndAfterSubmitingContract(t *t
 // Progression: "" 	 	--> "TEMP"
 // ----------------------------------------------------------------------------
 // In KVS
-// SLA_ALL_DATA						: "SLA_CONT_2017_00005|SLA_CONT_2017_00006"
+// SLA_INDEX_ALL CONTRACT				: "SLA_CONT_2017_00005"
 func TestChaincodeSla_Invoke_slaCreateTempContract(t *testing.T) {
 	inputContractContentInJson :=
 		`{
@@ -322,12 +378,13 @@ func TestChaincodeSla_Invoke_slaCreateTempContract(t *testing.T) {
 }`
 
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaCreateTempContract", []string{inputContractContentInJson})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00005"}, expectedContractContentInJson)
-	checkState(t, stub, "SLA_ALL_DATA", "SLA_CONT_2017_00005")
+	checkIndex(t, stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, []string{"SLA_CONT_2017_00005"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "홍길동", []string{})
 }
 
 // 최초 생성 + 바로 결제 요청
@@ -336,7 +393,7 @@ func TestChaincodeSla_Invoke_slaCreateTempContract(t *testing.T) {
 // Approvals[0].ApprovalState	""		--> "SUBMITTED"
 // ----------------------------------------------------------------------------
 // In KVS
-// SLA_ALL_DATA						: "SLA_CONT_2017_00005|SLA_CONT_2017_00006"
+// SLA_INDEX_ALL CONTRACT				: "SLA_CONT_2017_00005"
 func TestChaincodeSla_Invoke_slaSubmitContract(t *testing.T) {
 	inputContractContentInJson :=
 		`{
@@ -431,16 +488,16 @@ func TestChaincodeSla_Invoke_slaSubmitContract(t *testing.T) {
 }`
 
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00005"}, expectedContractContentInJson)
 
-	checkState(t, stub, "신한은행도급계약_201701", "SLA_CONT_2017_00005")
-	checkState(t, stub, "신한은행", "SLA_CONT_2017_00005")
-	checkState(t, stub, "SLA_ALL_DATA", "SLA_CONT_2017_00005")
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201701", []string{"SLA_CONT_2017_00005"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_CLIENT, "신한은행", []string{"SLA_CONT_2017_00005"})
+	checkIndex(t, stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, []string{"SLA_CONT_2017_00005"})
 }
 
 // 최초 생성 + 바로 결제 요청
@@ -452,11 +509,11 @@ func TestChaincodeSla_Invoke_slaSubmitContract(t *testing.T) {
 //						Approvals[0].ApprovalState	""		--> "SUBMITTED"
 //
 // Test2: 기타 KVS 저장 내용 확인 ------------------------------------------------------
-//					[Key]					[Value]
-// 계약명 			"신한은행도급계약_201701" 	"SLA_CONT_2017_00005"
-// 					"신한은행도급계약_201702" 	"SLA_CONT_2017_00006"
-// 고객사명 			"신한은행", 				"SLA_CONT_2017_00005|SLA_CONT_2017_00006"
-// 전체				SLA_ALL_DATA			"SLA_CONT_2017_00005|SLA_CONT_2017_00006"
+//					[Index]						[Value]					[ID]
+// 계약명 			SLA_INDEX_CONTRACT_NAME		"신한은행도급계약_201701" 	"SLA_CONT_2017_00005"
+// 					SLA_INDEX_CONTRACT_NAME		"신한은행도급계약_201702" 	"SLA_CONT_2017_00006"
+// 고객사명 			SLA_INDEX_CONTRACT_CLIENT	"신한은행", 				"SLA_CONT_2017_00005", "SLA_CONT_2017_00006"
+// 전체				SLA_INDEX_ALL				CONTRACT				"SLA_CONT_2017_00005", "SLA_CONT_2017_00006"
 // ----------------------------------------------------------------------------
 func TestChaincodeSla_SLA_ALL_DATA_AfterTwoSlaSubmitContract(t *testing.T) {
 	inputContractContentInJson_1 :=
@@ -643,7 +700,7 @@ func TestChaincodeSla_SLA_ALL_DATA_AfterTwoSlaSubmitContract(t *testing.T) {
 }`
 
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson_1})
@@ -653,10 +710,10 @@ func TestChaincodeSla_SLA_ALL_DATA_AfterTwoSlaSubmitContract(t *testing.T) {
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00006"}, expectedContractContentInJson_2)
 
 	// KVS 확인
-	checkState(t, stub, "신한은행도급계약_201701", "SLA_CONT_2017_00005")
-	checkState(t, stub, "신한은행도급계약_201702", "SLA_CONT_2017_00006")
-	checkState(t, stub, "신한은행", "SLA_CONT_2017_00005|SLA_CONT_2017_00006")
-	checkState(t, stub, "SLA_ALL_DATA", "SLA_CONT_2017_00005|SLA_CONT_2017_00006")
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201701", []string{"SLA_CONT_2017_00005"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201702", []string{"SLA_CONT_2017_00006"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_CLIENT, "신한은행", []string{"SLA_CONT_2017_00005", "SLA_CONT_2017_00006"})
+	checkIndex(t, stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, []string{"SLA_CONT_2017_00005", "SLA_CONT_2017_00006"})
 }
 
 // 계약을 업데이트 합니다.
@@ -760,7 +817,7 @@ func TestChaincodeSla_Invoke_slaUpdateContract(t *testing.T) {
 }`
 
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
@@ -916,13 +973,13 @@ func TestChaincodeSla_Invoke_slaApproveContract(t *testing.T) {
   ]
 }`
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00010"}, expectedContractContentInJsonAfterSubmission)
 
-	approvalDate := time.Now().Format("2006-01-02")
+	approvalDate := testTxTime.Format("2006-01-02")
 	// 내부검토자 승인 후 예상 결과갑
 	expectedContractContentInJsonAfterInternalApproval :=
 		`{
@@ -994,7 +1051,7 @@ func TestChaincodeSla_Invoke_slaApproveContract(t *testing.T) {
 	SlaContractApprovalUserId := "내부관리자_A"
 	SlaContractApprovalComment := "내용확인 하였음"
 
-	checkInvoke(t, stub, "slaApproveContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
+	checkInvokeWithAttributes(t, stub, approverAttributes(SlaContractApprovalUserId, "test", "test"), "slaApproveContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00010"}, expectedContractContentInJsonAfterInternalApproval)
 
 	// 고객담당자(계약접수자_ 승인 예상 결과값
@@ -1069,7 +1126,7 @@ func TestChaincodeSla_Invoke_slaApproveContract(t *testing.T) {
 	SlaContractApprovalUserId = "고객_A"
 	SlaContractApprovalComment = "내용확인 하였음"
 
-	checkInvoke(t, stub, "slaApproveContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
+	checkInvokeWithAttributes(t, stub, approverAttributes(SlaContractApprovalUserId, "test2", "test2"), "slaApproveContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00010"}, expectedContractContentInJsonAfterClientReview)
 
 	// 고객관리자(계약검토자) 승인 예상 결과값
@@ -1143,7 +1200,7 @@ func TestChaincodeSla_Invoke_slaApproveContract(t *testing.T) {
 	SlaContractApprovalUserId = "고객관리자_A"
 	SlaContractApprovalComment = "내용확인 하였음"
 
-	checkInvoke(t, stub, "slaCloseContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
+	checkInvokeWithAttributes(t, stub, approverAttributes(SlaContractApprovalUserId, "test2", "test2"), "slaCloseContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00010"}, expectedContractContentInJsonAfterClientManagerReview)
 }
 
@@ -1216,7 +1273,7 @@ func TestChaincodeSla_Invoke_slaRejectContract(t *testing.T) {
   ]
 }`
 
-	approvalDate := time.Now().Format("2006-01-02")
+	approvalDate := testTxTime.Format("2006-01-02")
 	// 예상 결과갑
 	expectedContractContentInJson :=
 		`{
@@ -1285,7 +1342,7 @@ func TestChaincodeSla_Invoke_slaRejectContract(t *testing.T) {
 }`
 
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
@@ -1295,7 +1352,7 @@ func TestChaincodeSla_Invoke_slaRejectContract(t *testing.T) {
 	SlaContractApprovalUserId := "내부관리자_A"
 	SlaContractApprovalComment := "추가내용 필요함"
 
-	checkInvoke(t, stub, "slaRejectContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
+	checkInvokeWithAttributes(t, stub, approverAttributes(SlaContractApprovalUserId, "test", "test"), "slaRejectContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00010"}, expectedContractContentInJson)
 }
 
@@ -1367,7 +1424,7 @@ func TestChaincodeSla_Invoke_slaCloseContract(t *testing.T) {
   ]
 }`
 
-	approvalDate := time.Now().Format("2006-01-02")
+	approvalDate := testTxTime.Format("2006-01-02")
 	// 예상 결과갑
 	expectedContractContentInJson :=
 		`{
@@ -1435,7 +1492,7 @@ func TestChaincodeSla_Invoke_slaCloseContract(t *testing.T) {
   ]
 }`
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
@@ -1445,7 +1502,7 @@ func TestChaincodeSla_Invoke_slaCloseContract(t *testing.T) {
 	SlaContractApprovalUserId := "내부관리자_A"
 	SlaContractApprovalComment := "내용확인 하였음"
 
-	checkInvoke(t, stub, "slaApproveContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
+	checkInvokeWithAttributes(t, stub, approverAttributes(SlaContractApprovalUserId, "test", "test"), "slaApproveContract", []string{SlaContractRegId, SlaContractApprovalUserId, SlaContractApprovalComment})
 	checkQuery(t, stub, "slaGetContractWithId", []string{"SLA_CONT_2017_00010"}, expectedContractContentInJson)
 }
 
@@ -1639,7 +1696,7 @@ func TestChaincodeSla_Invoke_GetFns(t *testing.T) {
 }`
 
 	scc := new(SimpleChaincode)
-	stub := shim.NewMockStub("sla_chaincode", scc)
+	stub := newMockStub(scc)
 
 	checkInit(t, stub, []string{})
 	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson_1})
@@ -1664,8 +1721,842 @@ func TestChaincodeSla_Invoke_GetFns(t *testing.T) {
 	checkQuery(t, stub, "slaGetContractsWithClient", []string{"신한은행"}, string(expected))
 
 	// KVS 확인
-	checkState(t, stub, "신한은행도급계약_201701", "SLA_CONT_2017_00005")
-	checkState(t, stub, "신한은행도급계약_201702", "SLA_CONT_2017_00006")
-	checkState(t, stub, "신한은행", "SLA_CONT_2017_00005|SLA_CONT_2017_00006")
-	checkState(t, stub, "SLA_ALL_DATA", "SLA_CONT_2017_00005|SLA_CONT_2017_00006")
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201701", []string{"SLA_CONT_2017_00005"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201702", []string{"SLA_CONT_2017_00006"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_CLIENT, "신한은행", []string{"SLA_CONT_2017_00005", "SLA_CONT_2017_00006"})
+	checkIndex(t, stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, []string{"SLA_CONT_2017_00005", "SLA_CONT_2017_00006"})
+}
+
+func checkInvokeFails(t *testing.T, stub *shim.MockStub, fnName string, args []string) {
+	_, err := stub.MockInvoke("1", fnName, args)
+	if err == nil {
+		fmt.Println("Invoke", fnName, "was expected to fail")
+		t.FailNow()
+	}
+}
+
+func checkInvokeFailsWithAttributes(t *testing.T, stub *shim.MockStub, attributes map[string]string, fnName string, args []string) {
+	_, err := mockInvokeWithAttributes(stub, "1", attributes, fnName, args)
+	if err == nil {
+		fmt.Println("Invoke", fnName, "was expected to fail")
+		t.FailNow()
+	}
+}
+
+func getEvaluation(t *testing.T, stub *shim.MockStub, evaluationId string) SlaEvaluation {
+	var evaluation SlaEvaluation
+	bytes, err := stub.MockQuery("slaGetEvaluationWithId", []string{evaluationId})
+	if err != nil || bytes == nil {
+		fmt.Println("Query slaGetEvaluationWithId failed with", evaluationId, err)
+		t.FailNow()
+	}
+	if err = json.Unmarshal(bytes, &evaluation); err != nil {
+		fmt.Println("Failed to unmarshal evaluation", string(bytes))
+		t.FailNow()
+	}
+	return evaluation
+}
+
+func getEvaluationRoot(t *testing.T, stub *shim.MockStub, evaluationRootId string) SlaEvalutionRoot {
+	var evaluationRoot SlaEvalutionRoot
+	bytes, err := stub.MockQuery("slaGetEvaluationWithId", []string{evaluationRootId})
+	if err != nil || bytes == nil {
+		fmt.Println("Query slaGetEvaluationWithId failed with", evaluationRootId, err)
+		t.FailNow()
+	}
+	if err = json.Unmarshal(bytes, &evaluationRoot); err != nil {
+		fmt.Println("Failed to unmarshal evaluation", string(bytes))
+		t.FailNow()
+	}
+	return evaluationRoot
+}
+
+func checkEvaluationProgression(t *testing.T, stub *shim.MockStub, evaluationId string, progression string) {
+	evaluation := getEvaluation(t, stub, evaluationId)
+	if evaluation.Progression != progression {
+		fmt.Printf("Evaluation %v progression [%v] was not the expected value[%v]\n", evaluationId, evaluation.Progression, progression)
+		t.FailNow()
+	}
+}
+
+// 평가 진행
+// 계약 최종 승인(CLOSED) --> 전체 평가 생성 --> 평가항목별 개별 평가 생성
+// 개별 평가: 점수입력 --> 결재요청 --> 승인/반려 --> 지급요청 --> 지급완료 --> 종료
+// 마지막 개별 평가가 종료되면 전체 평가도 종료
+func TestChaincodeSla_Invoke_EvaluationLifecycle(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00020",
+  "Name": "신한은행도급계약_201703",
+  "Kind": "보통계약",
+  "StaDate": "2017-02-01",
+  "EndDate": "2017-12-01",
+  "Client": "신한은행",
+  "ClientPerson": "개인",
+  "ClientPersonTel": "010-1111-2222",
+  "AssessDate": "2017-12-31",
+  "Progression": "",
+  "AssessYn": "포함",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalState": "TEMP" }
+  ],
+  "ServiceItems": [
+    { "ServiceItem": "장애처리", "ScoreItem": "처리시간", "DivideScore": "60" },
+    { "ServiceItem": "서비스요청", "ScoreItem": "처리율", "DivideScore": "40" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00020"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+
+	// 최종 승인 전에는 평가를 생성할 수 없음
+	checkInvokeFails(t, stub, "slaCreateEvaluationTemplateFromContract", []string{contractId})
+
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaApproveContract", []string{contractId, "고객관리자_A", "확인"})
+
+	// 전체 평가 + 개별 평가 생성
+	evaluationRootIdInBytes, err := stub.MockQuery("slaGetEvaluationId", []string{})
+	if err != nil {
+		fmt.Println("Query slaGetEvaluationId failed", err)
+		t.FailNow()
+	}
+	evaluationRootId := string(evaluationRootIdInBytes)
+	checkInvokeWithReturnValue(t, stub, "slaCreateEvaluationTemplateFromContract", []string{contractId}, evaluationRootId)
+	checkState(t, stub, SLA_EVALUATION_ID_COUNT_KEY, "2")
+	checkState(t, stub, EVALUATION_ROOT_OF_CONTRACT_PREFIX+contractId, evaluationRootId)
+
+	// 계약당 하나의 평가만 생성
+	checkInvokeFails(t, stub, "slaCreateEvaluationTemplateFromContract", []string{contractId})
+
+	evaluationRoot := getEvaluationRoot(t, stub, evaluationRootId)
+	if evaluationRoot.Status != SLA_EVALUATION_ROOT_STATUS_IN_PROGRESS || len(evaluationRoot.Evaluations) != 2 {
+		fmt.Println("Unexpected evaluation root", evaluationRoot)
+		t.FailNow()
+	}
+	evaluationId_1 := evaluationRoot.Evaluations[0]
+	evaluationId_2 := evaluationRoot.Evaluations[1]
+	if evaluationId_1 != evaluationRootId+"_001" || evaluationId_2 != evaluationRootId+"_002" {
+		fmt.Println("Unexpected evaluation ids", evaluationRoot.Evaluations)
+		t.FailNow()
+	}
+
+	evaluation := getEvaluation(t, stub, evaluationId_2)
+	if evaluation.ContractId != contractId || evaluation.ServiceItem.ServiceItem != "서비스요청" ||
+		evaluation.Progression != SLA_EVALUATION_PROGRESSION_TEMP || len(evaluation.Approvals) != 4 {
+		fmt.Println("Unexpected evaluation", evaluation)
+		t.FailNow()
+	}
+
+	// 조회
+	allEvaluations, _ := stub.MockQuery("slaGetAllEvaluations", []string{})
+	withName, _ := stub.MockQuery("slaGetEvaluationsWithName", []string{"신한은행도급계약_201703"})
+	withClient, _ := stub.MockQuery("slaGetEvaluationsWithClient", []string{"신한은행"})
+	var evaluationList []string
+	for _, bytes := range [][]byte{allEvaluations, withName, withClient} {
+		if err = json.Unmarshal(bytes, &evaluationList); err != nil || len(evaluationList) != 2 {
+			fmt.Println("Unexpected evaluation list", string(bytes))
+			t.FailNow()
+		}
+	}
+
+	// 점수입력 전에는 결재요청 불가, 잘못된 점수는 입력 불가
+	checkInvokeFailsWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaSubmitEvaluation", []string{evaluationId_1})
+	checkInvokeFailsWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaInitEvaluationValues", []string{evaluationId_1, "만점"})
+
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaInitEvaluationValues", []string{evaluationId_1, "55"})
+	checkInvokeFailsWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaInitEvaluationValues", []string{evaluationId_1, "56"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaUpdateEvaluationValues", []string{evaluationId_1, "57"})
+	checkEvaluationProgression(t, stub, evaluationId_1, SLA_EVALUATION_PROGRESSION_VALUES_ENTERED)
+
+	// 결재요청 --> 내부 승인 --> 고객 반려: 점수입력 상태로 돌아감
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaSubmitEvaluation", []string{evaluationId_1})
+	checkInvokeFailsWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaUpdateEvaluationValues", []string{evaluationId_1, "58"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveEvaluation", []string{evaluationId_1, "내부관리자_A", "확인"})
+	checkEvaluationProgression(t, stub, evaluationId_1, SLA_EVALUATION_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED)
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaRejectEvaluation", []string{evaluationId_1, "고객_A", "점수 재확인 요망"})
+	evaluation = getEvaluation(t, stub, evaluationId_1)
+	if evaluation.Progression != SLA_EVALUATION_PROGRESSION_VALUES_ENTERED ||
+		evaluation.Approvals[2].ApprovalState != SLA_APPROVAL_STATE_REJECTED ||
+		evaluation.Approvals[2].ApprovalComment != "점수 재확인 요망" {
+		fmt.Println("Unexpected evaluation after rejection", evaluation)
+		t.FailNow()
+	}
+
+	// 수정 후 재요청 --> 최종 승인
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaUpdateEvaluationValues", []string{evaluationId_1, "58"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaSubmitEvaluation", []string{evaluationId_1})
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveEvaluation", []string{evaluationId_1, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveEvaluation", []string{evaluationId_1, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaApproveEvaluation", []string{evaluationId_1, "고객관리자_A", "확인"})
+	checkEvaluationProgression(t, stub, evaluationId_1, SLA_EVALUATION_PROGRESSION_APPROVED)
+	checkInvokeFails(t, stub, "slaApproveEvaluation", []string{evaluationId_1, "고객관리자_A", "확인"})
+
+	// 지급요청 --> 지급완료 --> 종료
+	checkInvokeFails(t, stub, "slaClosePayment", []string{evaluationId_1, "지급자_A", "지급"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaSubmitPayment", []string{evaluationId_1, "지급자_A"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("지급자_A", "", ""), "slaClosePayment", []string{evaluationId_1, "지급자_A", "지급"})
+	checkInvokeUnauthorized(t, stub, payerAttributes("지급자_B"), "slaClosePayment", []string{evaluationId_1, "지급자_A", "지급"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaClosePayment", []string{evaluationId_1, "지급자_A", "지급"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaCloseEvaluation", []string{evaluationId_1})
+	checkEvaluationProgression(t, stub, evaluationId_1, SLA_EVALUATION_PROGRESSION_CLOSED)
+
+	// 개별 평가가 남아 있으면 전체 평가 종료 불가
+	checkInvokeFails(t, stub, "slaCloseEvaluationRoot", []string{evaluationRootId})
+	if getEvaluationRoot(t, stub, evaluationRootId).Status != SLA_EVALUATION_ROOT_STATUS_IN_PROGRESS {
+		fmt.Println("Evaluation root closed before all evaluations were closed")
+		t.FailNow()
+	}
+
+	// 마지막 개별 평가 종료 --> 전체 평가 자동 종료
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaInitEvaluationValues", []string{evaluationId_2, "40"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("기안자_A", "", ""), "slaSubmitEvaluation", []string{evaluationId_2})
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveEvaluation", []string{evaluationId_2, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveEvaluation", []string{evaluationId_2, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaApproveEvaluation", []string{evaluationId_2, "고객관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaSubmitPayment", []string{evaluationId_2, "지급자_A"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaClosePayment", []string{evaluationId_2, "지급자_A", "지급"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaCloseEvaluation", []string{evaluationId_2})
+
+	if getEvaluationRoot(t, stub, evaluationRootId).Status != SLA_EVALUATION_ROOT_STATUS_CLOSED {
+		fmt.Println("Evaluation root was not closed after the last evaluation was closed")
+		t.FailNow()
+	}
+}
+
+// 평가점수 입력/결재요청은 결재선의 평가자만, 지급요청/평가종료는 지급자만 할 수 있다.
+func TestChaincodeSla_Invoke_EvaluationAttributes(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00036",
+  "Name": "신한은행도급계약_201711",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalCompany": "신한DS", "ApprovalDepartment": "IT운영팀", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalCompany": "신한DS", "ApprovalDepartment": "IT운영팀", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalState": "TEMP" }
+  ],
+  "ServiceItems": [
+    { "ServiceItem": "장애처리", "ScoreItem": "처리시간", "DivideScore": "100" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00036"
+	evaluator := approverAttributes("기안자_A", "신한DS", "IT운영팀")
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT운영팀"), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "신한은행", "IT기획부"), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "신한은행", "IT기획부"), "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+
+	evaluationRootId, err := stub.MockInvoke("1", "slaCreateEvaluationTemplateFromContract", []string{contractId})
+	if err != nil {
+		fmt.Println("Invoke slaCreateEvaluationTemplateFromContract failed", err)
+		t.FailNow()
+	}
+	evaluationId := getEvaluationRoot(t, stub, string(evaluationRootId)).Evaluations[0]
+
+	// 속성이 없는 호출자, 평가자가 아닌 결재자, 회사/부서가 다른 사용자는 점수를 입력할 수 없음
+	for _, attributes := range []map[string]string{
+		{},
+		approverAttributes("내부관리자_A", "신한DS", "IT운영팀"),
+		approverAttributes("기안자_A", "신한DS", "IT개발팀"),
+		payerAttributes("기안자_A"),
+	} {
+		checkInvokeUnauthorized(t, stub, attributes, "slaInitEvaluationValues", []string{evaluationId, "55"})
+	}
+	checkEvaluationProgression(t, stub, evaluationId, SLA_EVALUATION_PROGRESSION_TEMP)
+	checkInvokeWithAttributes(t, stub, evaluator, "slaInitEvaluationValues", []string{evaluationId, "55"})
+
+	checkInvokeUnauthorized(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT운영팀"), "slaUpdateEvaluationValues", []string{evaluationId, "0"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT운영팀"), "slaSubmitEvaluation", []string{evaluationId})
+	if getEvaluation(t, stub, evaluationId).ScoresForServiceItems != "55" {
+		fmt.Println("Evaluation score was changed by an unauthorized caller")
+		t.FailNow()
+	}
+	checkEvaluationProgression(t, stub, evaluationId, SLA_EVALUATION_PROGRESSION_VALUES_ENTERED)
+	checkInvokeWithAttributes(t, stub, evaluator, "slaUpdateEvaluationValues", []string{evaluationId, "60"})
+	checkInvokeWithAttributes(t, stub, evaluator, "slaSubmitEvaluation", []string{evaluationId})
+
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT운영팀"), "slaApproveEvaluation", []string{evaluationId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "신한은행", "IT기획부"), "slaApproveEvaluation", []string{evaluationId, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "신한은행", "IT기획부"), "slaApproveEvaluation", []string{evaluationId, "고객관리자_A", "확인"})
+
+	// 지급자 역할이 없거나 다른 사용자ID로는 지급을 요청할 수 없음
+	checkInvokeUnauthorized(t, stub, evaluator, "slaSubmitPayment", []string{evaluationId, "기안자_A"})
+	checkInvokeUnauthorized(t, stub, payerAttributes("지급자_B"), "slaSubmitPayment", []string{evaluationId, "지급자_A"})
+	checkInvokeUnauthorized(t, stub, payerAttributes("지급자_A"), "slaSubmitPayment", []string{evaluationId, ""})
+	checkEvaluationProgression(t, stub, evaluationId, SLA_EVALUATION_PROGRESSION_APPROVED)
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaSubmitPayment", []string{evaluationId, "지급자_A"})
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaClosePayment", []string{evaluationId, "지급자_A", "지급"})
+
+	// 지급자 역할이 없으면 평가를 종료할 수 없음
+	checkInvokeUnauthorized(t, stub, map[string]string{}, "slaCloseEvaluation", []string{evaluationId})
+	checkInvokeUnauthorized(t, stub, evaluator, "slaCloseEvaluation", []string{evaluationId})
+	checkInvokeUnauthorized(t, stub, map[string]string{SLA_ATTR_ROLE: SLA_ROLE_PAYER}, "slaCloseEvaluation", []string{evaluationId})
+	checkEvaluationProgression(t, stub, evaluationId, SLA_EVALUATION_PROGRESSION_PAID)
+	checkInvokeWithAttributes(t, stub, payerAttributes("지급자_A"), "slaCloseEvaluation", []string{evaluationId})
+	checkEvaluationProgression(t, stub, evaluationId, SLA_EVALUATION_PROGRESSION_CLOSED)
+}
+
+func checkInvokeIllegalTransition(t *testing.T, stub *shim.MockStub, fnName string, args []string) {
+	_, err := stub.MockInvoke("1", fnName, args)
+	if _, ok := err.(*SlaIllegalTransitionError); !ok {
+		fmt.Println("Invoke", fnName, "was expected to fail with SlaIllegalTransitionError but got", err)
+		t.FailNow()
+	}
+}
+
+func checkContractProgression(t *testing.T, stub *shim.MockStub, contractId string, progression string) {
+	var contract SlaContract
+	bytes, _ := stub.MockQuery("slaGetContractWithId", []string{contractId})
+	if err := json.Unmarshal(bytes, &contract); err != nil {
+		fmt.Println("Failed to unmarshal contract", string(bytes))
+		t.FailNow()
+	}
+	if contract.Progression != progression {
+		fmt.Printf("Contract %v progression [%v] was not the expected value[%v]\n", contractId, contract.Progression, progression)
+		t.FailNow()
+	}
+}
+
+// 계약 상태 전이표
+// 허용되지 않는 상태 전이는 SlaIllegalTransitionError 로 거부되고, 허용된 전이는 이력에 기록된다.
+func TestChaincodeSla_Invoke_ContractTransitions(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00030",
+  "Name": "신한은행도급계약_201704",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00030"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+
+	// 저장되지 않은 계약
+	_, err := stub.MockInvoke("1", "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	if _, ok := err.(*SlaContractNotFoundError); !ok {
+		fmt.Println("slaApproveContract was expected to fail with SlaContractNotFoundError but got", err)
+		t.FailNow()
+	}
+
+	// 임시저장 상태에서는 승인/반려 불가
+	checkInvoke(t, stub, "slaCreateTempContract", []string{inputContractContentInJson})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_TEMP)
+	checkInvokeIllegalTransition(t, stub, "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeIllegalTransition(t, stub, "slaRejectContract", []string{contractId, "내부관리자_A", "확인"})
+
+	// 결재 요청은 두 번 할 수 없음
+	if _, err = stub.MockInvoke("tx_submit", "slaSubmitContract", []string{inputContractContentInJson}); err != nil {
+		fmt.Println("Invoke slaSubmitContract failed", err)
+		t.FailNow()
+	}
+	checkInvokeIllegalTransition(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvokeIllegalTransition(t, stub, "slaCreateTempContract", []string{inputContractContentInJson})
+	checkInvokeIllegalTransition(t, stub, "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+
+	// 반려 후에는 반려된 결재를 다시 승인할 수 없고, 재요청 후 승인 가능
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	_, err = mockInvokeWithAttributes(stub, "tx_reject", approverAttributes("고객_A", "", ""), "slaRejectContract", []string{contractId, "고객_A", "재검토 요망"})
+	if err != nil {
+		fmt.Println("Invoke slaRejectContract failed", err)
+		t.FailNow()
+	}
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED)
+	checkInvokeIllegalTransition(t, stub, "slaApproveContract", []string{contractId, "고객_A", "확인"})
+
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED)
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_CLOSED)
+
+	// 최종 승인된 계약은 다시 요청, 수정, 폐기할 수 없음
+	checkInvokeIllegalTransition(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvokeIllegalTransition(t, stub, "slaUpdateContract", []string{inputContractContentInJson})
+	checkInvokeIllegalTransition(t, stub, "slaAbandonContract", []string{contractId})
+	checkInvokeIllegalTransition(t, stub, "slaApproveContract", []string{contractId, "고객관리자_A", "확인"})
+
+	// 상태 전이 이력
+	var histories []SlaContractHistory
+	bytes, err := stub.MockQuery("slaGetContractHistory", []string{contractId})
+	if err != nil || json.Unmarshal(bytes, &histories) != nil {
+		fmt.Println("Query slaGetContractHistory failed", err, string(bytes))
+		t.FailNow()
+	}
+	expectedActions := []string{
+		SLA_CONTRACT_ACTION_CREATE_TEMP,
+		SLA_CONTRACT_ACTION_SUBMIT,
+		SLA_CONTRACT_ACTION_APPROVE,
+		SLA_CONTRACT_ACTION_REJECT,
+		SLA_CONTRACT_ACTION_SUBMIT,
+		SLA_CONTRACT_ACTION_APPROVE,
+		SLA_CONTRACT_ACTION_APPROVE,
+		SLA_CONTRACT_ACTION_CLOSE,
+	}
+	if len(histories) != len(expectedActions) {
+		fmt.Println("Unexpected contract history", string(bytes))
+		t.FailNow()
+	}
+	for i, action := range expectedActions {
+		if histories[i].Action != action {
+			fmt.Printf("History %v action [%v] was not the expected value[%v]\n", i, histories[i].Action, action)
+			t.FailNow()
+		}
+	}
+	if histories[1].TxId != "tx_submit" || histories[1].From != SLA_CONTRACT_PROGRESSION_TEMP ||
+		histories[1].To != SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED {
+		fmt.Println("Unexpected submit history", histories[1])
+		t.FailNow()
+	}
+	if histories[3].TxId != "tx_reject" || histories[3].UserId != "고객_A" {
+		fmt.Println("Unexpected reject history", histories[3])
+		t.FailNow()
+	}
+	if histories[7].To != SLA_CONTRACT_PROGRESSION_CLOSED {
+		fmt.Println("Unexpected close history", histories[7])
+		t.FailNow()
+	}
+}
+
+func TestChaincodeSla_Invoke_slaAbandonContract(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00031",
+  "Name": "신한은행도급계약_201705",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00031"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvoke(t, stub, "slaAbandonContract", []string{contractId})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_ABANDONED)
+
+	checkInvokeIllegalTransition(t, stub, "slaAbandonContract", []string{contractId})
+	checkInvokeIllegalTransition(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvokeIllegalTransition(t, stub, "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+}
+
+// 결재자 확인
+// 승인/반려는 결재선의 사용자ID, 회사, 부서가 호출자의 TCert 속성과 일치할 때만 허용된다.
+func TestChaincodeSla_Invoke_ApprovalAttributes(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00032",
+  "Name": "신한은행도급계약_201706",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalCompany": "신한DS", "ApprovalDepartment": "IT운영팀", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalCompany": "신한DS", "ApprovalDepartment": "IT운영팀", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00032"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+
+	// 속성이 없는 호출자, 결재선에 없는 사용자, 회사/부서가 다른 사용자는 승인할 수 없음
+	checkInvokeUnauthorized(t, stub, map[string]string{}, "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("고객_A", "신한은행", "IT기획부"), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT개발팀"), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("내부관리자_B", "신한DS", "IT운영팀"), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("내부관리자_B", "신한DS", "IT운영팀"), "slaRejectContract", []string{contractId, "내부관리자_A", "반려"})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED)
+
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT운영팀"), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "신한은행", "IT기획부"), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("고객관리자_A", "신한DS", "IT기획부"), "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "신한은행", "IT기획부"), "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_CLOSED)
+}
+
+// 사용자ID가 비어 있는 승인/반려/종료도 결재자 확인을 거친다.
+func TestChaincodeSla_Invoke_ApprovalWithoutUserId(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00034",
+  "Name": "신한은행도급계약_201708",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00034"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+
+	for _, fnName := range []string{"slaApproveContract", "slaRejectContract"} {
+		checkInvokeUnauthorized(t, stub, map[string]string{}, fnName, []string{contractId, "", "x"})
+		checkInvokeUnauthorized(t, stub, approverAttributes("", "", ""), fnName, []string{contractId, "", "x"})
+	}
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED)
+
+	// 마지막 결재 차례에서도 사용자ID 없이 종료할 수 없음
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeUnauthorized(t, stub, map[string]string{}, "slaCloseContract", []string{contractId, "", "x"})
+	checkInvokeUnauthorized(t, stub, approverAttributes("", "", ""), "slaCloseContract", []string{contractId, "", "x"})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_MANAGER_REVIEW_REQUESTED)
+
+	contract, err := (&SimpleChaincode{}).slaGetContract(stub, contractId)
+	if err != nil {
+		fmt.Println("Failed to get contract", err)
+		t.FailNow()
+	}
+	if contract.Approvals[3].ApprovalState != SLA_APPROVAL_STATE_TEMP {
+		fmt.Println("ApprovalState of", contract.Approvals[3].ApprovalUserId, "was changed to", contract.Approvals[3].ApprovalState)
+		t.FailNow()
+	}
+}
+
+// 결재 요청 후에는 결재선을 변경할 수 없다.
+// 결재 진행 중에 다음 결재자의 회사/부서를 호출자의 속성에 맞게 바꿔 승인할 수 없어야 함
+func TestChaincodeSla_Invoke_ApprovalLineFrozen(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00035",
+  "Name": "신한은행도급계약_201709",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalCompany": "신한DS", "ApprovalDepartment": "IT운영팀", "ApprovalName": "기안자", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalCompany": "신한DS", "ApprovalDepartment": "IT운영팀", "ApprovalName": "내부관리자", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalName": "고객", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalName": "고객관리자", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00035"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "신한DS", "IT운영팀"), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+
+	// 다음 결재자(고객_A)의 결재선 변경
+	for _, change := range [][2]string{
+		{`"ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalName": "고객"`, `"ApprovalCompany": "신한DS", "ApprovalDepartment": "IT기획부", "ApprovalName": "고객"`},
+		{`"ApprovalCompany": "신한은행", "ApprovalDepartment": "IT기획부", "ApprovalName": "고객"`, `"ApprovalCompany": "신한은행", "ApprovalDepartment": "IT운영팀", "ApprovalName": "고객"`},
+		{`"ApprovalName": "고객"`, `"ApprovalName": "현업"`},
+		{`"ApprovalUserId": "고객_A"`, `"ApprovalUserId": "고객_B"`},
+	} {
+		checkInvokeFails(t, stub, "slaUpdateContract", []string{strings.Replace(inputContractContentInJson, change[0], change[1], 1)})
+	}
+	checkInvokeUnauthorized(t, stub, approverAttributes("고객_A", "신한DS", "IT기획부"), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkContractProgression(t, stub, contractId, SLA_CONTRACT_PROGRESSION_IN_PROGRESS_CLIENT_REVIEW_REQUESTED)
+
+	// 결재선 이외의 변경은 허용되며 결재선은 저장된 값을 유지
+	checkInvoke(t, stub, "slaUpdateContract", []string{strings.Replace(inputContractContentInJson, "신한은행도급계약_201709", "신한은행도급계약_201710", 1)})
+	contract, err := (&SimpleChaincode{}).slaGetContract(stub, contractId)
+	if err != nil {
+		fmt.Println("Failed to get contract", err)
+		t.FailNow()
+	}
+	if contract.Name != "신한은행도급계약_201710" || contract.Approvals[1].ApprovalState != SLA_APPROVAL_STATE_APPROVED ||
+		contract.Approvals[2].ApprovalCompany != "신한은행" {
+		fmt.Println("Unexpected contract after slaUpdateContract", contract)
+		t.FailNow()
+	}
+}
+
+// 계약명이 시스템 키와 같아도 계약 카운트를 덮어쓰지 않음
+func TestChaincodeSla_Invoke_ContractNameCollidingWithSystemKey(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00040",
+  "Name": "SLA_CONTRACT_ID_COUNT",
+  "Client": "SLA_ALL_DATA",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" }
+  ]
+}`
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+
+	checkState(t, stub, SLA_CONTRACT_ID_COUNT_KEY, "2")
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "SLA_CONTRACT_ID_COUNT", []string{"SLA_CONT_2017_00040"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_CLIENT, "SLA_ALL_DATA", []string{"SLA_CONT_2017_00040"})
+}
+
+// 이전 버전의 "|" 구분 ID목록 키를 composite key 인덱스로 변환
+func TestChaincodeSla_Invoke_slaMigrateIndexes(t *testing.T) {
+	contract_1 := SlaContract{RegId: "SLA_CONT_2017_00001", Name: "신한은행도급계약_201701", Client: "신한은행", Progression: SLA_CONTRACT_PROGRESSION_IN_PROGRESS_INTERNAL_REVIEW_REQUESTED}
+	contract_12 := SlaContract{RegId: "SLA_CONT_2017_00012", Name: "신한은행도급계약_201702", Client: "신한은행", Progression: SLA_CONTRACT_PROGRESSION_CLOSED}
+	contract_temp := SlaContract{RegId: "SLA_CONT_2017_00013", Name: "신한은행도급계약_201703", Client: "신한카드", Progression: SLA_CONTRACT_PROGRESSION_TEMP}
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+
+	// 이전 버전의 ledger
+	stub.MockTransactionStart("legacy")
+	for _, contract := range []SlaContract{contract_1, contract_12, contract_temp} {
+		contractInJson, _ := json.MarshalIndent(contract, "", "  ")
+		stub.PutState(contract.RegId, contractInJson)
+	}
+	stub.PutState(SLA_ALL_DATA, []byte("SLA_CONT_2017_00001|SLA_CONT_2017_00012|SLA_CONT_2017_00013"))
+	stub.PutState("신한은행도급계약_201701", []byte("SLA_CONT_2017_00001"))
+	stub.PutState("신한은행도급계약_201702", []byte("SLA_CONT_2017_00012"))
+	stub.PutState("신한은행", []byte("SLA_CONT_2017_00001|SLA_CONT_2017_00012"))
+	stub.PutState(SLA_ALL_EVALUATION_DATA, []byte("SLA_EVAL_2017_00001"))
+	stub.MockTransactionEnd("legacy")
+
+	checkInvoke(t, stub, "slaMigrateIndexes", []string{})
+	checkInvoke(t, stub, "slaMigrateIndexes", []string{})
+
+	for _, key := range []string{SLA_ALL_DATA, SLA_ALL_EVALUATION_DATA, "신한은행도급계약_201701", "신한은행도급계약_201702", "신한은행"} {
+		if stub.State[key] != nil {
+			fmt.Println("State", key, "was not deleted by slaMigrateIndexes")
+			t.FailNow()
+		}
+	}
+	checkState(t, stub, SLA_CONTRACT_ID_COUNT_KEY, "1")
+
+	checkIndex(t, stub, SLA_INDEX_ALL, SLA_INDEX_ALL_CONTRACTS, []string{"SLA_CONT_2017_00001", "SLA_CONT_2017_00012", "SLA_CONT_2017_00013"})
+	checkIndex(t, stub, SLA_INDEX_ALL, SLA_INDEX_ALL_EVALUATIONS, []string{"SLA_EVAL_2017_00001"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201701", []string{"SLA_CONT_2017_00001"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_NAME, "신한은행도급계약_201703", []string{})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_CLIENT, "신한은행", []string{"SLA_CONT_2017_00001", "SLA_CONT_2017_00012"})
+	checkIndex(t, stub, SLA_INDEX_CONTRACT_CLIENT, "신한카드", []string{})
+}
+
+// KVS 전체의 해시값을 계산합니다. (키 순서대로)
+func stateHash(stub *shim.MockStub) []byte {
+	hash := sha256.New()
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		key := elem.Value.(string)
+		hash.Write([]byte(key))
+		hash.Write([]byte{0})
+		hash.Write(stub.State[key])
+		hash.Write([]byte{0})
+	}
+	return hash.Sum(nil)
+}
+
+// 같은 트랜잭션을 실행한 두 피어의 KVS는 실행 시각(time.Now)과 관계없이 같아야 한다.
+// 연도가 바뀌는 시점(KST 기준)의 채번과 결재일자도 트랜잭션 시각을 따른다.
+func TestChaincodeSla_Invoke_DeterministicReplay(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00001",
+  "Name": "신한은행도급계약_201712",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00001"
+
+	// 2017-12-31 23:59 KST 와 2018-01-01 00:01 KST (UTC 기준으로는 둘 다 2017년)
+	lastTxTimeOf2017 := time.Date(2017, 12, 31, 23, 59, 0, 0, slaTimeZone)
+	firstTxTimeOf2018 := time.Date(2018, 1, 1, 0, 1, 0, 0, slaTimeZone)
+
+	replay := func() *shim.MockStub {
+		scc := new(SimpleChaincode)
+		stub := newMockStub(scc)
+		checkInit(t, stub, []string{})
+
+		stub.SetTxTimestamp(lastTxTimeOf2017)
+		checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+
+		stub.SetTxTimestamp(firstTxTimeOf2018)
+		checkInvoke(t, stub, "slaSubmitContract", []string{strings.Replace(inputContractContentInJson, "SLA_CONT_2017_00001", "SLA_CONT_2018_00001", 1)})
+		checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+		return stub
+	}
+
+	peer1 := replay()
+	time.Sleep(10 * time.Millisecond)
+	peer2 := replay()
+
+	if string(stateHash(peer1)) != string(stateHash(peer2)) {
+		fmt.Println("State hash of two peers replaying the same transactions differ")
+		t.FailNow()
+	}
+
+	// 연도 변경은 KST 기준 트랜잭션 시각으로 판단
+	checkState(t, peer1, CURRENT_YEAR_KEY, "2018")
+	checkState(t, peer1, SLA_CONTRACT_ID_COUNT_KEY, "2")
+	checkQuery(t, peer1, "slaGetContractId", []string{}, "SLA_CONT_2018_00002")
+
+	contract, err := (&SimpleChaincode{}).slaGetContract(peer1, contractId)
+	if err != nil {
+		fmt.Println("Failed to get contract", err)
+		t.FailNow()
+	}
+	if contract.Approvals[1].ApprovalDate != "2018-01-01" {
+		fmt.Println("ApprovalDate", contract.Approvals[1].ApprovalDate, "was not the expected value 2018-01-01")
+		t.FailNow()
+	}
+}
+
+// 계약/평가ID의 연도는 조회와 등록 모두 트랜잭션 시각(KST)을 따르며, 카운트는 연도가 바뀌면 1부터 시작한다.
+func TestChaincodeSla_Invoke_YearRollover(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00001",
+  "Name": "신한은행도급계약_201712",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalState": "TEMP" }
+  ],
+  "ServiceItems": [
+    { "ServiceItem": "장애처리", "ScoreItem": "처리시간", "DivideScore": "100" }
+  ]
+}`
+	contractIds := []string{"SLA_CONT_2017_00001", "SLA_CONT_2017_00002"}
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+	stub.SetTxTimestamp(time.Date(2017, 12, 30, 12, 0, 0, 0, slaTimeZone))
+	checkInit(t, stub, []string{})
+
+	for _, contractId := range contractIds {
+		checkInvoke(t, stub, "slaSubmitContract", []string{strings.Replace(inputContractContentInJson, contractIds[0], contractId, 1)})
+		checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+		checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+		checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+	}
+	checkQuery(t, stub, "slaGetEvaluationId", []string{}, "SLA_EVAL_2017_00001")
+	checkInvokeWithReturnValue(t, stub, "slaCreateEvaluationTemplateFromContract", []string{contractIds[0]}, "SLA_EVAL_2017_00001")
+	checkQuery(t, stub, "slaGetContractId", []string{}, "SLA_CONT_2017_00003")
+	checkQuery(t, stub, "slaGetEvaluationId", []string{}, "SLA_EVAL_2017_00002")
+
+	// 2018-01-01 00:01 KST: 아직 등록이 없어도 조회되는 ID는 새로운 연도의 첫번째 ID
+	stub.SetTxTimestamp(time.Date(2018, 1, 1, 0, 1, 0, 0, slaTimeZone))
+	checkQuery(t, stub, "slaGetContractId", []string{}, "SLA_CONT_2018_00001")
+	checkQuery(t, stub, "slaGetEvaluationId", []string{}, "SLA_EVAL_2018_00001")
+
+	checkInvokeWithReturnValue(t, stub, "slaCreateEvaluationTemplateFromContract", []string{contractIds[1]}, "SLA_EVAL_2018_00001")
+	checkState(t, stub, SLA_EVALUATION_YEAR_KEY, "2018")
+	checkState(t, stub, SLA_EVALUATION_ID_COUNT_KEY, "2")
+	checkQuery(t, stub, "slaGetEvaluationId", []string{}, "SLA_EVAL_2018_00002")
+}
+
+// 트랜잭션 시각이 없으면 시각에 의존하는 invoke 는 실패한다.
+func TestChaincodeSla_Invoke_WithoutTxTimestamp(t *testing.T) {
+	scc := new(SimpleChaincode)
+	stub := shim.NewMockStub("sla_chaincode", shim.NewChaincodeAdapter(scc))
+
+	_, err := stub.MockInit("1", "init", []string{})
+	if err == nil {
+		fmt.Println("Init without transaction timestamp was expected to fail")
+		t.FailNow()
+	}
+}
+
+func checkContractEvent(t *testing.T, stub *shim.MockStub, name string, contractId string, nextApproverId string) {
+	if stub.ChaincodeEvent == nil || stub.ChaincodeEvent.EventName != name {
+		fmt.Printf("Event %v was not the expected event[%v]\n", stub.ChaincodeEvent, name)
+		t.FailNow()
+	}
+	var event SlaContractEvent
+	if err := json.Unmarshal(stub.ChaincodeEvent.Payload, &event); err != nil || event.RegId != contractId {
+		fmt.Println("Unexpected contract event", string(stub.ChaincodeEvent.Payload))
+		t.FailNow()
+	}
+	if (nextApproverId == "" && event.NextApprover != nil) ||
+		(nextApproverId != "" && (event.NextApprover == nil || event.NextApprover.ApprovalUserId != nextApproverId)) {
+		fmt.Printf("Event %v next approver was not the expected value[%v]\n", string(stub.ChaincodeEvent.Payload), nextApproverId)
+		t.FailNow()
+	}
+}
+
+// 계약 상태 전이 event
+// 결재 요청, 승인 후 다음 결재자에게 결재 요청, 계약 등록 완료 때 event 가 설정된다.
+func TestChaincodeSla_Invoke_ContractEvents(t *testing.T) {
+	inputContractContentInJson :=
+		`{
+  "RegId": "SLA_CONT_2017_00033",
+  "Name": "신한은행도급계약_201707",
+  "Client": "신한은행",
+  "Approvals": [
+    { "ApprovalUserId": "기안자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "내부관리자_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객_A", "ApprovalState": "TEMP" },
+    { "ApprovalUserId": "고객관리자_A", "ApprovalState": "TEMP" }
+  ]
+}`
+	contractId := "SLA_CONT_2017_00033"
+
+	scc := new(SimpleChaincode)
+	stub := newMockStub(scc)
+
+	checkInit(t, stub, []string{})
+	checkInvoke(t, stub, "slaCreateTempContract", []string{inputContractContentInJson})
+	if stub.ChaincodeEvent != nil {
+		fmt.Println("Unexpected event for slaCreateTempContract", stub.ChaincodeEvent)
+		t.FailNow()
+	}
+
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkContractEvent(t, stub, SLA_EVENT_CONTRACT_SUBMITTED, contractId, "내부관리자_A")
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkContractEvent(t, stub, SLA_EVENT_APPROVAL_REQUESTED, contractId, "고객_A")
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkContractEvent(t, stub, SLA_EVENT_APPROVAL_REQUESTED, contractId, "고객관리자_A")
+
+	// 반려는 event 없음
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaRejectContract", []string{contractId, "고객관리자_A", "반려"})
+	if stub.ChaincodeEvent != nil {
+		fmt.Println("Unexpected event for slaRejectContract", stub.ChaincodeEvent)
+		t.FailNow()
+	}
+
+	checkInvoke(t, stub, "slaSubmitContract", []string{inputContractContentInJson})
+	checkInvokeWithAttributes(t, stub, approverAttributes("내부관리자_A", "", ""), "slaApproveContract", []string{contractId, "내부관리자_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객_A", "", ""), "slaApproveContract", []string{contractId, "고객_A", "확인"})
+	checkInvokeWithAttributes(t, stub, approverAttributes("고객관리자_A", "", ""), "slaCloseContract", []string{contractId, "고객관리자_A", "확인"})
+	checkContractEvent(t, stub, SLA_EVENT_CONTRACT_CLOSED, contractId, "")
 }